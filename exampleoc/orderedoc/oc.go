@@ -0,0 +1,926 @@
+/*
+Package orderedoc is a generated package which contains definitions
+of structs which represent a YANG schema. The generated schema can be
+compressed by a series of transformations (compression was true
+in this case).
+
+NOTE WELL: This is an example code file that is distributed with ygot.
+It should not be used within your application, as it WILL change,
+without warning. Rather, you should generate structs directly from
+OpenConfig models using the ygot package.
+
+This package was generated by github.com/openconfig/ygot
+using the following YANG input files:
+  - ../../testdata/modules/openconfig-ordered-list.yang
+
+Imported modules were sourced from:
+  - ../../testdata/modules/...
+*/
+package orderedoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+// Binary is a type that is used for fields that have a YANG type of
+// binary. It is used such that binary fields can be distinguished from
+// leaf-lists of uint8s (which are mapped to []uint8, equivalent to
+// []byte in reflection).
+type Binary []byte
+
+// YANGEmpty is a type that is used for fields that have a YANG type of
+// empty. It is used such that empty fields can be distinguished from boolean fields
+// in the generated code.
+type YANGEmpty bool
+
+var (
+	SchemaTree map[string]*yang.Entry
+)
+
+func init() {
+	var err error
+	if SchemaTree, err = UnzipSchema(); err != nil {
+		panic("schema error: " + err.Error())
+	}
+}
+
+// Schema returns the details of the generated schema.
+func Schema() (*ytypes.Schema, error) {
+	uzp, err := UnzipSchema()
+	if err != nil {
+		return nil, fmt.Errorf("cannot unzip schema, %v", err)
+	}
+
+	return &ytypes.Schema{
+		Root:       &Device{},
+		SchemaTree: uzp,
+		Unmarshal:  Unmarshal,
+	}, nil
+}
+
+// UnzipSchema unzips the zipped schema and returns a map of yang.Entry nodes,
+// keyed by the name of the struct that the yang.Entry describes the schema for.
+func UnzipSchema() (map[string]*yang.Entry, error) {
+	var schemaTree map[string]*yang.Entry
+	var err error
+	if schemaTree, err = ygot.GzipToSchema(ySchema); err != nil {
+		return nil, fmt.Errorf("could not unzip the schema; %v", err)
+	}
+	return schemaTree, nil
+}
+
+// Unmarshal unmarshals data, which must be RFC7951 JSON format, into
+// destStruct, which must be non-nil and the correct GoStruct type. It returns
+// an error if the destStruct is not found in the schema or the data cannot be
+// unmarshaled. The supplied options (opts) are used to control the behaviour
+// of the unmarshal function - for example, determining whether errors are
+// thrown for unknown fields in the input JSON.
+func Unmarshal(data []byte, destStruct ygot.GoStruct, opts ...ytypes.UnmarshalOpt) error {
+	tn := reflect.TypeOf(destStruct).Elem().Name()
+	schema, ok := SchemaTree[tn]
+	if !ok {
+		return fmt.Errorf("could not find schema for type %s", tn)
+	}
+	var jsonTree interface{}
+	if err := json.Unmarshal([]byte(data), &jsonTree); err != nil {
+		return err
+	}
+	return ytypes.Unmarshal(schema, destStruct, jsonTree, opts...)
+}
+
+// Device represents the /device YANG schema element.
+type Device struct {
+	Model *Model `path:"model" module:"openconfig-ordered-list"`
+}
+
+// IsYANGGoStruct ensures that Device implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Device) IsYANGGoStruct() {}
+
+// GetOrCreateModel retrieves the value of the Model field
+// or returns the existing field if it already exists.
+func (t *Device) GetOrCreateModel() *Model {
+	if t.Model != nil {
+		return t.Model
+	}
+	t.Model = &Model{}
+	return t.Model
+}
+
+// GetModel returns the value of the Model struct pointer
+// from Device. If the receiver or the field Model is nil, nil
+// is returned such that the Get* methods can be safely chained.
+func (t *Device) GetModel() *Model {
+	if t != nil && t.Model != nil {
+		return t.Model
+	}
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Device) Validate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Device"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Device) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// Model represents the /openconfig-ordered-list/model YANG schema element.
+type Model struct {
+	OrderedList *Model_OrderedList_OrderedMap `path:"ordered-lists/ordered-list" module:"openconfig-ordered-list"`
+	Rule        *Model_Rule_OrderedMap        `path:"rules/rule" module:"openconfig-ordered-list"`
+	SystemList  map[string]*Model_SystemList  `path:"system-lists/system-list" module:"openconfig-ordered-list"`
+}
+
+// IsYANGGoStruct ensures that Model implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Model) IsYANGGoStruct() {}
+
+// Model_Rule_Key represents the key for list Rule of element /openconfig-ordered-list/model.
+type Model_Rule_Key struct {
+	Set string `path:"set"`
+	Seq uint32 `path:"seq"`
+}
+
+// Model_OrderedList_OrderedMap is an ordered map that represents the "ordered-by user"
+// list OrderedList of the Model struct. It stores the entries
+// of the list in the order that is specified by the user, such that they can
+// be both iterated in order and looked up by their key.
+type Model_OrderedList_OrderedMap struct {
+	keys     []string
+	valueMap map[string]*Model_OrderedList
+}
+
+// IsYANGOrderedList ensures that Model_OrderedList_OrderedMap implements the
+// ygot.GoOrderedMap interface.
+func (*Model_OrderedList_OrderedMap) IsYANGOrderedList() {}
+
+// init initialises the map of the entries of the receiver if it has not
+// already been created.
+func (o *Model_OrderedList_OrderedMap) init() {
+	if o.valueMap == nil {
+		o.valueMap = map[string]*Model_OrderedList{}
+	}
+}
+
+// Keys returns a copy of the keys of the list OrderedList, in order.
+func (o *Model_OrderedList_OrderedMap) Keys() []string {
+	if o == nil {
+		return nil
+	}
+	return append([]string{}, o.keys...)
+}
+
+// Values returns the entries of the list OrderedList, in order.
+func (o *Model_OrderedList_OrderedMap) Values() []*Model_OrderedList {
+	if o == nil {
+		return nil
+	}
+	var values []*Model_OrderedList
+	for _, key := range o.keys {
+		values = append(values, o.valueMap[key])
+	}
+	return values
+}
+
+// Len returns the number of entries in the list OrderedList.
+func (o *Model_OrderedList_OrderedMap) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.keys)
+}
+
+// Get returns the entry of the list OrderedList with the specified key. If
+// the receiver is nil, or there is no such entry, nil is returned.
+func (o *Model_OrderedList_OrderedMap) Get(key string) *Model_OrderedList {
+	if o == nil {
+		return nil
+	}
+	return o.valueMap[key]
+}
+
+// Delete deletes the entry of the list OrderedList with the specified key,
+// and reports whether such an entry existed.
+func (o *Model_OrderedList_OrderedMap) Delete(key string) bool {
+	if o == nil {
+		return false
+	}
+	if _, ok := o.valueMap[key]; !ok {
+		return false
+	}
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+	delete(o.valueMap, key)
+	return true
+}
+
+// Append appends the supplied Model_OrderedList struct as the last entry of
+// the list OrderedList. If the key value(s) specified in the supplied
+// Model_OrderedList already exist in the list, an error is returned.
+func (o *Model_OrderedList_OrderedMap) Append(v *Model_OrderedList) error {
+	if v == nil {
+		return fmt.Errorf("nil value for list OrderedList")
+	}
+
+	if v.Name == nil {
+		return fmt.Errorf("invalid nil key received for Name")
+	}
+
+	key := *v.Name
+
+	if _, ok := o.valueMap[key]; ok {
+		return fmt.Errorf("duplicate key for list OrderedList %v", key)
+	}
+
+	o.init()
+	o.keys = append(o.keys, key)
+	o.valueMap[key] = v
+	return nil
+}
+
+// AppendNew creates a new entry with the specified keys, and appends it as
+// the last entry of the list OrderedList. If an entry with the same keys
+// already exists in the list, an error is returned.
+func (o *Model_OrderedList_OrderedMap) AppendNew(Name string) (*Model_OrderedList, error) {
+	v := &Model_OrderedList{
+		Name: &Name,
+	}
+	if err := o.Append(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// MoveBefore moves the entry of the list OrderedList with the specified
+// key such that it immediately precedes the entry with the key before. An
+// error is returned if either entry does not exist.
+func (o *Model_OrderedList_OrderedMap) MoveBefore(key, before string) error {
+	return o.move(key, before, false)
+}
+
+// MoveAfter moves the entry of the list OrderedList with the specified
+// key such that it immediately follows the entry with the key after. An error
+// is returned if either entry does not exist.
+func (o *Model_OrderedList_OrderedMap) MoveAfter(key, after string) error {
+	return o.move(key, after, true)
+}
+
+// move moves the entry with the specified key to immediately before, or if
+// after is set, immediately after, the entry with the key ref.
+func (o *Model_OrderedList_OrderedMap) move(key, ref string, after bool) error {
+	for _, k := range []string{key, ref} {
+		if o.Get(k) == nil {
+			return fmt.Errorf("key %v not found in list OrderedList", k)
+		}
+	}
+	if key == ref {
+		return nil
+	}
+
+	keys := make([]string, 0, len(o.keys))
+	for _, k := range o.keys {
+		switch {
+		case k == key:
+			continue
+		case k == ref && after:
+			keys = append(keys, k, key)
+		case k == ref:
+			keys = append(keys, key, k)
+		default:
+			keys = append(keys, k)
+		}
+	}
+	o.keys = keys
+	return nil
+}
+
+// Model_Rule_OrderedMap is an ordered map that represents the "ordered-by user"
+// list Rule of the Model struct. It stores the entries
+// of the list in the order that is specified by the user, such that they can
+// be both iterated in order and looked up by their key.
+type Model_Rule_OrderedMap struct {
+	keys     []Model_Rule_Key
+	valueMap map[Model_Rule_Key]*Model_Rule
+}
+
+// IsYANGOrderedList ensures that Model_Rule_OrderedMap implements the
+// ygot.GoOrderedMap interface.
+func (*Model_Rule_OrderedMap) IsYANGOrderedList() {}
+
+// init initialises the map of the entries of the receiver if it has not
+// already been created.
+func (o *Model_Rule_OrderedMap) init() {
+	if o.valueMap == nil {
+		o.valueMap = map[Model_Rule_Key]*Model_Rule{}
+	}
+}
+
+// Keys returns a copy of the keys of the list Rule, in order.
+func (o *Model_Rule_OrderedMap) Keys() []Model_Rule_Key {
+	if o == nil {
+		return nil
+	}
+	return append([]Model_Rule_Key{}, o.keys...)
+}
+
+// Values returns the entries of the list Rule, in order.
+func (o *Model_Rule_OrderedMap) Values() []*Model_Rule {
+	if o == nil {
+		return nil
+	}
+	var values []*Model_Rule
+	for _, key := range o.keys {
+		values = append(values, o.valueMap[key])
+	}
+	return values
+}
+
+// Len returns the number of entries in the list Rule.
+func (o *Model_Rule_OrderedMap) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.keys)
+}
+
+// Get returns the entry of the list Rule with the specified key. If
+// the receiver is nil, or there is no such entry, nil is returned.
+func (o *Model_Rule_OrderedMap) Get(key Model_Rule_Key) *Model_Rule {
+	if o == nil {
+		return nil
+	}
+	return o.valueMap[key]
+}
+
+// Delete deletes the entry of the list Rule with the specified key,
+// and reports whether such an entry existed.
+func (o *Model_Rule_OrderedMap) Delete(key Model_Rule_Key) bool {
+	if o == nil {
+		return false
+	}
+	if _, ok := o.valueMap[key]; !ok {
+		return false
+	}
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+	delete(o.valueMap, key)
+	return true
+}
+
+// Append appends the supplied Model_Rule struct as the last entry of
+// the list Rule. If the key value(s) specified in the supplied
+// Model_Rule already exist in the list, an error is returned.
+func (o *Model_Rule_OrderedMap) Append(v *Model_Rule) error {
+	if v == nil {
+		return fmt.Errorf("nil value for list Rule")
+	}
+
+	if v.Set == nil {
+		return fmt.Errorf("invalid nil key for Set")
+	}
+
+	if v.Seq == nil {
+		return fmt.Errorf("invalid nil key for Seq")
+	}
+
+	key := Model_Rule_Key{
+		Set: *v.Set,
+		Seq: *v.Seq,
+	}
+
+	if _, ok := o.valueMap[key]; ok {
+		return fmt.Errorf("duplicate key for list Rule %v", key)
+	}
+
+	o.init()
+	o.keys = append(o.keys, key)
+	o.valueMap[key] = v
+	return nil
+}
+
+// AppendNew creates a new entry with the specified keys, and appends it as
+// the last entry of the list Rule. If an entry with the same keys
+// already exists in the list, an error is returned.
+func (o *Model_Rule_OrderedMap) AppendNew(Set string, Seq uint32) (*Model_Rule, error) {
+	v := &Model_Rule{
+		Set: &Set,
+		Seq: &Seq,
+	}
+	if err := o.Append(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// MoveBefore moves the entry of the list Rule with the specified
+// key such that it immediately precedes the entry with the key before. An
+// error is returned if either entry does not exist.
+func (o *Model_Rule_OrderedMap) MoveBefore(key, before Model_Rule_Key) error {
+	return o.move(key, before, false)
+}
+
+// MoveAfter moves the entry of the list Rule with the specified
+// key such that it immediately follows the entry with the key after. An error
+// is returned if either entry does not exist.
+func (o *Model_Rule_OrderedMap) MoveAfter(key, after Model_Rule_Key) error {
+	return o.move(key, after, true)
+}
+
+// move moves the entry with the specified key to immediately before, or if
+// after is set, immediately after, the entry with the key ref.
+func (o *Model_Rule_OrderedMap) move(key, ref Model_Rule_Key, after bool) error {
+	for _, k := range []Model_Rule_Key{key, ref} {
+		if o.Get(k) == nil {
+			return fmt.Errorf("key %v not found in list Rule", k)
+		}
+	}
+	if key == ref {
+		return nil
+	}
+
+	keys := make([]Model_Rule_Key, 0, len(o.keys))
+	for _, k := range o.keys {
+		switch {
+		case k == key:
+			continue
+		case k == ref && after:
+			keys = append(keys, k, key)
+		case k == ref:
+			keys = append(keys, key, k)
+		default:
+			keys = append(keys, k)
+		}
+	}
+	o.keys = keys
+	return nil
+}
+
+// NewOrderedList creates a new entry in the OrderedList list of the
+// Model struct, as the last entry of the list. The keys of the list
+// are populated from the input arguments.
+func (t *Model) NewOrderedList(Name string) (*Model_OrderedList, error) {
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.OrderedList == nil {
+		t.OrderedList = &Model_OrderedList_OrderedMap{}
+	}
+
+	return t.OrderedList.AppendNew(Name)
+}
+
+// GetOrCreateOrderedList retrieves the value with the specified keys from
+// the receiver Model. If the entry does not exist, then it is created
+// as the last entry of the list. It returns the existing or new list member.
+func (t *Model) GetOrCreateOrderedList(Name string) *Model_OrderedList {
+
+	if v := t.GetOrderedList(Name); v != nil {
+		return v
+	}
+	// Panic if we receive an error, since we should have retrieved an existing
+	// list member. This allows chaining of GetOrCreate methods.
+	v, err := t.NewOrderedList(Name)
+	if err != nil {
+		panic(fmt.Sprintf("GetOrCreateOrderedList got unexpected error: %v", err))
+	}
+	return v
+}
+
+// GetOrderedList retrieves the value with the specified key from
+// the OrderedList ordered map field of Model. If the receiver is
+// nil, or the specified key is not present in the list, nil is returned such
+// that Get* methods may be safely chained.
+func (t *Model) GetOrderedList(Name string) *Model_OrderedList {
+
+	if t == nil {
+		return nil
+	}
+
+	return t.OrderedList.Get(Name)
+}
+
+// DeleteOrderedList deletes the value with the specified keys from
+// the receiver Model. If there is no such element, the function
+// is a no-op.
+func (t *Model) DeleteOrderedList(Name string) {
+	t.OrderedList.Delete(Name)
+}
+
+// AppendOrderedList appends the supplied Model_OrderedList struct as the
+// last entry of the list OrderedList of Model. If the key
+// value(s) specified in the supplied Model_OrderedList already exist in the
+// list, an error is returned.
+func (t *Model) AppendOrderedList(v *Model_OrderedList) error {
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.OrderedList == nil {
+		t.OrderedList = &Model_OrderedList_OrderedMap{}
+	}
+
+	return t.OrderedList.Append(v)
+}
+
+// NewRule creates a new entry in the Rule list of the
+// Model struct, as the last entry of the list. The keys of the list
+// are populated from the input arguments.
+func (t *Model) NewRule(Set string, Seq uint32) (*Model_Rule, error) {
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.Rule == nil {
+		t.Rule = &Model_Rule_OrderedMap{}
+	}
+
+	return t.Rule.AppendNew(Set, Seq)
+}
+
+// GetOrCreateRule retrieves the value with the specified keys from
+// the receiver Model. If the entry does not exist, then it is created
+// as the last entry of the list. It returns the existing or new list member.
+func (t *Model) GetOrCreateRule(Set string, Seq uint32) *Model_Rule {
+
+	if v := t.GetRule(Set, Seq); v != nil {
+		return v
+	}
+	// Panic if we receive an error, since we should have retrieved an existing
+	// list member. This allows chaining of GetOrCreate methods.
+	v, err := t.NewRule(Set, Seq)
+	if err != nil {
+		panic(fmt.Sprintf("GetOrCreateRule got unexpected error: %v", err))
+	}
+	return v
+}
+
+// GetRule retrieves the value with the specified key from
+// the Rule ordered map field of Model. If the receiver is
+// nil, or the specified key is not present in the list, nil is returned such
+// that Get* methods may be safely chained.
+func (t *Model) GetRule(Set string, Seq uint32) *Model_Rule {
+
+	if t == nil {
+		return nil
+	}
+
+	return t.Rule.Get(Model_Rule_Key{
+		Set: Set,
+		Seq: Seq,
+	})
+}
+
+// DeleteRule deletes the value with the specified keys from
+// the receiver Model. If there is no such element, the function
+// is a no-op.
+func (t *Model) DeleteRule(Set string, Seq uint32) {
+	t.Rule.Delete(Model_Rule_Key{
+		Set: Set,
+		Seq: Seq,
+	})
+}
+
+// AppendRule appends the supplied Model_Rule struct as the
+// last entry of the list Rule of Model. If the key
+// value(s) specified in the supplied Model_Rule already exist in the
+// list, an error is returned.
+func (t *Model) AppendRule(v *Model_Rule) error {
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.Rule == nil {
+		t.Rule = &Model_Rule_OrderedMap{}
+	}
+
+	return t.Rule.Append(v)
+}
+
+// NewSystemList creates a new entry in the SystemList list of the
+// Model struct. The keys of the list are populated from the input
+// arguments.
+func (t *Model) NewSystemList(Name string) (*Model_SystemList, error) {
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.SystemList == nil {
+		t.SystemList = make(map[string]*Model_SystemList)
+	}
+
+	key := Name
+
+	// Ensure that this key has not already been used in the
+	// list. Keyed YANG lists do not allow duplicate keys to
+	// be created.
+	if _, ok := t.SystemList[key]; ok {
+		return nil, fmt.Errorf("duplicate key %v for list SystemList", key)
+	}
+
+	t.SystemList[key] = &Model_SystemList{
+		Name: &Name,
+	}
+
+	return t.SystemList[key], nil
+}
+
+// GetOrCreateSystemList retrieves the value with the specified keys from
+// the receiver Model. If the entry does not exist, then it is created.
+// It returns the existing or new list member.
+func (t *Model) GetOrCreateSystemList(Name string) *Model_SystemList {
+
+	key := Name
+
+	if v, ok := t.SystemList[key]; ok {
+		return v
+	}
+	// Panic if we receive an error, since we should have retrieved an existing
+	// list member. This allows chaining of GetOrCreate methods.
+	v, err := t.NewSystemList(Name)
+	if err != nil {
+		panic(fmt.Sprintf("GetOrCreateSystemList got unexpected error: %v", err))
+	}
+	return v
+}
+
+// GetSystemList retrieves the value with the specified key from
+// the SystemList map field of Model. If the receiver is nil, or
+// the specified key is not present in the list, nil is returned such that Get*
+// methods may be safely chained.
+func (t *Model) GetSystemList(Name string) *Model_SystemList {
+
+	if t == nil {
+		return nil
+	}
+
+	key := Name
+
+	if lm, ok := t.SystemList[key]; ok {
+		return lm
+	}
+	return nil
+}
+
+// DeleteSystemList deletes the value with the specified keys from
+// the receiver Model. If there is no such element, the function
+// is a no-op.
+func (t *Model) DeleteSystemList(Name string) {
+	key := Name
+
+	delete(t.SystemList, key)
+}
+
+// AppendSystemList appends the supplied Model_SystemList struct to the
+// list SystemList of Model. If the key value(s) specified in
+// the supplied Model_SystemList already exist in the list, an error is
+// returned.
+func (t *Model) AppendSystemList(v *Model_SystemList) error {
+	if v.Name == nil {
+		return fmt.Errorf("invalid nil key received for Name")
+	}
+
+	key := *v.Name
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.SystemList == nil {
+		t.SystemList = make(map[string]*Model_SystemList)
+	}
+
+	if _, ok := t.SystemList[key]; ok {
+		return fmt.Errorf("duplicate key for list SystemList %v", key)
+	}
+
+	t.SystemList[key] = v
+	return nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Model) Validate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Model"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Model) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// Model_OrderedList represents the /openconfig-ordered-list/model/ordered-lists/ordered-list YANG schema element.
+type Model_OrderedList struct {
+	Name  *string `path:"config/name|name" module:"openconfig-ordered-list"`
+	Value *uint32 `path:"config/value" module:"openconfig-ordered-list"`
+}
+
+// IsYANGGoStruct ensures that Model_OrderedList implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Model_OrderedList) IsYANGGoStruct() {}
+
+// ΛListKeyMap returns the keys of the Model_OrderedList struct, which is a YANG list entry.
+func (t *Model_OrderedList) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.Name == nil {
+		return nil, fmt.Errorf("nil value for key Name")
+	}
+
+	return map[string]interface{}{
+		"name": *t.Name,
+	}, nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Model_OrderedList) Validate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Model_OrderedList"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Model_OrderedList) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// Model_Rule represents the /openconfig-ordered-list/model/rules/rule YANG schema element.
+type Model_Rule struct {
+	Seq *uint32 `path:"config/seq|seq" module:"openconfig-ordered-list"`
+	Set *string `path:"config/set|set" module:"openconfig-ordered-list"`
+}
+
+// IsYANGGoStruct ensures that Model_Rule implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Model_Rule) IsYANGGoStruct() {}
+
+// ΛListKeyMap returns the keys of the Model_Rule struct, which is a YANG list entry.
+func (t *Model_Rule) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.Seq == nil {
+		return nil, fmt.Errorf("nil value for key Seq")
+	}
+
+	if t.Set == nil {
+		return nil, fmt.Errorf("nil value for key Set")
+	}
+
+	return map[string]interface{}{
+		"seq": *t.Seq,
+		"set": *t.Set,
+	}, nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Model_Rule) Validate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Model_Rule"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Model_Rule) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// Model_SystemList represents the /openconfig-ordered-list/model/system-lists/system-list YANG schema element.
+type Model_SystemList struct {
+	Name  *string `path:"config/name|name" module:"openconfig-ordered-list"`
+	Value *uint32 `path:"config/value" module:"openconfig-ordered-list"`
+}
+
+// IsYANGGoStruct ensures that Model_SystemList implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Model_SystemList) IsYANGGoStruct() {}
+
+// ΛListKeyMap returns the keys of the Model_SystemList struct, which is a YANG list entry.
+func (t *Model_SystemList) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.Name == nil {
+		return nil, fmt.Errorf("nil value for key Name")
+	}
+
+	return map[string]interface{}{
+		"name": *t.Name,
+	}, nil
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Model_SystemList) Validate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Model_SystemList"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Model_SystemList) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+var (
+	// ySchema is a byte slice contain a gzip compressed representation of the
+	// YANG schema from which the Go code was generated. When uncompressed the
+	// contents of the byte slice is a JSON document containing an object, keyed
+	// on the name of the generated struct, and containing the JSON marshalled
+	// contents of a goyang yang.Entry struct, which defines the schema for the
+	// fields within the struct.
+	ySchema = []byte{
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5c, 0x5b, 0x6f, 0xdb, 0x36,
+		0x14, 0x7e, 0xd7, 0xaf, 0x38, 0x3b, 0x2f, 0xdd, 0x00, 0x27, 0xb6, 0x53, 0x27, 0x6e, 0x0c, 0xf4,
+		0x21, 0x6b, 0x56, 0x0c, 0xe8, 0xb2, 0x15, 0xc9, 0xb0, 0x97, 0x22, 0x18, 0x34, 0x9b, 0x76, 0x84,
+		0xd9, 0x94, 0x47, 0x52, 0x69, 0x8c, 0xc1, 0xff, 0xbd, 0xa0, 0x2e, 0x86, 0xe4, 0xd8, 0x31, 0x2f,
+		0x52, 0xaa, 0x36, 0x47, 0x0f, 0x06, 0x2c, 0xf1, 0x7a, 0xbe, 0xef, 0x90, 0x3c, 0xfc, 0x44, 0xfd,
+		0x1f, 0x00, 0x00, 0xe0, 0xef, 0xe1, 0x82, 0xe1, 0x08, 0x70, 0xc2, 0xee, 0xa3, 0x31, 0xc3, 0x4e,
+		0x76, 0xf7, 0x43, 0xc4, 0x27, 0x38, 0x82, 0x7e, 0xfe, 0xf7, 0x5d, 0xcc, 0xa7, 0xd1, 0x0c, 0x47,
+		0xd0, 0xcb, 0x6f, 0x5c, 0x46, 0x02, 0x47, 0x90, 0x15, 0x01, 0x00, 0x80, 0x8b, 0x78, 0xc2, 0xe6,
+		0x95, 0x5b, 0x95, 0xd2, 0xb3, 0xc7, 0x9d, 0xea, 0xc3, 0x6a, 0x25, 0x9b, 0xdb, 0xdb, 0x95, 0x6d,
+		0x1e, 0x7c, 0x14, 0x6c, 0x1a, 0x3d, 0x3c, 0xaa, 0xa4, 0x52, 0x51, 0xbc, 0x5d, 0x0b, 0x00, 0x00,
+		0xde, 0xc4, 0x89, 0x18, 0xb3, 0x9d, 0x39, 0xb3, 0x96, 0xb0, 0xd5, 0xe7, 0x58, 0xe8, 0xc6, 0xe0,
+		0x32, 0xab, 0xa4, 0xb3, 0x3b, 0xe1, 0xaf, 0xa1, 0xbc, 0x10, 0xb3, 0x64, 0xc1, 0xb8, 0xc2, 0x11,
+		0x28, 0x91, 0xb0, 0x3d, 0x09, 0x4b, 0xa9, 0x74, 0x9b, 0x1e, 0x25, 0x5a, 0x57, 0xee, 0xac, 0xb7,
+		0x7a, 0xba, 0x6d, 0xde, 0xcd, 0x83, 0x58, 0x4c, 0x98, 0x60, 0x93, 0xa3, 0x79, 0x24, 0x95, 0xdc,
+		0xdf, 0x9f, 0x8d, 0x35, 0x2a, 0xc9, 0xf7, 0x34, 0x75, 0x37, 0x0c, 0x07, 0xe1, 0x30, 0x81, 0xc5,
+		0x0c, 0x1e, 0x53, 0x98, 0xac, 0xe1, 0xb2, 0x86, 0xcd, 0x18, 0xbe, 0xdd, 0x30, 0xee, 0x81, 0xf3,
+		0x20, 0xac, 0x3b, 0xe1, 0x3d, 0x6c, 0x86, 0x5d, 0x28, 0x1f, 0xb2, 0xc4, 0xd3, 0x60, 0x1b, 0x83,
+		0x6e, 0x03, 0xbe, 0x1d, 0x09, 0x6c, 0xc9, 0xe0, 0x4c, 0x0a, 0x67, 0x72, 0x58, 0x93, 0xe4, 0x69,
+		0xb2, 0x1c, 0x20, 0x8d, 0x31, 0x79, 0x8a, 0x0b, 0xc7, 0x05, 0x76, 0x86, 0x86, 0x2b, 0x60, 0xc9,
+		0xf3, 0x19, 0x76, 0xde, 0x8c, 0x48, 0xd6, 0x84, 0x72, 0x21, 0x96, 0x1b, 0xc1, 0x5c, 0x89, 0xe6,
+		0x4d, 0x38, 0x6f, 0xe2, 0x39, 0x13, 0xd0, 0x8c, 0x88, 0x86, 0x84, 0xb4, 0x26, 0x66, 0x71, 0x21,
+		0xcf, 0x60, 0xb2, 0x34, 0x77, 0x01, 0x6e, 0x9a, 0xdb, 0xd2, 0x50, 0x39, 0x59, 0x7b, 0x96, 0xd9,
+		0x6c, 0x49, 0xeb, 0x43, 0x5e, 0x3f, 0x12, 0xfb, 0x92, 0xb9, 0x36, 0x52, 0xd7, 0x46, 0x6e, 0x6f,
+		0x92, 0xdb, 0x91, 0xdd, 0x92, 0xf4, 0xc5, 0x85, 0x7f, 0xae, 0x96, 0xcc, 0x0f, 0x67, 0xa9, 0x44,
+		0xc4, 0x67, 0x2e, 0x58, 0x17, 0x43, 0xf0, 0x9b, 0xa0, 0x19, 0x7b, 0x58, 0xd8, 0x02, 0xef, 0xc3,
+		0x79, 0xe2, 0xe1, 0xd4, 0x59, 0x76, 0xf2, 0x6a, 0xf2, 0xea, 0xef, 0xc4, 0xab, 0x93, 0x88, 0xab,
+		0xd7, 0x27, 0x1e, 0x5e, 0x3d, 0x74, 0xc8, 0x7a, 0x1d, 0xf2, 0x99, 0xae, 0xfd, 0x93, 0x13, 0x26,
+		0x6e, 0xdc, 0x02, 0x00, 0xc0, 0xab, 0x88, 0xe3, 0xc8, 0xa3, 0x00, 0x0f, 0x77, 0xde, 0xbe, 0xf0,
+		0xaf, 0x7c, 0x24, 0xf2, 0x2d, 0xe7, 0xbd, 0x08, 0xc7, 0x2a, 0x8a, 0xf9, 0x65, 0x34, 0x8b, 0xd2,
+		0x58, 0xbb, 0xe7, 0x5c, 0xde, 0xba, 0xe3, 0x61, 0xda, 0xf0, 0xa1, 0x75, 0xa6, 0x1d, 0x9c, 0x9c,
+		0x0f, 0xce, 0xcf, 0x86, 0x27, 0xe7, 0xa7, 0x2d, 0xb2, 0x71, 0xf0, 0x3c, 0xb9, 0x6e, 0x1b, 0x1d,
+		0x78, 0x2e, 0x38, 0x8f, 0x55, 0xa8, 0x4d, 0xe2, 0x36, 0xfc, 0x7c, 0xbe, 0x63, 0x3a, 0x27, 0x1e,
+		0x1f, 0x77, 0xf5, 0x3a, 0x19, 0x7e, 0x78, 0x0b, 0xaf, 0x78, 0xcc, 0xd9, 0x2b, 0x6c, 0x6a, 0x8d,
+		0x50, 0x6b, 0x28, 0xe1, 0xd8, 0x7d, 0x94, 0xe3, 0x3b, 0xb6, 0x08, 0x97, 0xa1, 0xba, 0xd3, 0x9d,
+		0xef, 0xc6, 0x4b, 0xc6, 0xb3, 0x68, 0xf6, 0xa8, 0xbc, 0x37, 0xd2, 0x4d, 0x77, 0x21, 0xbb, 0xe5,
+		0x5b, 0xb2, 0xf2, 0xaf, 0x9b, 0x65, 0xc2, 0xa0, 0x9e, 0x9e, 0x1b, 0xf4, 0xda, 0x2e, 0x16, 0x72,
+		0x89, 0x81, 0x2c, 0x7d, 0x9f, 0x02, 0xf5, 0x06, 0x56, 0x3d, 0x2d, 0x09, 0xd4, 0xad, 0x57, 0x35,
+		0x1b, 0x9c, 0xe6, 0x2c, 0x9c, 0x0a, 0x36, 0xb5, 0x01, 0xab, 0x08, 0x4e, 0x2c, 0xd6, 0x31, 0xf8,
+		0x31, 0x77, 0xe0, 0xe3, 0xe3, 0xdc, 0x13, 0xd3, 0x41, 0xac, 0x36, 0x77, 0xf4, 0xda, 0x7e, 0xfb,
+		0xc0, 0x56, 0x86, 0x9e, 0x87, 0xbf, 0x45, 0x52, 0x5d, 0x28, 0x65, 0xb8, 0x5d, 0x77, 0x15, 0xf1,
+		0x5f, 0xe6, 0x4c, 0xb3, 0x43, 0x9a, 0x79, 0x9d, 0x5e, 0x12, 0x94, 0x72, 0xf4, 0xdf, 0x0c, 0x06,
+		0x67, 0xc3, 0xc1, 0xa0, 0x37, 0x7c, 0x3d, 0xec, 0x9d, 0x9f, 0x9e, 0xf6, 0xcf, 0xfa, 0x06, 0xd3,
+		0x32, 0xfe, 0x91, 0x8d, 0x7b, 0x3f, 0xaf, 0xec, 0x47, 0x9f, 0x44, 0x32, 0x61, 0x3a, 0xfa, 0x38,
+		0xf8, 0x6c, 0xd9, 0x5f, 0x8b, 0xd1, 0xf9, 0x9f, 0x95, 0x0d, 0xf7, 0x7c, 0xfc, 0xb5, 0xe2, 0xab,
+		0x69, 0x4f, 0x5b, 0x41, 0x3f, 0xcb, 0x99, 0xb1, 0xae, 0x19, 0xd1, 0xc0, 0xea, 0x7a, 0x03, 0x23,
+		0x19, 0xab, 0x7c, 0x22, 0xc3, 0x2b, 0x5d, 0xe2, 0xdf, 0x39, 0xbb, 0xb4, 0x27, 0x60, 0xe0, 0x66,
+		0x15, 0x3b, 0x69, 0xc5, 0xd0, 0x3e, 0xb8, 0x48, 0xa4, 0x3a, 0x18, 0x21, 0x19, 0xd8, 0xf7, 0xa1,
+		0x30, 0x2d, 0x8f, 0xd5, 0x8f, 0x65, 0x8b, 0x7d, 0xea, 0xdf, 0x16, 0x83, 0x57, 0xba, 0xa9, 0x01,
+		0x6f, 0xa1, 0xf7, 0x93, 0x89, 0x19, 0x99, 0x10, 0xb1, 0x38, 0x5a, 0x30, 0x29, 0xc3, 0x34, 0x88,
+		0xc3, 0x69, 0x24, 0xa4, 0x82, 0x72, 0xd9, 0xc0, 0xb8, 0x12, 0x2b, 0xd0, 0x7d, 0x00, 0x1e, 0x2b,
+		0xb8, 0x0b, 0xef, 0x19, 0x64, 0xb5, 0xf4, 0x5c, 0xcd, 0x7c, 0xdb, 0x09, 0x1a, 0x20, 0x11, 0x06,
+		0x66, 0xad, 0xd8, 0x01, 0x27, 0x8a, 0x64, 0xce, 0x0c, 0xb4, 0xcd, 0x2c, 0x19, 0x69, 0x9a, 0x6d,
+		0xd7, 0x34, 0x35, 0x4e, 0xe6, 0x5a, 0x66, 0x9a, 0x9a, 0x34, 0x4c, 0xd2, 0x30, 0x49, 0xc3, 0xa4,
+		0xd0, 0xc8, 0x9d, 0x88, 0x86, 0x84, 0xb4, 0x26, 0x66, 0x71, 0xa1, 0x64, 0xff, 0xb9, 0xab, 0x1d,
+		0x3a, 0x33, 0x69, 0x1d, 0xf5, 0x52, 0xb9, 0x36, 0x4a, 0xd7, 0x46, 0x6d, 0x6f, 0x8a, 0xdb, 0x51,
+		0xdd, 0x92, 0xf2, 0xee, 0xbb, 0x02, 0x00, 0xa4, 0x75, 0x90, 0xd6, 0x51, 0x13, 0xfd, 0x00, 0x48,
+		0xeb, 0x80, 0x66, 0x07, 0x84, 0x67, 0xd3, 0x3a, 0x1a, 0x79, 0xb1, 0x40, 0x32, 0xe5, 0x33, 0xd1,
+		0x2a, 0x9a, 0x68, 0x01, 0x68, 0xa2, 0x6d, 0xc2, 0x43, 0xe8, 0x55, 0x21, 0xdb, 0x94, 0xad, 0x92,
+		0x01, 0xd3, 0xfd, 0xa3, 0xf4, 0xf7, 0x2b, 0xc8, 0x7e, 0x36, 0xe1, 0x83, 0x43, 0xd8, 0x40, 0xa2,
+		0x1f, 0x45, 0xb6, 0xae, 0xa3, 0xce, 0xd7, 0x17, 0xfd, 0x34, 0xcf, 0x9f, 0xd5, 0x17, 0x95, 0x8b,
+		0x2f, 0x2a, 0xf2, 0x45, 0xf2, 0xc5, 0x17, 0xe0, 0x8b, 0xaa, 0x55, 0xfa, 0xbb, 0x64, 0x0a, 0x0e,
+		0xcf, 0x83, 0x24, 0xc1, 0xfb, 0x79, 0x2d, 0x49, 0xf0, 0xfe, 0xab, 0x52, 0xd7, 0xd5, 0xa8, 0xab,
+		0xe4, 0x7e, 0xad, 0xf3, 0xb6, 0x4a, 0x6b, 0xb7, 0x37, 0x80, 0x8f, 0x6c, 0x2c, 0x57, 0x52, 0xb1,
+		0x85, 0xe9, 0xc9, 0xd8, 0x4a, 0x6a, 0x12, 0x91, 0xdb, 0x2e, 0x22, 0x97, 0xe0, 0x32, 0xd7, 0x92,
+		0xcb, 0x99, 0x48, 0x52, 0x26, 0x49, 0x99, 0x24, 0x65, 0x5a, 0xec, 0xbb, 0x13, 0xd1, 0x90, 0x90,
+		0xd6, 0xc4, 0x2c, 0x2e, 0x3a, 0x16, 0x5b, 0x3f, 0x89, 0x7d, 0xc9, 0x5c, 0x1b, 0xa9, 0x6b, 0x23,
+		0xb7, 0x37, 0xc9, 0xed, 0xc8, 0x6e, 0x49, 0x7a, 0xf7, 0x48, 0xf7, 0x5b, 0xda, 0xeb, 0xa6, 0x63,
+		0xb1, 0xe4, 0xd5, 0xe4, 0xd5, 0x00, 0xf4, 0xaa, 0xc8, 0xe1, 0x7d, 0x25, 0x7a, 0x55, 0xa4, 0x36,
+		0xfa, 0x01, 0xd0, 0xab, 0x22, 0xd0, 0xec, 0x80, 0xf0, 0x6c, 0xaf, 0x8a, 0xd0, 0xb1, 0x58, 0x68,
+		0x9b, 0x1e, 0x5e, 0xde, 0x11, 0x2b, 0xff, 0xa1, 0x43, 0xb1, 0x14, 0xa6, 0x37, 0xb1, 0xe6, 0x21,
+		0x4d, 0x0e, 0xcc, 0xa8, 0x44, 0x87, 0x62, 0xbf, 0x05, 0x45, 0x2e, 0x1b, 0x31, 0x5f, 0x86, 0x26,
+		0x97, 0xf7, 0xf5, 0xfb, 0x57, 0xe5, 0xf6, 0xcd, 0x89, 0xae, 0x1a, 0xdd, 0x4d, 0x5a, 0x44, 0xfb,
+		0x4e, 0xc5, 0x3a, 0x1b, 0xc5, 0x58, 0xb0, 0x7b, 0xf2, 0x73, 0xb7, 0x07, 0x9a, 0x69, 0xd5, 0x3c,
+		0xec, 0x04, 0x26, 0x50, 0x60, 0xb0, 0xbb, 0x79, 0xeb, 0xa0, 0xd4, 0xc0, 0x7d, 0x0d, 0xc3, 0x48,
+		0xbe, 0x8b, 0x17, 0x4b, 0xc1, 0xa4, 0x64, 0x93, 0x9b, 0xb4, 0x71, 0x8f, 0x1c, 0x0a, 0x23, 0xf9,
+		0x3e, 0xfc, 0x97, 0x5d, 0xc7, 0xf1, 0x63, 0x67, 0xd3, 0x9f, 0x43, 0x4e, 0xe6, 0xec, 0x48, 0xb7,
+		0x48, 0x2e, 0xc3, 0xf1, 0x8e, 0xe3, 0xae, 0xb8, 0xa7, 0x93, 0x69, 0x10, 0x2d, 0xf8, 0xa8, 0x3c,
+		0x61, 0x96, 0xcc, 0xb9, 0x6d, 0x2a, 0xec, 0x04, 0x7b, 0xac, 0x70, 0x99, 0x7d, 0xef, 0x39, 0xeb,
+		0x6e, 0xb0, 0xfe, 0x02, 0x00, 0x00, 0xff, 0xff, 0x03, 0x00, 0x46, 0x45, 0x5c, 0x67, 0x0e, 0x5a,
+		0x00, 0x00,
+	}
+)
+
+// ΛEnumTypes is a map, keyed by a YANG schema path, of the enumerated types that
+// correspond with the leaf. The type is represented as a reflect.Type. The naming
+// of the map ensures that there are no clashes with valid YANG identifiers.
+var ΛEnumTypes = map[string][]reflect.Type{}
@@ -0,0 +1,21 @@
+#!/bin/bash
+
+# Hack to ensure that if we are running on OS X with a homebrew installed
+# GNU sed then we can still run sed.
+runsed() {
+  if hash gsed 2>/dev/null; then
+    gsed "$@"
+  else
+    sed "$@"
+  fi
+}
+
+go run ../../generator/generator.go -path=../../testdata/modules -output_file=oc.go \
+  -package_name=orderedoc -generate_fakeroot -fakeroot_name=device -compress_paths=true \
+  -generate_ordered_maps \
+  -generate_append \
+  -generate_getters \
+  -generate_delete \
+  ../../testdata/modules/openconfig-ordered-list.yang
+runsed -i 's/This package was generated by.*/NOTE WELL: This is an example code file that is distributed with ygot.\nIt should not be used within your application, as it WILL change,\nwithout warning. Rather, you should generate structs directly from\nOpenConfig models using the ygot package.\n\nThis package was generated by github.com\/openconfig\/ygot/g' oc.go
+gofmt -w -s oc.go
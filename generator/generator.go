@@ -48,6 +48,9 @@ const (
 	// pathStructsFileFmt is the format string filename (missing index) to
 	// be used for the path structs when path struct code is output to a directory.
 	pathStructsFileFmt = "path_structs-%d.go"
+	// deviationReportFn is the filename to be used for the report of the
+	// deviated schema nodes of a deviation profile.
+	deviationReportFn = "deviations.txt"
 )
 
 var (
@@ -58,11 +61,13 @@ var (
 	ocPathStructsOutputFile = flag.String("path_structs_output_file", "", "The file that the generated Go code for YANG path construction (path structs) will be generated. Specify \"-\" for stdout.")
 	pathStructsFileN        = flag.Int("path_structs_split_files_count", 0, "The number of files to split the generated path structs into when output_file is specified for generating path structs")
 	outputDir               = flag.String("output_dir", "", "The directory that the generated Go code should be written to. This is common between schema structs and path structs.")
+	deviationProfiles       = flag.String("deviation_profiles", "", "Semicolon separated set of named deviation profiles, of the form name=file1.yang,file2.yang. For each profile, the deviation modules of the profile are applied on top of the input modules, and the generated code, along with a report of the deviated schema nodes, is written to a subdirectory of output_dir named after the profile, in a package of the same name.")
 	compressPaths           = flag.Bool("compress_paths", false, "If set to true, the schema's paths are compressed, according to OpenConfig YANG module conventions. Path structs generation currently only supports compressed paths.")
 
 	// Common flags used for GoStruct and PathStruct generation.
 	yangPaths                            = flag.String("path", "", "Comma separated list of paths to be recursively searched for included modules or submodules within the defined YANG modules.")
 	excludeModules                       = flag.String("exclude_modules", "", "Comma separated set of module names that should be excluded from code generation this can be used to ensure overlapping namespaces can be ignored.")
+	enabledFeatures                      = flag.String("enabled_features", "", "Comma separated set of YANG features, of the form module:feature, that are supported by the target of the generated code. If set, schema nodes whose if-feature statements are false for the set of features are omitted from the generated code. A value of module: enables no features of the module.")
 	packageName                          = flag.String("package_name", "ocstructs", "The name of the Go package that should be generated.")
 	ignoreCircDeps                       = flag.Bool("ignore_circdeps", false, "If set to true, circular dependencies between submodules are ignored.")
 	fakeRootName                         = flag.String("fakeroot_name", "", "The name of the fake root entity.")
@@ -89,6 +94,11 @@ var (
 	generateLeafGetters  = flag.Bool("generate_leaf_getters", false, "If set to true, getters for YANG leaves are generated within the Go code. Caution should be exercised when using leaf getters, since values that are explicitly set to the Go default/zero value are not distinguishable from those that are unset when retrieved via the GetXXX method.")
 	generateSimpleUnions = flag.Bool("generate_simple_unions", false, "If set to true, then generated typedefs will be used to represent union subtypes within Go code instead of wrapper struct types.")
 	includeModelData     = flag.Bool("include_model_data", false, "If set to true, a slice of gNMI ModelData messages are included in the generated Go code containing the details of the input schemas from which the code was generated.")
+	generateOrderedMaps  = flag.Bool("generate_ordered_maps", false, "If set to true, keyed YANG lists that are ordered-by user are represented by generated ordered map types, which preserve the order of their entries, rather than Go maps.")
+	generateRPCTypes     = flag.Bool("generate_rpc_types", false, "If set to true, GoStructs are generated for the input and output of each YANG rpc and action.")
+	generateNotifTypes   = flag.Bool("generate_notification_types", false, "If set to true, GoStructs are generated for each YANG notification.")
+	generateLeafMetadata = flag.Bool("generate_leaf_metadata", false, "If set to true, a metadata annotation field is added to the fake root in which a ygot.LeafMetadataStore recording per-leaf timestamps, origins and source notifications can be stored.")
+	generateDefaults     = flag.Bool("generate_defaults_methods", false, "If set to true, methods that set and remove the default values of leaves, which are used by ygot.PopulateDefaults and ygot.PruneDefaults, are generated for each GoStruct. The JSON schema must also be generated.")
 
 	// Flags used for PathStruct generation only.
 	schemaStructPath        = flag.String("schema_struct_path", "", "The Go import path for the schema structs package. This should be specified if and only if schema structs are not being generated at the same time as path structs.")
@@ -126,6 +136,10 @@ func writeGoCodeSingleFile(w io.Writer, goCode *ygen.GeneratedGoCode) error {
 		fmt.Fprintln(w, goCode.EnumTypeMap)
 	}
 
+	if len(goCode.NotificationTypes) > 0 {
+		fmt.Fprintln(w, goCode.NotificationTypes)
+	}
+
 	return nil
 }
 
@@ -198,6 +212,7 @@ func splitCodeByFileN(goCode *ygen.GeneratedGoCode, fileN int) (map[string]strin
 		code.WriteString("\n")
 	}
 	code.WriteString(goCode.EnumTypeMap)
+	code.WriteString(goCode.NotificationTypes)
 
 	out[enumMapFn] = code.String()
 	out[interfaceFn] = interfaceCode.String()
@@ -293,11 +308,58 @@ func main() {
 		}
 	}
 
+	// Determine the set of YANG features that the user has requested to be
+	// enabled.
+	features, err := genutil.ParseEnabledFeatures(*enabledFeatures)
+	if err != nil {
+		log.Exitf("ERROR Generating Code: %v\n", err)
+	}
+
+	// Determine the set of deviation profiles for which code should be
+	// generated, in place of the input modules alone.
+	profiles, err := genutil.ParseDeviationProfiles(*deviationProfiles)
+	if err != nil {
+		log.Exitf("ERROR Generating Code: %v\n", err)
+	}
+	if profiles == nil {
+		generate(*packageName, *outputDir, generateModules, includePaths, modsExcluded, features)
+		return
+	}
+
+	if *outputDir == "" || *ocStructsOutputFile != "" || *ocPathStructsOutputFile != "" {
+		log.Exitf("Error: deviation profiles require an output directory, and cannot be used with output_file or path_structs_output_file.")
+	}
+	if !*generateGoStructs {
+		log.Exitf("Error: deviation profiles require schema structs to be generated, since the schema of each profile differs.")
+	}
+	for _, p := range profiles {
+		dir := filepath.Join(*outputDir, p.Name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			log.Exitf("Error creating directory for deviation profile %s: %v", p.Name, err)
+		}
+		yangFiles := append(append([]string{}, generateModules...), p.Files...)
+		deviations := generate(p.Name, dir, yangFiles, includePaths, modsExcluded, features)
+
+		report := fmt.Sprintf("Deviation profile %s, deviation modules: %s\n\n%s", p.Name, strings.Join(p.Files, ", "), ygen.DeviationReport(deviations))
+		if err := writeFiles(dir, map[string]string{deviationReportFn: report}); err != nil {
+			log.Exitf("Error while writing deviation report for profile %s: %v", p.Name, err)
+		}
+	}
+}
+
+// generate generates the schema structs and path structs, as requested by the
+// command-line flags, for the yangFiles within a package with the supplied
+// name. The code is written to outDir if it is non-empty, or to the files
+// specified by the command-line flags otherwise. It returns the schema nodes
+// that are deviated by the deviation statements of the YANG modules, which
+// are determined only if schema structs are generated.
+func generate(packageName, outDir string, yangFiles, includePaths, modsExcluded []string, features map[string][]string) []*ygen.DeviatedNode {
+	var deviations []*ygen.DeviatedNode
 	if *generateGoStructs {
 		generateGoStructsSingleFile := *ocStructsOutputFile != ""
-		generateGoStructsMultipleFiles := *outputDir != ""
+		generateGoStructsMultipleFiles := outDir != ""
 		if generateGoStructsSingleFile && generateGoStructsMultipleFiles {
-			log.Exitf("Error: cannot specify both output_file (%s) and output_dir (%s)", *ocStructsOutputFile, *outputDir)
+			log.Exitf("Error: cannot specify both output_file (%s) and output_dir (%s)", *ocStructsOutputFile, outDir)
 		}
 		if !generateGoStructsSingleFile && !generateGoStructsMultipleFiles {
 			log.Exitf("Error: Go struct generation requires a specified output file or output directory.")
@@ -313,6 +375,7 @@ func main() {
 			ParseOptions: ygen.ParseOpts{
 				ExcludeModules:        modsExcluded,
 				SkipEnumDeduplication: *skipEnumDedup,
+				EnabledFeatures:       features,
 				YANGParseOptions: yang.Options{
 					IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
 				},
@@ -325,28 +388,34 @@ func main() {
 				EnumOrgPrefixesToTrim:                enumOrgPrefixesToTrim,
 				UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
 			},
-			PackageName:        *packageName,
+			PackageName:        packageName,
 			GenerateJSONSchema: *generateSchema,
 			GoOptions: ygen.GoOpts{
-				YgotImportPath:       *ygotImportPath,
-				YtypesImportPath:     *ytypesImportPath,
-				GoyangImportPath:     *goyangImportPath,
-				GenerateRenameMethod: *generateRename,
-				AddAnnotationFields:  *addAnnotations,
-				AnnotationPrefix:     *annotationPrefix,
-				GenerateGetters:      *generateGetters,
-				GenerateDeleteMethod: *generateDelete,
-				GenerateAppendMethod: *generateAppend,
-				GenerateLeafGetters:  *generateLeafGetters,
-				GenerateSimpleUnions: *generateSimpleUnions,
-				IncludeModelData:     *includeModelData,
+				YgotImportPath:            *ygotImportPath,
+				YtypesImportPath:          *ytypesImportPath,
+				GoyangImportPath:          *goyangImportPath,
+				GenerateRenameMethod:      *generateRename,
+				AddAnnotationFields:       *addAnnotations,
+				AnnotationPrefix:          *annotationPrefix,
+				GenerateGetters:           *generateGetters,
+				GenerateDeleteMethod:      *generateDelete,
+				GenerateAppendMethod:      *generateAppend,
+				GenerateLeafGetters:       *generateLeafGetters,
+				GenerateSimpleUnions:      *generateSimpleUnions,
+				IncludeModelData:          *includeModelData,
+				GenerateLeafMetadata:      *generateLeafMetadata,
+				GenerateOrderedMaps:       *generateOrderedMaps,
+				GenerateRPCTypes:          *generateRPCTypes,
+				GenerateNotificationTypes: *generateNotifTypes,
+				GenerateDefaultsMethods:   *generateDefaults,
 			},
 		})
 
-		generatedGoCode, errs := cg.GenerateGoCode(generateModules, includePaths)
+		generatedGoCode, errs := cg.GenerateGoCode(yangFiles, includePaths)
 		if errs != nil {
 			log.Exitf("ERROR Generating GoStruct Code: %v\n", errs)
 		}
+		deviations = generatedGoCode.Deviations
 
 		switch {
 		case generateGoStructsSingleFile:
@@ -370,7 +439,7 @@ func main() {
 			if err != nil {
 				log.Exitf("ERROR writing split GoStruct Code: %v\n", err)
 			}
-			if err := writeFiles(*outputDir, out); err != nil {
+			if err := writeFiles(outDir, out); err != nil {
 				log.Exitf("Error while writing schema struct files: %v", err)
 			}
 		}
@@ -378,16 +447,16 @@ func main() {
 
 	// Generate PathStructs.
 	if !*generatePathStructs {
-		return
+		return deviations
 	}
 	if !*compressPaths {
 		log.Exitf("Error: path struct generation not supported for uncompressed paths. Please use compressed paths or remove output file flag for path struct generation.")
 	}
 
 	generatePathStructsSingleFile := *ocPathStructsOutputFile != ""
-	generatePathStructsMultipleFiles := *outputDir != ""
+	generatePathStructsMultipleFiles := outDir != ""
 	if generatePathStructsSingleFile && generatePathStructsMultipleFiles {
-		log.Exitf("Error: cannot specify both path_structs_output_file (%s) and output_dir (%s)", *ocPathStructsOutputFile, *outputDir)
+		log.Exitf("Error: cannot specify both path_structs_output_file (%s) and output_dir (%s)", *ocPathStructsOutputFile, outDir)
 	}
 	if !generatePathStructsSingleFile && !generatePathStructsMultipleFiles {
 		log.Exitf("Error: path struct generation requires a specified output file or directory.")
@@ -395,7 +464,7 @@ func main() {
 
 	// Perform the code generation.
 	pcg := &ypathgen.GenConfig{
-		PackageName: *packageName,
+		PackageName: packageName,
 		GoImports: ypathgen.GoImports{
 			SchemaStructPkgPath: *schemaStructPath,
 			YgotImportPath:      *ygotImportPath,
@@ -408,6 +477,7 @@ func main() {
 		FakeRootName:                         *fakeRootName,
 		PathStructSuffix:                     *pathStructSuffix,
 		ExcludeModules:                       modsExcluded,
+		EnabledFeatures:                      features,
 		YANGParseOptions: yang.Options{
 			IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
 		},
@@ -415,7 +485,7 @@ func main() {
 		ListBuilderKeyThreshold: *listBuilderKeyThreshold,
 	}
 
-	pathCode, _, errs := pcg.GeneratePathCode(generateModules, includePaths)
+	pathCode, _, errs := pcg.GeneratePathCode(yangFiles, includePaths)
 	if errs != nil {
 		log.Exitf("ERROR Generating PathStruct Code: %s\n", errs)
 	}
@@ -446,8 +516,10 @@ func main() {
 		for i, file := range files {
 			out[fmt.Sprintf(pathStructsFileFmt, i)] = file
 		}
-		if err := writeFiles(*outputDir, out); err != nil {
+		if err := writeFiles(outDir, out); err != nil {
 			log.Exitf("Error while writing path struct files: %v", err)
 		}
 	}
+
+	return deviations
 }
@@ -18,6 +18,7 @@ package genutil
 
 import (
 	"fmt"
+	"go/token"
 	"io"
 	"sort"
 	"strings"
@@ -166,6 +167,82 @@ func TranslateToCompressBehaviour(compressPaths, excludeState, preferOperational
 	}
 }
 
+// ParseEnabledFeatures parses s, a comma-separated list of YANG features of
+// the form module:feature, into a set of enabled features keyed by the name
+// of the module that defines them. An entry of the form module: enables no
+// features, such that all features can be disabled. A nil set is returned if
+// s is empty, which indicates that all features are enabled.
+func ParseEnabledFeatures(s string) (map[string][]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	features := map[string][]string{}
+	for _, f := range strings.Split(s, ",") {
+		p := strings.SplitN(strings.TrimSpace(f), ":", 2)
+		if len(p) != 2 || p[0] == "" {
+			return nil, fmt.Errorf("invalid feature %q, must be of the form module:feature", f)
+		}
+		if _, ok := features[p[0]]; !ok {
+			features[p[0]] = []string{}
+		}
+		if p[1] != "" {
+			features[p[0]] = append(features[p[0]], p[1])
+		}
+	}
+	return features, nil
+}
+
+// DeviationProfile is a named set of YANG modules containing deviation
+// statements, which are applied on top of a base set of YANG modules such
+// that code can be generated for a particular implementation of the modules.
+type DeviationProfile struct {
+	// Name is the name of the profile. It is used as the name of the
+	// package, and of the directory, that the code generated for the
+	// profile is written to, and hence must be a valid Go identifier.
+	Name string
+	// Files are the paths to the YANG modules containing the deviations
+	// of the profile.
+	Files []string
+}
+
+// ParseDeviationProfiles parses s, a semicolon-separated list of deviation
+// profiles of the form name=file1,file2, into a slice of DeviationProfile
+// structs, in the order in which they are specified. A nil slice is returned
+// if s is empty.
+func ParseDeviationProfiles(s string) ([]*DeviationProfile, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var profiles []*DeviationProfile
+	seen := map[string]bool{}
+	for _, p := range strings.Split(s, ";") {
+		parts := strings.SplitN(strings.TrimSpace(p), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid deviation profile %q, must be of the form name=file1,file2", p)
+		}
+		name := strings.TrimSpace(parts[0])
+		if !token.IsIdentifier(name) {
+			return nil, fmt.Errorf("invalid deviation profile name %q, must be a valid Go identifier", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate deviation profile %s", name)
+		}
+		seen[name] = true
+
+		dp := &DeviationProfile{Name: name}
+		for _, f := range strings.Split(parts[1], ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				dp.Files = append(dp.Files, f)
+			}
+		}
+		if len(dp.Files) == 0 {
+			return nil, fmt.Errorf("deviation profile %s does not specify any deviation modules", name)
+		}
+		profiles = append(profiles, dp)
+	}
+	return profiles, nil
+}
+
 // FindAllChildren finds the data tree elements that are children of a YANG entry e, which
 // should have code generated for them. In general, this means data tree elements that are
 // directly connected to a particular data tree element; however, when compression of the
@@ -495,7 +572,7 @@ func TransformEntry(e *yang.Entry, compressBehaviour CompressBehaviour) util.Err
 		case ch.IsContainer(), ch.IsList(), util.IsChoiceOrCase(ch):
 			// Recurse down the tree.
 			errs = util.AppendErrs(errs, TransformEntry(ch, compressBehaviour))
-		case ch.Kind == yang.AnyDataEntry:
+		case util.IsAnydata(ch):
 			continue
 		default:
 			errs = util.AppendErr(errs, fmt.Errorf("unknown type of entry %v in TransformEntry for %s", e.Kind, e.Path()))
@@ -75,6 +75,88 @@ func TestTranslateToCompressBehaviour(t *testing.T) {
 	}
 }
 
+func TestParseEnabledFeatures(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    map[string][]string
+		wantErr bool
+	}{{
+		in: "",
+	}, {
+		in:   "mod:a",
+		want: map[string][]string{"mod": {"a"}},
+	}, {
+		in:   "mod:a, mod:b,other:c",
+		want: map[string][]string{"mod": {"a", "b"}, "other": {"c"}},
+	}, {
+		in:   "mod:",
+		want: map[string][]string{"mod": {}},
+	}, {
+		in:      "a",
+		wantErr: true,
+	}, {
+		in:      ":a",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseEnabledFeatures(tt.in)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("gotErr: %v, wantErr: %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseDeviationProfiles(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []*DeviationProfile
+		wantErr bool
+	}{{
+		in: "",
+	}, {
+		in:   "vendora=a.yang",
+		want: []*DeviationProfile{{Name: "vendora", Files: []string{"a.yang"}}},
+	}, {
+		in: "vendora=a.yang, a2.yang; vendorb=b.yang",
+		want: []*DeviationProfile{
+			{Name: "vendora", Files: []string{"a.yang", "a2.yang"}},
+			{Name: "vendorb", Files: []string{"b.yang"}},
+		},
+	}, {
+		in:      "a.yang",
+		wantErr: true,
+	}, {
+		in:      "vendor-a=a.yang",
+		wantErr: true,
+	}, {
+		in:      "vendora=",
+		wantErr: true,
+	}, {
+		in:      "vendora=a.yang;vendora=b.yang",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseDeviationProfiles(tt.in)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("gotErr: %v, wantErr: %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestWriteIfNotEmpty(t *testing.T) {
 	tests := []struct {
 		name string
@@ -35,6 +35,7 @@ var (
 	yangPaths                            = flag.String("path", "", "Comma separated list of paths to be recursively searched for included modules or submodules within the defined YANG modules.")
 	compressPaths                        = flag.Bool("compress_paths", false, "If set to true, the schema's paths are compressed, according to OpenConfig YANG module conventions.")
 	excludeModules                       = flag.String("exclude_modules", "", "Comma separated set of module names that should be excluded from code generation. This can be used to ensure overlapping namespaces can be ignored.")
+	enabledFeatures                      = flag.String("enabled_features", "", "Comma separated set of YANG features, of the form module:feature, that are supported by the target of the generated messages. If set, schema nodes whose if-feature statements are false for the set of features are omitted from the generated messages. A value of module: enables no features of the module.")
 	packageName                          = flag.String("package_name", "openconfig", "The name of the Proto package that generated messages should belong to as their parent.")
 	enumPackageName                      = flag.String("enum_package_name", "enums", "The name of the package within the generated package that should contain global enum definitions.")
 	outputDir                            = flag.String("output_dir", "", "The path to which files should be output, hierarchical folders are created for the generated messages.")
@@ -94,6 +95,13 @@ func main() {
 		}
 	}
 
+	// Determine the set of YANG features that the user has requested to be
+	// enabled.
+	features, err := genutil.ParseEnabledFeatures(*enabledFeatures)
+	if err != nil {
+		log.Exitf("ERROR Generating Proto Code: %s\n", err)
+	}
+
 	compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
 	if err != nil {
 		log.Exitf("ERROR Generating Proto Code: %s\n", err)
@@ -104,6 +112,7 @@ func main() {
 		ParseOptions: ygen.ParseOpts{
 			ExcludeModules:        modsExcluded,
 			SkipEnumDeduplication: *skipEnumDedup,
+			EnabledFeatures:       features,
 			YANGParseOptions: yang.Options{
 				IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
 			},
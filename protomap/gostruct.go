@@ -0,0 +1,268 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protomap
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/openconfig/gnmi/value"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// ProtoFromGoStruct populates the ygen-generated protobuf message p from the
+// contents of the ygen-generated GoStruct s, which must have been generated
+// from the same schema, with the same path compression settings. The fields
+// of s are matched to those of p using the path struct tags of s and the
+// yext.schemapath annotations of p. The values of enumerated fields are
+// mapped by their YANG names, union fields to the member of the oneof that
+// corresponds to the type of their value, and keyed lists to the repeated key
+// messages of p.
+//
+// The paths of the fields of s are relative to s, such that where s is not
+// the root of the schema, the ValuePathPrefix option must be used to specify
+// its data tree path. The options are otherwise handled as described for
+// ProtoFromPaths, such that an error is returned if a populated field of s
+// does not map to a field of p.
+func ProtoFromGoStruct(s ygot.GoStruct, p proto.Message, opts ...UnmapOpt) error {
+	if util.IsValueNil(s) {
+		return errors.New("nil GoStruct supplied")
+	}
+	vals := map[*gpb.Path]interface{}{}
+	if err := goStructPaths(reflect.ValueOf(s), &gpb.Path{}, vals); err != nil {
+		return err
+	}
+	return ProtoFromPaths(p, vals, opts...)
+}
+
+// GoStructFromProto populates the ygen-generated GoStruct s, whose schema is
+// supplied, from the contents of the ygen-generated protobuf message p, which
+// must have been generated from the same schema, with the same path
+// compression settings. Each field of p is written to the field of s with the
+// corresponding data tree path, such that enumerated values, unions and keyed
+// lists are converted to their GoStruct representations.
+//
+// Where p and s are not the root of the schema, the ProtobufMessagePrefix
+// option must be used to specify their data tree path. Other options are
+// ignored.
+func GoStructFromProto(s ygot.GoStruct, schema *yang.Entry, p proto.Message, opts ...UnmapOpt) error {
+	if util.IsValueNil(s) {
+		return errors.New("nil GoStruct supplied")
+	}
+	if p == nil {
+		return errors.New("nil protobuf message supplied")
+	}
+
+	var prefix *gpb.Path
+	for _, o := range opts {
+		if v, ok := o.(*ProtobufMessagePrefix); ok {
+			prefix = v.Path
+		}
+	}
+
+	vals := map[*gpb.Path]interface{}{}
+	if err := pathsFromProtoInternal(p, vals, prefix); err != nil {
+		return err
+	}
+
+	paths := []*gpb.Path{}
+	for path := range vals {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return pathString(paths[i]) < pathString(paths[j])
+	})
+
+	var errs util.Errors
+	for _, path := range paths {
+		tv, err := value.FromScalar(vals[path])
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("cannot encode value at %s, %v", pathString(path), err))
+			continue
+		}
+		if err := ytypes.SetNode(schema, s, util.TrimGNMIPathElemPrefix(path, prefix), tv, &ytypes.InitMissingElements{}); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("cannot set value at %s, %v", pathString(path), err))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// goStructPaths appends the values of the populated leaves and leaf-lists of
+// the GoStruct v, whose data tree path is basePath, to the vals map, keyed by
+// their data tree path. It is called recursively for the child containers and
+// lists of v.
+func goStructPaths(v reflect.Value, basePath *gpb.Path, vals map[*gpb.Path]interface{}) error {
+	sv := v.Elem()
+	st := sv.Type()
+	for i := 0; i < sv.NumField(); i++ {
+		ft, fv := st.Field(i), sv.Field(i)
+		if util.IsYgotAnnotation(ft) || util.IsNilOrInvalidValue(fv) || util.IsValueNil(fv.Interface()) {
+			continue
+		}
+
+		paths, err := util.SchemaPaths(ft)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range paths {
+			fp := joinPaths(basePath, &gpb.Path{Elem: pathElems(p)})
+			switch {
+			case util.IsValueStructPtr(fv) && !isUnionValue(fv):
+				if err := goStructPaths(fv, fp, vals); err != nil {
+					return err
+				}
+			case util.IsValueMap(fv):
+				if err := goStructListPaths(fv, fp, vals); err != nil {
+					return err
+				}
+			case fv.Kind() == reflect.Slice && util.IsTypeStructPtr(fv.Type().Elem()):
+				return fmt.Errorf("unkeyed lists are not supported in ygen-generated protobufs, field %s", ft.Name)
+			case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8:
+				ll := []interface{}{}
+				for j := 0; j < fv.Len(); j++ {
+					ev, ok, err := goLeafValue(fv.Index(j))
+					if err != nil {
+						return fmt.Errorf("invalid value for leaf-list %s, %v", ft.Name, err)
+					}
+					if ok {
+						ll = append(ll, ev)
+					}
+				}
+				vals[fp] = ll
+			default:
+				lv, ok, err := goLeafValue(fv)
+				if err != nil {
+					return fmt.Errorf("invalid value for leaf %s, %v", ft.Name, err)
+				}
+				if ok {
+					vals[fp] = lv
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// goStructListPaths appends the values of the entries of the keyed list
+// represented by the map v, whose schema path is listPath, to the vals map.
+func goStructListPaths(v reflect.Value, listPath *gpb.Path, vals map[*gpb.Path]interface{}) error {
+	for _, k := range v.MapKeys() {
+		ev := v.MapIndex(k)
+		keys, err := ygot.PathKeyFromStruct(ev)
+		if err != nil {
+			return fmt.Errorf("cannot determine keys of list entry %v, %v", k.Interface(), err)
+		}
+		ep := proto.Clone(listPath).(*gpb.Path)
+		ep.Elem[len(ep.Elem)-1].Key = keys
+		if err := goStructPaths(ev, ep, vals); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// goLeafValue returns the value of the leaf v of a GoStruct as a Go scalar
+// value, and whether the leaf is set. Enumerated values are returned as their
+// YANG name, and union values as the value of their member type.
+func goLeafValue(v reflect.Value) (interface{}, bool, error) {
+	if util.IsNilOrInvalidValue(v) {
+		return nil, false, nil
+	}
+	if e, ok := v.Interface().(ygot.GoEnum); ok {
+		if reflect.ValueOf(e).Int() == 0 {
+			// The UNSET value of an enumerated type.
+			return nil, false, nil
+		}
+		n, err := ygot.EnumName(e)
+		return n, err == nil, err
+	}
+
+	switch {
+	case v.Kind() == reflect.Interface:
+		return goLeafValue(v.Elem())
+	case isUnionValue(v):
+		// Union values that are represented by a wrapper struct have a
+		// single field containing the value.
+		return goLeafValue(v.Elem().Field(0))
+	case v.Kind() == reflect.Ptr:
+		return goLeafValue(v.Elem())
+	case v.Type().Name() == ygot.EmptyTypeName:
+		return true, v.Bool(), nil
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return v.Bytes(), true, nil
+	}
+
+	// Derived types, such as those used for simple union types, are
+	// returned as their underlying scalar type.
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true, nil
+	case reflect.Bool:
+		return v.Bool(), true, nil
+	case reflect.Int8:
+		return int8(v.Int()), true, nil
+	case reflect.Int16:
+		return int16(v.Int()), true, nil
+	case reflect.Int32:
+		return int32(v.Int()), true, nil
+	case reflect.Int64:
+		return v.Int(), true, nil
+	case reflect.Uint8:
+		return uint8(v.Uint()), true, nil
+	case reflect.Uint16:
+		return uint16(v.Uint()), true, nil
+	case reflect.Uint32:
+		return uint32(v.Uint()), true, nil
+	case reflect.Uint64:
+		return v.Uint(), true, nil
+	case reflect.Float64:
+		return v.Float(), true, nil
+	}
+	return nil, false, fmt.Errorf("unhandled leaf value type %v", v.Type())
+}
+
+// isUnionValue reports whether v is a pointer to a wrapper struct that is
+// used to represent the value of a union within a GoStruct.
+func isUnionValue(v reflect.Value) bool {
+	if !util.IsValueStructPtr(v) || v.Elem().NumField() != 1 {
+		return false
+	}
+	_, ok := v.Type().Elem().Field(0).Tag.Lookup("path")
+	return !ok
+}
+
+// pathElems returns the gNMI PathElems corresponding to the relative schema
+// path p of a GoStruct field.
+func pathElems(p []string) []*gpb.PathElem {
+	elems := []*gpb.PathElem{}
+	for _, e := range p {
+		if e != "" {
+			elems = append(elems, &gpb.PathElem{Name: e})
+		}
+	}
+	return elems
+}
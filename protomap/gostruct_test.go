@@ -0,0 +1,500 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protomap
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	wpb "github.com/openconfig/ygot/proto/ywrapper"
+	epb "github.com/openconfig/ygot/protomap/testdata/exschemapath"
+)
+
+// The following types are GoStructs corresponding to the messages within the
+// exschemapath protobuf, as would be generated by ygen.
+
+type Root struct {
+	Interface map[string]*Interface `path:"interfaces/interface"`
+	System    *System               `path:"system"`
+}
+
+func (*Root) IsYANGGoStruct() {}
+
+type Interface struct {
+	Name        *string `path:"config/name|name"`
+	Description *string `path:"config/description"`
+}
+
+func (*Interface) IsYANGGoStruct() {}
+
+func (t *Interface) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.Name == nil {
+		return nil, fmt.Errorf("nil value for key Name")
+	}
+	return map[string]interface{}{"name": *t.Name}, nil
+}
+
+type System struct {
+	Hostname *string `path:"config/hostname"`
+}
+
+func (*System) IsYANGGoStruct() {}
+
+type Example struct {
+	Bool      *bool                                        `path:"bool"`
+	Bytes     Binary                                       `path:"bytes"`
+	Decimal   *float64                                     `path:"decimal"`
+	Int       *int64                                       `path:"int"`
+	String    *string                                      `path:"string"`
+	Uint      *uint64                                      `path:"uint"`
+	Message   *Example_Message                             `path:"message"`
+	ListName  map[string]*Example_ListName                 `path:"list-name"`
+	MultiList map[Example_MultiList_Key]*Example_MultiList `path:"multi-list"`
+	Enum      ExampleEnum                                  `path:"enum"`
+	LeafList  []string                                     `path:"leaf-list"`
+	EnumList  map[ExampleEnum]*Example_EnumList            `path:"enum-list"`
+	Union     Example_Union_Union                          `path:"union"`
+}
+
+func (*Example) IsYANGGoStruct() {}
+
+func (*Example) ΛEnumTypeMap() map[string][]reflect.Type {
+	return map[string][]reflect.Type{}
+}
+
+func (*Example) To_Example_Union_Union(i interface{}) (Example_Union_Union, error) {
+	switch v := i.(type) {
+	case string:
+		return &Example_Union_Union_String{v}, nil
+	case uint64:
+		return &Example_Union_Union_Uint64{v}, nil
+	}
+	return nil, fmt.Errorf("cannot convert %v to Example_Union_Union, unknown union type, got: %T, want any of [string, uint64]", i, i)
+}
+
+type Binary []byte
+
+type Example_Union_Union interface {
+	Is_Example_Union_Union()
+}
+
+type Example_Union_Union_String struct {
+	String string
+}
+
+func (*Example_Union_Union_String) Is_Example_Union_Union() {}
+
+type Example_Union_Union_Uint64 struct {
+	Uint64 uint64
+}
+
+func (*Example_Union_Union_Uint64) Is_Example_Union_Union() {}
+
+type Example_Message struct {
+	Str *string `path:"str"`
+}
+
+func (*Example_Message) IsYANGGoStruct() {}
+
+type Example_ListName struct {
+	SingleKey    *string                                `path:"single-key|config/single-key"`
+	AnotherField *string                                `path:"another-field"`
+	ChildList    map[string]*Example_ListName_ChildList `path:"child-list"`
+}
+
+func (*Example_ListName) IsYANGGoStruct() {}
+
+func (t *Example_ListName) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.SingleKey == nil {
+		return nil, fmt.Errorf("nil value for key SingleKey")
+	}
+	return map[string]interface{}{"single-key": *t.SingleKey}, nil
+}
+
+type Example_ListName_ChildList struct {
+	KeyOne *string `path:"key-one"`
+	Str    *string `path:"str"`
+}
+
+func (*Example_ListName_ChildList) IsYANGGoStruct() {}
+
+func (t *Example_ListName_ChildList) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.KeyOne == nil {
+		return nil, fmt.Errorf("nil value for key KeyOne")
+	}
+	return map[string]interface{}{"key-one": *t.KeyOne}, nil
+}
+
+type Example_MultiList_Key struct {
+	Index uint32 `path:"index"`
+	Name  string `path:"name"`
+}
+
+type Example_MultiList struct {
+	Index *uint32 `path:"index|config/index"`
+	Name  *string `path:"name|config/name"`
+	Child *string `path:"config/child"`
+}
+
+func (*Example_MultiList) IsYANGGoStruct() {}
+
+func (t *Example_MultiList) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.Index == nil || t.Name == nil {
+		return nil, fmt.Errorf("nil value for key")
+	}
+	return map[string]interface{}{"index": *t.Index, "name": *t.Name}, nil
+}
+
+type Example_EnumList struct {
+	Key   ExampleEnum `path:"key|config/key"`
+	Value *uint64     `path:"config/value"`
+}
+
+func (*Example_EnumList) IsYANGGoStruct() {}
+
+func (t *Example_EnumList) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"key": t.Key}, nil
+}
+
+type ExampleEnum int64
+
+const (
+	ExampleEnum_UNSET   ExampleEnum = 0
+	ExampleEnum_VAL_ONE ExampleEnum = 1
+	ExampleEnum_VAL_TWO ExampleEnum = 2
+)
+
+func (ExampleEnum) IsYANGGoEnum() {}
+
+func (ExampleEnum) ΛMap() map[string]map[int64]ygot.EnumDefinition {
+	return map[string]map[int64]ygot.EnumDefinition{
+		"ExampleEnum": {
+			1: {Name: "VAL_ONE"},
+			2: {Name: "VAL_TWO"},
+		},
+	}
+}
+
+func (e ExampleEnum) String() string {
+	return ygot.EnumLogString(e, int64(e), "ExampleEnum")
+}
+
+// leafSchema returns a leaf schema entry with the specified name and type.
+func leafSchema(name string, t *yang.YangType) *yang.Entry {
+	return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: t}
+}
+
+// dirSchema returns a directory schema entry with the specified name and
+// children.
+func dirSchema(name string, children ...*yang.Entry) *yang.Entry {
+	e := &yang.Entry{Name: name, Kind: yang.DirectoryEntry, Dir: map[string]*yang.Entry{}}
+	for _, c := range children {
+		c.Parent = e
+		e.Dir[c.Name] = c
+	}
+	return e
+}
+
+// listSchema returns a list schema entry with the specified name, keys and
+// children.
+func listSchema(name, key string, children ...*yang.Entry) *yang.Entry {
+	e := dirSchema(name, children...)
+	e.Key = key
+	e.ListAttr = yang.NewDefaultListAttr()
+	e.Config = yang.TSTrue
+	return e
+}
+
+var (
+	stringType = &yang.YangType{Kind: yang.Ystring}
+	uint32Type = &yang.YangType{Kind: yang.Yuint32}
+	uint64Type = &yang.YangType{Kind: yang.Yuint64}
+	enumType   = func() *yang.YangType {
+		e := yang.NewEnumType()
+		e.Set("VAL_ONE", 1)
+		e.Set("VAL_TWO", 2)
+		return &yang.YangType{Kind: yang.Yenum, Enum: e}
+	}()
+)
+
+// rootSchema returns the schema corresponding to the Root GoStruct.
+func rootSchema() *yang.Entry {
+	root := dirSchema("device",
+		dirSchema("interfaces",
+			listSchema("interface", "name",
+				leafSchema("name", stringType),
+				dirSchema("config",
+					leafSchema("name", stringType),
+					leafSchema("description", stringType),
+				),
+			),
+		),
+		dirSchema("system",
+			dirSchema("config", leafSchema("hostname", stringType)),
+		),
+	)
+	root.Annotation = map[string]interface{}{"isFakeRoot": true}
+	return root
+}
+
+// exampleSchema returns the schema corresponding to the Example GoStruct.
+func exampleSchema() *yang.Entry {
+	root := dirSchema("device",
+		leafSchema("bool", &yang.YangType{Kind: yang.Ybool}),
+		leafSchema("bytes", &yang.YangType{Kind: yang.Ybinary}),
+		leafSchema("decimal", &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 2}),
+		leafSchema("int", &yang.YangType{Kind: yang.Yint64}),
+		leafSchema("string", stringType),
+		leafSchema("uint", uint64Type),
+		dirSchema("message", leafSchema("str", stringType)),
+		listSchema("list-name", "single-key",
+			leafSchema("single-key", stringType),
+			dirSchema("config", leafSchema("single-key", stringType)),
+			leafSchema("another-field", stringType),
+			listSchema("child-list", "key-one",
+				leafSchema("key-one", stringType),
+				leafSchema("str", stringType),
+			),
+		),
+		listSchema("multi-list", "index name",
+			leafSchema("index", uint32Type),
+			leafSchema("name", stringType),
+			dirSchema("config",
+				leafSchema("index", uint32Type),
+				leafSchema("name", stringType),
+				leafSchema("child", stringType),
+			),
+		),
+		leafSchema("enum", enumType),
+		&yang.Entry{Name: "leaf-list", Kind: yang.LeafEntry, Type: stringType, ListAttr: yang.NewDefaultListAttr()},
+		listSchema("enum-list", "key",
+			leafSchema("key", enumType),
+			dirSchema("config",
+				leafSchema("key", enumType),
+				leafSchema("value", uint64Type),
+			),
+		),
+		leafSchema("union", &yang.YangType{Kind: yang.Yunion, Type: []*yang.YangType{stringType, uint64Type}}),
+	)
+	root.Annotation = map[string]interface{}{"isFakeRoot": true}
+	return root
+}
+
+func TestGoStructProtoConversion(t *testing.T) {
+	tests := []struct {
+		desc       string
+		inGoStruct ygot.GoStruct
+		inSchema   *yang.Entry
+		inProto    proto.Message
+		inOpts     []UnmapOpt
+		wantProto  proto.Message
+	}{{
+		desc: "root with keyed list and container",
+		inGoStruct: &Root{
+			Interface: map[string]*Interface{
+				"eth0": {Name: ygot.String("eth0"), Description: ygot.String("uplink")},
+				"eth1": {Name: ygot.String("eth1")},
+			},
+			System: &System{Hostname: ygot.String("router")},
+		},
+		inSchema: rootSchema(),
+		inProto:  &epb.Root{},
+		wantProto: &epb.Root{
+			System: &epb.System{Hostname: &wpb.StringValue{Value: "router"}},
+			Interface: []*epb.Root_InterfaceKey{{
+				Name:      "eth0",
+				Interface: &epb.Interface{Description: &wpb.StringValue{Value: "uplink"}},
+			}, {
+				Name:      "eth1",
+				Interface: &epb.Interface{},
+			}},
+		},
+	}, {
+		desc: "scalars, enumerated values, leaf-lists and unions",
+		inGoStruct: &Example{
+			Bool:     ygot.Bool(true),
+			Bytes:    Binary{1, 2, 3},
+			Int:      ygot.Int64(-42),
+			String:   ygot.String("hello"),
+			Uint:     ygot.Uint64(42),
+			Message:  &Example_Message{Str: ygot.String("child")},
+			Enum:     ExampleEnum_VAL_TWO,
+			LeafList: []string{"one", "two"},
+			Union:    &Example_Union_Union_Uint64{42},
+		},
+		inSchema: exampleSchema(),
+		inProto:  &epb.ExampleMessage{},
+		wantProto: &epb.ExampleMessage{
+			Bo:       &wpb.BoolValue{Value: true},
+			By:       &wpb.BytesValue{Value: []byte{1, 2, 3}},
+			In:       &wpb.IntValue{Value: -42},
+			Str:      &wpb.StringValue{Value: "hello"},
+			Ui:       &wpb.UintValue{Value: 42},
+			Ex:       &epb.ExampleMessageChild{Str: &wpb.StringValue{Value: "child"}},
+			En:       epb.ExampleEnum_EXAMPLEENUM_VAL_TWO,
+			LeafList: []*wpb.StringValue{{Value: "one"}, {Value: "two"}},
+			Union:    &epb.ExampleMessage_UnionUint64{UnionUint64: 42},
+		},
+	}, {
+		desc: "string union",
+		inGoStruct: &Example{
+			Union: &Example_Union_Union_String{"forty-two"},
+		},
+		inSchema: exampleSchema(),
+		inProto:  &epb.ExampleMessage{},
+		wantProto: &epb.ExampleMessage{
+			Union: &epb.ExampleMessage_UnionString{UnionString: "forty-two"},
+		},
+	}, {
+		desc: "lists with nested, multiple and enumerated keys",
+		inGoStruct: &Example{
+			ListName: map[string]*Example_ListName{
+				"k1": {
+					SingleKey:    ygot.String("k1"),
+					AnotherField: ygot.String("val-one"),
+					ChildList: map[string]*Example_ListName_ChildList{
+						"c1": {KeyOne: ygot.String("c1"), Str: ygot.String("two")},
+					},
+				},
+			},
+			MultiList: map[Example_MultiList_Key]*Example_MultiList{
+				{Index: 1, Name: "one"}: {Index: ygot.Uint32(1), Name: ygot.String("one"), Child: ygot.String("child")},
+			},
+			EnumList: map[ExampleEnum]*Example_EnumList{
+				ExampleEnum_VAL_ONE: {Key: ExampleEnum_VAL_ONE, Value: ygot.Uint64(1)},
+			},
+		},
+		inSchema: exampleSchema(),
+		inProto:  &epb.ExampleMessage{},
+		wantProto: &epb.ExampleMessage{
+			Em: []*epb.ExampleMessageKey{{
+				SingleKey: "k1",
+				Member: &epb.ExampleMessageListMember{
+					Str: &wpb.StringValue{Value: "val-one"},
+					ChildList: []*epb.NestedListKey{{
+						KeyOne: "c1",
+						Field:  &epb.NestedListMember{Str: &wpb.StringValue{Value: "two"}},
+					}},
+				},
+			}},
+			Multi: []*epb.ExampleMessageMultiKey{{
+				Index:  1,
+				Name:   "one",
+				Member: &epb.MultiKeyListMember{Child: &wpb.StringValue{Value: "child"}},
+			}},
+			EnumList: []*epb.ExampleMessageEnumKey{{
+				Key:    epb.ExampleEnum_EXAMPLEENUM_VAL_ONE,
+				Member: &epb.EnumListMember{Value: &wpb.UintValue{Value: 1}},
+			}},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := ProtoFromGoStruct(tt.inGoStruct, tt.inProto, tt.inOpts...); err != nil {
+				t.Fatalf("ProtoFromGoStruct: got unexpected error, %v", err)
+			}
+			if diff := cmp.Diff(tt.inProto, tt.wantProto, protocmp.Transform()); diff != "" {
+				t.Fatalf("ProtoFromGoStruct: did not get expected results, diff(-got,+want):\n%s", diff)
+			}
+
+			got := reflect.New(reflect.TypeOf(tt.inGoStruct).Elem()).Interface().(ygot.GoStruct)
+			if err := GoStructFromProto(got, tt.inSchema, tt.wantProto, tt.inOpts...); err != nil {
+				t.Fatalf("GoStructFromProto: got unexpected error, %v", err)
+			}
+			if diff := cmp.Diff(got, tt.inGoStruct); diff != "" {
+				t.Fatalf("GoStructFromProto: did not get expected results, diff(-got,+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGoStructProtoConversionErrors(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inGoStruct       ygot.GoStruct
+		inProto          proto.Message
+		wantErrSubstring string
+	}{{
+		desc:             "nil GoStruct",
+		inProto:          &epb.Root{},
+		wantErrSubstring: "nil GoStruct supplied",
+	}, {
+		desc: "field not in protobuf",
+		inGoStruct: &Root{
+			System: &System{Hostname: ygot.String("router")},
+		},
+		inProto:          &epb.ExampleMessage{},
+		wantErrSubstring: "did not map path /system/config/hostname",
+	}, {
+		desc: "decimal64 value",
+		inGoStruct: &Example{
+			Decimal: ygot.Float64(12.34),
+		},
+		inProto: &epb.ExampleMessage{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := ProtoFromGoStruct(tt.inGoStruct, tt.inProto)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ProtoFromGoStruct: did not get expected error, %s", diff)
+			}
+		})
+	}
+
+	// Decimal64 values are not currently supported when mapping from
+	// protobufs.
+	err := GoStructFromProto(&Example{}, exampleSchema(), &epb.ExampleMessage{De: &wpb.Decimal64Value{Digits: 1234, Precision: 2}})
+	if diff := errdiff.Substring(err, "unhandled type, decimal64"); diff != "" {
+		t.Fatalf("GoStructFromProto: did not get expected error, %s", diff)
+	}
+
+	// Paths that are not in the GoStruct are reported.
+	err = GoStructFromProto(&Root{}, rootSchema(), &epb.ExampleMessage{Str: &wpb.StringValue{Value: "hello"}})
+	if diff := errdiff.Substring(err, "cannot set value at /string"); diff != "" {
+		t.Fatalf("GoStructFromProto: did not get expected error, %s", diff)
+	}
+}
+
+func TestGoStructProtoConversionWithPrefix(t *testing.T) {
+	intf := &Interface{Name: ygot.String("eth0"), Description: ygot.String("uplink")}
+	p := &epb.Interface{}
+	prefix := mustPath("/interfaces/interface[name=eth0]")
+	if err := ProtoFromGoStruct(intf, p, &ValuePathPrefix{Path: prefix}, &ProtobufMessagePrefix{Path: prefix}, &IgnoreExtraPaths{}); err != nil {
+		t.Fatalf("ProtoFromGoStruct: got unexpected error, %v", err)
+	}
+	want := &epb.Interface{Description: &wpb.StringValue{Value: "uplink"}}
+	if diff := cmp.Diff(p, want, protocmp.Transform()); diff != "" {
+		t.Fatalf("ProtoFromGoStruct: did not get expected results, diff(-got,+want):\n%s", diff)
+	}
+
+	got := &Interface{}
+	schema := rootSchema().Dir["interfaces"].Dir["interface"]
+	if err := GoStructFromProto(got, schema, want, &ProtobufMessagePrefix{Path: prefix}); err != nil {
+		t.Fatalf("GoStructFromProto: got unexpected error, %v", err)
+	}
+	if diff := cmp.Diff(got, &Interface{Description: ygot.String("uplink")}); diff != "" {
+		t.Fatalf("GoStructFromProto: did not get expected results, diff(-got,+want):\n%s", diff)
+	}
+}
@@ -20,12 +20,18 @@ package protomap
 import (
 	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
 
+	"github.com/openconfig/gnmi/value"
+	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
 
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
@@ -33,6 +39,44 @@ import (
 	wpb "github.com/openconfig/ygot/proto/ywrapper"
 )
 
+// TogNMINotifications renders the ygen-generated protobuf message p, whose
+// fields are annotated with the yext.schemapath extension, to a slice of gNMI
+// Notification messages marked with the timestamp ts. The prefix is the data
+// tree path of p, which is used as the prefix of the Notification, and must be
+// specified where p is below a keyed list such that its keys are known. The
+// paths of the updates are relative to the prefix, and use the PathElem
+// format, with the keys of lists populated from the key fields of the
+// corresponding repeated messages in p.
+func TogNMINotifications(p proto.Message, ts int64, prefix *gpb.Path) ([]*gpb.Notification, error) {
+	if p == nil {
+		return nil, errors.New("nil protobuf message supplied")
+	}
+
+	vals := map[*gpb.Path]interface{}{}
+	if err := pathsFromProtoInternal(p, vals, prefix); err != nil {
+		return nil, err
+	}
+
+	n := &gpb.Notification{
+		Timestamp: ts,
+		Prefix:    prefix,
+	}
+	for path, v := range vals {
+		tv, err := value.FromScalar(v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode value at %s, %v", pathString(path), err)
+		}
+		n.Update = append(n.Update, &gpb.Update{
+			Path: util.TrimGNMIPathElemPrefix(path, prefix),
+			Val:  tv,
+		})
+	}
+	sort.Slice(n.Update, func(i, j int) bool {
+		return pathString(n.Update[i].Path) < pathString(n.Update[j].Path)
+	})
+	return []*gpb.Notification{n}, nil
+}
+
 // pathsFromProto returns, from a populated proto, a map between the YANG schema
 // path (as specified in the yext.schemapath extension) and the value populated in
 // the message.
@@ -73,6 +117,12 @@ func parseField(fd protoreflect.FieldDescriptor, v protoreflect.Value, vals map[
 		return errors.New("map fields are not supported in ygen-generated protobufs")
 	}
 
+	// Messages that are fields of the fake root do not have an annotated
+	// path, since their fields have absolute paths.
+	if fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !isWrapper(fd.Message()) && schemaPathAnnotation(fd) == "" {
+		return pathsFromProtoInternal(v.Message().Interface(), vals, basePath)
+	}
+
 	annotatedPath, err := annotatedSchemaPath(fd)
 	if err != nil {
 		return err
@@ -82,31 +132,35 @@ func parseField(fd protoreflect.FieldDescriptor, v protoreflect.Value, vals map[
 	// wrapper message, or child messages if required.
 	val := v.Interface()
 
-	if fd.IsList() {
-		return parseList(fd, v, vals, basePath, annotatedPath)
-	}
-
-	// Handle messages that are field values
-	if fd.Kind() == protoreflect.MessageKind {
-		switch t := v.Message().Interface().(type) {
-		case *wpb.BoolValue:
-			val = t.GetValue()
-		case *wpb.BytesValue:
-			val = t.GetValue()
-		case *wpb.Decimal64Value:
-			return fmt.Errorf("unhandled type, decimal64")
-		case *wpb.IntValue:
-			val = t.GetValue()
-		case *wpb.StringValue:
-			val = t.GetValue()
-		case *wpb.UintValue:
-			val = t.GetValue()
-		case proto.Message:
-			if len(annotatedPath) != 1 {
-				return fmt.Errorf("invalid container, maps to >1 schema path, field: %s", fd.FullName())
+	switch {
+	case fd.IsList() && fd.Kind() == protoreflect.MessageKind && isWrapper(fd.Message()):
+		// Leaf-lists are repeated wrapper messages.
+		l := v.List()
+		ll := []interface{}{}
+		for i := 0; i < l.Len(); i++ {
+			ev, err := unwrapValue(l.Get(i).Message().Interface())
+			if err != nil {
+				return err
 			}
-			return pathsFromProtoInternal(t, vals, basePath)
+			ll = append(ll, ev)
+		}
+		val = ll
+	case fd.IsList():
+		return parseList(fd, v, vals, basePath, annotatedPath)
+	case fd.Kind() == protoreflect.EnumKind:
+		if val, err = enumYANGName(fd.Enum(), v.Enum()); err != nil {
+			return err
+		}
+	case fd.Kind() == protoreflect.MessageKind && isWrapper(fd.Message()):
+		if val, err = unwrapValue(v.Message().Interface()); err != nil {
+			return err
+		}
+	case fd.Kind() == protoreflect.MessageKind:
+		// Handle messages that are field values
+		if len(annotatedPath) != 1 {
+			return fmt.Errorf("invalid container, maps to >1 schema path, field: %s", fd.FullName())
 		}
+		return pathsFromProtoInternal(v.Message().Interface(), vals, basePath)
 	}
 
 	// Handle cases where there is >1 path specified for a field based on
@@ -118,6 +172,40 @@ func parseField(fd protoreflect.FieldDescriptor, v protoreflect.Value, vals map[
 	return nil
 }
 
+// unwrapValue returns the scalar value contained within the supplied ywrapper
+// message.
+func unwrapValue(m proto.Message) (interface{}, error) {
+	switch t := m.(type) {
+	case *wpb.BoolValue:
+		return t.GetValue(), nil
+	case *wpb.BytesValue:
+		return t.GetValue(), nil
+	case *wpb.Decimal64Value:
+		return nil, fmt.Errorf("unhandled type, decimal64")
+	case *wpb.IntValue:
+		return t.GetValue(), nil
+	case *wpb.StringValue:
+		return t.GetValue(), nil
+	case *wpb.UintValue:
+		return t.GetValue(), nil
+	}
+	return nil, fmt.Errorf("unhandled wrapper message type %T", m)
+}
+
+// enumYANGName returns the YANG name of the value n of the enum described by
+// ed, as specified by the yext.yang_name annotation of the value.
+func enumYANGName(ed protoreflect.EnumDescriptor, n protoreflect.EnumNumber) (string, error) {
+	vd := ed.Values().ByNumber(n)
+	if vd == nil {
+		return "", fmt.Errorf("enum %s has no value with number %d", ed.FullName(), n)
+	}
+	yn := proto.GetExtension(vd.Options().(*descriptorpb.EnumValueOptions), yextpb.E_YangName).(string)
+	if yn == "" {
+		return "", fmt.Errorf("enum value %s does not have a YANG name annotation", vd.FullName())
+	}
+	return yn, nil
+}
+
 // Modify the Range function for a protoreflect.Message to be able to cover fields that
 // are not populated, since we need to be able to support scalar fields in our ranges.
 //
@@ -159,7 +247,8 @@ func (m unpopRange) Range(f func(protoreflect.FieldDescriptor, protoreflect.Valu
 // List fields are 'repeated' in the input protobuf. We have two cases of such
 // fields:
 //  1. leaf-list fields which have scalar values - and hence are mapped in the
-//     same way as the handling of individual fields in the protobuf.
+//     same way as the handling of individual fields in the protobuf by
+//     parseField.
 //  2. list types in YANG - we only support keyed lists, since these have their
 //     own valid paths. For the generated protobufs we create a new XXXKey message
 //     which is the repeated type. Scalar fields within that message are the
@@ -173,8 +262,8 @@ func parseList(fd protoreflect.FieldDescriptor, v protoreflect.Value, vals map[*
 	}
 	listPath := mapPath[0]
 
-	// TODO(robjs): This handles the case where we have a list - but not a leaf-list.
-	//              Add implementation to handle leaf lists.
+	// Leaf-lists, which are repeated ywrapper messages, are handled by
+	// parseField, such that only lists are handled here.
 	l := v.List()
 	if fd.Kind() != protoreflect.MessageKind {
 		return fmt.Errorf("invalid list, value is not a proto message, %s - is %T", fd.FullName(), l.NewElement())
@@ -299,7 +388,14 @@ func parseListField(fd protoreflect.FieldDescriptor, v protoreflect.Value, baseP
 		mappedPaths = append(mappedPaths, p)
 	}
 
-	kv, err := ygot.KeyValueAsString(v.Interface())
+	val := v.Interface()
+	if fd.Kind() == protoreflect.EnumKind {
+		if val, err = enumYANGName(fd.Enum(), v.Enum()); err != nil {
+			return nil, fmt.Errorf("cannot map list key %v, %v", v.Interface(), err)
+		}
+	}
+
+	kv, err := ygot.KeyValueAsString(val)
 	if err != nil {
 		return nil, fmt.Errorf("cannot map list key %v, %v", v.Interface(), err)
 	}
@@ -310,7 +406,7 @@ func parseListField(fd protoreflect.FieldDescriptor, v protoreflect.Value, baseP
 	}
 
 	for _, path := range mappedPaths {
-		p.mappedValues[resolvedPath(basePath, path)] = v.Interface()
+		p.mappedValues[resolvedPath(basePath, path)] = val
 	}
 	return p, nil
 }
@@ -318,8 +414,7 @@ func parseListField(fd protoreflect.FieldDescriptor, v protoreflect.Value, baseP
 // annotatedSchemaPath extracts the schemapath annotation from the supplied field descriptor,
 // parsing the included string paths into a slice of gNMI 'Path' messages.
 func annotatedSchemaPath(fd protoreflect.FieldDescriptor) ([]*gpb.Path, error) {
-	po := fd.Options().(*descriptorpb.FieldOptions)
-	ex := proto.GetExtension(po, yextpb.E_Schemapath).(string)
+	ex := schemaPathAnnotation(fd)
 	if ex == "" {
 		return nil, fmt.Errorf("received field with invalid annotation, field: %s", fd.FullName())
 	}
@@ -355,3 +450,753 @@ func resolvedPath(basePath, annotatedPath *gpb.Path) *gpb.Path {
 	np.Elem = append(np.Elem, annotatedPath.Elem[len(basePath.Elem):]...)
 	return np
 }
+
+// UnmapOpt marks that a particular option can be supplied as an argument
+// to the ProtoFromPaths or UnmarshalNotifications functions.
+type UnmapOpt interface {
+	isUnmapOpt()
+}
+
+// IgnoreExtraPaths indicates that unmapping should ignore any additional
+// paths that are found in the input values that do not map to fields of the
+// protobuf message, rather than returning an error.
+type IgnoreExtraPaths struct{}
+
+// isUnmapOpt marks IgnoreExtraPaths as an unmap option.
+func (*IgnoreExtraPaths) isUnmapOpt() {}
+
+// ValuePathPrefix indicates that the paths of the input values are relative
+// to the specified data tree path, rather than being absolute paths.
+type ValuePathPrefix struct {
+	Path *gpb.Path
+}
+
+// isUnmapOpt marks ValuePathPrefix as an unmap option.
+func (*ValuePathPrefix) isUnmapOpt() {}
+
+// ProtobufMessagePrefix specifies the data tree path of the protobuf message
+// that is being populated. It is required where the message is not the root
+// of the schema, and corresponds to a subtree below a keyed list, such that
+// the keys of the list entry can be determined.
+type ProtobufMessagePrefix struct {
+	Path *gpb.Path
+}
+
+// isUnmapOpt marks ProtobufMessagePrefix as an unmap option.
+func (*ProtobufMessagePrefix) isUnmapOpt() {}
+
+// unmapValue is an input value that is to be mapped to a field of a protobuf
+// message.
+type unmapValue struct {
+	// path is the absolute data tree path of the value.
+	path *gpb.Path
+	// val is the value itself.
+	val interface{}
+	// mapped indicates whether the value has been mapped to a field.
+	mapped bool
+}
+
+// ProtoFromPaths populates the ygen-generated protobuf message p with the
+// supplied values, which are keyed by their gNMI data tree path. The field that
+// each value is written to is determined using the yext.schemapath annotations
+// of the fields of p and its child messages. Values may be Go scalars, or gNMI
+// TypedValue messages.
+//
+// An entry is created within the repeated field corresponding to a keyed list
+// for each set of keys found in the supplied paths, and enumerated values are
+// mapped using the yext.yang_name annotations of the protobuf enum values.
+//
+// Unless the IgnoreExtraPaths option is specified, any paths which cannot be
+// mapped to a field of p are reported in the returned error, which is a
+// util.Errors containing one error per path.
+func ProtoFromPaths(p proto.Message, vals map[*gpb.Path]interface{}, opts ...UnmapOpt) error {
+	if p == nil {
+		return errors.New("nil protobuf message supplied")
+	}
+
+	var ignoreExtra bool
+	var valPrefix, msgPrefix *gpb.Path
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *IgnoreExtraPaths:
+			ignoreExtra = true
+		case *ValuePathPrefix:
+			valPrefix = v.Path
+		case *ProtobufMessagePrefix:
+			msgPrefix = v.Path
+		}
+	}
+
+	uvs := []*unmapValue{}
+	for path, v := range vals {
+		uvs = append(uvs, &unmapValue{path: joinPaths(valPrefix, path), val: v})
+	}
+	// Sort the values such that the order of list entries, and of any
+	// returned errors, is deterministic.
+	sort.Slice(uvs, func(i, j int) bool {
+		return pathString(uvs[i].path) < pathString(uvs[j].path)
+	})
+
+	if err := protoFromPathsInternal(p.ProtoReflect(), msgPrefix, uvs); err != nil {
+		return err
+	}
+
+	if ignoreExtra {
+		return nil
+	}
+	var errs util.Errors
+	for _, uv := range uvs {
+		if !uv.mapped {
+			errs = util.AppendErr(errs, fmt.Errorf("did not map path %s to a field of %s", pathString(uv.path), p.ProtoReflect().Descriptor().FullName()))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// UnmarshalNotifications populates the ygen-generated protobuf message p with
+// the updates contained in the supplied gNMI Notifications, which are applied
+// in the order that they are supplied. The deletes within a Notification are
+// applied before its updates, and remove any value that was updated by an
+// earlier Notification at or below the deleted path. The resulting values are
+// mapped into p as described for ProtoFromPaths.
+func UnmarshalNotifications(p proto.Message, ns []*gpb.Notification, opts ...UnmapOpt) error {
+	paths := map[string]*gpb.Path{}
+	vals := map[string]*gpb.TypedValue{}
+	for _, n := range ns {
+		for _, d := range n.GetDelete() {
+			dp := joinPaths(n.GetPrefix(), d)
+			for s, path := range paths {
+				if matchesPrefix(path, dp, false) {
+					delete(paths, s)
+					delete(vals, s)
+				}
+			}
+		}
+		for _, u := range n.GetUpdate() {
+			up := joinPaths(n.GetPrefix(), u.GetPath())
+			s, err := ygot.PathToString(up)
+			if err != nil {
+				return fmt.Errorf("invalid path in update, %v", err)
+			}
+			paths[s] = up
+			vals[s] = u.GetVal()
+		}
+	}
+
+	pv := map[*gpb.Path]interface{}{}
+	for s, path := range paths {
+		pv[path] = vals[s]
+	}
+	return ProtoFromPaths(p, pv, opts...)
+}
+
+// protoFromPathsInternal populates the fields of the message m, whose data
+// tree path is basePath, from the supplied values. It is called recursively
+// for each child message of m. Values that are written to a field of m, or
+// its children, are marked as being mapped.
+func protoFromPathsInternal(m protoreflect.Message, basePath *gpb.Path, uvs []*unmapValue) error {
+	fds := m.Descriptor().Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		switch {
+		case fd.IsMap():
+			return fmt.Errorf("map fields are not supported in ygen-generated protobufs, field: %s", fd.FullName())
+		case fd.Kind() == protoreflect.MessageKind && !isWrapper(fd.Message()) && fd.IsList():
+			if err := unmapList(m, fd, basePath, uvs); err != nil {
+				return err
+			}
+		case fd.Kind() == protoreflect.MessageKind && !isWrapper(fd.Message()):
+			if err := unmapContainer(m, fd, basePath, uvs); err != nil {
+				return err
+			}
+		default:
+			if err := unmapField(m, fd, basePath, uvs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unmapContainer populates the field fd of m, which is a child message that
+// corresponds to a YANG container, from the supplied values. The child message
+// is only set where at least one of its fields is populated.
+func unmapContainer(m protoreflect.Message, fd protoreflect.FieldDescriptor, basePath *gpb.Path, uvs []*unmapValue) error {
+	childPath := basePath
+	// Messages that are fields of the fake root do not have an annotated
+	// path, and hence have the same base path as their parent.
+	if schemaPathAnnotation(fd) != "" {
+		paths, err := annotatedSchemaPath(fd)
+		if err != nil {
+			return err
+		}
+		if len(paths) != 1 {
+			return fmt.Errorf("invalid container, maps to >1 schema path, field: %s", fd.FullName())
+		}
+		if childPath, err = resolvePath(basePath, paths[0]); err != nil {
+			return err
+		}
+		if !hasUnmappedValues(uvs, childPath, false) {
+			return nil
+		}
+	}
+
+	child := m.NewField(fd).Message()
+	if err := protoFromPathsInternal(child, childPath, uvs); err != nil {
+		return err
+	}
+	if isPopulated(child) {
+		m.Set(fd, protoreflect.ValueOfMessage(child))
+	}
+	return nil
+}
+
+// unmapList populates the repeated field fd of m, which corresponds to a YANG
+// keyed list, from the supplied values. An entry is appended to the field for
+// each set of keys of the list that is found in the paths of the values.
+func unmapList(m protoreflect.Message, fd protoreflect.FieldDescriptor, basePath *gpb.Path, uvs []*unmapValue) error {
+	paths, err := annotatedSchemaPath(fd)
+	if err != nil {
+		return err
+	}
+	if len(paths) != 1 {
+		return fmt.Errorf("invalid list, does not map to 1 schema path, field: %s", fd.FullName())
+	}
+	listPath, err := resolvePath(basePath, paths[0])
+	if err != nil {
+		return err
+	}
+
+	// Find the data tree paths of the list entries. Since the values are
+	// sorted by path, the entries are found in order of their keys.
+	n := len(listPath.GetElem())
+	entries := []*gpb.Path{}
+	seen := map[string]bool{}
+	for _, uv := range uvs {
+		if uv.mapped || !matchesPrefix(uv.path, listPath, true) || len(uv.path.Elem[n-1].GetKey()) == 0 {
+			continue
+		}
+		ep := &gpb.Path{Elem: uv.path.Elem[:n]}
+		if s := pathString(ep); !seen[s] {
+			seen[s] = true
+			entries = append(entries, ep)
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	l := m.Mutable(fd).List()
+	for _, ep := range entries {
+		km := l.NewElement().Message()
+		if err := unmapListEntry(km, ep, uvs); err != nil {
+			return fmt.Errorf("could not unmap list entry %s, %v", pathString(ep), err)
+		}
+		l.Append(protoreflect.ValueOfMessage(km))
+	}
+	return nil
+}
+
+// unmapListEntry populates the message km, which is the repeated key message
+// of a list, for the list entry with data tree path entryPath. The scalar
+// fields of km are populated with the keys of the entry, and its member
+// message from the values below entryPath.
+func unmapListEntry(km protoreflect.Message, entryPath *gpb.Path, uvs []*unmapValue) error {
+	keys := entryPath.Elem[len(entryPath.Elem)-1].GetKey()
+	var member protoreflect.FieldDescriptor
+	fds := km.Descriptor().Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		if fd.Kind() == protoreflect.MessageKind {
+			member = fd
+			continue
+		}
+
+		paths, err := annotatedSchemaPath(fd)
+		if err != nil {
+			return err
+		}
+		name, err := fieldName(paths[0])
+		if err != nil {
+			return err
+		}
+		kv, ok := keys[name]
+		if !ok {
+			return fmt.Errorf("missing key %s", name)
+		}
+		v, err := keyFieldValue(fd, kv)
+		if err != nil {
+			return fmt.Errorf("invalid value for key %s, %v", name, err)
+		}
+		km.Set(fd, v)
+
+		// The key leaves of the entry are represented by the key field.
+		for _, uv := range valuesAt(uvs, entryPath, paths) {
+			uv.mapped = true
+		}
+	}
+
+	if member == nil {
+		return fmt.Errorf("list key message %s does not have a member field", km.Descriptor().FullName())
+	}
+	mm := km.Mutable(member).Message()
+	return protoFromPathsInternal(mm, entryPath, uvs)
+}
+
+// unmapField populates the field fd of m, which corresponds to a YANG leaf or
+// leaf-list, from the value at its annotated path.
+func unmapField(m protoreflect.Message, fd protoreflect.FieldDescriptor, basePath *gpb.Path, uvs []*unmapValue) error {
+	paths, err := annotatedSchemaPath(fd)
+	if err != nil {
+		return err
+	}
+	vs := valuesAt(uvs, basePath, paths)
+	if len(vs) == 0 {
+		return nil
+	}
+
+	od := fd.ContainingOneof()
+	if od != nil && m.WhichOneof(od) != nil {
+		// Another field of the oneof, which corresponds to a YANG union,
+		// has already been populated.
+		return nil
+	}
+
+	var v protoreflect.Value
+	if fd.IsList() {
+		v, err = leafListValue(m.NewField(fd).List(), fd, vs[0].val)
+	} else {
+		v, err = fieldValue(fd, vs[0].val)
+	}
+	switch {
+	case err != nil && od != nil:
+		// The value may be of the type of a different field of the oneof.
+		return nil
+	case err != nil:
+		return fmt.Errorf("cannot map value at %s to field %s, %v", pathString(vs[0].path), fd.FullName(), err)
+	}
+
+	m.Set(fd, v)
+	for _, uv := range vs {
+		uv.mapped = true
+	}
+	return nil
+}
+
+// wrapperNames is the set of the names of the ywrapper messages that are used
+// to wrap scalar values within ygen-generated protobufs.
+var wrapperNames = map[protoreflect.FullName]bool{
+	"ywrapper.BoolValue":      true,
+	"ywrapper.BytesValue":     true,
+	"ywrapper.Decimal64Value": true,
+	"ywrapper.IntValue":       true,
+	"ywrapper.StringValue":    true,
+	"ywrapper.UintValue":      true,
+}
+
+// isWrapper reports whether the message described by md is a ywrapper message.
+func isWrapper(md protoreflect.MessageDescriptor) bool {
+	return wrapperNames[md.FullName()]
+}
+
+// isPopulated reports whether any field of m is populated.
+func isPopulated(m protoreflect.Message) bool {
+	var set bool
+	m.Range(func(protoreflect.FieldDescriptor, protoreflect.Value) bool {
+		set = true
+		return false
+	})
+	return set
+}
+
+// leafListValue returns the protobuf list value of the repeated field fd,
+// which corresponds to a leaf-list, populated with the elements of v. The
+// empty list l is populated and returned.
+func leafListValue(l protoreflect.List, fd protoreflect.FieldDescriptor, v interface{}) (protoreflect.Value, error) {
+	v, err := goValue(v)
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return protoreflect.Value{}, fmt.Errorf("got %T, want a slice for a leaf-list", v)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		ev, err := fieldValue(fd, rv.Index(i).Interface())
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid leaf-list element %d, %v", i, err)
+		}
+		l.Append(ev)
+	}
+	return protoreflect.ValueOfList(l), nil
+}
+
+// fieldValue returns the protobuf value of a single (non-repeated) value of
+// the field fd, populated from the input value v.
+func fieldValue(fd protoreflect.FieldDescriptor, v interface{}) (protoreflect.Value, error) {
+	v, err := goValue(v)
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return wrapperValue(fd.Message(), v)
+	case protoreflect.EnumKind:
+		n, err := enumNumber(fd.Enum(), v)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfEnum(n), nil
+	case protoreflect.StringKind:
+		s, ok := v.(string)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("got %T, want string", v)
+		}
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BoolKind:
+		b, ok := v.(bool)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("got %T, want bool", v)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.BytesKind:
+		b, ok := v.([]byte)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("got %T, want []byte", v)
+		}
+		return protoreflect.ValueOfBytes(b), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := toInt64(v, 32)
+		return protoreflect.ValueOfInt32(int32(i)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := toInt64(v, 64)
+		return protoreflect.ValueOfInt64(i), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		u, err := toUint64(v, 32)
+		return protoreflect.ValueOfUint32(uint32(u)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		u, err := toUint64(v, 64)
+		return protoreflect.ValueOfUint64(u), err
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f, err := toFloat64(v)
+		if fd.Kind() == protoreflect.FloatKind {
+			return protoreflect.ValueOfFloat32(float32(f)), err
+		}
+		return protoreflect.ValueOfFloat64(f), err
+	}
+	return protoreflect.Value{}, fmt.Errorf("unhandled field kind %s", fd.Kind())
+}
+
+// wrapperValue returns a message value of the ywrapper message type described
+// by md, wrapping the input value v.
+func wrapperValue(md protoreflect.MessageDescriptor, v interface{}) (protoreflect.Value, error) {
+	var m proto.Message
+	switch md.FullName() {
+	case "ywrapper.BoolValue":
+		b, ok := v.(bool)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("got %T, want bool", v)
+		}
+		m = &wpb.BoolValue{Value: b}
+	case "ywrapper.BytesValue":
+		b, ok := v.([]byte)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("got %T, want []byte", v)
+		}
+		m = &wpb.BytesValue{Value: b}
+	case "ywrapper.Decimal64Value":
+		d, err := toDecimal64(v)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		m = d
+	case "ywrapper.IntValue":
+		i, err := toInt64(v, 64)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		m = &wpb.IntValue{Value: i}
+	case "ywrapper.StringValue":
+		s, ok := v.(string)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("got %T, want string", v)
+		}
+		m = &wpb.StringValue{Value: s}
+	case "ywrapper.UintValue":
+		u, err := toUint64(v, 64)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		m = &wpb.UintValue{Value: u}
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unhandled message type %s", md.FullName())
+	}
+	return protoreflect.ValueOfMessage(m.ProtoReflect()), nil
+}
+
+// keyFieldValue returns the protobuf value of the list key field fd, from
+// the string value of the key within a gNMI path.
+func keyFieldValue(fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	var v interface{} = s
+	var err error
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		v, err = strconv.ParseBool(s)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err = strconv.ParseInt(s, 10, 64)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err = strconv.ParseUint(s, 10, 64)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		v, err = strconv.ParseFloat(s, 64)
+	}
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+	return fieldValue(fd, v)
+}
+
+// enumNumber returns the number of the value of the enum described by ed
+// whose yext.yang_name annotation matches the input value v, which is either
+// the YANG name of the value as a string, or a ygot-generated enum.
+func enumNumber(ed protoreflect.EnumDescriptor, v interface{}) (protoreflect.EnumNumber, error) {
+	var name string
+	switch t := v.(type) {
+	case string:
+		name = t
+	case ygot.GoEnum:
+		n, err := ygot.EnumName(t)
+		if err != nil {
+			return 0, err
+		}
+		name = n
+	default:
+		return 0, fmt.Errorf("got %T, want string or enumerated value", v)
+	}
+
+	// Identity values may be qualified with the name of their module, which
+	// is not included in the annotation.
+	for _, n := range []string{name, util.StripModulePrefix(name)} {
+		vds := ed.Values()
+		for i := 0; i < vds.Len(); i++ {
+			vd := vds.Get(i)
+			if yn := proto.GetExtension(vd.Options().(*descriptorpb.EnumValueOptions), yextpb.E_YangName).(string); yn != "" && yn == n {
+				return vd.Number(), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("enum %s has no value with YANG name %s", ed.FullName(), name)
+}
+
+// goValue returns the Go value of the input value v, converting a gNMI
+// TypedValue to the corresponding Go type. Decimal64 values are returned
+// as a gNMI Decimal64 message, and leaf-lists as a []interface{}.
+func goValue(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, errors.New("nil value")
+	}
+	tv, ok := v.(*gpb.TypedValue)
+	if !ok {
+		return v, nil
+	}
+	switch t := tv.GetValue().(type) {
+	case *gpb.TypedValue_StringVal:
+		return t.StringVal, nil
+	case *gpb.TypedValue_IntVal:
+		return t.IntVal, nil
+	case *gpb.TypedValue_UintVal:
+		return t.UintVal, nil
+	case *gpb.TypedValue_BoolVal:
+		return t.BoolVal, nil
+	case *gpb.TypedValue_BytesVal:
+		return t.BytesVal, nil
+	case *gpb.TypedValue_FloatVal:
+		return float64(t.FloatVal), nil
+	case *gpb.TypedValue_DecimalVal:
+		return t.DecimalVal, nil
+	case *gpb.TypedValue_LeaflistVal:
+		out := []interface{}{}
+		for _, e := range t.LeaflistVal.GetElement() {
+			ev, err := goValue(e)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, ev)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("unhandled TypedValue type %T", tv.GetValue())
+}
+
+// toInt64 returns the input integer value v as an int64, checking that it can
+// be represented as a signed integer of the specified number of bits.
+func toInt64(v interface{}, bits int) (int64, error) {
+	rv := reflect.ValueOf(v)
+	var i int64
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i = rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("value %d overflows int%d", rv.Uint(), bits)
+		}
+		i = int64(rv.Uint())
+	default:
+		return 0, fmt.Errorf("got %T, want integer", v)
+	}
+	if bits == 32 && (i < math.MinInt32 || i > math.MaxInt32) {
+		return 0, fmt.Errorf("value %d overflows int%d", i, bits)
+	}
+	return i, nil
+}
+
+// toUint64 returns the input integer value v as a uint64, checking that it can
+// be represented as an unsigned integer of the specified number of bits.
+func toUint64(v interface{}, bits int) (uint64, error) {
+	rv := reflect.ValueOf(v)
+	var u uint64
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u = rv.Uint()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Int() < 0 {
+			return 0, fmt.Errorf("negative value %d for uint%d", rv.Int(), bits)
+		}
+		u = uint64(rv.Int())
+	default:
+		return 0, fmt.Errorf("got %T, want integer", v)
+	}
+	if bits == 32 && u > math.MaxUint32 {
+		return 0, fmt.Errorf("value %d overflows uint%d", u, bits)
+	}
+	return u, nil
+}
+
+// toFloat64 returns the input floating point or decimal64 value v as a
+// float64.
+func toFloat64(v interface{}) (float64, error) {
+	switch t := v.(type) {
+	case float32:
+		return float64(t), nil
+	case float64:
+		return t, nil
+	case *gpb.Decimal64:
+		return float64(t.GetDigits()) / math.Pow10(int(t.GetPrecision())), nil
+	}
+	return 0, fmt.Errorf("got %T, want float", v)
+}
+
+// toDecimal64 returns the input floating point or decimal64 value v as a
+// ywrapper Decimal64Value message.
+func toDecimal64(v interface{}) (*wpb.Decimal64Value, error) {
+	switch t := v.(type) {
+	case *gpb.Decimal64:
+		return &wpb.Decimal64Value{Digits: t.GetDigits(), Precision: t.GetPrecision()}, nil
+	case float32, float64:
+		f, _ := toFloat64(t)
+		s := strconv.FormatFloat(f, 'f', -1, 64)
+		var prec uint32
+		if i := strings.IndexByte(s, '.'); i != -1 {
+			prec = uint32(len(s) - i - 1)
+			s = s[:i] + s[i+1:]
+		}
+		d, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot represent %v as a decimal64, %v", f, err)
+		}
+		return &wpb.Decimal64Value{Digits: d, Precision: prec}, nil
+	}
+	return nil, fmt.Errorf("got %T, want decimal64", v)
+}
+
+// valuesAt returns the values within uvs whose path is one of the supplied
+// annotated schema paths, resolved against basePath.
+func valuesAt(uvs []*unmapValue, basePath *gpb.Path, paths []*gpb.Path) []*unmapValue {
+	var out []*unmapValue
+	for _, p := range paths {
+		rp, err := resolvePath(basePath, p)
+		if err != nil {
+			continue
+		}
+		for _, uv := range uvs {
+			if len(uv.path.GetElem()) == len(rp.Elem) && matchesPrefix(uv.path, rp, false) {
+				out = append(out, uv)
+			}
+		}
+	}
+	return out
+}
+
+// hasUnmappedValues reports whether any value in uvs that has not yet been
+// mapped has a path that has the supplied prefix.
+func hasUnmappedValues(uvs []*unmapValue, prefix *gpb.Path, ignoreLastKeys bool) bool {
+	for _, uv := range uvs {
+		if !uv.mapped && matchesPrefix(uv.path, prefix, ignoreLastKeys) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPrefix reports whether the data tree path p has the supplied prefix.
+// If ignoreLastKeys is set, the keys of the last element of the prefix are not
+// compared, such that the paths of all entries of a list match the path of the
+// list.
+func matchesPrefix(p, prefix *gpb.Path, ignoreLastKeys bool) bool {
+	pe, xe := p.GetElem(), prefix.GetElem()
+	if len(pe) < len(xe) {
+		return false
+	}
+	for i, e := range xe {
+		if ignoreLastKeys && i == len(xe)-1 {
+			if e.GetName() != pe[i].GetName() {
+				return false
+			}
+			continue
+		}
+		if !util.PathElemsEqual(e, pe[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolvePath returns the data tree path of the annotated schema path p,
+// using the keys of the data tree path basePath. It returns an error if p is
+// not below basePath.
+func resolvePath(basePath, p *gpb.Path) (*gpb.Path, error) {
+	if len(p.GetElem()) < len(basePath.GetElem()) {
+		return nil, fmt.Errorf("annotated path %s is not below base path %s", pathString(p), pathString(basePath))
+	}
+	return resolvedPath(basePath, p), nil
+}
+
+// joinPaths returns the path formed by appending the elements of p to those
+// of prefix.
+func joinPaths(prefix, p *gpb.Path) *gpb.Path {
+	if prefix == nil {
+		return p
+	}
+	elems := append([]*gpb.PathElem{}, prefix.GetElem()...)
+	return &gpb.Path{Elem: append(elems, p.GetElem()...)}
+}
+
+// pathString returns the string representation of the path p, for use in
+// error messages.
+func pathString(p *gpb.Path) string {
+	s, err := ygot.PathToString(p)
+	if err != nil {
+		return p.String()
+	}
+	return s
+}
+
+// schemaPathAnnotation returns the value of the yext.schemapath annotation of
+// the field described by fd, or the empty string if it is not annotated.
+func schemaPathAnnotation(fd protoreflect.FieldDescriptor) string {
+	return proto.GetExtension(fd.Options().(*descriptorpb.FieldOptions), yextpb.E_Schemapath).(string)
+}
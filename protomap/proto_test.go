@@ -96,6 +96,37 @@ func TestPathsFromProtoInternal(t *testing.T) {
 			mustPath("/interfaces/interface/config/name"): "value",
 			mustPath("/interfaces/interface/name"):        "value",
 		},
+	}, {
+		desc: "enumerated value and leaf-list",
+		inMsg: &epb.ExampleMessage{
+			En:       epb.ExampleEnum_EXAMPLEENUM_VAL_ONE,
+			LeafList: []*wpb.StringValue{{Value: "one"}, {Value: "two"}},
+		},
+		wantPaths: map[*gpb.Path]interface{}{
+			mustPath("/enum"):      "VAL_ONE",
+			mustPath("/leaf-list"): []interface{}{"one", "two"},
+		},
+	}, {
+		desc: "list with enumerated key",
+		inMsg: &epb.ExampleMessage{
+			EnumList: []*epb.ExampleMessageEnumKey{{
+				Key:    epb.ExampleEnum_EXAMPLEENUM_VAL_TWO,
+				Member: &epb.EnumListMember{Value: &wpb.UintValue{Value: 2}},
+			}},
+		},
+		wantPaths: map[*gpb.Path]interface{}{
+			mustPath("/enum-list[key=VAL_TWO]/key"):          "VAL_TWO",
+			mustPath("/enum-list[key=VAL_TWO]/config/key"):   "VAL_TWO",
+			mustPath("/enum-list[key=VAL_TWO]/config/value"): uint64(2),
+		},
+	}, {
+		desc: "root message with unannotated container",
+		inMsg: &epb.Root{
+			System: &epb.System{Hostname: &wpb.StringValue{Value: "router"}},
+		},
+		wantPaths: map[*gpb.Path]interface{}{
+			mustPath("/system/config/hostname"): "router",
+		},
 	}, {
 		desc: "invalid message with a map",
 		inMsg: &epb.InvalidMessage{
@@ -283,3 +314,472 @@ func TestPathsFromProtoInternal(t *testing.T) {
 		})
 	}
 }
+
+func TestProtoFromPaths(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inProto          proto.Message
+		inVals           map[*gpb.Path]interface{}
+		inOpts           []UnmapOpt
+		wantProto        proto.Message
+		wantErrSubstring string
+	}{{
+		desc:    "simple message with a prefix",
+		inProto: &epb.Interface{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/interfaces/interface[name=eth0]/config/description"): "hello",
+		},
+		inOpts: []UnmapOpt{&ProtobufMessagePrefix{Path: mustPath("/interfaces/interface[name=eth0]")}},
+		wantProto: &epb.Interface{
+			Description: &wpb.StringValue{Value: "hello"},
+		},
+	}, {
+		desc:    "wrapper and scalar fields",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/bool"):    &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: true}},
+			mustPath("/bytes"):   []byte{1, 2, 3},
+			mustPath("/decimal"): 12.34,
+			mustPath("/int"):     &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: -42}},
+			mustPath("/string"):  "hello",
+			mustPath("/uint"):    uint32(42),
+		},
+		wantProto: &epb.ExampleMessage{
+			Bo:  &wpb.BoolValue{Value: true},
+			By:  &wpb.BytesValue{Value: []byte{1, 2, 3}},
+			De:  &wpb.Decimal64Value{Digits: 1234, Precision: 2},
+			In:  &wpb.IntValue{Value: -42},
+			Str: &wpb.StringValue{Value: "hello"},
+			Ui:  &wpb.UintValue{Value: 42},
+		},
+	}, {
+		desc:    "decimal64 typed value",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/decimal"): &gpb.TypedValue{Value: &gpb.TypedValue_DecimalVal{DecimalVal: &gpb.Decimal64{Digits: 15, Precision: 1}}},
+		},
+		wantProto: &epb.ExampleMessage{
+			De: &wpb.Decimal64Value{Digits: 15, Precision: 1},
+		},
+	}, {
+		desc:    "child message",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/message/str"): "hello",
+		},
+		wantProto: &epb.ExampleMessage{
+			Ex: &epb.ExampleMessageChild{Str: &wpb.StringValue{Value: "hello"}},
+		},
+	}, {
+		desc:    "enumerated values and leaf-list",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/enum"): "VAL_TWO",
+			mustPath("/leaf-list"): &gpb.TypedValue{Value: &gpb.TypedValue_LeaflistVal{LeaflistVal: &gpb.ScalarArray{
+				Element: []*gpb.TypedValue{
+					{Value: &gpb.TypedValue_StringVal{StringVal: "one"}},
+					{Value: &gpb.TypedValue_StringVal{StringVal: "two"}},
+				},
+			}}},
+			mustPath("/enum-list[key=VAL_ONE]/key"):          "VAL_ONE",
+			mustPath("/enum-list[key=VAL_ONE]/config/value"): uint64(1),
+			mustPath("/enum-list[key=VAL_TWO]/config/value"): uint64(2),
+		},
+		wantProto: &epb.ExampleMessage{
+			En:       epb.ExampleEnum_EXAMPLEENUM_VAL_TWO,
+			LeafList: []*wpb.StringValue{{Value: "one"}, {Value: "two"}},
+			EnumList: []*epb.ExampleMessageEnumKey{{
+				Key:    epb.ExampleEnum_EXAMPLEENUM_VAL_ONE,
+				Member: &epb.EnumListMember{Value: &wpb.UintValue{Value: 1}},
+			}, {
+				Key:    epb.ExampleEnum_EXAMPLEENUM_VAL_TWO,
+				Member: &epb.EnumListMember{Value: &wpb.UintValue{Value: 2}},
+			}},
+		},
+	}, {
+		desc:    "module-qualified enumerated value",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/enum"): "mod:VAL_ONE",
+		},
+		wantProto: &epb.ExampleMessage{
+			En: epb.ExampleEnum_EXAMPLEENUM_VAL_ONE,
+		},
+	}, {
+		desc:    "union mapped to oneof",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/union"): uint64(42),
+		},
+		wantProto: &epb.ExampleMessage{
+			Union: &epb.ExampleMessage_UnionUint64{UnionUint64: 42},
+		},
+	}, {
+		desc:    "nested lists",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/list-name[single-key=k2]/another-field"):                   "val-two",
+			mustPath("/list-name[single-key=k1]/single-key"):                      "k1",
+			mustPath("/list-name[single-key=k1]/config/single-key"):               "k1",
+			mustPath("/list-name[single-key=k1]/another-field"):                   "val-one",
+			mustPath("/list-name[single-key=k1]/child-list[key-one=key]/key-one"): "key",
+			mustPath("/list-name[single-key=k1]/child-list[key-one=key]/str"):     "two",
+		},
+		wantProto: &epb.ExampleMessage{
+			Em: []*epb.ExampleMessageKey{{
+				SingleKey: "k1",
+				Member: &epb.ExampleMessageListMember{
+					Str: &wpb.StringValue{Value: "val-one"},
+					ChildList: []*epb.NestedListKey{{
+						KeyOne: "key",
+						Field:  &epb.NestedListMember{Str: &wpb.StringValue{Value: "two"}},
+					}},
+				},
+			}, {
+				SingleKey: "k2",
+				Member: &epb.ExampleMessageListMember{
+					Str: &wpb.StringValue{Value: "val-two"},
+				},
+			}},
+		},
+	}, {
+		desc:    "list with multiple keys",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/multi-list[index=0][name=zero]/config/child"): "zero-child",
+			mustPath("/multi-list[index=0][name=zero]/config/index"): uint32(0),
+		},
+		wantProto: &epb.ExampleMessage{
+			Multi: []*epb.ExampleMessageMultiKey{{
+				Index:  0,
+				Name:   "zero",
+				Member: &epb.MultiKeyListMember{Child: &wpb.StringValue{Value: "zero-child"}},
+			}},
+		},
+	}, {
+		desc:    "root message with unannotated container",
+		inProto: &epb.Root{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("system/config/hostname"):                             "router",
+			mustPath("interfaces/interface[name=eth0]/config/description"): "uplink",
+		},
+		wantProto: &epb.Root{
+			System: &epb.System{Hostname: &wpb.StringValue{Value: "router"}},
+			Interface: []*epb.Root_InterfaceKey{{
+				Name:      "eth0",
+				Interface: &epb.Interface{Description: &wpb.StringValue{Value: "uplink"}},
+			}},
+		},
+	}, {
+		desc:    "value path prefix",
+		inProto: &epb.Root{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("config/hostname"): "router",
+		},
+		inOpts: []UnmapOpt{&ValuePathPrefix{Path: mustPath("/system")}},
+		wantProto: &epb.Root{
+			System: &epb.System{Hostname: &wpb.StringValue{Value: "router"}},
+		},
+	}, {
+		desc:    "unmapped paths",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/string"):                  "hello",
+			mustPath("/unknown"):                 "one",
+			mustPath("/list-name/another-field"): "no-keys",
+		},
+		wantErrSubstring: "did not map path /list-name/another-field to a field of exschemapath.ExampleMessage, did not map path /unknown to a field of exschemapath.ExampleMessage",
+	}, {
+		desc:    "unmapped paths ignored",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/string"):  "hello",
+			mustPath("/unknown"): "one",
+		},
+		inOpts: []UnmapOpt{&IgnoreExtraPaths{}},
+		wantProto: &epb.ExampleMessage{
+			Str: &wpb.StringValue{Value: "hello"},
+		},
+	}, {
+		desc:    "value of wrong type",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/uint"): "forty-two",
+		},
+		wantErrSubstring: "cannot map value at /uint to field exschemapath.ExampleMessage.ui, got string, want integer",
+	}, {
+		desc:    "negative value for unsigned field",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/uint"): -1,
+		},
+		wantErrSubstring: "negative value -1 for uint64",
+	}, {
+		desc:    "unknown enumerated value",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/enum"): "VAL_THREE",
+		},
+		wantErrSubstring: "enum exschemapath.ExampleEnum has no value with YANG name VAL_THREE",
+	}, {
+		desc:    "missing list key",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/multi-list[index=0]/config/child"): "zero-child",
+		},
+		wantErrSubstring: "could not unmap list entry /multi-list[index=0], missing key name",
+	}, {
+		desc:    "invalid list key value",
+		inProto: &epb.ExampleMessage{},
+		inVals: map[*gpb.Path]interface{}{
+			mustPath("/multi-list[index=minus-one][name=zero]/config/child"): "zero-child",
+		},
+		wantErrSubstring: "invalid value for key index",
+	}, {
+		desc:             "nil message",
+		wantErrSubstring: "nil protobuf message supplied",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := ProtoFromPaths(tt.inProto, tt.inVals, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tt.inProto, tt.wantProto, protocmp.Transform()); diff != "" {
+				t.Fatalf("did not get expected results, diff(-got,+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalNotifications(t *testing.T) {
+	strVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+	}
+
+	tests := []struct {
+		desc             string
+		inNotifications  []*gpb.Notification
+		inOpts           []UnmapOpt
+		wantProto        proto.Message
+		wantErrSubstring string
+	}{{
+		desc: "updates with prefix",
+		inNotifications: []*gpb.Notification{{
+			Prefix: mustPath("/interfaces/interface[name=eth0]"),
+			Update: []*gpb.Update{{
+				Path: mustPath("config/description"),
+				Val:  strVal("uplink"),
+			}, {
+				Path: mustPath("config/name"),
+				Val:  strVal("eth0"),
+			}},
+		}, {
+			Update: []*gpb.Update{{
+				Path: mustPath("/system/config/hostname"),
+				Val:  strVal("router"),
+			}},
+		}},
+		wantProto: &epb.Root{
+			System: &epb.System{Hostname: &wpb.StringValue{Value: "router"}},
+			Interface: []*epb.Root_InterfaceKey{{
+				Name:      "eth0",
+				Interface: &epb.Interface{Description: &wpb.StringValue{Value: "uplink"}},
+			}},
+		},
+	}, {
+		desc: "later updates and deletes are applied",
+		inNotifications: []*gpb.Notification{{
+			Update: []*gpb.Update{{
+				Path: mustPath("/system/config/hostname"),
+				Val:  strVal("router"),
+			}, {
+				Path: mustPath("/interfaces/interface[name=eth0]/config/description"),
+				Val:  strVal("uplink"),
+			}, {
+				Path: mustPath("/interfaces/interface[name=eth1]/config/description"),
+				Val:  strVal("downlink"),
+			}},
+		}, {
+			Delete: []*gpb.Path{mustPath("/interfaces/interface[name=eth0]")},
+			Update: []*gpb.Update{{
+				Path: mustPath("/system/config/hostname"),
+				Val:  strVal("switch"),
+			}},
+		}},
+		wantProto: &epb.Root{
+			System: &epb.System{Hostname: &wpb.StringValue{Value: "switch"}},
+			Interface: []*epb.Root_InterfaceKey{{
+				Name:      "eth1",
+				Interface: &epb.Interface{Description: &wpb.StringValue{Value: "downlink"}},
+			}},
+		},
+	}, {
+		desc: "unmapped update",
+		inNotifications: []*gpb.Notification{{
+			Update: []*gpb.Update{{
+				Path: mustPath("/system/config/domain-name"),
+				Val:  strVal("example.com"),
+			}},
+		}},
+		wantErrSubstring: "did not map path /system/config/domain-name",
+	}, {
+		desc: "invalid update path",
+		inNotifications: []*gpb.Notification{{
+			Update: []*gpb.Update{{
+				Path: &gpb.Path{Elem: []*gpb.PathElem{{}}},
+				Val:  strVal("example.com"),
+			}},
+		}},
+		wantErrSubstring: "invalid path in update",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &epb.Root{}
+			err := UnmarshalNotifications(got, tt.inNotifications, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff(got, tt.wantProto, protocmp.Transform()); diff != "" {
+				t.Fatalf("did not get expected results, diff(-got,+want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTogNMINotifications(t *testing.T) {
+	strVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+	}
+
+	tests := []struct {
+		desc              string
+		inMsg             proto.Message
+		inTimestamp       int64
+		inPrefix          *gpb.Path
+		wantNotifications []*gpb.Notification
+		wantErrSubstring  string
+	}{{
+		desc: "root message",
+		inMsg: &epb.Root{
+			System: &epb.System{Hostname: &wpb.StringValue{Value: "router"}},
+			Interface: []*epb.Root_InterfaceKey{{
+				Name:      "eth0",
+				Interface: &epb.Interface{Description: &wpb.StringValue{Value: "uplink"}},
+			}},
+		},
+		inTimestamp: 42,
+		wantNotifications: []*gpb.Notification{{
+			Timestamp: 42,
+			Update: []*gpb.Update{{
+				Path: mustPath("/interfaces/interface[name=eth0]/config/description"),
+				Val:  strVal("uplink"),
+			}, {
+				Path: mustPath("/interfaces/interface[name=eth0]/config/name"),
+				Val:  strVal("eth0"),
+			}, {
+				Path: mustPath("/interfaces/interface[name=eth0]/name"),
+				Val:  strVal("eth0"),
+			}, {
+				Path: mustPath("/system/config/hostname"),
+				Val:  strVal("router"),
+			}},
+		}},
+	}, {
+		desc: "message with prefix",
+		inMsg: &epb.Interface{
+			Description: &wpb.StringValue{Value: "uplink"},
+		},
+		inTimestamp: 42,
+		inPrefix:    mustPath("/interfaces/interface[name=eth0]"),
+		wantNotifications: []*gpb.Notification{{
+			Timestamp: 42,
+			Prefix:    mustPath("/interfaces/interface[name=eth0]"),
+			Update: []*gpb.Update{{
+				Path: mustPath("config/description"),
+				Val:  strVal("uplink"),
+			}},
+		}},
+	}, {
+		desc: "scalar, enumerated and leaf-list values",
+		inMsg: &epb.ExampleMessage{
+			Bo:       &wpb.BoolValue{Value: true},
+			In:       &wpb.IntValue{Value: -42},
+			Ui:       &wpb.UintValue{Value: 42},
+			En:       epb.ExampleEnum_EXAMPLEENUM_VAL_TWO,
+			LeafList: []*wpb.StringValue{{Value: "one"}},
+			Multi: []*epb.ExampleMessageMultiKey{{
+				Index:  1,
+				Name:   "one",
+				Member: &epb.MultiKeyListMember{Child: &wpb.StringValue{Value: "child"}},
+			}},
+		},
+		wantNotifications: []*gpb.Notification{{
+			Update: []*gpb.Update{{
+				Path: mustPath("/bool"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: true}},
+			}, {
+				Path: mustPath("/enum"),
+				Val:  strVal("VAL_TWO"),
+			}, {
+				Path: mustPath("/int"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: -42}},
+			}, {
+				Path: mustPath("/leaf-list"),
+				Val: &gpb.TypedValue{Value: &gpb.TypedValue_LeaflistVal{LeaflistVal: &gpb.ScalarArray{
+					Element: []*gpb.TypedValue{strVal("one")},
+				}}},
+			}, {
+				Path: mustPath("/multi-list[index=1][name=one]/config/child"),
+				Val:  strVal("child"),
+			}, {
+				Path: mustPath("/multi-list[index=1][name=one]/config/index"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 1}},
+			}, {
+				Path: mustPath("/multi-list[index=1][name=one]/config/name"),
+				Val:  strVal("one"),
+			}, {
+				Path: mustPath("/multi-list[index=1][name=one]/index"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 1}},
+			}, {
+				Path: mustPath("/multi-list[index=1][name=one]/name"),
+				Val:  strVal("one"),
+			}, {
+				Path: mustPath("/uint"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 42}},
+			}},
+		}},
+	}, {
+		desc: "unsupported field",
+		inMsg: &epb.ExampleMessage{
+			De: &wpb.Decimal64Value{Digits: 1234, Precision: 1},
+		},
+		wantErrSubstring: "unhandled type, decimal64",
+	}, {
+		desc:             "nil message",
+		wantErrSubstring: "nil protobuf message supplied",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := TogNMINotifications(tt.inMsg, tt.inTimestamp, tt.inPrefix)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+
+			if diff := cmp.Diff(got, tt.wantNotifications, protocmp.Transform()); diff != "" {
+				t.Fatalf("did not get expected results, diff(-got,+want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -27,6 +27,55 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type ExampleEnum int32
+
+const (
+	ExampleEnum_EXAMPLEENUM_UNSET   ExampleEnum = 0
+	ExampleEnum_EXAMPLEENUM_VAL_ONE ExampleEnum = 1
+	ExampleEnum_EXAMPLEENUM_VAL_TWO ExampleEnum = 2
+)
+
+// Enum value maps for ExampleEnum.
+var (
+	ExampleEnum_name = map[int32]string{
+		0: "EXAMPLEENUM_UNSET",
+		1: "EXAMPLEENUM_VAL_ONE",
+		2: "EXAMPLEENUM_VAL_TWO",
+	}
+	ExampleEnum_value = map[string]int32{
+		"EXAMPLEENUM_UNSET":   0,
+		"EXAMPLEENUM_VAL_ONE": 1,
+		"EXAMPLEENUM_VAL_TWO": 2,
+	}
+)
+
+func (x ExampleEnum) Enum() *ExampleEnum {
+	p := new(ExampleEnum)
+	*p = x
+	return p
+}
+
+func (x ExampleEnum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExampleEnum) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_enumTypes[0].Descriptor()
+}
+
+func (ExampleEnum) Type() protoreflect.EnumType {
+	return &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_enumTypes[0]
+}
+
+func (x ExampleEnum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExampleEnum.Descriptor instead.
+func (ExampleEnum) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{0}
+}
+
 type Root struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -181,15 +230,22 @@ type ExampleMessage struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Bo    *ywrapper.BoolValue       `protobuf:"bytes,1,opt,name=bo,proto3" json:"bo,omitempty"`
-	By    *ywrapper.BytesValue      `protobuf:"bytes,2,opt,name=by,proto3" json:"by,omitempty"`
-	De    *ywrapper.Decimal64Value  `protobuf:"bytes,3,opt,name=de,proto3" json:"de,omitempty"`
-	In    *ywrapper.IntValue        `protobuf:"bytes,4,opt,name=in,proto3" json:"in,omitempty"`
-	Str   *ywrapper.StringValue     `protobuf:"bytes,5,opt,name=str,proto3" json:"str,omitempty"`
-	Ui    *ywrapper.UintValue       `protobuf:"bytes,6,opt,name=ui,proto3" json:"ui,omitempty"`
-	Ex    *ExampleMessageChild      `protobuf:"bytes,7,opt,name=ex,proto3" json:"ex,omitempty"`
-	Em    []*ExampleMessageKey      `protobuf:"bytes,8,rep,name=em,proto3" json:"em,omitempty"`
-	Multi []*ExampleMessageMultiKey `protobuf:"bytes,9,rep,name=multi,proto3" json:"multi,omitempty"`
+	Bo       *ywrapper.BoolValue       `protobuf:"bytes,1,opt,name=bo,proto3" json:"bo,omitempty"`
+	By       *ywrapper.BytesValue      `protobuf:"bytes,2,opt,name=by,proto3" json:"by,omitempty"`
+	De       *ywrapper.Decimal64Value  `protobuf:"bytes,3,opt,name=de,proto3" json:"de,omitempty"`
+	In       *ywrapper.IntValue        `protobuf:"bytes,4,opt,name=in,proto3" json:"in,omitempty"`
+	Str      *ywrapper.StringValue     `protobuf:"bytes,5,opt,name=str,proto3" json:"str,omitempty"`
+	Ui       *ywrapper.UintValue       `protobuf:"bytes,6,opt,name=ui,proto3" json:"ui,omitempty"`
+	Ex       *ExampleMessageChild      `protobuf:"bytes,7,opt,name=ex,proto3" json:"ex,omitempty"`
+	Em       []*ExampleMessageKey      `protobuf:"bytes,8,rep,name=em,proto3" json:"em,omitempty"`
+	Multi    []*ExampleMessageMultiKey `protobuf:"bytes,9,rep,name=multi,proto3" json:"multi,omitempty"`
+	En       ExampleEnum               `protobuf:"varint,10,opt,name=en,proto3,enum=exschemapath.ExampleEnum" json:"en,omitempty"`
+	LeafList []*ywrapper.StringValue   `protobuf:"bytes,11,rep,name=leaf_list,json=leafList,proto3" json:"leaf_list,omitempty"`
+	EnumList []*ExampleMessageEnumKey  `protobuf:"bytes,12,rep,name=enum_list,json=enumList,proto3" json:"enum_list,omitempty"`
+	// Types that are assignable to Union:
+	//	*ExampleMessage_UnionString
+	//	*ExampleMessage_UnionUint64
+	Union isExampleMessage_Union `protobuf_oneof:"union"`
 }
 
 func (x *ExampleMessage) Reset() {
@@ -287,6 +343,166 @@ func (x *ExampleMessage) GetMulti() []*ExampleMessageMultiKey {
 	return nil
 }
 
+func (x *ExampleMessage) GetEn() ExampleEnum {
+	if x != nil {
+		return x.En
+	}
+	return ExampleEnum_EXAMPLEENUM_UNSET
+}
+
+func (x *ExampleMessage) GetLeafList() []*ywrapper.StringValue {
+	if x != nil {
+		return x.LeafList
+	}
+	return nil
+}
+
+func (x *ExampleMessage) GetEnumList() []*ExampleMessageEnumKey {
+	if x != nil {
+		return x.EnumList
+	}
+	return nil
+}
+
+func (m *ExampleMessage) GetUnion() isExampleMessage_Union {
+	if m != nil {
+		return m.Union
+	}
+	return nil
+}
+
+func (x *ExampleMessage) GetUnionString() string {
+	if x, ok := x.GetUnion().(*ExampleMessage_UnionString); ok {
+		return x.UnionString
+	}
+	return ""
+}
+
+func (x *ExampleMessage) GetUnionUint64() uint64 {
+	if x, ok := x.GetUnion().(*ExampleMessage_UnionUint64); ok {
+		return x.UnionUint64
+	}
+	return 0
+}
+
+type isExampleMessage_Union interface {
+	isExampleMessage_Union()
+}
+
+type ExampleMessage_UnionString struct {
+	UnionString string `protobuf:"bytes,13,opt,name=union_string,json=unionString,proto3,oneof"`
+}
+
+type ExampleMessage_UnionUint64 struct {
+	UnionUint64 uint64 `protobuf:"varint,14,opt,name=union_uint64,json=unionUint64,proto3,oneof"`
+}
+
+func (*ExampleMessage_UnionString) isExampleMessage_Union() {}
+
+func (*ExampleMessage_UnionUint64) isExampleMessage_Union() {}
+
+type ExampleMessageEnumKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    ExampleEnum     `protobuf:"varint,1,opt,name=key,proto3,enum=exschemapath.ExampleEnum" json:"key,omitempty"`
+	Member *EnumListMember `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+}
+
+func (x *ExampleMessageEnumKey) Reset() {
+	*x = ExampleMessageEnumKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleMessageEnumKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleMessageEnumKey) ProtoMessage() {}
+
+func (x *ExampleMessageEnumKey) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleMessageEnumKey.ProtoReflect.Descriptor instead.
+func (*ExampleMessageEnumKey) Descriptor() ([]byte, []int) {
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExampleMessageEnumKey) GetKey() ExampleEnum {
+	if x != nil {
+		return x.Key
+	}
+	return ExampleEnum_EXAMPLEENUM_UNSET
+}
+
+func (x *ExampleMessageEnumKey) GetMember() *EnumListMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type EnumListMember struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *ywrapper.UintValue `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *EnumListMember) Reset() {
+	*x = EnumListMember{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnumListMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnumListMember) ProtoMessage() {}
+
+func (x *EnumListMember) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnumListMember.ProtoReflect.Descriptor instead.
+func (*EnumListMember) Descriptor() ([]byte, []int) {
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EnumListMember) GetValue() *ywrapper.UintValue {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
 type ExampleMessageChild struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -298,7 +514,7 @@ type ExampleMessageChild struct {
 func (x *ExampleMessageChild) Reset() {
 	*x = ExampleMessageChild{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[4]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -311,7 +527,7 @@ func (x *ExampleMessageChild) String() string {
 func (*ExampleMessageChild) ProtoMessage() {}
 
 func (x *ExampleMessageChild) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[4]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -324,7 +540,7 @@ func (x *ExampleMessageChild) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExampleMessageChild.ProtoReflect.Descriptor instead.
 func (*ExampleMessageChild) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{4}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ExampleMessageChild) GetStr() *ywrapper.StringValue {
@@ -346,7 +562,7 @@ type ExampleMessageKey struct {
 func (x *ExampleMessageKey) Reset() {
 	*x = ExampleMessageKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[5]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -359,7 +575,7 @@ func (x *ExampleMessageKey) String() string {
 func (*ExampleMessageKey) ProtoMessage() {}
 
 func (x *ExampleMessageKey) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[5]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -372,7 +588,7 @@ func (x *ExampleMessageKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExampleMessageKey.ProtoReflect.Descriptor instead.
 func (*ExampleMessageKey) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{5}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ExampleMessageKey) GetSingleKey() string {
@@ -401,7 +617,7 @@ type ExampleMessageListMember struct {
 func (x *ExampleMessageListMember) Reset() {
 	*x = ExampleMessageListMember{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[6]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -414,7 +630,7 @@ func (x *ExampleMessageListMember) String() string {
 func (*ExampleMessageListMember) ProtoMessage() {}
 
 func (x *ExampleMessageListMember) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[6]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -427,7 +643,7 @@ func (x *ExampleMessageListMember) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExampleMessageListMember.ProtoReflect.Descriptor instead.
 func (*ExampleMessageListMember) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{6}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ExampleMessageListMember) GetStr() *ywrapper.StringValue {
@@ -456,7 +672,7 @@ type NestedListKey struct {
 func (x *NestedListKey) Reset() {
 	*x = NestedListKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[7]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -469,7 +685,7 @@ func (x *NestedListKey) String() string {
 func (*NestedListKey) ProtoMessage() {}
 
 func (x *NestedListKey) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[7]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,7 +698,7 @@ func (x *NestedListKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NestedListKey.ProtoReflect.Descriptor instead.
 func (*NestedListKey) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{7}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *NestedListKey) GetKeyOne() string {
@@ -510,7 +726,7 @@ type NestedListMember struct {
 func (x *NestedListMember) Reset() {
 	*x = NestedListMember{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[8]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -523,7 +739,7 @@ func (x *NestedListMember) String() string {
 func (*NestedListMember) ProtoMessage() {}
 
 func (x *NestedListMember) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[8]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -536,7 +752,7 @@ func (x *NestedListMember) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NestedListMember.ProtoReflect.Descriptor instead.
 func (*NestedListMember) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{8}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *NestedListMember) GetStr() *ywrapper.StringValue {
@@ -559,7 +775,7 @@ type ExampleMessageMultiKey struct {
 func (x *ExampleMessageMultiKey) Reset() {
 	*x = ExampleMessageMultiKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[9]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -572,7 +788,7 @@ func (x *ExampleMessageMultiKey) String() string {
 func (*ExampleMessageMultiKey) ProtoMessage() {}
 
 func (x *ExampleMessageMultiKey) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[9]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -585,7 +801,7 @@ func (x *ExampleMessageMultiKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExampleMessageMultiKey.ProtoReflect.Descriptor instead.
 func (*ExampleMessageMultiKey) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{9}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ExampleMessageMultiKey) GetIndex() uint32 {
@@ -620,7 +836,7 @@ type MultiKeyListMember struct {
 func (x *MultiKeyListMember) Reset() {
 	*x = MultiKeyListMember{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[10]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -633,7 +849,7 @@ func (x *MultiKeyListMember) String() string {
 func (*MultiKeyListMember) ProtoMessage() {}
 
 func (x *MultiKeyListMember) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[10]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -646,7 +862,7 @@ func (x *MultiKeyListMember) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MultiKeyListMember.ProtoReflect.Descriptor instead.
 func (*MultiKeyListMember) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{10}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *MultiKeyListMember) GetChild() *ywrapper.StringValue {
@@ -677,7 +893,7 @@ type InvalidMessage struct {
 func (x *InvalidMessage) Reset() {
 	*x = InvalidMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[11]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -690,7 +906,7 @@ func (x *InvalidMessage) String() string {
 func (*InvalidMessage) ProtoMessage() {}
 
 func (x *InvalidMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[11]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -703,7 +919,7 @@ func (x *InvalidMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvalidMessage.ProtoReflect.Descriptor instead.
 func (*InvalidMessage) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{11}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *InvalidMessage) GetMapField() map[string]string {
@@ -794,7 +1010,7 @@ type BadMessageKeyTwo struct {
 func (x *BadMessageKeyTwo) Reset() {
 	*x = BadMessageKeyTwo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[12]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -807,7 +1023,7 @@ func (x *BadMessageKeyTwo) String() string {
 func (*BadMessageKeyTwo) ProtoMessage() {}
 
 func (x *BadMessageKeyTwo) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[12]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -820,7 +1036,7 @@ func (x *BadMessageKeyTwo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BadMessageKeyTwo.ProtoReflect.Descriptor instead.
 func (*BadMessageKeyTwo) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{12}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *BadMessageKeyTwo) GetKey() string {
@@ -841,7 +1057,7 @@ type BadMessageKey struct {
 func (x *BadMessageKey) Reset() {
 	*x = BadMessageKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[13]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -854,7 +1070,7 @@ func (x *BadMessageKey) String() string {
 func (*BadMessageKey) ProtoMessage() {}
 
 func (x *BadMessageKey) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[13]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -867,7 +1083,7 @@ func (x *BadMessageKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BadMessageKey.ProtoReflect.Descriptor instead.
 func (*BadMessageKey) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{13}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *BadMessageKey) GetBadKeyType() float32 {
@@ -889,7 +1105,7 @@ type BadMessageMember struct {
 func (x *BadMessageMember) Reset() {
 	*x = BadMessageMember{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[14]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -902,7 +1118,7 @@ func (x *BadMessageMember) String() string {
 func (*BadMessageMember) ProtoMessage() {}
 
 func (x *BadMessageMember) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[14]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -915,7 +1131,7 @@ func (x *BadMessageMember) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BadMessageMember.ProtoReflect.Descriptor instead.
 func (*BadMessageMember) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{14}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *BadMessageMember) GetKey() string {
@@ -943,7 +1159,7 @@ type BadKeyPathMessage struct {
 func (x *BadKeyPathMessage) Reset() {
 	*x = BadKeyPathMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[15]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -956,7 +1172,7 @@ func (x *BadKeyPathMessage) String() string {
 func (*BadKeyPathMessage) ProtoMessage() {}
 
 func (x *BadKeyPathMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[15]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -969,7 +1185,7 @@ func (x *BadKeyPathMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BadKeyPathMessage.ProtoReflect.Descriptor instead.
 func (*BadKeyPathMessage) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{15}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *BadKeyPathMessage) GetKey() string {
@@ -990,7 +1206,7 @@ type InvalidKeyPathKey struct {
 func (x *InvalidKeyPathKey) Reset() {
 	*x = InvalidKeyPathKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[16]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1003,7 +1219,7 @@ func (x *InvalidKeyPathKey) String() string {
 func (*InvalidKeyPathKey) ProtoMessage() {}
 
 func (x *InvalidKeyPathKey) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[16]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1016,7 +1232,7 @@ func (x *InvalidKeyPathKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvalidKeyPathKey.ProtoReflect.Descriptor instead.
 func (*InvalidKeyPathKey) Descriptor() ([]byte, []int) {
-	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{16}
+	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *InvalidKeyPathKey) GetKey() string {
@@ -1038,7 +1254,7 @@ type Root_InterfaceKey struct {
 func (x *Root_InterfaceKey) Reset() {
 	*x = Root_InterfaceKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[17]
+		mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1051,7 +1267,7 @@ func (x *Root_InterfaceKey) String() string {
 func (*Root_InterfaceKey) ProtoMessage() {}
 
 func (x *Root_InterfaceKey) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[17]
+	mi := &file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1128,7 +1344,7 @@ var file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_
 	0x70, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42,
 	0x1a, 0x82, 0x41, 0x17, 0x2f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x66,
 	0x69, 0x67, 0x2f, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x08, 0x68, 0x6f, 0x73,
-	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x84, 0x04, 0x0a, 0x0e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xb6, 0x06, 0x0a, 0x0e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c,
 	0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x02, 0x62, 0x6f, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x79, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x2e,
 	0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x08, 0x82, 0x41, 0x05, 0x2f, 0x62,
@@ -1160,143 +1376,185 @@ var file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_
 	0x24, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x45,
 	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x75, 0x6c,
 	0x74, 0x69, 0x4b, 0x65, 0x79, 0x42, 0x0e, 0x82, 0x41, 0x0b, 0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69,
-	0x2d, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x05, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x22, 0x4f, 0x0a, 0x13,
-	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x68,
-	0x69, 0x6c, 0x64, 0x12, 0x38, 0x0a, 0x03, 0x73, 0x74, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x79, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0f, 0x82, 0x41, 0x0c, 0x2f, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x2f, 0x73, 0x74, 0x72, 0x52, 0x03, 0x73, 0x74, 0x72, 0x22, 0xa9, 0x01,
-	0x0a, 0x11, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x4b, 0x65, 0x79, 0x12, 0x54, 0x0a, 0x0a, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x35, 0x82, 0x41, 0x32, 0x2f, 0x6c, 0x69, 0x73,
-	0x74, 0x2d, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x2d, 0x6b, 0x65,
-	0x79, 0x7c, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x2d, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x2f, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x2d, 0x6b, 0x65, 0x79, 0x52, 0x09,
-	0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x3e, 0x0a, 0x06, 0x6d, 0x65, 0x6d,
-	0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x65, 0x78, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65,
-	0x72, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xb6, 0x01, 0x0a, 0x18, 0x45, 0x78,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x73, 0x74,
-	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x03, 0x73, 0x74, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x79, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x2e, 0x53,
-	0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x1b, 0x82, 0x41, 0x18, 0x2f,
-	0x6c, 0x69, 0x73, 0x74, 0x2d, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x61, 0x6e, 0x6f, 0x74, 0x68, 0x65,
-	0x72, 0x2d, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x03, 0x73, 0x74, 0x72, 0x12, 0x54, 0x0a, 0x0a,
-	0x63, 0x68, 0x69, 0x6c, 0x64, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1b, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e,
-	0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x4b, 0x65, 0x79, 0x42, 0x18, 0x82,
-	0x41, 0x15, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x2d, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x63, 0x68, 0x69,
-	0x6c, 0x64, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x09, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x4c, 0x69,
-	0x73, 0x74, 0x22, 0x80, 0x01, 0x0a, 0x0d, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4c, 0x69, 0x73,
-	0x74, 0x4b, 0x65, 0x79, 0x12, 0x39, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x5f, 0x6f, 0x6e, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x20, 0x82, 0x41, 0x1d, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x2d,
-	0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f,
-	0x6b, 0x65, 0x79, 0x2d, 0x6f, 0x6e, 0x65, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x4f, 0x6e, 0x65, 0x12,
-	0x34, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
-	0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x4e, 0x65,
-	0x73, 0x74, 0x65, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x05,
-	0x66, 0x69, 0x65, 0x6c, 0x64, 0x22, 0x59, 0x0a, 0x10, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4c,
-	0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x45, 0x0a, 0x03, 0x73, 0x74, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x79, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65,
-	0x72, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x1c, 0x82,
-	0x41, 0x19, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x2d, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x63, 0x68, 0x69,
-	0x6c, 0x64, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f, 0x73, 0x74, 0x72, 0x52, 0x03, 0x73, 0x74, 0x72,
-	0x22, 0xd8, 0x01, 0x0a, 0x16, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4b, 0x65, 0x79, 0x12, 0x43, 0x0a, 0x05, 0x69,
-	0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x2d, 0x82, 0x41, 0x2a, 0x2f,
-	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f, 0x69, 0x6e, 0x64, 0x65, 0x78,
-	0x7c, 0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x2f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
-	0x12, 0x3f, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2b,
-	0x82, 0x41, 0x28, 0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f, 0x6e,
-	0x61, 0x6d, 0x65, 0x7c, 0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x38, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x20, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68,
-	0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d,
-	0x62, 0x65, 0x72, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x5e, 0x0a, 0x12, 0x4d,
-	0x75, 0x6c, 0x74, 0x69, 0x4b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65,
-	0x72, 0x12, 0x48, 0x0a, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x79, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x1b, 0x82, 0x41, 0x18, 0x2f, 0x6d, 0x75, 0x6c,
-	0x74, 0x69, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x63,
-	0x68, 0x69, 0x6c, 0x64, 0x52, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x22, 0xb1, 0x06, 0x0a, 0x0e,
-	0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x5d,
-	0x0a, 0x09, 0x6d, 0x61, 0x70, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x2a, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68,
-	0x2e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
-	0x4d, 0x61, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x14, 0x82,
-	0x41, 0x11, 0x2f, 0x61, 0x6e, 0x2f, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x2f, 0x66, 0x69,
-	0x65, 0x6c, 0x64, 0x52, 0x08, 0x6d, 0x61, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x23, 0x0a,
-	0x0d, 0x6e, 0x6f, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x6f, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x02, 0x6b, 0x6d, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
-	0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x45, 0x78,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x42,
-	0x0c, 0x82, 0x41, 0x09, 0x2f, 0x6f, 0x6e, 0x65, 0x7c, 0x2f, 0x74, 0x77, 0x6f, 0x52, 0x02, 0x6b,
-	0x6d, 0x12, 0x19, 0x0a, 0x02, 0x6b, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x42, 0x09, 0x82,
-	0x41, 0x06, 0x2f, 0x74, 0x68, 0x72, 0x65, 0x65, 0x52, 0x02, 0x6b, 0x65, 0x12, 0x35, 0x0a, 0x02,
-	0x62, 0x6b, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x42, 0x61, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x4b, 0x65, 0x79, 0x42, 0x08, 0x82, 0x41, 0x05, 0x2f, 0x66, 0x6f, 0x75, 0x72, 0x52,
-	0x02, 0x62, 0x6b, 0x12, 0x38, 0x0a, 0x02, 0x62, 0x6d, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1e, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x42,
-	0x61, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x42,
-	0x08, 0x82, 0x41, 0x05, 0x2f, 0x66, 0x69, 0x76, 0x65, 0x52, 0x02, 0x62, 0x6d, 0x12, 0x59, 0x0a,
-	0x16, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
-	0x65, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
-	0x79, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x42, 0x0c, 0x82, 0x41, 0x09, 0x2f, 0x6f, 0x6e, 0x65, 0x5b, 0x74, 0x77,
-	0x6f, 0x5d, 0x52, 0x14, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x6e, 0x6e, 0x6f, 0x74,
-	0x61, 0x74, 0x65, 0x64, 0x50, 0x61, 0x74, 0x68, 0x12, 0x3e, 0x0a, 0x06, 0x62, 0x6b, 0x5f, 0x74,
-	0x77, 0x6f, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x42, 0x61, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x4b, 0x65, 0x79, 0x54, 0x77, 0x6f, 0x42, 0x07, 0x82, 0x41, 0x04, 0x2f, 0x73, 0x69,
-	0x78, 0x52, 0x05, 0x62, 0x6b, 0x54, 0x77, 0x6f, 0x12, 0x79, 0x0a, 0x22, 0x6d, 0x75, 0x6c, 0x74,
-	0x69, 0x70, 0x6c, 0x65, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x09,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70,
-	0x61, 0x74, 0x68, 0x2e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x42, 0x0e, 0x82, 0x41, 0x0b, 0x2f, 0x73, 0x69, 0x78, 0x7c, 0x2f, 0x73, 0x65, 0x76,
-	0x65, 0x6e, 0x52, 0x1f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x41, 0x6e, 0x6e, 0x6f,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x46, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
-	0x6e, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x04, 0x62, 0x6b, 0x70, 0x6d, 0x18, 0x0a, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1f, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68,
-	0x2e, 0x42, 0x61, 0x64, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x74, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x42, 0x09, 0x82, 0x41, 0x06, 0x2f, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x04, 0x62,
-	0x6b, 0x70, 0x6d, 0x12, 0x3d, 0x0a, 0x04, 0x69, 0x6b, 0x70, 0x6b, 0x18, 0x0b, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1f, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68,
-	0x2e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x74, 0x68, 0x4b,
-	0x65, 0x79, 0x42, 0x08, 0x82, 0x41, 0x05, 0x2f, 0x6e, 0x69, 0x6e, 0x65, 0x52, 0x04, 0x69, 0x6b,
-	0x70, 0x6b, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x61, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0x36, 0x0a, 0x10, 0x42, 0x61, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79,
-	0x54, 0x77, 0x6f, 0x12, 0x22, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x42, 0x10, 0x82, 0x41, 0x0d, 0x2f, 0x6f, 0x6e, 0x65, 0x7c, 0x2f, 0x6f, 0x6e, 0x65, 0x2f, 0x74,
-	0x77, 0x6f, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x3f, 0x0a, 0x0d, 0x42, 0x61, 0x64, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x0c, 0x62, 0x61, 0x64, 0x5f,
-	0x6b, 0x65, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x42, 0x0c,
-	0x82, 0x41, 0x09, 0x2f, 0x66, 0x6f, 0x75, 0x72, 0x2f, 0x6b, 0x65, 0x79, 0x52, 0x0a, 0x62, 0x61,
-	0x64, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x22, 0x58, 0x0a, 0x10, 0x42, 0x61, 0x64, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x82, 0x41, 0x07, 0x2f, 0x6f,
-	0x6b, 0x2d, 0x6b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x08, 0x62, 0x61,
-	0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0b, 0x82, 0x41,
-	0x08, 0x2f, 0x62, 0x61, 0x64, 0x2d, 0x6b, 0x65, 0x79, 0x52, 0x07, 0x62, 0x61, 0x64, 0x54, 0x79,
-	0x70, 0x65, 0x22, 0x2b, 0x0a, 0x11, 0x42, 0x61, 0x64, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x74, 0x68,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x42, 0x04, 0x82, 0x41, 0x01, 0x2f, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22,
-	0x33, 0x0a, 0x11, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x74,
-	0x68, 0x4b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x42, 0x0c, 0x82, 0x41, 0x09, 0x2f, 0x6f, 0x6e, 0x65, 0x5b, 0x74, 0x77, 0x6f, 0x5d, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x79, 0x67,
-	0x6f, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6d, 0x61, 0x70, 0x2f, 0x74, 0x65, 0x73, 0x74,
-	0x64, 0x61, 0x74, 0x61, 0x2f, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74,
-	0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2d, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x05, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x12, 0x33, 0x0a, 0x02,
+	0x65, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x45,
+	0x6e, 0x75, 0x6d, 0x42, 0x08, 0x82, 0x41, 0x05, 0x2f, 0x65, 0x6e, 0x75, 0x6d, 0x52, 0x02, 0x65,
+	0x6e, 0x12, 0x41, 0x0a, 0x09, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x0b,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x79, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x2e,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0d, 0x82, 0x41, 0x0a,
+	0x2f, 0x6c, 0x65, 0x61, 0x66, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x08, 0x6c, 0x65, 0x61, 0x66,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x4f, 0x0a, 0x09, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x6c, 0x69, 0x73,
+	0x74, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x4b, 0x65, 0x79, 0x42, 0x0d, 0x82, 0x41,
+	0x0a, 0x2f, 0x65, 0x6e, 0x75, 0x6d, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x08, 0x65, 0x6e, 0x75,
+	0x6d, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x0c, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x5f, 0x73,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x42, 0x09, 0x82, 0x41, 0x06,
+	0x2f, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x2e, 0x0a, 0x0c, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x5f, 0x75,
+	0x69, 0x6e, 0x74, 0x36, 0x34, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0x82, 0x41, 0x06,
+	0x2f, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x55,
+	0x69, 0x6e, 0x74, 0x36, 0x34, 0x42, 0x07, 0x0a, 0x05, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0xa3,
+	0x01, 0x0a, 0x15, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x45, 0x6e, 0x75, 0x6d, 0x4b, 0x65, 0x79, 0x12, 0x54, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x70, 0x61, 0x74, 0x68, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x6e, 0x75, 0x6d,
+	0x42, 0x27, 0x82, 0x41, 0x24, 0x2f, 0x65, 0x6e, 0x75, 0x6d, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f,
+	0x6b, 0x65, 0x79, 0x7c, 0x2f, 0x65, 0x6e, 0x75, 0x6d, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x6b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x34,
+	0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x45, 0x6e,
+	0x75, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x06, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x22, 0x57, 0x0a, 0x0e, 0x45, 0x6e, 0x75, 0x6d, 0x4c, 0x69, 0x73, 0x74,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x45, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x79, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72,
+	0x2e, 0x55, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x1a, 0x82, 0x41, 0x17, 0x2f,
+	0x65, 0x6e, 0x75, 0x6d, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4f, 0x0a,
+	0x13, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43,
+	0x68, 0x69, 0x6c, 0x64, 0x12, 0x38, 0x0a, 0x03, 0x73, 0x74, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x79, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0f, 0x82, 0x41, 0x0c, 0x2f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2f, 0x73, 0x74, 0x72, 0x52, 0x03, 0x73, 0x74, 0x72, 0x22, 0xa9,
+	0x01, 0x0a, 0x11, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x4b, 0x65, 0x79, 0x12, 0x54, 0x0a, 0x0a, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x35, 0x82, 0x41, 0x32, 0x2f, 0x6c, 0x69,
+	0x73, 0x74, 0x2d, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x2d, 0x6b,
+	0x65, 0x79, 0x7c, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x2d, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2f, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x2d, 0x6b, 0x65, 0x79, 0x52,
+	0x09, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x3e, 0x0a, 0x06, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x65, 0x78, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xb6, 0x01, 0x0a, 0x18, 0x45,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x73,
+	0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x03, 0x73, 0x74, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x79, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x2e,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x1b, 0x82, 0x41, 0x18,
+	0x2f, 0x6c, 0x69, 0x73, 0x74, 0x2d, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x61, 0x6e, 0x6f, 0x74, 0x68,
+	0x65, 0x72, 0x2d, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x03, 0x73, 0x74, 0x72, 0x12, 0x54, 0x0a,
+	0x0a, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68,
+	0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x4b, 0x65, 0x79, 0x42, 0x18,
+	0x82, 0x41, 0x15, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x2d, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x63, 0x68,
+	0x69, 0x6c, 0x64, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x09, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x4c,
+	0x69, 0x73, 0x74, 0x22, 0x80, 0x01, 0x0a, 0x0d, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4c, 0x69,
+	0x73, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x39, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x5f, 0x6f, 0x6e, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x20, 0x82, 0x41, 0x1d, 0x2f, 0x6c, 0x69, 0x73, 0x74,
+	0x2d, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x2d, 0x6c, 0x69, 0x73, 0x74,
+	0x2f, 0x6b, 0x65, 0x79, 0x2d, 0x6f, 0x6e, 0x65, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x4f, 0x6e, 0x65,
+	0x12, 0x34, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x4e,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52,
+	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x22, 0x59, 0x0a, 0x10, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x45, 0x0a, 0x03, 0x73, 0x74,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x79, 0x77, 0x72, 0x61, 0x70, 0x70,
+	0x65, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x1c,
+	0x82, 0x41, 0x19, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x2d, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x63, 0x68,
+	0x69, 0x6c, 0x64, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f, 0x73, 0x74, 0x72, 0x52, 0x03, 0x73, 0x74,
+	0x72, 0x22, 0xd8, 0x01, 0x0a, 0x16, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4b, 0x65, 0x79, 0x12, 0x43, 0x0a, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x2d, 0x82, 0x41, 0x2a,
+	0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x7c, 0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x3f, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x2b, 0x82, 0x41, 0x28, 0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f,
+	0x6e, 0x61, 0x6d, 0x65, 0x7c, 0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2d, 0x6c, 0x69, 0x73, 0x74,
+	0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x38, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74,
+	0x68, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x5e, 0x0a, 0x12,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x48, 0x0a, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x79, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x1b, 0x82, 0x41, 0x18, 0x2f, 0x6d, 0x75,
+	0x6c, 0x74, 0x69, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x63, 0x68, 0x69, 0x6c, 0x64, 0x52, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x22, 0xb1, 0x06, 0x0a,
+	0x0e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x5d, 0x0a, 0x09, 0x6d, 0x61, 0x70, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74,
+	0x68, 0x2e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x4d, 0x61, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x14,
+	0x82, 0x41, 0x11, 0x2f, 0x61, 0x6e, 0x2f, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x2f, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x52, 0x08, 0x6d, 0x61, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x6e, 0x6f, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x6f, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x02, 0x6b, 0x6d, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x45,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79,
+	0x42, 0x0c, 0x82, 0x41, 0x09, 0x2f, 0x6f, 0x6e, 0x65, 0x7c, 0x2f, 0x74, 0x77, 0x6f, 0x52, 0x02,
+	0x6b, 0x6d, 0x12, 0x19, 0x0a, 0x02, 0x6b, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x42, 0x09,
+	0x82, 0x41, 0x06, 0x2f, 0x74, 0x68, 0x72, 0x65, 0x65, 0x52, 0x02, 0x6b, 0x65, 0x12, 0x35, 0x0a,
+	0x02, 0x62, 0x6b, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x78, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x42, 0x61, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x42, 0x08, 0x82, 0x41, 0x05, 0x2f, 0x66, 0x6f, 0x75, 0x72,
+	0x52, 0x02, 0x62, 0x6b, 0x12, 0x38, 0x0a, 0x02, 0x62, 0x6d, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e,
+	0x42, 0x61, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x42, 0x08, 0x82, 0x41, 0x05, 0x2f, 0x66, 0x69, 0x76, 0x65, 0x52, 0x02, 0x62, 0x6d, 0x12, 0x59,
+	0x0a, 0x16, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x79, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0c, 0x82, 0x41, 0x09, 0x2f, 0x6f, 0x6e, 0x65, 0x5b, 0x74,
+	0x77, 0x6f, 0x5d, 0x52, 0x14, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x41, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x65, 0x64, 0x50, 0x61, 0x74, 0x68, 0x12, 0x3e, 0x0a, 0x06, 0x62, 0x6b, 0x5f,
+	0x74, 0x77, 0x6f, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x78, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x42, 0x61, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x54, 0x77, 0x6f, 0x42, 0x07, 0x82, 0x41, 0x04, 0x2f, 0x73,
+	0x69, 0x78, 0x52, 0x05, 0x62, 0x6b, 0x54, 0x77, 0x6f, 0x12, 0x79, 0x0a, 0x22, 0x6d, 0x75, 0x6c,
+	0x74, 0x69, 0x70, 0x6c, 0x65, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x70, 0x61, 0x74, 0x68, 0x2e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x42, 0x0e, 0x82, 0x41, 0x0b, 0x2f, 0x73, 0x69, 0x78, 0x7c, 0x2f, 0x73, 0x65,
+	0x76, 0x65, 0x6e, 0x52, 0x1f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x41, 0x6e, 0x6e,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x46, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x04, 0x62, 0x6b, 0x70, 0x6d, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74,
+	0x68, 0x2e, 0x42, 0x61, 0x64, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x74, 0x68, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x42, 0x09, 0x82, 0x41, 0x06, 0x2f, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x04,
+	0x62, 0x6b, 0x70, 0x6d, 0x12, 0x3d, 0x0a, 0x04, 0x69, 0x6b, 0x70, 0x6b, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61, 0x74,
+	0x68, 0x2e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x74, 0x68,
+	0x4b, 0x65, 0x79, 0x42, 0x08, 0x82, 0x41, 0x05, 0x2f, 0x6e, 0x69, 0x6e, 0x65, 0x52, 0x04, 0x69,
+	0x6b, 0x70, 0x6b, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x61, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x36, 0x0a, 0x10, 0x42, 0x61, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4b, 0x65,
+	0x79, 0x54, 0x77, 0x6f, 0x12, 0x22, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x10, 0x82, 0x41, 0x0d, 0x2f, 0x6f, 0x6e, 0x65, 0x7c, 0x2f, 0x6f, 0x6e, 0x65, 0x2f,
+	0x74, 0x77, 0x6f, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x3f, 0x0a, 0x0d, 0x42, 0x61, 0x64, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x0c, 0x62, 0x61, 0x64,
+	0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x42,
+	0x0c, 0x82, 0x41, 0x09, 0x2f, 0x66, 0x6f, 0x75, 0x72, 0x2f, 0x6b, 0x65, 0x79, 0x52, 0x0a, 0x62,
+	0x61, 0x64, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x22, 0x58, 0x0a, 0x10, 0x42, 0x61, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x82, 0x41, 0x07, 0x2f,
+	0x6f, 0x6b, 0x2d, 0x6b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x08, 0x62,
+	0x61, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0b, 0x82,
+	0x41, 0x08, 0x2f, 0x62, 0x61, 0x64, 0x2d, 0x6b, 0x65, 0x79, 0x52, 0x07, 0x62, 0x61, 0x64, 0x54,
+	0x79, 0x70, 0x65, 0x22, 0x2b, 0x0a, 0x11, 0x42, 0x61, 0x64, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x74,
+	0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x04, 0x82, 0x41, 0x01, 0x2f, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x22, 0x33, 0x0a, 0x11, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4b, 0x65, 0x79, 0x50, 0x61,
+	0x74, 0x68, 0x4b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x0c, 0x82, 0x41, 0x09, 0x2f, 0x6f, 0x6e, 0x65, 0x5b, 0x74, 0x77, 0x6f, 0x5d,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x2a, 0x6e, 0x0a, 0x0b, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x45, 0x6e, 0x75, 0x6d, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x58, 0x41, 0x4d, 0x50, 0x4c, 0x45, 0x45,
+	0x4e, 0x55, 0x4d, 0x5f, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x13, 0x45,
+	0x58, 0x41, 0x4d, 0x50, 0x4c, 0x45, 0x45, 0x4e, 0x55, 0x4d, 0x5f, 0x56, 0x41, 0x4c, 0x5f, 0x4f,
+	0x4e, 0x45, 0x10, 0x01, 0x1a, 0x0a, 0x82, 0x41, 0x07, 0x56, 0x41, 0x4c, 0x5f, 0x4f, 0x4e, 0x45,
+	0x12, 0x23, 0x0a, 0x13, 0x45, 0x58, 0x41, 0x4d, 0x50, 0x4c, 0x45, 0x45, 0x4e, 0x55, 0x4d, 0x5f,
+	0x56, 0x41, 0x4c, 0x5f, 0x54, 0x57, 0x4f, 0x10, 0x02, 0x1a, 0x0a, 0x82, 0x41, 0x07, 0x56, 0x41,
+	0x4c, 0x5f, 0x54, 0x57, 0x4f, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x79,
+	0x67, 0x6f, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6d, 0x61, 0x70, 0x2f, 0x74, 0x65, 0x73,
+	0x74, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x65, 0x78, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x70, 0x61,
+	0x74, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1311,71 +1569,81 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 	return file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDescData
 }
 
-var file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_goTypes = []interface{}{
-	(*Root)(nil),                     // 0: exschemapath.Root
-	(*Interface)(nil),                // 1: exschemapath.Interface
-	(*System)(nil),                   // 2: exschemapath.System
-	(*ExampleMessage)(nil),           // 3: exschemapath.ExampleMessage
-	(*ExampleMessageChild)(nil),      // 4: exschemapath.ExampleMessageChild
-	(*ExampleMessageKey)(nil),        // 5: exschemapath.ExampleMessageKey
-	(*ExampleMessageListMember)(nil), // 6: exschemapath.ExampleMessageListMember
-	(*NestedListKey)(nil),            // 7: exschemapath.NestedListKey
-	(*NestedListMember)(nil),         // 8: exschemapath.NestedListMember
-	(*ExampleMessageMultiKey)(nil),   // 9: exschemapath.ExampleMessageMultiKey
-	(*MultiKeyListMember)(nil),       // 10: exschemapath.MultiKeyListMember
-	(*InvalidMessage)(nil),           // 11: exschemapath.InvalidMessage
-	(*BadMessageKeyTwo)(nil),         // 12: exschemapath.BadMessageKeyTwo
-	(*BadMessageKey)(nil),            // 13: exschemapath.BadMessageKey
-	(*BadMessageMember)(nil),         // 14: exschemapath.BadMessageMember
-	(*BadKeyPathMessage)(nil),        // 15: exschemapath.BadKeyPathMessage
-	(*InvalidKeyPathKey)(nil),        // 16: exschemapath.InvalidKeyPathKey
-	(*Root_InterfaceKey)(nil),        // 17: exschemapath.Root.InterfaceKey
-	nil,                              // 18: exschemapath.InvalidMessage.MapFieldEntry
-	(*ywrapper.StringValue)(nil),     // 19: ywrapper.StringValue
-	(*ywrapper.BoolValue)(nil),       // 20: ywrapper.BoolValue
-	(*ywrapper.BytesValue)(nil),      // 21: ywrapper.BytesValue
-	(*ywrapper.Decimal64Value)(nil),  // 22: ywrapper.Decimal64Value
-	(*ywrapper.IntValue)(nil),        // 23: ywrapper.IntValue
-	(*ywrapper.UintValue)(nil),       // 24: ywrapper.UintValue
+	(ExampleEnum)(0),                 // 0: exschemapath.ExampleEnum
+	(*Root)(nil),                     // 1: exschemapath.Root
+	(*Interface)(nil),                // 2: exschemapath.Interface
+	(*System)(nil),                   // 3: exschemapath.System
+	(*ExampleMessage)(nil),           // 4: exschemapath.ExampleMessage
+	(*ExampleMessageEnumKey)(nil),    // 5: exschemapath.ExampleMessageEnumKey
+	(*EnumListMember)(nil),           // 6: exschemapath.EnumListMember
+	(*ExampleMessageChild)(nil),      // 7: exschemapath.ExampleMessageChild
+	(*ExampleMessageKey)(nil),        // 8: exschemapath.ExampleMessageKey
+	(*ExampleMessageListMember)(nil), // 9: exschemapath.ExampleMessageListMember
+	(*NestedListKey)(nil),            // 10: exschemapath.NestedListKey
+	(*NestedListMember)(nil),         // 11: exschemapath.NestedListMember
+	(*ExampleMessageMultiKey)(nil),   // 12: exschemapath.ExampleMessageMultiKey
+	(*MultiKeyListMember)(nil),       // 13: exschemapath.MultiKeyListMember
+	(*InvalidMessage)(nil),           // 14: exschemapath.InvalidMessage
+	(*BadMessageKeyTwo)(nil),         // 15: exschemapath.BadMessageKeyTwo
+	(*BadMessageKey)(nil),            // 16: exschemapath.BadMessageKey
+	(*BadMessageMember)(nil),         // 17: exschemapath.BadMessageMember
+	(*BadKeyPathMessage)(nil),        // 18: exschemapath.BadKeyPathMessage
+	(*InvalidKeyPathKey)(nil),        // 19: exschemapath.InvalidKeyPathKey
+	(*Root_InterfaceKey)(nil),        // 20: exschemapath.Root.InterfaceKey
+	nil,                              // 21: exschemapath.InvalidMessage.MapFieldEntry
+	(*ywrapper.StringValue)(nil),     // 22: ywrapper.StringValue
+	(*ywrapper.BoolValue)(nil),       // 23: ywrapper.BoolValue
+	(*ywrapper.BytesValue)(nil),      // 24: ywrapper.BytesValue
+	(*ywrapper.Decimal64Value)(nil),  // 25: ywrapper.Decimal64Value
+	(*ywrapper.IntValue)(nil),        // 26: ywrapper.IntValue
+	(*ywrapper.UintValue)(nil),       // 27: ywrapper.UintValue
 }
 var file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_depIdxs = []int32{
-	2,  // 0: exschemapath.Root.system:type_name -> exschemapath.System
-	17, // 1: exschemapath.Root.interface:type_name -> exschemapath.Root.InterfaceKey
-	19, // 2: exschemapath.Interface.description:type_name -> ywrapper.StringValue
-	19, // 3: exschemapath.System.hostname:type_name -> ywrapper.StringValue
-	20, // 4: exschemapath.ExampleMessage.bo:type_name -> ywrapper.BoolValue
-	21, // 5: exschemapath.ExampleMessage.by:type_name -> ywrapper.BytesValue
-	22, // 6: exschemapath.ExampleMessage.de:type_name -> ywrapper.Decimal64Value
-	23, // 7: exschemapath.ExampleMessage.in:type_name -> ywrapper.IntValue
-	19, // 8: exschemapath.ExampleMessage.str:type_name -> ywrapper.StringValue
-	24, // 9: exschemapath.ExampleMessage.ui:type_name -> ywrapper.UintValue
-	4,  // 10: exschemapath.ExampleMessage.ex:type_name -> exschemapath.ExampleMessageChild
-	5,  // 11: exschemapath.ExampleMessage.em:type_name -> exschemapath.ExampleMessageKey
-	9,  // 12: exschemapath.ExampleMessage.multi:type_name -> exschemapath.ExampleMessageMultiKey
-	19, // 13: exschemapath.ExampleMessageChild.str:type_name -> ywrapper.StringValue
-	6,  // 14: exschemapath.ExampleMessageKey.member:type_name -> exschemapath.ExampleMessageListMember
-	19, // 15: exschemapath.ExampleMessageListMember.str:type_name -> ywrapper.StringValue
-	7,  // 16: exschemapath.ExampleMessageListMember.child_list:type_name -> exschemapath.NestedListKey
-	8,  // 17: exschemapath.NestedListKey.field:type_name -> exschemapath.NestedListMember
-	19, // 18: exschemapath.NestedListMember.str:type_name -> ywrapper.StringValue
-	10, // 19: exschemapath.ExampleMessageMultiKey.member:type_name -> exschemapath.MultiKeyListMember
-	19, // 20: exschemapath.MultiKeyListMember.child:type_name -> ywrapper.StringValue
-	18, // 21: exschemapath.InvalidMessage.map_field:type_name -> exschemapath.InvalidMessage.MapFieldEntry
-	5,  // 22: exschemapath.InvalidMessage.km:type_name -> exschemapath.ExampleMessageKey
-	13, // 23: exschemapath.InvalidMessage.bk:type_name -> exschemapath.BadMessageKey
-	14, // 24: exschemapath.InvalidMessage.bm:type_name -> exschemapath.BadMessageMember
-	19, // 25: exschemapath.InvalidMessage.invalid_annotated_path:type_name -> ywrapper.StringValue
-	12, // 26: exschemapath.InvalidMessage.bk_two:type_name -> exschemapath.BadMessageKeyTwo
-	11, // 27: exschemapath.InvalidMessage.multiple_annotations_for_container:type_name -> exschemapath.InvalidMessage
-	15, // 28: exschemapath.InvalidMessage.bkpm:type_name -> exschemapath.BadKeyPathMessage
-	16, // 29: exschemapath.InvalidMessage.ikpk:type_name -> exschemapath.InvalidKeyPathKey
-	1,  // 30: exschemapath.Root.InterfaceKey.interface:type_name -> exschemapath.Interface
-	31, // [31:31] is the sub-list for method output_type
-	31, // [31:31] is the sub-list for method input_type
-	31, // [31:31] is the sub-list for extension type_name
-	31, // [31:31] is the sub-list for extension extendee
-	0,  // [0:31] is the sub-list for field type_name
+	3,  // 0: exschemapath.Root.system:type_name -> exschemapath.System
+	20, // 1: exschemapath.Root.interface:type_name -> exschemapath.Root.InterfaceKey
+	22, // 2: exschemapath.Interface.description:type_name -> ywrapper.StringValue
+	22, // 3: exschemapath.System.hostname:type_name -> ywrapper.StringValue
+	23, // 4: exschemapath.ExampleMessage.bo:type_name -> ywrapper.BoolValue
+	24, // 5: exschemapath.ExampleMessage.by:type_name -> ywrapper.BytesValue
+	25, // 6: exschemapath.ExampleMessage.de:type_name -> ywrapper.Decimal64Value
+	26, // 7: exschemapath.ExampleMessage.in:type_name -> ywrapper.IntValue
+	22, // 8: exschemapath.ExampleMessage.str:type_name -> ywrapper.StringValue
+	27, // 9: exschemapath.ExampleMessage.ui:type_name -> ywrapper.UintValue
+	7,  // 10: exschemapath.ExampleMessage.ex:type_name -> exschemapath.ExampleMessageChild
+	8,  // 11: exschemapath.ExampleMessage.em:type_name -> exschemapath.ExampleMessageKey
+	12, // 12: exschemapath.ExampleMessage.multi:type_name -> exschemapath.ExampleMessageMultiKey
+	0,  // 13: exschemapath.ExampleMessage.en:type_name -> exschemapath.ExampleEnum
+	22, // 14: exschemapath.ExampleMessage.leaf_list:type_name -> ywrapper.StringValue
+	5,  // 15: exschemapath.ExampleMessage.enum_list:type_name -> exschemapath.ExampleMessageEnumKey
+	0,  // 16: exschemapath.ExampleMessageEnumKey.key:type_name -> exschemapath.ExampleEnum
+	6,  // 17: exschemapath.ExampleMessageEnumKey.member:type_name -> exschemapath.EnumListMember
+	27, // 18: exschemapath.EnumListMember.value:type_name -> ywrapper.UintValue
+	22, // 19: exschemapath.ExampleMessageChild.str:type_name -> ywrapper.StringValue
+	9,  // 20: exschemapath.ExampleMessageKey.member:type_name -> exschemapath.ExampleMessageListMember
+	22, // 21: exschemapath.ExampleMessageListMember.str:type_name -> ywrapper.StringValue
+	10, // 22: exschemapath.ExampleMessageListMember.child_list:type_name -> exschemapath.NestedListKey
+	11, // 23: exschemapath.NestedListKey.field:type_name -> exschemapath.NestedListMember
+	22, // 24: exschemapath.NestedListMember.str:type_name -> ywrapper.StringValue
+	13, // 25: exschemapath.ExampleMessageMultiKey.member:type_name -> exschemapath.MultiKeyListMember
+	22, // 26: exschemapath.MultiKeyListMember.child:type_name -> ywrapper.StringValue
+	21, // 27: exschemapath.InvalidMessage.map_field:type_name -> exschemapath.InvalidMessage.MapFieldEntry
+	8,  // 28: exschemapath.InvalidMessage.km:type_name -> exschemapath.ExampleMessageKey
+	16, // 29: exschemapath.InvalidMessage.bk:type_name -> exschemapath.BadMessageKey
+	17, // 30: exschemapath.InvalidMessage.bm:type_name -> exschemapath.BadMessageMember
+	22, // 31: exschemapath.InvalidMessage.invalid_annotated_path:type_name -> ywrapper.StringValue
+	15, // 32: exschemapath.InvalidMessage.bk_two:type_name -> exschemapath.BadMessageKeyTwo
+	14, // 33: exschemapath.InvalidMessage.multiple_annotations_for_container:type_name -> exschemapath.InvalidMessage
+	18, // 34: exschemapath.InvalidMessage.bkpm:type_name -> exschemapath.BadKeyPathMessage
+	19, // 35: exschemapath.InvalidMessage.ikpk:type_name -> exschemapath.InvalidKeyPathKey
+	2,  // 36: exschemapath.Root.InterfaceKey.interface:type_name -> exschemapath.Interface
+	37, // [37:37] is the sub-list for method output_type
+	37, // [37:37] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_init() }
@@ -1433,7 +1701,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExampleMessageChild); i {
+			switch v := v.(*ExampleMessageEnumKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1445,7 +1713,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExampleMessageKey); i {
+			switch v := v.(*EnumListMember); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1457,7 +1725,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExampleMessageListMember); i {
+			switch v := v.(*ExampleMessageChild); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1469,7 +1737,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NestedListKey); i {
+			switch v := v.(*ExampleMessageKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1481,7 +1749,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NestedListMember); i {
+			switch v := v.(*ExampleMessageListMember); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1493,7 +1761,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExampleMessageMultiKey); i {
+			switch v := v.(*NestedListKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1505,7 +1773,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MultiKeyListMember); i {
+			switch v := v.(*NestedListMember); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1517,7 +1785,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InvalidMessage); i {
+			switch v := v.(*ExampleMessageMultiKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1529,7 +1797,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BadMessageKeyTwo); i {
+			switch v := v.(*MultiKeyListMember); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1541,7 +1809,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BadMessageKey); i {
+			switch v := v.(*InvalidMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1553,7 +1821,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BadMessageMember); i {
+			switch v := v.(*BadMessageKeyTwo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1565,7 +1833,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BadKeyPathMessage); i {
+			switch v := v.(*BadMessageKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1577,7 +1845,7 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InvalidKeyPathKey); i {
+			switch v := v.(*BadMessageMember); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1589,6 +1857,30 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BadKeyPathMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvalidKeyPathKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Root_InterfaceKey); i {
 			case 0:
 				return &v.state
@@ -1601,18 +1893,23 @@ func file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath
 			}
 		}
 	}
+	file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes[3].OneofWrappers = []interface{}{
+		(*ExampleMessage_UnionString)(nil),
+		(*ExampleMessage_UnionUint64)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   19,
+			NumEnums:      1,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_goTypes,
 		DependencyIndexes: file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_depIdxs,
+		EnumInfos:         file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_enumTypes,
 		MessageInfos:      file_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto_msgTypes,
 	}.Build()
 	File_github_com_openconfig_ygot_protomap_testdata_exschemapath_exschemapath_proto = out.File
@@ -106,6 +106,18 @@ func ValueStrDebug(value interface{}) string {
 	return ValueStr(value)
 }
 
+// PrettyStrDebug returns the pretty-printed representation of value if
+// debugLibrary is set, or a placeholder otherwise. Self-referential values,
+// such as the schema bound to a ygot.Anydata, are printed with placeholders.
+// Like ValueStrDebug, it avoids the cost of formatting value when the output
+// is not used.
+func PrettyStrDebug(value interface{}) string {
+	if !debugLibrary {
+		return "<not calculated>"
+	}
+	return pretty.CycleTracker.Sprint(value)
+}
+
 // ValueStr returns a string representation of value which may be a value, ptr,
 // or struct type.
 func ValueStr(value interface{}) string {
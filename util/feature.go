@@ -0,0 +1,432 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+const (
+	// EnabledFeaturesAnnotation is the name of the annotation within which
+	// ygen stores, on the root of a serialised schema tree, the YANG features
+	// that were enabled when the code was generated, keyed by the name of
+	// the module that defines them.
+	EnabledFeaturesAnnotation string = "enabled-features"
+	// DisabledChildrenAnnotation is the name of the annotation within which
+	// ygen stores the children of a yang.Entry that were removed from the
+	// schema since their if-feature statements evaluated to false, keyed by
+	// the name of the child, with the value being the module-qualified
+	// if-feature expressions of the child.
+	DisabledChildrenAnnotation string = "feature-disabled-children"
+)
+
+// ifFeatureOp is the operation performed by a node of a parsed if-feature
+// expression. Operations are ordered such that those that bind less tightly
+// have greater values.
+type ifFeatureOp int
+
+const (
+	// ifFeatureRef is a reference to a feature.
+	ifFeatureRef ifFeatureOp = iota
+	// ifFeatureNot is the negation of its single operand.
+	ifFeatureNot
+	// ifFeatureAnd is the conjunction of its operands.
+	ifFeatureAnd
+	// ifFeatureOr is the disjunction of its operands.
+	ifFeatureOr
+)
+
+// IfFeatureExpr is a parsed YANG if-feature expression, as defined in RFC7950
+// Section 7.20.2.
+type IfFeatureExpr struct {
+	op ifFeatureOp
+	// ref is the feature that is referenced, of the form prefix:feature or
+	// feature, where op is ifFeatureRef.
+	ref string
+	// operands are the operands of the expression for other operations.
+	operands []*IfFeatureExpr
+}
+
+// ParseIfFeatureExpr parses the argument s of a YANG if-feature statement.
+func ParseIfFeatureExpr(s string) (*IfFeatureExpr, error) {
+	p := &ifFeatureParser{tokens: ifFeatureTokens(s)}
+	x, err := p.expr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid if-feature expression %q, %v", s, err)
+	}
+	if len(p.tokens) != 0 {
+		return nil, fmt.Errorf("invalid if-feature expression %q, unexpected %q", s, p.tokens[0])
+	}
+	return x, nil
+}
+
+// Eval evaluates the expression x, using the enabled function to determine
+// whether each referenced feature is enabled.
+func (x *IfFeatureExpr) Eval(enabled func(ref string) bool) bool {
+	switch x.op {
+	case ifFeatureRef:
+		return enabled(x.ref)
+	case ifFeatureNot:
+		return !x.operands[0].Eval(enabled)
+	case ifFeatureAnd:
+		for _, o := range x.operands {
+			if !o.Eval(enabled) {
+				return false
+			}
+		}
+		return true
+	default:
+		for _, o := range x.operands {
+			if o.Eval(enabled) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Qualify replaces each feature referenced by x with the value returned by
+// the supplied function, which is typically used to replace the prefix of the
+// feature with the name of the module that defines it.
+func (x *IfFeatureExpr) Qualify(fn func(ref string) (string, error)) error {
+	if x.op == ifFeatureRef {
+		r, err := fn(x.ref)
+		if err != nil {
+			return err
+		}
+		x.ref = r
+		return nil
+	}
+	for _, o := range x.operands {
+		if err := o.Qualify(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String returns the expression x in the syntax of a YANG if-feature
+// statement.
+func (x *IfFeatureExpr) String() string {
+	switch x.op {
+	case ifFeatureRef:
+		return x.ref
+	case ifFeatureNot:
+		return "not " + x.operands[0].operandString(x.op)
+	default:
+		sep := " and "
+		if x.op == ifFeatureOr {
+			sep = " or "
+		}
+		var s []string
+		for _, o := range x.operands {
+			s = append(s, o.operandString(x.op))
+		}
+		return strings.Join(s, sep)
+	}
+}
+
+// operandString returns the expression x as an operand of the operation op,
+// adding parentheses where x binds less tightly than op.
+func (x *IfFeatureExpr) operandString(op ifFeatureOp) string {
+	if x.op > ifFeatureNot && x.op > op {
+		return "(" + x.String() + ")"
+	}
+	return x.String()
+}
+
+// ifFeatureTokens splits the if-feature expression s into its tokens.
+func ifFeatureTokens(s string) []string {
+	s = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(s)
+	return strings.Fields(s)
+}
+
+// ifFeatureParser is a recursive descent parser for if-feature expressions,
+// following the grammar in RFC7950 Section 14:
+//   if-feature-expr   = if-feature-term [sep or-keyword sep if-feature-expr]
+//   if-feature-term   = if-feature-factor [sep and-keyword sep if-feature-term]
+//   if-feature-factor = not-keyword sep if-feature-factor /
+//                       "(" optsep if-feature-expr optsep ")" /
+//                       identifier-ref-arg
+type ifFeatureParser struct {
+	tokens []string
+}
+
+// peek returns the next token, or the empty string if there are none.
+func (p *ifFeatureParser) peek() string {
+	if len(p.tokens) == 0 {
+		return ""
+	}
+	return p.tokens[0]
+}
+
+// next consumes and returns the next token.
+func (p *ifFeatureParser) next() string {
+	t := p.peek()
+	if len(p.tokens) != 0 {
+		p.tokens = p.tokens[1:]
+	}
+	return t
+}
+
+// expr parses an if-feature-expr.
+func (p *ifFeatureParser) expr() (*IfFeatureExpr, error) {
+	return p.binary(ifFeatureOr, "or", p.term)
+}
+
+// term parses an if-feature-term.
+func (p *ifFeatureParser) term() (*IfFeatureExpr, error) {
+	return p.binary(ifFeatureAnd, "and", p.factor)
+}
+
+// binary parses one or more operands using the operand function, separated by
+// the keyword kw, which are combined using the operation op.
+func (p *ifFeatureParser) binary(op ifFeatureOp, kw string, operand func() (*IfFeatureExpr, error)) (*IfFeatureExpr, error) {
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != kw {
+		return x, nil
+	}
+	out := &IfFeatureExpr{op: op, operands: []*IfFeatureExpr{x}}
+	for p.peek() == kw {
+		p.next()
+		o, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		out.operands = append(out.operands, o)
+	}
+	return out, nil
+}
+
+// factor parses an if-feature-factor.
+func (p *ifFeatureParser) factor() (*IfFeatureExpr, error) {
+	switch t := p.next(); t {
+	case "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case "not":
+		o, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return &IfFeatureExpr{op: ifFeatureNot, operands: []*IfFeatureExpr{o}}, nil
+	case "(":
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c != ")" {
+			return nil, fmt.Errorf("got %q, expect \")\"", c)
+		}
+		return x, nil
+	case ")", "and", "or":
+		return nil, fmt.Errorf("unexpected %q", t)
+	default:
+		return &IfFeatureExpr{op: ifFeatureRef, ref: t}, nil
+	}
+}
+
+// IfFeatures returns the if-feature expressions that must all evaluate to true
+// for the supplied yang.Entry to be part of the schema. These are the
+// if-feature statements of the YANG node that the entry was created from,
+// along with those of any uses or augment statements through which the entry
+// was added to its parent. Each feature that is referenced by the returned
+// expressions is qualified with the name of the module that defines it, e.g.,
+// "module:feature".
+//
+// The if-feature statements of uses statements are only available where the
+// entry was parsed with the StoreUses option of the goyang library set.
+func IfFeatures(e *yang.Entry) ([]*IfFeatureExpr, error) {
+	if e == nil || e.Node == nil {
+		return nil, nil
+	}
+
+	var out []*IfFeatureExpr
+	for _, n := range append([]yang.Node{e.Node}, augmentAndUsesNodes(e)...) {
+		exprs, err := NodeIfFeatures(n)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, exprs...)
+	}
+	return out, nil
+}
+
+// NodeIfFeatures returns the expressions of the if-feature statements of the
+// YANG node n, which may be any node that can have if-feature statements,
+// including a feature. Each feature that is referenced by the returned
+// expressions is qualified with the name of the module that defines it.
+func NodeIfFeatures(n yang.Node) ([]*IfFeatureExpr, error) {
+	var out []*IfFeatureExpr
+	for _, v := range nodeIfFeatures(n) {
+		x, err := ParseIfFeatureExpr(v.Name)
+		if err != nil {
+			return nil, err
+		}
+		if err := x.Qualify(func(ref string) (string, error) {
+			return qualifyFeature(n, ref)
+		}); err != nil {
+			return nil, err
+		}
+		out = append(out, x)
+	}
+	return out, nil
+}
+
+// nodeIfFeatures returns the arguments of the if-feature statements of the
+// YANG node n.
+func nodeIfFeatures(n yang.Node) []*yang.Value {
+	v := reflect.ValueOf(n)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	f := v.Elem().FieldByName("IfFeature")
+	if !f.IsValid() {
+		return nil
+	}
+	vs, _ := f.Interface().([]*yang.Value)
+	return vs
+}
+
+// augmentAndUsesNodes returns the YANG nodes of the augment and uses
+// statements through which the supplied yang.Entry was added to its parent.
+// The uses statements are only available where the entry was parsed with the
+// StoreUses option of the goyang library set.
+func augmentAndUsesNodes(e *yang.Entry) []yang.Node {
+	if e.Parent == nil {
+		return nil
+	}
+	var nodes []yang.Node
+	for _, a := range e.Parent.Augmented {
+		if _, ok := a.Dir[e.Name]; ok && a.Node != nil {
+			nodes = append(nodes, a.Node)
+			nodes = append(nodes, usesWithChild(a.Uses, e.Name)...)
+		}
+	}
+	return append(nodes, usesWithChild(e.Parent.Uses, e.Name)...)
+}
+
+// usesWithChild returns the uses statements within uses through which the
+// child with the supplied name was added, including those within the
+// groupings that are referenced.
+func usesWithChild(uses []*yang.UsesStmt, name string) []yang.Node {
+	var out []yang.Node
+	for _, u := range uses {
+		if u.Grouping == nil || u.Uses == nil {
+			continue
+		}
+		if _, ok := u.Grouping.Dir[name]; !ok {
+			continue
+		}
+		out = append(out, u.Uses)
+		out = append(out, usesWithChild(u.Grouping.Uses, name)...)
+	}
+	return out
+}
+
+// qualifyFeature returns the feature ref, which is referenced by an if-feature
+// statement within the YANG node n, qualified with the name of the module
+// that defines it.
+func qualifyFeature(n yang.Node, ref string) (string, error) {
+	var prefix, name string
+	switch p := strings.SplitN(ref, ":", 2); len(p) {
+	case 1:
+		name = p[0]
+	default:
+		prefix, name = p[0], p[1]
+	}
+	m := yang.FindModuleByPrefix(n, prefix)
+	if m == nil {
+		return "", fmt.Errorf("cannot resolve prefix of feature %s referenced by %s", ref, yang.NodePath(n))
+	}
+	return fmt.Sprintf("%s:%s", moduleName(m), name), nil
+}
+
+// moduleName returns the name of the module m, or of the module that it
+// belongs to where m is a submodule.
+func moduleName(m *yang.Module) string {
+	if m.Kind() == "submodule" && m.BelongsTo != nil {
+		return m.BelongsTo.Name
+	}
+	return m.Name
+}
+
+// EnabledFeatures returns the YANG features that are enabled for the schema
+// tree that the supplied yang.Entry belongs to, keyed by the name of the
+// module that defines them. The features are taken from the
+// EnabledFeaturesAnnotation of the root of the tree, and nil is returned if
+// the annotation is not present, in which case all features are enabled.
+func EnabledFeatures(e *yang.Entry) map[string][]string {
+	if e == nil {
+		return nil
+	}
+	root := e
+	for root.Parent != nil {
+		root = root.Parent
+	}
+
+	switch a := root.Annotation[EnabledFeaturesAnnotation].(type) {
+	case map[string][]string:
+		return a
+	case map[string]interface{}:
+		// The annotation has been unmarshalled from a JSON schema.
+		return stringSliceMap(a)
+	}
+	return nil
+}
+
+// DisabledChildren returns the children of the supplied yang.Entry that were
+// removed from the schema since their if-feature statements evaluated to
+// false, keyed by the name of the child, with the value being the if-feature
+// expressions of the child. The children are taken from the
+// DisabledChildrenAnnotation of the entry.
+func DisabledChildren(e *yang.Entry) map[string][]string {
+	if e == nil {
+		return nil
+	}
+
+	switch a := e.Annotation[DisabledChildrenAnnotation].(type) {
+	case map[string][]string:
+		return a
+	case map[string]interface{}:
+		// The annotation has been unmarshalled from a JSON schema.
+		return stringSliceMap(a)
+	}
+	return nil
+}
+
+// stringSliceMap converts the map m, which has been unmarshalled from JSON, to
+// a map of string slices. Values that are not string slices are skipped.
+func stringSliceMap(m map[string]interface{}) map[string][]string {
+	out := map[string][]string{}
+	for k, v := range m {
+		vs, ok := v.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, s := range vs {
+			if ss, ok := s.(string); ok {
+				out[k] = append(out[k], ss)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func TestParseIfFeatureExpr(t *testing.T) {
+	tests := []struct {
+		desc       string
+		in         string
+		inEnabled  []string
+		wantString string
+		wantEval   bool
+		wantErr    string
+	}{{
+		desc:       "single feature",
+		in:         "a",
+		inEnabled:  []string{"a"},
+		wantString: "a",
+		wantEval:   true,
+	}, {
+		desc:       "prefixed feature, disabled",
+		in:         "p:a",
+		wantString: "p:a",
+	}, {
+		desc:       "not",
+		in:         "not a",
+		wantString: "not a",
+		wantEval:   true,
+	}, {
+		desc:       "and binds more tightly than or",
+		in:         "a or b and c",
+		inEnabled:  []string{"a"},
+		wantString: "a or b and c",
+		wantEval:   true,
+	}, {
+		desc:       "parentheses",
+		in:         "(a or b) and c",
+		inEnabled:  []string{"a"},
+		wantString: "(a or b) and c",
+	}, {
+		desc:       "nested parentheses without whitespace",
+		in:         "not(a and(b or c))",
+		inEnabled:  []string{"a", "c"},
+		wantString: "not (a and (b or c))",
+	}, {
+		desc:       "redundant parentheses",
+		in:         "((a))",
+		inEnabled:  []string{"a"},
+		wantString: "a",
+		wantEval:   true,
+	}, {
+		desc:    "empty expression",
+		in:      "",
+		wantErr: "unexpected end of expression",
+	}, {
+		desc:    "missing operand",
+		in:      "a and",
+		wantErr: "unexpected end of expression",
+	}, {
+		desc:    "missing closing parenthesis",
+		in:      "(a or b",
+		wantErr: `got "", expect ")"`,
+	}, {
+		desc:    "trailing token",
+		in:      "a b",
+		wantErr: `unexpected "b"`,
+	}, {
+		desc:    "leading keyword",
+		in:      "or a",
+		wantErr: `unexpected "or"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseIfFeatureExpr(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("ParseIfFeatureExpr(%q): %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if s := got.String(); s != tt.wantString {
+				t.Errorf("ParseIfFeatureExpr(%q).String(): got %q, want %q", tt.in, s, tt.wantString)
+			}
+			enabled := map[string]bool{}
+			for _, f := range tt.inEnabled {
+				enabled[f] = true
+			}
+			if e := got.Eval(func(ref string) bool { return enabled[ref] }); e != tt.wantEval {
+				t.Errorf("ParseIfFeatureExpr(%q).Eval(%v): got %v, want %v", tt.in, tt.inEnabled, e, tt.wantEval)
+			}
+		})
+	}
+}
+
+func TestIfFeatures(t *testing.T) {
+	imported := &yang.Module{
+		Name:   "imported",
+		Prefix: &yang.Value{Name: "imp"},
+	}
+	mod := &yang.Module{
+		Name:   "mod",
+		Prefix: &yang.Value{Name: "m"},
+		Import: []*yang.Import{{
+			Name:   "imported",
+			Prefix: &yang.Value{Name: "i"},
+			Module: imported,
+		}},
+	}
+	sub := &yang.Module{
+		Name:      "sub",
+		BelongsTo: &yang.BelongsTo{Name: "mod", Prefix: &yang.Value{Name: "m"}},
+	}
+	container := &yang.Container{Name: "c", Parent: mod}
+	parent := &yang.Entry{Name: "c", Kind: yang.DirectoryEntry, Node: container}
+
+	leaf := func(name string, parent yang.Node, ifFeatures ...string) *yang.Entry {
+		l := &yang.Leaf{Name: name, Parent: parent}
+		for _, f := range ifFeatures {
+			l.IfFeature = append(l.IfFeature, &yang.Value{Name: f})
+		}
+		return &yang.Entry{Name: name, Kind: yang.LeafEntry, Node: l}
+	}
+	withParent := func(e, p *yang.Entry) *yang.Entry {
+		e.Parent = p
+		return e
+	}
+
+	uses := &yang.Uses{Name: "g", Parent: container, IfFeature: []*yang.Value{{Name: "u"}}}
+	usesParent := &yang.Entry{
+		Name: "c",
+		Kind: yang.DirectoryEntry,
+		Node: container,
+		Uses: []*yang.UsesStmt{{
+			Uses: uses,
+			Grouping: &yang.Entry{
+				Name: "g",
+				Dir:  map[string]*yang.Entry{"from-grouping": {Name: "from-grouping"}},
+			},
+		}},
+	}
+
+	augment := &yang.Augment{Name: "/m:c", Parent: mod, IfFeature: []*yang.Value{{Name: "i:aug"}}}
+	augmentParent := &yang.Entry{
+		Name: "c",
+		Kind: yang.DirectoryEntry,
+		Node: container,
+		Augmented: []*yang.Entry{{
+			Name: "/m:c",
+			Node: augment,
+			Dir:  map[string]*yang.Entry{"augmented": {Name: "augmented"}},
+		}},
+	}
+
+	tests := []struct {
+		desc    string
+		in      *yang.Entry
+		want    []string
+		wantErr string
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "entry without if-feature",
+		in:   withParent(leaf("l", container), parent),
+	}, {
+		desc: "unprefixed feature",
+		in:   withParent(leaf("l", container, "f"), parent),
+		want: []string{"mod:f"},
+	}, {
+		desc: "features of local and imported modules",
+		in:   withParent(leaf("l", container, "m:f and not i:g", "h"), parent),
+		want: []string{"mod:f and not imported:g", "mod:h"},
+	}, {
+		desc: "feature referenced in submodule",
+		in:   leaf("l", sub, "m:f"),
+		want: []string{"mod:f"},
+	}, {
+		desc:    "unknown prefix",
+		in:      leaf("l", mod, "x:f"),
+		wantErr: "cannot resolve prefix of feature x:f",
+	}, {
+		desc:    "invalid expression",
+		in:      leaf("l", mod, "f and"),
+		wantErr: "invalid if-feature expression",
+	}, {
+		desc: "child added by uses",
+		in:   withParent(leaf("from-grouping", &yang.Grouping{Name: "g", Parent: mod}, "f"), usesParent),
+		want: []string{"mod:f", "mod:u"},
+	}, {
+		desc: "child not added by uses",
+		in:   withParent(leaf("other", container), usesParent),
+	}, {
+		desc: "child added by augment",
+		in:   withParent(leaf("augmented", augment), augmentParent),
+		want: []string{"imported:aug"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := IfFeatures(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("IfFeatures: %s", diff)
+			}
+			var gotStrs []string
+			for _, x := range got {
+				gotStrs = append(gotStrs, x.String())
+			}
+			if diff := cmp.Diff(tt.want, gotStrs); diff != "" {
+				t.Errorf("IfFeatures: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFeatureAnnotations(t *testing.T) {
+	jsonRoot := &yang.Entry{}
+	if err := json.Unmarshal([]byte(`{
+		"Annotation": {
+			"enabled-features": {"mod": ["a", "b"]},
+			"feature-disabled-children": {"c": ["mod:c"]}
+		}
+	}`), jsonRoot); err != nil {
+		t.Fatalf("cannot unmarshal schema, %v", err)
+	}
+	root := &yang.Entry{
+		Annotation: map[string]interface{}{
+			EnabledFeaturesAnnotation:  map[string][]string{"mod": {"a"}},
+			DisabledChildrenAnnotation: map[string][]string{"d": {"mod:d or mod:e"}},
+		},
+	}
+
+	tests := []struct {
+		desc             string
+		in               *yang.Entry
+		wantEnabled      map[string][]string
+		wantDisabledKids map[string][]string
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "no annotations",
+		in:   &yang.Entry{},
+	}, {
+		desc:             "annotations created by ygen",
+		in:               root,
+		wantEnabled:      map[string][]string{"mod": {"a"}},
+		wantDisabledKids: map[string][]string{"d": {"mod:d or mod:e"}},
+	}, {
+		desc:        "enabled features of child",
+		in:          &yang.Entry{Parent: root},
+		wantEnabled: map[string][]string{"mod": {"a"}},
+	}, {
+		desc:             "annotations unmarshalled from JSON",
+		in:               jsonRoot,
+		wantEnabled:      map[string][]string{"mod": {"a", "b"}},
+		wantDisabledKids: map[string][]string{"c": {"mod:c"}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.wantEnabled, EnabledFeatures(tt.in)); diff != "" {
+				t.Errorf("EnabledFeatures: (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantDisabledKids, DisabledChildren(tt.in)); diff != "" {
+				t.Errorf("DisabledChildren: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -237,8 +237,16 @@ func stripModulePrefixes(in []string) []string {
 // stripModulePrefixWithCheck removes the prefix from a YANG path element, and
 // returns an error for unexpected formats. For example, removing foo from
 // "foo:bar".  Such qualified paths are used in YANG modules where remote paths
-// are referenced.
+// are referenced. The member name of RFC7952 metadata for a node, "@foo:bar",
+// is stripped to "@bar".
 func stripModulePrefixWithCheck(name string) (string, error) {
+	if len(name) > 1 && strings.HasPrefix(name, "@") {
+		n, err := stripModulePrefixWithCheck(name[1:])
+		if err != nil {
+			return "", err
+		}
+		return "@" + n, nil
+	}
 	ps := strings.Split(name, ":")
 	switch len(ps) {
 	case 1:
@@ -252,8 +260,12 @@ func stripModulePrefixWithCheck(name string) (string, error) {
 // StripModulePrefix removes the prefix from a YANG path element, and
 // the string format is invalid, simply returns the argument. For example,
 // removing foo from "foo:bar". Such qualified paths are used in YANG modules
-// where remote paths are referenced.
+// where remote paths are referenced. The member name of RFC7952 metadata for
+// a node, "@foo:bar", is stripped to "@bar".
 func StripModulePrefix(name string) string {
+	if len(name) > 1 && strings.HasPrefix(name, "@") {
+		return "@" + StripModulePrefix(name[1:])
+	}
 	ps := strings.Split(name, ":")
 	switch len(ps) {
 	case 1:
@@ -591,6 +591,14 @@ func TestStripModulePrefix(t *testing.T) {
 		desc:     "empty string",
 		inName:   "",
 		wantName: "",
+	}, {
+		desc:     "metadata member with prefix",
+		inName:   "@one:two",
+		wantName: "@two",
+	}, {
+		desc:     "metadata member of container",
+		inName:   "@",
+		wantName: "@",
 	}}
 
 	for _, tt := range tests {
@@ -41,6 +41,40 @@ func IsTypeStructPtr(t reflect.Type) bool {
 	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
 }
 
+// yangAnydata is the interface implemented by the type that stores the
+// contents of YANG anydata and anyxml nodes within GoStructs.
+type yangAnydata interface {
+	IsYANGAnydata()
+}
+
+// IsTypeAnydata reports whether t is the type that stores the contents of YANG
+// anydata and anyxml nodes within GoStructs. Such values are nodes of the data
+// tree without children, and hence are not recursed into as containers.
+func IsTypeAnydata(t reflect.Type) bool {
+	if t == reflect.TypeOf(nil) {
+		return false
+	}
+	return t.Implements(reflect.TypeOf((*yangAnydata)(nil)).Elem())
+}
+
+// yangOrderedMap is the interface implemented by the types that are
+// generated for YANG lists that are "ordered-by user", which store the entries
+// of the list in the order that is specified by the user.
+type yangOrderedMap interface {
+	IsYANGOrderedList()
+}
+
+// IsTypeOrderedMap reports whether t is an ordered map type that is generated
+// for a YANG list that is "ordered-by user". Such a type is a struct ptr
+// whose entries are accessed through its Keys, Values and Append methods,
+// rather than through its fields.
+func IsTypeOrderedMap(t reflect.Type) bool {
+	if t == reflect.TypeOf(nil) {
+		return false
+	}
+	return t.Implements(reflect.TypeOf((*yangOrderedMap)(nil)).Elem())
+}
+
 // IsTypeSlice reports whether v is a slice type.
 func IsTypeSlice(t reflect.Type) bool {
 	return t.Kind() == reflect.Slice
@@ -134,6 +168,12 @@ func IsValueMap(v reflect.Value) bool {
 	return v.Kind() == reflect.Map
 }
 
+// IsValueOrderedMap reports whether v is an ordered map that is generated for
+// a YANG list that is "ordered-by user".
+func IsValueOrderedMap(v reflect.Value) bool {
+	return v.IsValid() && IsTypeOrderedMap(v.Type())
+}
+
 // IsValueSlice reports whether v is a slice type.
 func IsValueSlice(v reflect.Value) bool {
 	return v.Kind() == reflect.Slice
@@ -208,6 +248,130 @@ func InsertIntoMap(parentMap interface{}, key interface{}, value interface{}) er
 	return nil
 }
 
+// OrderedMapElemType returns the type of the entries of the ordered map type
+// t, which is the return type of the elements of its Values method.
+func OrderedMapElemType(t reflect.Type) (reflect.Type, error) {
+	m, ok := t.MethodByName("Values")
+	if !IsTypeOrderedMap(t) || !ok || m.Type.NumOut() != 1 || !IsTypeSlice(m.Type.Out(0)) {
+		return nil, fmt.Errorf("%v is not an ordered map type", t)
+	}
+	return m.Type.Out(0).Elem(), nil
+}
+
+// OrderedMapKeyType returns the type of the keys of the ordered map type t,
+// which is the return type of the elements of its Keys method.
+func OrderedMapKeyType(t reflect.Type) (reflect.Type, error) {
+	m, ok := t.MethodByName("Keys")
+	if !IsTypeOrderedMap(t) || !ok || m.Type.NumOut() != 1 || !IsTypeSlice(m.Type.Out(0)) {
+		return nil, fmt.Errorf("%v is not an ordered map type", t)
+	}
+	return m.Type.Out(0).Elem(), nil
+}
+
+// OrderedMapKeys returns the keys of the ordered map v, in the order of the
+// entries of the ordered map.
+func OrderedMapKeys(v reflect.Value) ([]reflect.Value, error) {
+	return orderedMapSlice(v, "Keys")
+}
+
+// OrderedMapValues returns the entries of the ordered map v, in order.
+func OrderedMapValues(v reflect.Value) ([]reflect.Value, error) {
+	return orderedMapSlice(v, "Values")
+}
+
+// orderedMapSlice calls the method with the supplied name, which must return
+// a slice, on the ordered map v and returns the elements of the slice.
+func orderedMapSlice(v reflect.Value, name string) ([]reflect.Value, error) {
+	if !IsValueOrderedMap(v) {
+		return nil, fmt.Errorf("%v is not an ordered map", v.Type())
+	}
+	m := v.MethodByName(name)
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 || !IsTypeSlice(m.Type().Out(0)) {
+		return nil, fmt.Errorf("ordered map %v does not have method %s", v.Type(), name)
+	}
+	sv := m.Call(nil)[0]
+	var out []reflect.Value
+	for i := 0; i < sv.Len(); i++ {
+		out = append(out, sv.Index(i))
+	}
+	return out, nil
+}
+
+// OrderedMapToMap returns a map containing the entries of the ordered map v,
+// keyed by their keys within v. The order of the entries is not retained.
+func OrderedMapToMap(v reflect.Value) (reflect.Value, error) {
+	keys, err := OrderedMapKeys(v)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	vals, err := OrderedMapValues(v)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if len(keys) != len(vals) {
+		return reflect.Value{}, fmt.Errorf("ordered map %v has %d keys, but %d values", v.Type(), len(keys), len(vals))
+	}
+	keyT, err := OrderedMapKeyType(v.Type())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	elemT, err := OrderedMapElemType(v.Type())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	m := reflect.MakeMapWithSize(reflect.MapOf(keyT, elemT), len(keys))
+	for i, k := range keys {
+		m.SetMapIndex(k, vals[i])
+	}
+	return m, nil
+}
+
+// AppendIntoOrderedMap appends value, which must be a struct ptr, as the last
+// entry of orderedMap, which must be a non-nil ordered map. The key of the
+// entry is determined from the key fields of value.
+func AppendIntoOrderedMap(orderedMap interface{}, value interface{}) error {
+	DbgPrint("AppendIntoOrderedMap into parent type %T with value \n%s\n (%T)",
+		orderedMap, pretty.Sprint(value), value)
+
+	v := reflect.ValueOf(orderedMap)
+	if !IsValueOrderedMap(v) || IsValueNil(orderedMap) {
+		return fmt.Errorf("AppendIntoOrderedMap parent type is %T, must be non-nil ordered map", orderedMap)
+	}
+	m := v.MethodByName("Append")
+	if !m.IsValid() || m.Type().NumIn() != 1 || m.Type().NumOut() != 1 {
+		return fmt.Errorf("ordered map %T does not have method Append", orderedMap)
+	}
+	vv := reflect.ValueOf(value)
+	if !vv.IsValid() || !vv.Type().AssignableTo(m.Type().In(0)) {
+		return fmt.Errorf("cannot append value of type %T to ordered map %T", value, orderedMap)
+	}
+	if err, _ := m.Call([]reflect.Value{vv})[0].Interface().(error); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteFromOrderedMap deletes the entry with the supplied key from
+// orderedMap, which must be a non-nil ordered map. It reports whether such an
+// entry existed.
+func DeleteFromOrderedMap(orderedMap interface{}, key interface{}) (bool, error) {
+	DbgPrint("DeleteFromOrderedMap from parent type %T with key %v", orderedMap, key)
+
+	v := reflect.ValueOf(orderedMap)
+	if !IsValueOrderedMap(v) || IsValueNil(orderedMap) {
+		return false, fmt.Errorf("DeleteFromOrderedMap parent type is %T, must be non-nil ordered map", orderedMap)
+	}
+	m := v.MethodByName("Delete")
+	if !m.IsValid() || m.Type().NumIn() != 1 || m.Type().NumOut() != 1 || m.Type().Out(0).Kind() != reflect.Bool {
+		return false, fmt.Errorf("ordered map %T does not have method Delete", orderedMap)
+	}
+	kv := reflect.ValueOf(key)
+	if !kv.IsValid() || !kv.Type().AssignableTo(m.Type().In(0)) {
+		return false, fmt.Errorf("cannot delete key of type %T from ordered map %T", key, orderedMap)
+	}
+	return m.Call([]reflect.Value{kv})[0].Bool(), nil
+}
+
 // UpdateField updates a field called fieldName (which must exist, but may be
 // nil) in parentStruct, with value fieldValue. If the field is a slice,
 // fieldValue is appended.
@@ -556,6 +720,9 @@ type NodeInfo struct {
 	// Annotation is a field that can be populated by an iterFunction such that
 	// context can be carried with a node throughout the iteration.
 	Annotation []interface{}
+	// SkipChildren can be set by an iterFunction to indicate that the
+	// children of the node should not be iterated.
+	SkipChildren bool
 }
 
 // PathQueryMemo caches nodes retrieved from (string) path queries. This memo
@@ -644,6 +811,56 @@ func forEachFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction FieldI
 	t := v.Type()
 
 	switch {
+	case IsTypeAnydata(t):
+		// Anydata has no children within the schema.
+		return errs
+	case IsTypeOrderedMap(t):
+		// An ordered map is a keyed list whose entries are iterated in the
+		// order in which they are stored.
+		schema := *(ni.Schema)
+		schema.ListAttr = nil
+		if IsNilOrInvalidValue(v) {
+			et, err := OrderedMapElemType(t)
+			if err != nil {
+				return AppendErr(errs, err)
+			}
+			nn := &NodeInfo{
+				Parent:         ni,
+				PathFromParent: []string{schema.Name},
+				Schema:         &schema,
+				FieldValue:     reflect.Zero(et),
+			}
+			switch in.(type) {
+			case *PathQueryNodeMemo: // Memoization of path queries requested.
+				errs = AppendErrs(errs, forEachFieldInternal(nn, newPathQueryMemo(), out, iterFunction))
+			default:
+				errs = AppendErrs(errs, forEachFieldInternal(nn, in, out, iterFunction))
+			}
+			return errs
+		}
+		keys, err := OrderedMapKeys(v)
+		if err != nil {
+			return AppendErr(errs, err)
+		}
+		vals, err := OrderedMapValues(v)
+		if err != nil {
+			return AppendErr(errs, err)
+		}
+		for i, val := range vals {
+			nn := *ni
+			nn.Schema = &schema
+			nn.Parent = ni
+			nn.PathFromParent = []string{schema.Name}
+			nn.FieldValue = val
+			nn.FieldKey = keys[i]
+			nn.FieldKeys = keys
+			switch in.(type) {
+			case *PathQueryNodeMemo: // Memoization of path queries requested.
+				errs = AppendErrs(errs, forEachFieldInternal(&nn, newPathQueryMemo(), out, iterFunction))
+			default:
+				errs = AppendErrs(errs, forEachFieldInternal(&nn, in, out, iterFunction))
+			}
+		}
 	case IsTypeStructPtr(t):
 		t = t.Elem()
 		if !IsNilOrInvalidValue(v) {
@@ -684,7 +901,7 @@ func forEachFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction FieldI
 				// In the case of a map/slice, the path is of the form
 				// "container/element" in the compressed schema, so trim off
 				// any extra path elements in this case.
-				if IsTypeSlice(sf.Type) || IsTypeMap(sf.Type) {
+				if IsTypeSlice(sf.Type) || IsTypeMap(sf.Type) || IsTypeOrderedMap(sf.Type) {
 					nn.PathFromParent = p[0:1]
 				}
 				switch in.(type) {
@@ -753,14 +970,15 @@ func forEachFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction FieldI
 				errs = AppendErrs(errs, forEachFieldInternal(nn, in, out, iterFunction))
 			}
 		} else {
-			for _, key := range ni.FieldValue.MapKeys() {
+			keys := ni.FieldValue.MapKeys()
+			for _, key := range keys {
 				nn := *ni
 				nn.Schema = &schema
 				nn.Parent = ni
 				nn.PathFromParent = []string{schema.Name}
 				nn.FieldValue = ni.FieldValue.MapIndex(key)
 				nn.FieldKey = key
-				nn.FieldKeys = ni.FieldValue.MapKeys()
+				nn.FieldKeys = keys
 				switch in.(type) {
 				case *PathQueryNodeMemo: // Memoization of path queries requested.
 					errs = AppendErrs(errs, forEachFieldInternal(&nn, newPathQueryMemo(), out, iterFunction))
@@ -801,6 +1019,9 @@ func forEachDataFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction Fi
 	var errs Errors
 	// Run the iterator function for this field.
 	errs = AppendErrs(errs, iterFunction(ni, in, out))
+	if ni.SkipChildren {
+		return errs
+	}
 
 	v := ni.FieldValue
 	t := v.Type()
@@ -809,6 +1030,29 @@ func forEachDataFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction Fi
 	// a leaf or leaf-list, which are not recursed into when traversing the
 	// data tree.
 	switch {
+	case IsTypeAnydata(t):
+		// Anydata is not a container, and its contents are not described
+		// by the GoStruct, hence it is not recursed into.
+		return errs
+	case IsTypeOrderedMap(t):
+		// Handle the case of an ordered map, which is a YANG list that is
+		// ordered-by user, in the order of its entries.
+		keys, err := OrderedMapKeys(v)
+		if err != nil {
+			return AppendErr(errs, err)
+		}
+		vals, err := OrderedMapValues(v)
+		if err != nil {
+			return AppendErr(errs, err)
+		}
+		for i, val := range vals {
+			nn := *ni
+			nn.Parent = ni
+			nn.FieldValue = val
+			nn.FieldKey = keys[i]
+			nn.FieldKeys = keys
+			errs = AppendErrs(errs, forEachDataFieldInternal(&nn, in, out, iterFunction))
+		}
 	case IsTypeStructPtr(t):
 		// A struct pointer in a GoStruct is a pointer to another container within
 		// the YANG, therefore we dereference the pointer and then recurse. If the
@@ -839,7 +1083,7 @@ func forEachDataFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction Fi
 			// fields.
 			for _, p := range ps {
 				nn.PathFromParent = p
-				if IsTypeSlice(sf.Type) || IsTypeMap(sf.Type) {
+				if IsTypeSlice(sf.Type) || IsTypeMap(sf.Type) || IsTypeOrderedMap(sf.Type) {
 					// Since lists can have path compression - where the path contains more
 					// than one element, ensure that the schema path we received is only two
 					// elements long. This protects against compression errors where there are
@@ -871,12 +1115,16 @@ func forEachDataFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction Fi
 		if IsNilOrInvalidValue(v) {
 			return errs
 		}
-		for _, key := range ni.FieldValue.MapKeys() {
+		// The keys of the map are retrieved once, and shared between its
+		// entries, since retrieving them for each entry is quadratic in the
+		// size of the map.
+		keys := ni.FieldValue.MapKeys()
+		for _, key := range keys {
 			nn := *ni
 			nn.Parent = ni
 			nn.FieldValue = ni.FieldValue.MapIndex(key)
 			nn.FieldKey = key
-			nn.FieldKeys = ni.FieldValue.MapKeys()
+			nn.FieldKeys = keys
 			errs = AppendErrs(errs, forEachDataFieldInternal(&nn, in, out, iterFunction))
 		}
 	}
@@ -922,7 +1170,7 @@ func getNodesInternal(schema *yang.Entry, root interface{}, path *gpb.Path) ([]i
 	DbgPrint("GetNode next path %v, value %v", path.GetElem()[0], ValueStrDebug(root))
 
 	switch {
-	case schema.IsContainer() || (schema.IsList() && IsTypeStructPtr(reflect.TypeOf(root))):
+	case schema.IsContainer() || (schema.IsList() && IsTypeStructPtr(reflect.TypeOf(root)) && !IsTypeOrderedMap(reflect.TypeOf(root))):
 		// Either a container or list schema with struct data node (which could
 		// be an element of a list).
 		return getNodesContainer(schema, root, path)
@@ -974,7 +1222,7 @@ func getNodesContainer(schema *yang.Entry, root interface{}, path *gpb.Path) ([]
 				// don't trim whole prefix  for keyed list since name and key
 				// are a in the same element.
 				to := len(p)
-				if IsTypeMap(ft.Type) {
+				if IsTypeMap(ft.Type) || IsTypeOrderedMap(ft.Type) {
 					to--
 				}
 				return getNodesInternal(cschema, f.Interface(), TrimGNMIPathPrefix(path, p[0:to]))
@@ -986,7 +1234,7 @@ func getNodesContainer(schema *yang.Entry, root interface{}, path *gpb.Path) ([]
 }
 
 // getNodesList traverses the list root, which must be a map of struct
-// type and matches each map key against the keys specified in the first
+// type, or an ordered map, and matches each map key against the keys specified in the first
 // PathElem of the Path. If the key matches, it recurses into that field with
 // the remaining path. If empty key is specified, all list elements match.
 func getNodesList(schema *yang.Entry, root interface{}, path *gpb.Path) ([]interface{}, []*yang.Entry, error) {
@@ -996,6 +1244,13 @@ func getNodesList(schema *yang.Entry, root interface{}, path *gpb.Path) ([]inter
 	if schema.Key == "" {
 		return nil, nil, fmt.Errorf("getNodesList: path %v cannot traverse unkeyed list type %T", path, root)
 	}
+	if IsValueOrderedMap(rv) {
+		m, err := OrderedMapToMap(rv)
+		if err != nil {
+			return nil, nil, err
+		}
+		rv = m
+	}
 	if !IsValueMap(rv) {
 		// Only keyed lists can be traversed with a path.
 		return nil, nil, fmt.Errorf("getNodesList: root has type %T, expect map", root)
@@ -1347,6 +1347,196 @@ func TestForEachDataField(t *testing.T) {
 	}
 }
 
+// basicStructOrderedMap is an ordered map of BasicStruct entries, keyed by
+// their StringField, which mirrors the ordered maps generated by ygen.
+type basicStructOrderedMap struct {
+	keys     []string
+	valueMap map[string]*BasicStruct
+}
+
+func (*basicStructOrderedMap) IsYANGOrderedList() {}
+
+func (o *basicStructOrderedMap) Keys() []string {
+	if o == nil {
+		return nil
+	}
+	return append([]string{}, o.keys...)
+}
+
+func (o *basicStructOrderedMap) Values() []*BasicStruct {
+	if o == nil {
+		return nil
+	}
+	var values []*BasicStruct
+	for _, k := range o.keys {
+		values = append(values, o.valueMap[k])
+	}
+	return values
+}
+
+func (o *basicStructOrderedMap) Delete(key string) bool {
+	if _, ok := o.valueMap[key]; !ok {
+		return false
+	}
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i:i], o.keys[i+1:]...)
+			break
+		}
+	}
+	delete(o.valueMap, key)
+	return true
+}
+
+func (o *basicStructOrderedMap) Append(v *BasicStruct) error {
+	if _, ok := o.valueMap[v.StringField]; ok {
+		return fmt.Errorf("duplicate key %s", v.StringField)
+	}
+	if o.valueMap == nil {
+		o.valueMap = map[string]*BasicStruct{}
+	}
+	o.keys = append(o.keys, v.StringField)
+	o.valueMap[v.StringField] = v
+	return nil
+}
+
+type StructOfOrderedMapOfStructs struct {
+	BasicStructOrderedMapField *basicStructOrderedMap `path:"basic-struct"`
+}
+
+func TestOrderedMapFuncs(t *testing.T) {
+	om := &basicStructOrderedMap{}
+	for _, k := range []string{"b", "c", "a"} {
+		if err := AppendIntoOrderedMap(om, &BasicStruct{StringField: k}); err != nil {
+			t.Fatalf("AppendIntoOrderedMap(%s): got unexpected error, %v", k, err)
+		}
+	}
+
+	if !IsTypeOrderedMap(reflect.TypeOf(om)) || !IsValueOrderedMap(reflect.ValueOf(om)) {
+		t.Errorf("IsTypeOrderedMap/IsValueOrderedMap(%T): got false, want true", om)
+	}
+	if IsTypeOrderedMap(reflect.TypeOf(&BasicStruct{})) {
+		t.Errorf("IsTypeOrderedMap(%T): got true, want false", &BasicStruct{})
+	}
+
+	keys, err := OrderedMapKeys(reflect.ValueOf(om))
+	if err != nil {
+		t.Fatalf("OrderedMapKeys: got unexpected error, %v", err)
+	}
+	var gotKeys []string
+	for _, k := range keys {
+		gotKeys = append(gotKeys, k.String())
+	}
+	if diff := cmp.Diff([]string{"b", "c", "a"}, gotKeys); diff != "" {
+		t.Errorf("OrderedMapKeys: did not get expected keys, diff(-want, +got):\n%s", diff)
+	}
+
+	vals, err := OrderedMapValues(reflect.ValueOf(om))
+	if err != nil {
+		t.Fatalf("OrderedMapValues: got unexpected error, %v", err)
+	}
+	if len(vals) != 3 || vals[0].Interface() != om.valueMap["b"] {
+		t.Errorf("OrderedMapValues: did not get expected values, got: %v", vals)
+	}
+
+	if got, err := OrderedMapElemType(reflect.TypeOf(om)); err != nil || got != reflect.TypeOf(&BasicStruct{}) {
+		t.Errorf("OrderedMapElemType: got %v, %v, want %T, nil", got, err, &BasicStruct{})
+	}
+	if got, err := OrderedMapKeyType(reflect.TypeOf(om)); err != nil || got != reflect.TypeOf("") {
+		t.Errorf("OrderedMapKeyType: got %v, %v, want string, nil", got, err)
+	}
+
+	m, err := OrderedMapToMap(reflect.ValueOf(om))
+	if err != nil {
+		t.Fatalf("OrderedMapToMap: got unexpected error, %v", err)
+	}
+	if diff := cmp.Diff(om.valueMap, m.Interface()); diff != "" {
+		t.Errorf("OrderedMapToMap: did not get expected map, diff(-want, +got):\n%s", diff)
+	}
+
+	if err := AppendIntoOrderedMap(om, &BasicStruct{StringField: "a"}); err == nil {
+		t.Errorf("AppendIntoOrderedMap: did not get expected error for duplicate key")
+	}
+	if err := AppendIntoOrderedMap(om, "a"); err == nil {
+		t.Errorf("AppendIntoOrderedMap: did not get expected error for invalid value")
+	}
+	if err := AppendIntoOrderedMap((*basicStructOrderedMap)(nil), &BasicStruct{}); err == nil {
+		t.Errorf("AppendIntoOrderedMap: did not get expected error for nil ordered map")
+	}
+
+	if ok, err := DeleteFromOrderedMap(om, "c"); err != nil || !ok {
+		t.Errorf("DeleteFromOrderedMap(c): got %v, %v, want true, nil", ok, err)
+	}
+	if ok, err := DeleteFromOrderedMap(om, "c"); err != nil || ok {
+		t.Errorf("DeleteFromOrderedMap(c) of deleted entry: got %v, %v, want false, nil", ok, err)
+	}
+	if diff := cmp.Diff([]string{"b", "a"}, om.Keys()); diff != "" {
+		t.Errorf("DeleteFromOrderedMap: did not get expected keys, diff(-want, +got):\n%s", diff)
+	}
+	if _, err := DeleteFromOrderedMap(om, 42); err == nil {
+		t.Errorf("DeleteFromOrderedMap: did not get expected error for invalid key")
+	}
+	if _, err := DeleteFromOrderedMap(map[string]*BasicStruct{}, "a"); err == nil {
+		t.Errorf("DeleteFromOrderedMap: did not get expected error for map")
+	}
+}
+
+func TestForEachDataFieldOrderedMap(t *testing.T) {
+	om := &basicStructOrderedMap{}
+	for _, k := range []string{"b", "c", "a"} {
+		if err := om.Append(&BasicStruct{StringField: k, Int32Field: int32(len(k))}); err != nil {
+			t.Fatalf("cannot append %s, %v", k, err)
+		}
+	}
+
+	var got []string
+	errs := ForEachDataField(&StructOfOrderedMapOfStructs{BasicStructOrderedMapField: om}, nil, nil, func(ni *NodeInfo, in, out interface{}) Errors {
+		if !IsNilOrInvalidValue(ni.FieldKey) {
+			got = append(got, fmt.Sprintf("%v", ni.FieldKey.Interface()))
+		}
+		if ni.StructField.Name == "StringField" {
+			got = append(got, fmt.Sprintf("%s=%v", ni.PathFromParent, ni.FieldValue.Interface()))
+		}
+		return nil
+	})
+	if errs != nil {
+		t.Fatalf("ForEachDataField: got unexpected errors, %v", errs)
+	}
+	want := []string{"b", "[string]=b", "c", "[string]=c", "a", "[string]=a"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ForEachDataField: did not iterate ordered map in order, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestForEachDataFieldSkipChildren(t *testing.T) {
+	om := &basicStructOrderedMap{}
+	for _, k := range []string{"b", "a"} {
+		if err := om.Append(&BasicStruct{StringField: k}); err != nil {
+			t.Fatalf("cannot append %s, %v", k, err)
+		}
+	}
+
+	var got []string
+	errs := ForEachDataField(&StructOfOrderedMapOfStructs{BasicStructOrderedMapField: om}, nil, nil, func(ni *NodeInfo, in, out interface{}) Errors {
+		if !IsNilOrInvalidValue(ni.FieldKey) {
+			got = append(got, fmt.Sprintf("%v", ni.FieldKey.Interface()))
+			// Only the fields of entry a are iterated.
+			ni.SkipChildren = ni.FieldKey.Interface() != "a"
+		}
+		if ni.StructField.Name == "StringField" {
+			got = append(got, fmt.Sprintf("%s=%v", ni.PathFromParent, ni.FieldValue.Interface()))
+		}
+		return nil
+	})
+	if errs != nil {
+		t.Fatalf("ForEachDataField: got unexpected errors, %v", errs)
+	}
+	want := []string{"b", "a", "[string]=a"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ForEachDataField: did not skip the children of entry b, diff(-want, +got):\n%s", diff)
+	}
+}
+
 func TestUpdateFieldUsingForEachField(t *testing.T) {
 	type BasicStruct struct {
 		Int32Field     int32   `path:"int32"`
@@ -38,12 +38,12 @@ var (
 const CompressedSchemaAnnotation string = "isCompressedSchema"
 
 // Children returns all child elements of a directory element e that are not
-// RPC entries.
+// RPC, action or notification entries.
 func Children(e *yang.Entry) []*yang.Entry {
 	var entries []*yang.Entry
 
 	for _, e := range e.Dir {
-		if e.RPC == nil {
+		if !IsRPCOrAction(e) && !IsNotification(e) {
 			entries = append(entries, e)
 		}
 	}
@@ -110,12 +110,63 @@ func IsEnumeratedType(t *yang.YangType) bool {
 	return t.Kind == yang.Yenum || t.Kind == yang.Yidentityref
 }
 
-// IsAnydata returns true if the entry is an Anydata node.
+// IsAnydata returns true if the entry is an Anydata or AnyXML node, whose
+// contents are not described by the schema.
 func IsAnydata(e *yang.Entry) bool {
 	if e == nil {
 		return false
 	}
-	return e.Kind == yang.AnyDataEntry
+	return e.Kind == yang.AnyDataEntry || e.Kind == yang.AnyXMLEntry
+}
+
+// IsRPCOrAction reports whether the entry e corresponds to a YANG rpc or
+// action statement. An action without an input or output statement does not
+// have its RPC field populated, hence the type of its node is also checked.
+func IsRPCOrAction(e *yang.Entry) bool {
+	if e == nil {
+		return false
+	}
+	if e.RPC != nil {
+		return true
+	}
+	switch e.Node.(type) {
+	case *yang.RPC, *yang.Action:
+		return true
+	}
+	return false
+}
+
+// IsAction reports whether the entry e corresponds to a YANG action, i.e.,
+// an operation that is defined within a data node rather than at the top
+// level of a module.
+func IsAction(e *yang.Entry) bool {
+	if !IsRPCOrAction(e) {
+		return false
+	}
+	if _, ok := e.Node.(*yang.Action); ok {
+		return true
+	}
+	return e.Parent != nil && !IsRoot(e.Parent)
+}
+
+// IsRPCInputOrOutput reports whether the entry e is the input or output of a
+// YANG rpc or action. Such entries contain data nodes, and are handled in the
+// same way as a container.
+func IsRPCInputOrOutput(e *yang.Entry) bool {
+	if e == nil {
+		return false
+	}
+	return e.Kind == yang.InputEntry || e.Kind == yang.OutputEntry
+}
+
+// IsNotification reports whether the entry e corresponds to a YANG
+// notification statement. The entry contains the data nodes of the
+// notification, and is handled in the same way as a container.
+func IsNotification(e *yang.Entry) bool {
+	if e == nil {
+		return false
+	}
+	return e.Kind == yang.NotificationEntry
 }
 
 // IsLeafRef reports whether schema is a leafref schema node type.
@@ -146,6 +197,15 @@ func IsKeyedList(e *yang.Entry) bool {
 	return e.IsList() && e.Key != ""
 }
 
+// IsOrderedByUser reports whether e is a list or leaf-list whose entries are
+// "ordered-by user", such that the order of its entries is significant.
+func IsOrderedByUser(e *yang.Entry) bool {
+	if e == nil || e.ListAttr == nil || e.ListAttr.OrderedBy == nil {
+		return false
+	}
+	return e.ListAttr.OrderedBy.Name == "user"
+}
+
 // IsUnkeyedList reports whether e is an unkeyed list.
 func IsUnkeyedList(e *yang.Entry) bool {
 	if e == nil {
@@ -548,3 +608,479 @@ func ListKeyFieldsMap(e *yang.Entry) map[string]bool {
 	}
 	return r
 }
+
+const (
+	// MustAnnotation is the name of the annotation within which ygen stores
+	// the must statements of a yang.Entry, since the Node of the entry is not
+	// retained when the schema is serialised into the generated code.
+	MustAnnotation string = "must"
+	// WhenAnnotation is the name of the annotation within which ygen stores
+	// the when statement of a yang.Entry.
+	WhenAnnotation string = "when"
+	// InheritedWhenAnnotation is the name of the annotation within which
+	// ygen stores the when statements of the augment and uses statements
+	// through which a yang.Entry was added to its parent.
+	InheritedWhenAnnotation string = "inherited-when"
+	// UniqueAnnotation is the name of the annotation within which ygen stores
+	// the arguments of the unique statements of a list yang.Entry.
+	UniqueAnnotation string = "unique"
+	// PresenceAnnotation is the name of the annotation within which ygen
+	// stores the argument of the presence statement of a container
+	// yang.Entry.
+	PresenceAnnotation string = "presence"
+	// ModuleNamespacesAnnotation is the name of the annotation within which
+	// ygen stores, on the root of a serialised schema tree, the XML namespace
+	// of each YANG module, keyed by the name of the module.
+	ModuleNamespacesAnnotation string = "module-namespaces"
+	// ModulePrefixesAnnotation is the name of the annotation within which
+	// ygen stores, on the root of a serialised schema tree, the modules that
+	// the prefixes used within each YANG module refer to, keyed by the name
+	// of the module and then by the prefix.
+	ModulePrefixesAnnotation string = "module-prefixes"
+	// ModuleAnnotation is the name of the annotation within which ygen
+	// stores, on each directory entry of a serialised schema tree, the name
+	// of the module that instantiates the entry.
+	ModuleAnnotation string = "module"
+	// MetadataAnnotationsAnnotation is the name of the annotation within
+	// which ygen stores, on the root of a serialised schema tree, the
+	// metadata annotations defined using the RFC7952 md:annotation extension
+	// by each YANG module.
+	MetadataAnnotationsAnnotation string = "metadata-annotations"
+	// metadataModule is the name of the YANG module that defines the
+	// md:annotation extension.
+	metadataModule string = "ietf-yang-metadata"
+)
+
+// MustStatement is the serialisable form of a YANG must statement.
+type MustStatement struct {
+	// XPath is the XPath expression of the must statement.
+	XPath string `json:"xpath"`
+	// ErrorMessage is the error-message substatement of the must
+	// statement, if one is specified.
+	ErrorMessage string `json:"error-message,omitempty"`
+}
+
+// MustStatements returns the must statements of the supplied yang.Entry. The
+// statements are taken from the YANG node that the entry was created from
+// where it is available, or otherwise from the MustAnnotation added by ygen.
+func MustStatements(e *yang.Entry) []*MustStatement {
+	if e == nil {
+		return nil
+	}
+
+	var musts []*yang.Must
+	switch n := e.Node.(type) {
+	case *yang.Container:
+		musts = n.Must
+	case *yang.Leaf:
+		musts = n.Must
+	case *yang.LeafList:
+		musts = n.Must
+	case *yang.List:
+		musts = n.Must
+	case *yang.AnyData:
+		musts = n.Must
+	case *yang.AnyXML:
+		musts = n.Must
+	}
+	if len(musts) != 0 {
+		var out []*MustStatement
+		for _, m := range musts {
+			ms := &MustStatement{XPath: m.Name}
+			if m.ErrorMessage != nil {
+				ms.ErrorMessage = m.ErrorMessage.Name
+			}
+			out = append(out, ms)
+		}
+		return out
+	}
+
+	switch a := e.Annotation[MustAnnotation].(type) {
+	case []*MustStatement:
+		return a
+	case []interface{}:
+		// The annotation has been unmarshalled from a JSON schema.
+		var out []*MustStatement
+		for _, m := range a {
+			mm, ok := m.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ms := &MustStatement{}
+			ms.XPath, _ = mm["xpath"].(string)
+			ms.ErrorMessage, _ = mm["error-message"].(string)
+			out = append(out, ms)
+		}
+		return out
+	}
+	return nil
+}
+
+// WhenStatement returns the XPath expression of the when statement of the
+// supplied yang.Entry, and whether the entry has a when statement. The
+// statement is taken from the YANG node that the entry was created from where
+// it is available, or otherwise from the WhenAnnotation added by ygen.
+func WhenStatement(e *yang.Entry) (string, bool) {
+	if e == nil {
+		return "", false
+	}
+	if w, ok := e.GetWhenXPath(); ok {
+		return w, true
+	}
+	w, ok := e.Annotation[WhenAnnotation].(string)
+	return w, ok
+}
+
+// InheritedWhenStatements returns the XPath expressions of the when statements
+// of the augment and uses statements through which the supplied yang.Entry
+// was added to its parent. Unlike the when statement of the entry itself, the
+// context node of these expressions is the closest ancestor data node of the
+// entry. The statements are taken from the InheritedWhenAnnotation added by
+// ygen where it is present, since the augment and uses statements are not
+// retained when the schema is serialised into the generated code. Otherwise,
+// they are taken from the YANG nodes of the augments merged into the parent of
+// the entry, and of its uses statements, which are only available where the
+// entry was parsed with the StoreUses option of the goyang library set.
+func InheritedWhenStatements(e *yang.Entry) []string {
+	if e == nil {
+		return nil
+	}
+	switch a := e.Annotation[InheritedWhenAnnotation].(type) {
+	case []string:
+		return a
+	case []interface{}:
+		// The annotation has been unmarshalled from a JSON schema.
+		var out []string
+		for _, w := range a {
+			if s, ok := w.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+
+	var out []string
+	for _, n := range augmentAndUsesNodes(e) {
+		var w *yang.Value
+		switch n := n.(type) {
+		case *yang.Augment:
+			w = n.When
+		case *yang.Uses:
+			w = n.When
+		}
+		if w != nil {
+			out = append(out, w.Name)
+		}
+	}
+	return out
+}
+
+// IsPresenceContainer reports whether the supplied yang.Entry is a presence
+// container. The presence statement is taken from the YANG node that the entry
+// was created from where it is available, or otherwise from the
+// PresenceAnnotation added by ygen.
+func IsPresenceContainer(e *yang.Entry) bool {
+	if e == nil || !e.IsContainer() {
+		return false
+	}
+	if c, ok := e.Node.(*yang.Container); ok {
+		return c.Presence != nil
+	}
+	if len(e.Extra["presence"]) != 0 {
+		return true
+	}
+	_, ok := e.Annotation[PresenceAnnotation].(string)
+	return ok
+}
+
+// UniqueStatements returns the arguments of the unique statements of the
+// supplied list yang.Entry. Each argument is a space-separated set of
+// descendant schema node identifiers. The statements are taken from the YANG
+// node that the entry was created from where it is available, or otherwise
+// from the UniqueAnnotation added by ygen.
+func UniqueStatements(e *yang.Entry) []string {
+	if e == nil {
+		return nil
+	}
+	if l, ok := e.Node.(*yang.List); ok {
+		var out []string
+		for _, u := range l.Unique {
+			out = append(out, u.Name)
+		}
+		return out
+	}
+
+	switch a := e.Annotation[UniqueAnnotation].(type) {
+	case []string:
+		return a
+	case []interface{}:
+		// The annotation has been unmarshalled from a JSON schema.
+		var out []string
+		for _, u := range a {
+			if us, ok := u.(string); ok {
+				out = append(out, us)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// ModuleNamespaces returns the XML namespace of each YANG module of the schema
+// tree that the supplied yang.Entry belongs to, keyed by the name of the
+// module. The namespaces are taken from the ModuleNamespacesAnnotation of the
+// root of the tree where it is present, as is the case for schemas serialised
+// by ygen, or otherwise from the set of modules that the root of the tree was
+// created from.
+func ModuleNamespaces(e *yang.Entry) map[string]string {
+	if e == nil {
+		return nil
+	}
+	root := e
+	for root.Parent != nil {
+		root = root.Parent
+	}
+
+	switch a := root.Annotation[ModuleNamespacesAnnotation].(type) {
+	case map[string]string:
+		return a
+	case map[string]interface{}:
+		// The annotation has been unmarshalled from a JSON schema.
+		out := map[string]string{}
+		for m, ns := range a {
+			if nss, ok := ns.(string); ok {
+				out[m] = nss
+			}
+		}
+		return out
+	}
+
+	mod, ok := root.Node.(*yang.Module)
+	if !ok || mod == nil {
+		return nil
+	}
+	ms := root.Modules()
+	if ms == nil {
+		if mod.Namespace == nil {
+			return nil
+		}
+		return map[string]string{mod.Name: mod.Namespace.Name}
+	}
+	out := map[string]string{}
+	for _, m := range ms.Modules {
+		if m.Namespace != nil {
+			out[m.Name] = m.Namespace.Name
+		}
+	}
+	return out
+}
+
+// ModuleImportPrefixes returns the names of the modules that the prefixes used
+// within the supplied module refer to, keyed by prefix. The prefixes are the
+// module's own prefix, and those of the modules that it, or any submodule
+// that it includes, imports.
+func ModuleImportPrefixes(mod *yang.Module) map[string]string {
+	if mod == nil {
+		return nil
+	}
+	out := map[string]string{}
+	add := func(m *yang.Module) {
+		for _, i := range m.Import {
+			if i.Prefix != nil {
+				out[i.Prefix.Name] = i.Name
+			}
+		}
+	}
+	add(mod)
+	for _, i := range mod.Include {
+		if i.Module != nil {
+			add(i.Module)
+		}
+	}
+	if mod.Prefix != nil {
+		out[mod.Prefix.Name] = mod.Name
+	}
+	return out
+}
+
+// EntryModule returns the name of the YANG module that instantiates the
+// supplied yang.Entry within the schema tree. The module is taken from the
+// YANG node that the entry was created from where it is available, or
+// otherwise from the ModuleAnnotation added by ygen.
+func EntryModule(e *yang.Entry) (string, error) {
+	if e == nil {
+		return "", fmt.Errorf("nil entry")
+	}
+	if e.Node != nil {
+		return e.InstantiatingModule()
+	}
+	if m, ok := e.Annotation[ModuleAnnotation].(string); ok && m != "" {
+		return m, nil
+	}
+	return "", fmt.Errorf("cannot determine the module of %s", e.Name)
+}
+
+// ModulePrefixes returns, for each YANG module of the schema tree that the
+// supplied yang.Entry belongs to, the names of the modules that the prefixes
+// used within the module refer to, keyed by the name of the module and then
+// by the prefix. The prefixes are taken from the ModulePrefixesAnnotation of
+// the root of the tree where it is present, as is the case for schemas
+// serialised by ygen, or otherwise from the set of modules that the root of
+// the tree was created from.
+func ModulePrefixes(e *yang.Entry) map[string]map[string]string {
+	if e == nil {
+		return nil
+	}
+	root := e
+	for root.Parent != nil {
+		root = root.Parent
+	}
+
+	switch a := root.Annotation[ModulePrefixesAnnotation].(type) {
+	case map[string]map[string]string:
+		return a
+	case map[string]interface{}:
+		// The annotation has been unmarshalled from a JSON schema.
+		out := map[string]map[string]string{}
+		for m, ps := range a {
+			psm, ok := ps.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			out[m] = map[string]string{}
+			for p, pm := range psm {
+				if pms, ok := pm.(string); ok {
+					out[m][p] = pms
+				}
+			}
+		}
+		return out
+	}
+
+	mod, ok := root.Node.(*yang.Module)
+	if !ok || mod == nil {
+		return nil
+	}
+	ms := root.Modules()
+	if ms == nil {
+		return map[string]map[string]string{mod.Name: ModuleImportPrefixes(mod)}
+	}
+	out := map[string]map[string]string{}
+	for _, m := range ms.Modules {
+		out[m.Name] = ModuleImportPrefixes(m)
+	}
+	return out
+}
+
+// ModuleMetadataAnnotations returns the metadata annotations that are defined
+// using the RFC7952 md:annotation extension within the supplied module, keyed
+// by the module-qualified name of the annotation, e.g., "ietf-origin:origin".
+// The value of each annotation is the name of the YANG built-in type of the
+// annotation. Where the type is a typedef that is not defined within the
+// module, the name of the typedef is returned instead.
+func ModuleMetadataAnnotations(mod *yang.Module) map[string]string {
+	if mod == nil {
+		return nil
+	}
+	// The md:annotation extension is used with the prefix that the module
+	// imports ietf-yang-metadata with.
+	var prefix string
+	for _, i := range mod.Import {
+		if i.Name == metadataModule && i.Prefix != nil {
+			prefix = i.Prefix.Name
+		}
+	}
+	if prefix == "" {
+		return nil
+	}
+
+	out := map[string]string{}
+	for _, ext := range mod.Extensions {
+		if ext.Keyword != fmt.Sprintf("%s:annotation", prefix) {
+			continue
+		}
+		var t string
+		for _, s := range ext.SubStatements() {
+			if s.Keyword == "type" {
+				t = s.Argument
+			}
+		}
+		out[fmt.Sprintf("%s:%s", mod.Name, ext.Argument)] = metadataBuiltinType(mod, t)
+	}
+	return out
+}
+
+// metadataBuiltinType resolves the type t, used within the module mod, to the
+// name of a YANG built-in type by following the typedefs of mod. If t cannot
+// be resolved, it is returned unchanged.
+func metadataBuiltinType(mod *yang.Module, t string) string {
+	name := t
+	// Bound the number of typedefs that are followed, such that a cycle of
+	// typedefs does not cause an infinite loop.
+	for i := 0; i <= len(mod.Typedef); i++ {
+		if ps := strings.Split(name, ":"); len(ps) == 2 && mod.Prefix != nil && ps[0] == mod.Prefix.Name {
+			name = ps[1]
+		}
+		if _, ok := yang.TypeKindFromName[name]; ok {
+			return name
+		}
+		var next string
+		for _, td := range mod.Typedef {
+			if td.Name == name && td.Type != nil {
+				next = td.Type.Name
+			}
+		}
+		if next == "" {
+			break
+		}
+		name = next
+	}
+	return t
+}
+
+// MetadataAnnotations returns the metadata annotations of the schema tree that
+// the supplied yang.Entry belongs to, keyed by the module-qualified name of the
+// annotation, with the value being the name of the YANG built-in type of the
+// annotation. The annotations are taken from the MetadataAnnotationsAnnotation
+// of the root of the tree where it is present, as is the case for schemas
+// serialised by ygen, or otherwise from the set of modules that the root of the
+// tree was created from.
+func MetadataAnnotations(e *yang.Entry) map[string]string {
+	if e == nil {
+		return nil
+	}
+	root := e
+	for root.Parent != nil {
+		root = root.Parent
+	}
+
+	switch a := root.Annotation[MetadataAnnotationsAnnotation].(type) {
+	case map[string]string:
+		return a
+	case map[string]interface{}:
+		// The annotation has been unmarshalled from a JSON schema.
+		out := map[string]string{}
+		for n, t := range a {
+			if ts, ok := t.(string); ok {
+				out[n] = ts
+			}
+		}
+		return out
+	}
+
+	mod, ok := root.Node.(*yang.Module)
+	if !ok || mod == nil {
+		return nil
+	}
+	ms := root.Modules()
+	if ms == nil {
+		return ModuleMetadataAnnotations(mod)
+	}
+	out := map[string]string{}
+	for _, m := range ms.Modules {
+		for n, t := range ModuleMetadataAnnotations(m) {
+			out[n] = t
+		}
+	}
+	return out
+}
@@ -336,6 +336,30 @@ func TestYangChildren(t *testing.T) {
 			"state":  true,
 			"rpc":    false,
 		},
+	}, {
+		name: "test container with action entry without input or output",
+		inEntry: &yang.Entry{
+			Dir: map[string]*yang.Entry{
+				"action": {Name: "action", Node: &yang.Action{Name: "action"}},
+				"config": {Name: "config"},
+			},
+		},
+		wantChildNames: map[string]bool{
+			"config": true,
+			"action": false,
+		},
+	}, {
+		name: "test container with notification entry",
+		inEntry: &yang.Entry{
+			Dir: map[string]*yang.Entry{
+				"event":  {Name: "event", Kind: yang.NotificationEntry},
+				"config": {Name: "config"},
+			},
+		},
+		wantChildNames: map[string]bool{
+			"config": true,
+			"event":  false,
+		},
 	}}
 
 	for _, tt := range tests {
@@ -812,6 +836,17 @@ func TestIsYangTypes(t *testing.T) {
 			wantAnydata:    true,
 			wantSimpleEnum: false,
 		},
+		{
+			desc: "anyxml",
+			schema: &yang.Entry{
+				Kind: yang.AnyXMLEntry,
+			},
+			wantLeafRef:    false,
+			wantUnion:      false,
+			wantEnumerated: false,
+			wantAnydata:    true,
+			wantSimpleEnum: false,
+		},
 		{
 			desc: "non-simple enum",
 			schema: &yang.Entry{
@@ -2004,3 +2039,703 @@ func TestListKeyFieldsMap(t *testing.T) {
 		})
 	}
 }
+
+func TestMustWhenStatements(t *testing.T) {
+	tests := []struct {
+		desc     string
+		in       *yang.Entry
+		wantMust []*MustStatement
+		wantWhen string
+		wantOK   bool
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "statements from node",
+		in: &yang.Entry{
+			Name: "leaf",
+			Node: &yang.Leaf{
+				Name: "leaf",
+				Must: []*yang.Must{{
+					Name:         ". > 10",
+					ErrorMessage: &yang.Value{Name: "must be greater than 10"},
+				}},
+				When: &yang.Value{
+					Name:   "../enabled = 'true'",
+					Source: &yang.Statement{Keyword: "when", HasArgument: true, Argument: "../enabled = 'true'"},
+				},
+			},
+		},
+		wantMust: []*MustStatement{{XPath: ". > 10", ErrorMessage: "must be greater than 10"}},
+		wantWhen: "../enabled = 'true'",
+		wantOK:   true,
+	}, {
+		desc: "statements from annotation",
+		in: &yang.Entry{
+			Name: "container",
+			Annotation: map[string]interface{}{
+				MustAnnotation: []*MustStatement{{XPath: "a or b"}},
+				WhenAnnotation: "c",
+			},
+		},
+		wantMust: []*MustStatement{{XPath: "a or b"}},
+		wantWhen: "c",
+		wantOK:   true,
+	}, {
+		desc: "statements from JSON annotation",
+		in: &yang.Entry{
+			Name: "container",
+			Annotation: map[string]interface{}{
+				MustAnnotation: []interface{}{
+					map[string]interface{}{"xpath": "a", "error-message": "msg"},
+				},
+			},
+		},
+		wantMust: []*MustStatement{{XPath: "a", ErrorMessage: "msg"}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.wantMust, MustStatements(tt.in)); diff != "" {
+				t.Errorf("MustStatements: did not get expected statements, (-want, +got):\n%s", diff)
+			}
+			w, ok := WhenStatement(tt.in)
+			if w != tt.wantWhen || ok != tt.wantOK {
+				t.Errorf("WhenStatement: got (%q, %v), want (%q, %v)", w, ok, tt.wantWhen, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestInheritedWhenStatements(t *testing.T) {
+	withParent := func(e, p *yang.Entry) *yang.Entry {
+		e.Parent = p
+		return e
+	}
+
+	usesParent := &yang.Entry{
+		Name: "c",
+		Kind: yang.DirectoryEntry,
+		Uses: []*yang.UsesStmt{{
+			Uses: &yang.Uses{Name: "outer", When: &yang.Value{Name: "../enabled = 'true'"}},
+			Grouping: &yang.Entry{
+				Name: "outer",
+				Dir:  map[string]*yang.Entry{"from-grouping": {Name: "from-grouping"}},
+				Uses: []*yang.UsesStmt{{
+					Uses: &yang.Uses{Name: "inner", When: &yang.Value{Name: "mode = 'a'"}},
+					Grouping: &yang.Entry{
+						Name: "inner",
+						Dir:  map[string]*yang.Entry{"from-grouping": {Name: "from-grouping"}},
+					},
+				}},
+			},
+		}},
+	}
+
+	augmentParent := &yang.Entry{
+		Name: "c",
+		Kind: yang.DirectoryEntry,
+		Augmented: []*yang.Entry{{
+			Name: "/m:c",
+			Node: &yang.Augment{Name: "/m:c", When: &yang.Value{Name: "type = 'ethernet'"}},
+			Dir:  map[string]*yang.Entry{"augmented": {Name: "augmented"}},
+		}, {
+			Name: "/m:c",
+			Node: &yang.Augment{Name: "/m:c"},
+			Dir:  map[string]*yang.Entry{"other": {Name: "other"}},
+		}},
+	}
+
+	tests := []struct {
+		desc string
+		in   *yang.Entry
+		want []string
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "entry without parent",
+		in:   &yang.Entry{Name: "leaf"},
+	}, {
+		desc: "child added by nested uses",
+		in:   withParent(&yang.Entry{Name: "from-grouping"}, usesParent),
+		want: []string{"../enabled = 'true'", "mode = 'a'"},
+	}, {
+		desc: "child not added by uses",
+		in:   withParent(&yang.Entry{Name: "other"}, usesParent),
+	}, {
+		desc: "child added by augment with when",
+		in:   withParent(&yang.Entry{Name: "augmented"}, augmentParent),
+		want: []string{"type = 'ethernet'"},
+	}, {
+		desc: "child added by augment without when",
+		in:   withParent(&yang.Entry{Name: "other"}, augmentParent),
+	}, {
+		desc: "statements from annotation",
+		in: &yang.Entry{
+			Name:       "leaf",
+			Annotation: map[string]interface{}{InheritedWhenAnnotation: []string{"a", "b"}},
+		},
+		want: []string{"a", "b"},
+	}, {
+		desc: "statements from JSON annotation",
+		in: &yang.Entry{
+			Name:       "leaf",
+			Annotation: map[string]interface{}{InheritedWhenAnnotation: []interface{}{"a"}},
+		},
+		want: []string{"a"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, InheritedWhenStatements(tt.in)); diff != "" {
+				t.Errorf("InheritedWhenStatements: did not get expected statements, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUniqueStatements(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *yang.Entry
+		want []string
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "statements from node",
+		in: &yang.Entry{
+			Name: "list",
+			Node: &yang.List{
+				Name:   "list",
+				Unique: []*yang.Value{{Name: "config/ip config/port"}, {Name: "seq"}},
+			},
+		},
+		want: []string{"config/ip config/port", "seq"},
+	}, {
+		desc: "statements from annotation",
+		in: &yang.Entry{
+			Name:       "list",
+			Annotation: map[string]interface{}{UniqueAnnotation: []string{"seq"}},
+		},
+		want: []string{"seq"},
+	}, {
+		desc: "statements from JSON annotation",
+		in: &yang.Entry{
+			Name:       "list",
+			Annotation: map[string]interface{}{UniqueAnnotation: []interface{}{"seq"}},
+		},
+		want: []string{"seq"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, UniqueStatements(tt.in)); diff != "" {
+				t.Errorf("UniqueStatements: did not get expected statements, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsPresenceContainer(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *yang.Entry
+		want bool
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "presence container from node",
+		in: &yang.Entry{
+			Name: "c",
+			Kind: yang.DirectoryEntry,
+			Node: &yang.Container{Name: "c", Presence: &yang.Value{Name: "enables c"}},
+		},
+		want: true,
+	}, {
+		desc: "non-presence container from node",
+		in: &yang.Entry{
+			Name: "c",
+			Kind: yang.DirectoryEntry,
+			Node: &yang.Container{Name: "c"},
+		},
+	}, {
+		desc: "presence container from annotation",
+		in: &yang.Entry{
+			Name:       "c",
+			Kind:       yang.DirectoryEntry,
+			Annotation: map[string]interface{}{PresenceAnnotation: "enables c"},
+		},
+		want: true,
+	}, {
+		desc: "list",
+		in: &yang.Entry{
+			Name:       "l",
+			Kind:       yang.DirectoryEntry,
+			ListAttr:   &yang.ListAttr{},
+			Annotation: map[string]interface{}{PresenceAnnotation: "enables l"},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := IsPresenceContainer(tt.in); got != tt.want {
+				t.Errorf("IsPresenceContainer: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsOrderedByUser(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *yang.Entry
+		want bool
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "container",
+		in:   &yang.Entry{Name: "c", Kind: yang.DirectoryEntry},
+	}, {
+		desc: "list without ordered-by",
+		in:   &yang.Entry{Name: "l", Kind: yang.DirectoryEntry, ListAttr: &yang.ListAttr{}},
+	}, {
+		desc: "list ordered-by system",
+		in: &yang.Entry{
+			Name:     "l",
+			Kind:     yang.DirectoryEntry,
+			ListAttr: &yang.ListAttr{OrderedBy: &yang.Value{Name: "system"}},
+		},
+	}, {
+		desc: "list ordered-by user",
+		in: &yang.Entry{
+			Name:     "l",
+			Kind:     yang.DirectoryEntry,
+			ListAttr: &yang.ListAttr{OrderedBy: &yang.Value{Name: "user"}},
+		},
+		want: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := IsOrderedByUser(tt.in); got != tt.want {
+				t.Errorf("IsOrderedByUser: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRPCEntries(t *testing.T) {
+	module := &yang.Entry{Name: "module", Kind: yang.DirectoryEntry}
+	container := &yang.Entry{Name: "c", Kind: yang.DirectoryEntry, Parent: module}
+	rpc := &yang.Entry{
+		Name:   "rpc",
+		Kind:   yang.DirectoryEntry,
+		Parent: module,
+		Node:   &yang.RPC{Name: "rpc"},
+	}
+	rpc.RPC = &yang.RPCEntry{
+		Input: &yang.Entry{Name: "input", Kind: yang.InputEntry, Parent: rpc},
+	}
+	action := &yang.Entry{
+		Name:   "action",
+		Kind:   yang.DirectoryEntry,
+		Parent: container,
+		Node:   &yang.Action{Name: "action"},
+	}
+	action.RPC = &yang.RPCEntry{
+		Output: &yang.Entry{Name: "output", Kind: yang.OutputEntry, Parent: action},
+	}
+
+	tests := []struct {
+		desc                 string
+		in                   *yang.Entry
+		wantRPCOrAction      bool
+		wantAction           bool
+		wantRPCInputOrOutput bool
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "container",
+		in:   container,
+	}, {
+		desc:            "rpc",
+		in:              rpc,
+		wantRPCOrAction: true,
+	}, {
+		desc:                 "rpc input",
+		in:                   rpc.RPC.Input,
+		wantRPCInputOrOutput: true,
+	}, {
+		desc:            "action",
+		in:              action,
+		wantRPCOrAction: true,
+		wantAction:      true,
+	}, {
+		desc: "action without input or output",
+		in: &yang.Entry{
+			Name:   "action",
+			Kind:   yang.DirectoryEntry,
+			Parent: container,
+			Node:   &yang.Action{Name: "action"},
+		},
+		wantRPCOrAction: true,
+		wantAction:      true,
+	}, {
+		desc: "action from serialised schema",
+		in: &yang.Entry{
+			Name:   "action",
+			Kind:   yang.DirectoryEntry,
+			Parent: container,
+			RPC:    &yang.RPCEntry{},
+		},
+		wantRPCOrAction: true,
+		wantAction:      true,
+	}, {
+		desc:                 "action output",
+		in:                   action.RPC.Output,
+		wantRPCInputOrOutput: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := IsRPCOrAction(tt.in); got != tt.wantRPCOrAction {
+				t.Errorf("IsRPCOrAction: got %v, want %v", got, tt.wantRPCOrAction)
+			}
+			if got := IsAction(tt.in); got != tt.wantAction {
+				t.Errorf("IsAction: got %v, want %v", got, tt.wantAction)
+			}
+			if got := IsRPCInputOrOutput(tt.in); got != tt.wantRPCInputOrOutput {
+				t.Errorf("IsRPCInputOrOutput: got %v, want %v", got, tt.wantRPCInputOrOutput)
+			}
+		})
+	}
+}
+
+func TestIsNotification(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *yang.Entry
+		want bool
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "container",
+		in:   &yang.Entry{Name: "c", Kind: yang.DirectoryEntry},
+	}, {
+		desc: "notification",
+		in:   &yang.Entry{Name: "event", Kind: yang.NotificationEntry},
+		want: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := IsNotification(tt.in); got != tt.want {
+				t.Errorf("IsNotification: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModuleNamespaces(t *testing.T) {
+	root := &yang.Entry{
+		Name: "device",
+		Annotation: map[string]interface{}{
+			ModuleNamespacesAnnotation: map[string]string{"m1": "urn:m1"},
+		},
+	}
+	child := &yang.Entry{Name: "child", Parent: root}
+
+	jsonRoot := &yang.Entry{
+		Name: "device",
+		Annotation: map[string]interface{}{
+			ModuleNamespacesAnnotation: map[string]interface{}{"m1": "urn:m1", "m2": "urn:m2"},
+		},
+	}
+
+	mod := &yang.Module{Name: "m3", Namespace: &yang.Value{Name: "urn:m3"}}
+	modRoot := &yang.Entry{Name: "m3", Node: mod}
+
+	tests := []struct {
+		desc string
+		in   *yang.Entry
+		want map[string]string
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "namespaces from annotation of root",
+		in:   child,
+		want: map[string]string{"m1": "urn:m1"},
+	}, {
+		desc: "namespaces from JSON annotation",
+		in:   jsonRoot,
+		want: map[string]string{"m1": "urn:m1", "m2": "urn:m2"},
+	}, {
+		desc: "namespace from module",
+		in:   modRoot,
+		want: map[string]string{"m3": "urn:m3"},
+	}, {
+		desc: "no namespaces",
+		in:   &yang.Entry{Name: "device"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, ModuleNamespaces(tt.in)); diff != "" {
+				t.Errorf("ModuleNamespaces: did not get expected namespaces, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntryModule(t *testing.T) {
+	ms := yang.NewModules()
+	for n, src := range map[string]string{
+		"a": `module a {
+			namespace "urn:a";
+			prefix a;
+			import b { prefix b; }
+			container c { uses b:g; }
+		}`,
+		"b": `module b {
+			namespace "urn:b";
+			prefix b;
+			grouping g { container d { leaf l { type string; } } }
+		}`,
+	} {
+		if err := ms.Parse(src, n); err != nil {
+			t.Fatalf("cannot parse module %s, %v", n, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) != 0 {
+		t.Fatalf("cannot process modules, %v", errs)
+	}
+	modEntry, errs := ms.GetModule("a")
+	if len(errs) != 0 {
+		t.Fatalf("cannot get module a, %v", errs)
+	}
+
+	tests := []struct {
+		desc             string
+		in               *yang.Entry
+		want             string
+		wantErrSubstring string
+	}{{
+		desc:             "nil entry",
+		wantErrSubstring: "nil entry",
+	}, {
+		desc: "module of node instantiated by a grouping",
+		in:   modEntry.Dir["c"].Dir["d"],
+		want: "a",
+	}, {
+		desc: "module from annotation",
+		in:   &yang.Entry{Name: "d", Annotation: map[string]interface{}{ModuleAnnotation: "a"}},
+		want: "a",
+	}, {
+		desc:             "no module",
+		in:               &yang.Entry{Name: "d"},
+		wantErrSubstring: "cannot determine the module of d",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := EntryModule(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("EntryModule: did not get expected error, %s", diff)
+			}
+			if got != tt.want {
+				t.Errorf("EntryModule: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModulePrefixes(t *testing.T) {
+	ms := yang.NewModules()
+	for n, src := range map[string]string{
+		"a": `module a {
+			namespace "urn:a";
+			prefix a;
+			include a-sub;
+			import b { prefix other; }
+		}`,
+		"a-sub": `submodule a-sub {
+			belongs-to a { prefix a; }
+			import c { prefix c; }
+		}`,
+		"b": `module b { namespace "urn:b"; prefix b; }`,
+		"c": `module c { namespace "urn:c"; prefix c; }`,
+	} {
+		if err := ms.Parse(src, n); err != nil {
+			t.Fatalf("cannot parse module %s, %v", n, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) != 0 {
+		t.Fatalf("cannot process modules, %v", errs)
+	}
+	modEntry, errs := ms.GetModule("a")
+	if len(errs) != 0 {
+		t.Fatalf("cannot get module a, %v", errs)
+	}
+
+	root := &yang.Entry{
+		Name: "device",
+		Annotation: map[string]interface{}{
+			ModulePrefixesAnnotation: map[string]map[string]string{"m1": {"m1": "m1", "t": "m2"}},
+		},
+	}
+	child := &yang.Entry{Name: "child", Parent: root}
+
+	jsonRoot := &yang.Entry{
+		Name: "device",
+		Annotation: map[string]interface{}{
+			ModulePrefixesAnnotation: map[string]interface{}{
+				"m1": map[string]interface{}{"m1": "m1", "t": "m2"},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc string
+		in   *yang.Entry
+		want map[string]map[string]string
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "prefixes from annotation of root",
+		in:   child,
+		want: map[string]map[string]string{"m1": {"m1": "m1", "t": "m2"}},
+	}, {
+		desc: "prefixes from JSON annotation",
+		in:   jsonRoot,
+		want: map[string]map[string]string{"m1": {"m1": "m1", "t": "m2"}},
+	}, {
+		desc: "prefixes from modules, including imports of submodules",
+		in:   modEntry,
+		want: map[string]map[string]string{
+			"a": {"a": "a", "other": "b", "c": "c"},
+			"b": {"b": "b"},
+			"c": {"c": "c"},
+		},
+	}, {
+		desc: "no prefixes",
+		in:   &yang.Entry{Name: "device"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, ModulePrefixes(tt.in)); diff != "" {
+				t.Errorf("ModulePrefixes: did not get expected prefixes, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// metadataTestModules parses a module that defines RFC7952 metadata
+// annotations, along with the ietf-yang-metadata module that it imports.
+func metadataTestModules(t *testing.T) *yang.Modules {
+	ms := yang.NewModules()
+	for n, src := range map[string]string{
+		"ietf-yang-metadata": `module ietf-yang-metadata {
+			namespace "urn:ietf:params:xml:ns:yang:ietf-yang-metadata";
+			prefix md;
+			extension annotation {
+				argument name;
+			}
+		}`,
+		"m1": `module m1 {
+			namespace "urn:m1";
+			prefix m1;
+			import ietf-yang-metadata { prefix meta; }
+			typedef counter { type m1:base; }
+			typedef base { type uint8; }
+			meta:annotation origin { type string; }
+			meta:annotation count { type m1:counter; }
+			meta:annotation remote { type t:remote-type; }
+			container device { leaf name { type string; } }
+		}`,
+	} {
+		if err := ms.Parse(src, n); err != nil {
+			t.Fatalf("cannot parse module %s, %v", n, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) != 0 {
+		t.Fatalf("cannot process modules, %v", errs)
+	}
+	return ms
+}
+
+func TestModuleMetadataAnnotations(t *testing.T) {
+	ms := metadataTestModules(t)
+	tests := []struct {
+		desc string
+		in   *yang.Module
+		want map[string]string
+	}{{
+		desc: "nil module",
+	}, {
+		desc: "module defining annotations",
+		in:   ms.Modules["m1"],
+		want: map[string]string{"m1:origin": "string", "m1:count": "uint8", "m1:remote": "t:remote-type"},
+	}, {
+		desc: "module without metadata import",
+		in:   ms.Modules["ietf-yang-metadata"],
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, ModuleMetadataAnnotations(tt.in)); diff != "" {
+				t.Errorf("ModuleMetadataAnnotations: did not get expected annotations, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetadataAnnotations(t *testing.T) {
+	root := &yang.Entry{
+		Name: "device",
+		Annotation: map[string]interface{}{
+			MetadataAnnotationsAnnotation: map[string]string{"m1:origin": "string"},
+		},
+	}
+	child := &yang.Entry{Name: "child", Parent: root}
+
+	jsonRoot := &yang.Entry{
+		Name: "device",
+		Annotation: map[string]interface{}{
+			MetadataAnnotationsAnnotation: map[string]interface{}{"m1:origin": "string", "m2:count": "uint8"},
+		},
+	}
+
+	ms := metadataTestModules(t)
+	modRoot := yang.ToEntry(ms.Modules["m1"])
+
+	tests := []struct {
+		desc string
+		in   *yang.Entry
+		want map[string]string
+	}{{
+		desc: "nil entry",
+	}, {
+		desc: "annotations from annotation of root",
+		in:   child,
+		want: map[string]string{"m1:origin": "string"},
+	}, {
+		desc: "annotations from JSON annotation",
+		in:   jsonRoot,
+		want: map[string]string{"m1:origin": "string", "m2:count": "uint8"},
+	}, {
+		desc: "annotations from modules",
+		in:   modRoot.Dir["device"].Dir["name"],
+		want: map[string]string{"m1:origin": "string", "m1:count": "uint8", "m1:remote": "t:remote-type"},
+	}, {
+		desc: "no annotations",
+		in:   &yang.Entry{Name: "device"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, MetadataAnnotations(tt.in)); diff != "" {
+				t.Errorf("MetadataAnnotations: did not get expected annotations, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -121,6 +121,14 @@ type ParseOpts struct {
 	// When it is disabled, two different enumerations (ModuleName_(State|Config)_Enabled)
 	// will be output in the generated code.
 	SkipEnumDeduplication bool
+	// EnabledFeatures specifies the YANG features that are supported by the
+	// target of the generated code, keyed by the name of the module that
+	// defines them. If it is non-nil, schema nodes whose if-feature
+	// statements evaluate to false using the set of enabled features are
+	// omitted from the generated code, and the set is recorded in the
+	// generated schema. If it is nil, all features are considered to be
+	// enabled.
+	EnabledFeatures map[string][]string
 }
 
 // TransformationOpts specifies transformations to the generated code with
@@ -176,7 +184,9 @@ type GoOpts struct {
 	// AddAnnotationFields specifies whether annotation fields should be added to
 	// the generated structs. When set to true, a metadata field is added for each
 	// struct, and for each field of each struct. Metadata field's names are
-	// prefixed by the string specified in the AnnotationPrefix argument.
+	// prefixed by the string specified in the AnnotationPrefix argument. RFC7952
+	// metadata, stored as ygot.Metadata within these fields, is rendered as
+	// the "@" members of RFC7951 JSON.
 	AddAnnotationFields bool
 	// AnnotationPrefix specifies the string which is prefixed to the name of
 	// annotation fields. It defaults to Λ.
@@ -192,6 +202,23 @@ type GoOpts struct {
 	// list fields of a struct. These methods take an input list member type, extract
 	// the key and append the supplied value to the list.
 	GenerateAppendMethod bool
+	// GenerateOrderedMaps specifies whether keyed lists that are "ordered-by
+	// user" should be represented by a generated ordered map type, rather
+	// than a Go map, such that the order of the entries of the list is
+	// preserved. The ordered map type allows entries to be looked up by their
+	// key, appended, deleted, and moved before or after another entry.
+	GenerateOrderedMaps bool
+	// GenerateRPCTypes specifies whether GoStructs should be generated for
+	// the input and output of each YANG rpc and action. For an action, a
+	// function that builds the data tree path at which the action is invoked
+	// is also generated.
+	GenerateRPCTypes bool
+	// GenerateNotificationTypes specifies whether GoStructs should be
+	// generated for each YANG notification, including those defined within
+	// a container or list. When the JSON schema is also generated, a
+	// function that unmarshals a notification into the GoStruct selected
+	// using its qualified name is generated.
+	GenerateNotificationTypes bool
 	// GenerateSimpleUnions specifies whether simple typedefs are used to
 	// represent union subtypes in the generated code instead of using
 	// wrapper types.
@@ -209,6 +236,17 @@ type GoOpts struct {
 	// IncludeModelData specifies whether gNMI ModelData messages should be generated
 	// in the output code.
 	IncludeModelData bool
+	// GenerateLeafMetadata specifies whether a struct-level annotation field
+	// should be added to the fake root, such that a ygot.LeafMetadataStore
+	// recording the timestamp, origin and source notification of each leaf
+	// can be stored alongside the data tree. The field is always added when
+	// AddAnnotationFields is set.
+	GenerateLeafMetadata bool
+	// GenerateDefaultsMethods specifies whether the ΛPopulateDefaults and
+	// ΛPruneDefaults methods, which are required by ygot.PopulateDefaults
+	// and ygot.PruneDefaults, should be generated for each struct. They are
+	// generated only when the JSON schema is also generated.
+	GenerateDefaultsMethods bool
 }
 
 // ProtoOpts stores Protobuf specific options for the code generation library.
@@ -300,6 +338,17 @@ type GeneratedGoCode struct {
 	RawJSONSchema []byte
 	// EnumTypeMap is a Go map that allows YANG schemapaths to be mapped to reflect.Type values.
 	EnumTypeMap string
+	// NotificationTypes is a Go map that allows the qualified names of YANG
+	// notifications to be mapped to the reflect.Type of the struct that
+	// represents them, along with a function that unmarshals a notification.
+	// It is only populated if notification types and the JSON schema are
+	// generated.
+	NotificationTypes string
+	// Deviations is the set of schema nodes that are deviated by the
+	// deviation statements within the input YANG modules, such that a
+	// report of the differences between the generated code and the
+	// modules that are deviated can be produced using DeviationReport.
+	Deviations []*DeviatedNode
 }
 
 // GeneratedProto3 stores a set of generated Protobuf packages.
@@ -408,7 +457,19 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 
 	// Code generation begins
 	var codegenErr util.Errors
-	commonHeader, oneoffHeader, err := writeGoHeader(yangFiles, includePaths, cg.Config, rootName, mdef.modelData)
+	// The paths of actions are returned as gNMI Path messages, such that the
+	// gNMI protobuf package must be imported if any are generated.
+	var hasActionPaths bool
+	if cg.Config.GoOptions.GenerateRPCTypes {
+		for _, d := range directoryMap {
+			if isActionInputOrOutput(d.Entry) {
+				hasActionPaths = true
+				break
+			}
+		}
+	}
+
+	commonHeader, oneoffHeader, err := writeGoHeader(yangFiles, includePaths, cg.Config, rootName, mdef.modelData, hasActionPaths)
 
 	if err != nil {
 		return nil, util.AppendErr(codegenErr, err)
@@ -451,10 +512,10 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 
 	var rawSchema []byte
 	var jsonSchema string
-	var enumTypeMapCode string
+	var enumTypeMapCode, notificationTypesCode string
 	if cg.Config.GenerateJSONSchema {
 		var err error
-		rawSchema, err = buildJSONTree(mdef.modules, gogen.uniqueDirectoryNames, mdef.directoryEntries["/"], cg.Config.TransformationOptions.CompressBehaviour.CompressEnabled())
+		rawSchema, err = buildJSONTree(mdef.modules, gogen.uniqueDirectoryNames, mdef.directoryEntries["/"], cg.Config.TransformationOptions.CompressBehaviour.CompressEnabled(), cg.Config.GoOptions.GenerateRPCTypes, cg.Config.GoOptions.GenerateNotificationTypes, sortedFeatures(cg.Config.ParseOptions.EnabledFeatures))
 		if err != nil {
 			util.AppendErr(codegenErr, fmt.Errorf("error marshalling JSON schema: %v", err))
 		}
@@ -468,6 +529,12 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 		if enumTypeMapCode, err = generateEnumTypeMap(enumTypeMap); err != nil {
 			util.AppendErr(codegenErr, err)
 		}
+
+		if cg.Config.GoOptions.GenerateNotificationTypes {
+			if notificationTypesCode, err = generateNotificationTypes(directoryMap); err != nil {
+				codegenErr = util.AppendErr(codegenErr, err)
+			}
+		}
 	}
 
 	// Return any errors that were encountered during code generation.
@@ -476,14 +543,16 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 	}
 
 	return &GeneratedGoCode{
-		CommonHeader:   commonHeader,
-		OneOffHeader:   oneoffHeader,
-		Structs:        structSnippets,
-		Enums:          enumSnippets,
-		EnumMap:        enumMap,
-		JSONSchemaCode: jsonSchema,
-		RawJSONSchema:  rawSchema,
-		EnumTypeMap:    enumTypeMapCode,
+		CommonHeader:      commonHeader,
+		OneOffHeader:      oneoffHeader,
+		Structs:           structSnippets,
+		Enums:             enumSnippets,
+		EnumMap:           enumMap,
+		JSONSchemaCode:    jsonSchema,
+		RawJSONSchema:     rawSchema,
+		EnumTypeMap:       enumTypeMapCode,
+		NotificationTypes: notificationTypesCode,
+		Deviations:        mdef.deviations,
 	}, nil
 }
 
@@ -835,6 +904,9 @@ type mappedYANGDefinitions struct {
 	// modelData stores the details of the set of modules that were parsed to produce
 	// the code. It is optionally returned in the generated code.
 	modelData []*gpb.ModelData
+	// deviations is the set of schema nodes that are deviated by the
+	// deviation statements of the parsed YANG modules.
+	deviations []*DeviatedNode
 }
 
 // mappedDefinitions finds the set of directory and enumeration entities
@@ -847,11 +919,45 @@ type mappedYANGDefinitions struct {
 // It returns a mappedYANGDefinitions struct populated with the directory, enum
 // entries in the input schemas as well as the calculated schema tree.
 func mappedDefinitions(yangFiles, includePaths []string, cfg *GeneratorConfig) (*mappedYANGDefinitions, util.Errors) {
-	modules, errs := processModules(yangFiles, includePaths, cfg.ParseOptions.YANGParseOptions)
+	// The uses statements of each entry are required to determine whether
+	// the if-feature and when statements of a uses statement apply to an
+	// entry.
+	parseOpts := cfg.ParseOptions.YANGParseOptions
+	parseOpts.StoreUses = true
+	modules, errs := processModules(yangFiles, includePaths, parseOpts)
+	if errs != nil {
+		return nil, errs
+	}
+
+	deviations, errs := findDeviations(modules)
 	if errs != nil {
 		return nil, errs
 	}
 
+	// Remove the entries for disabled features prior to any other
+	// processing, such that they are not mapped to entities within the
+	// generated code.
+	if cfg.ParseOptions.EnabledFeatures != nil {
+		if errs := pruneDisabledFeatures(modules, cfg.ParseOptions.EnabledFeatures); errs != nil {
+			return nil, errs
+		}
+	}
+
+	// Record the when statements of the augment and uses statements that
+	// add each entry, since neither is retained within the serialised
+	// schema.
+	for _, m := range modules {
+		annotateInheritedWhen(m)
+	}
+
+	// The uses statements are discarded once they have been processed,
+	// unless they were requested by the caller.
+	if !cfg.ParseOptions.YANGParseOptions.StoreUses {
+		for _, m := range modules {
+			clearUses(m)
+		}
+	}
+
 	// Build a map of excluded modules to simplify lookup.
 	excluded := map[string]bool{}
 	for _, e := range cfg.ParseOptions.ExcludeModules {
@@ -868,6 +974,12 @@ func mappedDefinitions(yangFiles, includePaths []string, cfg *GeneratorConfig) (
 		genutil.TransformEntry(module, cfg.TransformationOptions.CompressBehaviour)
 
 		errs = append(errs, findMappableEntities(module, dirs, enums, cfg.ParseOptions.ExcludeModules, cfg.TransformationOptions.CompressBehaviour.CompressEnabled(), modules)...)
+		if cfg.GoOptions.GenerateRPCTypes && !excluded[module.Name] {
+			errs = append(errs, findRPCEntities(module, dirs, enums, cfg.ParseOptions.ExcludeModules, cfg.TransformationOptions.CompressBehaviour.CompressEnabled(), modules)...)
+		}
+		if cfg.GoOptions.GenerateNotificationTypes && !excluded[module.Name] {
+			errs = append(errs, findNotificationEntities(module, dirs, enums, cfg.ParseOptions.ExcludeModules, cfg.TransformationOptions.CompressBehaviour.CompressEnabled(), modules)...)
+		}
 		if module == nil {
 			errs = append(errs, errors.New("found a nil module in the returned module set"))
 			continue
@@ -920,6 +1032,7 @@ func mappedDefinitions(yangFiles, includePaths []string, cfg *GeneratorConfig) (
 		schematree:       st,
 		modules:          ms,
 		modelData:        modelData,
+		deviations:       deviations,
 	}, nil
 }
 
@@ -1023,7 +1136,7 @@ func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[
 			dirs[ch.Path()] = ch
 			// Recurse down the tree.
 			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressPaths, modules))
-		case ch.Kind == yang.AnyDataEntry:
+		case util.IsAnydata(ch):
 			continue
 		default:
 			errs = util.AppendErr(errs, fmt.Errorf("unknown type of entry %v in findMappableEntities for %s", e.Kind, e.Path()))
@@ -1032,6 +1145,56 @@ func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[
 	return errs
 }
 
+// findRPCEntities walks the schema tree rooted at e to find the rpc and action
+// statements within it. The input and output of each rpc or action are added
+// to the dirs map, such that code is generated for them, and the entities
+// within them are mapped as per findMappableEntities.
+func findRPCEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[string]*yang.Entry, excludeModules []string, compressPaths bool, modules []*yang.Entry) util.Errors {
+	var errs util.Errors
+	for _, ch := range e.Dir {
+		switch {
+		case util.IsRPCOrAction(ch):
+			if ch.RPC == nil {
+				// An rpc or action without an input or output statement has
+				// no entities to be mapped.
+				continue
+			}
+			for _, io := range []*yang.Entry{ch.RPC.Input, ch.RPC.Output} {
+				if io == nil || io.Dir == nil {
+					continue
+				}
+				dirs[io.Path()] = io
+				errs = util.AppendErrs(errs, findMappableEntities(io, dirs, enums, excludeModules, compressPaths, modules))
+			}
+		case ch.Dir != nil:
+			errs = util.AppendErrs(errs, findRPCEntities(ch, dirs, enums, excludeModules, compressPaths, modules))
+		}
+	}
+	return errs
+}
+
+// findNotificationEntities walks the schema tree rooted at e to find the
+// notification statements within it, including those defined within a
+// container or list. Each notification is added to the dirs map, such that
+// code is generated for it, and the entities within it are mapped as per
+// findMappableEntities.
+func findNotificationEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[string]*yang.Entry, excludeModules []string, compressPaths bool, modules []*yang.Entry) util.Errors {
+	var errs util.Errors
+	for _, ch := range e.Dir {
+		switch {
+		case util.IsNotification(ch):
+			dirs[ch.Path()] = ch
+			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressPaths, modules))
+		case util.IsRPCOrAction(ch):
+			// Notifications cannot be defined within an rpc or action.
+			continue
+		case ch.Dir != nil:
+			errs = util.AppendErrs(errs, findNotificationEntities(ch, dirs, enums, excludeModules, compressPaths, modules))
+		}
+	}
+	return errs
+}
+
 // findRootEntries finds the entities that are at the root of the YANG schema tree,
 // and returns them.
 func findRootEntries(structs map[string]*yang.Entry, compressPaths bool) map[string]*yang.Entry {
@@ -597,6 +597,22 @@ func TestSimpleStructs(t *testing.T) {
 			},
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-fakeroot.formatted-txt"),
+	}, {
+		name:    "openconfig tests with fakeroot and leaf metadata",
+		inFiles: []string{filepath.Join(datapath, "openconfig-fakeroot.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+				GenerateLeafMetadata: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				GenerateFakeRoot:                     true,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-fakeroot-leaf-metadata.formatted-txt"),
 	}, {
 		name:    "openconfig noncompressed tests with fakeroot",
 		inFiles: []string{filepath.Join(datapath, "openconfig-fakeroot.yang")},
@@ -627,6 +643,23 @@ func TestSimpleStructs(t *testing.T) {
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress.formatted-txt"),
 		wantSchemaFile:      filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress-schema.json"),
+	}, {
+		name:    "schema test with compression and defaults methods",
+		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:    true,
+				GenerateDefaultsMethods: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+			},
+			GenerateJSONSchema: true,
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress.defaults-methods.formatted-txt"),
+		wantSchemaFile:      filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress-schema.json"),
 	}, {
 		name:    "schema test without compression",
 		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
@@ -816,6 +849,114 @@ func TestSimpleStructs(t *testing.T) {
 			},
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-list-enum-key.getters-append.formatted-txt"),
+	}, {
+		name:    "module with ordered-by user lists, with ordered maps",
+		inFiles: []string{filepath.Join(datapath, "", "openconfig-ordered-list.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:  true,
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+			GoOptions: GoOpts{
+				GenerateAppendMethod: true,
+				GenerateGetters:      true,
+				GenerateDeleteMethod: true,
+				GenerateSimpleUnions: true,
+				GenerateOrderedMaps:  true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-ordered-list.ordered-maps.formatted-txt"),
+	}, {
+		name:    "module with ordered-by user lists, without ordered maps",
+		inFiles: []string{filepath.Join(datapath, "", "openconfig-ordered-list.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:  true,
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-ordered-list.formatted-txt"),
+	}, {
+		name:    "module with rpc and action statements, with rpc types",
+		inFiles: []string{filepath.Join(datapath, "", "openconfig-rpc.yang")},
+		inConfig: GeneratorConfig{
+			GenerateJSONSchema: true,
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:  true,
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+				GenerateRPCTypes:     true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-rpc.rpc-types.formatted-txt"),
+		wantSchemaFile:      filepath.Join(TestRoot, "testdata", "schema", "openconfig-rpc.rpc-types-schema.json"),
+	}, {
+		name:    "module with rpc and action statements, without rpc types",
+		inFiles: []string{filepath.Join(datapath, "", "openconfig-rpc.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:  true,
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-rpc.formatted-txt"),
+	}, {
+		name:    "module with notification statements, with notification types",
+		inFiles: []string{filepath.Join(datapath, "", "openconfig-notification.yang")},
+		inConfig: GeneratorConfig{
+			GenerateJSONSchema: true,
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:  true,
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:      true,
+				GenerateNotificationTypes: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-notification.notification-types.formatted-txt"),
+		wantSchemaFile:      filepath.Join(TestRoot, "testdata", "schema", "openconfig-notification.notification-types-schema.json"),
+	}, {
+		name:    "module with notification statements, without notification types",
+		inFiles: []string{filepath.Join(datapath, "", "openconfig-notification.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:  true,
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-notification.formatted-txt"),
+	}, {
+		name:    "module with if-feature statements, subset of features enabled",
+		inFiles: []string{filepath.Join(datapath, "", "openconfig-features.yang")},
+		inConfig: GeneratorConfig{
+			GenerateJSONSchema: true,
+			ParseOptions: ParseOpts{
+				EnabledFeatures: map[string][]string{
+					"openconfig-features": {"mtu", "counters"},
+				},
+			},
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:  true,
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-features.formatted-txt"),
+		wantSchemaFile:      filepath.Join(TestRoot, "testdata", "schema", "openconfig-features-schema.json"),
 	}, {
 		name:    "module with excluded state, with RO list, path compression on",
 		inFiles: []string{filepath.Join(datapath, "", "exclude-state-ro-list.yang")},
@@ -1094,6 +1235,7 @@ func TestSimpleStructs(t *testing.T) {
 					// Write the schema byte array out.
 					fmt.Fprint(&gotCode, gotGeneratedCode.JSONSchemaCode)
 					fmt.Fprint(&gotCode, gotGeneratedCode.EnumTypeMap)
+					fmt.Fprint(&gotCode, gotGeneratedCode.NotificationTypes)
 
 					if err := json.Unmarshal(gotGeneratedCode.RawJSONSchema, &gotJSON); err != nil {
 						t.Fatalf("%s: json.Unmarshal(..., %v), could not unmarshal received JSON: %v", tt.name, gotGeneratedCode.RawJSONSchema, err)
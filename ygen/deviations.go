@@ -0,0 +1,201 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// deviateTypes are the arguments of the YANG deviate statement, in the order
+// in which the nodes deviated by each are listed within a deviation report.
+var deviateTypes = []string{"not-supported", "replace", "add", "delete"}
+
+// DeviatedNode describes a schema node that is modified by a deviate
+// statement within the set of YANG modules for which code is generated.
+type DeviatedNode struct {
+	// Path is the schema path of the deviated node, of the same form as
+	// that returned by the Path method of a yang.Entry, e.g.,
+	// /openconfig-interfaces/interfaces/interface/config/mtu.
+	Path string
+	// Module is the name of the module that contains the deviation.
+	Module string
+	// Type is the argument of the deviate statement, i.e., one of
+	// not-supported, replace, add, or delete.
+	Type string
+	// Properties are the properties of the node that are replaced, added
+	// or deleted by the deviate statement, each of the form
+	// "keyword argument", e.g., "type uint32". It is empty for a node that
+	// is not-supported.
+	Properties []string
+}
+
+// findDeviations returns the nodes that are deviated by the deviation
+// statements within the supplied modules, or the submodules that they
+// include. The nodes are sorted by path, and then by the name of the module
+// containing the deviation.
+func findDeviations(modules []*yang.Entry) ([]*DeviatedNode, util.Errors) {
+	var nodes []*DeviatedNode
+	var errs util.Errors
+	for _, m := range modules {
+		mod, ok := m.Node.(*yang.Module)
+		if !ok {
+			continue
+		}
+		devs := append([]*yang.Deviation{}, mod.Deviation...)
+		for _, i := range mod.Include {
+			if i.Module != nil {
+				devs = append(devs, i.Module.Deviation...)
+			}
+		}
+
+		for _, d := range devs {
+			path, err := deviationPath(d)
+			if err != nil {
+				errs = util.AppendErr(errs, err)
+				continue
+			}
+			for _, dv := range d.Deviate {
+				nodes = append(nodes, &DeviatedNode{
+					Path:       path,
+					Module:     m.Name,
+					Type:       dv.Name,
+					Properties: deviateProperties(dv),
+				})
+			}
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Path != nodes[j].Path {
+			return nodes[i].Path < nodes[j].Path
+		}
+		return nodes[i].Module < nodes[j].Module
+	})
+	return nodes, nil
+}
+
+// deviationPath returns the schema path of the node that is the target of
+// the deviation d, with the prefixes of its elements removed, and the name of
+// the module that defines the first element of the path prepended.
+func deviationPath(d *yang.Deviation) (string, error) {
+	if !strings.HasPrefix(d.Name, "/") || len(d.Name) == 1 {
+		return "", fmt.Errorf("%s: invalid target of deviation %s, must be an absolute schema node identifier", yang.Source(d), d.Name)
+	}
+	elems := strings.Split(d.Name[1:], "/")
+
+	var prefix string
+	if p := strings.SplitN(elems[0], ":", 2); len(p) == 2 {
+		prefix = p[0]
+	}
+	m := yang.FindModuleByPrefix(d, prefix)
+	if m == nil {
+		return "", fmt.Errorf("%s: cannot resolve prefix of target of deviation %s", yang.Source(d), d.Name)
+	}
+	name := m.Name
+	if m.Kind() == "submodule" && m.BelongsTo != nil {
+		name = m.BelongsTo.Name
+	}
+
+	path := []string{"", name}
+	for _, e := range elems {
+		path = append(path, util.StripModulePrefix(e))
+	}
+	return strings.Join(path, "/"), nil
+}
+
+// deviateProperties returns the properties that are replaced, added or
+// deleted by the deviate statement dv, each of the form "keyword argument".
+func deviateProperties(dv *yang.Deviate) []string {
+	var props []string
+	addValue := func(keyword string, v *yang.Value) {
+		if v != nil {
+			props = append(props, fmt.Sprintf("%s %s", keyword, v.Name))
+		}
+	}
+	addValue("config", dv.Config)
+	addValue("default", dv.Default)
+	addValue("mandatory", dv.Mandatory)
+	addValue("max-elements", dv.MaxElements)
+	addValue("min-elements", dv.MinElements)
+	for _, m := range dv.Must {
+		props = append(props, fmt.Sprintf("must %q", m.Name))
+	}
+	if dv.Type != nil {
+		props = append(props, fmt.Sprintf("type %s", dv.Type.Name))
+	}
+	for _, u := range dv.Unique {
+		addValue("unique", u)
+	}
+	addValue("units", dv.Units)
+	return props
+}
+
+// DeviationReport returns a human-readable report of the supplied deviated
+// nodes, such that the differences between the schema for which code is
+// generated and the base set of YANG modules can be reviewed. The nodes are
+// grouped by the type of the deviation, with nodes that are not-supported
+// listed first, followed by those that are replaced, added and deleted.
+func DeviationReport(nodes []*DeviatedNode) string {
+	if len(nodes) == 0 {
+		return "No schema nodes are deviated.\n"
+	}
+
+	byType := map[string][]*DeviatedNode{}
+	for _, n := range nodes {
+		byType[n.Type] = append(byType[n.Type], n)
+	}
+
+	// Any other type of deviation is invalid, but is reported after
+	// those that are valid such that no deviated node is omitted.
+	var others []string
+	for t := range byType {
+		valid := false
+		for _, vt := range deviateTypes {
+			valid = valid || t == vt
+		}
+		if !valid {
+			others = append(others, t)
+		}
+	}
+	sort.Strings(others)
+	types := append(append([]string{}, deviateTypes...), others...)
+
+	var b strings.Builder
+	for _, t := range types {
+		if len(byType[t]) == 0 {
+			continue
+		}
+		if b.Len() != 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s (%d):\n", t, len(byType[t]))
+		for _, n := range byType[t] {
+			fmt.Fprintf(&b, "  %s", n.Path)
+			if len(n.Properties) != 0 {
+				fmt.Fprintf(&b, ": %s", strings.Join(n.Properties, ", "))
+			}
+			fmt.Fprintf(&b, " [%s]\n", n.Module)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func TestFindDeviations(t *testing.T) {
+	const (
+		intf   = "/openconfig-deviation-base/interfaces/interface"
+		vendor = "openconfig-deviation-vendor"
+	)
+	tests := []struct {
+		desc    string
+		inFiles []string
+		want    []*DeviatedNode
+	}{{
+		desc:    "module without deviations",
+		inFiles: []string{"openconfig-deviation-base.yang"},
+	}, {
+		desc:    "module with deviations",
+		inFiles: []string{"openconfig-deviation-base.yang", "openconfig-deviation-vendor.yang"},
+		want: []*DeviatedNode{{
+			Path:       intf + "/config/aliases",
+			Module:     vendor,
+			Type:       "delete",
+			Properties: []string{"max-elements 8"},
+		}, {
+			Path:   intf + "/config/description",
+			Module: vendor,
+			Type:   "not-supported",
+		}, {
+			Path:       intf + "/config/mtu",
+			Module:     vendor,
+			Type:       "replace",
+			Properties: []string{"type uint32", "units octets"},
+		}, {
+			Path:   intf + "/state/description",
+			Module: vendor,
+			Type:   "not-supported",
+		}, {
+			Path:       intf + "/state/oper-status",
+			Module:     vendor,
+			Type:       "add",
+			Properties: []string{"mandatory true"},
+		}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var files []string
+			for _, f := range tt.inFiles {
+				files = append(files, filepath.Join(datapath, f))
+			}
+			modules, errs := processModules(files, nil, yang.Options{})
+			if errs != nil {
+				t.Fatalf("processModules: got unexpected errors, %v", errs)
+			}
+
+			got, errs := findDeviations(modules)
+			if errs != nil {
+				t.Fatalf("findDeviations: got unexpected errors, %v", errs)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("findDeviations: did not get expected deviated nodes, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeviationPath(t *testing.T) {
+	mod := &yang.Module{
+		Name:   "mod",
+		Prefix: &yang.Value{Name: "m"},
+		Import: []*yang.Import{{
+			Name:   "base",
+			Prefix: &yang.Value{Name: "b"},
+			Module: &yang.Module{Name: "base", Prefix: &yang.Value{Name: "base"}},
+		}},
+	}
+	sub := &yang.Module{
+		Name:      "sub",
+		BelongsTo: &yang.BelongsTo{Name: "mod", Prefix: &yang.Value{Name: "m"}},
+	}
+
+	tests := []struct {
+		desc    string
+		in      *yang.Deviation
+		want    string
+		wantErr string
+	}{{
+		desc: "imported module",
+		in:   &yang.Deviation{Name: "/b:a/b:b", Parent: mod},
+		want: "/base/a/b",
+	}, {
+		desc: "local module",
+		in:   &yang.Deviation{Name: "/m:a/b", Parent: mod},
+		want: "/mod/a/b",
+	}, {
+		desc: "unprefixed path within submodule",
+		in:   &yang.Deviation{Name: "/a", Parent: sub},
+		want: "/mod/a",
+	}, {
+		desc:    "relative path",
+		in:      &yang.Deviation{Name: "b:a", Parent: mod},
+		wantErr: "must be an absolute schema node identifier",
+	}, {
+		desc:    "unknown prefix",
+		in:      &yang.Deviation{Name: "/x:a", Parent: mod},
+		wantErr: "cannot resolve prefix",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := deviationPath(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("deviationPath(%s): %s", tt.in.Name, diff)
+			}
+			if got != tt.want {
+				t.Errorf("deviationPath(%s): got %s, want %s", tt.in.Name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviationReport(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   []*DeviatedNode
+		want string
+	}{{
+		desc: "no deviations",
+		want: "No schema nodes are deviated.\n",
+	}, {
+		desc: "deviations of each type",
+		in: []*DeviatedNode{
+			{Path: "/m/a", Module: "dev", Type: "add", Properties: []string{"must \"x\""}},
+			{Path: "/m/b", Module: "dev", Type: "not-supported"},
+			{Path: "/m/c", Module: "dev", Type: "replace", Properties: []string{"config false", "type string"}},
+			{Path: "/m/d", Module: "other-dev", Type: "not-supported"},
+			{Path: "/m/e", Module: "dev", Type: "delete", Properties: []string{"units bytes"}},
+			{Path: "/m/f", Module: "dev", Type: "remove"},
+		},
+		want: `not-supported (2):
+  /m/b [dev]
+  /m/d [other-dev]
+
+replace (1):
+  /m/c: config false, type string [dev]
+
+add (1):
+  /m/a: must "x" [dev]
+
+delete (1):
+  /m/e: units bytes [dev]
+
+remove (1):
+  /m/f [dev]
+`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, DeviationReport(tt.in)); diff != "" {
+				t.Errorf("DeviationReport: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
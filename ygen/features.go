@@ -0,0 +1,214 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/util"
+)
+
+// pruneDisabledFeatures removes the entries whose if-feature statements
+// evaluate to false from the schema trees of the supplied modules, such that
+// no code is generated for them. The features map specifies the enabled
+// features, keyed by the name of the module that defines them. Each removed
+// entry is recorded in the DisabledChildrenAnnotation of its parent. The
+// modules must have been parsed with the StoreUses option of the goyang
+// library set, such that the if-feature statements of uses statements are
+// applied.
+func pruneDisabledFeatures(modules []*yang.Entry, features map[string][]string) util.Errors {
+	enabled, errs := featureEnabledFunc(modules, features)
+	if errs != nil {
+		return errs
+	}
+	for _, m := range modules {
+		errs = util.AppendErrs(errs, pruneEntry(m, enabled))
+	}
+	return errs
+}
+
+// clearUses clears the Uses field of each entry within the schema tree rooted
+// at e, and of the augments merged into each entry, once the uses statements
+// are no longer required.
+func clearUses(e *yang.Entry) {
+	if e == nil {
+		return
+	}
+	e.Uses = nil
+	for _, a := range e.Augmented {
+		a.Uses = nil
+	}
+	for _, ch := range e.Dir {
+		clearUses(ch)
+	}
+	if e.RPC != nil {
+		clearUses(e.RPC.Input)
+		clearUses(e.RPC.Output)
+	}
+}
+
+// pruneEntry removes the children of e whose if-feature statements evaluate to
+// false using the enabled function, and recurses into the remaining children,
+// and the input and output of any rpc or action.
+func pruneEntry(e *yang.Entry, enabled func(string) bool) util.Errors {
+	if e == nil {
+		return nil
+	}
+
+	var errs util.Errors
+	for _, name := range genutil.GetOrderedEntryKeys(e.Dir) {
+		ch := e.Dir[name]
+		exprs, err := util.IfFeatures(ch)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("%s: %v", ch.Path(), err))
+			continue
+		}
+		var disabled bool
+		var exprStrs []string
+		for _, x := range exprs {
+			exprStrs = append(exprStrs, x.String())
+			if !x.Eval(enabled) {
+				disabled = true
+			}
+		}
+		if disabled {
+			if e.Annotation == nil {
+				e.Annotation = map[string]interface{}{}
+			}
+			dc, ok := e.Annotation[util.DisabledChildrenAnnotation].(map[string][]string)
+			if !ok {
+				dc = map[string][]string{}
+				e.Annotation[util.DisabledChildrenAnnotation] = dc
+			}
+			dc[name] = exprStrs
+			delete(e.Dir, name)
+			continue
+		}
+		errs = util.AppendErrs(errs, pruneEntry(ch, enabled))
+	}
+	if e.RPC != nil {
+		errs = util.AppendErrs(errs, pruneEntry(e.RPC.Input, enabled))
+		errs = util.AppendErrs(errs, pruneEntry(e.RPC.Output, enabled))
+	}
+	return errs
+}
+
+// featureEnabledFunc returns a function that reports whether a
+// module-qualified feature, of the form "module:feature", is enabled
+// according to the supplied set of features, keyed by the name of the module
+// that defines them. A feature is only enabled where it is listed, and the
+// if-feature statements of the feature itself evaluate to true. All features
+// are enabled where the set is nil. An error is returned if a listed feature
+// is not defined by a module within the supplied modules that has the
+// corresponding name.
+func featureEnabledFunc(modules []*yang.Entry, features map[string][]string) (func(string) bool, util.Errors) {
+	if features == nil {
+		return func(string) bool { return true }, nil
+	}
+
+	defined := map[string]*yang.Feature{}
+	parsed := map[string]bool{}
+	for _, m := range modules {
+		mod, ok := m.Node.(*yang.Module)
+		if !ok {
+			continue
+		}
+		parsed[mod.Name] = true
+		for _, f := range moduleFeatures(mod) {
+			defined[fmt.Sprintf("%s:%s", mod.Name, f.Name)] = f
+		}
+	}
+
+	var errs util.Errors
+	listed := map[string]bool{}
+	for m, fs := range features {
+		for _, f := range fs {
+			ref := fmt.Sprintf("%s:%s", m, f)
+			if _, ok := defined[ref]; !ok && parsed[m] {
+				errs = util.AppendErr(errs, fmt.Errorf("feature %s is not defined by module %s", f, m))
+			}
+			listed[ref] = true
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	// The result for each feature is cached, and a feature that is being
+	// evaluated is treated as disabled, such that a circular dependency
+	// between features, which is invalid YANG, does not recurse infinitely.
+	cache := map[string]bool{}
+	var enabled func(string) bool
+	enabled = func(ref string) bool {
+		if v, ok := cache[ref]; ok {
+			return v
+		}
+		cache[ref] = false
+		if !listed[ref] {
+			return false
+		}
+		if f, ok := defined[ref]; ok {
+			exprs, err := util.NodeIfFeatures(f)
+			if err != nil {
+				return false
+			}
+			for _, x := range exprs {
+				if !x.Eval(enabled) {
+					return false
+				}
+			}
+		}
+		cache[ref] = true
+		return true
+	}
+	return enabled, nil
+}
+
+// moduleFeatures returns the features that are defined by the module m, and
+// by the submodules that it includes.
+func moduleFeatures(m *yang.Module) []*yang.Feature {
+	fs := append([]*yang.Feature{}, m.Feature...)
+	for _, i := range m.Include {
+		if i.Module != nil {
+			fs = append(fs, moduleFeatures(i.Module)...)
+		}
+	}
+	return fs
+}
+
+// sortedFeatures returns a copy of the supplied set of enabled features, keyed
+// by module name, in which the features of each module are sorted and
+// deduplicated, such that it can be stored in the generated schema.
+func sortedFeatures(features map[string][]string) map[string][]string {
+	if features == nil {
+		return nil
+	}
+	out := map[string][]string{}
+	for m, fs := range features {
+		seen := map[string]bool{}
+		out[m] = []string{}
+		for _, f := range fs {
+			if !seen[f] {
+				seen[f] = true
+				out[m] = append(out[m], f)
+			}
+		}
+		sort.Strings(out[m])
+	}
+	return out
+}
@@ -0,0 +1,251 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// leafPaths returns the paths of the leaves within the schema tree rooted at
+// e, in sorted order.
+func leafPaths(e *yang.Entry) []string {
+	var out []string
+	for _, ch := range e.Dir {
+		if ch.IsLeaf() {
+			out = append(out, ch.Path())
+			continue
+		}
+		out = append(out, leafPaths(ch)...)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestPruneDisabledFeatures(t *testing.T) {
+	const mod = "openconfig-features"
+	tests := []struct {
+		desc         string
+		inFeatures   map[string][]string
+		wantLeaves   []string
+		wantDisabled map[string]map[string][]string
+		wantErr      string
+	}{{
+		desc:       "all features enabled",
+		inFeatures: map[string][]string{mod: {"counters", "mtu", "jumbo-frames", "legacy"}},
+		wantLeaves: []string{
+			"/openconfig-features/interfaces/interface/config/jumbo-mtu",
+			"/openconfig-features/interfaces/interface/config/mtu",
+			"/openconfig-features/interfaces/interface/config/name",
+			"/openconfig-features/interfaces/interface/config/speed",
+			"/openconfig-features/interfaces/interface/name",
+			"/openconfig-features/interfaces/interface/state/counters/in-pkts",
+			"/openconfig-features/interfaces/interface/state/jumbo-mtu",
+			"/openconfig-features/interfaces/interface/state/mtu",
+			"/openconfig-features/interfaces/interface/state/name",
+			"/openconfig-features/legacy-settings/enabled",
+		},
+		wantDisabled: map[string]map[string][]string{
+			"/openconfig-features/interfaces/interface/config": {"description": {"not openconfig-features:legacy"}},
+			"/openconfig-features/interfaces/interface/state":  {"description": {"not openconfig-features:legacy"}},
+		},
+	}, {
+		desc:       "no features enabled",
+		inFeatures: map[string][]string{mod: {}},
+		wantLeaves: []string{
+			"/openconfig-features/interfaces/interface/config/description",
+			"/openconfig-features/interfaces/interface/config/name",
+			"/openconfig-features/interfaces/interface/name",
+			"/openconfig-features/interfaces/interface/state/description",
+			"/openconfig-features/interfaces/interface/state/name",
+		},
+		wantDisabled: map[string]map[string][]string{
+			"/openconfig-features": {
+				"legacy-settings": {"openconfig-features:legacy or openconfig-features:mtu and not openconfig-features:counters"},
+			},
+			"/openconfig-features/interfaces/interface/config": {
+				"jumbo-mtu": {"openconfig-features:jumbo-frames"},
+				"mtu":       {"openconfig-features:mtu"},
+				"speed":     {"openconfig-features:legacy"},
+			},
+			"/openconfig-features/interfaces/interface/state": {
+				"counters":  {"openconfig-features:counters"},
+				"jumbo-mtu": {"openconfig-features:jumbo-frames"},
+				"mtu":       {"openconfig-features:mtu"},
+			},
+		},
+	}, {
+		desc:       "feature that depends on a disabled feature",
+		inFeatures: map[string][]string{mod: {"jumbo-frames", "counters"}},
+		wantLeaves: []string{
+			"/openconfig-features/interfaces/interface/config/description",
+			"/openconfig-features/interfaces/interface/config/name",
+			"/openconfig-features/interfaces/interface/name",
+			"/openconfig-features/interfaces/interface/state/counters/in-pkts",
+			"/openconfig-features/interfaces/interface/state/description",
+			"/openconfig-features/interfaces/interface/state/name",
+		},
+		wantDisabled: map[string]map[string][]string{
+			"/openconfig-features": {
+				"legacy-settings": {"openconfig-features:legacy or openconfig-features:mtu and not openconfig-features:counters"},
+			},
+			"/openconfig-features/interfaces/interface/config": {
+				"jumbo-mtu": {"openconfig-features:jumbo-frames"},
+				"mtu":       {"openconfig-features:mtu"},
+				"speed":     {"openconfig-features:legacy"},
+			},
+			"/openconfig-features/interfaces/interface/state": {
+				"jumbo-mtu": {"openconfig-features:jumbo-frames"},
+				"mtu":       {"openconfig-features:mtu"},
+			},
+		},
+	}, {
+		desc:       "features of a module that is not parsed",
+		inFeatures: map[string][]string{"other-module": {"a"}, mod: {"mtu", "counters"}},
+		wantLeaves: []string{
+			"/openconfig-features/interfaces/interface/config/description",
+			"/openconfig-features/interfaces/interface/config/mtu",
+			"/openconfig-features/interfaces/interface/config/name",
+			"/openconfig-features/interfaces/interface/name",
+			"/openconfig-features/interfaces/interface/state/counters/in-pkts",
+			"/openconfig-features/interfaces/interface/state/description",
+			"/openconfig-features/interfaces/interface/state/mtu",
+			"/openconfig-features/interfaces/interface/state/name",
+		},
+		wantDisabled: map[string]map[string][]string{
+			"/openconfig-features": {
+				"legacy-settings": {"openconfig-features:legacy or openconfig-features:mtu and not openconfig-features:counters"},
+			},
+			"/openconfig-features/interfaces/interface/config": {
+				"jumbo-mtu": {"openconfig-features:jumbo-frames"},
+				"speed":     {"openconfig-features:legacy"},
+			},
+			"/openconfig-features/interfaces/interface/state": {
+				"jumbo-mtu": {"openconfig-features:jumbo-frames"},
+			},
+		},
+	}, {
+		desc:       "undefined feature",
+		inFeatures: map[string][]string{mod: {"mtu", "fast-reroute"}},
+		wantErr:    "feature fast-reroute is not defined by module openconfig-features",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			modules, errs := processModules([]string{filepath.Join(datapath, "openconfig-features.yang")}, nil, yang.Options{StoreUses: true})
+			if errs != nil {
+				t.Fatalf("processModules: got unexpected errors, %v", errs)
+			}
+
+			errs = pruneDisabledFeatures(modules, tt.inFeatures)
+			var err error
+			if errs != nil {
+				err = errs
+			}
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("pruneDisabledFeatures: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			var gotLeaves []string
+			gotDisabled := map[string]map[string][]string{}
+			var walk func(e *yang.Entry)
+			walk = func(e *yang.Entry) {
+				if dc := util.DisabledChildren(e); dc != nil {
+					gotDisabled[e.Path()] = dc
+				}
+				for _, ch := range e.Dir {
+					walk(ch)
+				}
+			}
+			for _, m := range modules {
+				gotLeaves = append(gotLeaves, leafPaths(m)...)
+				walk(m)
+			}
+
+			if diff := cmp.Diff(tt.wantLeaves, gotLeaves); diff != "" {
+				t.Errorf("pruneDisabledFeatures: did not get expected leaves, (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantDisabled, gotDisabled); diff != "" {
+				t.Errorf("pruneDisabledFeatures: did not get expected disabled children, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClearUses(t *testing.T) {
+	modules, errs := processModules([]string{filepath.Join(datapath, "openconfig-features.yang")}, nil, yang.Options{StoreUses: true})
+	if errs != nil {
+		t.Fatalf("processModules: got unexpected errors, %v", errs)
+	}
+
+	countUses := func() int {
+		var n int
+		var walk func(e *yang.Entry)
+		walk = func(e *yang.Entry) {
+			n += len(e.Uses)
+			for _, a := range e.Augmented {
+				n += len(a.Uses)
+			}
+			for _, ch := range e.Dir {
+				walk(ch)
+			}
+		}
+		for _, m := range modules {
+			walk(m)
+		}
+		return n
+	}
+
+	if countUses() == 0 {
+		t.Fatalf("processModules: did not store any uses statements")
+	}
+	for _, m := range modules {
+		clearUses(m)
+	}
+	if got := countUses(); got != 0 {
+		t.Errorf("clearUses: got %d uses statements retained, want 0", got)
+	}
+}
+
+func TestSortedFeatures(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   map[string][]string
+		want map[string][]string
+	}{{
+		desc: "nil set",
+	}, {
+		desc: "unsorted features with duplicates",
+		in:   map[string][]string{"a": {"z", "y", "z"}, "b": nil},
+		want: map[string][]string{"a": {"y", "z"}, "b": {}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, sortedFeatures(tt.in)); diff != "" {
+				t.Errorf("sortedFeatures: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -47,6 +47,9 @@ const (
 	// annotationFieldType defines the type that should be used for the
 	// annotation/metadata fields within each struct when they are generated.
 	annotationFieldType string = "[]ygot.Annotation"
+	// anydataFieldType is the type that is used for fields that represent
+	// YANG anydata and anyxml nodes.
+	anydataFieldType string = "*ygot.Anydata"
 )
 
 // The methods in this file take the structs that have been generated by
@@ -142,9 +145,10 @@ type GoStructCodeSnippet struct {
 	// the input when code generation is performed.
 	StructDef string
 	// ListKeys stores code snippets that are associated with structs that are
-	// generated to represent the keys of multi-key lists. In the case that the
-	// Go struct for which the code is being generated does not contain a list
-	// with multiple keys, this string is empty.
+	// generated to represent the keys of multi-key lists, and with the ordered
+	// map types that are generated for "ordered-by user" lists. In the case
+	// that the Go struct for which the code is being generated does not
+	// contain such lists, this string is empty.
 	ListKeys string
 	// Methods contains code snippsets that represent functions that have the
 	// input struct as a receiver, that help the user create new entries within
@@ -245,6 +249,19 @@ type generatedGoListMethod struct {
 	Keys      []goStructField // Keys of the list that is being generated (length = 1 if the list is single keyed).
 	KeyStruct string          // KeyStruct is the name of the struct used as a key for a multi-keyed list.
 	Receiver  string          // Receiver is the name of the parent struct of the list, which is the receiver for the generated method.
+	// OrderedMap is the name of the ordered map type that stores the entries
+	// of the list, which is only set if the list is "ordered-by user" and
+	// ordered maps are being generated.
+	OrderedMap string
+}
+
+// generatedGoOrderedListMethods contains the fields required for generating
+// the methods of the parent struct of a list that is stored in an ordered map.
+type generatedGoOrderedListMethods struct {
+	*generatedGoListMethod
+	GenerateGetters bool // GenerateGetters indicates whether GetOrCreate and Get methods are generated.
+	GenerateDelete  bool // GenerateDelete indicates whether a Delete method is generated.
+	GenerateAppend  bool // GenerateAppend indicates whether an Append method is generated.
 }
 
 // generatedGoKeyHelper contains the fields required for generating a method
@@ -303,6 +320,41 @@ type generatedLeafGetter struct {
 	Receiver string
 }
 
+// generatedActionPath is used to represent the parameters required to generate
+// a method that returns the data tree path at which a YANG action is invoked.
+type generatedActionPath struct {
+	// Receiver is the name of the struct representing the input or output
+	// of the action, which is the receiver for the generated method.
+	Receiver string
+	// YANGPath is the schema path of the action.
+	YANGPath string
+	// Keys are the parameters of the method, which specify the keys of each
+	// list that the action is defined within, ordered from the root.
+	Keys []goStructField
+	// Elems are the elements of the data tree path of the action.
+	Elems []*actionPathElem
+}
+
+// actionPathElem is an element of the data tree path at which an action is
+// invoked.
+type actionPathElem struct {
+	// Name is the name of the element.
+	Name string
+	// Keys are the keys of a list element, in the order that they are
+	// specified in the schema. It is nil if the element is not a list.
+	Keys []*actionPathKey
+}
+
+// actionPathKey is a key of a list element within the data tree path at which
+// an action is invoked.
+type actionPathKey struct {
+	// Name is the name of the key leaf.
+	Name string
+	// Index is the index of the method parameter that specifies the value
+	// of the key.
+	Index int
+}
+
 var (
 	// goCommonHeaderTemplate is populated and output at the top of the generated code package
 	goCommonHeaderTemplate = mustMakeTemplate("commonHeader", `
@@ -336,7 +388,7 @@ import (
 	"{{ .GoOptions.GoyangImportPath }}"
 	"{{ .GoOptions.YtypesImportPath }}"
 {{- end }}
-{{- if .GoOptions.IncludeModelData }}
+{{- if or .GoOptions.IncludeModelData .HasActionPaths }}
 	gpb "{{ .GoOptions.GNMIProtoPath }}"
 {{- end }}
 )
@@ -512,6 +564,23 @@ func (t *{{ .StructName }}) Validate(opts ...ygot.ValidationOption) error {
 	}
 	return nil
 }
+`)
+
+	// goStructDefaultsTemplate takes an input generatedGoStruct, and
+	// generates the methods that are used by ygot.PopulateDefaults and
+	// ygot.PruneDefaults to set or remove the default values of its leaves.
+	goStructDefaultsTemplate = mustMakeTemplate("structDefaults", `
+// ΛPopulateDefaults sets each unset leaf of t, and of its descendants, that has
+// an applicable default value in the YANG schema to that default.
+func (t *{{ .StructName }}) ΛPopulateDefaults() error {
+	return ytypes.PopulateDefaults(SchemaTree["{{ .StructName }}"], t)
+}
+
+// ΛPruneDefaults removes each leaf of t, and of its descendants, whose value is
+// equal to its default value in the YANG schema.
+func (t *{{ .StructName }}) ΛPruneDefaults() error {
+	return ytypes.PruneDefaults(SchemaTree["{{ .StructName }}"], t)
+}
 `)
 
 	// goContainerGetterTemplate defines a template that generates a getter function
@@ -914,6 +983,339 @@ func (t *{{ .Receiver }}) Rename{{ .ListName }}(
 	delete(t.{{ .ListName }}, oldK)
 	return nil
 }
+`)
+
+	// goOrderedMapTemplate takes an input generatedGoListMethod struct for a
+	// list that is "ordered-by user" and outputs the ordered map type that
+	// stores the entries of the list, along with its methods. The entries are
+	// stored in a map keyed by the list key, and the order of the entries is
+	// stored as a slice of the keys.
+	goOrderedMapTemplate = mustMakeTemplate("orderedMap", `
+{{ $keyType := .KeyStruct -}}
+{{ if eq .KeyStruct "" }}{{ $keyType = (index .Keys 0).Type }}{{ end -}}
+// {{ .OrderedMap }} is an ordered map that represents the "ordered-by user"
+// list {{ .ListName }} of the {{ .Receiver }} struct. It stores the entries
+// of the list in the order that is specified by the user, such that they can
+// be both iterated in order and looked up by their key.
+type {{ .OrderedMap }} struct {
+	keys     []{{ $keyType }}
+	valueMap map[{{ $keyType }}]*{{ .ListType }}
+}
+
+// IsYANGOrderedList ensures that {{ .OrderedMap }} implements the
+// ygot.GoOrderedMap interface.
+func (*{{ .OrderedMap }}) IsYANGOrderedList() {}
+
+// init initialises the map of the entries of the receiver if it has not
+// already been created.
+func (o *{{ .OrderedMap }}) init() {
+	if o.valueMap == nil {
+		o.valueMap = map[{{ $keyType }}]*{{ .ListType }}{}
+	}
+}
+
+// Keys returns a copy of the keys of the list {{ .ListName }}, in order.
+func (o *{{ .OrderedMap }}) Keys() []{{ $keyType }} {
+	if o == nil {
+		return nil
+	}
+	return append([]{{ $keyType }}{}, o.keys...)
+}
+
+// Values returns the entries of the list {{ .ListName }}, in order.
+func (o *{{ .OrderedMap }}) Values() []*{{ .ListType }} {
+	if o == nil {
+		return nil
+	}
+	var values []*{{ .ListType }}
+	for _, key := range o.keys {
+		values = append(values, o.valueMap[key])
+	}
+	return values
+}
+
+// Len returns the number of entries in the list {{ .ListName }}.
+func (o *{{ .OrderedMap }}) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.keys)
+}
+
+// Get returns the entry of the list {{ .ListName }} with the specified key. If
+// the receiver is nil, or there is no such entry, nil is returned.
+func (o *{{ .OrderedMap }}) Get(key {{ $keyType }}) *{{ .ListType }} {
+	if o == nil {
+		return nil
+	}
+	return o.valueMap[key]
+}
+
+// Delete deletes the entry of the list {{ .ListName }} with the specified key,
+// and reports whether such an entry existed.
+func (o *{{ .OrderedMap }}) Delete(key {{ $keyType }}) bool {
+	if o == nil {
+		return false
+	}
+	if _, ok := o.valueMap[key]; !ok {
+		return false
+	}
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+	delete(o.valueMap, key)
+	return true
+}
+
+// Append appends the supplied {{ .ListType }} struct as the last entry of
+// the list {{ .ListName }}. If the key value(s) specified in the supplied
+// {{ .ListType }} already exist in the list, an error is returned.
+func (o *{{ .OrderedMap }}) Append(v *{{ .ListType }}) error {
+	if v == nil {
+		return fmt.Errorf("nil value for list {{ .ListName }}")
+	}
+
+	{{ if ne .KeyStruct "" -}}
+	{{- range $key := .Keys }}
+	{{- if $key.IsScalarField -}}
+	if v.{{ $key.Name }} == nil {
+		return fmt.Errorf("invalid nil key for {{ $key.Name }}")
+	}
+
+	{{ end -}}
+	{{- end -}}
+	key := {{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{- if $key.IsScalarField }}
+		{{ $key.Name }}: *v.{{ $key.Name }},
+		{{- else }}
+		{{ $key.Name }}: v.{{ $key.Name }},
+		{{- end -}}
+		{{ end }}
+	}
+	{{- else -}}
+	{{- range $key := .Keys -}}
+		{{- if $key.IsScalarField -}}
+	if v.{{ $key.Name }} == nil {
+		return fmt.Errorf("invalid nil key received for {{ $key.Name }}")
+	}
+
+	key := *v.{{ $key.Name }}
+		{{- else -}}
+	key := v.{{ $key.Name }}
+		{{- end -}}
+	{{- end -}}
+	{{- end }}
+
+	if _, ok := o.valueMap[key]; ok {
+		return fmt.Errorf("duplicate key for list {{ .ListName }} %v", key)
+	}
+
+	o.init()
+	o.keys = append(o.keys, key)
+	o.valueMap[key] = v
+	return nil
+}
+
+// AppendNew creates a new entry with the specified keys, and appends it as
+// the last entry of the list {{ .ListName }}. If an entry with the same keys
+// already exists in the list, an error is returned.
+func (o *{{ .OrderedMap }}) AppendNew(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) (*{{ .ListType }}, error) {
+	v := &{{ .ListType }}{
+		{{- range $key := .Keys }}
+		{{- if $key.IsScalarField }}
+		{{ $key.Name }}: &{{ $key.Name }},
+		{{- else }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end -}}
+		{{- end }}
+	}
+	if err := o.Append(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// MoveBefore moves the entry of the list {{ .ListName }} with the specified
+// key such that it immediately precedes the entry with the key before. An
+// error is returned if either entry does not exist.
+func (o *{{ .OrderedMap }}) MoveBefore(key, before {{ $keyType }}) error {
+	return o.move(key, before, false)
+}
+
+// MoveAfter moves the entry of the list {{ .ListName }} with the specified
+// key such that it immediately follows the entry with the key after. An error
+// is returned if either entry does not exist.
+func (o *{{ .OrderedMap }}) MoveAfter(key, after {{ $keyType }}) error {
+	return o.move(key, after, true)
+}
+
+// move moves the entry with the specified key to immediately before, or if
+// after is set, immediately after, the entry with the key ref.
+func (o *{{ .OrderedMap }}) move(key, ref {{ $keyType }}, after bool) error {
+	for _, k := range []{{ $keyType }}{key, ref} {
+		if o.Get(k) == nil {
+			return fmt.Errorf("key %v not found in list {{ .ListName }}", k)
+		}
+	}
+	if key == ref {
+		return nil
+	}
+
+	keys := make([]{{ $keyType }}, 0, len(o.keys))
+	for _, k := range o.keys {
+		switch {
+		case k == key:
+			continue
+		case k == ref && after:
+			keys = append(keys, k, key)
+		case k == ref:
+			keys = append(keys, key, k)
+		default:
+			keys = append(keys, k)
+		}
+	}
+	o.keys = keys
+	return nil
+}
+`)
+
+	// goOrderedListMethodsTemplate takes an input generatedGoListMethod struct
+	// for a list that is "ordered-by user" and outputs the methods, using the
+	// specified receiver, that create, retrieve, delete and append entries of
+	// the list by calling the methods of its ordered map. The methods have the
+	// same signatures as those that are generated for lists that are stored in
+	// Go maps.
+	goOrderedListMethodsTemplate = mustMakeTemplate("orderedListMethods", `
+{{ $length := len .Keys -}}
+// New{{ .ListName }} creates a new entry in the {{ .ListName }} list of the
+// {{ .Receiver}} struct, as the last entry of the list. The keys of the list
+// are populated from the input arguments.
+func (t *{{ .Receiver }}) New{{ .ListName }}(
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) (*{{ .ListType }}, error){
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.{{ .ListName }} == nil {
+		t.{{ .ListName }} = &{{ .OrderedMap }}{}
+	}
+
+	return t.{{ .ListName }}.AppendNew(
+		{{- range $i, $key := .Keys -}}
+		{{ $key.Name }}
+		{{- if ne (inc $i) $length -}}, {{ end -}}
+		{{- end -}})
+}
+{{ if .GenerateGetters }}
+// GetOrCreate{{ .ListName }} retrieves the value with the specified keys from
+// the receiver {{ .Receiver }}. If the entry does not exist, then it is created
+// as the last entry of the list. It returns the existing or new list member.
+func (t *{{ .Receiver }}) GetOrCreate{{ .ListName }}(
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) (*{{ .ListType }}){
+
+	if v := t.Get{{ .ListName }}(
+		{{- range $i, $key := .Keys -}}
+		{{ $key.Name }}
+		{{- if ne (inc $i) $length -}}, {{ end -}}
+		{{- end -}}); v != nil {
+		return v
+	}
+	// Panic if we receive an error, since we should have retrieved an existing
+	// list member. This allows chaining of GetOrCreate methods.
+	v, err := t.New{{ .ListName }}(
+		{{- range $i, $key := .Keys -}}
+		{{ $key.Name }}
+		{{- if ne (inc $i) $length -}}, {{ end -}}
+		{{- end -}})
+	if err != nil {
+		panic(fmt.Sprintf("GetOrCreate{{ .ListName }} got unexpected error: %v", err))
+	}
+	return v
+}
+
+// Get{{ .ListName }} retrieves the value with the specified key from
+// the {{ .ListName }} ordered map field of {{ .Receiver }}. If the receiver is
+// nil, or the specified key is not present in the list, nil is returned such
+// that Get* methods may be safely chained.
+func (t *{{ .Receiver }}) Get{{ .ListName }}(
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) (*{{ .ListType }}){
+
+	if t == nil {
+		return nil
+	}
+
+	{{ if ne .KeyStruct "" -}}
+	return t.{{ .ListName }}.Get({{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end }}
+	})
+	{{- else -}}
+	{{- range $key := .Keys -}}
+	return t.{{ $.ListName }}.Get({{ $key.Name }})
+	{{- end -}}
+	{{- end }}
+}
+{{ end -}}
+{{ if .GenerateDelete }}
+// Delete{{ .ListName }} deletes the value with the specified keys from
+// the receiver {{ .Receiver }}. If there is no such element, the function
+// is a no-op.
+func (t *{{ .Receiver }}) Delete{{ .ListName }}(
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) {
+	{{ if ne .KeyStruct "" -}}
+	t.{{ .ListName }}.Delete({{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end }}
+	})
+	{{- else -}}
+	{{- range $key := .Keys -}}
+	t.{{ $.ListName }}.Delete({{ $key.Name }})
+	{{- end -}}
+	{{- end }}
+}
+{{ end -}}
+{{ if .GenerateAppend }}
+// Append{{ .ListName }} appends the supplied {{ .ListType }} struct as the
+// last entry of the list {{ .ListName }} of {{ .Receiver }}. If the key
+// value(s) specified in the supplied {{ .ListType }} already exist in the
+// list, an error is returned.
+func (t *{{ .Receiver }}) Append{{ .ListName }}(v *{{ .ListType }}) error {
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.{{ .ListName }} == nil {
+		t.{{ .ListName }} = &{{ .OrderedMap }}{}
+	}
+
+	return t.{{ .ListName }}.Append(v)
+}
+{{ end -}}
 `)
 
 	// goKeyMapTemplate defines the template for a function that is generated for a YANG
@@ -936,6 +1338,39 @@ func (t *{{ .Receiver }}) ΛListKeyMap() (map[string]interface{}, error) {
 		{{- end }}
 	}, nil
 }
+`)
+
+	// goActionPathTemplate provides a template to output a method that
+	// returns the data tree path at which a YANG action is invoked, for the
+	// struct representing the input or output of the action.
+	goActionPathTemplate = mustMakeTemplate("actionPath", `
+// ΛActionPath returns the data tree path at which the YANG action
+// {{ .YANGPath }} is invoked
+{{- if .Keys }}, for
+// the list entries with the specified keys{{ end }}.
+func (*{{ .Receiver }}) ΛActionPath(
+	{{- range $i, $key := .Keys -}}
+	{{ if $i }}, {{ end }}{{ $key.Name }} {{ $key.Type }}
+	{{- end -}}
+) (*gpb.Path, error) {
+	{{- range $i, $key := .Keys }}
+	k{{ $i }}, err := ygot.KeyValueAsString({{ $key.Name }})
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for key {{ $key.Name }}, %v", err)
+	}
+	{{- end }}
+	return &gpb.Path{
+		Elem: []*gpb.PathElem{
+			{{- range $elem := .Elems }}
+			{Name: "{{ $elem.Name }}"
+			{{- if $elem.Keys }}, Key: map[string]string{
+				{{- range $i, $key := $elem.Keys }}{{ if $i }}, {{ end }}"{{ $key.Name }}": k{{ $key.Index }}{{ end -}}
+			}{{ end -}}
+			},
+			{{- end }}
+		},
+	}, nil
+}
 `)
 
 	// goEnumMapTemplate provides a template to output a constant map which
@@ -978,6 +1413,30 @@ var ΛEnumTypes = map[string][]reflect.Type{
 	},
 	{{- end }}
 }
+`)
+
+	// goNotificationTypesTemplate provides a template to output a map which
+	// can be used to resolve the qualified name of a YANG notification to
+	// the type of the struct that represents it, along with a function that
+	// unmarshals a notification using the map.
+	goNotificationTypesTemplate = mustMakeTemplate("notificationTypes", `
+// ΛNotificationTypes is a map, keyed by the qualified name of each YANG
+// notification, of the type of the struct that represents the notification.
+// The qualified name of a notification is its path within the data tree,
+// prefixed by the name of the module of its top-level node.
+var ΛNotificationTypes = map[string]reflect.Type{
+	{{- range $name, $struct := . }}
+	"{{ $name }}": reflect.TypeOf((*{{ $struct }})(nil)),
+	{{- end }}
+}
+
+// UnmarshalNotification unmarshals the RFC7951 JSON encoded YANG notification
+// in data, which may be encoded within an RFC8040 notification envelope, into
+// a new instance of the struct that represents it. The struct is selected
+// using the qualified name of the notification.
+func UnmarshalNotification(data []byte, opts ...ytypes.UnmarshalOpt) (*ytypes.Notification, error) {
+	return ytypes.UnmarshalNotification(SchemaTree, ΛNotificationTypes, data, opts...)
+}
 `)
 
 	// goEnumTypeMapAccessTemplate provides a template to output an accessor
@@ -1161,12 +1620,14 @@ func mustMakeTemplate(name, src string) *template.Template {
 // GoDefaultYgotImportPath, and an unset cfg.GoOptions.YtypesImportPath results in the
 // path for ytypes being set to GoDefaultYtypesImportPath. The supplied rootName is the
 // name of the fake root struct, if it was produced - and is used to output a schema
-// definition in the file header.
+// definition in the file header. The hasActionPaths argument indicates whether
+// methods returning the data tree paths of actions are generated, such that the
+// gNMI protobuf package is imported.
 //
 // The header returned is split into two strings, the common header is a header that
 // should be used for all files within the output package. The one off header should
 // be included in only one file of the package.
-func writeGoHeader(yangFiles, includePaths []string, cfg GeneratorConfig, rootName string, modelData []*gpb.ModelData) (string, string, error) {
+func writeGoHeader(yangFiles, includePaths []string, cfg GeneratorConfig, rootName string, modelData []*gpb.ModelData, hasActionPaths bool) (string, string, error) {
 	// Determine the running binary's name.
 	if cfg.Caller == "" {
 		cfg.Caller = genutil.CallerName()
@@ -1203,6 +1664,7 @@ func writeGoHeader(yangFiles, includePaths []string, cfg GeneratorConfig, rootNa
 		EmptyTypeName    string           // EmptyTypeName is the name of the type used for YANG empty types.
 		FakeRootName     string           // FakeRootName is the name of the fake root struct in the YANG type
 		ModelData        []*gpb.ModelData // ModelData contains the gNMI ModelData definition for the input types.
+		HasActionPaths   bool             // HasActionPaths indicates whether methods returning the paths of actions are generated.
 	}{
 		PackageName:      cfg.PackageName,
 		YANGFiles:        yangFiles,
@@ -1214,6 +1676,7 @@ func writeGoHeader(yangFiles, includePaths []string, cfg GeneratorConfig, rootNa
 		BinaryTypeName:   ygot.BinaryTypeName,
 		EmptyTypeName:    ygot.EmptyTypeName,
 		ModelData:        modelData,
+		HasActionPaths:   hasActionPaths,
 	}
 
 	s.FakeRootName = "nil"
@@ -1323,7 +1786,7 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 		annotationPrefix = DefaultAnnotationPrefix
 	}
 
-	if goOpts.AddAnnotationFields {
+	if goOpts.AddAnnotationFields || (goOpts.GenerateLeafMetadata && IsFakeRoot(targetStruct.Entry)) {
 		// Add the top-level struct metadata field.
 		structDef.Fields = append(structDef.Fields, &goStructField{
 			Name: fmt.Sprintf("%sMetadata", annotationPrefix),
@@ -1349,7 +1812,7 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 			// If the field within the struct is a list, then generate code for this list. This
 			// includes extracting any new types that are required to represent the key of a
 			// list that has multiple keys.
-			fieldType, multiKeyListKey, listMethods, listErr := yangListFieldToGoType(field, fieldName, targetStruct, goStructElements, gogen)
+			fieldType, multiKeyListKey, listMethods, listErr := yangListFieldToGoType(field, fieldName, targetStruct, goStructElements, gogen, goOpts.GenerateOrderedMaps)
 			if listErr != nil {
 				errs = append(errs, listErr)
 			}
@@ -1385,6 +1848,13 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 				Type:            fmt.Sprintf("*%s", structName),
 				IsYANGContainer: true,
 			}
+		case util.IsAnydata(field):
+			// The contents of anydata and anyxml nodes are not described by
+			// the schema, so they are stored as arbitrary RFC7951 JSON.
+			fieldDef = &goStructField{
+				Name: fieldName,
+				Type: anydataFieldType,
+			}
 		case field.IsLeaf() || field.IsLeafList():
 			// This is a leaf or leaf-list, so we map it into the Go type that corresponds to the
 			// YANG type that the leaf represents.
@@ -1542,6 +2012,10 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 			log.Infof("field %s has a nil module, error discarded", field.Path())
 		} else {
 			tagBuf.WriteString(fmt.Sprintf(` module:"%s"`, im))
+			// The annotation field is in the module of the field that it
+			// annotates, such that its RFC7952 member name is qualified
+			// in the same way as the field.
+			metadataTagBuf.WriteString(fmt.Sprintf(` module:"%s"`, im))
 		}
 
 		fieldDef.Tags = tagBuf.String()
@@ -1575,11 +2049,36 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 			errs = append(errs, err)
 		}
 	}
+	// The ordered map types of lists that are "ordered-by user" are also
+	// associated with the struct, and stored alongside the list keys.
+	for _, method := range associatedListMethods {
+		if method.OrderedMap == "" {
+			continue
+		}
+		if err := goOrderedMapTemplate.Execute(&listkeyBuf, method); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	// methodBuf is used to store the code generated for methods that have the
 	// target entity's generated struct as a receiver.
 	var methodBuf bytes.Buffer
 	for _, method := range associatedListMethods {
+		if method.OrderedMap != "" {
+			// Lists that are stored in an ordered map have methods that call
+			// the methods of the ordered map, and are not renamed since the
+			// key of an entry determines its position in the list.
+			if err := goOrderedListMethodsTemplate.Execute(&methodBuf, generatedGoOrderedListMethods{
+				generatedGoListMethod: method,
+				GenerateGetters:       goOpts.GenerateGetters,
+				GenerateDelete:        goOpts.GenerateDeleteMethod,
+				GenerateAppend:        goOpts.GenerateAppendMethod,
+			}); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
 		if err := goNewListMemberTemplate.Execute(&methodBuf, method); err != nil {
 			errs = append(errs, err)
 		}
@@ -1631,6 +2130,12 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 		errs = append(errs, err)
 	}
 
+	if goOpts.GenerateRPCTypes {
+		if err := generateActionPath(&methodBuf, targetStruct, goStructElements); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	// interfaceBuf is used to store the code generated for interfaces that
 	// are used for multi-type unions within the struct.
 	var interfaceBuf bytes.Buffer
@@ -1663,6 +2168,12 @@ func writeGoStruct(targetStruct *Directory, goStructElements map[string]*Directo
 			errs = append(errs, err)
 		}
 
+		if goOpts.GenerateDefaultsMethods {
+			if err := goStructDefaultsTemplate.Execute(&methodBuf, structDef); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
 		if err := generateEnumTypeMapAccessor(&methodBuf, structDef); err != nil {
 			errs = append(errs, err)
 		}
@@ -1883,6 +2394,62 @@ func generateGetListKey(buf *bytes.Buffer, s *Directory, nameMap map[string]*yan
 	return goKeyMapTemplate.Execute(buf, h)
 }
 
+// isActionInputOrOutput reports whether the yang.Entry e is the input or output
+// of a YANG action.
+func isActionInputOrOutput(e *yang.Entry) bool {
+	return util.IsRPCInputOrOutput(e) && util.IsAction(e.Parent)
+}
+
+// generateActionPath generates a method that returns the data tree path at
+// which the action that s is the input or output of is invoked, and appends
+// it to the supplied buffer. The method takes the keys of each list that the
+// action is defined within as arguments, with the types used for the keys of
+// the structs representing the lists in goStructElements. No method is
+// generated if s does not represent the input or output of an action.
+func generateActionPath(buf *bytes.Buffer, s *Directory, goStructElements map[string]*Directory) error {
+	if !isActionInputOrOutput(s.Entry) {
+		return nil
+	}
+	action := s.Entry.Parent
+
+	var lineage []*yang.Entry
+	for e := action; e.Parent != nil; e = e.Parent {
+		if !util.IsChoiceOrCase(e) {
+			lineage = append([]*yang.Entry{e}, lineage...)
+		}
+	}
+
+	ap := generatedActionPath{
+		Receiver: s.Name,
+		YANGPath: action.Path(),
+	}
+	usedKeyNames := map[string]bool{}
+	for _, e := range lineage {
+		elem := &actionPathElem{Name: e.Name}
+		ap.Elems = append(ap.Elems, elem)
+		if !e.IsList() || e.Key == "" {
+			continue
+		}
+		list, ok := goStructElements[e.Path()]
+		if !ok || list.ListAttr == nil {
+			return fmt.Errorf("struct for list %s, within which action %s is defined, did not exist", e.Path(), action.Path())
+		}
+		for _, k := range strings.Fields(e.Key) {
+			kt, ok := list.ListAttr.Keys[k]
+			if !ok {
+				return fmt.Errorf("type of key %s of list %s did not exist", k, e.Path())
+			}
+			elem.Keys = append(elem.Keys, &actionPathKey{Name: k, Index: len(ap.Keys)})
+			ap.Keys = append(ap.Keys, goStructField{
+				Name: genutil.MakeNameUnique(genutil.EntryCamelCaseName(e.Dir[k]), usedKeyNames),
+				Type: kt.NativeType,
+			})
+		}
+	}
+
+	return goActionPathTemplate.Execute(buf, ap)
+}
+
 // yangListFieldToGoType takes a yang.Entry (listField) and returns a string corresponding to the Go
 // type that should be used to represent it within its parent struct (the parent argument). A map, keyed
 // by schema path, of the other code entities that have been extracted within the context that the
@@ -1896,9 +2463,11 @@ func generateGetListKey(buf *bytes.Buffer, s *Directory, nameMap map[string]*yan
 //	- If the list has multiple keys, a new struct is defined which represents the set of
 //	  leaves that make up the key. The type of the list is then a map, keyed by the new struct
 //	  type.
+//	- If the list is keyed and "ordered-by user", and generateOrderedMaps is set, a pointer
+//	  to an ordered map type, which stores the entries keyed as above, is returned.
 // In the case that the list has multiple keys, the type generated as the key of the list is returned.
 // If errors are encountered during the type generation for the list, the error is returned.
-func yangListFieldToGoType(listField *yang.Entry, listFieldName string, parent *Directory, goStructElements map[string]*Directory, gogen *goGenState) (string, *generatedGoMultiKeyListStruct, *generatedGoListMethod, error) {
+func yangListFieldToGoType(listField *yang.Entry, listFieldName string, parent *Directory, goStructElements map[string]*Directory, gogen *goGenState, generateOrderedMaps bool) (string, *generatedGoMultiKeyListStruct, *generatedGoListMethod, error) {
 	// The list itself, since it is a container, has a struct associated with it. Retrieve
 	// this from the set of Directory structs for which code (a Go struct) will be
 	//  generated such that additional details can be used in the code generation.
@@ -1975,6 +2544,11 @@ func yangListFieldToGoType(listField *yang.Entry, listFieldName string, parent *
 		Receiver:  parent.Name,
 	}
 
+	if generateOrderedMaps && util.IsOrderedByUser(listField) {
+		listMethodSpec.OrderedMap = fmt.Sprintf("%s_%s_OrderedMap", parent.Name, listFieldName)
+		listType = fmt.Sprintf("*%s", listMethodSpec.OrderedMap)
+	}
+
 	return listType, multiListKey, listMethodSpec, nil
 }
 
@@ -2132,6 +2706,49 @@ func generateEnumTypeMap(enumTypeMap map[string][]string) (string, error) {
 	return buf.String(), nil
 }
 
+// generateNotificationTypes generates a map of the qualified name of each YANG
+// notification within the supplied directories to the type of the struct that
+// represents it, and a function that unmarshals a notification into the struct
+// selected using the map. An empty string is returned if there are no
+// notifications.
+func generateNotificationTypes(directories map[string]*Directory) (string, error) {
+	types := map[string]string{}
+	for _, d := range directories {
+		if !util.IsNotification(d.Entry) {
+			continue
+		}
+		n, err := notificationName(d.Entry)
+		if err != nil {
+			return "", err
+		}
+		types[n] = d.Name
+	}
+	if len(types) == 0 {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := goNotificationTypesTemplate.Execute(&buf, types); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// notificationName returns the qualified name of the notification e, which is
+// its path within the data tree, prefixed by the name of the module of its
+// top-level node, for example, "module:container/list/notification".
+func notificationName(e *yang.Entry) (string, error) {
+	top := e
+	for top.Parent != nil && !util.IsRoot(top.Parent) {
+		top = top.Parent
+	}
+	mod, err := top.InstantiatingModule()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine module of notification %s, %v", e.Path(), err)
+	}
+	return fmt.Sprintf("%s:%s", mod, strings.Join(util.SchemaPathNoChoiceCase(e)[1:], "/")), nil
+}
+
 // generateEnumTypeMapAccessor generates a function which returns the defined
 // enumTypeMap for a struct.
 func generateEnumTypeMapAccessor(b *bytes.Buffer, s generatedGoStruct) error {
@@ -15,6 +15,7 @@
 package ygen
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -759,14 +760,104 @@ func (t *Tstruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes
 		},
 		wantCompressed:   wantGoStructOut{wantErr: true},
 		wantUncompressed: wantGoStructOut{wantErr: true},
+	}, {
+		name: "struct with anydata and anyxml fields",
+		inStructToMap: &Directory{
+			Name: "Tstruct",
+			Fields: map[string]*yang.Entry{
+				"blob": {
+					Name: "blob",
+					Kind: yang.AnyDataEntry,
+					Parent: &yang.Entry{
+						Name: "tstruct",
+						Parent: &yang.Entry{
+							Name: "root-module",
+							Node: &yang.Module{
+								Name: "exmod",
+							},
+						},
+					},
+					Node: &yang.AnyData{Name: "blob", Parent: &yang.Module{Name: "exmod"}},
+				},
+				"raw": {
+					Name: "raw",
+					Kind: yang.AnyXMLEntry,
+					Parent: &yang.Entry{
+						Name: "tstruct",
+						Parent: &yang.Entry{
+							Name: "root-module",
+							Node: &yang.Module{
+								Name: "exmod",
+							},
+						},
+					},
+					Node: &yang.AnyXML{Name: "raw", Parent: &yang.Module{Name: "exmod"}},
+				},
+			},
+			Path: []string{"", "root-module", "tstruct"},
+		},
+		wantCompressed: wantGoStructOut{
+			structs: `
+// Tstruct represents the /root-module/tstruct YANG schema element.
+type Tstruct struct {
+	Blob	*ygot.Anydata	` + "`" + `path:"blob"` + "`" + `
+	Raw	*ygot.Anydata	` + "`" + `path:"raw"` + "`" + `
+}
+
+// IsYANGGoStruct ensures that Tstruct implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Tstruct) IsYANGGoStruct() {}
+`,
+			methods: `
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Tstruct) Validate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Tstruct"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Tstruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+`,
+		},
+		wantUncompressed: wantGoStructOut{
+			structs: `
+// Tstruct represents the /root-module/tstruct YANG schema element.
+type Tstruct struct {
+	Blob	*ygot.Anydata	` + "`" + `path:"blob"` + "`" + `
+	Raw	*ygot.Anydata	` + "`" + `path:"raw"` + "`" + `
+}
+
+// IsYANGGoStruct ensures that Tstruct implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Tstruct) IsYANGGoStruct() {}
+`,
+			methods: `
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Tstruct) Validate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Tstruct"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Tstruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+`,
+		},
 	}, {
 		name: "unknown kind",
 		inStructToMap: &Directory{
 			Name: "AStruct",
 			Fields: map[string]*yang.Entry{
-				"anydata": {
-					Name: "anydata",
-					Kind: yang.AnyDataEntry,
+				"notification": {
+					Name: "notification",
+					Kind: yang.NotificationEntry,
 				},
 			},
 		},
@@ -1638,7 +1729,7 @@ func (t *Container) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTyp
 		},
 		wantSame: true,
 	}, {
-		name: "leaf getter with default value",
+		name: "leaf getter and defaults methods with default value",
 		inStructToMap: &Directory{
 			Name: "Container",
 			Fields: map[string]*yang.Entry{
@@ -1670,7 +1761,8 @@ func (t *Container) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTyp
 			Path: []string{"foo", "bar"},
 		},
 		inGoOpts: GoOpts{
-			GenerateLeafGetters: true,
+			GenerateLeafGetters:     true,
+			GenerateDefaultsMethods: true,
 		},
 		wantCompressed: wantGoStructOut{
 			structs: `
@@ -1707,6 +1799,18 @@ func (t *Container) Validate(opts ...ygot.ValidationOption) error {
 	return nil
 }
 
+// ΛPopulateDefaults sets each unset leaf of t, and of its descendants, that has
+// an applicable default value in the YANG schema to that default.
+func (t *Container) ΛPopulateDefaults() error {
+	return ytypes.PopulateDefaults(SchemaTree["Container"], t)
+}
+
+// ΛPruneDefaults removes each leaf of t, and of its descendants, whose value is
+// equal to its default value in the YANG schema.
+func (t *Container) ΛPruneDefaults() error {
+	return ytypes.PruneDefaults(SchemaTree["Container"], t)
+}
+
 // ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
 // that are included in the generated code.
 func (t *Container) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
@@ -2291,3 +2395,133 @@ func TestGoLeafDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateActionPath(t *testing.T) {
+	module := &yang.Entry{Name: "module", Kind: yang.DirectoryEntry}
+	container := &yang.Entry{Name: "a", Kind: yang.DirectoryEntry, Parent: module}
+	outer := &yang.Entry{
+		Name:     "b",
+		Kind:     yang.DirectoryEntry,
+		Parent:   container,
+		ListAttr: &yang.ListAttr{},
+		Key:      "name",
+	}
+	inner := &yang.Entry{
+		Name:     "c",
+		Kind:     yang.DirectoryEntry,
+		Parent:   outer,
+		ListAttr: &yang.ListAttr{},
+		Key:      "name id",
+	}
+	outer.Dir = map[string]*yang.Entry{
+		"name": {Name: "name", Kind: yang.LeafEntry, Parent: outer},
+		"c":    inner,
+	}
+	inner.Dir = map[string]*yang.Entry{
+		"name": {Name: "name", Kind: yang.LeafEntry, Parent: inner},
+		"id":   {Name: "id", Kind: yang.LeafEntry, Parent: inner},
+	}
+	action := &yang.Entry{
+		Name:   "reset",
+		Kind:   yang.DirectoryEntry,
+		Parent: inner,
+		Node:   &yang.Action{Name: "reset"},
+	}
+	input := &yang.Entry{Name: "input", Kind: yang.InputEntry, Parent: action}
+	action.RPC = &yang.RPCEntry{Input: input}
+	inner.Dir["reset"] = action
+
+	rpc := &yang.Entry{
+		Name:   "reboot",
+		Kind:   yang.DirectoryEntry,
+		Parent: module,
+		Node:   &yang.RPC{Name: "reboot"},
+	}
+	rpcInput := &yang.Entry{Name: "input", Kind: yang.InputEntry, Parent: rpc}
+	rpc.RPC = &yang.RPCEntry{Input: rpcInput}
+
+	goStructElements := map[string]*Directory{
+		outer.Path(): {
+			Name:  "B",
+			Entry: outer,
+			ListAttr: &YangListAttr{
+				Keys: map[string]*MappedType{
+					"name": {NativeType: "string"},
+				},
+			},
+		},
+		inner.Path(): {
+			Name:  "B_C",
+			Entry: inner,
+			ListAttr: &YangListAttr{
+				Keys: map[string]*MappedType{
+					"name": {NativeType: "string"},
+					"id":   {NativeType: "uint32"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		in      *Directory
+		inDirs  map[string]*Directory
+		want    string
+		wantErr bool
+	}{{
+		name:   "action within nested lists",
+		in:     &Directory{Name: "B_C_Reset_Input", Entry: input},
+		inDirs: goStructElements,
+		want: `
+// ΛActionPath returns the data tree path at which the YANG action
+// /module/a/b/c/reset is invoked, for
+// the list entries with the specified keys.
+func (*B_C_Reset_Input) ΛActionPath(Name string, Name_ string, Id uint32) (*gpb.Path, error) {
+	k0, err := ygot.KeyValueAsString(Name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for key Name, %v", err)
+	}
+	k1, err := ygot.KeyValueAsString(Name_)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for key Name_, %v", err)
+	}
+	k2, err := ygot.KeyValueAsString(Id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for key Id, %v", err)
+	}
+	return &gpb.Path{
+		Elem: []*gpb.PathElem{
+			{Name: "a"},
+			{Name: "b", Key: map[string]string{"name": k0}},
+			{Name: "c", Key: map[string]string{"name": k1, "id": k2}},
+			{Name: "reset"},
+		},
+	}, nil
+}
+`,
+	}, {
+		name: "rpc input",
+		in:   &Directory{Name: "Reboot_Input", Entry: rpcInput},
+	}, {
+		name: "container",
+		in:   &Directory{Name: "A", Entry: container},
+	}, {
+		name:    "missing list struct",
+		in:      &Directory{Name: "B_C_Reset_Input", Entry: input},
+		inDirs:  map[string]*Directory{},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := generateActionPath(&buf, tt.in, tt.inDirs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generateActionPath: got error: %v, want error: %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, buf.String()); diff != "" {
+				t.Errorf("generateActionPath: did not get expected code, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
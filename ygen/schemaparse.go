@@ -35,20 +35,42 @@ import (
 // YANG directories are annotated in the output JSON with the name of the type
 // they correspond to in the generated code, and the absolute schema path that
 // the entry corresponds to. In the case that the fake root struct that is provided
-// is nil, a synthetic root entry is used to store the schema tree.
-func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry, compressed bool) ([]byte, error) {
+// is nil, a synthetic root entry is used to store the schema tree. If the rpcs
+// boolean is set, the rpc entries of each module are also stored within the
+// root, such that the schema of their input and output can be found. The
+// notifications boolean similarly stores the notification entries of each
+// module within the root. If the features map is non-nil, it is recorded
+// within the root as the set of enabled YANG features.
+func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry, compressed, rpcs, notifications bool, features map[string][]string) ([]byte, error) {
 	rootEntry := &yang.Entry{
 		Dir:        map[string]*yang.Entry{},
 		Annotation: map[string]interface{}{},
 	}
 	for _, m := range ms {
 		annotateChildren(m, dn)
-		for _, ch := range util.Children(m) {
+		children := util.Children(m)
+		for _, ch := range m.Dir {
+			if rpcs && util.IsRPCOrAction(ch) || notifications && util.IsNotification(ch) {
+				children = append(children, ch)
+			}
+		}
+		for _, ch := range children {
 			if _, ex := rootEntry.Dir[ch.Name]; ex {
 				return nil, fmt.Errorf("overlapping root children for key %s", ch.Name)
 			}
 			rootEntry.Dir[ch.Name] = ch
 		}
+		// The root-level entries that were removed for disabled features
+		// are recorded against the root, since module entries are not
+		// included in the serialised schema.
+		for n, exprs := range util.DisabledChildren(m) {
+			dc, ok := rootEntry.Annotation[util.DisabledChildrenAnnotation].(map[string][]string)
+			if !ok {
+				dc = map[string][]string{}
+				rootEntry.Annotation[util.DisabledChildrenAnnotation] = dc
+			}
+			dc[n] = exprs
+		}
 	}
 
 	if fakeroot != nil {
@@ -66,6 +88,32 @@ func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry,
 		rootEntry.Annotation[util.CompressedSchemaAnnotation] = compressed
 	}
 
+	// Annotate the root with the namespace of each module, such that data
+	// can be encoded as XML using the serialised schema.
+	if ns := moduleNamespaces(ms); len(ns) != 0 {
+		rootEntry.Annotation[util.ModuleNamespacesAnnotation] = ns
+	}
+
+	// Annotate the root with the modules that the prefixes used within each
+	// module refer to, such that the prefixes within the XPath expressions
+	// of the schema can be resolved.
+	if ps := modulePrefixes(ms); len(ps) != 0 {
+		rootEntry.Annotation[util.ModulePrefixesAnnotation] = ps
+	}
+
+	// Annotate the root with the RFC7952 metadata annotations that are
+	// defined by the modules, such that they can be recognised when
+	// unmarshalling.
+	if md := metadataAnnotations(ms); len(md) != 0 {
+		rootEntry.Annotation[util.MetadataAnnotationsAnnotation] = md
+	}
+
+	// Annotate the root with the set of enabled features, such that it
+	// is known which features are supported by the schema.
+	if features != nil {
+		rootEntry.Annotation[util.EnabledFeaturesAnnotation] = features
+	}
+
 	j, err := json.MarshalIndent(rootEntry, "", strings.Repeat(" ", 4))
 	if err != nil {
 		return nil, fmt.Errorf("JSON marshalling error: %v", err)
@@ -76,6 +124,60 @@ func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry,
 	return j, nil
 }
 
+// moduleNamespaces returns the XML namespace of each of the supplied module
+// entries, keyed by the name of the module. Only modules that define data
+// nodes or identities are included, since the namespaces of other modules
+// are not used when encoding data. Modules without a namespace, such as
+// those that are not created from a YANG module, are omitted.
+func moduleNamespaces(ms []*yang.Entry) map[string]string {
+	nss := map[string]string{}
+	for _, m := range ms {
+		mod, ok := m.Node.(*yang.Module)
+		if !ok || mod.Namespace == nil || mod.Namespace.Name == "" {
+			continue
+		}
+		if len(m.Dir) == 0 && len(m.Identities) == 0 {
+			continue
+		}
+		nss[mod.Name] = mod.Namespace.Name
+	}
+	return nss
+}
+
+// modulePrefixes returns the names of the modules that the prefixes used
+// within each of the supplied module entries refer to, keyed by the name of
+// the module and then by the prefix.
+func modulePrefixes(ms []*yang.Entry) map[string]map[string]string {
+	ps := map[string]map[string]string{}
+	for _, m := range ms {
+		mod, ok := m.Node.(*yang.Module)
+		if !ok {
+			continue
+		}
+		if p := util.ModuleImportPrefixes(mod); len(p) != 0 {
+			ps[mod.Name] = p
+		}
+	}
+	return ps
+}
+
+// metadataAnnotations returns the RFC7952 metadata annotations that are
+// defined within the supplied module entries, keyed by the module-qualified
+// name of the annotation.
+func metadataAnnotations(ms []*yang.Entry) map[string]string {
+	md := map[string]string{}
+	for _, m := range ms {
+		mod, ok := m.Node.(*yang.Module)
+		if !ok {
+			continue
+		}
+		for n, t := range util.ModuleMetadataAnnotations(mod) {
+			md[n] = t
+		}
+	}
+	return md
+}
+
 // annotateChildren annotates the children of e with their schema path, and the value corresponding
 // to its path in the supplied dn map. The dn map is assumed to contain the
 // names of unique directories that are generated within the code to be output.
@@ -90,6 +192,21 @@ func annotateChildren(e *yang.Entry, dn map[string]string) {
 			annotateChildren(ch, dn)
 		}
 	}
+	// Annotate any notification entries, and the input and output of any
+	// rpc or action entries, since structs may be generated for them.
+	for _, ch := range e.Dir {
+		switch {
+		case util.IsNotification(ch):
+			annotateChildren(ch, dn)
+		case util.IsRPCOrAction(ch) && ch.RPC != nil:
+			annotateEntry(ch, dn)
+			for _, io := range []*yang.Entry{ch.RPC.Input, ch.RPC.Output} {
+				if io != nil {
+					annotateChildren(io, dn)
+				}
+			}
+		}
+	}
 }
 
 // annotateEntry modifies the yang.Entry e to:
@@ -97,8 +214,14 @@ func annotateChildren(e *yang.Entry, dn map[string]string) {
 //    filesizes of serialised schemas.
 //  - add the struct name corresponding to the path of the entry
 //    in the supplied dn map to the annotations.
-//  - add the YANG schema path to the annotations, where e
-//    corresponds to a YANG directory.
+//  - add the YANG schema path, and the name of the module that
+//    instantiates e, to the annotations, where e corresponds to a YANG
+//    directory.
+//  - add any must and when statements of the entry to the annotations,
+//    such that they can be evaluated when validating the data tree.
+//  - add the arguments of any unique statements of a list entry to the
+//    annotations.
+//  - add the presence statement of a container entry to the annotations.
 func annotateEntry(e *yang.Entry, dn map[string]string) {
 	e.Description = ""
 	if e.Annotation == nil {
@@ -109,6 +232,47 @@ func annotateEntry(e *yang.Entry, dn map[string]string) {
 	}
 	if e.IsDir() {
 		e.Annotation["schemapath"] = e.Path()
+		if !util.IsFakeRoot(e) && e.Node != nil {
+			if m, err := e.InstantiatingModule(); err == nil {
+				e.Annotation[util.ModuleAnnotation] = m
+			}
+		}
+	}
+	if m := util.MustStatements(e); len(m) != 0 {
+		e.Annotation[util.MustAnnotation] = m
+	}
+	if w, ok := util.WhenStatement(e); ok {
+		e.Annotation[util.WhenAnnotation] = w
+	}
+	if u := util.UniqueStatements(e); len(u) != 0 {
+		e.Annotation[util.UniqueAnnotation] = u
+	}
+	if c, ok := e.Node.(*yang.Container); ok && c.Presence != nil {
+		e.Annotation[util.PresenceAnnotation] = c.Presence.Name
+	}
+}
+
+// annotateInheritedWhen walks the schema tree rooted at e, storing the when
+// statements of the augment and uses statements through which each entry was
+// added to its parent within the InheritedWhenAnnotation of the entry. The
+// modules must have been parsed with the StoreUses option of the goyang
+// library set, such that the uses statements are available.
+func annotateInheritedWhen(e *yang.Entry) {
+	if e == nil {
+		return
+	}
+	for _, ch := range e.Dir {
+		if ws := util.InheritedWhenStatements(ch); len(ws) != 0 {
+			if ch.Annotation == nil {
+				ch.Annotation = map[string]interface{}{}
+			}
+			ch.Annotation[util.InheritedWhenAnnotation] = ws
+		}
+		annotateInheritedWhen(ch)
+	}
+	if e.RPC != nil {
+		annotateInheritedWhen(e.RPC.Input)
+		annotateInheritedWhen(e.RPC.Output)
 	}
 }
 
@@ -16,6 +16,7 @@ package ygen
 
 import (
 	"encoding/json"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -24,6 +25,7 @@ import (
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
 )
 
@@ -88,12 +90,76 @@ func TestBuildJSONTree(t *testing.T) {
 		"container-two": moduleTwoContainerTwo,
 	}
 
+	rpcModule := &yang.Entry{
+		Name: "rpc-module",
+		Kind: yang.DirectoryEntry,
+	}
+	rpcEntry := &yang.Entry{
+		Name:   "reboot",
+		Kind:   yang.DirectoryEntry,
+		Parent: rpcModule,
+	}
+	rpcEntry.RPC = &yang.RPCEntry{
+		Input: &yang.Entry{
+			Name:   "input",
+			Kind:   yang.InputEntry,
+			Parent: rpcEntry,
+			Dir: map[string]*yang.Entry{
+				"delay": {
+					Name: "delay",
+					Kind: yang.LeafEntry,
+				},
+			},
+		},
+	}
+	rpcModule.Dir = map[string]*yang.Entry{
+		"reboot": rpcEntry,
+	}
+
+	featureModule := &yang.Entry{
+		Name: "feature-module",
+		Kind: yang.DirectoryEntry,
+		Annotation: map[string]interface{}{
+			util.DisabledChildrenAnnotation: map[string][]string{
+				"legacy": {"feature-module:legacy"},
+			},
+		},
+	}
+	featureModule.Dir = map[string]*yang.Entry{
+		"leaf": {
+			Name:   "leaf",
+			Kind:   yang.LeafEntry,
+			Parent: featureModule,
+		},
+	}
+
+	notificationModule := &yang.Entry{
+		Name: "notification-module",
+		Kind: yang.DirectoryEntry,
+	}
+	notificationModule.Dir = map[string]*yang.Entry{
+		"alarm": {
+			Name:   "alarm",
+			Kind:   yang.NotificationEntry,
+			Parent: notificationModule,
+			Dir: map[string]*yang.Entry{
+				"severity": {
+					Name: "severity",
+					Kind: yang.LeafEntry,
+				},
+			},
+		},
+	}
+
 	tests := []struct {
 		name             string
 		inEntries        []*yang.Entry
 		inDirectoryNames map[string]string
 		inFakeRoot       *yang.Entry
 		inCompressed     bool
+		inRPCs           bool
+		inNotifications  bool
+		inFeatures       map[string][]string
 		want             string
 		wantErr          string
 	}{{
@@ -218,6 +284,137 @@ func TestBuildJSONTree(t *testing.T) {
 			"/a-module/simple-container": "uniqueName",
 		},
 		wantErr: "overlapping root children for key simple-container",
+	}, {
+		name:      "module with rpc, rpcs not included",
+		inEntries: []*yang.Entry{rpcModule},
+		inDirectoryNames: map[string]string{
+			"/rpc-module/reboot/input": "Reboot_Input",
+		},
+		want: `{
+    "Name": "",
+    "Kind": 0,
+    "Config": 0,
+    "Annotation": {
+        "isFakeRoot": true
+    }
+}`,
+	}, {
+		name:      "module with rpc, rpcs included",
+		inEntries: []*yang.Entry{rpcModule},
+		inDirectoryNames: map[string]string{
+			"/rpc-module/reboot/input": "Reboot_Input",
+		},
+		inRPCs: true,
+		want: `{
+    "Name": "",
+    "Kind": 0,
+    "Config": 0,
+    "Dir": {
+        "reboot": {
+            "Name": "reboot",
+            "Kind": 1,
+            "Config": 0,
+            "RPC": {
+                "Input": {
+                    "Name": "input",
+                    "Kind": 6,
+                    "Config": 0,
+                    "Dir": {
+                        "delay": {
+                            "Name": "delay",
+                            "Kind": 0,
+                            "Config": 0
+                        }
+                    },
+                    "Annotation": {
+                        "schemapath": "/rpc-module/reboot/input",
+                        "structname": "Reboot_Input"
+                    }
+                },
+                "Output": null
+            }
+        }
+    },
+    "Annotation": {
+        "isFakeRoot": true
+    }
+}`,
+	}, {
+		name:      "module with notification, notifications not included",
+		inEntries: []*yang.Entry{notificationModule},
+		inDirectoryNames: map[string]string{
+			"/notification-module/alarm": "Alarm",
+		},
+		want: `{
+    "Name": "",
+    "Kind": 0,
+    "Config": 0,
+    "Annotation": {
+        "isFakeRoot": true
+    }
+}`,
+	}, {
+		name:      "module with notification, notifications included",
+		inEntries: []*yang.Entry{notificationModule},
+		inDirectoryNames: map[string]string{
+			"/notification-module/alarm": "Alarm",
+		},
+		inNotifications: true,
+		want: `{
+    "Name": "",
+    "Kind": 0,
+    "Config": 0,
+    "Dir": {
+        "alarm": {
+            "Name": "alarm",
+            "Kind": 7,
+            "Config": 0,
+            "Dir": {
+                "severity": {
+                    "Name": "severity",
+                    "Kind": 0,
+                    "Config": 0
+                }
+            },
+            "Annotation": {
+                "schemapath": "/notification-module/alarm",
+                "structname": "Alarm"
+            }
+        }
+    },
+    "Annotation": {
+        "isFakeRoot": true
+    }
+}`,
+	}, {
+		name:       "module with disabled features",
+		inEntries:  []*yang.Entry{featureModule},
+		inFeatures: map[string][]string{"feature-module": {"counters"}},
+		want: `{
+    "Name": "",
+    "Kind": 0,
+    "Config": 0,
+    "Dir": {
+        "leaf": {
+            "Name": "leaf",
+            "Kind": 0,
+            "Config": 0
+        }
+    },
+    "Annotation": {
+        "enabled-features": {
+            "feature-module": [
+                "counters"
+            ]
+        },
+        "feature-disabled-children": {
+            "legacy": [
+                "feature-module:legacy"
+            ]
+        },
+        "isFakeRoot": true
+    }
+}`,
 	}, {
 		name:      "non-nil fake root",
 		inEntries: []*yang.Entry{simpleModule},
@@ -259,7 +456,7 @@ func TestBuildJSONTree(t *testing.T) {
 	}}
 
 	for _, tt := range tests {
-		gotb, err := buildJSONTree(tt.inEntries, tt.inDirectoryNames, tt.inFakeRoot, tt.inCompressed)
+		gotb, err := buildJSONTree(tt.inEntries, tt.inDirectoryNames, tt.inFakeRoot, tt.inCompressed, tt.inRPCs, tt.inNotifications, tt.inFeatures)
 		if err != nil && err.Error() != tt.wantErr {
 			t.Errorf("%s: buildJSONTree(%v, %v): did not get expected error, got: %v, want: %v", tt.name, tt.inEntries, tt.inDirectoryNames, err, tt.wantErr)
 		}
@@ -469,7 +666,7 @@ func TestSchemaRoundtrip(t *testing.T) {
 	}}
 
 	for _, tt := range tests {
-		gotByte, err := buildJSONTree(tt.inEntries, tt.inDirectoryNames, tt.inFakeRoot, tt.inCompressed)
+		gotByte, err := buildJSONTree(tt.inEntries, tt.inDirectoryNames, tt.inFakeRoot, tt.inCompressed, false, false, nil)
 		if err != nil && err.Error() != tt.wantJSONErr {
 			t.Errorf("%s: buildJSONTree(%v, %v): did not get expected error, got: %v, want: %v", tt.name, tt.inEntries, tt.inDirectoryNames, err, tt.wantJSONErr)
 			continue
@@ -496,3 +693,26 @@ func TestSchemaRoundtrip(t *testing.T) {
 		}
 	}
 }
+
+func TestAnnotateInheritedWhen(t *testing.T) {
+	modules, errs := processModules([]string{filepath.Join(datapath, "openconfig-inherited-when.yang")}, nil, yang.Options{StoreUses: true})
+	if errs != nil {
+		t.Fatalf("processModules: got unexpected errors, %v", errs)
+	}
+	if len(modules) != 1 {
+		t.Fatalf("processModules: got %d modules, want 1", len(modules))
+	}
+	annotateInheritedWhen(modules[0])
+
+	intf := modules[0].Dir["interface"]
+	for name, want := range map[string][]string{
+		"name":    nil,
+		"speed":   {"type = 'ethernet'"},
+		"address": {"type = 'loopback'"},
+	} {
+		got, _ := intf.Dir[name].Annotation[util.InheritedWhenAnnotation].([]string)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("%s: did not get expected inherited when statements, (-want, +got):\n%s", name, diff)
+		}
+	}
+}
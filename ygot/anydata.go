@@ -0,0 +1,121 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Anydata is the value of a YANG anydata or anyxml node, whose contents are
+// not described by the schema of the node. Generated GoStructs use a
+// *Anydata field for each anydata and anyxml node.
+//
+// The contents are stored as their RFC7951 JSON encoding, such that they are
+// rendered unchanged by the RFC7951 JSON marshalling functions, and are
+// encoded as a JSON_IETF TypedValue within gNMI notifications.
+type Anydata struct {
+	// Value is the contents of the node, encoded as RFC7951 JSON and stored
+	// using the types produced by encoding/json when unmarshalling into an
+	// interface{}; i.e., map[string]interface{}, []interface{}, string,
+	// float64, bool and nil. The value of an anydata node is a JSON object,
+	// whereas the value of an anyxml node can be any JSON value.
+	Value interface{}
+	// Schema is the schema of the contents of the node, which may be bound
+	// at runtime when the contents are known to be described by a YANG
+	// module. The members of Value are children of Schema, such that the
+	// schema of a module, returned by yang.ToEntry, can be used. When Schema
+	// is set, the contents are validated against it.
+	Schema *yang.Entry
+}
+
+// IsYANGAnydata ensures that Anydata implements the interface used to
+// distinguish it from the GoStructs that represent YANG containers.
+func (*Anydata) IsYANGAnydata() {}
+
+// NewAnydata returns an Anydata with the contents v, which is any Go value
+// that can be marshalled by encoding/json to the RFC7951 JSON encoding of
+// the contents.
+func NewAnydata(v interface{}) (*Anydata, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal anydata contents, %v", err)
+	}
+	return NewAnydataFromJSON(b)
+}
+
+// NewAnydataFromJSON returns an Anydata with the contents that are encoded
+// by the RFC7951 JSON document b.
+func NewAnydataFromJSON(b []byte) (*Anydata, error) {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal anydata contents, %v", err)
+	}
+	return &Anydata{Value: v}, nil
+}
+
+// JSON returns the RFC7951 JSON document that encodes the contents of a.
+func (a *Anydata) JSON() ([]byte, error) {
+	return json.Marshal(a.Value)
+}
+
+// isAnydataValue reports whether v is a *Anydata.
+func isAnydataValue(v reflect.Value) bool {
+	return v.IsValid() && util.IsTypeAnydata(v.Type())
+}
+
+// copyAnydata returns a copy of the Anydata a, in which the contents are
+// copied, and the schema, which is not modified, is shared.
+func copyAnydata(a *Anydata) *Anydata {
+	if a == nil {
+		return nil
+	}
+	return &Anydata{Value: copyJSONValue(a.Value), Schema: a.Schema}
+}
+
+// copyJSONValue returns a deep copy of the JSON value v.
+func copyJSONValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(vv))
+		for k, mv := range vv {
+			m[k] = copyJSONValue(mv)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(vv))
+		for i, lv := range vv {
+			l[i] = copyJSONValue(lv)
+		}
+		return l
+	}
+	return v
+}
+
+// anydataTypedValue returns the JSON_IETF TypedValue that encodes the
+// contents of a.
+func anydataTypedValue(a *Anydata) (*gnmipb.TypedValue, error) {
+	b, err := a.JSON()
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal anydata contents, %v", err)
+	}
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: b}}, nil
+}
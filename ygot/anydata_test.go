@@ -0,0 +1,191 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+type anydataExample struct {
+	Name *string              `path:"name" module:"m1"`
+	Blob *Anydata             `path:"blob" module:"m1"`
+	Sub  *anydataExampleChild `path:"sub" module:"m1"`
+}
+
+func (*anydataExample) IsYANGGoStruct()                         {}
+func (*anydataExample) Validate(...ValidationOption) error      { return nil }
+func (*anydataExample) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+
+type anydataExampleChild struct {
+	Raw *Anydata `path:"raw" module:"m2"`
+}
+
+func (*anydataExampleChild) IsYANGGoStruct() {}
+
+func mustAnydata(t *testing.T, j string) *Anydata {
+	t.Helper()
+	a, err := NewAnydataFromJSON([]byte(j))
+	if err != nil {
+		t.Fatalf("NewAnydataFromJSON(%s): got unexpected error, %v", j, err)
+	}
+	return a
+}
+
+func TestNewAnydata(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               interface{}
+		want             *Anydata
+		wantErrSubstring string
+	}{{
+		desc: "map",
+		in:   map[string]interface{}{"a": 1, "b": []string{"c"}},
+		want: &Anydata{Value: map[string]interface{}{"a": float64(1), "b": []interface{}{"c"}}},
+	}, {
+		desc: "struct",
+		in: struct {
+			A string `json:"m1:a"`
+		}{A: "b"},
+		want: &Anydata{Value: map[string]interface{}{"m1:a": "b"}},
+	}, {
+		desc:             "unmarshallable value",
+		in:               func() {},
+		wantErrSubstring: "cannot marshal anydata contents",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := NewAnydata(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("NewAnydata(%v): did not get expected error, %s", tt.in, diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NewAnydata(%v): did not get expected Anydata, diff(-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestAnydataRender(t *testing.T) {
+	in := &anydataExample{
+		Name: String("a"),
+		Blob: mustAnydata(t, `{"m3:x": {"y": [1, "two", null]}}`),
+		Sub:  &anydataExampleChild{Raw: &Anydata{Value: "text"}},
+	}
+
+	t.Run("RFC7951 JSON", func(t *testing.T) {
+		got, err := ConstructIETFJSON(in, nil)
+		if err != nil {
+			t.Fatalf("ConstructIETFJSON: got unexpected error, %v", err)
+		}
+		want := map[string]interface{}{
+			"name": "a",
+			"blob": map[string]interface{}{"m3:x": map[string]interface{}{"y": []interface{}{float64(1), "two", nil}}},
+			"sub":  map[string]interface{}{"raw": "text"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("ConstructIETFJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("gNMI notifications", func(t *testing.T) {
+		got, err := TogNMINotifications(in, 42, GNMINotificationsConfig{UsePathElem: true})
+		if err != nil {
+			t.Fatalf("TogNMINotifications: got unexpected error, %v", err)
+		}
+		if len(got) != 1 {
+			t.Fatalf("TogNMINotifications: got %d notifications, want 1", len(got))
+		}
+		want := map[string]*gnmipb.TypedValue{
+			"name":    {Value: &gnmipb.TypedValue_StringVal{StringVal: "a"}},
+			"blob":    {Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"m3:x":{"y":[1,"two",null]}}`)}},
+			"sub/raw": {Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`"text"`)}},
+		}
+		if len(got[0].Update) != len(want) {
+			t.Fatalf("TogNMINotifications: got %d updates, want %d: %v", len(got[0].Update), len(want), got[0].Update)
+		}
+		for _, u := range got[0].Update {
+			p, err := PathToString(u.Path)
+			if err != nil {
+				t.Fatalf("PathToString(%v): got unexpected error, %v", u.Path, err)
+			}
+			if w := want[p[1:]]; !proto.Equal(u.Val, w) {
+				t.Errorf("TogNMINotifications: did not get expected value for %s, got: %v, want: %v", p, u.Val, w)
+			}
+		}
+	})
+}
+
+func TestAnydataCopyMergeDiff(t *testing.T) {
+	schema := &yang.Entry{Name: "payload", Kind: yang.DirectoryEntry}
+	orig := &anydataExample{Blob: mustAnydata(t, `{"a": {"b": 1}}`)}
+	orig.Blob.Schema = schema
+
+	t.Run("DeepCopy", func(t *testing.T) {
+		c, err := DeepCopy(orig)
+		if err != nil {
+			t.Fatalf("DeepCopy: got unexpected error, %v", err)
+		}
+		got := c.(*anydataExample)
+		if got.Blob == orig.Blob || got.Blob.Schema != schema || !cmp.Equal(got.Blob.Value, orig.Blob.Value) {
+			t.Fatalf("DeepCopy: did not get expected copy, got: %v, want: %v with shared schema", got.Blob, orig.Blob)
+		}
+		got.Blob.Value.(map[string]interface{})["a"].(map[string]interface{})["b"] = float64(2)
+		if v := orig.Blob.Value.(map[string]interface{})["a"].(map[string]interface{})["b"]; v != float64(1) {
+			t.Errorf("DeepCopy: contents of copy are shared with original, got original value %v", v)
+		}
+	})
+
+	t.Run("MergeStructs", func(t *testing.T) {
+		if _, err := MergeStructs(orig, &anydataExample{Name: String("a"), Blob: mustAnydata(t, `{"a": {"b": 1}}`)}); err != nil {
+			t.Errorf("MergeStructs with equal anydata: got unexpected error, %v", err)
+		}
+		_, err := MergeStructs(orig, &anydataExample{Blob: mustAnydata(t, `{"a": {"b": 2}}`)})
+		if diff := errdiff.Substring(err, "anydata"); diff != "" {
+			t.Errorf("MergeStructs with different anydata: did not get expected error, %s", diff)
+		}
+		got, err := MergeStructs(orig, &anydataExample{Blob: mustAnydata(t, `{"a": {"b": 2}}`)}, &MergeOverwriteExistingFields{})
+		if err != nil {
+			t.Fatalf("MergeStructs with overwrite: got unexpected error, %v", err)
+		}
+		if v := got.(*anydataExample).Blob.Value; !cmp.Equal(v, interface{}(map[string]interface{}{"a": map[string]interface{}{"b": float64(2)}})) {
+			t.Errorf("MergeStructs with overwrite: did not get expected contents, got: %v", v)
+		}
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		got, err := Diff(orig, &anydataExample{Blob: mustAnydata(t, `{"a": {"b": 2}}`)})
+		if err != nil {
+			t.Fatalf("Diff: got unexpected error, %v", err)
+		}
+		want := &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "blob"}}},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"a":{"b":2}}`)}},
+			}},
+		}
+		if !proto.Equal(got, want) {
+			t.Errorf("Diff: did not get expected notification, got: %v, want: %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,630 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// CBOR major types, as defined in RFC8949 Section 3.1.
+const (
+	cborUnsigned byte = 0
+	cborNegative byte = 1
+	cborBytes    byte = 2
+	cborText     byte = 3
+	cborArray    byte = 4
+	cborMap      byte = 5
+	cborTag      byte = 6
+	cborSimple   byte = 7
+)
+
+// CBOR tags that are used by the YANG CBOR encoding, as defined in RFC9254
+// Section 9.3.
+const (
+	// CBORTagDecimalFraction is the tag of a decimal fraction, which is used
+	// to encode decimal64 values.
+	CBORTagDecimalFraction uint64 = 4
+	// CBORTagBits is the tag of a bits value within a union.
+	CBORTagBits uint64 = 43
+	// CBORTagEnumeration is the tag of an enumeration value within a union.
+	CBORTagEnumeration uint64 = 44
+	// CBORTagIdentityref is the tag of an identityref value, encoded as a
+	// SID, within a union.
+	CBORTagIdentityref uint64 = 45
+	// CBORTagInstanceIdentifier is the tag of an instance-identifier value,
+	// encoded using SIDs, within a union.
+	CBORTagInstanceIdentifier uint64 = 46
+	// CBORTagSID is the tag of an absolute SID used as a map key.
+	CBORTagSID uint64 = 47
+)
+
+// CBORConfig specifies the options used when encoding a GoStruct as CBOR.
+type CBORConfig struct {
+	// SIDs specifies the SIDs of the data nodes and identities of the
+	// schema. Where it is set, the members of each container and list entry
+	// are identified by the difference between their SID and that of their
+	// parent, and identityref values are encoded as their SID. Otherwise,
+	// members are identified by name, and identityref values are encoded as
+	// text strings, as per RFC7951.
+	SIDs *SIDs
+}
+
+// MarshalCBOR encodes the GoStruct s as CBOR following the encoding rules of
+// RFC9254, using the supplied schema, which must be the schema of s. Where
+// schema is the root of the schema tree, the encoded CBOR map has a member for
+// each populated top-level data node, otherwise it has a member for each
+// populated child of s.
+//
+// Member names are qualified with the name of their module where it differs
+// from that of their parent, as per RFC7951. Where cfg specifies SIDs, the
+// members of the root are identified by their absolute SID. Integer values are
+// encoded as CBOR integers, decimal64 values as decimal fractions, enumeration
+// values as their integer value, bits values as byte strings, and empty values
+// as null. The integer values of enumerations are taken from the generated
+// enumerated types of s where it is a ValidatedGoStruct, since the schema
+// within generated code does not retain them. Where a union member type cannot be determined from the CBOR data
+// type alone, the value is tagged as specified by RFC9254. Instance-identifier
+// values are always encoded as text strings.
+func MarshalCBOR(s GoStruct, schema *yang.Entry, cfg *CBORConfig) ([]byte, error) {
+	if util.IsValueNil(s) {
+		return nil, errors.New("cannot marshal nil GoStruct to CBOR")
+	}
+	if schema == nil {
+		return nil, fmt.Errorf("nil schema supplied for %T", s)
+	}
+	if cfg == nil {
+		cfg = &CBORConfig{}
+	}
+
+	j, err := ConstructIETFJSON(s, &RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		return nil, fmt.Errorf("cannot construct JSON for %T, %v", s, err)
+	}
+
+	e := &cborEncoder{sids: cfg.SIDs}
+	if vs, ok := s.(ValidatedGoStruct); ok {
+		e.enumTypes = vs.ΛEnumTypeMap()
+	}
+	var path string
+	var sid uint64
+	if schema.Parent != nil && !util.IsFakeRoot(schema) && e.sids != nil {
+		p, ok := e.sids.DataPathForNames(util.SchemaPathNoChoiceCase(schema)[1:])
+		if !ok {
+			return nil, fmt.Errorf("cannot find SID for %s", util.SchemaTreePath(schema))
+		}
+		path, sid = p, e.sids.data[p]
+	}
+
+	var buf bytes.Buffer
+	if err := e.container(&buf, j, schema, path, sid); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// cborHead writes the initial byte, and any following argument bytes, of a
+// CBOR data item with the major type major and argument n to b.
+func cborHead(b *bytes.Buffer, major byte, n uint64) {
+	switch {
+	case n < 24:
+		b.WriteByte(major<<5 | byte(n))
+	case n <= math.MaxUint8:
+		b.WriteByte(major<<5 | 24)
+		b.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		b.WriteByte(major<<5 | 25)
+		b.Write([]byte{byte(n >> 8), byte(n)})
+	case n <= math.MaxUint32:
+		b.WriteByte(major<<5 | 26)
+		b.Write([]byte{byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)})
+	default:
+		b.WriteByte(major<<5 | 27)
+		for i := 56; i >= 0; i -= 8 {
+			b.WriteByte(byte(n >> uint(i)))
+		}
+	}
+}
+
+// cborInt writes the signed integer i to b.
+func cborInt(b *bytes.Buffer, i int64) {
+	if i < 0 {
+		cborHead(b, cborNegative, uint64(-1-i))
+		return
+	}
+	cborHead(b, cborUnsigned, uint64(i))
+}
+
+// cborString writes the text string s to b.
+func cborString(b *bytes.Buffer, s string) {
+	cborHead(b, cborText, uint64(len(s)))
+	b.WriteString(s)
+}
+
+// cborByteString writes the byte string s to b.
+func cborByteString(b *bytes.Buffer, s []byte) {
+	cborHead(b, cborBytes, uint64(len(s)))
+	b.Write(s)
+}
+
+// cborEncoder stores the state used when encoding a data tree as CBOR.
+type cborEncoder struct {
+	// sids is the set of SIDs used to encode the data tree, or nil if names
+	// are used.
+	sids *SIDs
+	// enumTypes is the map, keyed by schema path, of the generated
+	// enumerated types of the leaves of the data tree, as returned by the
+	// ΛEnumTypeMap method of the GoStruct being encoded.
+	enumTypes map[string][]reflect.Type
+}
+
+// container writes the CBOR map corresponding to the RFC7951 JSON object j,
+// whose members are children of the data node with the supplied schema,
+// schema node path and SID. Members are written in the bytewise order of
+// their encoded keys, such that the encoding is deterministic as per RFC8949
+// Section 4.2.1.
+func (e *cborEncoder) container(b *bytes.Buffer, j map[string]interface{}, schema *yang.Entry, path string, sid uint64) error {
+	type member struct {
+		key, value []byte
+	}
+	var members []member
+	var errs util.Errors
+	for k, v := range j {
+		_, n := splitQualifiedName(k, "")
+		cs := util.FirstChild(schema, []string{n})
+		if cs == nil {
+			errs = util.AppendErr(errs, fmt.Errorf("cannot find schema for %s within %s", k, schema.Name))
+			continue
+		}
+
+		cpath := childSchemaNodePath(path, k)
+		var kb, vb bytes.Buffer
+		var csid uint64
+		if e.sids != nil {
+			var ok bool
+			if csid, ok = e.sids.DataSID(cpath); !ok {
+				errs = util.AppendErr(errs, fmt.Errorf("cannot find SID for %s", cpath))
+				continue
+			}
+			// RFC9254 Section 3.2 specifies that the key of a member is the
+			// difference between its SID and that of its parent.
+			cborInt(&kb, int64(csid-sid))
+		} else {
+			cborString(&kb, k)
+		}
+		if err := e.node(&vb, v, cs, cpath, csid); err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		members = append(members, member{key: kb.Bytes(), value: vb.Bytes()})
+	}
+	if errs != nil {
+		return errs
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return bytes.Compare(members[i].key, members[j].key) < 0
+	})
+	cborHead(b, cborMap, uint64(len(members)))
+	for _, m := range members {
+		b.Write(m.key)
+		b.Write(m.value)
+	}
+	return nil
+}
+
+// childSchemaNodePath returns the schema node path of the child with the
+// RFC7951 JSON member name k of the data node with the schema node path path.
+// The module of the child is omitted from the returned path where it is the
+// same as that of the data node, as is the case for the top-level members of
+// the JSON constructed for a GoStruct that is not the root.
+func childSchemaNodePath(path, k string) string {
+	mod, n := splitQualifiedName(k, "")
+	if mod != "" && mod == SchemaNodePathModule(path) {
+		k = n
+	}
+	return fmt.Sprintf("%s/%s", path, k)
+}
+
+// SchemaNodePathModule returns the name of the module of the data node with
+// the schema node path path, as used to identify data nodes within a .sid
+// file. The module is that of the last element of the path that is qualified
+// with a module name, or the empty string if there is no such element.
+func SchemaNodePathModule(path string) string {
+	elems := strings.Split(path, "/")
+	for i := len(elems) - 1; i >= 0; i-- {
+		if mod, _ := splitQualifiedName(elems[i], ""); mod != "" {
+			return mod
+		}
+	}
+	return ""
+}
+
+// node writes the CBOR encoding of the data node with the supplied schema,
+// schema node path, SID, and RFC7951 JSON value v to b.
+func (e *cborEncoder) node(b *bytes.Buffer, v interface{}, schema *yang.Entry, path string, sid uint64) error {
+	switch {
+	case util.IsAnydata(schema):
+		return cborAnydata(b, v)
+	case schema.IsContainer():
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid value %v for %s, got: %T, want: map[string]interface{}", v, schema.Name, v)
+		}
+		return e.container(b, m, schema, path, sid)
+	case schema.IsList(), schema.IsLeafList():
+		vs, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("invalid value %v for %s, got: %T, want: []interface{}", v, schema.Name, v)
+		}
+		cborHead(b, cborArray, uint64(len(vs)))
+		for _, lv := range vs {
+			var err error
+			if schema.IsList() {
+				m, ok := lv.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("invalid list entry %v for %s, got: %T, want: map[string]interface{}", lv, schema.Name, lv)
+				}
+				err = e.container(b, m, schema, path, sid)
+			} else {
+				err = e.leaf(b, lv, schema)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return e.leaf(b, v, schema)
+}
+
+// cborAnydata writes the CBOR encoding of the RFC7951 JSON value v, which is
+// the contents of an anydata or anyxml node, to b. Since the contents are not
+// described by the schema, objects are written as maps keyed by their member
+// names, and integral numbers are written as integers.
+func cborAnydata(b *bytes.Buffer, v interface{}) error {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		type member struct {
+			key, value []byte
+		}
+		var members []member
+		for k, mv := range vv {
+			var kb, vb bytes.Buffer
+			cborString(&kb, k)
+			if err := cborAnydata(&vb, mv); err != nil {
+				return err
+			}
+			members = append(members, member{key: kb.Bytes(), value: vb.Bytes()})
+		}
+		sort.Slice(members, func(i, j int) bool { return bytes.Compare(members[i].key, members[j].key) < 0 })
+		cborHead(b, cborMap, uint64(len(members)))
+		for _, m := range members {
+			b.Write(m.key)
+			b.Write(m.value)
+		}
+	case []interface{}:
+		cborHead(b, cborArray, uint64(len(vv)))
+		for _, lv := range vv {
+			if err := cborAnydata(b, lv); err != nil {
+				return err
+			}
+		}
+	case string:
+		cborString(b, vv)
+	case bool:
+		if vv {
+			b.WriteByte(cborSimple<<5 | 21)
+		} else {
+			b.WriteByte(cborSimple<<5 | 20)
+		}
+	case nil:
+		b.WriteByte(cborSimple<<5 | 22)
+	case float64:
+		if vv == math.Trunc(vv) && math.Abs(vv) < 1<<63 {
+			cborInt(b, int64(vv))
+			return nil
+		}
+		b.WriteByte(cborSimple<<5 | 27)
+		n := math.Float64bits(vv)
+		for i := 56; i >= 0; i -= 8 {
+			b.WriteByte(byte(n >> uint(i)))
+		}
+	default:
+		return fmt.Errorf("invalid anydata value %v (%T)", v, v)
+	}
+	return nil
+}
+
+// leaf writes the CBOR encoding of the RFC7951 JSON value v of the leaf, or
+// leaf-list entry, with the supplied schema to b. Where the leaf is a union,
+// the value is encoded as the first member type that it is valid for.
+func (e *cborEncoder) leaf(b *bytes.Buffer, v interface{}, schema *yang.Entry) error {
+	if schema.Type == nil {
+		return fmt.Errorf("leaf %s has nil type", schema.Name)
+	}
+	ets := e.enumTypes[util.SchemaTreePathNoModule(schema)]
+	if rs, err := util.ResolveIfLeafRef(schema); err == nil && rs != nil {
+		schema = rs
+		if len(ets) == 0 {
+			ets = e.enumTypes[util.SchemaTreePathNoModule(schema)]
+		}
+	}
+	enumValues := goEnumValues(ets)
+
+	if schema.Type.Kind != yang.Yunion {
+		if err := e.scalar(b, v, schema.Type, enumValues, false); err != nil {
+			return fmt.Errorf("invalid value %v for leaf %s: %v", v, schema.Name, err)
+		}
+		return nil
+	}
+
+	var errs util.Errors
+	for _, t := range util.FlattenedTypes(schema.Type.Type) {
+		var vb bytes.Buffer
+		err := e.scalar(&vb, v, t, enumValues, true)
+		if err == nil {
+			b.Write(vb.Bytes())
+			return nil
+		}
+		errs = util.AppendErr(errs, err)
+	}
+	return fmt.Errorf("invalid value %v for union leaf %s: %v", v, schema.Name, errs)
+}
+
+// scalar writes the CBOR encoding of the RFC7951 JSON value v, of the YANG
+// type t, to b. The values of enumerations are taken from enumValues, which
+// are those of the generated enumerated types of the leaf, or otherwise from
+// t. inUnion specifies whether t is a member of a union, in which case values
+// whose type cannot be determined from their encoding are tagged.
+func (e *cborEncoder) scalar(b *bytes.Buffer, v interface{}, t *yang.YangType, enumValues map[string]int64, inUnion bool) error {
+	switch t.Kind {
+	case yang.Yempty:
+		if vs, ok := v.([]interface{}); !ok || len(vs) != 1 || vs[0] != nil {
+			return fmt.Errorf("got %v (%T), want [null] for empty", v, v)
+		}
+		b.WriteByte(cborSimple<<5 | 22)
+	case yang.Ybool:
+		bv, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("got %v (%T), want bool", v, v)
+		}
+		if bv {
+			b.WriteByte(cborSimple<<5 | 21)
+		} else {
+			b.WriteByte(cborSimple<<5 | 20)
+		}
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
+		i, err := jsonInt(v, t.Kind)
+		if err != nil {
+			return err
+		}
+		cborInt(b, i)
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		u, err := jsonUint(v, t.Kind)
+		if err != nil {
+			return err
+		}
+		cborHead(b, cborUnsigned, u)
+	case yang.Ydecimal64:
+		exp, mant, err := decimalFraction(v, t.FractionDigits)
+		if err != nil {
+			return err
+		}
+		cborHead(b, cborTag, CBORTagDecimalFraction)
+		cborHead(b, cborArray, 2)
+		cborInt(b, exp)
+		cborInt(b, mant)
+	case yang.Ystring, yang.YinstanceIdentifier:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("got %v (%T), want string", v, v)
+		}
+		cborString(b, s)
+	case yang.Ybinary:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("got %v (%T), want base64 encoded string", v, v)
+		}
+		bs, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return err
+		}
+		cborByteString(b, bs)
+	case yang.Yenum:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("got %v (%T), want enumeration name", v, v)
+		}
+		_, n := splitQualifiedName(s, "")
+		ev, ok := enumValues[n]
+		if !ok {
+			if t.Enum == nil || !t.Enum.IsDefined(n) {
+				return fmt.Errorf("%s is not a valid enumeration value", s)
+			}
+			ev = t.Enum.Value(n)
+		}
+		if inUnion {
+			cborHead(b, cborTag, CBORTagEnumeration)
+		}
+		cborInt(b, ev)
+	case yang.Ybits:
+		s, ok := v.(string)
+		if !ok || t.Bit == nil {
+			return fmt.Errorf("got %v (%T), want bits names", v, v)
+		}
+		bs, err := bitsBytes(s, t.Bit)
+		if err != nil {
+			return err
+		}
+		if inUnion {
+			cborHead(b, cborTag, CBORTagBits)
+		}
+		cborByteString(b, bs)
+	case yang.Yidentityref:
+		s, ok := v.(string)
+		if !ok || !strings.Contains(s, ":") {
+			return fmt.Errorf("got %v (%T), want module-qualified identity", v, v)
+		}
+		if e.sids == nil {
+			cborString(b, s)
+			return nil
+		}
+		sid, ok := e.sids.IdentitySID(s)
+		if !ok {
+			return fmt.Errorf("cannot find SID for identity %s", s)
+		}
+		if inUnion {
+			cborHead(b, cborTag, CBORTagIdentityref)
+		}
+		cborHead(b, cborUnsigned, sid)
+	default:
+		return fmt.Errorf("unsupported type %v", t.Kind)
+	}
+	return nil
+}
+
+// jsonInt returns the integer value of the RFC7951 JSON value v of a signed
+// integer type of kind k, which is a number for types of up to 32 bits, and a
+// string otherwise.
+func jsonInt(v interface{}, k yang.TypeKind) (int64, error) {
+	bits := map[yang.TypeKind]int{yang.Yint8: 8, yang.Yint16: 16, yang.Yint32: 32, yang.Yint64: 64}[k]
+	if s, ok := v.(string); ok && bits == 64 {
+		return strconv.ParseInt(s, 10, bits)
+	}
+	f, err := jsonNumber(v)
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) || f < -math.Pow(2, float64(bits-1)) || f >= math.Pow(2, float64(bits-1)) {
+		return 0, fmt.Errorf("%v is not a valid %v value", v, k)
+	}
+	return int64(f), nil
+}
+
+// jsonUint returns the integer value of the RFC7951 JSON value v of an
+// unsigned integer type of kind k, which is a number for types of up to 32
+// bits, and a string otherwise.
+func jsonUint(v interface{}, k yang.TypeKind) (uint64, error) {
+	bits := map[yang.TypeKind]int{yang.Yuint8: 8, yang.Yuint16: 16, yang.Yuint32: 32, yang.Yuint64: 64}[k]
+	if s, ok := v.(string); ok && bits == 64 {
+		return strconv.ParseUint(s, 10, bits)
+	}
+	f, err := jsonNumber(v)
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) || f < 0 || f >= math.Pow(2, float64(bits)) {
+		return 0, fmt.Errorf("%v is not a valid %v value", v, k)
+	}
+	return uint64(f), nil
+}
+
+// jsonNumber returns the numeric JSON value v as a float64. v may be any Go
+// numeric type, since the values within the JSON tree constructed from a
+// GoStruct retain the type of the field they were rendered from.
+func jsonNumber(v interface{}) (float64, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	}
+	return 0, fmt.Errorf("got %v (%T), want number", v, v)
+}
+
+// decimalFraction returns the exponent and mantissa of the decimal fraction
+// that represents the RFC7951 JSON value v of a decimal64 type with the
+// supplied number of fraction digits. Where fractionDigits is zero, such as
+// is the case for schemas that do not record it, the smallest number of
+// fraction digits that represents v exactly is used.
+func decimalFraction(v interface{}, fractionDigits int) (int64, int64, error) {
+	var r *big.Rat
+	switch vv := v.(type) {
+	case string:
+		var ok bool
+		if r, ok = new(big.Rat).SetString(vv); !ok {
+			return 0, 0, fmt.Errorf("%s is not a valid decimal64 value", vv)
+		}
+	case float64:
+		r = new(big.Rat).SetFloat64(vv)
+	default:
+		return 0, 0, fmt.Errorf("got %v (%T), want string", v, v)
+	}
+
+	ten := big.NewRat(10, 1)
+	m := new(big.Rat).Set(r)
+	fd := 0
+	for ; fd < fractionDigits || (fractionDigits == 0 && !m.IsInt() && fd < 18); fd++ {
+		m.Mul(m, ten)
+	}
+	if !m.IsInt() || !m.Num().IsInt64() {
+		return 0, 0, fmt.Errorf("%v cannot be represented as a decimal64 value with %d fraction digits", v, fd)
+	}
+	return int64(-fd), m.Num().Int64(), nil
+}
+
+// goEnumValues returns the YANG values of the enumeration values defined by
+// the generated enumerated types ets, keyed by name. The values of the
+// identities within ets are not included. Since the generated types reserve
+// zero for the unset value, the YANG value of each enumeration value is one
+// less than its value within the generated code.
+func goEnumValues(ets []reflect.Type) map[string]int64 {
+	vals := map[string]int64{}
+	for _, et := range ets {
+		ge, ok := reflect.Zero(et).Interface().(GoEnum)
+		if !ok {
+			continue
+		}
+		for v, d := range ge.ΛMap()[et.Name()] {
+			if d.DefiningModule == "" {
+				vals[d.Name] = v - 1
+			}
+		}
+	}
+	return vals
+}
+
+// bitsBytes returns the byte string encoding of the space-separated bits
+// names s, as specified in RFC9254 Section 6.7, where the position of each
+// bit is stored in bits.
+func bitsBytes(s string, bits *yang.EnumType) ([]byte, error) {
+	var out []byte
+	for _, n := range strings.Fields(s) {
+		if !bits.IsDefined(n) {
+			return nil, fmt.Errorf("%s is not a valid bit", n)
+		}
+		pos := bits.Value(n)
+		for int64(len(out)) <= pos/8 {
+			out = append(out, 0)
+		}
+		out[pos/8] |= 1 << uint(pos%8)
+	}
+	return out, nil
+}
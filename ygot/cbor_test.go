@@ -0,0 +1,391 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// mustHex returns the bytes encoded by the hexadecimal string s, which may
+// contain whitespace for readability.
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		t.Fatalf("invalid hex string %s, %v", s, err)
+	}
+	return b
+}
+
+// cborTestSIDs returns the SIDs of the data nodes of the schema returned by
+// xmlSchema.
+func cborTestSIDs(t *testing.T) *SIDs {
+	s := NewSIDs()
+	for p, sid := range map[string]uint64{
+		"/m1:interfaces":                          1000,
+		"/m1:interfaces/interface":                1001,
+		"/m1:interfaces/interface/name":           1002,
+		"/m1:interfaces/interface/mtu":            1003,
+		"/m1:interfaces/interface/type":           1004,
+		"/m1:interfaces/interface/address":        1005,
+		"/m1:interfaces/interface/m2:description": 1006,
+		"/m2:enabled":                             2000,
+	} {
+		if err := s.AddDataSID(p, sid); err != nil {
+			t.Fatalf("cannot add SID for %s, %v", p, err)
+		}
+	}
+	if err := s.AddIdentitySID("bar:VAL_TWO", 3000); err != nil {
+		t.Fatalf("cannot add SID for identity, %v", err)
+	}
+	return s
+}
+
+func TestMarshalCBOR(t *testing.T) {
+	schema := xmlSchema()
+	sids := cborTestSIDs(t)
+	intf := func() *xmlInterface {
+		return &xmlInterface{Name: String("eth0"), Mtu: Uint16(1500), Type: EnumTestVALTWO}
+	}
+
+	tests := []struct {
+		name             string
+		inStruct         GoStruct
+		inSchema         *yang.Entry
+		inConfig         *CBORConfig
+		want             string
+		wantErrSubstring string
+	}{{
+		name:     "names",
+		inStruct: &xmlRoot{Interface: map[string]*xmlInterface{"eth0": intf()}, Enabled: true},
+		inSchema: schema,
+		want: `a2` +
+			`6a 6d323a656e61626c6564 f6` + // "m2:enabled": null
+			`6d 6d313a696e7465726661636573` + // "m1:interfaces"
+			`a1 69 696e74657266616365 81 a3` + // {"interface": [{
+			`63 6d7475 19 05dc` + // "mtu": 1500
+			`64 6e616d65 64 65746830` + // "name": "eth0"
+			`64 74797065 6b 6261723a56414c5f54574f`, // "type": "bar:VAL_TWO"
+	}, {
+		name:     "SIDs",
+		inStruct: &xmlRoot{Interface: map[string]*xmlInterface{"eth0": intf()}, Enabled: true},
+		inSchema: schema,
+		inConfig: &CBORConfig{SIDs: sids},
+		want: `a2` +
+			`19 03e8 a1 01 81 a3` + // 1000: {+1: [{
+			`01 64 65746830` + // +1: "eth0"
+			`02 19 05dc` + // +2: 1500
+			`03 19 0bb8` + // +3: 3000
+			`19 07d0 f6`, // 2000: null
+	}, {
+		name:     "list entry with SIDs",
+		inStruct: &xmlInterface{Name: String("eth0"), Description: String("uplink")},
+		inSchema: schema.Dir["interfaces"].Dir["interface"],
+		inConfig: &CBORConfig{SIDs: sids},
+		want:     `a2 01 64 65746830 05 66 75706c696e6b`,
+	}, {
+		name:     "leaf-list",
+		inStruct: &xmlInterface{Address: []string{"a", "b"}},
+		inSchema: schema.Dir["interfaces"].Dir["interface"],
+		want:     `a1 6a 6d313a61646472657373 82 61 61 61 62`,
+	}, {
+		name:             "missing SID",
+		inStruct:         &xmlInterface{Name: String("eth0")},
+		inSchema:         schema.Dir["interfaces"].Dir["interface"],
+		inConfig:         &CBORConfig{SIDs: NewSIDs()},
+		wantErrSubstring: "cannot find SID for /device/interfaces/interface",
+	}, {
+		name:             "missing identity SID",
+		inStruct:         &xmlRoot{Interface: map[string]*xmlInterface{"eth0": {Name: String("eth0"), Type: EnumTestVALONE}}},
+		inSchema:         schema,
+		inConfig:         &CBORConfig{SIDs: sids},
+		wantErrSubstring: "cannot find SID for identity foo:VAL_ONE",
+	}, {
+		name:             "nil struct",
+		inStruct:         (*xmlRoot)(nil),
+		inSchema:         schema,
+		wantErrSubstring: "cannot marshal nil GoStruct",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalCBOR(tt.inStruct, tt.inSchema, tt.inConfig)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MarshalCBOR: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if want := mustHex(t, tt.want); !bytes.Equal(got, want) {
+				t.Errorf("MarshalCBOR: did not get expected CBOR, got: %x, want: %x", got, want)
+			}
+		})
+	}
+}
+
+// cborEnumState is an enumerated type, as generated for an enumeration
+// whose values are "up", with value 1, and "down", with value 5. Its values
+// are one greater than those of the enumeration, since zero is reserved for
+// the unset value.
+type cborEnumState int64
+
+func (cborEnumState) IsYANGGoEnum() {}
+
+func (cborEnumState) ΛMap() map[string]map[int64]EnumDefinition {
+	return map[string]map[int64]EnumDefinition{
+		"cborEnumState": {
+			2: {Name: "up"},
+			6: {Name: "down"},
+		},
+	}
+}
+
+func (e cborEnumState) String() string {
+	return EnumLogString(e, int64(e), "cborEnumState")
+}
+
+type cborEnumRoot struct {
+	State  cborEnumState   `path:"state" module:"m1"`
+	States []cborEnumState `path:"states" module:"m1"`
+}
+
+func (*cborEnumRoot) IsYANGGoStruct()                    {}
+func (*cborEnumRoot) Validate(...ValidationOption) error { return nil }
+func (*cborEnumRoot) ΛEnumTypeMap() map[string][]reflect.Type {
+	return map[string][]reflect.Type{
+		"/state":  {reflect.TypeOf(cborEnumState(0))},
+		"/states": {reflect.TypeOf(cborEnumState(0))},
+	}
+}
+
+// cborEnumSchema returns the schema of cborEnumRoot, after it has been
+// serialised to JSON and back as within generated code, such that the values
+// of its enumerations are not retained.
+func cborEnumSchema(t *testing.T) *yang.Entry {
+	enum := yang.NewEnumType()
+	enum.Set("up", 1)
+	enum.Set("down", 5)
+	root := &yang.Entry{
+		Name:       "device",
+		Kind:       yang.DirectoryEntry,
+		Annotation: map[string]interface{}{"isFakeRoot": true},
+		Dir: map[string]*yang.Entry{
+			"state": {Name: "state", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Yenum, Enum: enum}},
+			"states": {
+				Name:     "states",
+				Kind:     yang.LeafEntry,
+				ListAttr: &yang.ListAttr{},
+				Type:     &yang.YangType{Kind: yang.Yenum, Enum: enum},
+			},
+		},
+	}
+	js, err := json.Marshal(root)
+	if err != nil {
+		t.Fatalf("cannot marshal schema, %v", err)
+	}
+	schema := &yang.Entry{}
+	if err := json.Unmarshal(js, schema); err != nil {
+		t.Fatalf("cannot unmarshal schema, %v", err)
+	}
+	for _, e := range schema.Dir {
+		e.Parent = schema
+	}
+	return schema
+}
+
+func TestMarshalCBORGoEnum(t *testing.T) {
+	schema := cborEnumSchema(t)
+	if schema.Dir["state"].Type.Enum.IsDefined("up") {
+		t.Fatalf("schema unexpectedly retains enumeration values")
+	}
+
+	got, err := MarshalCBOR(&cborEnumRoot{State: 6, States: []cborEnumState{2, 6}}, schema, nil)
+	if err != nil {
+		t.Fatalf("MarshalCBOR: got unexpected error, %v", err)
+	}
+	want := mustHex(t, `a2`+
+		`68 6d313a7374617465 05`+ // "m1:state": 5
+		`69 6d313a737461746573 82 01 05`) // "m1:states": [1, 5]
+	if !bytes.Equal(got, want) {
+		t.Errorf("MarshalCBOR: did not get expected CBOR, got: %x, want: %x", got, want)
+	}
+}
+
+func TestCBORScalar(t *testing.T) {
+	enum := yang.NewEnumType()
+	enum.Set("UP", 1)
+	enum.Set("DOWN", -2)
+	bits := yang.NewEnumType()
+	bits.Set("a", 0)
+	bits.Set("b", 9)
+
+	sids := NewSIDs()
+	if err := sids.AddIdentitySID("m1:ETHERNET", 42); err != nil {
+		t.Fatalf("cannot add identity SID, %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		inValue          interface{}
+		inType           *yang.YangType
+		inUnion          bool
+		inSIDs           *SIDs
+		want             string
+		wantErrSubstring string
+	}{{
+		name:    "empty",
+		inValue: []interface{}{nil},
+		inType:  &yang.YangType{Kind: yang.Yempty},
+		want:    "f6",
+	}, {
+		name:    "bool",
+		inValue: false,
+		inType:  &yang.YangType{Kind: yang.Ybool},
+		want:    "f4",
+	}, {
+		name:    "negative int8",
+		inValue: float64(-100),
+		inType:  &yang.YangType{Kind: yang.Yint8},
+		want:    "38 63",
+	}, {
+		name:    "int64 string",
+		inValue: "-4294967296",
+		inType:  &yang.YangType{Kind: yang.Yint64},
+		want:    "3a ffffffff",
+	}, {
+		name:    "uint64 string",
+		inValue: "18446744073709551615",
+		inType:  &yang.YangType{Kind: yang.Yuint64},
+		want:    "1b ffffffffffffffff",
+	}, {
+		name:             "out of range uint8",
+		inValue:          float64(256),
+		inType:           &yang.YangType{Kind: yang.Yuint8},
+		wantErrSubstring: "256 is not a valid uint8 value",
+	}, {
+		name:    "decimal64 with fraction digits",
+		inValue: "3.14",
+		inType:  &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 2},
+		want:    "c4 82 21 19 013a",
+	}, {
+		name:    "decimal64 without fraction digits",
+		inValue: "-2.5",
+		inType:  &yang.YangType{Kind: yang.Ydecimal64},
+		want:    "c4 82 20 38 18",
+	}, {
+		name:             "decimal64 with too many fraction digits",
+		inValue:          "3.141",
+		inType:           &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 2},
+		wantErrSubstring: "cannot be represented as a decimal64 value with 2 fraction digits",
+	}, {
+		name:    "binary",
+		inValue: "AQI=",
+		inType:  &yang.YangType{Kind: yang.Ybinary},
+		want:    "42 0102",
+	}, {
+		name:    "enumeration",
+		inValue: "DOWN",
+		inType:  &yang.YangType{Kind: yang.Yenum, Enum: enum},
+		want:    "21",
+	}, {
+		name:    "enumeration in union",
+		inValue: "UP",
+		inType:  &yang.YangType{Kind: yang.Yenum, Enum: enum},
+		inUnion: true,
+		want:    "d8 2c 01",
+	}, {
+		name:             "invalid enumeration",
+		inValue:          "SIDEWAYS",
+		inType:           &yang.YangType{Kind: yang.Yenum, Enum: enum},
+		wantErrSubstring: "SIDEWAYS is not a valid enumeration value",
+	}, {
+		name:    "bits",
+		inValue: "a b",
+		inType:  &yang.YangType{Kind: yang.Ybits, Bit: bits},
+		want:    "42 0102",
+	}, {
+		name:    "bits in union",
+		inValue: "b",
+		inType:  &yang.YangType{Kind: yang.Ybits, Bit: bits},
+		inUnion: true,
+		want:    "d8 2b 42 0002",
+	}, {
+		name:    "identityref name",
+		inValue: "m1:ETHERNET",
+		inType:  &yang.YangType{Kind: yang.Yidentityref},
+		want:    "6b 6d313a4554484552 4e4554",
+	}, {
+		name:    "identityref SID in union",
+		inValue: "m1:ETHERNET",
+		inType:  &yang.YangType{Kind: yang.Yidentityref},
+		inUnion: true,
+		inSIDs:  sids,
+		want:    "d8 2d 18 2a",
+	}, {
+		name:             "identityref without module",
+		inValue:          "ETHERNET",
+		inType:           &yang.YangType{Kind: yang.Yidentityref},
+		wantErrSubstring: "want module-qualified identity",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := (&cborEncoder{sids: tt.inSIDs}).scalar(&b, tt.inValue, tt.inType, nil, tt.inUnion)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("scalar: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if want := mustHex(t, tt.want); !bytes.Equal(b.Bytes(), want) {
+				t.Errorf("scalar: did not get expected CBOR, got: %x, want: %x", b.Bytes(), want)
+			}
+		})
+	}
+}
+
+func TestCBORUnionLeaf(t *testing.T) {
+	schema := &yang.Entry{
+		Name: "union",
+		Kind: yang.LeafEntry,
+		Type: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{{Kind: yang.Yuint8}, {Kind: yang.Ystring}},
+		},
+	}
+	for _, tt := range []struct {
+		in   interface{}
+		want string
+	}{
+		{in: float64(42), want: "18 2a"},
+		{in: "42", want: "62 3432"},
+	} {
+		var b bytes.Buffer
+		if err := (&cborEncoder{}).leaf(&b, tt.in, schema); err != nil {
+			t.Fatalf("leaf(%v): got unexpected error, %v", tt.in, err)
+		}
+		if want := mustHex(t, tt.want); !bytes.Equal(b.Bytes(), want) {
+			t.Errorf("leaf(%v): did not get expected CBOR, got: %x, want: %x", tt.in, b.Bytes(), want)
+		}
+	}
+}
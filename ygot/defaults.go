@@ -0,0 +1,122 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+)
+
+// DefaultsGoStruct is an interface which can be implemented by Go structs
+// that are generated to represent a YANG container or list member, such that
+// the default values of the schema can be populated within, and removed from,
+// the struct.
+type DefaultsGoStruct interface {
+	// GoStruct ensures that the interface for a standard GoStruct
+	// is embedded.
+	GoStruct
+	// ΛPopulateDefaults sets each unset leaf of the struct, and of its
+	// descendants, that has an applicable default value in the schema to
+	// that default.
+	ΛPopulateDefaults() error
+	// ΛPruneDefaults removes each leaf of the struct, and of its
+	// descendants, whose value is equal to its default value in the schema.
+	ΛPruneDefaults() error
+}
+
+// PopulateDefaults sets each unset leaf of the GoStruct s, and of its
+// descendants, to its default value in the YANG schema. Defaults are only
+// populated where they apply, such that the when statements of a leaf, and
+// the case of any choice that it is within, are respected. Since when
+// statements may refer to any part of the data tree, s should be the root of
+// the data tree. s must have been generated by ygen with validation and the
+// GenerateDefaultsMethods option enabled.
+func PopulateDefaults(s GoStruct) error {
+	ds, ok := s.(DefaultsGoStruct)
+	if !ok {
+		return fmt.Errorf("%T does not support populating default values", s)
+	}
+	return ds.ΛPopulateDefaults()
+}
+
+// PruneDefaults removes each leaf of the GoStruct s, and of its descendants,
+// whose value is equal to its default value in the YANG schema. s must have
+// been generated by ygen with validation and the GenerateDefaultsMethods option
+// enabled.
+func PruneDefaults(s GoStruct) error {
+	ds, ok := s.(DefaultsGoStruct)
+	if !ok {
+		return fmt.Errorf("%T does not support pruning default values", s)
+	}
+	return ds.ΛPruneDefaults()
+}
+
+// WithDefaultsMode specifies how leaves that have default values in the
+// schema are rendered to RFC7951 JSON, following the with-defaults modes
+// defined by RFC6243 and used by RFC8527.
+type WithDefaultsMode int64
+
+const (
+	// WithDefaultsExplicit renders the leaves that are set within the
+	// GoStruct, regardless of whether they are equal to their default.
+	WithDefaultsExplicit WithDefaultsMode = iota
+	// WithDefaultsReportAll renders all leaves, such that each unset leaf
+	// that has an applicable default value is rendered with its default.
+	WithDefaultsReportAll
+	// WithDefaultsTrim omits each leaf whose value is equal to its default
+	// value.
+	WithDefaultsTrim
+)
+
+// String returns the name of the with-defaults mode m, as used by RFC6243.
+func (m WithDefaultsMode) String() string {
+	switch m {
+	case WithDefaultsExplicit:
+		return "explicit"
+	case WithDefaultsReportAll:
+		return "report-all"
+	case WithDefaultsTrim:
+		return "trim"
+	}
+	return fmt.Sprintf("WithDefaultsMode(%d)", int64(m))
+}
+
+// applyWithDefaults returns a copy of the GoStruct s within which the default
+// values are populated or pruned according to the with-defaults mode m. s is
+// returned unmodified for the explicit mode.
+func applyWithDefaults(s GoStruct, m WithDefaultsMode) (GoStruct, error) {
+	var f func(GoStruct) error
+	switch m {
+	case WithDefaultsExplicit:
+		return s, nil
+	case WithDefaultsReportAll:
+		f = PopulateDefaults
+	case WithDefaultsTrim:
+		f = PruneDefaults
+	default:
+		return nil, fmt.Errorf("invalid with-defaults mode %v", m)
+	}
+
+	if _, ok := s.(DefaultsGoStruct); !ok {
+		return nil, fmt.Errorf("cannot render %T with with-defaults mode %v: %T does not support default values", s, m, s)
+	}
+	c, err := DeepCopy(s)
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy %T for with-defaults mode %v: %v", s, m, err)
+	}
+	if err := f(c); err != nil {
+		return nil, fmt.Errorf("cannot apply with-defaults mode %v: %v", m, err)
+	}
+	return c, nil
+}
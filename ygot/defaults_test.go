@@ -0,0 +1,154 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+// defaultsExample is a GoStruct whose mtu leaf has the default value 1500.
+type defaultsExample struct {
+	Name *string `path:"name"`
+	Mtu  *uint16 `path:"mtu"`
+}
+
+func (*defaultsExample) IsYANGGoStruct() {}
+
+func (d *defaultsExample) ΛPopulateDefaults() error {
+	if d.Mtu == nil {
+		d.Mtu = Uint16(1500)
+	}
+	return nil
+}
+
+func (d *defaultsExample) ΛPruneDefaults() error {
+	if d.Mtu != nil && *d.Mtu == 1500 {
+		d.Mtu = nil
+	}
+	return nil
+}
+
+type noDefaultsExample struct {
+	Name *string `path:"name"`
+}
+
+func (*noDefaultsExample) IsYANGGoStruct() {}
+
+func TestPopulateAndPruneDefaults(t *testing.T) {
+	d := &defaultsExample{Name: String("eth0")}
+	if err := PopulateDefaults(d); err != nil {
+		t.Fatalf("PopulateDefaults: got unexpected error, %v", err)
+	}
+	if want := (&defaultsExample{Name: String("eth0"), Mtu: Uint16(1500)}); !cmp.Equal(d, want) {
+		t.Errorf("PopulateDefaults: did not get expected struct, got: %v, want: %v", d, want)
+	}
+	if err := PruneDefaults(d); err != nil {
+		t.Fatalf("PruneDefaults: got unexpected error, %v", err)
+	}
+	if want := (&defaultsExample{Name: String("eth0")}); !cmp.Equal(d, want) {
+		t.Errorf("PruneDefaults: did not get expected struct, got: %v, want: %v", d, want)
+	}
+
+	if err := PopulateDefaults(&noDefaultsExample{}); err == nil {
+		t.Errorf("PopulateDefaults with unsupported struct: did not get expected error")
+	}
+	if err := PruneDefaults(&noDefaultsExample{}); err == nil {
+		t.Errorf("PruneDefaults with unsupported struct: did not get expected error")
+	}
+}
+
+func TestWithDefaultsJSON(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               GoStruct
+		inMode           WithDefaultsMode
+		want             map[string]interface{}
+		wantMarshal      string
+		wantErrSubstring string
+	}{{
+		desc:        "explicit with unset default",
+		in:          &defaultsExample{Name: String("eth0")},
+		inMode:      WithDefaultsExplicit,
+		want:        map[string]interface{}{"name": "eth0"},
+		wantMarshal: `{"name":"eth0"}`,
+	}, {
+		desc:        "explicit with value equal to default",
+		in:          &defaultsExample{Name: String("eth0"), Mtu: Uint16(1500)},
+		inMode:      WithDefaultsExplicit,
+		want:        map[string]interface{}{"name": "eth0", "mtu": uint16(1500)},
+		wantMarshal: `{"mtu":1500,"name":"eth0"}`,
+	}, {
+		desc:        "report-all",
+		in:          &defaultsExample{Name: String("eth0")},
+		inMode:      WithDefaultsReportAll,
+		want:        map[string]interface{}{"name": "eth0", "mtu": uint16(1500)},
+		wantMarshal: `{"mtu":1500,"name":"eth0"}`,
+	}, {
+		desc:        "trim",
+		in:          &defaultsExample{Name: String("eth0"), Mtu: Uint16(1500)},
+		inMode:      WithDefaultsTrim,
+		want:        map[string]interface{}{"name": "eth0"},
+		wantMarshal: `{"name":"eth0"}`,
+	}, {
+		desc:        "trim with non-default value",
+		in:          &defaultsExample{Name: String("eth0"), Mtu: Uint16(9000)},
+		inMode:      WithDefaultsTrim,
+		want:        map[string]interface{}{"name": "eth0", "mtu": uint16(9000)},
+		wantMarshal: `{"mtu":9000,"name":"eth0"}`,
+	}, {
+		desc:             "struct without default support",
+		in:               &noDefaultsExample{Name: String("eth0")},
+		inMode:           WithDefaultsReportAll,
+		wantErrSubstring: "does not support default values",
+	}, {
+		desc:             "invalid mode",
+		in:               &defaultsExample{},
+		inMode:           WithDefaultsMode(42),
+		wantErrSubstring: "invalid with-defaults mode WithDefaultsMode(42)",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			orig, err := DeepCopy(tt.in)
+			if err != nil {
+				t.Fatalf("DeepCopy: got unexpected error, %v", err)
+			}
+			cfg := &RFC7951JSONConfig{WithDefaults: tt.inMode}
+
+			got, err := ConstructIETFJSON(tt.in, cfg)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ConstructIETFJSON: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ConstructIETFJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+			}
+
+			gotMarshal, err := Marshal7951(tt.in, cfg)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Marshal7951: did not get expected error, %s", diff)
+			}
+			if string(gotMarshal) != tt.wantMarshal {
+				t.Errorf("Marshal7951: did not get expected JSON, got: %s, want: %s", gotMarshal, tt.wantMarshal)
+			}
+
+			if diff := cmp.Diff(orig, tt.in); diff != "" {
+				t.Errorf("input struct was modified, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -199,22 +199,49 @@ func getPathSpec(ni *util.NodeInfo) (*pathSpec, error) {
 	return nil, fmt.Errorf("could not find path specification annotation")
 }
 
+// setLeaf describes a leaf or leaf-list that is set within a GoStruct.
+type setLeaf struct {
+	// path is the set of data tree paths of the leaf.
+	path *pathSpec
+	// val is the value that the leaf is set to.
+	val interface{}
+	// seq is the position of the leaf in the order in which the leaves of
+	// the GoStruct were walked, such that the leaves within the entries of
+	// an ordered map are in the order of the entries.
+	seq int
+}
+
 // findSetLeaves iteratively walks the fields of the supplied GoStruct, s, and
-// returns a map, keyed by the path of the leaves that are set, with a the value
-// that the leaf is set to. YANG lists (Go maps), and containers (Go structs) are
-// not included within the returned map, such that only leaf or leaf-list values
-// that are set are returned.
+// returns a map, keyed by the canonical string representation of the paths of
+// the leaves that are set, describing the path and the value that each leaf is
+// set to. YANG lists (Go maps), and containers (Go structs) are not included
+// within the returned map, such that only leaf or leaf-list values that are set
+// are returned. Since the key is determined solely by the paths of the leaf,
+// the maps returned for two GoStructs of the same type can be compared by key.
 //
 // The ForEachDataField helper of the util library is used to perform the iterative
 // walk of the struct - using the out argument to store the set of changed leaves.
 // A specific Annotation is used to store the absolute path of the entity during
 // the walk.
-func findSetLeaves(s GoStruct, opts ...DiffOpt) (map[*pathSpec]interface{}, error) {
+func findSetLeaves(s GoStruct, opts ...DiffOpt) (map[string]*setLeaf, error) {
+	leaves, _, err := findSetLeavesAndLists(s, opts...)
+	return leaves, err
+}
+
+// findSetLeavesAndLists returns the set leaves of the supplied GoStruct, s, as
+// per findSetLeaves, along with the ordered lists within s, keyed by the
+// canonical string representation of their paths.
+func findSetLeavesAndLists(s GoStruct, opts ...DiffOpt) (map[string]*setLeaf, map[string]*orderedList, error) {
 	pathOpt := hasDiffPathOpt(opts)
+	subtreeOpt := hasDiffSubtreeOpt(opts)
 	processedPaths := map[string]bool{}
+	lists := map[string]*orderedList{}
 
 	findSetIterFunc := func(ni *util.NodeInfo, in, out interface{}) (errs util.Errors) {
-		if cmp.Equal(ni.StructField, reflect.StructField{}) {
+		// The root of the GoStruct is the only node without a StructField,
+		// since every field has a name, this is checked without comparing the
+		// entire StructField.
+		if ni.StructField.Name == "" {
 			return
 		}
 
@@ -245,25 +272,36 @@ func findSetLeaves(s GoStruct, opts ...DiffOpt) (map[*pathSpec]interface{}, erro
 			return util.NewErrs(err)
 		}
 
-		//prevent duplicate key
-		keys := make([]string, len(vp.gNMIPaths))
-		for i, paths := range vp.gNMIPaths {
-			s, err := PathToString(paths)
-			if err != nil {
-				return util.NewErrs(err)
-			}
-			keys[i] = s
+		// Branches of the data tree that neither lead to, nor are within,
+		// the subtree are not walked.
+		if subtreeOpt != nil && !subtreeOpt.overlaps(vp) {
+			ni.SkipChildren = true
+			return
+		}
+
+		// Fields that map to more than one path are iterated once per path,
+		// hence skip those that have already been processed.
+		key, err := pathSpecKey(vp)
+		if err != nil {
+			return util.NewErrs(err)
 		}
-		sort.Strings(keys)
-		key := strings.Join(keys, "/")
 		if _, ok := processedPaths[key]; ok {
 			return
 		}
 		processedPaths[key] = true
 
 		ni.Annotation = []interface{}{vp}
+		if err := recordOrderedList(lists, ni, vp, key); err != nil {
+			return util.NewErrs(err)
+		}
+
+		// Anydata is a leaf of the data tree, although it is a struct ptr.
+		isContainer := util.IsValueStructPtr(ni.FieldValue) && !util.IsTypeAnydata(ni.FieldValue.Type())
+		if util.IsNilOrInvalidValue(ni.FieldValue) || isContainer || util.IsValueMap(ni.FieldValue) {
+			return
+		}
 
-		if util.IsNilOrInvalidValue(ni.FieldValue) || util.IsValueStructPtr(ni.FieldValue) || util.IsValueMap(ni.FieldValue) {
+		if subtreeOpt != nil && !subtreeOpt.contains(vp) {
 			return
 		}
 
@@ -283,18 +321,148 @@ func findSetLeaves(s GoStruct, opts ...DiffOpt) (map[*pathSpec]interface{}, erro
 			}
 		}
 
-		outs := out.(map[*pathSpec]interface{})
-		outs[vp] = ival
+		outs := out.(map[string]*setLeaf)
+		outs[key] = &setLeaf{path: vp, val: ival, seq: len(outs)}
 
 		return
 	}
 
-	out := map[*pathSpec]interface{}{}
+	out := map[string]*setLeaf{}
 	if errs := util.ForEachDataField(s, nil, out, findSetIterFunc); errs != nil {
-		return nil, fmt.Errorf("error from ForEachDataField iteration: %v", errs)
+		return nil, nil, fmt.Errorf("error from ForEachDataField iteration: %v", errs)
 	}
 
-	return out, nil
+	return out, lists, nil
+}
+
+// orderedList describes an ordered map within a GoStruct data tree, which
+// represents a YANG list that is "ordered-by user".
+type orderedList struct {
+	// path is the set of data tree paths of the list.
+	path *pathSpec
+	// entries are the keys of the entries of the list, in order.
+	entries []string
+	// val is the ordered map.
+	val GoOrderedMap
+}
+
+// recordOrderedList records the node described by ni, whose path is vp and
+// whose key is key, within the supplied ordered lists if it is an ordered map
+// or an entry of an ordered map. The ordered map must be recorded before its
+// entries.
+func recordOrderedList(lists map[string]*orderedList, ni *util.NodeInfo, vp *pathSpec, key string) error {
+	switch {
+	case util.IsValueOrderedMap(ni.FieldValue):
+		om, ok := ni.FieldValue.Interface().(GoOrderedMap)
+		if !ok {
+			return fmt.Errorf("%s: was not a valid GoOrderedMap", vp)
+		}
+		lists[key] = &orderedList{path: vp, val: om}
+	case ni.Parent != nil && util.IsValueOrderedMap(ni.Parent.FieldValue):
+		ps, err := getPathSpec(ni.Parent)
+		if err != nil {
+			return err
+		}
+		lk, err := pathSpecKey(ps)
+		if err != nil {
+			return err
+		}
+		if l, ok := lists[lk]; ok {
+			l.entries = append(l.entries, key)
+		}
+	}
+	return nil
+}
+
+// reorderedLists returns the ordered lists within modified whose entries
+// cannot be reached from those of the same list within original by deleting
+// and appending entries, such that the list must be rewritten to achieve the
+// order of modified. This is the case when the entries that are common to
+// both lists are in a different order, or when a new entry precedes one of
+// the common entries. Lists within the entries of another reordered list are
+// not returned, since they are rewritten with the containing list.
+func reorderedLists(original, modified map[string]*orderedList) []*orderedList {
+	var lists []*orderedList
+	for k, ml := range modified {
+		ol, ok := original[k]
+		if !ok || !isReordered(ol.entries, ml.entries) {
+			continue
+		}
+		lists = append(lists, ml)
+	}
+
+	var out []*orderedList
+	for _, l := range lists {
+		var nested bool
+		for _, o := range lists {
+			if o != l && o.path.containsPath(l.path) {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			out = append(out, l)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return pathLess(out[i].path.gNMIPaths[0], out[j].path.gNMIPaths[0]) })
+	return out
+}
+
+// isReordered determines whether the keys of the entries of a list, mod,
+// cannot be reached from the keys orig by deleting and appending entries.
+func isReordered(orig, mod []string) bool {
+	inOrig, inMod := map[string]bool{}, map[string]bool{}
+	for _, k := range orig {
+		inOrig[k] = true
+	}
+	for _, k := range mod {
+		inMod[k] = true
+	}
+
+	var common []string
+	for _, k := range orig {
+		if inMod[k] {
+			common = append(common, k)
+		}
+	}
+
+	var i int
+	var appended bool
+	for _, k := range mod {
+		if !inOrig[k] {
+			appended = true
+			continue
+		}
+		if appended || common[i] != k {
+			return true
+		}
+		i++
+	}
+	return false
+}
+
+// withinLists determines whether any of the paths of p are at, or below, the
+// path of one of the supplied lists.
+func withinLists(p *pathSpec, lists []*orderedList) bool {
+	for _, l := range lists {
+		if l.path.containsPath(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsPath determines whether any of the paths of o are at, or below, any
+// of the paths of p.
+func (p *pathSpec) containsPath(o *pathSpec) bool {
+	for _, pp := range p.gNMIPaths {
+		for _, op := range o.gNMIPaths {
+			if pathHasElemPrefix(op, pp) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // hasDiffPathOpt extracts a DiffPathOpt from the opts slice provided. In
@@ -369,6 +537,100 @@ type DiffPathOpt struct {
 // IsDiffOpt marks DiffPathOpt as a diff option.
 func (*DiffPathOpt) IsDiffOpt() {}
 
+// DiffSubtreeOpt is a DiffOpt that limits the Diff function to the subtree
+// of the supplied GoStructs at a particular path, such that only leaves at,
+// or below, Path are compared.
+type DiffSubtreeOpt struct {
+	// Path is the data tree path of the root of the subtree, relative to
+	// the supplied GoStructs. The keys of list elements within Path may be
+	// omitted, or set to "*", to match all entries of the list.
+	Path *gnmipb.Path
+}
+
+// IsDiffOpt marks DiffSubtreeOpt as a diff option.
+func (*DiffSubtreeOpt) IsDiffOpt() {}
+
+// contains determines whether any of the paths within p are at, or below,
+// the path of the DiffSubtreeOpt.
+func (o *DiffSubtreeOpt) contains(p *pathSpec) bool {
+	for _, path := range p.gNMIPaths {
+		if pathHasElemPrefix(path, o.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps determines whether any of the paths within p are on the route to,
+// at, or below, the path of the DiffSubtreeOpt. A key that is absent from
+// an element of p, as is the case for the path of a list rather than its
+// entries, matches any value of the key.
+func (o *DiffSubtreeOpt) overlaps(p *pathSpec) bool {
+	for _, path := range p.gNMIPaths {
+		if pathElemsOverlap(path, o.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathElemsOverlap determines whether the elements that path and prefix have
+// in common are equal, using the matching of pathHasElemPrefix, with the
+// exception that a key that is absent from path also matches any value.
+func pathElemsOverlap(path, prefix *gnmipb.Path) bool {
+	for i, e := range path.GetElem() {
+		if i == len(prefix.GetElem()) {
+			break
+		}
+		pe := prefix.GetElem()[i]
+		if pe.GetName() != "*" && e.GetName() != pe.GetName() {
+			return false
+		}
+		for k, v := range pe.GetKey() {
+			if ev, ok := e.GetKey()[k]; ok && v != "*" && ev != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// pathHasElemPrefix determines whether the elements of path begin with the
+// elements of prefix. An element name of "*" within prefix matches any
+// element, and a key that is absent from an element of prefix, or that has
+// the value "*", matches any value of the key within path.
+func pathHasElemPrefix(path, prefix *gnmipb.Path) bool {
+	if len(path.GetElem()) < len(prefix.GetElem()) {
+		return false
+	}
+	for i, pe := range prefix.GetElem() {
+		e := path.GetElem()[i]
+		if pe.GetName() != "*" && e.GetName() != pe.GetName() {
+			return false
+		}
+		for k, v := range pe.GetKey() {
+			if v == "*" {
+				continue
+			}
+			if ev, ok := e.GetKey()[k]; !ok || ev != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hasDiffSubtreeOpt extracts a DiffSubtreeOpt from the opts slice provided,
+// returning nil if none is present.
+func hasDiffSubtreeOpt(opts []DiffOpt) *DiffSubtreeOpt {
+	for _, o := range opts {
+		if v, ok := o.(*DiffSubtreeOpt); ok {
+			return v
+		}
+	}
+	return nil
+}
+
 // Diff takes an original and modified GoStruct, which must be of the same type
 // and returns a gNMI Notification that contains the diff between them. The original
 // struct is considered as the "from" data, with the modified struct the "to" such that:
@@ -396,51 +658,406 @@ func Diff(original, modified GoStruct, opts ...DiffOpt) (*gnmipb.Notification, e
 		return nil, fmt.Errorf("cannot diff structs of different types, original: %T, modified: %T", original, modified)
 	}
 
-	origLeaves, err := findSetLeaves(original, opts...)
+	origLeaves, origLists, err := findSetLeavesAndLists(original, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("could not extract set leaves from original struct: %v", err)
 	}
 
-	modLeaves, err := findSetLeaves(modified, opts...)
+	modLeaves, modLists, err := findSetLeavesAndLists(modified, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("could not extract set leaves from modified struct: %v", err)
 	}
 
-	matched := map[*pathSpec]bool{}
+	// Lists that are ordered-by user whose entries have been reordered are
+	// deleted, and all of their leaves are written again in order.
+	reordered := reorderedLists(origLists, modLists)
 	n := &gnmipb.Notification{}
-	for origPath, origVal := range origLeaves {
-		var origMatched bool
-		for modPath, modVal := range modLeaves {
-			if origPath.Equal(modPath) {
-				// This path is set in both of the structs, so check whether the value
-				// is equal.
-				matched[modPath] = true
-				origMatched = true
-				if !cmp.Equal(origVal, modVal) {
-					// The contents of the value should indicate that value a has changed
-					// to value b.
-					if err := appendUpdate(n, origPath, modVal); err != nil {
-						return nil, err
-					}
-				}
-			}
+	for _, l := range reordered {
+		n.Delete = append(n.Delete, l.path.gNMIPaths...)
+	}
+
+	// Since the leaves of each struct are keyed by their paths, each leaf of
+	// the original struct is matched to the corresponding leaf of the modified
+	// struct by a single lookup.
+	var updates []*setLeaf
+	for key, origLeaf := range origLeaves {
+		if withinLists(origLeaf.path, reordered) {
+			continue
 		}
-		if !origMatched {
+		modLeaf, ok := modLeaves[key]
+		if !ok {
 			// This leaf was set in the original struct, but not in the modified
 			// struct, therefore it has been deleted.
-			n.Delete = append(n.Delete, origPath.gNMIPaths...)
+			n.Delete = append(n.Delete, origLeaf.path.gNMIPaths...)
+			continue
+		}
+		// This path is set in both of the structs, so check whether the value
+		// is equal.
+		if !cmp.Equal(origLeaf.val, modLeaf.val) {
+			// The contents of the value should indicate that value a has changed
+			// to value b.
+			updates = append(updates, modLeaf)
+		}
+	}
+
+	// All paths that are in the modified struct but not in the original are
+	// updates, as are all paths within reordered lists.
+	for key, modLeaf := range modLeaves {
+		if _, ok := origLeaves[key]; !ok || withinLists(modLeaf.path, reordered) {
+			updates = append(updates, modLeaf)
+		}
+	}
+
+	// Updates are written in the order that the leaves were walked, such
+	// that the entries of ordered lists are created in order.
+	sort.Slice(updates, func(i, j int) bool { return updates[i].seq < updates[j].seq })
+	for _, u := range updates {
+		if err := appendUpdate(n, u.path, u.val); err != nil {
+			return nil, err
+		}
+	}
+
+	return n, nil
+}
+
+// DiffSetRequestOpt is a DiffOpt that controls how the differences between
+// two GoStructs are expressed within the SetRequest returned by
+// DiffToSetRequest.
+type DiffSetRequestOpt struct {
+	// ReplaceNewSubtrees specifies that a container or list entry that is
+	// populated in the modified struct, but not in the original, should be
+	// written by a single replace operation of the entire subtree, rather
+	// than by an update for each of its leaves.
+	ReplaceNewSubtrees bool
+	// JSONIETF specifies that values should be encoded as JSON_IETF
+	// subtrees. When set, the changed leaves that share a parent container
+	// or list entry are written by a single update of the parent, and
+	// replaced subtrees are encoded as JSON_IETF rather than internal JSON.
+	JSONIETF bool
+}
+
+// IsDiffOpt marks DiffSetRequestOpt as a diff option.
+func (*DiffSetRequestOpt) IsDiffOpt() {}
+
+// hasDiffSetRequestOpt extracts a DiffSetRequestOpt from the opts slice
+// provided, returning an empty DiffSetRequestOpt if none is present.
+func hasDiffSetRequestOpt(opts []DiffOpt) *DiffSetRequestOpt {
+	for _, o := range opts {
+		if v, ok := o.(*DiffSetRequestOpt); ok {
+			return v
+		}
+	}
+	return &DiffSetRequestOpt{}
+}
+
+// diffNode describes a populated node of a GoStruct data tree.
+type diffNode struct {
+	// path is the set of data tree paths of the node.
+	path *pathSpec
+	// parent is the key of the node's parent container or list entry, it is
+	// the empty string for the root of the data tree.
+	parent string
+	// leaf indicates whether the node is a leaf or leaf-list.
+	leaf bool
+	// val is the value of the leaf, or the GoStruct corresponding to the
+	// container or list entry.
+	val interface{}
+	// field is the struct field of a leaf within its parent.
+	field reflect.StructField
+}
+
+// pathSpecKey returns a string that uniquely identifies the paths of p.
+func pathSpecKey(p *pathSpec) (string, error) {
+	keys := make([]string, len(p.gNMIPaths))
+	for i, path := range p.gNMIPaths {
+		s, err := PathToString(path)
+		if err != nil {
+			return "", err
+		}
+		keys[i] = s
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, "/"), nil
+}
+
+// findSetNodes walks the supplied GoStruct, s, and returns a map of the
+// containers, list entries, leaves and leaf-lists within it, keyed by the
+// string representation of their path. The root of s is stored with an
+// empty key. Like findSetLeaves, it uses the pathSpec annotation to track
+// the path of each node during the walk. The ordered lists within s are
+// returned as per findSetLeavesAndLists.
+func findSetNodes(s GoStruct, opts ...DiffOpt) (map[string]*diffNode, map[string]*orderedList, error) {
+	pathOpt := hasDiffPathOpt(opts)
+	nodes := map[string]*diffNode{
+		"": {path: &pathSpec{gNMIPaths: []*gnmipb.Path{{}}}, val: s},
+	}
+	lists := map[string]*orderedList{}
+
+	// parentKey returns the key of the closest ancestor of ni that is a
+	// container or list entry, skipping the map that holds list entries.
+	parentKey := func(ni *util.NodeInfo) (string, error) {
+		p := ni.Parent
+		for p != nil && (util.IsValueMap(p.FieldValue) || util.IsValueOrderedMap(p.FieldValue)) {
+			p = p.Parent
+		}
+		if p == nil || p.Annotation == nil {
+			return "", nil
 		}
+		ps, err := getPathSpec(p)
+		if err != nil {
+			return "", err
+		}
+		return pathSpecKey(ps)
 	}
 
-	// Check that all paths that are in the modified struct have been examined, if
-	// not they are updates.
-	for modPath, modVal := range modLeaves {
-		if !matched[modPath] {
-			if err := appendUpdate(n, modPath, modVal); err != nil {
+	findSetIterFunc := func(ni *util.NodeInfo, in, out interface{}) (errs util.Errors) {
+		if ni.StructField.Name == "" || util.IsYgotAnnotation(ni.StructField) {
+			return
+		}
+
+		sp, err := util.SchemaPaths(ni.StructField)
+		if err != nil {
+			return util.NewErrs(err)
+		}
+		if len(sp) == 0 {
+			return util.NewErrs(fmt.Errorf("invalid schema path for %s", ni.StructField.Name))
+		}
+		if pathOpt != nil && pathOpt.MapToSinglePath {
+			sp = [][]string{leastSpecificPath(sp)}
+		}
+
+		vp, err := nodeValuePath(ni, sp)
+		if err != nil {
+			return util.NewErrs(err)
+		}
+		key, err := pathSpecKey(vp)
+		if err != nil {
+			return util.NewErrs(err)
+		}
+		if _, ok := nodes[key]; ok {
+			return
+		}
+		ni.Annotation = []interface{}{vp}
+		if err := recordOrderedList(lists, ni, vp, key); err != nil {
+			return util.NewErrs(err)
+		}
+
+		if util.IsNilOrInvalidValue(ni.FieldValue) || util.IsValueMap(ni.FieldValue) || util.IsValueOrderedMap(ni.FieldValue) {
+			return
+		}
+
+		pk, err := parentKey(ni)
+		if err != nil {
+			return util.NewErrs(err)
+		}
+
+		if util.IsValueStructPtr(ni.FieldValue) && !util.IsTypeAnydata(ni.FieldValue.Type()) {
+			gs, ok := ni.FieldValue.Interface().(GoStruct)
+			if !ok {
+				return util.NewErrs(fmt.Errorf("%s: was not a valid GoStruct", vp))
+			}
+			nodes[key] = &diffNode{path: vp, parent: pk, val: gs}
+			return
+		}
+
+		ival := ni.FieldValue.Interface()
+		if _, isEnum := ival.(GoEnum); isEnum {
+			val := ni.FieldValue
+			if val.Kind() == reflect.Interface {
+				val = val.Elem()
+			}
+			if val.Int() == 0 {
+				return
+			}
+		}
+
+		nodes[key] = &diffNode{
+			path:   vp,
+			parent: pk,
+			leaf:   true,
+			val:    ival,
+			field:  ni.StructField,
+		}
+		return
+	}
+
+	if errs := util.ForEachDataField(s, nil, nil, findSetIterFunc); errs != nil {
+		return nil, nil, fmt.Errorf("error from ForEachDataField iteration: %v", errs)
+	}
+	return nodes, lists, nil
+}
+
+// populatedNodes returns the set of keys of the nodes within the supplied
+// map that are leaves, or which have a leaf as a descendant. Containers and
+// list entries without leaves do not exist within the data tree. The root,
+// whose key is the empty string, is always included, since it exists
+// regardless of whether any of its descendants are populated.
+func populatedNodes(nodes map[string]*diffNode) map[string]bool {
+	pop := map[string]bool{"": true}
+	for k, n := range nodes {
+		if !n.leaf {
+			continue
+		}
+		pop[k] = true
+		for p := n.parent; !pop[p]; p = nodes[p].parent {
+			pop[p] = true
+		}
+	}
+	return pop
+}
+
+// pathLess reports whether the string representation of path a sorts
+// before that of path b.
+func pathLess(a, b *gnmipb.Path) bool {
+	as, _ := PathToString(a)
+	bs, _ := PathToString(b)
+	return as < bs
+}
+
+// sortUpdates sorts the supplied updates by their path.
+func sortUpdates(u []*gnmipb.Update) {
+	sort.SliceStable(u, func(i, j int) bool { return pathLess(u[i].Path, u[j].Path) })
+}
+
+// DiffToSetRequest takes an original and modified GoStruct, which must be of
+// the same type, and returns a gNMI SetRequest that changes a data tree that
+// contains original such that it contains modified. The paths within the
+// SetRequest are relative to the GoStruct supplied, such that the Prefix of
+// the SetRequest must be set where original and modified are not the root of
+// the schema tree.
+//
+// Compared to the Notification returned by Diff:
+//
+//  - Deletes are collapsed, such that where a container or list entry is
+//    populated in original but not in modified, a single delete of its path
+//    is returned, rather than a delete of each of its leaves. Deletes are
+//    processed before replaces and updates by a gNMI target.
+//  - Where the ReplaceNewSubtrees field of a supplied DiffSetRequestOpt is
+//    set, containers and list entries that are populated only in modified
+//    are written using a replace of the entire subtree.
+//  - Where the JSONIETF field of a supplied DiffSetRequestOpt is set, values
+//    are encoded as JSON_IETF subtrees, with the changed leaves of each
+//    container or list entry written by a single update of its path.
+//    Otherwise, each changed leaf is written by an update containing its
+//    scalar value.
+//  - Where the entries of a list that is ordered-by user have been reordered,
+//    the list is written using a replace of its path containing all of its
+//    entries. Since internal JSON does not retain the order of the entries
+//    of a list, the JSONIETF field should be set when diffing such lists.
+//
+// The deletes, replaces and updates within the SetRequest are each sorted by
+// path.
+func DiffToSetRequest(original, modified GoStruct, opts ...DiffOpt) (*gnmipb.SetRequest, error) {
+	if reflect.TypeOf(original) != reflect.TypeOf(modified) {
+		return nil, fmt.Errorf("cannot diff structs of different types, original: %T, modified: %T", original, modified)
+	}
+
+	origNodes, origLists, err := findSetNodes(original, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract set nodes from original struct: %v", err)
+	}
+	modNodes, modLists, err := findSetNodes(modified, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract set nodes from modified struct: %v", err)
+	}
+	origPop, modPop := populatedNodes(origNodes), populatedNodes(modNodes)
+	setOpt := hasDiffSetRequestOpt(opts)
+	reordered := reorderedLists(origLists, modLists)
+
+	enc := gnmipb.Encoding_JSON
+	if setOpt.JSONIETF {
+		enc = gnmipb.Encoding_JSON_IETF
+	}
+
+	req := &gnmipb.SetRequest{}
+	for k, n := range origNodes {
+		// Nodes whose parent is also deleted are removed by the delete of
+		// the parent, and nodes within reordered lists are removed by their
+		// replace.
+		if k == "" || !origPop[k] || modPop[k] || !modPop[n.parent] || withinLists(n.path, reordered) {
+			continue
+		}
+		req.Delete = append(req.Delete, n.path.gNMIPaths...)
+	}
+	sort.SliceStable(req.Delete, func(i, j int) bool { return pathLess(req.Delete[i], req.Delete[j]) })
+
+	for _, l := range reordered {
+		v, err := EncodeTypedValue(l.val, enc)
+		if err != nil {
+			return nil, fmt.Errorf("cannot represent list %v as TypedValue: %v", l.path, err)
+		}
+		for _, p := range l.path.gNMIPaths {
+			req.Replace = append(req.Replace, &gnmipb.Update{Path: p, Val: v})
+		}
+	}
+
+	replaced := map[string]bool{}
+	if setOpt.ReplaceNewSubtrees {
+		for k, n := range modNodes {
+			// Nodes whose parent is also new are written by the replace
+			// of the parent.
+			if k == "" || n.leaf || !modPop[k] || origPop[k] || !origPop[n.parent] || withinLists(n.path, reordered) {
+				continue
+			}
+			replaced[k] = true
+			v, err := EncodeTypedValue(n.val, enc)
+			if err != nil {
+				return nil, fmt.Errorf("cannot represent subtree %v as TypedValue: %v", n.path, err)
+			}
+			for _, p := range n.path.gNMIPaths {
+				req.Replace = append(req.Replace, &gnmipb.Update{Path: p, Val: v})
+			}
+		}
+	}
+	sortUpdates(req.Replace)
+
+	// isReplaced determines whether the node with key k is within a
+	// replaced subtree.
+	isReplaced := func(k string) bool {
+		for ; k != ""; k = modNodes[k].parent {
+			if replaced[k] {
+				return true
+			}
+		}
+		return false
+	}
+
+	changed := map[string][]*diffNode{}
+	for k, n := range modNodes {
+		if !n.leaf || isReplaced(n.parent) || withinLists(n.path, reordered) {
+			continue
+		}
+		if on, ok := origNodes[k]; ok && on.leaf && cmp.Equal(on.val, n.val) {
+			continue
+		}
+		if !setOpt.JSONIETF {
+			un := &gnmipb.Notification{}
+			if err := appendUpdate(un, n.path, n.val); err != nil {
 				return nil, err
 			}
+			req.Update = append(req.Update, un.Update...)
+			continue
 		}
+		changed[n.parent] = append(changed[n.parent], n)
 	}
 
-	return n, nil
+	for pk, leaves := range changed {
+		// Construct a copy of the parent that contains only the changed
+		// leaves, and write it as a subtree.
+		parent := modNodes[pk]
+		ps := reflect.New(reflect.TypeOf(parent.val).Elem())
+		for _, l := range leaves {
+			ps.Elem().FieldByIndex(l.field.Index).Set(reflect.ValueOf(l.val))
+		}
+		v, err := EncodeTypedValue(ps.Interface(), enc)
+		if err != nil {
+			return nil, fmt.Errorf("cannot represent changed leaves of %v as TypedValue: %v", parent.path, err)
+		}
+		for _, p := range parent.path.gNMIPaths {
+			req.Update = append(req.Update, &gnmipb.Update{Path: p, Val: v})
+		}
+	}
+	sortUpdates(req.Update)
+
+	return req, nil
 }
@@ -15,6 +15,7 @@
 package ygot
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -418,6 +419,15 @@ type multiPathStruct struct {
 
 func (*multiPathStruct) IsYANGGoStruct() {}
 
+// subtreeStruct has a branch, error-value, that cannot be walked, such that
+// it can be tested whether the walk is pruned by a DiffSubtreeOpt.
+type subtreeStruct struct {
+	StructValue *basicStructTwo `path:"struct-value"`
+	ErrorValue  *errorStruct    `path:"error-value"`
+}
+
+func (*subtreeStruct) IsYANGGoStruct() {}
+
 func TestFindSetLeaves(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -429,6 +439,35 @@ func TestFindSetLeaves(t *testing.T) {
 		desc:     "struct with fields missing path annotation",
 		inStruct: &errorStruct{Value: String("foo")},
 		wantErr:  "error from ForEachDataField iteration: field Value did not specify a path",
+	}, {
+		desc: "subtree option prunes branches outside of the subtree",
+		inStruct: &subtreeStruct{
+			StructValue: &basicStructTwo{StringValue: String("value-two")},
+			ErrorValue:  &errorStruct{Value: String("foo")},
+		},
+		inOpts: []DiffOpt{
+			&DiffSubtreeOpt{Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "struct-value"}}}},
+		},
+		want: map[*pathSpec]interface{}{
+			{
+				gNMIPaths: []*gnmipb.Path{{
+					Elem: []*gnmipb.PathElem{
+						{Name: "struct-value"},
+						{Name: "second-string-value"},
+					},
+				}},
+			}: String("value-two"),
+		},
+	}, {
+		desc: "subtree option walks branches within the subtree",
+		inStruct: &subtreeStruct{
+			StructValue: &basicStructTwo{StringValue: String("value-two")},
+			ErrorValue:  &errorStruct{Value: String("foo")},
+		},
+		inOpts: []DiffOpt{
+			&DiffSubtreeOpt{Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "error-value"}}}},
+		},
+		wantErr: "error from ForEachDataField iteration: field Value did not specify a path",
 	}, {
 		desc: "multi-level string values",
 		inStruct: &basicStruct{
@@ -616,11 +655,21 @@ func TestFindSetLeaves(t *testing.T) {
 	}}
 
 	for _, tt := range tests {
-		got, err := findSetLeaves(tt.inStruct, tt.inOpts...)
+		leaves, err := findSetLeaves(tt.inStruct, tt.inOpts...)
 		if err != nil && (err.Error() != tt.wantErr) {
 			t.Errorf("%s: findSetLeaves(%v): did not get expected error: %v", tt.desc, tt.inStruct, err)
 			continue
 		}
+		var got map[*pathSpec]interface{}
+		if leaves != nil {
+			got = map[*pathSpec]interface{}{}
+		}
+		for key, l := range leaves {
+			if want, err := pathSpecKey(l.path); err != nil || key != want {
+				t.Errorf("%s: findSetLeaves(%v): leaf %v has key %s, want: %s", tt.desc, tt.inStruct, l.path, key, want)
+			}
+			got[l.path] = l.val
+		}
 		if diff := cmp.Diff(tt.want, got,
 			cmpopts.SortMaps(func(x, y *pathSpec) bool {
 				return x.String() < y.String()
@@ -1487,6 +1536,91 @@ func TestDiff(t *testing.T) {
 				},
 			}},
 		},
+	}, {
+		desc: "subtree option - container",
+		inOrig: &basicStruct{
+			StringValue: String("foo"),
+			StructValue: &basicStructTwo{StringValue: String("bar")},
+		},
+		inMod: &basicStruct{
+			StringValue: String("baz"),
+			StructValue: &basicStructTwo{StringValue: String("qux")},
+		},
+		inOpts: []DiffOpt{
+			&DiffSubtreeOpt{Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "struct-value"}}}},
+		},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{
+					Elem: []*gnmipb.PathElem{{
+						Name: "struct-value",
+					}, {
+						Name: "second-string-value",
+					}},
+				},
+				Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"qux"}},
+			}},
+		},
+	}, {
+		desc: "subtree option - list entry",
+		inOrig: &basicStruct{
+			MapValue: map[string]*basicListMember{
+				"one": {ListKey: String("one")},
+				"two": {ListKey: String("two")},
+			},
+		},
+		inMod: &basicStruct{},
+		inOpts: []DiffOpt{
+			&DiffSubtreeOpt{Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "map-list", Key: map[string]string{"list-key": "one"}}}}},
+		},
+		want: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{{
+				Elem: []*gnmipb.PathElem{{
+					Name: "map-list",
+					Key:  map[string]string{"list-key": "one"},
+				}, {
+					Name: "list-key",
+				}},
+			}},
+		},
+	}, {
+		desc: "subtree option - wildcarded list",
+		inOrig: &basicStruct{
+			StringValue: String("foo"),
+			MapValue: map[string]*basicListMember{
+				"one": {ListKey: String("one")},
+				"two": {ListKey: String("two")},
+			},
+		},
+		inMod: &basicStruct{},
+		inOpts: []DiffOpt{
+			&DiffSubtreeOpt{Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "map-list", Key: map[string]string{"list-key": "*"}}}}},
+		},
+		want: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{{
+				Elem: []*gnmipb.PathElem{{
+					Name: "map-list",
+					Key:  map[string]string{"list-key": "one"},
+				}, {
+					Name: "list-key",
+				}},
+			}, {
+				Elem: []*gnmipb.PathElem{{
+					Name: "map-list",
+					Key:  map[string]string{"list-key": "two"},
+				}, {
+					Name: "list-key",
+				}},
+			}},
+		},
+	}, {
+		desc:   "subtree option - no leaves within subtree",
+		inOrig: &basicStruct{StringValue: String("foo")},
+		inMod:  &basicStruct{StringValue: String("bar")},
+		inOpts: []DiffOpt{
+			&DiffSubtreeOpt{Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "struct-value"}}}},
+		},
+		want: &gnmipb.Notification{},
 	}}
 
 	for _, tt := range tests {
@@ -1545,3 +1679,320 @@ func TestLeastSpecificPath(t *testing.T) {
 		}
 	}
 }
+
+type setDiffRoot struct {
+	Name  *string                       `path:"name"`
+	Child *setDiffChild                 `path:"child"`
+	List  map[string]*setDiffListMember `path:"list"`
+}
+
+func (*setDiffRoot) IsYANGGoStruct() {}
+
+type setDiffChild struct {
+	Value      *string            `path:"value"`
+	Number     *uint32            `path:"number"`
+	Grandchild *setDiffGrandchild `path:"grandchild"`
+}
+
+func (*setDiffChild) IsYANGGoStruct() {}
+
+type setDiffGrandchild struct {
+	Value *string `path:"value"`
+}
+
+func (*setDiffGrandchild) IsYANGGoStruct() {}
+
+type setDiffListMember struct {
+	Key   *string `path:"config/key|key"`
+	Value *string `path:"config/value"`
+}
+
+func (*setDiffListMember) IsYANGGoStruct() {}
+
+func (l *setDiffListMember) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"key": *l.Key}, nil
+}
+
+func TestDiffToSetRequest(t *testing.T) {
+	path := func(s string) *gnmipb.Path {
+		p, err := StringToStructuredPath(s)
+		if err != nil {
+			t.Fatalf("cannot parse path %s, %v", s, err)
+		}
+		return p
+	}
+	strVal := func(s string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}}
+	}
+	jsonVal := func(v interface{}, ietf bool) *gnmipb.TypedValue {
+		js, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			t.Fatalf("cannot marshal JSON, %v", err)
+		}
+		if ietf {
+			return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: js}}
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonVal{JsonVal: js}}
+	}
+	listMember := func(k, v string) *setDiffListMember {
+		return &setDiffListMember{Key: String(k), Value: String(v)}
+	}
+
+	tests := []struct {
+		desc          string
+		inOrig        GoStruct
+		inMod         GoStruct
+		inOpts        []DiffOpt
+		want          *gnmipb.SetRequest
+		wantErrSubStr string
+	}{{
+		desc:   "no changes",
+		inOrig: &setDiffRoot{Name: String("a"), Child: &setDiffChild{Value: String("b")}},
+		inMod:  &setDiffRoot{Name: String("a"), Child: &setDiffChild{Value: String("b")}},
+		want:   &gnmipb.SetRequest{},
+	}, {
+		desc:   "leaf updates",
+		inOrig: &setDiffRoot{Name: String("a")},
+		inMod:  &setDiffRoot{Name: String("b"), Child: &setDiffChild{Number: Uint32(42)}},
+		want: &gnmipb.SetRequest{
+			Update: []*gnmipb.Update{{
+				Path: path("/child/number"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 42}},
+			}, {
+				Path: path("/name"),
+				Val:  strVal("b"),
+			}},
+		},
+	}, {
+		desc: "deleted container collapsed into single delete",
+		inOrig: &setDiffRoot{
+			Name: String("a"),
+			Child: &setDiffChild{
+				Value:      String("b"),
+				Number:     Uint32(42),
+				Grandchild: &setDiffGrandchild{Value: String("c")},
+			},
+		},
+		inMod: &setDiffRoot{Name: String("a"), Child: &setDiffChild{}},
+		want: &gnmipb.SetRequest{
+			Delete: []*gnmipb.Path{path("/child")},
+		},
+	}, {
+		desc: "leaves and child container deleted within container",
+		inOrig: &setDiffRoot{
+			Child: &setDiffChild{
+				Value:      String("b"),
+				Number:     Uint32(42),
+				Grandchild: &setDiffGrandchild{Value: String("c")},
+			},
+		},
+		inMod: &setDiffRoot{Child: &setDiffChild{Value: String("d")}},
+		want: &gnmipb.SetRequest{
+			Delete: []*gnmipb.Path{path("/child/grandchild"), path("/child/number")},
+			Update: []*gnmipb.Update{{
+				Path: path("/child/value"),
+				Val:  strVal("d"),
+			}},
+		},
+	}, {
+		desc: "deleted list entry",
+		inOrig: &setDiffRoot{
+			List: map[string]*setDiffListMember{"a": listMember("a", "one"), "b": listMember("b", "two")},
+		},
+		inMod: &setDiffRoot{
+			List: map[string]*setDiffListMember{"a": listMember("a", "one")},
+		},
+		want: &gnmipb.SetRequest{
+			Delete: []*gnmipb.Path{path("/list[key=b]")},
+		},
+	}, {
+		desc:   "new list entry as leaf updates",
+		inOrig: &setDiffRoot{},
+		inMod: &setDiffRoot{
+			List: map[string]*setDiffListMember{"a": listMember("a", "one")},
+		},
+		want: &gnmipb.SetRequest{
+			Update: []*gnmipb.Update{{
+				Path: path("/list[key=a]/config/key"),
+				Val:  strVal("a"),
+			}, {
+				Path: path("/list[key=a]/config/value"),
+				Val:  strVal("one"),
+			}, {
+				Path: path("/list[key=a]/key"),
+				Val:  strVal("a"),
+			}},
+		},
+	}, {
+		desc: "new list entry replaced",
+		inOrig: &setDiffRoot{
+			List: map[string]*setDiffListMember{"a": listMember("a", "one")},
+		},
+		inMod: &setDiffRoot{
+			List: map[string]*setDiffListMember{"a": listMember("a", "two"), "b": listMember("b", "three")},
+		},
+		inOpts: []DiffOpt{&DiffSetRequestOpt{ReplaceNewSubtrees: true}},
+		want: &gnmipb.SetRequest{
+			Replace: []*gnmipb.Update{{
+				Path: path("/list[key=b]"),
+				Val: jsonVal(map[string]interface{}{
+					"config": map[string]interface{}{"key": "b", "value": "three"},
+					"key":    "b",
+				}, false),
+			}},
+			Update: []*gnmipb.Update{{
+				Path: path("/list[key=a]/config/value"),
+				Val:  strVal("two"),
+			}},
+		},
+	}, {
+		desc:   "new nested containers replaced at the highest new container",
+		inOrig: &setDiffRoot{Name: String("a")},
+		inMod: &setDiffRoot{
+			Name: String("a"),
+			Child: &setDiffChild{
+				Value:      String("b"),
+				Grandchild: &setDiffGrandchild{Value: String("c")},
+			},
+		},
+		inOpts: []DiffOpt{&DiffSetRequestOpt{ReplaceNewSubtrees: true, JSONIETF: true}},
+		want: &gnmipb.SetRequest{
+			Replace: []*gnmipb.Update{{
+				Path: path("/child"),
+				Val: jsonVal(map[string]interface{}{
+					"grandchild": map[string]interface{}{"value": "c"},
+					"value":      "b",
+				}, true),
+			}},
+		},
+	}, {
+		desc: "all subtrees deleted from root without leaves",
+		inOrig: &setDiffRoot{
+			Child: &setDiffChild{Value: String("b")},
+			List:  map[string]*setDiffListMember{"a": listMember("a", "one")},
+		},
+		inMod: &setDiffRoot{},
+		want: &gnmipb.SetRequest{
+			Delete: []*gnmipb.Path{path("/child"), path("/list[key=a]")},
+		},
+	}, {
+		desc:   "new subtrees replaced within root without leaves",
+		inOrig: &setDiffRoot{},
+		inMod: &setDiffRoot{
+			Child: &setDiffChild{Value: String("b")},
+		},
+		inOpts: []DiffOpt{&DiffSetRequestOpt{ReplaceNewSubtrees: true, JSONIETF: true}},
+		want: &gnmipb.SetRequest{
+			Replace: []*gnmipb.Update{{
+				Path: path("/child"),
+				Val:  jsonVal(map[string]interface{}{"value": "b"}, true),
+			}},
+		},
+	}, {
+		desc: "JSON_IETF updates grouped by parent",
+		inOrig: &setDiffRoot{
+			Name:  String("a"),
+			Child: &setDiffChild{Value: String("b"), Number: Uint32(1)},
+			List:  map[string]*setDiffListMember{"a": listMember("a", "one")},
+		},
+		inMod: &setDiffRoot{
+			Name:  String("z"),
+			Child: &setDiffChild{Value: String("y"), Number: Uint32(2)},
+			List:  map[string]*setDiffListMember{"a": listMember("a", "two")},
+		},
+		inOpts: []DiffOpt{&DiffSetRequestOpt{JSONIETF: true}},
+		want: &gnmipb.SetRequest{
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{},
+				Val:  jsonVal(map[string]interface{}{"name": "z"}, true),
+			}, {
+				Path: path("/child"),
+				Val:  jsonVal(map[string]interface{}{"number": 2, "value": "y"}, true),
+			}, {
+				Path: path("/list[key=a]"),
+				Val: jsonVal(map[string]interface{}{
+					"config": map[string]interface{}{"value": "two"},
+				}, true),
+			}},
+		},
+	}, {
+		desc: "single path mapping",
+		inOrig: &setDiffRoot{
+			List: map[string]*setDiffListMember{"a": listMember("a", "one")},
+		},
+		inMod: &setDiffRoot{
+			List: map[string]*setDiffListMember{"a": listMember("a", "one"), "b": {Key: String("b")}},
+		},
+		inOpts: []DiffOpt{&DiffPathOpt{MapToSinglePath: true}},
+		want: &gnmipb.SetRequest{
+			Update: []*gnmipb.Update{{
+				Path: path("/list[key=b]/key"),
+				Val:  strVal("b"),
+			}},
+		},
+	}, {
+		desc:          "different types",
+		inOrig:        &setDiffRoot{},
+		inMod:         &basicStruct{},
+		wantErrSubStr: "cannot diff structs of different types",
+	}, {
+		desc:          "invalid GoStruct",
+		inOrig:        &basicStruct{StructValue: &basicStructTwo{StringValue: String("a")}},
+		inMod:         &basicStruct{},
+		wantErrSubStr: "was not a valid GoStruct",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := DiffToSetRequest(tt.inOrig, tt.inMod, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubStr); diff != "" {
+				t.Fatalf("DiffToSetRequest(%s, %s): did not get expected error, %s", pretty.Sprint(tt.inOrig), pretty.Sprint(tt.inMod), diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("DiffToSetRequest(%s, %s): did not get expected SetRequest, diff(-want,+got):\n%s", pretty.Sprint(tt.inOrig), pretty.Sprint(tt.inMod), diff)
+			}
+		})
+	}
+}
+
+// diffBenchmarkStructs returns a pair of setDiffRoot structs with size list
+// entries, in which the value of every tenth list entry differs.
+func diffBenchmarkStructs(size int) (*setDiffRoot, *setDiffRoot) {
+	orig := &setDiffRoot{Name: String("device"), List: map[string]*setDiffListMember{}}
+	mod := &setDiffRoot{Name: String("device"), List: map[string]*setDiffListMember{}}
+	for i := 0; i < size; i++ {
+		k := fmt.Sprintf("entry-%d", i)
+		orig.List[k] = &setDiffListMember{Key: String(k), Value: String("value")}
+		mod.List[k] = &setDiffListMember{Key: String(k), Value: String("value")}
+		if i%10 == 0 {
+			mod.List[k].Value = String("modified")
+		}
+	}
+	return orig, mod
+}
+
+func BenchmarkDiff(b *testing.B) {
+	for _, size := range []int{100, 1000, 10000} {
+		orig, mod := diffBenchmarkStructs(size)
+		b.Run(fmt.Sprintf("%d-entries", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := Diff(orig, mod); err != nil {
+					b.Fatalf("Diff: got unexpected error, %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDiffSubtree(b *testing.B) {
+	orig, mod := diffBenchmarkStructs(10000)
+	opt := &DiffSubtreeOpt{Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "list", Key: map[string]string{"key": "entry-0"}}}}}
+	for i := 0; i < b.N; i++ {
+		if _, err := Diff(orig, mod, opt); err != nil {
+			b.Fatalf("Diff: got unexpected error, %v", err)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// LeafMetadata stores the metadata that describes how the value of a leaf
+// within a GoStruct was received, such that it can be retained alongside the
+// value.
+type LeafMetadata struct {
+	// Timestamp is the timestamp, in nanoseconds since the Unix epoch, of
+	// the Notification that last updated the leaf.
+	Timestamp int64 `json:"timestamp"`
+	// Origin is the origin of the path of the leaf, as specified within the
+	// Notification that last updated it.
+	Origin string `json:"origin,omitempty"`
+	// Target is the target of the path of the leaf, as specified within the
+	// Notification that last updated it.
+	Target string `json:"target,omitempty"`
+	// Notification is the Notification that last updated the leaf. It is
+	// not serialised when the LeafMetadataStore is marshalled to JSON.
+	Notification *gnmipb.Notification `json:"-"`
+}
+
+// LeafMetadataStore stores the LeafMetadata for each populated leaf of a
+// GoStruct data tree. It implements the Annotation interface, such that it
+// can be stored within the struct-level annotation field of the root of the
+// data tree, which is generated when the GenerateLeafMetadata or
+// AddAnnotationFields Go generation options are used. It is not included
+// when the data tree is rendered to JSON.
+type LeafMetadataStore struct {
+	// Leaves is the metadata for each leaf, keyed by the string
+	// representation of the leaf's data tree path, relative to the root of
+	// the data tree.
+	Leaves map[string]*LeafMetadata
+}
+
+// NewLeafMetadataStore returns an empty LeafMetadataStore.
+func NewLeafMetadataStore() *LeafMetadataStore {
+	return &LeafMetadataStore{Leaves: map[string]*LeafMetadata{}}
+}
+
+// MarshalJSON implements the Annotation interface, marshalling the metadata
+// of each leaf, keyed by its path.
+func (m *LeafMetadataStore) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"leaf-metadata": m.Leaves})
+}
+
+// UnmarshalJSON implements the Annotation interface, unmarshalling JSON that
+// was created by MarshalJSON into the LeafMetadataStore.
+func (m *LeafMetadataStore) UnmarshalJSON(b []byte) error {
+	s := struct {
+		Leaves map[string]*LeafMetadata `json:"leaf-metadata"`
+	}{}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	m.Leaves = s.Leaves
+	if m.Leaves == nil {
+		m.Leaves = map[string]*LeafMetadata{}
+	}
+	return nil
+}
+
+// Set stores the metadata md for the leaf at the data tree path p.
+func (m *LeafMetadataStore) Set(p *gnmipb.Path, md *LeafMetadata) error {
+	ps, err := PathToString(p)
+	if err != nil {
+		return fmt.Errorf("invalid leaf path %v, %v", p, err)
+	}
+	if m.Leaves == nil {
+		m.Leaves = map[string]*LeafMetadata{}
+	}
+	m.Leaves[ps] = md
+	return nil
+}
+
+// Get returns the metadata for the leaf at the data tree path p, and whether
+// metadata is stored for the leaf.
+func (m *LeafMetadataStore) Get(p *gnmipb.Path) (*LeafMetadata, bool) {
+	ps, err := PathToString(p)
+	if err != nil {
+		return nil, false
+	}
+	md, ok := m.Leaves[ps]
+	return md, ok
+}
+
+// Delete removes the metadata for the leaves at, or below, the data tree
+// path p. The elements of p are compared to those of each leaf's path in
+// turn, such that a list element of p that does not specify a key, or whose
+// name or key value is "*", matches any entry of the list.
+func (m *LeafMetadataStore) Delete(p *gnmipb.Path) error {
+	if _, err := PathToString(p); err != nil {
+		return fmt.Errorf("invalid path %v, %v", p, err)
+	}
+	for k := range m.Leaves {
+		lp, err := StringToStructuredPath(k)
+		if err != nil {
+			return fmt.Errorf("invalid leaf path %s, %v", k, err)
+		}
+		if pathHasElemPrefix(lp, p) {
+			delete(m.Leaves, k)
+		}
+	}
+	return nil
+}
+
+// RecordNotification updates the metadata stored within the
+// LeafMetadataStore for the deletes and updates within the Notification n,
+// whose paths are assumed to be relative to the root of the data tree. The
+// metadata for the leaves below each deleted path is removed, and the
+// metadata for each updated leaf is set to the timestamp, origin and target
+// of n.
+func (m *LeafMetadataStore) RecordNotification(n *gnmipb.Notification) error {
+	if n == nil {
+		return errors.New("nil Notification")
+	}
+
+	var errs util.Errors
+	prefix := n.GetPrefix()
+	for _, d := range n.GetDelete() {
+		if err := m.Delete(joinLeafPath(prefix, d)); err != nil {
+			errs = util.AppendErr(errs, err)
+		}
+	}
+	for _, u := range n.GetUpdate() {
+		md := &LeafMetadata{
+			Timestamp:    n.GetTimestamp(),
+			Origin:       prefix.GetOrigin(),
+			Target:       prefix.GetTarget(),
+			Notification: n,
+		}
+		if o := u.GetPath().GetOrigin(); o != "" {
+			md.Origin = o
+		}
+		if err := m.Set(joinLeafPath(prefix, u.GetPath()), md); err != nil {
+			errs = util.AppendErr(errs, err)
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// joinLeafPath returns the path formed by appending the elements of p to
+// those of prefix.
+func joinLeafPath(prefix, p *gnmipb.Path) *gnmipb.Path {
+	np := &gnmipb.Path{}
+	np.Elem = append(np.Elem, prefix.GetElem()...)
+	np.Elem = append(np.Elem, p.GetElem()...)
+	return np
+}
+
+// LeafMetadataFromGoStruct returns the LeafMetadataStore that is stored
+// within the struct-level annotation field of the GoStruct s, or nil if s
+// does not store one.
+func LeafMetadataFromGoStruct(s GoStruct) *LeafMetadataStore {
+	f, ok := structAnnotationField(s)
+	if !ok {
+		return nil
+	}
+	for i := 0; i < f.Len(); i++ {
+		if m, ok := f.Index(i).Interface().(*LeafMetadataStore); ok {
+			return m
+		}
+	}
+	return nil
+}
+
+// AttachLeafMetadata stores the LeafMetadataStore m within the struct-level
+// annotation field of the GoStruct s, replacing any LeafMetadataStore that is
+// already stored. It returns an error if s does not have a struct-level
+// annotation field, which is generated for the fakeroot when the
+// GenerateLeafMetadata Go generation option is used.
+func AttachLeafMetadata(s GoStruct, m *LeafMetadataStore) error {
+	f, ok := structAnnotationField(s)
+	if !ok {
+		return fmt.Errorf("%T does not have a struct-level annotation field", s)
+	}
+	for i := 0; i < f.Len(); i++ {
+		if _, ok := f.Index(i).Interface().(*LeafMetadataStore); ok {
+			f.Index(i).Set(reflect.ValueOf(m))
+			return nil
+		}
+	}
+	f.Set(reflect.Append(f, reflect.ValueOf(m)))
+	return nil
+}
+
+// structAnnotationField returns the struct-level annotation field of the
+// GoStruct s, which has the path "@", and whether such a field exists.
+func structAnnotationField(s GoStruct) (reflect.Value, bool) {
+	v := reflect.ValueOf(s)
+	if !util.IsValueStructPtr(v) {
+		return reflect.Value{}, false
+	}
+	sv := v.Elem()
+	for i := 0; i < sv.NumField(); i++ {
+		ft := sv.Type().Field(i)
+		if util.IsYgotAnnotation(ft) && ft.Tag.Get("path") == "@" && ft.Type == reflect.TypeOf([]Annotation{}) {
+			return sv.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
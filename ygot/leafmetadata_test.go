@@ -0,0 +1,368 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/testutil"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// leafMetadataRoot is a GoStruct with a struct-level annotation field, as is
+// generated for the fakeroot with the GenerateLeafMetadata option.
+type leafMetadataRoot struct {
+	ΛMetadata   []Annotation                     `path:"@" ygotAnnotation:"true"`
+	StringField *string                          `path:"string-field"`
+	List        map[string]*pathElemExampleChild `path:"list"`
+}
+
+func (*leafMetadataRoot) IsYANGGoStruct()                         {}
+func (*leafMetadataRoot) Validate(...ValidationOption) error      { return nil }
+func (*leafMetadataRoot) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+
+func TestLeafMetadataStore(t *testing.T) {
+	n1 := &gnmipb.Notification{
+		Timestamp: 42,
+		Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut", Elem: []*gnmipb.PathElem{{Name: "list", Key: map[string]string{"val": "p1"}}}},
+		Update: []*gnmipb.Update{{
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "val"}}},
+		}, {
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "config"}, {Name: "val"}}},
+		}},
+	}
+	n2 := &gnmipb.Notification{
+		Timestamp: 84,
+		Update: []*gnmipb.Update{{
+			Path: &gnmipb.Path{Origin: "cli", Elem: []*gnmipb.PathElem{{Name: "string-field"}}},
+		}},
+	}
+	n3 := &gnmipb.Notification{
+		Timestamp: 126,
+		Delete:    []*gnmipb.Path{{Elem: []*gnmipb.PathElem{{Name: "list", Key: map[string]string{"val": "p1"}}, {Name: "config"}}}},
+	}
+
+	m := NewLeafMetadataStore()
+	for _, n := range []*gnmipb.Notification{n1, n2, n3} {
+		if err := m.RecordNotification(n); err != nil {
+			t.Fatalf("RecordNotification(%v): got unexpected error, %v", n, err)
+		}
+	}
+
+	want := map[string]*LeafMetadata{
+		"/list[val=p1]/val": {Timestamp: 42, Origin: "openconfig", Target: "dut", Notification: n1},
+		"/string-field":     {Timestamp: 84, Origin: "cli", Notification: n2},
+	}
+	if diff := cmp.Diff(want, m.Leaves, protocmp.Transform()); diff != "" {
+		t.Fatalf("RecordNotification: did not get expected metadata, (-want, +got):\n%s", diff)
+	}
+
+	got, ok := m.Get(&gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "string-field"}}})
+	if !ok || got.Timestamp != 84 {
+		t.Errorf("Get(/string-field): got (%v, %v), want timestamp 84", got, ok)
+	}
+
+	// Only the metadata of the leaves is retained in JSON.
+	js, err := m.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: got unexpected error, %v", err)
+	}
+	wantJSON := `{"leaf-metadata":{"/list[val=p1]/val":{"timestamp":42,"origin":"openconfig","target":"dut"},"/string-field":{"timestamp":84,"origin":"cli"}}}`
+	if string(js) != wantJSON {
+		t.Errorf("MarshalJSON: got %s, want %s", js, wantJSON)
+	}
+	um := &LeafMetadataStore{}
+	if err := um.UnmarshalJSON(js); err != nil {
+		t.Fatalf("UnmarshalJSON: got unexpected error, %v", err)
+	}
+	if diff := cmp.Diff(m, um, protocmp.Transform(), cmpopts.IgnoreFields(LeafMetadata{}, "Notification")); diff != "" {
+		t.Errorf("UnmarshalJSON: did not get expected store, (-want, +got):\n%s", diff)
+	}
+
+	if err := m.Delete(&gnmipb.Path{}); err != nil {
+		t.Fatalf("Delete(/): got unexpected error, %v", err)
+	}
+	if len(m.Leaves) != 0 {
+		t.Errorf("Delete(/): got metadata %v, want none", m.Leaves)
+	}
+
+	if err := m.RecordNotification(nil); err == nil {
+		t.Errorf("RecordNotification(nil): did not get expected error")
+	}
+}
+
+func TestLeafMetadataStoreDelete(t *testing.T) {
+	leaves := []string{
+		"/list[val=p1]/val",
+		"/list[val=p1]/config/val",
+		"/list[val=p2]/val",
+		"/list[val=p2]/config/val",
+		"/multi[a=1][b=1]/config/a",
+		"/multi[a=1][b=2]/config/a",
+		"/multi[a=2][b=1]/config/a",
+		"/string-field",
+	}
+
+	tests := []struct {
+		desc       string
+		inPath     string
+		wantLeaves []string
+	}{{
+		desc:   "root",
+		inPath: "/",
+	}, {
+		desc:       "leaf",
+		inPath:     "/string-field",
+		wantLeaves: leaves[:7],
+	}, {
+		desc:       "list entry",
+		inPath:     "/list[val=p1]",
+		wantLeaves: leaves[2:],
+	}, {
+		desc:       "list without keys",
+		inPath:     "/list",
+		wantLeaves: leaves[4:],
+	}, {
+		desc:       "list with wildcard key",
+		inPath:     "/list[val=*]/config",
+		wantLeaves: []string{leaves[0], leaves[2], leaves[4], leaves[5], leaves[6], leaves[7]},
+	}, {
+		desc:       "list with one of multiple keys",
+		inPath:     "/multi[a=1]",
+		wantLeaves: []string{leaves[0], leaves[1], leaves[2], leaves[3], leaves[6], leaves[7]},
+	}, {
+		desc:       "wildcard element name",
+		inPath:     "/*/config/val",
+		wantLeaves: []string{leaves[0], leaves[2], leaves[4], leaves[5], leaves[6], leaves[7]},
+	}, {
+		desc:       "no match",
+		inPath:     "/list[val=p3]",
+		wantLeaves: leaves,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m := NewLeafMetadataStore()
+			for _, l := range leaves {
+				m.Leaves[l] = &LeafMetadata{Timestamp: 42}
+			}
+			p, err := StringToStructuredPath(tt.inPath)
+			if err != nil {
+				t.Fatalf("cannot parse path %s, %v", tt.inPath, err)
+			}
+			if err := m.Delete(p); err != nil {
+				t.Fatalf("Delete(%s): got unexpected error, %v", tt.inPath, err)
+			}
+			var got []string
+			for l := range m.Leaves {
+				got = append(got, l)
+			}
+			if diff := cmp.Diff(tt.wantLeaves, got, cmpopts.SortSlices(func(a, b string) bool { return a < b }), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Delete(%s): did not get expected leaves, (-want, +got):\n%s", tt.inPath, diff)
+			}
+		})
+	}
+}
+
+func TestAttachLeafMetadata(t *testing.T) {
+	s := &leafMetadataRoot{ΛMetadata: []Annotation{&testAnnotation{AnnotationFieldOne: "foo"}}}
+	if got := LeafMetadataFromGoStruct(s); got != nil {
+		t.Fatalf("LeafMetadataFromGoStruct: got %v, want nil", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		m := NewLeafMetadataStore()
+		if err := AttachLeafMetadata(s, m); err != nil {
+			t.Fatalf("AttachLeafMetadata: got unexpected error, %v", err)
+		}
+		if got := LeafMetadataFromGoStruct(s); got != m {
+			t.Fatalf("LeafMetadataFromGoStruct: got %v, want %v", got, m)
+		}
+	}
+	if got, want := len(s.ΛMetadata), 2; got != want {
+		t.Errorf("AttachLeafMetadata: got %d annotations, want %d", got, want)
+	}
+
+	err := AttachLeafMetadata(&pathElemExample{}, NewLeafMetadataStore())
+	if diff := errdiff.Substring(err, "does not have a struct-level annotation field"); diff != "" {
+		t.Errorf("AttachLeafMetadata: did not get expected error, %s", diff)
+	}
+}
+
+func TestEmitJSONLeafMetadata(t *testing.T) {
+	metadata := NewLeafMetadataStore()
+	if err := metadata.Set(&gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "string-field"}}}, &LeafMetadata{Timestamp: 42}); err != nil {
+		t.Fatalf("cannot set metadata, %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		inAnnos  []Annotation
+		inFormat JSONFormat
+		want     string
+	}{{
+		name:     "internal JSON with only leaf metadata",
+		inAnnos:  []Annotation{metadata},
+		inFormat: Internal,
+		want:     `{"string-field":"foo"}`,
+	}, {
+		name:     "RFC7951 JSON with only leaf metadata",
+		inAnnos:  []Annotation{metadata},
+		inFormat: RFC7951,
+		want:     `{"string-field":"foo"}`,
+	}, {
+		name:     "internal JSON with leaf metadata and other annotation",
+		inAnnos:  []Annotation{metadata, &testAnnotation{AnnotationFieldOne: "bar"}},
+		inFormat: Internal,
+		want:     `{"@":[{"field":"bar"}],"string-field":"foo"}`,
+	}, {
+		name:     "RFC7951 JSON with leaf metadata and RFC7952 metadata",
+		inAnnos:  []Annotation{&Metadata{Values: map[string]interface{}{"m:tag": "x"}}, metadata},
+		inFormat: RFC7951,
+		want:     `{"@":{"m:tag":"x"},"string-field":"foo"}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &leafMetadataRoot{ΛMetadata: tt.inAnnos, StringField: String("foo")}
+			got, err := EmitJSON(s, &EmitJSONConfig{Format: tt.inFormat, SkipValidation: true})
+			if err != nil {
+				t.Fatalf("EmitJSON: got unexpected error, %v", err)
+			}
+			if diff := cmp.Diff(tt.want, strings.Join(strings.Fields(got), "")); diff != "" {
+				t.Errorf("EmitJSON: did not get expected JSON, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTogNMINotificationsLeafMetadata(t *testing.T) {
+	path := func(elems ...*gnmipb.PathElem) *gnmipb.Path { return &gnmipb.Path{Elem: elems} }
+	strVal := func(s string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}}
+	}
+	p1 := &gnmipb.PathElem{Name: "list", Key: map[string]string{"val": "p1"}}
+
+	metadata := func() *LeafMetadataStore {
+		m := NewLeafMetadataStore()
+		m.Set(path(p1, &gnmipb.PathElem{Name: "val"}), &LeafMetadata{Timestamp: 10})
+		m.Set(path(p1, &gnmipb.PathElem{Name: "config"}, &gnmipb.PathElem{Name: "val"}), &LeafMetadata{Timestamp: 10})
+		m.Set(path(&gnmipb.PathElem{Name: "string-field"}), &LeafMetadata{Timestamp: 20, Origin: "openconfig", Target: "dut"})
+		return m
+	}
+	inStruct := func() *leafMetadataRoot {
+		return &leafMetadataRoot{
+			StringField: String("foo"),
+			List: map[string]*pathElemExampleChild{
+				"p1": {Val: String("p1"), OtherField: Uint8(42)},
+			},
+		}
+	}
+
+	want := []*gnmipb.Notification{{
+		Timestamp: 10,
+		Update: []*gnmipb.Update{{
+			Path: path(p1, &gnmipb.PathElem{Name: "val"}),
+			Val:  strVal("p1"),
+		}, {
+			Path: path(p1, &gnmipb.PathElem{Name: "config"}, &gnmipb.PathElem{Name: "val"}),
+			Val:  strVal("p1"),
+		}},
+	}, {
+		Timestamp: 20,
+		Prefix:    &gnmipb.Path{Origin: "openconfig", Target: "dut"},
+		Update: []*gnmipb.Update{{
+			Path: path(&gnmipb.PathElem{Name: "string-field"}),
+			Val:  strVal("foo"),
+		}},
+	}, {
+		Timestamp: 42,
+		Update: []*gnmipb.Update{{
+			Path: path(p1, &gnmipb.PathElem{Name: "other-field"}),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 42}},
+		}},
+	}}
+
+	tests := []struct {
+		name     string
+		inStruct func() *leafMetadataRoot
+		inConfig func() GNMINotificationsConfig
+		want     []*gnmipb.Notification
+	}{{
+		name: "metadata within struct annotation",
+		inStruct: func() *leafMetadataRoot {
+			s := inStruct()
+			if err := AttachLeafMetadata(s, metadata()); err != nil {
+				t.Fatalf("cannot attach metadata, %v", err)
+			}
+			return s
+		},
+		inConfig: func() GNMINotificationsConfig { return GNMINotificationsConfig{UsePathElem: true} },
+		want:     want,
+	}, {
+		name:     "metadata within config",
+		inStruct: inStruct,
+		inConfig: func() GNMINotificationsConfig {
+			return GNMINotificationsConfig{UsePathElem: true, LeafMetadata: metadata()}
+		},
+		want: want,
+	}, {
+		name:     "no metadata",
+		inStruct: inStruct,
+		inConfig: func() GNMINotificationsConfig { return GNMINotificationsConfig{UsePathElem: true} },
+		want: []*gnmipb.Notification{{
+			Timestamp: 42,
+			Update: []*gnmipb.Update{{
+				Path: path(&gnmipb.PathElem{Name: "string-field"}),
+				Val:  strVal("foo"),
+			}, {
+				Path: path(p1, &gnmipb.PathElem{Name: "val"}),
+				Val:  strVal("p1"),
+			}, {
+				Path: path(p1, &gnmipb.PathElem{Name: "config"}, &gnmipb.PathElem{Name: "val"}),
+				Val:  strVal("p1"),
+			}, {
+				Path: path(p1, &gnmipb.PathElem{Name: "other-field"}),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 42}},
+			}},
+		}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TogNMINotifications(tt.inStruct(), 42, tt.inConfig())
+			if err != nil {
+				t.Fatalf("TogNMINotifications: got unexpected error, %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("TogNMINotifications: did not get expected number of Notifications, got: %d, want: %d", len(got), len(tt.want))
+			}
+			// The order of the Notifications is deterministic, whereas the
+			// order of the updates within them is not.
+			for i := range got {
+				if !testutil.NotificationSetEqual(got[i:i+1], tt.want[i:i+1]) {
+					t.Errorf("TogNMINotifications: did not get expected Notification %d, diff(-got,+want):\n%s", i, cmp.Diff(got[i], tt.want[i], protocmp.Transform()))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/openconfig/ygot/util"
+)
+
+// Metadata is an Annotation that stores the metadata annotations of a data
+// node, as defined by RFC7952, for example the origin of a node described by
+// the ietf-origin module. Metadata is stored within the annotation fields of a
+// GoStruct, which are generated when the AddAnnotationFields Go generation
+// option is used: the struct-level annotation field (with path "@") stores the
+// metadata of a container or list entry, and the annotation field of a leaf
+// (with path "@leaf-name") stores the metadata of the leaf.
+//
+// When a GoStruct is marshalled to RFC7951 JSON, an annotation field that
+// contains only Metadata is rendered as the RFC7952 metadata object of the
+// data node, e.g.:
+//
+//	"@": {"ietf-origin:origin": "ietf-origin:intended"}
+type Metadata struct {
+	// Values is the value of each metadata annotation, keyed by the
+	// module-qualified name of the annotation, e.g., "ietf-origin:origin".
+	// Values are stored in their RFC7951 JSON encoding.
+	Values map[string]interface{}
+}
+
+// NewMetadata returns an empty Metadata.
+func NewMetadata() *Metadata {
+	return &Metadata{Values: map[string]interface{}{}}
+}
+
+// Set sets the value of the metadata annotation with the module-qualified
+// name to value, which must be the RFC7951 JSON encoding of the value.
+func (m *Metadata) Set(name string, value interface{}) error {
+	if err := validateMetadataName(name); err != nil {
+		return err
+	}
+	if m.Values == nil {
+		m.Values = map[string]interface{}{}
+	}
+	m.Values[name] = value
+	return nil
+}
+
+// Get returns the value of the metadata annotation with the module-qualified
+// name, and whether it is set.
+func (m *Metadata) Get(name string) (interface{}, bool) {
+	v, ok := m.Values[name]
+	return v, ok
+}
+
+// MarshalJSON implements the Annotation interface, marshalling the metadata
+// to an RFC7952 metadata object.
+func (m *Metadata) MarshalJSON() ([]byte, error) {
+	for n := range m.Values {
+		if err := validateMetadataName(n); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(m.Values)
+}
+
+// UnmarshalJSON implements the Annotation interface, unmarshalling an RFC7952
+// metadata object into the Metadata.
+func (m *Metadata) UnmarshalJSON(b []byte) error {
+	vals := map[string]interface{}{}
+	if err := json.Unmarshal(b, &vals); err != nil {
+		return err
+	}
+	for n := range vals {
+		if err := validateMetadataName(n); err != nil {
+			return err
+		}
+	}
+	m.Values = vals
+	return nil
+}
+
+// validateMetadataName returns an error if name is not a module-qualified
+// metadata annotation name, as required by RFC7952 Section 5.2.1.
+func validateMetadataName(name string) error {
+	ps := strings.Split(name, ":")
+	if len(ps) != 2 || ps[0] == "" || ps[1] == "" {
+		return fmt.Errorf("metadata annotation name %q is not of the form module:name", name)
+	}
+	return nil
+}
+
+// GetMetadata returns the Metadata that is stored for the field of the GoStruct
+// s with the name fieldName, or for s itself if fieldName is empty. It returns
+// nil if no Metadata is stored, and an error if s does not have an annotation
+// field for fieldName.
+func GetMetadata(s GoStruct, fieldName string) (*Metadata, error) {
+	f, err := metadataField(s, fieldName)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < f.Len(); i++ {
+		if md, ok := f.Index(i).Interface().(*Metadata); ok {
+			return md, nil
+		}
+	}
+	return nil, nil
+}
+
+// SetMetadata sets the value of the metadata annotation with the
+// module-qualified name to value for the field of the GoStruct s with the name
+// fieldName, or for s itself if fieldName is empty. The Metadata is created
+// within the annotation field if it does not already exist.
+func SetMetadata(s GoStruct, fieldName, name string, value interface{}) error {
+	f, err := metadataField(s, fieldName)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < f.Len(); i++ {
+		if md, ok := f.Index(i).Interface().(*Metadata); ok {
+			return md.Set(name, value)
+		}
+	}
+	md := NewMetadata()
+	if err := md.Set(name, value); err != nil {
+		return err
+	}
+	f.Set(reflect.Append(f, reflect.ValueOf(md)))
+	return nil
+}
+
+// metadataField returns the annotation field of the GoStruct s that annotates
+// the field with the name fieldName, or the struct-level annotation field if
+// fieldName is empty.
+func metadataField(s GoStruct, fieldName string) (reflect.Value, error) {
+	if fieldName == "" {
+		f, ok := structAnnotationField(s)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%T does not have a struct-level annotation field", s)
+		}
+		return f, nil
+	}
+
+	v := reflect.ValueOf(s)
+	if !util.IsValueStructPtr(v) {
+		return reflect.Value{}, fmt.Errorf("%T is not a struct ptr", s)
+	}
+	sv := v.Elem()
+	ft, ok := sv.Type().FieldByName(fieldName)
+	if !ok || util.IsYgotAnnotation(ft) {
+		return reflect.Value{}, fmt.Errorf("%T does not have a data field %s", s, fieldName)
+	}
+
+	// The path of the annotation field of a data field is the path of the
+	// data field with the last element prefixed by "@".
+	var ps []string
+	for _, p := range strings.Split(ft.Tag.Get("path"), "|") {
+		i := strings.LastIndex(p, "/") + 1
+		ps = append(ps, p[:i]+"@"+p[i:])
+	}
+	want := strings.Join(ps, "|")
+	for i := 0; i < sv.NumField(); i++ {
+		aft := sv.Type().Field(i)
+		if util.IsYgotAnnotation(aft) && aft.Tag.Get("path") == want && aft.Type == reflect.TypeOf([]Annotation{}) {
+			return sv.Field(i), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("%T does not have an annotation field for field %s", s, fieldName)
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+type metadataExample struct {
+	ΛMetadata []Annotation          `path:"@" ygotAnnotation:"true"`
+	F1        *string               `path:"f1" module:"f1mod"`
+	ΛF1       []Annotation          `path:"@f1" ygotAnnotation:"true" module:"f1mod"`
+	F2        *string               `path:"config/f2" module:"f2mod"`
+	ΛF2       []Annotation          `path:"config/@f2" ygotAnnotation:"true" module:"f2mod"`
+	Child     *metadataExampleChild `path:"child" module:"f1mod"`
+	ΛChild    []Annotation          `path:"@child" ygotAnnotation:"true" module:"f1mod"`
+}
+
+func (*metadataExample) IsYANGGoStruct() {}
+
+type metadataExampleChild struct {
+	ΛMetadata []Annotation `path:"@" ygotAnnotation:"true"`
+	F3        *string      `path:"f3" module:"f1mod"`
+}
+
+func (*metadataExampleChild) IsYANGGoStruct() {}
+
+func TestConstructIETFJSONMetadata(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               *metadataExample
+		inConfig         *RFC7951JSONConfig
+		want             map[string]interface{}
+		wantErrSubstring string
+	}{{
+		desc: "metadata of struct and fields",
+		in: &metadataExample{
+			ΛMetadata: []Annotation{&Metadata{Values: map[string]interface{}{"o:origin": "o:intended"}}},
+			F1:        String("one"),
+			ΛF1:       []Annotation{&Metadata{Values: map[string]interface{}{"o:origin": "o:learned"}}},
+			F2:        String("two"),
+			ΛF2: []Annotation{
+				&Metadata{Values: map[string]interface{}{"o:origin": "o:system"}},
+				&Metadata{Values: map[string]interface{}{"m:count": float64(1)}},
+			},
+			Child:  &metadataExampleChild{F3: String("three")},
+			ΛChild: []Annotation{&Metadata{Values: map[string]interface{}{"m:flag": []interface{}{nil}}}},
+		},
+		want: map[string]interface{}{
+			"@":   map[string]interface{}{"o:origin": "o:intended"},
+			"f1":  "one",
+			"@f1": map[string]interface{}{"o:origin": "o:learned"},
+			"config": map[string]interface{}{
+				"f2":  "two",
+				"@f2": map[string]interface{}{"o:origin": "o:system", "m:count": float64(1)},
+			},
+			"child":  map[string]interface{}{"f3": "three"},
+			"@child": map[string]interface{}{"m:flag": []interface{}{nil}},
+		},
+	}, {
+		desc: "metadata with module names",
+		in: &metadataExample{
+			F1:  String("one"),
+			ΛF1: []Annotation{&Metadata{Values: map[string]interface{}{"o:origin": "o:learned"}}},
+			F2:  String("two"),
+			ΛF2: []Annotation{&Metadata{Values: map[string]interface{}{"o:origin": "o:system"}}},
+			Child: &metadataExampleChild{
+				ΛMetadata: []Annotation{&Metadata{Values: map[string]interface{}{"o:origin": "o:intended"}}},
+				F3:        String("three"),
+			},
+		},
+		inConfig: &RFC7951JSONConfig{AppendModuleName: true},
+		want: map[string]interface{}{
+			"f1mod:f1":  "one",
+			"@f1mod:f1": map[string]interface{}{"o:origin": "o:learned"},
+			"f2mod:config": map[string]interface{}{
+				"f2":  "two",
+				"@f2": map[string]interface{}{"o:origin": "o:system"},
+			},
+			"f1mod:child": map[string]interface{}{
+				"@":  map[string]interface{}{"o:origin": "o:intended"},
+				"f3": "three",
+			},
+		},
+	}, {
+		desc: "empty metadata is omitted",
+		in: &metadataExample{
+			ΛMetadata: []Annotation{NewMetadata()},
+			F1:        String("one"),
+		},
+		want: map[string]interface{}{"f1": "one"},
+	}, {
+		desc: "unqualified metadata name",
+		in: &metadataExample{
+			ΛMetadata: []Annotation{&Metadata{Values: map[string]interface{}{"origin": "intended"}}},
+		},
+		wantErrSubstring: `metadata annotation name "origin" is not of the form module:name`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ConstructIETFJSON(tt.in, tt.inConfig)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ConstructIETFJSON: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ConstructIETFJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	s := &metadataExample{}
+	if err := SetMetadata(s, "", "o:origin", "o:intended"); err != nil {
+		t.Fatalf("SetMetadata(struct): got unexpected error, %v", err)
+	}
+	if err := SetMetadata(s, "F2", "m:count", float64(2)); err != nil {
+		t.Fatalf("SetMetadata(F2): got unexpected error, %v", err)
+	}
+	if err := SetMetadata(s, "F2", "m:flag", []interface{}{nil}); err != nil {
+		t.Fatalf("SetMetadata(F2): got unexpected error, %v", err)
+	}
+
+	want := &metadataExample{
+		ΛMetadata: []Annotation{&Metadata{Values: map[string]interface{}{"o:origin": "o:intended"}}},
+		ΛF2:       []Annotation{&Metadata{Values: map[string]interface{}{"m:count": float64(2), "m:flag": []interface{}{nil}}}},
+	}
+	if diff := cmp.Diff(want, s); diff != "" {
+		t.Errorf("SetMetadata: did not get expected struct, diff(-want, +got):\n%s", diff)
+	}
+
+	md, err := GetMetadata(s, "F2")
+	if err != nil {
+		t.Fatalf("GetMetadata(F2): got unexpected error, %v", err)
+	}
+	if v, ok := md.Get("m:count"); !ok || v != float64(2) {
+		t.Errorf("Get(m:count): got %v, %v, want 2, true", v, ok)
+	}
+	if md, err := GetMetadata(s, "F1"); err != nil || md != nil {
+		t.Errorf("GetMetadata(F1): got %v, %v, want nil, nil", md, err)
+	}
+
+	for _, tt := range []struct {
+		desc             string
+		inField          string
+		inName           string
+		wantErrSubstring string
+	}{
+		{desc: "unqualified name", inName: "origin", wantErrSubstring: `metadata annotation name "origin" is not of the form module:name`},
+		{desc: "unknown field", inField: "F4", inName: "o:origin", wantErrSubstring: "does not have a data field F4"},
+		{desc: "annotation field", inField: "ΛF1", inName: "o:origin", wantErrSubstring: "does not have a data field ΛF1"},
+		{desc: "field without annotation field", inField: "F3", inName: "o:origin", wantErrSubstring: "does not have an annotation field for field F3"},
+	} {
+		var gs GoStruct = s
+		if tt.inField == "F3" {
+			gs = &metadataExampleChild{}
+		}
+		err := SetMetadata(gs, tt.inField, tt.inName, "x")
+		if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+			t.Errorf("%s: SetMetadata: did not get expected error, %s", tt.desc, diff)
+		}
+	}
+}
+
+func TestMetadataJSON(t *testing.T) {
+	md := &Metadata{}
+	if err := md.UnmarshalJSON([]byte(`{"o:origin": "o:intended", "m:count": 1}`)); err != nil {
+		t.Fatalf("UnmarshalJSON: got unexpected error, %v", err)
+	}
+	b, err := md.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: got unexpected error, %v", err)
+	}
+	if got, want := string(b), `{"m:count":1,"o:origin":"o:intended"}`; got != want {
+		t.Errorf("MarshalJSON: got %s, want %s", got, want)
+	}
+	if err := md.UnmarshalJSON([]byte(`{"origin": "intended"}`)); err == nil {
+		t.Errorf("UnmarshalJSON(unqualified name): did not get expected error")
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// isOrderedMapValue reports whether v is an ordered map that is generated for
+// a YANG list that is "ordered-by user".
+func isOrderedMapValue(v reflect.Value) bool {
+	return util.IsValueOrderedMap(v)
+}
+
+// orderedMapEntries returns the keys and the entries of the ordered map v,
+// such that the entry at each index of the returned slices has the key at
+// the same index.
+func orderedMapEntries(v reflect.Value) ([]reflect.Value, []reflect.Value, error) {
+	keys, err := util.OrderedMapKeys(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	vals, err := util.OrderedMapValues(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(keys) != len(vals) {
+		return nil, nil, fmt.Errorf("ordered map %v has %d keys, but %d values", v.Type(), len(keys), len(vals))
+	}
+	return keys, vals, nil
+}
+
+// orderedMapJSON takes an input reflect.Value containing an ordered map, and
+// constructs the representation for JSON marshalling that corresponds to it.
+// For RFC7951 JSON, the entries are output as an array in the order that
+// they are stored. Internal JSON represents a list as an object, and hence
+// the order of its entries is not retained.
+func orderedMapJSON(field reflect.Value, parentMod string, args jsonOutputConfig) (interface{}, error) {
+	_, vals, err := orderedMapEntries(field)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) == 0 {
+		return nil, nil
+	}
+
+	switch args.jType {
+	case RFC7951:
+		var errs errlist.List
+		out := []interface{}{}
+		for _, v := range vals {
+			goStruct, ok := v.Interface().(GoStruct)
+			if !ok {
+				errs.Add(fmt.Errorf("cannot map struct %v, invalid GoStruct", v))
+				continue
+			}
+			val, err := structJSON(goStruct, parentMod, args)
+			if err != nil {
+				errs.Add(err)
+				continue
+			}
+			out = append(out, val)
+		}
+		if errs.Err() != nil {
+			return nil, errs.Err()
+		}
+		return out, nil
+	case Internal:
+		m, err := util.OrderedMapToMap(field)
+		if err != nil {
+			return nil, err
+		}
+		return mapJSON(m, parentMod, args)
+	default:
+		return nil, fmt.Errorf("unknown JSON type: %v", args.jType)
+	}
+}
+
+// orderedMapTypedValue returns the gNMI TypedValue that encodes the entries of
+// the ordered map m using the supplied encoding, which must be JSON or
+// JSON_IETF.
+func orderedMapTypedValue(m GoOrderedMap, enc gnmipb.Encoding) (*gnmipb.TypedValue, error) {
+	v := reflect.ValueOf(m)
+	if util.IsNilOrInvalidValue(v) {
+		return nil, nil
+	}
+
+	var (
+		j     interface{}
+		err   error
+		encfn func(b []byte) *gnmipb.TypedValue
+	)
+	switch enc {
+	case gnmipb.Encoding_JSON:
+		j, err = orderedMapJSON(v, "", jsonOutputConfig{jType: Internal})
+		encfn = func(b []byte) *gnmipb.TypedValue {
+			return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonVal{JsonVal: b}}
+		}
+	case gnmipb.Encoding_JSON_IETF:
+		// We always append the module name when marshalling within a Notification.
+		j, err = orderedMapJSON(v, "", jsonOutputConfig{
+			jType:         RFC7951,
+			rfc7951Config: &RFC7951JSONConfig{AppendModuleName: true},
+		})
+		encfn = func(b []byte) *gnmipb.TypedValue {
+			return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: b}}
+		}
+	default:
+		return nil, fmt.Errorf("invalid encoding %v", gnmipb.Encoding_name[int32(enc)])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode JSON, %v", err)
+	}
+	return encfn(js), nil
+}
+
+// copyOrderedMapField copies srcField into dstField, which are both pointers
+// to an ordered map of the same type. Entries that are present in both are
+// merged, as per copyMapField. The merged ordered map contains the entries of
+// dstField in their existing order, followed by the entries that are only
+// present in srcField, in the order of srcField.
+func copyOrderedMapField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
+	if srcField.Type() != dstField.Type() {
+		return fmt.Errorf("cannot copy ordered map of type %v to %v", srcField.Type(), dstField.Type())
+	}
+
+	srcKeys, srcVals, err := orderedMapEntries(srcField)
+	if err != nil {
+		return err
+	}
+	var dstKeys, dstVals []reflect.Value
+	if !util.IsNilOrInvalidValue(dstField) {
+		if dstKeys, dstVals, err = orderedMapEntries(dstField); err != nil {
+			return err
+		}
+	}
+
+	type entry struct {
+		src, dst reflect.Value
+	}
+	var order []interface{}
+	entries := map[interface{}]*entry{}
+	for i, k := range dstKeys {
+		order = append(order, k.Interface())
+		entries[k.Interface()] = &entry{dst: dstVals[i]}
+	}
+	for i, k := range srcKeys {
+		e, ok := entries[k.Interface()]
+		if !ok {
+			e = &entry{}
+			order = append(order, k.Interface())
+			entries[k.Interface()] = e
+		}
+		e.src = srcVals[i]
+	}
+
+	nm := reflect.New(srcField.Type().Elem())
+	for _, k := range order {
+		e := entries[k]
+		var d reflect.Value
+		// As per copyMapField, the source entry is copied before the
+		// destination entry.
+		for _, v := range []reflect.Value{e.src, e.dst} {
+			if util.IsNilOrInvalidValue(v) {
+				continue
+			}
+			if !d.IsValid() {
+				d = reflect.New(v.Elem().Type())
+			}
+			if err := copyStruct(d.Elem(), v.Elem(), opts...); err != nil {
+				return err
+			}
+		}
+		if !d.IsValid() {
+			continue
+		}
+		if err := util.AppendIntoOrderedMap(nm.Interface(), d.Interface()); err != nil {
+			return err
+		}
+	}
+	dstField.Set(nm)
+	return nil
+}
@@ -0,0 +1,482 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// orderedRoot is a GoStruct containing an ordered map, as generated for a
+// YANG list that is ordered-by user.
+type orderedRoot struct {
+	Name *string     `path:"name"`
+	List *orderedMap `path:"list"`
+}
+
+func (*orderedRoot) Validate(...ValidationOption) error      { return nil }
+func (*orderedRoot) IsYANGGoStruct()                         {}
+func (*orderedRoot) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+
+type orderedListMember struct {
+	Key   *string `path:"config/key|key"`
+	Value *string `path:"config/value"`
+}
+
+func (*orderedListMember) IsYANGGoStruct() {}
+
+func (l *orderedListMember) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"key": *l.Key}, nil
+}
+
+// orderedMap is an ordered map of orderedListMember structs, which mirrors
+// the ordered maps that are generated by ygen.
+type orderedMap struct {
+	keys     []string
+	valueMap map[string]*orderedListMember
+}
+
+func (*orderedMap) IsYANGOrderedList() {}
+
+func (o *orderedMap) Keys() []string {
+	if o == nil {
+		return nil
+	}
+	return append([]string{}, o.keys...)
+}
+
+func (o *orderedMap) Values() []*orderedListMember {
+	if o == nil {
+		return nil
+	}
+	var values []*orderedListMember
+	for _, k := range o.keys {
+		values = append(values, o.valueMap[k])
+	}
+	return values
+}
+
+func (o *orderedMap) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.keys)
+}
+
+func (o *orderedMap) Append(v *orderedListMember) error {
+	if v == nil || v.Key == nil {
+		return fmt.Errorf("invalid entry %v", v)
+	}
+	if _, ok := o.valueMap[*v.Key]; ok {
+		return fmt.Errorf("duplicate key %s", *v.Key)
+	}
+	if o.valueMap == nil {
+		o.valueMap = map[string]*orderedListMember{}
+	}
+	o.keys = append(o.keys, *v.Key)
+	o.valueMap[*v.Key] = v
+	return nil
+}
+
+// newOrderedMap returns an ordered map containing entries with the supplied
+// keys, in order, whose values are their keys suffixed with "-val".
+func newOrderedMap(t *testing.T, keys ...string) *orderedMap {
+	t.Helper()
+	o := &orderedMap{}
+	for _, k := range keys {
+		if err := o.Append(&orderedListMember{Key: String(k), Value: String(k + "-val")}); err != nil {
+			t.Fatalf("cannot append %s to ordered map, %v", k, err)
+		}
+	}
+	return o
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	tests := []struct {
+		desc     string
+		inStruct GoStruct
+		inIETF   bool
+		want     string
+	}{{
+		desc:     "RFC7951 retains order",
+		inStruct: &orderedRoot{List: newOrderedMap(t, "b", "c", "a")},
+		inIETF:   true,
+		want: `{
+  "list": [
+    {
+      "config": {
+        "key": "b",
+        "value": "b-val"
+      },
+      "key": "b"
+    },
+    {
+      "config": {
+        "key": "c",
+        "value": "c-val"
+      },
+      "key": "c"
+    },
+    {
+      "config": {
+        "key": "a",
+        "value": "a-val"
+      },
+      "key": "a"
+    }
+  ]
+}`,
+	}, {
+		desc:     "internal JSON",
+		inStruct: &orderedRoot{List: newOrderedMap(t, "b", "a")},
+		want: `{
+  "list": {
+    "a": {
+      "config": {
+        "key": "a",
+        "value": "a-val"
+      },
+      "key": "a"
+    },
+    "b": {
+      "config": {
+        "key": "b",
+        "value": "b-val"
+      },
+      "key": "b"
+    }
+  }
+}`,
+	}, {
+		desc:     "empty ordered map",
+		inStruct: &orderedRoot{Name: String("n"), List: &orderedMap{}},
+		inIETF:   true,
+		want: `{
+  "name": "n"
+}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var (
+				got map[string]interface{}
+				err error
+			)
+			if tt.inIETF {
+				got, err = ConstructIETFJSON(tt.inStruct, nil)
+			} else {
+				got, err = ConstructInternalJSON(tt.inStruct)
+			}
+			if err != nil {
+				t.Fatalf("cannot construct JSON, %v", err)
+			}
+			js, err := json.MarshalIndent(got, "", "  ")
+			if err != nil {
+				t.Fatalf("cannot marshal JSON, %v", err)
+			}
+			if diff := cmp.Diff(tt.want, string(js)); diff != "" {
+				t.Errorf("did not get expected JSON, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEncodeTypedValueOrderedMap(t *testing.T) {
+	got, err := EncodeTypedValue(newOrderedMap(t, "b", "a"), gnmipb.Encoding_JSON_IETF)
+	if err != nil {
+		t.Fatalf("EncodeTypedValue: got unexpected error, %v", err)
+	}
+	var entries []map[string]interface{}
+	if err := json.Unmarshal(got.GetJsonIetfVal(), &entries); err != nil {
+		t.Fatalf("cannot unmarshal JSON_IETF value %s, %v", got.GetJsonIetfVal(), err)
+	}
+	var keys []interface{}
+	for _, e := range entries {
+		keys = append(keys, e["key"])
+	}
+	if want := []interface{}{"b", "a"}; !cmp.Equal(keys, want) {
+		t.Errorf("EncodeTypedValue: did not get entries in order, got keys: %v, want: %v", keys, want)
+	}
+
+	if _, err := EncodeTypedValue(newOrderedMap(t, "a"), gnmipb.Encoding_PROTO); err == nil {
+		t.Errorf("EncodeTypedValue: did not get expected error for PROTO encoding")
+	}
+}
+
+func TestTogNMINotificationsOrderedMap(t *testing.T) {
+	got, err := TogNMINotifications(&orderedRoot{List: newOrderedMap(t, "b", "a")}, 42, GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		t.Fatalf("TogNMINotifications: got unexpected error, %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("TogNMINotifications: got %d notifications, want 1", len(got))
+	}
+
+	var gotPaths []string
+	for _, u := range got[0].Update {
+		p, err := PathToString(u.Path)
+		if err != nil {
+			t.Fatalf("cannot convert path %v to string, %v", u.Path, err)
+		}
+		gotPaths = append(gotPaths, p)
+	}
+	wantPaths := []string{
+		"/list[key=b]/config/key",
+		"/list[key=b]/key",
+		"/list[key=b]/config/value",
+		"/list[key=a]/config/key",
+		"/list[key=a]/key",
+		"/list[key=a]/config/value",
+	}
+	if diff := cmp.Diff(wantPaths, gotPaths); diff != "" {
+		t.Errorf("TogNMINotifications: did not get updates in order, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestIsReordered(t *testing.T) {
+	tests := []struct {
+		desc   string
+		inOrig []string
+		inMod  []string
+		want   bool
+	}{{
+		desc:   "same order",
+		inOrig: []string{"a", "b", "c"},
+		inMod:  []string{"a", "b", "c"},
+	}, {
+		desc:   "entries appended and deleted",
+		inOrig: []string{"a", "b", "c"},
+		inMod:  []string{"a", "c", "d"},
+	}, {
+		desc:   "common entries swapped",
+		inOrig: []string{"a", "b"},
+		inMod:  []string{"b", "a"},
+		want:   true,
+	}, {
+		desc:   "new entry inserted before existing entry",
+		inOrig: []string{"a", "b"},
+		inMod:  []string{"a", "c", "b"},
+		want:   true,
+	}, {
+		desc:  "no original entries",
+		inMod: []string{"b", "a"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := isReordered(tt.inOrig, tt.inMod); got != tt.want {
+				t.Errorf("isReordered(%v, %v): got %v, want %v", tt.inOrig, tt.inMod, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffOrderedMap(t *testing.T) {
+	path := func(s string) *gnmipb.Path {
+		p, err := StringToStructuredPath(s)
+		if err != nil {
+			t.Fatalf("cannot parse path %s, %v", s, err)
+		}
+		return p
+	}
+	update := func(p, v string) *gnmipb.Update {
+		return &gnmipb.Update{Path: path(p), Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: v}}}
+	}
+
+	tests := []struct {
+		desc       string
+		inOrig     *orderedRoot
+		inMod      *orderedRoot
+		want       *gnmipb.Notification
+		wantErrSub string
+	}{{
+		desc:   "unchanged",
+		inOrig: &orderedRoot{List: newOrderedMap(t, "a", "b")},
+		inMod:  &orderedRoot{List: newOrderedMap(t, "a", "b")},
+		want:   &gnmipb.Notification{},
+	}, {
+		desc:   "entry appended",
+		inOrig: &orderedRoot{List: newOrderedMap(t, "a")},
+		inMod:  &orderedRoot{List: newOrderedMap(t, "a", "b")},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{
+				update("/list[key=b]/config/key", "b"),
+				update("/list[key=b]/key", "b"),
+				update("/list[key=b]/config/value", "b-val"),
+			},
+		},
+	}, {
+		desc:   "entries reordered",
+		inOrig: &orderedRoot{Name: String("n"), List: newOrderedMap(t, "a", "b")},
+		inMod:  &orderedRoot{Name: String("n"), List: newOrderedMap(t, "b", "a")},
+		want: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{path("/list")},
+			Update: []*gnmipb.Update{
+				update("/list[key=b]/config/key", "b"),
+				update("/list[key=b]/key", "b"),
+				update("/list[key=b]/config/value", "b-val"),
+				update("/list[key=a]/config/key", "a"),
+				update("/list[key=a]/key", "a"),
+				update("/list[key=a]/config/value", "a-val"),
+			},
+		},
+	}, {
+		desc:   "entry inserted before existing entry",
+		inOrig: &orderedRoot{List: newOrderedMap(t, "a")},
+		inMod:  &orderedRoot{List: newOrderedMap(t, "b", "a")},
+		want: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{path("/list")},
+			Update: []*gnmipb.Update{
+				update("/list[key=b]/config/key", "b"),
+				update("/list[key=b]/key", "b"),
+				update("/list[key=b]/config/value", "b-val"),
+				update("/list[key=a]/config/key", "a"),
+				update("/list[key=a]/key", "a"),
+				update("/list[key=a]/config/value", "a-val"),
+			},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Diff(tt.inOrig, tt.inMod)
+			if diff := errdiff.Substring(err, tt.wantErrSub); diff != "" {
+				t.Fatalf("Diff: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Diff: did not get expected notification, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffToSetRequestOrderedMap(t *testing.T) {
+	orig := &orderedRoot{Name: String("n"), List: newOrderedMap(t, "a", "b", "c")}
+	mod := &orderedRoot{Name: String("m"), List: newOrderedMap(t, "c", "a")}
+
+	got, err := DiffToSetRequest(orig, mod, &DiffSetRequestOpt{JSONIETF: true})
+	if err != nil {
+		t.Fatalf("DiffToSetRequest: got unexpected error, %v", err)
+	}
+
+	wantVal, err := EncodeTypedValue(mod.List, gnmipb.Encoding_JSON_IETF)
+	if err != nil {
+		t.Fatalf("cannot encode ordered map, %v", err)
+	}
+	nameVal, err := EncodeTypedValue(&orderedRoot{Name: String("m")}, gnmipb.Encoding_JSON_IETF)
+	if err != nil {
+		t.Fatalf("cannot encode root, %v", err)
+	}
+	want := &gnmipb.SetRequest{
+		Replace: []*gnmipb.Update{{Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "list"}}}, Val: wantVal}},
+		Update:  []*gnmipb.Update{{Path: &gnmipb.Path{}, Val: nameVal}},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("DiffToSetRequest: did not get expected SetRequest, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestCopyOrderedMap(t *testing.T) {
+	tests := []struct {
+		desc     string
+		inSrc    *orderedRoot
+		inDst    *orderedRoot
+		wantKeys []string
+		wantVals []string
+	}{{
+		desc:     "copy into empty struct",
+		inSrc:    &orderedRoot{List: newOrderedMap(t, "c", "a", "b")},
+		inDst:    &orderedRoot{},
+		wantKeys: []string{"c", "a", "b"},
+		wantVals: []string{"c-val", "a-val", "b-val"},
+	}, {
+		desc:  "merge retains order of destination",
+		inSrc: &orderedRoot{List: newOrderedMap(t, "c", "a")},
+		inDst: &orderedRoot{List: func() *orderedMap {
+			o := &orderedMap{}
+			o.Append(&orderedListMember{Key: String("a")})
+			o.Append(&orderedListMember{Key: String("b"), Value: String("dst")})
+			return o
+		}()},
+		wantKeys: []string{"a", "b", "c"},
+		wantVals: []string{"a-val", "dst", "c-val"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := MergeStructInto(tt.inDst, tt.inSrc); err != nil {
+				t.Fatalf("MergeStructInto: got unexpected error, %v", err)
+			}
+			var gotVals []string
+			for _, v := range tt.inDst.List.Values() {
+				gotVals = append(gotVals, *v.Value)
+			}
+			if diff := cmp.Diff(tt.wantKeys, tt.inDst.List.Keys()); diff != "" {
+				t.Errorf("MergeStructInto: did not get expected keys, diff(-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantVals, gotVals); diff != "" {
+				t.Errorf("MergeStructInto: did not get expected values, diff(-want, +got):\n%s", diff)
+			}
+			// The copied entries must not be shared with the source.
+			for _, v := range tt.inSrc.List.Values() {
+				for _, d := range tt.inDst.List.Values() {
+					if v == d {
+						t.Errorf("MergeStructInto: entry %s is shared between source and destination", *v.Key)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMergeStructsThreeWayOrderedMap(t *testing.T) {
+	base := &orderedRoot{List: newOrderedMap(t, "a", "b", "c")}
+	ours := &orderedRoot{List: newOrderedMap(t, "c", "a", "b")}
+	theirs := &orderedRoot{List: newOrderedMap(t, "b", "c", "d")}
+
+	got, conflicts, err := MergeStructsThreeWay(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("MergeStructsThreeWay: got unexpected error, %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("MergeStructsThreeWay: got unexpected conflicts, %v", conflicts)
+	}
+	// The entry "a" is deleted in theirs, and "d" is added.
+	if diff := cmp.Diff([]string{"c", "b", "d"}, got.(*orderedRoot).List.Keys()); diff != "" {
+		t.Errorf("MergeStructsThreeWay: did not get expected order, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestPruneEmptyBranchesOrderedMap(t *testing.T) {
+	in := &orderedRoot{Name: String("n"), List: &orderedMap{}}
+	PruneEmptyBranches(in)
+	if in.List != nil {
+		t.Errorf("PruneEmptyBranches: empty ordered map was not pruned, got: %v", in.List)
+	}
+
+	in = &orderedRoot{List: newOrderedMap(t, "a")}
+	PruneEmptyBranches(in)
+	if got := in.List.Keys(); !cmp.Equal(got, []string{"a"}) {
+		t.Errorf("PruneEmptyBranches: populated ordered map was pruned, got keys: %v", got)
+	}
+}
@@ -315,6 +315,11 @@ type GNMINotificationsConfig struct {
 	// of PathElem messages. This path format is used by gNMI 0.4.0 and
 	// above. Used if PathElem is set.
 	PathElemPrefix []*gnmipb.PathElem
+	// LeafMetadata stores the per-leaf metadata that should be used when
+	// rendering the notifications. If it is unset, the LeafMetadataStore
+	// within the struct-level annotation field of the input GoStruct is
+	// used, if one exists.
+	LeafMetadata *LeafMetadataStore
 }
 
 // TogNMINotifications takes an input GoStruct and renders it to slice of
@@ -322,6 +327,11 @@ type GNMINotificationsConfig struct {
 // provided determines the path format utilised, and the prefix to be included
 // in the message if relevant.
 //
+// Where per-leaf metadata is available, each leaf that has metadata is rendered
+// within a Notification marked with its recorded timestamp, origin and target,
+// such that a Notification is returned for each distinct set of these values.
+// The paths of the leaves in the metadata are relative to the input GoStruct.
+//
 // TODO(robjs): When we have deprecated the string slice paths, then this function
 // can be simplified to remove support for them - including removing the gnmiPath
 // abstraction. It can also be refactored to simply use the findSetleaves function
@@ -336,11 +346,17 @@ func TogNMINotifications(s GoStruct, ts int64, cfg GNMINotificationsConfig) ([]*
 	}
 
 	leaves := map[*path]interface{}{}
-	if err := findUpdatedLeaves(leaves, s, pfx); err != nil {
+	var order []*path
+	if err := findUpdatedLeaves(leaves, &order, s, pfx); err != nil {
 		return nil, err
 	}
 
-	msgs, err := leavesToNotifications(leaves, ts, pfx)
+	md := cfg.LeafMetadata
+	if md == nil {
+		md = LeafMetadataFromGoStruct(s)
+	}
+
+	msgs, err := leavesToNotifications(leaves, order, ts, pfx, md)
 	if err != nil {
 		return nil, err
 	}
@@ -353,8 +369,10 @@ func TogNMINotifications(s GoStruct, ts int64, cfg GNMINotificationsConfig) ([]*
 // If errors are encountered they are appended to the errlist.List supplied. If
 // the GoStruct contains fields that are themselves structured objects (YANG
 // lists, or containers - represented as maps or struct pointers), the function
-// is called recursively on them.
-func findUpdatedLeaves(leaves map[*path]interface{}, s GoStruct, parent *gnmiPath) error {
+// is called recursively on them. If order is non-nil, the path of each leaf is
+// appended to it in the order that the leaves are found, such that the leaves
+// of the entries of an ordered map are in the order of the entries.
+func findUpdatedLeaves(leaves map[*path]interface{}, order *[]*path, s GoStruct, parent *gnmiPath) error {
 	var errs errlist.List
 
 	if !parent.isValid() {
@@ -374,6 +392,11 @@ func findUpdatedLeaves(leaves map[*path]interface{}, s GoStruct, parent *gnmiPat
 		fval := sval.Field(i)
 		ftype := stype.Field(i)
 
+		// Annotation fields do not store values within the data tree.
+		if util.IsYgotAnnotation(ftype) {
+			continue
+		}
+
 		// Handle nil values, and enumerations specifically.
 		switch fval.Kind() {
 		case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
@@ -403,21 +426,48 @@ func findUpdatedLeaves(leaves map[*path]interface{}, s GoStruct, parent *gnmiPat
 					errs.Add(fmt.Errorf("%v: was not a valid GoStruct", mapPaths[0]))
 					continue
 				}
-				errs.Add(findUpdatedLeaves(leaves, goStruct, childPath))
+				errs.Add(findUpdatedLeaves(leaves, order, goStruct, childPath))
 			}
 		case reflect.Ptr:
 			// Determine whether this is a pointer to a struct (another YANG container), or a leaf.
-			switch fval.Elem().Kind() {
-			case reflect.Struct:
+			switch {
+			case isAnydataValue(fval):
+				// Anydata is output as a single value at its path.
+				for _, p := range mapPaths {
+					addLeaf(leaves, order, p, fval.Interface())
+				}
+			case isOrderedMapValue(fval):
+				// An ordered map is a keyed list, whose children are mapped
+				// along with their key values as per a map.
+				keys, vals, err := orderedMapEntries(fval)
+				if err != nil {
+					errs.Add(err)
+					continue
+				}
+				for i, k := range keys {
+					childPath, err := mapValuePath(k, vals[i], mapPaths[0])
+					if err != nil {
+						errs.Add(err)
+						continue
+					}
+
+					goStruct, ok := vals[i].Interface().(GoStruct)
+					if !ok {
+						errs.Add(fmt.Errorf("%v: was not a valid GoStruct", mapPaths[0]))
+						continue
+					}
+					errs.Add(findUpdatedLeaves(leaves, order, goStruct, childPath))
+				}
+			case fval.Elem().Kind() == reflect.Struct:
 				goStruct, ok := fval.Interface().(GoStruct)
 				if !ok {
 					errs.Add(fmt.Errorf("%v: was not a valid GoStruct", mapPaths[0]))
 					continue
 				}
-				errs.Add(findUpdatedLeaves(leaves, goStruct, mapPaths[0]))
+				errs.Add(findUpdatedLeaves(leaves, order, goStruct, mapPaths[0]))
 			default:
 				for _, p := range mapPaths {
-					leaves[&path{p}] = fval.Interface()
+					addLeaf(leaves, order, p, fval.Interface())
 				}
 			}
 		case reflect.Slice:
@@ -429,7 +479,7 @@ func findUpdatedLeaves(leaves map[*path]interface{}, s GoStruct, parent *gnmiPat
 			}
 			// This is a leaf-list, so add it as though it were a leaf.
 			for _, p := range mapPaths {
-				leaves[&path{p}] = fval.Interface()
+				addLeaf(leaves, order, p, fval.Interface())
 			}
 		case reflect.Int64:
 			name, set, err := enumFieldToString(fval, false)
@@ -444,13 +494,13 @@ func findUpdatedLeaves(leaves map[*path]interface{}, s GoStruct, parent *gnmiPat
 			}
 
 			for _, p := range mapPaths {
-				leaves[&path{p}] = name
+				addLeaf(leaves, order, p, name)
 			}
 			continue
 		case reflect.Interface:
 			// This is a union value.
 			for _, p := range mapPaths {
-				leaves[&path{p}] = fval.Interface()
+				addLeaf(leaves, order, p, fval.Interface())
 			}
 			continue
 		}
@@ -458,6 +508,16 @@ func findUpdatedLeaves(leaves map[*path]interface{}, s GoStruct, parent *gnmiPat
 	return errs.Err()
 }
 
+// addLeaf adds the leaf with the path p and value v to the supplied leaves
+// map, and appends its path to order if it is non-nil.
+func addLeaf(leaves map[*path]interface{}, order *[]*path, p *gnmiPath, v interface{}) {
+	pk := &path{p}
+	leaves[pk] = v
+	if order != nil {
+		*order = append(*order, pk)
+	}
+}
+
 // mapValuePath calculates the gNMI Path of a map element with the specified
 // key and value. The format of the path returned depends on the input format
 // of the parentPath.
@@ -625,23 +685,34 @@ func sliceToScalarArray(v []interface{}) (*gnmipb.ScalarArray, error) {
 
 // leavesToNotifications takes an input map of leaves, and outputs a slice of
 // notifications that corresponds to the leaf update, the supplied timestamp is
-// used in the set of notifications. If an error is encountered it is returned.
-// TODO(robjs): Currently, we return only a single Notification, but this is
-// likely to be suboptimal since it results in very large Notifications for particular
-// structs. There should be some fragmentation of Updates across Notification messages
-// in a future implementation. We return a slice to keep the API stable.
-func leavesToNotifications(leaves map[*path]interface{}, ts int64, pfx *gnmiPath) ([]*gnmipb.Notification, error) {
-	n := &gnmipb.Notification{
-		Timestamp: ts,
-	}
-
+// used in the set of notifications. The updates are output in the order of the
+// supplied paths of the leaves. If an error is encountered it is returned.
+// Where the supplied LeafMetadataStore is non-nil, leaves that have metadata
+// are placed in a Notification with their recorded timestamp, origin and target,
+// and the Notifications are returned ordered by timestamp.
+// TODO(robjs): Currently, we return only a single Notification when there is no
+// leaf metadata, but this is likely to be suboptimal since it results in very
+// large Notifications for particular structs. There should be some fragmentation
+// of Updates across Notification messages in a future implementation. We return
+// a slice to keep the API stable.
+func leavesToNotifications(leaves map[*path]interface{}, order []*path, ts int64, pfx *gnmiPath, md *LeafMetadataStore) ([]*gnmipb.Notification, error) {
 	p, err := pfx.ToProto()
 	if err != nil {
 		return nil, err
 	}
-	n.Prefix = p
 
-	for pk, v := range leaves {
+	// notificationKey identifies the Notification that a leaf is output in.
+	type notificationKey struct {
+		ts             int64
+		origin, target string
+	}
+	defKey := notificationKey{ts: ts}
+	msgs := map[notificationKey]*gnmipb.Notification{
+		defKey: {Timestamp: ts, Prefix: p},
+	}
+
+	for _, pk := range order {
+		v := leaves[pk]
 		path, err := pk.p.StripPrefix(pfx)
 		if err != nil {
 			return nil, err
@@ -657,19 +728,66 @@ func leavesToNotifications(leaves map[*path]interface{}, ts int64, pfx *gnmiPath
 			return nil, err
 		}
 
+		k := defKey
+		if md != nil {
+			if m, ok := md.Get(ppath); ok {
+				k = notificationKey{ts: m.Timestamp, origin: m.Origin, target: m.Target}
+			}
+		}
+
+		n, ok := msgs[k]
+		if !ok {
+			n = &gnmipb.Notification{Timestamp: k.ts, Prefix: p}
+			if k.origin != "" || k.target != "" {
+				np := &gnmipb.Path{}
+				if p != nil {
+					np = proto.Clone(p).(*gnmipb.Path)
+				}
+				np.Origin, np.Target = k.origin, k.target
+				n.Prefix = np
+			}
+			msgs[k] = n
+		}
+
 		n.Update = append(n.Update, &gnmipb.Update{
 			Path: ppath,
 			Val:  val,
 		})
 	}
 
-	return []*gnmipb.Notification{n}, nil
+	if len(msgs) > 1 && len(msgs[defKey].Update) == 0 {
+		delete(msgs, defKey)
+	}
+
+	keys := []notificationKey{}
+	for k := range msgs {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		switch {
+		case keys[i].ts != keys[j].ts:
+			return keys[i].ts < keys[j].ts
+		case keys[i].origin != keys[j].origin:
+			return keys[i].origin < keys[j].origin
+		}
+		return keys[i].target < keys[j].target
+	})
+
+	ns := []*gnmipb.Notification{}
+	for _, k := range keys {
+		ns = append(ns, msgs[k])
+	}
+	return ns, nil
 }
 
 // EncodeTypedValue encodes val into a gNMI TypedValue message, using the specified encoding
 // type if the value is a struct.
 func EncodeTypedValue(val interface{}, enc gnmipb.Encoding) (*gnmipb.TypedValue, error) {
 	switch v := val.(type) {
+	case *Anydata:
+		return anydataTypedValue(v)
+	case GoOrderedMap:
+		return orderedMapTypedValue(v, enc)
 	case GoStruct:
 		return marshalStruct(v, enc)
 	case GoEnum:
@@ -917,6 +1035,12 @@ type RFC7951JSONConfig struct {
 	// elements that are defined within a different YANG module than their
 	// parent.
 	AppendModuleName bool
+	// WithDefaults specifies how leaves that have default values in the
+	// schema are rendered. By default, the explicit mode is used, in which
+	// only the leaves that are set within the GoStruct are rendered. The
+	// report-all and trim modes require the GoStruct to implement the
+	// DefaultsGoStruct interface, and are applied to a copy of it.
+	WithDefaults WithDefaultsMode
 }
 
 // IsMarshal7951Arg marks the RFC7951JSONConfig struct as a valid argument to
@@ -929,6 +1053,12 @@ func (*RFC7951JSONConfig) IsMarshal7951Arg() {}
 // the module name should be appended to entities that are defined in a different
 // module to their parent.
 func ConstructIETFJSON(s GoStruct, args *RFC7951JSONConfig) (map[string]interface{}, error) {
+	if args != nil {
+		var err error
+		if s, err = applyWithDefaults(s, args.WithDefaults); err != nil {
+			return nil, err
+		}
+	}
 	return structJSON(s, "", jsonOutputConfig{
 		jType:         RFC7951,
 		rfc7951Config: args,
@@ -979,6 +1109,16 @@ func Marshal7951(d interface{}, args ...Marshal7951Arg) ([]byte, error) {
 		}
 
 	}
+	if rfcCfg != nil && rfcCfg.WithDefaults != WithDefaultsExplicit {
+		s, ok := d.(GoStruct)
+		if !ok {
+			return nil, fmt.Errorf("cannot render %T with with-defaults mode %v: not a GoStruct", d, rfcCfg.WithDefaults)
+		}
+		var err error
+		if d, err = applyWithDefaults(s, rfcCfg.WithDefaults); err != nil {
+			return nil, err
+		}
+	}
 	j, err := jsonValue(reflect.ValueOf(d), "", jsonOutputConfig{
 		jType:         RFC7951,
 		rfc7951Config: rfcCfg,
@@ -1102,7 +1242,7 @@ func structJSON(s GoStruct, parentMod string, args jsonOutputConfig) (map[string
 					continue
 				}
 				if appendModName {
-					pelem = fmt.Sprintf("%s:%s", appmod, pelem)
+					pelem = qualifiedMemberName(appmod, pelem)
 				}
 				jsonout[pelem] = value
 			default:
@@ -1150,7 +1290,7 @@ func structJSON(s GoStruct, parentMod string, args jsonOutputConfig) (map[string
 					// In the case that the parent was nil, then we must not
 					// append the name here, since we must be within the same
 					// module.
-					k = fmt.Sprintf("%s:%s", appmod, k)
+					k = qualifiedMemberName(appmod, k)
 				}
 				parent[k] = value
 
@@ -1306,6 +1446,16 @@ func mapJSON(field reflect.Value, parentMod string, args jsonOutputConfig) (inte
 	return vals, nil
 }
 
+// qualifiedMemberName returns the RFC7951 member name for name qualified with
+// the module mod. The member name of the RFC7952 metadata of a node, "@name",
+// is qualified as "@mod:name".
+func qualifiedMemberName(mod, name string) string {
+	if len(name) > 1 && strings.HasPrefix(name, "@") {
+		return fmt.Sprintf("@%s:%s", mod, name[1:])
+	}
+	return fmt.Sprintf("%s:%s", mod, name)
+}
+
 // jsonValue takes a reflect.Value which represents a struct field and
 // constructs the representation that can be used to marshal the field to JSON.
 // The module within which the value is defined is specified by the parentMod string,
@@ -1335,8 +1485,18 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (in
 			errs.Add(err)
 		}
 	case reflect.Ptr:
-		switch field.Elem().Kind() {
-		case reflect.Struct:
+		switch {
+		case isAnydataValue(field):
+			// The contents of anydata are stored in their RFC7951 JSON
+			// encoding, and hence are output unchanged.
+			value = copyJSONValue(field.Interface().(*Anydata).Value)
+		case isOrderedMapValue(field):
+			var err error
+			value, err = orderedMapJSON(field, parentMod, args)
+			if err != nil {
+				errs.Add(err)
+			}
+		case field.Elem().Kind() == reflect.Struct:
 			goStruct, ok := field.Interface().(GoStruct)
 			if !ok {
 				return nil, fmt.Errorf("cannot map struct %v, invalid GoStruct", field)
@@ -1363,7 +1523,7 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (in
 		var err error
 		switch {
 		case isAnnotationSlice(field):
-			value, err = jsonAnnotationSlice(field)
+			value, err = jsonAnnotationSlice(field, args)
 		default:
 			value, err = jsonSlice(field, parentMod, args)
 		}
@@ -1486,15 +1646,48 @@ func jsonSlice(field reflect.Value, parentMod string, args jsonOutputConfig) (in
 
 // jsonAnnotationSlice takes a reflect.Value which must represent a
 // ygot Annotation field ([]ygot.Annotation), and marshals it to JSON to be
-// included in the output JSON.
-func jsonAnnotationSlice(v reflect.Value) (interface{}, error) {
-	if v.Len() == 0 {
+// included in the output JSON. In RFC7951 output, a field that contains only
+// Metadata annotations is rendered as an RFC7952 metadata object. A
+// LeafMetadataStore is not rendered, since it describes the data tree rather
+// than the node that it is attached to.
+func jsonAnnotationSlice(v reflect.Value, args jsonOutputConfig) (interface{}, error) {
+	var annos []Annotation
+	for i := 0; i < v.Len(); i++ {
+		if _, ok := v.Index(i).Interface().(*LeafMetadataStore); ok {
+			continue
+		}
+		annos = append(annos, v.Index(i).Interface().(Annotation))
+	}
+	if len(annos) == 0 {
 		return nil, nil
 	}
 
+	if args.jType == RFC7951 {
+		md := map[string]interface{}{}
+		isMetadata := true
+		for _, a := range annos {
+			m, ok := a.(*Metadata)
+			if !ok || m == nil {
+				isMetadata = false
+				break
+			}
+			for n, mv := range m.Values {
+				if err := validateMetadataName(n); err != nil {
+					return nil, err
+				}
+				md[n] = mv
+			}
+		}
+		if isMetadata {
+			if len(md) == 0 {
+				return nil, nil
+			}
+			return md, nil
+		}
+	}
+
 	vals := []interface{}{}
-	for i := 0; i < v.Len(); i++ {
-		fv := v.Index(i).Interface().(Annotation)
+	for _, fv := range annos {
 		jv, err := fv.MarshalJSON()
 		if err != nil {
 			return nil, fmt.Errorf("cannot marshal annotation %v type %T to JSON: %v", fv, fv, err)
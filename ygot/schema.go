@@ -51,7 +51,8 @@ func GzipToSchema(gzj []byte) (map[string]*yang.Entry, error) {
 // rebuildSchemaMap takes an input yang.Entry and appends it to the
 // schema map. The key of the map is the stored name of the generated
 // struct which is stored in the Annotation field of the yang.Entry when
-// serialised.
+// serialised. The input and output of an rpc or action entry are also
+// appended, since structs may be generated for them.
 func rebuildSchemaMap(e, parent *yang.Entry, schema map[string]*yang.Entry) {
 	if n, ok := e.Annotation["structname"]; ok {
 		if s, ok := n.(string); ok {
@@ -63,4 +64,12 @@ func rebuildSchemaMap(e, parent *yang.Entry, schema map[string]*yang.Entry) {
 	for _, ch := range e.Dir {
 		rebuildSchemaMap(ch, e, schema)
 	}
+
+	if e.RPC != nil {
+		for _, ch := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+			if ch != nil {
+				rebuildSchemaMap(ch, e, schema)
+			}
+		}
+	}
 }
@@ -104,3 +104,50 @@ func TestGzipToSchema(t *testing.T) {
 		}
 	}
 }
+
+func TestRebuildSchemaMapRPC(t *testing.T) {
+	in := `{
+		"Name": "device",
+		"Kind": 1,
+		"Dir": {
+			"reboot": {
+				"Name": "reboot",
+				"Kind": 1,
+				"RPC": {
+					"Input": {
+						"Name": "input",
+						"Kind": 6,
+						"Dir": {
+							"delay": {"Name": "delay", "Kind": 0}
+						},
+						"Annotation": {"structname": "Reboot_Input"}
+					},
+					"Output": null
+				}
+			}
+		},
+		"Annotation": {"structname": "Device"}
+	}`
+
+	root := &yang.Entry{}
+	if err := json.Unmarshal([]byte(in), root); err != nil {
+		t.Fatalf("json.Unmarshal(%s): got unexpected error: %v", in, err)
+	}
+
+	got := map[string]*yang.Entry{}
+	rebuildSchemaMap(root, nil, got)
+
+	if _, ok := got["Device"]; !ok {
+		t.Errorf("rebuildSchemaMap: did not find Device in the schema map, got: %v", got)
+	}
+	input, ok := got["Reboot_Input"]
+	if !ok {
+		t.Fatalf("rebuildSchemaMap: did not find Reboot_Input in the schema map, got: %v", got)
+	}
+	if want := root.Dir["reboot"]; input.Parent != want {
+		t.Errorf("rebuildSchemaMap: did not get expected parent of Reboot_Input, got: %v, want: %v", input.Parent, want)
+	}
+	if want := "/device/reboot/input/delay"; input.Dir["delay"].Path() != want {
+		t.Errorf("rebuildSchemaMap: did not get expected path of input leaf, got: %s, want: %s", input.Dir["delay"].Path(), want)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SIDs stores the YANG Schema Item iDentifiers (SIDs) that are assigned to
+// the data nodes and identities of a set of YANG modules, as described in
+// RFC9254 and RFC9595.
+type SIDs struct {
+	// data maps the schema node path of each data node to its SID.
+	data map[string]uint64
+	// identities maps each identity, qualified with the name of its
+	// module, to its SID.
+	identities map[string]uint64
+	// dataBySID and identitiesBySID are the inverse of data and identities.
+	dataBySID       map[uint64]string
+	identitiesBySID map[uint64]string
+}
+
+// NewSIDs returns a new SIDs which has no SIDs assigned.
+func NewSIDs() *SIDs {
+	return &SIDs{
+		data:            map[string]uint64{},
+		identities:      map[string]uint64{},
+		dataBySID:       map[uint64]string{},
+		identitiesBySID: map[uint64]string{},
+	}
+}
+
+// sidFile is the JSON representation of a .sid file, as defined by the
+// ietf-sid-file YANG module of RFC9595.
+type sidFile struct {
+	SIDFile *sidFileContents `json:"ietf-sid-file:sid-file"`
+}
+
+// sidFileContents is the contents of the sid-file container of a .sid file.
+type sidFileContents struct {
+	ModuleName string         `json:"module-name"`
+	Item       []*sidFileItem `json:"item"`
+}
+
+// sidFileItem is an entry of the item list of a .sid file, which assigns a
+// SID to a YANG item.
+type sidFileItem struct {
+	Namespace  string          `json:"namespace"`
+	Identifier string          `json:"identifier"`
+	SID        json.RawMessage `json:"sid"`
+}
+
+// AddSIDFile adds the SIDs that are assigned by the supplied .sid file,
+// which must be encoded in JSON as specified by RFC9595, to s. The SIDs of
+// data nodes and identities are stored, whereas those of modules and
+// features are not, since they do not appear in instance data. An error is
+// returned if the file cannot be parsed, or if it assigns a SID that is
+// already assigned to a different item.
+func (s *SIDs) AddSIDFile(data []byte) error {
+	f := &sidFile{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return fmt.Errorf("cannot parse .sid file, %v", err)
+	}
+	if f.SIDFile == nil {
+		return fmt.Errorf("cannot parse .sid file, missing ietf-sid-file:sid-file container")
+	}
+
+	for _, i := range f.SIDFile.Item {
+		// The sid leaf is a uint64, which is encoded as a string in RFC7951
+		// JSON, but may be encoded as a number by some tools.
+		sid, err := strconv.ParseUint(strings.Trim(string(i.SID), `"`), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid SID %s for %s in module %s, %v", i.SID, i.Identifier, f.SIDFile.ModuleName, err)
+		}
+		switch i.Namespace {
+		case "data":
+			err = s.AddDataSID(i.Identifier, sid)
+		case "identity":
+			err = s.AddIdentitySID(i.Identifier, sid)
+		case "module", "feature":
+		default:
+			err = fmt.Errorf("invalid namespace %s for %s", i.Namespace, i.Identifier)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid item in module %s, %v", f.SIDFile.ModuleName, err)
+		}
+	}
+	return nil
+}
+
+// AddDataSID assigns sid to the data node with the schema node path path. The
+// path consists of the names of the data nodes, which do not include choice
+// and case nodes, from the root of the schema tree to the data node, where
+// each name is qualified with the name of its module if it is defined in a
+// different module to its parent, for example,
+// /ietf-interfaces:interfaces/interface/name.
+func (s *SIDs) AddDataSID(path string, sid uint64) error {
+	if p, ok := s.dataBySID[sid]; ok && p != path {
+		return fmt.Errorf("SID %d for %s is already assigned to %s", sid, path, p)
+	}
+	s.data[path] = sid
+	s.dataBySID[sid] = path
+	return nil
+}
+
+// AddIdentitySID assigns sid to the identity id, which is qualified with the
+// name of the module that defines it, for example, iana-if-type:ethernetCsmacd.
+func (s *SIDs) AddIdentitySID(id string, sid uint64) error {
+	if i, ok := s.identitiesBySID[sid]; ok && i != id {
+		return fmt.Errorf("SID %d for %s is already assigned to %s", sid, id, i)
+	}
+	s.identities[id] = sid
+	s.identitiesBySID[sid] = id
+	return nil
+}
+
+// DataSID returns the SID of the data node with the schema node path path,
+// and whether such a SID is assigned.
+func (s *SIDs) DataSID(path string) (uint64, bool) {
+	sid, ok := s.data[path]
+	return sid, ok
+}
+
+// DataPath returns the schema node path of the data node with the SID sid,
+// and whether such a data node exists.
+func (s *SIDs) DataPath(sid uint64) (string, bool) {
+	p, ok := s.dataBySID[sid]
+	return p, ok
+}
+
+// IdentitySID returns the SID of the identity id, which is qualified with
+// the name of the module that defines it, and whether such a SID is assigned.
+func (s *SIDs) IdentitySID(id string) (uint64, bool) {
+	sid, ok := s.identities[id]
+	return sid, ok
+}
+
+// Identity returns the identity with the SID sid, qualified with the name of
+// the module that defines it, and whether such an identity exists.
+func (s *SIDs) Identity(sid uint64) (string, bool) {
+	id, ok := s.identitiesBySID[sid]
+	return id, ok
+}
+
+// DataPathForNames returns the schema node path of the data node whose path
+// consists of the supplied unqualified names, and whether a single such data
+// node exists. It allows the SID of a data node to be determined from a schema
+// that does not record the module of each schema node.
+func (s *SIDs) DataPathForNames(names []string) (string, bool) {
+	var found string
+	for p := range s.data {
+		elems := strings.Split(strings.TrimPrefix(p, "/"), "/")
+		if len(elems) != len(names) {
+			continue
+		}
+		match := true
+		for i, e := range elems {
+			if e[strings.Index(e, ":")+1:] != names[i] {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		if found != "" {
+			return "", false
+		}
+		found = p
+	}
+	return found, found != ""
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestAddSIDFile(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               string
+		wantData         map[string]uint64
+		wantIdentities   map[string]uint64
+		wantErrSubstring string
+	}{{
+		name: "valid file",
+		in: `{
+  "ietf-sid-file:sid-file": {
+    "module-name": "m1",
+    "module-revision": "2020-01-01",
+    "assignment-range": [{"entry-point": "1000", "size": "100"}],
+    "item": [
+      {"namespace": "module", "identifier": "m1", "sid": "1000"},
+      {"namespace": "feature", "identifier": "m1:feat", "sid": "1001"},
+      {"namespace": "identity", "identifier": "m1:ETHERNET", "sid": "1002"},
+      {"namespace": "data", "identifier": "/m1:interfaces", "sid": "1003"},
+      {"namespace": "data", "identifier": "/m1:interfaces/interface", "sid": 1004}
+    ]
+  }
+}`,
+		wantData:       map[string]uint64{"/m1:interfaces": 1003, "/m1:interfaces/interface": 1004},
+		wantIdentities: map[string]uint64{"m1:ETHERNET": 1002},
+	}, {
+		name:             "invalid JSON",
+		in:               `{`,
+		wantErrSubstring: "cannot parse .sid file",
+	}, {
+		name:             "missing sid-file container",
+		in:               `{"sid-file": {}}`,
+		wantErrSubstring: "missing ietf-sid-file:sid-file container",
+	}, {
+		name:             "invalid SID",
+		in:               `{"ietf-sid-file:sid-file": {"module-name": "m1", "item": [{"namespace": "data", "identifier": "/m1:a", "sid": "-1"}]}}`,
+		wantErrSubstring: "invalid SID \"-1\" for /m1:a in module m1",
+	}, {
+		name:             "invalid namespace",
+		in:               `{"ietf-sid-file:sid-file": {"module-name": "m1", "item": [{"namespace": "typedef", "identifier": "m1:t", "sid": "1"}]}}`,
+		wantErrSubstring: "invalid namespace typedef for m1:t",
+	}, {
+		name: "duplicate SID",
+		in: `{"ietf-sid-file:sid-file": {"module-name": "m1", "item": [
+  {"namespace": "data", "identifier": "/m1:a", "sid": "1"},
+  {"namespace": "data", "identifier": "/m1:b", "sid": "1"}
+]}}`,
+		wantErrSubstring: "SID 1 for /m1:b is already assigned to /m1:a",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSIDs()
+			err := s.AddSIDFile([]byte(tt.in))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("AddSIDFile: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			for p, want := range tt.wantData {
+				if got, ok := s.DataSID(p); !ok || got != want {
+					t.Errorf("DataSID(%s): got (%d, %v), want (%d, true)", p, got, ok, want)
+				}
+				if got, ok := s.DataPath(want); !ok || got != p {
+					t.Errorf("DataPath(%d): got (%s, %v), want (%s, true)", want, got, ok, p)
+				}
+			}
+			for id, want := range tt.wantIdentities {
+				if got, ok := s.IdentitySID(id); !ok || got != want {
+					t.Errorf("IdentitySID(%s): got (%d, %v), want (%d, true)", id, got, ok, want)
+				}
+				if got, ok := s.Identity(want); !ok || got != id {
+					t.Errorf("Identity(%d): got (%s, %v), want (%s, true)", want, got, ok, id)
+				}
+			}
+			if got, want := len(s.data), len(tt.wantData); got != want {
+				t.Errorf("AddSIDFile: got %d data SIDs, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestDataPathForNames(t *testing.T) {
+	s := NewSIDs()
+	for p, sid := range map[string]uint64{
+		"/m1:interfaces":                       1,
+		"/m1:interfaces/interface":             2,
+		"/m1:interfaces/interface/m2:counters": 3,
+		"/m2:counters":                         4,
+		"/m3:counters":                         5,
+	} {
+		if err := s.AddDataSID(p, sid); err != nil {
+			t.Fatalf("AddDataSID(%s, %d): got unexpected error, %v", p, sid, err)
+		}
+	}
+
+	tests := []struct {
+		in     []string
+		want   string
+		wantOK bool
+	}{{
+		in:     []string{"interfaces", "interface"},
+		want:   "/m1:interfaces/interface",
+		wantOK: true,
+	}, {
+		in:     []string{"interfaces", "interface", "counters"},
+		want:   "/m1:interfaces/interface/m2:counters",
+		wantOK: true,
+	}, {
+		// Ambiguous, since it is defined in both m2 and m3.
+		in: []string{"counters"},
+	}, {
+		in: []string{"interfaces", "counters"},
+	}}
+
+	for _, tt := range tests {
+		got, ok := s.DataPathForNames(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("DataPathForNames(%v): got (%s, %v), want (%s, %v)", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
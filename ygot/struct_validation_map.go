@@ -202,6 +202,28 @@ func pruneBranchesInternal(t reflect.Type, v reflect.Value) bool {
 	for i := 0; i < v.NumField(); i++ {
 		fVal := v.Field(i)
 		fType := t.Field(i)
+		if util.IsTypeOrderedMap(fType.Type) {
+			// An ordered map is a list, which is pruned if it has no entries,
+			// and whose entries are otherwise recursed into as per a map.
+			if fVal.IsNil() {
+				continue
+			}
+			if om, ok := fVal.Interface().(GoOrderedMap); ok && om.Len() == 0 {
+				fVal.Set(reflect.Zero(fType.Type))
+				continue
+			}
+			allChildrenPruned = false
+			vals, err := util.OrderedMapValues(fVal)
+			if err != nil {
+				continue
+			}
+			for _, v := range vals {
+				if util.IsValueStructPtr(v) {
+					_ = pruneBranchesInternal(v.Elem().Type(), v.Elem())
+				}
+			}
+			continue
+		}
 		if util.IsTypeStructPtr(fType.Type) {
 			// Create an empty version of the struct that is within the struct pointer.
 			// We can safely call Elem() here since we verified above that this type
@@ -626,6 +648,29 @@ func copyPtrField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
 		return fmt.Errorf("received non-ptr type: %v", srcField.Kind())
 	}
 
+	// An ordered map is copied by its entries, rather than as a struct, such
+	// that their order is retained.
+	if isOrderedMapValue(srcField) {
+		return copyOrderedMapField(dstField, srcField, opts...)
+	}
+
+	// Anydata is copied as a single value, rather than as a struct. A schema
+	// bound to the destination is retained where the source has none.
+	if isAnydataValue(srcField) {
+		src := copyAnydata(srcField.Interface().(*Anydata))
+		if !util.IsNilOrInvalidValue(dstField) {
+			dst := dstField.Interface().(*Anydata)
+			if diff := cmp.Diff(src.Value, dst.Value); !fieldOverwriteEnabled(opts) && (diff != "" || (src.Schema != nil && dst.Schema != nil && src.Schema != dst.Schema)) {
+				return fmt.Errorf("destination value was set, but was not equal to source value when merging anydata field, (-src, +dst):\n%s", diff)
+			}
+			if src.Schema == nil {
+				src.Schema = dst.Schema
+			}
+		}
+		dstField.Set(reflect.ValueOf(src))
+		return nil
+	}
+
 	// Check for struct ptr, or ptr to avoid panic.
 	if util.IsValueStructPtr(srcField) {
 		var d reflect.Value
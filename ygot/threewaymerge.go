@@ -0,0 +1,376 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/util"
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// MergeConflict describes a leaf whose value was modified differently in
+// each of the two modified GoStructs supplied to MergeStructsThreeWay.
+type MergeConflict struct {
+	// Path is the data tree path of the leaf, relative to the root of the
+	// merged GoStructs.
+	Path *gnmipb.Path
+	// Base is the value of the leaf within the common ancestor of the
+	// modified GoStructs. Like Ours and Theirs, it is the value of the
+	// corresponding struct field, or nil if the leaf is not set.
+	Base interface{}
+	// Ours is the value of the leaf within the first modified GoStruct,
+	// which is retained within the merged GoStruct.
+	Ours interface{}
+	// Theirs is the value of the leaf within the second modified GoStruct.
+	Theirs interface{}
+}
+
+// String returns a string representation of the MergeConflict c.
+func (c *MergeConflict) String() string {
+	ps, err := PathToString(c.Path)
+	if err != nil {
+		ps = fmt.Sprintf("%v", c.Path)
+	}
+	return fmt.Sprintf("%s: base: %v, ours: %v, theirs: %v", ps, derefValue(c.Base), derefValue(c.Ours), derefValue(c.Theirs))
+}
+
+// derefValue returns the value that v points to, or v if it is not a
+// non-nil pointer.
+func derefValue(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		return rv.Elem().Interface()
+	}
+	return v
+}
+
+// MergeStructsThreeWay performs a three-way merge of the ValidatedGoStructs
+// ours and theirs, which are both modified versions of the common ancestor
+// base, returning a new ValidatedGoStruct along with the conflicts that were
+// found. All supplied structs must be of the same type, and must have path
+// struct tags such that the path of each leaf can be determined.
+//
+// Each leaf of the merged struct is determined by comparing its value within
+// the three inputs, where an unset leaf is considered to have a nil value:
+//
+//   - If the leaf has the same value in ours and theirs, that value is used.
+//   - If the leaf was modified in only one of ours and theirs, relative to
+//     base, the modified value is used.
+//   - If the leaf was modified to different values in ours and theirs, the
+//     value in ours is used, and a MergeConflict is returned for the leaf.
+//
+// Containers and list entries are present in the merged struct if any of the
+// leaves within them are set, or if they were added in either of ours and
+// theirs and not removed by the other. Leaf-lists and unkeyed lists are
+// merged as single values, as are anydata and anyxml nodes, and annotation
+// fields are copied from ours. The entries of lists that are ordered-by user
+// retain their order within ours, with the entries added in theirs following
+// them.
+//
+// The returned conflicts are sorted by path. The supplied structs are not
+// modified.
+func MergeStructsThreeWay(base, ours, theirs ValidatedGoStruct) (ValidatedGoStruct, []*MergeConflict, error) {
+	for _, s := range []ValidatedGoStruct{base, ours, theirs} {
+		if util.IsValueNil(s) || !util.IsValueStructPtr(reflect.ValueOf(s)) {
+			return nil, nil, fmt.Errorf("cannot merge invalid struct %v, must be a non-nil struct pointer", s)
+		}
+	}
+	if reflect.TypeOf(base) != reflect.TypeOf(ours) || reflect.TypeOf(base) != reflect.TypeOf(theirs) {
+		return nil, nil, fmt.Errorf("cannot merge structs that are not of matching types, %T, %T, %T", base, ours, theirs)
+	}
+
+	m := &threeWayMerger{}
+	merged, err := m.mergeStruct(reflect.ValueOf(base), reflect.ValueOf(ours), reflect.ValueOf(theirs), &gnmipb.Path{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if merged.IsNil() {
+		merged = reflect.New(reflect.TypeOf(ours).Elem())
+	}
+
+	// The merged struct shares its values with the inputs, hence it is
+	// copied such that subsequent changes to it do not affect them.
+	n, err := DeepCopy(merged.Interface().(GoStruct))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(m.conflicts, func(i, j int) bool {
+		return pathLess(m.conflicts[i].Path, m.conflicts[j].Path)
+	})
+	return n.(ValidatedGoStruct), m.conflicts, nil
+}
+
+// threeWayMerger stores the state of a three-way merge of GoStructs.
+type threeWayMerger struct {
+	// conflicts is the set of conflicting leaves found during the merge.
+	conflicts []*MergeConflict
+}
+
+// mergeStruct merges the struct pointers base, ours and theirs, any of which
+// may be nil, returning a pointer to the merged struct. The returned pointer
+// is nil if the merged struct has no populated fields and is not present per
+// mergePresence. The path of the struct is supplied as path.
+func (m *threeWayMerger) mergeStruct(base, ours, theirs reflect.Value, path *gnmipb.Path) (reflect.Value, error) {
+	t := ours.Type()
+	merged := reflect.New(t.Elem())
+
+	var populated bool
+	for i := 0; i < t.Elem().NumField(); i++ {
+		sf := t.Elem().Field(i)
+		bf, of, tf := structField(base, i), structField(ours, i), structField(theirs, i)
+
+		if util.IsYgotAnnotation(sf) {
+			merged.Elem().Field(i).Set(of)
+			continue
+		}
+
+		sp, err := util.SchemaPaths(sf)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if len(sp) == 0 {
+			return reflect.Value{}, fmt.Errorf("invalid schema path for %s", sf.Name)
+		}
+		fp := joingNMIPaths(path, schemaPathTogNMIPath(leastSpecificPath(sp)))
+
+		var v reflect.Value
+		switch {
+		case util.IsTypeOrderedMap(sf.Type):
+			if v, err = m.mergeOrderedMap(bf, of, tf, fp); err != nil {
+				return reflect.Value{}, err
+			}
+			if v.IsNil() {
+				continue
+			}
+		case util.IsTypeStructPtr(sf.Type) && !util.IsTypeAnydata(sf.Type):
+			if v, err = m.mergeStruct(bf, of, tf, fp); err != nil {
+				return reflect.Value{}, err
+			}
+			if v.IsNil() {
+				continue
+			}
+		case util.IsTypeMap(sf.Type):
+			if v, err = m.mergeMap(bf, of, tf, fp); err != nil {
+				return reflect.Value{}, err
+			}
+			if v.Len() == 0 {
+				continue
+			}
+		default:
+			equal := util.DeepEqualDerefPtrs
+			if util.IsTypeAnydata(sf.Type) {
+				// Anydata is a leaf of the data tree, although it is a
+				// struct ptr.
+				equal = anydataEqual
+			}
+			v = m.mergeLeaf(bf, of, tf, fp, equal)
+			if leafValue(v) == nil {
+				continue
+			}
+		}
+		merged.Elem().Field(i).Set(v)
+		populated = true
+	}
+
+	if !populated && !mergePresence(!base.IsNil(), !ours.IsNil(), !theirs.IsNil()) {
+		return reflect.Zero(t), nil
+	}
+	return merged, nil
+}
+
+// mergeMap merges the maps base, ours and theirs, which represent a keyed
+// YANG list with the path path, returning the merged map. Each list entry is
+// merged using mergeStruct.
+func (m *threeWayMerger) mergeMap(base, ours, theirs reflect.Value, path *gnmipb.Path) (reflect.Value, error) {
+	t := ours.Type()
+	merged := reflect.MakeMap(t)
+
+	keys := map[interface{}]reflect.Value{}
+	for _, v := range []reflect.Value{base, ours, theirs} {
+		for _, k := range v.MapKeys() {
+			keys[k.Interface()] = k
+		}
+	}
+
+	for _, k := range keys {
+		be, oe, te := mapEntry(base, k), mapEntry(ours, k), mapEntry(theirs, k)
+
+		ep, err := listEntryPath(path, oe, te, be)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v, err := m.mergeStruct(be, oe, te, ep)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if !v.IsNil() {
+			merged.SetMapIndex(k, v)
+		}
+	}
+	return merged, nil
+}
+
+// mergeOrderedMap merges the ordered maps base, ours and theirs of a YANG
+// list that is ordered-by user with the path path, returning a pointer to the
+// merged ordered map, which is nil if it has no entries. The entries are
+// merged as per mergeMap. The merged entries are ordered as they are in ours,
+// followed by the entries that were added in theirs, in the order of theirs.
+func (m *threeWayMerger) mergeOrderedMap(base, ours, theirs reflect.Value, path *gnmipb.Path) (reflect.Value, error) {
+	var maps []reflect.Value
+	var order []reflect.Value
+	for _, v := range []reflect.Value{ours, theirs, base} {
+		mv, err := util.OrderedMapToMap(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		keys, err := util.OrderedMapKeys(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		maps = append(maps, mv)
+		order = append(order, keys...)
+	}
+
+	mm, err := m.mergeMap(maps[2], maps[0], maps[1], path)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if mm.Len() == 0 {
+		return reflect.Zero(ours.Type()), nil
+	}
+
+	merged := reflect.New(ours.Type().Elem())
+	seen := map[interface{}]bool{}
+	for _, k := range order {
+		e := mm.MapIndex(k)
+		if seen[k.Interface()] || !e.IsValid() {
+			continue
+		}
+		seen[k.Interface()] = true
+		if err := util.AppendIntoOrderedMap(merged.Interface(), e.Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return merged, nil
+}
+
+// mergeLeaf merges the values base, ours and theirs of a leaf with the path
+// path, which are compared using equal, returning the merged value. Where ours
+// and theirs both modify the value of the leaf, a conflict is recorded and
+// ours is returned.
+func (m *threeWayMerger) mergeLeaf(base, ours, theirs reflect.Value, path *gnmipb.Path, equal func(a, b interface{}) bool) reflect.Value {
+	bv, ov, tv := leafValue(base), leafValue(ours), leafValue(theirs)
+	switch {
+	case equal(ov, tv):
+		return ours
+	case equal(bv, ov):
+		return theirs
+	case equal(bv, tv):
+		return ours
+	}
+	m.conflicts = append(m.conflicts, &MergeConflict{
+		Path:   path,
+		Base:   bv,
+		Ours:   ov,
+		Theirs: tv,
+	})
+	return ours
+}
+
+// anydataEqual reports whether the anydata values a and b, either of which
+// may be nil, are equal. Their contents are compared by value, whereas their
+// schemas are compared by identity, as when merging anydata in MergeStructs.
+func anydataEqual(a, b interface{}) bool {
+	aa, _ := a.(*Anydata)
+	bb, _ := b.(*Anydata)
+	if aa == nil || bb == nil {
+		return aa == bb
+	}
+	return aa.Schema == bb.Schema && cmp.Equal(aa.Value, bb.Value)
+}
+
+// mergePresence returns whether a container or list entry that has no
+// populated fields after a three-way merge is present in the merged struct,
+// given whether it is present in base, ours and theirs.
+func mergePresence(base, ours, theirs bool) bool {
+	if ours == theirs || ours != base {
+		return ours
+	}
+	return theirs
+}
+
+// structField returns the i-th field of the struct that v points to, or the
+// zero value of the field if v is nil.
+func structField(v reflect.Value, i int) reflect.Value {
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem().Field(i).Type)
+	}
+	return v.Elem().Field(i)
+}
+
+// mapEntry returns the value of the map v at key k, or the zero value of the
+// map's element type if k is not present.
+func mapEntry(v reflect.Value, k reflect.Value) reflect.Value {
+	if e := v.MapIndex(k); e.IsValid() {
+		return e
+	}
+	return reflect.Zero(v.Type().Elem())
+}
+
+// leafValue returns the value of the leaf field v, or nil if the leaf is
+// unset. Enumerated values that are set to zero are considered unset.
+func leafValue(v reflect.Value) interface{} {
+	if util.IsNilOrInvalidValue(v) || (v.Kind() == reflect.Slice && v.Len() == 0) {
+		return nil
+	}
+	i := v.Interface()
+	if util.IsValueNilOrDefault(i) {
+		return nil
+	}
+	return i
+}
+
+// listEntryPath returns the path of a list entry within the list with path
+// path, using the keys of the first of the supplied entries that is non-nil.
+func listEntryPath(path *gnmipb.Path, entries ...reflect.Value) (*gnmipb.Path, error) {
+	for _, e := range entries {
+		if e.IsNil() {
+			continue
+		}
+		kh, ok := e.Interface().(KeyHelperGoStruct)
+		if !ok {
+			return nil, fmt.Errorf("list entry %T at %v does not implement KeyHelperGoStruct", e.Interface(), path)
+		}
+		keys, err := kh.ΛListKeyMap()
+		if err != nil {
+			return nil, err
+		}
+		strkeys, err := keyMapAsStrings(keys)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert keys to map[string]string: %v", err)
+		}
+		np := proto.Clone(path).(*gnmipb.Path)
+		np.Elem[len(np.Elem)-1].Key = strkeys
+		return np, nil
+	}
+	return nil, fmt.Errorf("no valid list entry at %v", path)
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+type threeWayRoot struct {
+	Name      *string                        `path:"name"`
+	Enum      EnumTest                       `path:"enum"`
+	LeafList  []string                       `path:"leaf-list"`
+	Container *threeWayContainer             `path:"container"`
+	List      map[string]*threeWayListMember `path:"list"`
+	Data      *Anydata                       `path:"data"`
+}
+
+func (*threeWayRoot) Validate(...ValidationOption) error      { return nil }
+func (*threeWayRoot) IsYANGGoStruct()                         {}
+func (*threeWayRoot) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+
+type threeWayContainer struct {
+	Value *uint32 `path:"config/value|state/value"`
+}
+
+func (*threeWayContainer) IsYANGGoStruct() {}
+
+type threeWayListMember struct {
+	Key   *string `path:"config/key|key"`
+	Value *string `path:"config/value"`
+}
+
+func (*threeWayListMember) IsYANGGoStruct() {}
+
+func (l *threeWayListMember) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"key": *l.Key}, nil
+}
+
+type threeWayNoPath struct {
+	Name *string
+}
+
+func (*threeWayNoPath) Validate(...ValidationOption) error      { return nil }
+func (*threeWayNoPath) IsYANGGoStruct()                         {}
+func (*threeWayNoPath) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+
+func TestMergeStructsThreeWay(t *testing.T) {
+	path := func(s string) *gnmipb.Path {
+		p, err := StringToStructuredPath(s)
+		if err != nil {
+			t.Fatalf("cannot parse path %s, %v", s, err)
+		}
+		return p
+	}
+	entry := func(k, v string) *threeWayListMember {
+		m := &threeWayListMember{Key: String(k)}
+		if v != "" {
+			m.Value = String(v)
+		}
+		return m
+	}
+	anydata := func(v string) *Anydata {
+		return &Anydata{Value: map[string]interface{}{"value": v}}
+	}
+
+	tests := []struct {
+		desc             string
+		inBase           ValidatedGoStruct
+		inOurs           ValidatedGoStruct
+		inTheirs         ValidatedGoStruct
+		want             ValidatedGoStruct
+		wantConflicts    []*MergeConflict
+		wantErrSubstring string
+	}{{
+		desc:     "changes to different leaves",
+		inBase:   &threeWayRoot{Name: String("base")},
+		inOurs:   &threeWayRoot{Name: String("ours")},
+		inTheirs: &threeWayRoot{Name: String("base"), Container: &threeWayContainer{Value: Uint32(42)}},
+		want:     &threeWayRoot{Name: String("ours"), Container: &threeWayContainer{Value: Uint32(42)}},
+	}, {
+		desc:     "same change in ours and theirs",
+		inBase:   &threeWayRoot{Name: String("base")},
+		inOurs:   &threeWayRoot{Name: String("new"), Enum: EnumTestVALTWO},
+		inTheirs: &threeWayRoot{Name: String("new"), Enum: EnumTestVALTWO},
+		want:     &threeWayRoot{Name: String("new"), Enum: EnumTestVALTWO},
+	}, {
+		desc:     "deletion in theirs",
+		inBase:   &threeWayRoot{Name: String("base"), LeafList: []string{"a", "b"}},
+		inOurs:   &threeWayRoot{Name: String("base"), LeafList: []string{"a", "b"}},
+		inTheirs: &threeWayRoot{LeafList: []string{"a", "b"}},
+		want:     &threeWayRoot{LeafList: []string{"a", "b"}},
+	}, {
+		desc:     "conflicting leaf modifications",
+		inBase:   &threeWayRoot{Name: String("base"), Container: &threeWayContainer{Value: Uint32(1)}},
+		inOurs:   &threeWayRoot{Name: String("ours"), Container: &threeWayContainer{Value: Uint32(2)}},
+		inTheirs: &threeWayRoot{Name: String("theirs"), Container: &threeWayContainer{Value: Uint32(1)}},
+		want:     &threeWayRoot{Name: String("ours"), Container: &threeWayContainer{Value: Uint32(2)}},
+		wantConflicts: []*MergeConflict{{
+			Path:   path("/name"),
+			Base:   String("base"),
+			Ours:   String("ours"),
+			Theirs: String("theirs"),
+		}},
+	}, {
+		desc:     "conflicting deletion and modification",
+		inBase:   &threeWayRoot{Container: &threeWayContainer{Value: Uint32(1)}, LeafList: []string{"a"}},
+		inOurs:   &threeWayRoot{LeafList: []string{"a", "b"}},
+		inTheirs: &threeWayRoot{Container: &threeWayContainer{Value: Uint32(2)}, LeafList: []string{"a", "c"}},
+		want:     &threeWayRoot{LeafList: []string{"a", "b"}},
+		wantConflicts: []*MergeConflict{{
+			Path:   path("/container/config/value"),
+			Base:   Uint32(1),
+			Theirs: Uint32(2),
+		}, {
+			Path:   path("/leaf-list"),
+			Base:   []string{"a"},
+			Ours:   []string{"a", "b"},
+			Theirs: []string{"a", "c"},
+		}},
+	}, {
+		desc: "list entries added, deleted and modified",
+		inBase: &threeWayRoot{List: map[string]*threeWayListMember{
+			"one":   entry("one", "1"),
+			"two":   entry("two", "2"),
+			"three": entry("three", "3"),
+		}},
+		inOurs: &threeWayRoot{List: map[string]*threeWayListMember{
+			"one":   entry("one", "1"),
+			"three": entry("three", "33"),
+			"four":  entry("four", "4"),
+		}},
+		inTheirs: &threeWayRoot{List: map[string]*threeWayListMember{
+			"one":   entry("one", "11"),
+			"two":   entry("two", "2"),
+			"three": entry("three", "333"),
+			"five":  entry("five", ""),
+		}},
+		want: &threeWayRoot{List: map[string]*threeWayListMember{
+			"one":   entry("one", "11"),
+			"three": entry("three", "33"),
+			"four":  entry("four", "4"),
+			"five":  entry("five", ""),
+		}},
+		wantConflicts: []*MergeConflict{{
+			Path:   path("/list[key=three]/config/value"),
+			Base:   String("3"),
+			Ours:   String("33"),
+			Theirs: String("333"),
+		}},
+	}, {
+		desc:     "anydata modified in theirs",
+		inBase:   &threeWayRoot{Data: anydata("base")},
+		inOurs:   &threeWayRoot{Name: String("ours"), Data: anydata("base")},
+		inTheirs: &threeWayRoot{Data: anydata("theirs")},
+		want:     &threeWayRoot{Name: String("ours"), Data: anydata("theirs")},
+	}, {
+		desc:     "conflicting anydata modifications",
+		inBase:   &threeWayRoot{Data: anydata("base")},
+		inOurs:   &threeWayRoot{Data: anydata("ours")},
+		inTheirs: &threeWayRoot{},
+		want:     &threeWayRoot{Data: anydata("ours")},
+		wantConflicts: []*MergeConflict{{
+			Path: path("/data"),
+			Base: anydata("base"),
+			Ours: anydata("ours"),
+		}},
+	}, {
+		desc:     "empty container added in theirs",
+		inBase:   &threeWayRoot{},
+		inOurs:   &threeWayRoot{Name: String("ours")},
+		inTheirs: &threeWayRoot{Container: &threeWayContainer{}},
+		want:     &threeWayRoot{Name: String("ours"), Container: &threeWayContainer{}},
+	}, {
+		desc:     "empty container deleted in ours",
+		inBase:   &threeWayRoot{Container: &threeWayContainer{}},
+		inOurs:   &threeWayRoot{},
+		inTheirs: &threeWayRoot{Container: &threeWayContainer{}},
+		want:     &threeWayRoot{},
+	}, {
+		desc:             "mismatched types",
+		inBase:           &threeWayRoot{},
+		inOurs:           &threeWayRoot{},
+		inTheirs:         &threeWayNoPath{},
+		wantErrSubstring: "cannot merge structs that are not of matching types",
+	}, {
+		desc:             "nil struct",
+		inBase:           &threeWayRoot{},
+		inOurs:           nil,
+		inTheirs:         &threeWayRoot{},
+		wantErrSubstring: "cannot merge invalid struct",
+	}, {
+		desc:             "missing path tags",
+		inBase:           &threeWayNoPath{},
+		inOurs:           &threeWayNoPath{},
+		inTheirs:         &threeWayNoPath{Name: String("theirs")},
+		wantErrSubstring: "did not specify a path",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, conflicts, err := MergeStructsThreeWay(tt.inBase, tt.inOurs, tt.inTheirs)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MergeStructsThreeWay: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MergeStructsThreeWay: did not get expected merged struct, diff(-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantConflicts, conflicts, protocmp.Transform()); diff != "" {
+				t.Errorf("MergeStructsThreeWay: did not get expected conflicts, diff(-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeStructsThreeWayDoesNotModifyInputs(t *testing.T) {
+	base := &threeWayRoot{Container: &threeWayContainer{Value: Uint32(1)}}
+	ours := &threeWayRoot{Container: &threeWayContainer{Value: Uint32(1)}}
+	theirs := &threeWayRoot{Container: &threeWayContainer{Value: Uint32(2)}}
+
+	got, _, err := MergeStructsThreeWay(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("MergeStructsThreeWay: got unexpected error, %v", err)
+	}
+	*got.(*threeWayRoot).Container.Value = 42
+	if v := *theirs.Container.Value; v != 2 {
+		t.Errorf("MergeStructsThreeWay: modifying merged struct modified input, got value %d, want 2", v)
+	}
+}
+
+func TestMergeConflictString(t *testing.T) {
+	c := &MergeConflict{
+		Path:   &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "name"}}},
+		Base:   String("base"),
+		Theirs: String("theirs"),
+	}
+	if got, want := c.String(), "/name: base: base, ours: <nil>, theirs: theirs"; got != want {
+		t.Errorf("String(): got %q, want %q", got, want)
+	}
+}
@@ -65,6 +65,19 @@ type KeyHelperGoStruct interface {
 	ΛListKeyMap() (map[string]interface{}, error)
 }
 
+// GoOrderedMap is an interface which can be implemented by the types that
+// are generated to represent a keyed YANG list that is "ordered-by user",
+// which store the entries of the list in the order that is specified by the
+// user. The entries are accessed through the Keys, Values and Append methods
+// of the generated type.
+type GoOrderedMap interface {
+	// IsYANGOrderedList is a marker method that indicates that the type
+	// represents an ordered-by user YANG list.
+	IsYANGOrderedList()
+	// Len returns the number of entries within the list.
+	Len() int
+}
+
 // GoEnum is an interface which can be implemented by derived types which
 // represent an enumerated value within a YANG schema. This allows handling
 // code that finds struct fields that implement this interface to do specific
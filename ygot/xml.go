@@ -0,0 +1,398 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// NETCONFNamespace is the XML namespace of the NETCONF protocol, within which
+// the <config> and <data> elements that wrap NETCONF datastore contents are
+// defined.
+const NETCONFNamespace = "urn:ietf:params:xml:ns:netconf:base:1.0"
+
+// XMLConfig specifies the options used when encoding a GoStruct as XML.
+type XMLConfig struct {
+	// Indent is the string that is used to indent each level of the XML
+	// document. If it is empty, the document is not indented.
+	Indent string
+	// RootElement is the name of an element within which the encoded data is
+	// wrapped, such as the <config> element of a NETCONF <edit-config>
+	// operation. If it is empty, the data is not wrapped.
+	RootElement string
+	// RootNamespace is the namespace of RootElement.
+	RootNamespace string
+}
+
+// MarshalXML encodes the GoStruct s as XML following the encoding rules of
+// RFC7950 Section 7, using the supplied schema, which must be the schema of
+// s. Where schema is the root of the schema tree, the XML document consists
+// of an element for each populated top-level data node, otherwise it consists
+// of a single element corresponding to s.
+//
+// The namespace of each element is taken from the module that defines it,
+// and is declared wherever it differs from that of the element's parent. The
+// namespaces of modules are retrieved using util.ModuleNamespaces, and the
+// module of a non-root schema using util.EntryModule, such that the schema
+// returned by the generated Schema() function can be used. The
+// values of identityref leaves are qualified with the name of the module that
+// defines the identity, which is declared as a namespace prefix. Leaf-lists
+// and list entries are encoded as a sequence of elements, and the keys of each
+// list entry are encoded before its other children.
+func MarshalXML(s GoStruct, schema *yang.Entry, cfg *XMLConfig) ([]byte, error) {
+	if util.IsValueNil(s) {
+		return nil, errors.New("cannot marshal nil GoStruct to XML")
+	}
+	if schema == nil {
+		return nil, fmt.Errorf("nil schema supplied for %T", s)
+	}
+	if cfg == nil {
+		cfg = &XMLConfig{}
+	}
+
+	nss := util.ModuleNamespaces(schema)
+	if len(nss) == 0 {
+		return nil, fmt.Errorf("schema %s does not specify the namespaces of its modules", schema.Name)
+	}
+
+	j, err := ConstructIETFJSON(s, &RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		return nil, fmt.Errorf("cannot construct JSON for %T, %v", s, err)
+	}
+
+	e := &xmlEncoder{indent: cfg.Indent, namespaces: nss}
+	var depth int
+	var parentNS string
+	if cfg.RootElement != "" {
+		e.start(cfg.RootElement, cfg.RootNamespace, nil, 0)
+		depth, parentNS = 1, cfg.RootNamespace
+	}
+
+	if schema.Parent == nil || util.IsFakeRoot(schema) {
+		if err := e.children(j, schema, "", parentNS, depth); err != nil {
+			return nil, err
+		}
+	} else {
+		mod, err := util.EntryModule(schema)
+		if err != nil {
+			return nil, fmt.Errorf("cannot determine the namespace of %s, %v", schema.Name, err)
+		}
+		ns, err := e.namespace(mod)
+		if err != nil {
+			return nil, err
+		}
+		declNS := ns
+		if ns == parentNS {
+			declNS = ""
+		}
+		e.start(schema.Name, declNS, nil, depth)
+		if err := e.children(j, schema, mod, ns, depth+1); err != nil {
+			return nil, err
+		}
+		e.end(schema.Name, depth)
+	}
+
+	if cfg.RootElement != "" {
+		e.end(cfg.RootElement, 0)
+	}
+	return e.buf.Bytes(), nil
+}
+
+// splitQualifiedName splits the RFC7951 JSON member name n into its module
+// and local name, returning parentMod as the module where n is unqualified.
+func splitQualifiedName(n, parentMod string) (string, string) {
+	if i := strings.Index(n, ":"); i != -1 {
+		return n[:i], n[i+1:]
+	}
+	return parentMod, n
+}
+
+// xmlEncoder stores the state used when encoding a data tree as XML.
+type xmlEncoder struct {
+	// buf is the buffer to which the XML document is written.
+	buf bytes.Buffer
+	// indent is the string used to indent each level of the document.
+	indent string
+	// namespaces is the namespace of each module, keyed by module name.
+	namespaces map[string]string
+}
+
+// namespace returns the namespace of the module mod.
+func (e *xmlEncoder) namespace(mod string) (string, error) {
+	ns, ok := e.namespaces[mod]
+	if !ok {
+		return "", fmt.Errorf("cannot find namespace for module %s", mod)
+	}
+	return ns, nil
+}
+
+// newline writes a newline and the indentation for the supplied depth, if
+// the encoder indents its output.
+func (e *xmlEncoder) newline(depth int) {
+	if e.indent == "" {
+		return
+	}
+	if e.buf.Len() != 0 {
+		e.buf.WriteByte('\n')
+	}
+	e.buf.WriteString(strings.Repeat(e.indent, depth))
+}
+
+// start writes the start tag of the element name, declaring ns as its default
+// namespace where it is non-empty, and prefixes as namespace prefixes.
+func (e *xmlEncoder) start(name, ns string, prefixes map[string]string, depth int) {
+	e.newline(depth)
+	e.buf.WriteString("<" + name)
+	e.attributes(ns, prefixes)
+	e.buf.WriteString(">")
+}
+
+// attributes writes the namespace declarations of an element.
+func (e *xmlEncoder) attributes(ns string, prefixes map[string]string) {
+	if ns != "" {
+		e.buf.WriteString(` xmlns="`)
+		xml.EscapeText(&e.buf, []byte(ns))
+		e.buf.WriteString(`"`)
+	}
+	var ps []string
+	for p := range prefixes {
+		ps = append(ps, p)
+	}
+	sort.Strings(ps)
+	for _, p := range ps {
+		e.buf.WriteString(` xmlns:` + p + `="`)
+		xml.EscapeText(&e.buf, []byte(prefixes[p]))
+		e.buf.WriteString(`"`)
+	}
+}
+
+// end writes the end tag of the element name.
+func (e *xmlEncoder) end(name string, depth int) {
+	e.newline(depth)
+	e.buf.WriteString("</" + name + ">")
+}
+
+// children writes an element for each member of the RFC7951 JSON object j,
+// whose members are children of the data node with the supplied schema. mod
+// is the module of the data node, and parentNS is the namespace of its
+// element. Where the data node is a list entry, its keys are written first,
+// followed by its remaining children in name order.
+func (e *xmlEncoder) children(j map[string]interface{}, schema *yang.Entry, mod, parentNS string, depth int) error {
+	names := map[string]string{}
+	var order []string
+	for k := range j {
+		_, n := splitQualifiedName(k, mod)
+		names[n] = k
+		order = append(order, n)
+	}
+	sort.Strings(order)
+	if schema.IsList() {
+		var keys []string
+		isKey := util.ListKeyFieldsMap(schema)
+		for _, k := range strings.Fields(schema.Key) {
+			if _, ok := names[k]; ok {
+				keys = append(keys, k)
+			}
+		}
+		for _, n := range order {
+			if !isKey[n] {
+				keys = append(keys, n)
+			}
+		}
+		order = keys
+	}
+
+	var errs util.Errors
+	for _, n := range order {
+		k := names[n]
+		cmod, _ := splitQualifiedName(k, mod)
+		cs := util.FirstChild(schema, []string{n})
+		if cs == nil {
+			errs = util.AppendErr(errs, fmt.Errorf("cannot find schema for %s within %s", k, schema.Name))
+			continue
+		}
+		ns, err := e.namespace(cmod)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		if err := e.node(j[k], cs, cmod, ns, parentNS, depth); err != nil {
+			errs = util.AppendErr(errs, err)
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// node writes the element, or sequence of elements, for the data node with
+// the supplied schema and RFC7951 JSON value v. mod and ns are the module and
+// namespace of the data node, and parentNS is the namespace of its parent.
+func (e *xmlEncoder) node(v interface{}, schema *yang.Entry, mod, ns, parentNS string, depth int) error {
+	declNS := ns
+	if ns == parentNS {
+		declNS = ""
+	}
+
+	switch {
+	case util.IsAnydata(schema):
+		e.anydata(schema.Name, v, declNS, depth)
+	case schema.IsContainer() || (schema.IsList() && !isJSONArray(v)):
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid value %v for %s, got: %T, want: map[string]interface{}", v, schema.Name, v)
+		}
+		e.start(schema.Name, declNS, nil, depth)
+		if err := e.children(m, schema, mod, ns, depth+1); err != nil {
+			return err
+		}
+		e.end(schema.Name, depth)
+	case schema.IsList():
+		for _, le := range v.([]interface{}) {
+			if err := e.node(le, schema, mod, ns, parentNS, depth); err != nil {
+				return err
+			}
+		}
+	case schema.IsLeafList():
+		vs, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("invalid value %v for leaf-list %s, got: %T, want: []interface{}", v, schema.Name, v)
+		}
+		for _, lv := range vs {
+			if err := e.leaf(lv, schema, declNS, depth); err != nil {
+				return err
+			}
+		}
+	default:
+		return e.leaf(v, schema, declNS, depth)
+	}
+	return nil
+}
+
+// anydata writes the element name for the RFC7951 JSON value v, which is the
+// contents of an anydata or anyxml node, or one of its members, declaring the
+// namespace ns. Since the contents are not described by the schema, the
+// members of an object are written as child elements whose names omit any
+// module qualification, the entries of an array are written as repeated
+// elements, and other values are written as text.
+func (e *xmlEncoder) anydata(name string, v interface{}, ns string, depth int) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		var ks []string
+		for k := range vv {
+			// RFC7952 metadata is not represented in XML.
+			if !strings.HasPrefix(k, "@") {
+				ks = append(ks, k)
+			}
+		}
+		sort.Strings(ks)
+		e.start(name, ns, nil, depth)
+		for _, k := range ks {
+			_, n := splitQualifiedName(k, "")
+			e.anydata(n, vv[k], "", depth+1)
+		}
+		e.end(name, depth)
+	case []interface{}:
+		if len(vv) == 1 && vv[0] == nil {
+			e.anydata(name, nil, ns, depth)
+			return
+		}
+		for _, lv := range vv {
+			e.anydata(name, lv, ns, depth)
+		}
+	case nil:
+		e.newline(depth)
+		e.buf.WriteString("<" + name)
+		e.attributes(ns, nil)
+		e.buf.WriteString("/>")
+	default:
+		e.start(name, ns, nil, depth)
+		xml.EscapeText(&e.buf, []byte(fmt.Sprintf("%v", vv)))
+		e.buf.WriteString("</" + name + ">")
+	}
+}
+
+// leaf writes the element for a leaf, or leaf-list entry, with the supplied
+// schema and RFC7951 JSON value v, declaring the namespace ns.
+func (e *xmlEncoder) leaf(v interface{}, schema *yang.Entry, ns string, depth int) error {
+	// An empty leaf is encoded as [null] in RFC7951 JSON, and an element
+	// without content in XML.
+	if vs, ok := v.([]interface{}); ok {
+		if len(vs) != 1 || vs[0] != nil {
+			return fmt.Errorf("invalid value %v for leaf %s", v, schema.Name)
+		}
+		e.newline(depth)
+		e.buf.WriteString("<" + schema.Name)
+		e.attributes(ns, nil)
+		e.buf.WriteString("/>")
+		return nil
+	}
+
+	var text string
+	var prefixes map[string]string
+	switch vv := v.(type) {
+	case string:
+		text = vv
+		// Identity values are qualified with the name of the module that
+		// defines them, which is used as their namespace prefix.
+		if mod, _ := splitQualifiedName(vv, ""); mod != "" && isIdentityrefSchema(schema) {
+			if ns, ok := e.namespaces[mod]; ok {
+				prefixes = map[string]string{mod: ns}
+			}
+		}
+	case map[string]interface{}, []interface{}:
+		return fmt.Errorf("invalid value %v for leaf %s, got: %T", v, schema.Name, v)
+	default:
+		text = fmt.Sprintf("%v", vv)
+	}
+
+	e.start(schema.Name, ns, prefixes, depth)
+	xml.EscapeText(&e.buf, []byte(text))
+	e.buf.WriteString("</" + schema.Name + ">")
+	return nil
+}
+
+// isJSONArray reports whether v is an RFC7951 JSON array.
+func isJSONArray(v interface{}) bool {
+	_, ok := v.([]interface{})
+	return ok
+}
+
+// isIdentityrefSchema reports whether the leaf or leaf-list with the supplied
+// schema can have an identityref value, following leafrefs where the schema
+// tree allows them to be resolved.
+func isIdentityrefSchema(schema *yang.Entry) bool {
+	if schema.Type == nil {
+		return false
+	}
+	if rs, err := util.ResolveIfLeafRef(schema); err == nil && rs != nil {
+		schema = rs
+	}
+	for _, t := range util.FlattenedTypes([]*yang.YangType{schema.Type}) {
+		if t.Kind == yang.Yidentityref {
+			return true
+		}
+	}
+	return false
+}
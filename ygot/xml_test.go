@@ -0,0 +1,203 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+type xmlRoot struct {
+	Interface map[string]*xmlInterface `path:"interfaces/interface" module:"m1"`
+	Enabled   YANGEmpty                `path:"enabled" module:"m2"`
+}
+
+func (*xmlRoot) IsYANGGoStruct() {}
+
+type xmlInterface struct {
+	Name        *string  `path:"name" module:"m1"`
+	Mtu         *uint16  `path:"mtu" module:"m1"`
+	Type        EnumTest `path:"type" module:"m1"`
+	Address     []string `path:"address" module:"m1"`
+	Description *string  `path:"description" module:"m2"`
+}
+
+func (*xmlInterface) IsYANGGoStruct() {}
+
+func (i *xmlInterface) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"name": *i.Name}, nil
+}
+
+// xmlSchema returns the schema of xmlRoot, which is a fakeroot whose
+// children are defined in the modules m1 and m2. As for a serialised schema,
+// the module of each directory entry is stored within its annotations.
+func xmlSchema() *yang.Entry {
+	root := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Annotation: map[string]interface{}{
+			"isFakeRoot":        true,
+			"module-namespaces": map[string]string{"m1": "urn:m1", "m2": "urn:m2", "bar": "urn:bar"},
+		},
+		Dir: map[string]*yang.Entry{},
+	}
+	intfs := &yang.Entry{
+		Name:       "interfaces",
+		Kind:       yang.DirectoryEntry,
+		Parent:     root,
+		Dir:        map[string]*yang.Entry{},
+		Annotation: map[string]interface{}{"module": "m1"},
+	}
+	intf := &yang.Entry{
+		Name:       "interface",
+		Kind:       yang.DirectoryEntry,
+		ListAttr:   &yang.ListAttr{},
+		Key:        "name",
+		Parent:     intfs,
+		Dir:        map[string]*yang.Entry{},
+		Annotation: map[string]interface{}{"module": "m1"},
+	}
+	intf.Dir["name"] = &yang.Entry{Name: "name", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}, Parent: intf}
+	intf.Dir["mtu"] = &yang.Entry{Name: "mtu", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Yuint16}, Parent: intf}
+	intf.Dir["type"] = &yang.Entry{Name: "type", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Yidentityref}, Parent: intf}
+	intf.Dir["address"] = &yang.Entry{
+		Name:     "address",
+		Kind:     yang.LeafEntry,
+		ListAttr: &yang.ListAttr{},
+		Type:     &yang.YangType{Kind: yang.Ystring},
+		Parent:   intf,
+	}
+	intf.Dir["description"] = &yang.Entry{Name: "description", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}, Parent: intf}
+	intfs.Dir["interface"] = intf
+	root.Dir["interfaces"] = intfs
+	root.Dir["enabled"] = &yang.Entry{Name: "enabled", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Yempty}, Parent: root}
+	return root
+}
+
+func TestMarshalXML(t *testing.T) {
+	schema := xmlSchema()
+	intf := &xmlInterface{
+		Name:        String("eth0"),
+		Mtu:         Uint16(1500),
+		Type:        EnumTestVALTWO,
+		Address:     []string{"192.0.2.1", "192.0.2.2"},
+		Description: String("<uplink>"),
+	}
+
+	tests := []struct {
+		name             string
+		inStruct         GoStruct
+		inSchema         *yang.Entry
+		inConfig         *XMLConfig
+		want             string
+		wantErrSubstring string
+	}{{
+		name:     "fakeroot",
+		inStruct: &xmlRoot{Interface: map[string]*xmlInterface{"eth0": intf}, Enabled: true},
+		inSchema: schema,
+		want: `<enabled xmlns="urn:m2"/>` +
+			`<interfaces xmlns="urn:m1"><interface>` +
+			`<name>eth0</name>` +
+			`<address>192.0.2.1</address><address>192.0.2.2</address>` +
+			`<description xmlns="urn:m2">&lt;uplink&gt;</description>` +
+			`<mtu>1500</mtu>` +
+			`<type xmlns:bar="urn:bar">bar:VAL_TWO</type>` +
+			`</interface></interfaces>`,
+	}, {
+		name:     "fakeroot within NETCONF config element",
+		inStruct: &xmlRoot{Interface: map[string]*xmlInterface{"eth0": {Name: String("eth0")}}},
+		inSchema: schema,
+		inConfig: &XMLConfig{Indent: "  ", RootElement: "config", RootNamespace: NETCONFNamespace},
+		want: `<config xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">
+  <interfaces xmlns="urn:m1">
+    <interface>
+      <name>eth0</name>
+    </interface>
+  </interfaces>
+</config>`,
+	}, {
+		name:     "list entry",
+		inStruct: intf,
+		inSchema: schema.Dir["interfaces"].Dir["interface"],
+		want: `<interface xmlns="urn:m1">` +
+			`<name>eth0</name>` +
+			`<address>192.0.2.1</address><address>192.0.2.2</address>` +
+			`<description xmlns="urn:m2">&lt;uplink&gt;</description>` +
+			`<mtu>1500</mtu>` +
+			`<type xmlns:bar="urn:bar">bar:VAL_TWO</type>` +
+			`</interface>`,
+	}, {
+		name: "list entry whose children are mostly within another module",
+		inStruct: &xmlInterface{
+			Name:        String("eth0"),
+			Description: String("uplink"),
+		},
+		inSchema: func() *yang.Entry {
+			s := xmlSchema().Dir["interfaces"].Dir["interface"]
+			s.Annotation["module"] = "m2"
+			return s
+		}(),
+		want: `<interface xmlns="urn:m2">` +
+			`<name xmlns="urn:m1">eth0</name>` +
+			`<description>uplink</description>` +
+			`</interface>`,
+	}, {
+		name:     "list entry without module",
+		inStruct: intf,
+		inSchema: func() *yang.Entry {
+			s := xmlSchema().Dir["interfaces"].Dir["interface"]
+			delete(s.Annotation, "module")
+			return s
+		}(),
+		wantErrSubstring: "cannot determine the module of interface",
+	}, {
+		name:     "empty struct",
+		inStruct: &xmlRoot{},
+		inSchema: schema,
+		want:     ``,
+	}, {
+		name:             "nil struct",
+		inStruct:         (*xmlRoot)(nil),
+		inSchema:         schema,
+		wantErrSubstring: "cannot marshal nil GoStruct",
+	}, {
+		name:             "schema without namespaces",
+		inStruct:         &xmlRoot{Enabled: true},
+		inSchema:         &yang.Entry{Name: "device", Kind: yang.DirectoryEntry},
+		wantErrSubstring: "does not specify the namespaces of its modules",
+	}, {
+		name:             "data node missing from schema",
+		inStruct:         &xmlRoot{Enabled: true},
+		inSchema:         &yang.Entry{Name: "device", Kind: yang.DirectoryEntry, Annotation: schema.Annotation},
+		wantErrSubstring: "cannot find schema for m2:enabled",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalXML(tt.inStruct, tt.inSchema, tt.inConfig)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MarshalXML: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalXML: did not get expected XML, got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
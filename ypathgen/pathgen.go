@@ -131,6 +131,12 @@ type GenConfig struct {
 	// github.com/openconfig/goyang/pkg/yang library. These specify how the
 	// input YANG files should be parsed.
 	YANGParseOptions yang.Options
+	// EnabledFeatures specifies the YANG features that are supported, keyed
+	// by the name of the module that defines them. If it is non-nil, schema
+	// nodes whose if-feature statements evaluate to false are omitted from
+	// the generated code. This is the same option used by ygen: they must
+	// match for pathgen's generated code to be compatible with it.
+	EnabledFeatures map[string][]string
 	// GeneratingBinary is the name of the binary calling the generator library, it is
 	// included in the header of output files for debugging purposes. If a
 	// string is not specified, the location of the library is utilised.
@@ -189,6 +195,7 @@ func (cg *GenConfig) GeneratePathCode(yangFiles, includePaths []string) (*Genera
 			YANGParseOptions:      cg.YANGParseOptions,
 			ExcludeModules:        cg.ExcludeModules,
 			SkipEnumDeduplication: cg.SkipEnumDeduplication,
+			EnabledFeatures:       cg.EnabledFeatures,
 		},
 		TransformationOptions: ygen.TransformationOpts{
 			CompressBehaviour:                    compressBehaviour,
@@ -518,7 +525,10 @@ func getNodeDataMap(directories map[string]*ygen.Directory, leafTypeMap map[stri
 				continue
 			}
 
-			isLeaf := mType != nil
+			// Anydata nodes have no children, but are not mapped to a
+			// type by ygen.
+			isAnydata := util.IsAnydata(field)
+			isLeaf := mType != nil || isAnydata
 
 			subsumingGoStructName := dir.Name
 			if !isLeaf {
@@ -527,6 +537,8 @@ func getNodeDataMap(directories map[string]*ygen.Directory, leafTypeMap map[stri
 
 			var goTypeName string
 			switch {
+			case isAnydata:
+				goTypeName = "*ygot.Anydata"
 			case !isLeaf:
 				goTypeName = "*" + schemaStructPkgAccessor + subsumingGoStructName
 			case field.ListAttr != nil && ygen.IsYgenDefinedGoType(mType):
@@ -540,7 +552,7 @@ func getNodeDataMap(directories map[string]*ygen.Directory, leafTypeMap map[stri
 			}
 
 			var yangTypeName string
-			if isLeaf {
+			if isLeaf && !isAnydata {
 				yangTypeName = field.Type.Name
 			}
 			nodeDataMap[pathStructName] = &NodeData{
@@ -683,10 +695,10 @@ func generateDirectorySnippet(directory *ygen.Directory, directories map[string]
 			errs = util.AppendErrs(errs, es)
 		}
 
-		// Since leaves don't have their own Directory entries, we need
-		// to output their struct snippets somewhere, and here is
-		// convenient.
-		if field.IsLeaf() || field.IsLeafList() {
+		// Since leaves, and anydata nodes, don't have their own Directory
+		// entries, we need to output their struct snippets somewhere, and
+		// here is convenient.
+		if field.IsLeaf() || field.IsLeafList() || util.IsAnydata(field) {
 			leafTypeName, err := getFieldTypeName(directory, fieldName, goFieldName, directories, pathStructSuffix)
 			if err != nil {
 				errs = util.AppendErr(errs, err)
@@ -967,7 +979,7 @@ func getFieldTypeName(directory *ygen.Directory, directoryFieldName string, goFi
 		return "", fmt.Errorf("getFieldTypeName: field %s not found in directory %v", directoryFieldName, directory)
 	}
 
-	if !field.IsLeaf() && !field.IsLeafList() {
+	if !field.IsLeaf() && !field.IsLeafList() && !util.IsAnydata(field) {
 		fieldDirectory, ok := directories[field.Path()]
 		if !ok {
 			return "", fmt.Errorf("getFieldTypeName: unexpected - field %s not found in parsed yang structs map: %v", field.Path(), directories)
@@ -975,7 +987,7 @@ func getFieldTypeName(directory *ygen.Directory, directoryFieldName string, goFi
 		return fieldDirectory.Name + pathStructSuffix, nil
 	}
 
-	// Leaves do not have corresponding Directory entries, so their names need to be constructed.
+	// Leaves and anydata nodes do not have corresponding Directory entries, so their names need to be constructed.
 	if isTopLevelLeaf := directory.Entry.Parent == nil; isTopLevelLeaf {
 		// When a leaf resides at the root, its type name is its whole name -- we never want fakeroot's name as a prefix.
 		return goFieldName + pathStructSuffix, nil
@@ -1137,6 +1137,18 @@ func TestGetNodeDataMap(t *testing.T) {
 		},
 	}
 
+	anydataEntry := &yang.Entry{Name: "blob", Kind: yang.AnyDataEntry, Parent: binaryContainerEntry}
+	directoryWithAnydata := map[string]*ygen.Directory{
+		"/root-module/container": {
+			Name: "Container",
+			Fields: map[string]*yang.Entry{
+				"blob": anydataEntry,
+			},
+			Path:  []string{"", "root-module", "container"},
+			Entry: binaryContainerEntry,
+		},
+	}
+
 	tests := []struct {
 		name                      string
 		inDirectories             map[string]*ygen.Directory
@@ -1190,6 +1202,26 @@ func TestGetNodeDataMap(t *testing.T) {
 			},
 		},
 		wantSorted: []string{"Container_Leaf_Path", "Container_Path"},
+	}, {
+		name:          "anydata",
+		inDirectories: directoryWithAnydata,
+		inLeafTypeMap: map[string]map[string]*ygen.MappedType{
+			"/root-module/container": {
+				"blob": nil,
+			},
+		},
+		inSchemaStructPkgAccessor: "struct.",
+		inPathStructSuffix:        "Path",
+		wantNodeDataMap: NodeDataMap{
+			"Container_BlobPath": {
+				GoTypeName:            "*ygot.Anydata",
+				GoFieldName:           "Blob",
+				SubsumingGoStructName: "Container",
+				IsLeaf:                true,
+				IsScalarField:         false,
+			},
+		},
+		wantSorted: []string{"Container_BlobPath"},
 	}, {
 		name:          "non-existent path",
 		inDirectories: map[string]*ygen.Directory{"/root-module/container": directories["/root-module/container"]},
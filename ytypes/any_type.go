@@ -15,15 +15,296 @@
 package ytypes
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
-// validateAny validates value against the given schema. Always succeeds.
-func validateAny(schema *yang.Entry, value interface{}) error {
-	return nil
+// validateAny validates value, which must be a *ygot.Anydata, against the
+// schema of an anydata or anyxml node. The contents of an anydata node must
+// be a JSON object. Where a schema has been bound to the contents at runtime,
+// the contents are validated against it.
+func validateAny(schema *yang.Entry, value interface{}) util.Errors {
+	ad, ok := value.(*ygot.Anydata)
+	if !ok {
+		return util.NewErrs(fmt.Errorf("type %T is not a *ygot.Anydata for schema %s", value, schema.Name))
+	}
+	if ad.Value == nil {
+		return nil
+	}
+	if schema.Kind == yang.AnyDataEntry {
+		if _, ok := ad.Value.(map[string]interface{}); !ok {
+			return util.NewErrs(fmt.Errorf("invalid value %v (%T) for anydata %s, want JSON object", ad.Value, ad.Value, schema.Name))
+		}
+	}
+	if ad.Schema == nil {
+		return nil
+	}
+	return validateAnydataTree(ad.Schema, ad.Value)
 }
 
 // validateAnySlice validates value against the given schema. Always succeeds.
 func validateAnySlice(schema *yang.Entry, value interface{}) error {
 	return nil
 }
+
+// validateAnydataTree validates the RFC7951 JSON value v against the schema
+// of the data node that it encodes.
+func validateAnydataTree(schema *yang.Entry, v interface{}) util.Errors {
+	switch {
+	case util.IsAnydata(schema):
+		return nil
+	case schema.IsLeaf():
+		return util.NewErrs(validateAnydataLeaf(schema, v))
+	case schema.IsLeafList():
+		l, ok := v.([]interface{})
+		if !ok {
+			return util.NewErrs(fmt.Errorf("invalid value %v (%T) for leaf-list %s, want JSON array", v, v, schema.Name))
+		}
+		var errs util.Errors
+		for _, lv := range l {
+			errs = util.AppendErr(errs, validateAnydataLeaf(schema, lv))
+		}
+		return errs
+	case schema.IsList():
+		l, ok := v.([]interface{})
+		if !ok {
+			return util.NewErrs(fmt.Errorf("invalid value %v (%T) for list %s, want JSON array", v, v, schema.Name))
+		}
+		var errs util.Errors
+		for _, le := range l {
+			m, ok := le.(map[string]interface{})
+			if !ok {
+				errs = util.AppendErr(errs, fmt.Errorf("invalid entry %v (%T) for list %s, want JSON object", le, le, schema.Name))
+				continue
+			}
+			for _, k := range strings.Fields(schema.Key) {
+				if _, ok := anydataMember(m, k); !ok {
+					errs = util.AppendErr(errs, fmt.Errorf("entry of list %s does not have key %s", schema.Name, k))
+				}
+			}
+			errs = util.AppendErrs(errs, validateAnydataMembers(schema, m))
+		}
+		return errs
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return util.NewErrs(fmt.Errorf("invalid value %v (%T) for %s, want JSON object", v, v, schema.Name))
+	}
+	return validateAnydataMembers(schema, m)
+}
+
+// validateAnydataMembers validates the members of the JSON object m, which
+// encodes the container or list entry with the supplied schema.
+func validateAnydataMembers(schema *yang.Entry, m map[string]interface{}) util.Errors {
+	var names []string
+	for k := range m {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var errs util.Errors
+	for _, k := range names {
+		// RFC7952 metadata is not described by the schema.
+		if strings.HasPrefix(k, "@") {
+			continue
+		}
+		cs := util.FirstChild(schema, []string{k})
+		if cs == nil {
+			errs = util.AppendErr(errs, fmt.Errorf("member %s does not correspond to a data node of %s", k, schema.Name))
+			continue
+		}
+		errs = util.AppendErrs(errs, util.PrefixErrors(validateAnydataTree(cs, m[k]), cs.Path()))
+	}
+	return errs
+}
+
+// anydataMember returns the value of the member of the JSON object m with the
+// supplied name, which may be qualified with the name of a module in m.
+func anydataMember(m map[string]interface{}, name string) (interface{}, bool) {
+	for k, v := range m {
+		if util.StripModulePrefix(k) == name {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// validateAnydataLeaf validates the RFC7951 JSON value v of the leaf, or
+// leaf-list entry, with the supplied schema. Where the leaf is a union, v must
+// be valid for one of its member types.
+func validateAnydataLeaf(schema *yang.Entry, v interface{}) error {
+	if rs, err := util.ResolveIfLeafRef(schema); err == nil && rs != nil {
+		schema = rs
+	}
+	if schema.Type == nil {
+		return fmt.Errorf("leaf %s has nil type", schema.Name)
+	}
+
+	var errs util.Errors
+	for _, t := range util.FlattenedTypes([]*yang.YangType{schema.Type}) {
+		err := validateAnydataScalar(&yang.Entry{Name: schema.Name, Kind: yang.LeafEntry, Type: t}, v)
+		if err == nil {
+			return nil
+		}
+		errs = util.AppendErr(errs, err)
+	}
+	return fmt.Errorf("invalid value %v for leaf %s: %v", v, schema.Name, errs)
+}
+
+// validateAnydataScalar validates the RFC7951 JSON value v against the leaf
+// schema, whose type must not be a union.
+func validateAnydataScalar(schema *yang.Entry, v interface{}) error {
+	t := schema.Type
+	switch t.Kind {
+	case yang.Yempty:
+		if l, ok := v.([]interface{}); !ok || len(l) != 1 || l[0] != nil {
+			return fmt.Errorf("got %v (%T), want [null] for empty type", v, v)
+		}
+		return nil
+	case yang.Ybool:
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("got %v (%T), want bool", v, v)
+		}
+		return nil
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		f, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("got %v (%T), want number for %v type", v, v, t.Kind)
+		}
+		return validateAnydataInt(schema, strconv.FormatFloat(f, 'f', -1, 64))
+	}
+
+	// All other types are encoded as JSON strings.
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("got %v (%T), want string for %v type", v, v, t.Kind)
+	}
+	switch t.Kind {
+	case yang.Yint64, yang.Yuint64:
+		return validateAnydataInt(schema, s)
+	case yang.Ydecimal64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		return validateDecimal(schema, f)
+	case yang.Ystring:
+		return validateString(schema, s)
+	case yang.Ybinary:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return err
+		}
+		if !lengthOk(t.Length, uint64(len(b))) {
+			return fmt.Errorf("length %d is outside range %v", len(b), t.Length)
+		}
+		return nil
+	case yang.Yenum:
+		if t.Enum == nil || !t.Enum.IsDefined(s) {
+			return fmt.Errorf("%q is not a value of the enumeration", s)
+		}
+		return nil
+	case yang.Yidentityref:
+		name := util.StripModulePrefix(s)
+		if t.IdentityBase == nil || !t.IdentityBase.IsDefined(name) {
+			return fmt.Errorf("%q is not an identity derived from the base of the identityref", s)
+		}
+		return nil
+	case yang.Ybits:
+		for _, b := range strings.Fields(s) {
+			if t.Bit == nil || !t.Bit.IsDefined(b) {
+				return fmt.Errorf("%q is not a bit of the bits type", b)
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// validateAnydataInt validates the decimal string s, which encodes a value of
+// the integer leaf schema.
+func validateAnydataInt(schema *yang.Entry, s string) error {
+	bits, err := yangIntTypeBits(schema.Type.Kind)
+	if err != nil {
+		return err
+	}
+	gt := reflect.TypeOf(yangBuiltinTypeToGoType(schema.Type.Kind))
+	var gv reflect.Value
+	if isSigned(schema.Type.Kind) {
+		n, err := strconv.ParseInt(s, 10, bits)
+		if err != nil {
+			return err
+		}
+		gv = reflect.ValueOf(n).Convert(gt)
+	} else {
+		n, err := strconv.ParseUint(s, 10, bits)
+		if err != nil {
+			return err
+		}
+		gv = reflect.ValueOf(n).Convert(gt)
+	}
+	return validateInt(schema, gv.Interface())
+}
+
+// unmarshalAnydata unmarshals the value of the anydata or anyxml node with the
+// supplied schema into its *ygot.Anydata field within parent. With
+// JSONEncoding, value is the RFC7951 JSON value of the node, otherwise it
+// must be a TypedValue with a JSON or JSON_IETF value. A schema that has
+// been bound to the existing contents of the field at runtime is retained.
+func unmarshalAnydata(schema *yang.Entry, parent interface{}, value interface{}, enc Encoding) error {
+	if util.IsValueNil(value) {
+		if enc == JSONEncoding {
+			return nil
+		}
+		return fmt.Errorf("unmarshalAnydata: invalid nil value to unmarshal")
+	}
+
+	if enc != JSONEncoding {
+		tv, ok := value.(*gpb.TypedValue)
+		if !ok {
+			return fmt.Errorf("got type %T, want *gpb.TypedValue for anydata %s", value, schema.Name)
+		}
+		var b []byte
+		switch v := tv.GetValue().(type) {
+		case *gpb.TypedValue_JsonIetfVal:
+			b = v.JsonIetfVal
+		case *gpb.TypedValue_JsonVal:
+			b = v.JsonVal
+		default:
+			return fmt.Errorf("got TypedValue %v for anydata %s, want JSON or JSON_IETF value", tv, schema.Name)
+		}
+		var jv interface{}
+		if err := json.Unmarshal(b, &jv); err != nil {
+			return fmt.Errorf("cannot unmarshal JSON value of anydata %s, %v", schema.Name, err)
+		}
+		value = jv
+	}
+
+	if schema.Kind == yang.AnyDataEntry {
+		if _, ok := value.(map[string]interface{}); !ok {
+			return fmt.Errorf("invalid value %v (%T) for anydata %s, want JSON object", value, value, schema.Name)
+		}
+	}
+
+	fieldName, _, err := schemaToStructFieldName(schema, parent)
+	if err != nil {
+		return err
+	}
+	ad := &ygot.Anydata{Value: value}
+	if prev, ok := reflect.ValueOf(parent).Elem().FieldByName(fieldName).Interface().(*ygot.Anydata); ok && prev != nil {
+		ad.Schema = prev.Schema
+	}
+	return util.InsertIntoStruct(parent, fieldName, ad)
+}
@@ -18,22 +18,40 @@ import (
 	"testing"
 
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
 )
 
 func TestValidateAny(t *testing.T) {
+	anydata := &yang.Entry{Name: "blob", Kind: yang.AnyDataEntry}
+	anyxml := &yang.Entry{Name: "raw", Kind: yang.AnyXMLEntry}
+
 	tests := []struct {
 		desc    string
 		schema  *yang.Entry
 		val     interface{}
 		wantErr bool
 	}{{
-		desc:   "test success",
-		schema: &yang.Entry{},
-		val:    []interface{}{},
+		desc:   "anydata object success",
+		schema: anydata,
+		val:    &ygot.Anydata{Value: map[string]interface{}{"a": "b"}},
 	}, {
-		desc:   "test string success",
-		schema: &yang.Entry{},
-		val:    "xxx",
+		desc:   "anydata without contents success",
+		schema: anydata,
+		val:    &ygot.Anydata{},
+	}, {
+		desc:    "anydata string failure",
+		schema:  anydata,
+		val:     &ygot.Anydata{Value: "xxx"},
+		wantErr: true,
+	}, {
+		desc:   "anyxml string success",
+		schema: anyxml,
+		val:    &ygot.Anydata{Value: "xxx"},
+	}, {
+		desc:    "bad type failure",
+		schema:  anydata,
+		val:     "xxx",
+		wantErr: true,
 	}}
 
 	for _, tt := range tests {
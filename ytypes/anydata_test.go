@@ -0,0 +1,361 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+type adRoot struct {
+	Top *adTop `path:"top" module:"m1"`
+}
+
+func (*adRoot) IsYANGGoStruct() {}
+
+type adTop struct {
+	Name *string       `path:"name" module:"m1"`
+	Blob *ygot.Anydata `path:"blob" module:"m1"`
+	Raw  *ygot.Anydata `path:"raw" module:"m1"`
+}
+
+func (*adTop) IsYANGGoStruct() {}
+
+// adSchema returns the schema of adRoot, which is a fakeroot with a container
+// that has an anydata node, blob, and an anyxml node, raw.
+func adSchema() *yang.Entry {
+	root := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Annotation: map[string]interface{}{
+			"isFakeRoot":        true,
+			"module-namespaces": map[string]string{"m1": "urn:m1"},
+		},
+		Dir: map[string]*yang.Entry{
+			"top": {
+				Name: "top",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"name": {Name: "name", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+					"blob": {Name: "blob", Kind: yang.AnyDataEntry},
+					"raw":  {Name: "raw", Kind: yang.AnyXMLEntry},
+				},
+			},
+		},
+	}
+	populateParentField(nil, root)
+	return root
+}
+
+// adPayloadSchema returns a schema that can be bound to the contents of an
+// anydata node.
+func adPayloadSchema() *yang.Entry {
+	leaf := func(name string, k yang.TypeKind) *yang.Entry {
+		return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: &yang.YangType{Kind: k}}
+	}
+	kind := leaf("kind", yang.Yenum)
+	kind.Type.Enum = yang.NewEnumType()
+	kind.Type.Enum.Set("up", 0)
+	kind.Type.Enum.Set("down", 1)
+	tags := leaf("tags", yang.Ystring)
+	tags.ListAttr = &yang.ListAttr{}
+
+	payload := &yang.Entry{
+		Name: "payload",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"config": {
+				Name: "config",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"mtu":     leaf("mtu", yang.Yuint16),
+					"counter": leaf("counter", yang.Yuint64),
+					"enabled": leaf("enabled", yang.Ybool),
+					"kind":    kind,
+				},
+			},
+			"entry": {
+				Name:     "entry",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: &yang.ListAttr{},
+				Key:      "id",
+				Dir: map[string]*yang.Entry{
+					"id":   leaf("id", yang.Yuint32),
+					"tags": tags,
+				},
+			},
+		},
+	}
+	populateParentField(nil, payload)
+	return payload
+}
+
+func TestUnmarshalAnydata(t *testing.T) {
+	schema := adSchema()
+
+	tests := []struct {
+		desc             string
+		inJSON           string
+		want             *adRoot
+		wantErrSubstring string
+	}{{
+		desc:   "anydata and anyxml",
+		inJSON: `{"m1:top": {"name": "a", "blob": {"m2:x": {"y": [1, "two", null]}}, "raw": "<z/>"}}`,
+		want: &adRoot{Top: &adTop{
+			Name: ygot.String("a"),
+			Blob: &ygot.Anydata{Value: map[string]interface{}{"m2:x": map[string]interface{}{"y": []interface{}{float64(1), "two", nil}}}},
+			Raw:  &ygot.Anydata{Value: "<z/>"},
+		}},
+	}, {
+		desc:   "empty anydata",
+		inJSON: `{"top": {"blob": {}}}`,
+		want:   &adRoot{Top: &adTop{Blob: &ygot.Anydata{Value: map[string]interface{}{}}}},
+	}, {
+		desc:             "anydata that is not an object",
+		inJSON:           `{"top": {"blob": [1]}}`,
+		wantErrSubstring: "want JSON object",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var jt interface{}
+			if err := json.Unmarshal([]byte(tt.inJSON), &jt); err != nil {
+				t.Fatalf("json.Unmarshal(%s): got unexpected error, %v", tt.inJSON, err)
+			}
+			got := &adRoot{}
+			err := Unmarshal(schema, got, jt)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Unmarshal: did not get expected error, %s", diff)
+			}
+			if err == nil {
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("Unmarshal: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+				}
+			}
+
+			streamed := &adRoot{}
+			err = UnmarshalJSONStream(schema, streamed, bytes.NewReader([]byte(tt.inJSON)))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalJSONStream: did not get expected error, %s", diff)
+			}
+			if err == nil {
+				if diff := cmp.Diff(tt.want, streamed); diff != "" {
+					t.Errorf("UnmarshalJSONStream: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUnmarshalAnydataRetainsSchema(t *testing.T) {
+	payload := adPayloadSchema()
+	top := &adTop{Blob: &ygot.Anydata{Schema: payload}}
+	if err := Unmarshal(adSchema().Dir["top"], top, map[string]interface{}{"blob": map[string]interface{}{"config": map[string]interface{}{"mtu": float64(1500)}}}); err != nil {
+		t.Fatalf("Unmarshal: got unexpected error, %v", err)
+	}
+	if top.Blob.Schema != payload {
+		t.Errorf("Unmarshal: did not retain bound schema, got: %v, want: %v", top.Blob.Schema, payload)
+	}
+	if got, want := top.Blob.Value, interface{}(map[string]interface{}{"config": map[string]interface{}{"mtu": float64(1500)}}); !cmp.Equal(got, want) {
+		t.Errorf("Unmarshal: did not get expected value, got: %v, want: %v", got, want)
+	}
+}
+
+func TestSetNodeAnydata(t *testing.T) {
+	schema := adSchema()
+	path := &gpb.Path{Elem: []*gpb.PathElem{{Name: "top"}, {Name: "blob"}}}
+
+	tests := []struct {
+		desc             string
+		inVal            interface{}
+		want             *adRoot
+		wantErrSubstring string
+	}{{
+		desc:  "JSON_IETF value",
+		inVal: &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"m2:x": 1}`)}},
+		want:  &adRoot{Top: &adTop{Blob: &ygot.Anydata{Value: map[string]interface{}{"m2:x": float64(1)}}}},
+	}, {
+		desc:  "JSON value",
+		inVal: &gpb.TypedValue{Value: &gpb.TypedValue_JsonVal{JsonVal: []byte(`{"x": "y"}`)}},
+		want:  &adRoot{Top: &adTop{Blob: &ygot.Anydata{Value: map[string]interface{}{"x": "y"}}}},
+	}, {
+		desc:             "scalar value",
+		inVal:            &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "x"}},
+		wantErrSubstring: "want JSON or JSON_IETF value",
+	}, {
+		desc:             "invalid JSON",
+		inVal:            &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{`)}},
+		wantErrSubstring: "cannot unmarshal JSON value of anydata blob",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &adRoot{}
+			err := SetNode(schema, got, path, tt.inVal, &InitMissingElements{})
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("SetNode: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("SetNode: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateAnydataSchema(t *testing.T) {
+	schema := adSchema().Dir["top"]
+	payload := adPayloadSchema()
+
+	tests := []struct {
+		desc             string
+		inJSON           string
+		wantErrSubstring string
+	}{{
+		desc: "valid contents",
+		inJSON: `{
+			"m3:config": {"mtu": 1500, "counter": "42", "enabled": true, "kind": "up"},
+			"entry": [{"id": 1, "tags": ["a", "b"]}, {"id": 2}],
+			"@entry": {"m:x": 1}
+		}`,
+	}, {
+		desc:             "value out of range",
+		inJSON:           `{"config": {"mtu": 70000}}`,
+		wantErrSubstring: "invalid value 70000 for leaf mtu",
+	}, {
+		desc:             "64-bit integer as number",
+		inJSON:           `{"config": {"counter": 42}}`,
+		wantErrSubstring: "want string for uint64 type",
+	}, {
+		desc:             "undefined enumeration value",
+		inJSON:           `{"config": {"kind": "sideways"}}`,
+		wantErrSubstring: `"sideways" is not a value of the enumeration`,
+	}, {
+		desc:             "unknown member",
+		inJSON:           `{"config": {"bogus": 1}}`,
+		wantErrSubstring: "member bogus does not correspond to a data node of config",
+	}, {
+		desc:             "list entry without key",
+		inJSON:           `{"entry": [{"tags": ["a"]}]}`,
+		wantErrSubstring: "entry of list entry does not have key id",
+	}, {
+		desc:             "container that is not an object",
+		inJSON:           `{"config": [1]}`,
+		wantErrSubstring: "want JSON object",
+	}, {
+		desc:             "leaf-list that is not an array",
+		inJSON:           `{"entry": [{"id": 1, "tags": "a"}]}`,
+		wantErrSubstring: "want JSON array",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ad, err := ygot.NewAnydataFromJSON([]byte(tt.inJSON))
+			if err != nil {
+				t.Fatalf("NewAnydataFromJSON(%s): got unexpected error, %v", tt.inJSON, err)
+			}
+			ad.Schema = payload
+			err = nil
+			if errs := Validate(schema, &adTop{Blob: ad}); errs != nil {
+				err = errs
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Errorf("Validate: did not get expected error, %s", diff)
+			}
+		})
+	}
+}
+
+func TestAnydataXMLAndCBOR(t *testing.T) {
+	schema := adSchema()
+
+	t.Run("XML", func(t *testing.T) {
+		in := &adRoot{Top: &adTop{
+			Blob: &ygot.Anydata{Value: map[string]interface{}{"a": map[string]interface{}{"b": "x", "c": []interface{}{"y", "z"}}}},
+			Raw:  &ygot.Anydata{Value: "text"},
+		}}
+		b, err := ygot.MarshalXML(in, schema, nil)
+		if err != nil {
+			t.Fatalf("MarshalXML: got unexpected error, %v", err)
+		}
+		got := &adRoot{}
+		if err := UnmarshalXML(schema, got, b); err != nil {
+			t.Fatalf("UnmarshalXML(%s): got unexpected error, %v", b, err)
+		}
+		if diff := cmp.Diff(in, got); diff != "" {
+			t.Errorf("UnmarshalXML(%s): did not get expected GoStruct, diff(-want, +got):\n%s", b, diff)
+		}
+	})
+
+	t.Run("CBOR", func(t *testing.T) {
+		in := &adRoot{Top: &adTop{
+			Blob: &ygot.Anydata{Value: map[string]interface{}{"m2:a": map[string]interface{}{"n": float64(-3), "f": 1.5, "t": true, "l": []interface{}{"x", nil}}}},
+		}}
+		b, err := ygot.MarshalCBOR(in, schema, nil)
+		if err != nil {
+			t.Fatalf("MarshalCBOR: got unexpected error, %v", err)
+		}
+		got := &adRoot{}
+		if err := UnmarshalCBOR(schema, got, b); err != nil {
+			t.Fatalf("UnmarshalCBOR(%x): got unexpected error, %v", b, err)
+		}
+		if diff := cmp.Diff(in, got); diff != "" {
+			t.Errorf("UnmarshalCBOR(%x): did not get expected GoStruct, diff(-want, +got):\n%s", b, diff)
+		}
+	})
+}
+
+func TestValidateMustWhenAnydata(t *testing.T) {
+	schema := adSchema()
+	schema.Dir["top"].Dir["name"].Annotation = map[string]interface{}{
+		util.WhenAnnotation: "../blob",
+	}
+
+	tests := []struct {
+		desc     string
+		in       *adRoot
+		wantErrs []string
+	}{{
+		desc: "anydata exists",
+		in:   &adRoot{Top: &adTop{Name: ygot.String("a"), Blob: &ygot.Anydata{Value: map[string]interface{}{"x": "y"}}}},
+	}, {
+		desc:     "anydata does not exist",
+		in:       &adRoot{Top: &adTop{Name: ygot.String("a")}},
+		wantErrs: []string{`schema path /device/top/name, data path /top/name: data node exists but when statement "../blob" is false`},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var gotErrs []string
+			for _, err := range ValidateMustWhenData(schema, tt.in, nil) {
+				gotErrs = append(gotErrs, err.Error())
+			}
+			if diff := cmp.Diff(tt.wantErrs, gotErrs); diff != "" {
+				t.Errorf("ValidateMustWhenData: did not get expected errors, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,717 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// CBORSIDs is an unmarshal option that supplies the SIDs that are used to
+// decode CBOR data that identifies data nodes or identities by SID. It is
+// used by UnmarshalCBOR, and ignored by other functions.
+type CBORSIDs struct {
+	SIDs *ygot.SIDs
+}
+
+// IsUnmarshalOpt marks CBORSIDs as a valid UnmarshalOpt.
+func (*CBORSIDs) IsUnmarshalOpt() {}
+
+// cborTagged is a CBOR data item that is tagged with a tag number.
+type cborTagged struct {
+	tag     uint64
+	content interface{}
+}
+
+// cborNegInt is a CBOR negative integer, whose value is -1-n.
+type cborNegInt uint64
+
+// UnmarshalCBOR unmarshals the CBOR data, encoded following the rules of
+// RFC9254, into the parent GoStruct using the supplied schema, which must be
+// the schema of parent. Where schema is the root of the schema tree, data is a
+// CBOR map with a member for each top-level data node, otherwise it is a map
+// with a member for each child of parent. Any values already in the parent
+// that are not present in data are preserved.
+//
+// Members may be identified by name or SID, and identityref values may be
+// text strings or SIDs. Where SIDs are used, they are resolved using the SIDs
+// supplied by the CBORSIDs option. The names of enumeration values are taken
+// from the generated enumerated types of parent where it is a generated
+// GoStruct, since the schema within generated code does not retain them.
+//
+// The CBOR data is converted to an RFC7951 JSON tree, which is unmarshalled
+// using Unmarshal with the supplied options. Where IgnoreExtraFields is
+// specified, members that do not correspond to a data node of the schema are
+// discarded.
+func UnmarshalCBOR(schema *yang.Entry, parent interface{}, data []byte, opts ...UnmarshalOpt) error {
+	if schema == nil {
+		return fmt.Errorf("nil schema for parent type %T", parent)
+	}
+
+	d := &cborDecoder{ignoreExtra: hasIgnoreExtraFields(opts)}
+	if s, ok := parent.(ygot.ValidatedGoStruct); ok {
+		d.enumTypes = s.ΛEnumTypeMap()
+	}
+	for _, o := range opts {
+		if s, ok := o.(*CBORSIDs); ok {
+			d.sids = s.SIDs
+		}
+	}
+
+	v, rest, err := parseCBOR(data)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("cannot parse CBOR, %d bytes after data item", len(rest))
+	}
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return fmt.Errorf("invalid CBOR data for %s, got: %T, want map", schema.Name, v)
+	}
+
+	var path string
+	var sid uint64
+	if schema.Parent != nil && !util.IsFakeRoot(schema) && d.sids != nil {
+		if p, ok := d.sids.DataPathForNames(util.SchemaPathNoChoiceCase(schema)[1:]); ok {
+			path = p
+			sid, _ = d.sids.DataSID(p)
+		}
+	}
+
+	jsonTree, err := d.container(m, schema, path, sid)
+	if err != nil {
+		return err
+	}
+	return Unmarshal(schema, parent, jsonTree, opts...)
+}
+
+// parseCBOR parses the first CBOR data item in data, returning its value and
+// the remaining bytes. Maps are returned as map[interface{}]interface{},
+// arrays as []interface{}, unsigned integers as uint64, negative integers as
+// cborNegInt, byte strings as []byte, text strings as string, tagged items as
+// cborTagged, floating-point numbers as float64, and the simple values false,
+// true and null as false, true and nil respectively.
+func parseCBOR(data []byte) (interface{}, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, errors.New("cannot parse CBOR, unexpected end of data")
+	}
+	major, info := data[0]>>5, data[0]&0x1f
+	data = data[1:]
+
+	// Indefinite-length strings, arrays and maps are terminated by a break.
+	if info == 31 {
+		switch major {
+		case 2, 3:
+			var s []byte
+			for {
+				if len(data) == 0 {
+					return nil, nil, errors.New("cannot parse CBOR, unterminated string")
+				}
+				if data[0] == 0xff {
+					data = data[1:]
+					break
+				}
+				var c interface{}
+				var err error
+				if c, data, err = parseCBOR(data); err != nil {
+					return nil, nil, err
+				}
+				switch cc := c.(type) {
+				case []byte:
+					s = append(s, cc...)
+				case string:
+					s = append(s, cc...)
+				default:
+					return nil, nil, fmt.Errorf("cannot parse CBOR, invalid string chunk %T", c)
+				}
+			}
+			if major == 2 {
+				return s, data, nil
+			}
+			return string(s), data, nil
+		case 4, 5:
+			var l []interface{}
+			for {
+				if len(data) == 0 {
+					return nil, nil, errors.New("cannot parse CBOR, unterminated container")
+				}
+				if data[0] == 0xff {
+					data = data[1:]
+					break
+				}
+				var c interface{}
+				var err error
+				if c, data, err = parseCBOR(data); err != nil {
+					return nil, nil, err
+				}
+				l = append(l, c)
+			}
+			if major == 4 {
+				return l, data, nil
+			}
+			return cborPairs(l, data)
+		}
+		return nil, nil, fmt.Errorf("cannot parse CBOR, invalid indefinite length for major type %d", major)
+	}
+
+	var n uint64
+	switch {
+	case info < 24:
+		n = uint64(info)
+	case info <= 27:
+		size := 1 << (info - 24)
+		if len(data) < size {
+			return nil, nil, errors.New("cannot parse CBOR, unexpected end of data")
+		}
+		for _, b := range data[:size] {
+			n = n<<8 | uint64(b)
+		}
+		data = data[size:]
+	default:
+		return nil, nil, fmt.Errorf("cannot parse CBOR, invalid additional information %d", info)
+	}
+
+	switch major {
+	case 0:
+		return n, data, nil
+	case 1:
+		return cborNegInt(n), data, nil
+	case 2, 3:
+		if uint64(len(data)) < n {
+			return nil, nil, errors.New("cannot parse CBOR, unexpected end of data")
+		}
+		if major == 2 {
+			return append([]byte{}, data[:n]...), data[n:], nil
+		}
+		return string(data[:n]), data[n:], nil
+	case 4, 5:
+		count := n
+		if major == 5 {
+			count *= 2
+		}
+		var l []interface{}
+		for i := uint64(0); i < count; i++ {
+			var c interface{}
+			var err error
+			if c, data, err = parseCBOR(data); err != nil {
+				return nil, nil, err
+			}
+			l = append(l, c)
+		}
+		if major == 4 {
+			return l, data, nil
+		}
+		return cborPairs(l, data)
+	case 6:
+		c, rest, err := parseCBOR(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cborTagged{tag: n, content: c}, rest, nil
+	}
+
+	switch info {
+	case 20:
+		return false, data, nil
+	case 21:
+		return true, data, nil
+	case 22, 23:
+		return nil, data, nil
+	case 25:
+		return halfFloat(uint16(n)), data, nil
+	case 26:
+		return float64(math.Float32frombits(uint32(n))), data, nil
+	case 27:
+		return math.Float64frombits(n), data, nil
+	}
+	return nil, nil, fmt.Errorf("cannot parse CBOR, unsupported simple value %d", n)
+}
+
+// cborPairs returns the map whose keys and values alternate in l, along with
+// rest, as returned by parseCBOR.
+func cborPairs(l []interface{}, rest []byte) (interface{}, []byte, error) {
+	if len(l)%2 != 0 {
+		return nil, nil, errors.New("cannot parse CBOR, map has key without value")
+	}
+	m := map[interface{}]interface{}{}
+	for i := 0; i < len(l); i += 2 {
+		if !cborKeyValid(l[i]) {
+			return nil, nil, fmt.Errorf("cannot parse CBOR, unsupported map key %v (%T)", l[i], l[i])
+		}
+		m[l[i]] = l[i+1]
+	}
+	return m, rest, nil
+}
+
+// cborKeyValid reports whether the CBOR value k can be used as a map key,
+// which requires that it is comparable.
+func cborKeyValid(k interface{}) bool {
+	switch kk := k.(type) {
+	case map[interface{}]interface{}, []interface{}, []byte:
+		return false
+	case cborTagged:
+		return cborKeyValid(kk.content)
+	}
+	return true
+}
+
+// halfFloat returns the value of the IEEE 754 half-precision number h.
+func halfFloat(h uint16) float64 {
+	exp, mant := int(h>>10)&0x1f, float64(h&0x3ff)
+	var v float64
+	switch exp {
+	case 0:
+		v = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			v = math.Inf(1)
+		} else {
+			v = math.NaN()
+		}
+	default:
+		v = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -v
+	}
+	return v
+}
+
+// cborDecoder stores the state used when converting CBOR data to an RFC7951
+// JSON tree.
+type cborDecoder struct {
+	// sids is the set of SIDs used to resolve SIDs in the data, or nil if
+	// none were supplied.
+	sids *ygot.SIDs
+	// ignoreExtra specifies whether members that do not correspond to a
+	// data node are discarded rather than causing an error.
+	ignoreExtra bool
+	// enumTypes is the map, keyed by schema path, of the generated
+	// enumerated types of the leaves of the data tree, as returned by the
+	// ΛEnumTypeMap method of the parent GoStruct.
+	enumTypes map[string][]reflect.Type
+}
+
+// container returns the RFC7951 JSON object that corresponds to the CBOR map
+// m, whose members are children of the data node with the supplied schema,
+// schema node path and SID.
+func (d *cborDecoder) container(m map[interface{}]interface{}, schema *yang.Entry, path string, sid uint64) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	for k, v := range m {
+		name, cpath, csid, err := d.memberName(k, path, sid)
+		if err != nil {
+			return nil, err
+		}
+		var cs *yang.Entry
+		if name != "" {
+			cs = util.FirstChild(schema, []string{util.StripModulePrefix(name)})
+		}
+		if cs == nil {
+			if d.ignoreExtra {
+				continue
+			}
+			return nil, fmt.Errorf("member %v does not correspond to a data node of %s", k, schema.Name)
+		}
+
+		jv, err := d.node(v, cs, cpath, csid)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = jv
+	}
+	return out, nil
+}
+
+// memberName returns the RFC7951 JSON member name, schema node path and SID
+// of the member with the CBOR key k within the data node with the supplied
+// schema node path and SID. The returned name is empty where k is a SID that
+// does not identify a data node. The SID of the member is zero if it is
+// unknown.
+func (d *cborDecoder) memberName(k interface{}, path string, sid uint64) (string, string, uint64, error) {
+	var csid uint64
+	switch kk := k.(type) {
+	case string:
+		cpath := fmt.Sprintf("%s/%s", path, kk)
+		if i := strings.Index(kk, ":"); i != -1 && kk[:i] == ygot.SchemaNodePathModule(path) {
+			cpath = fmt.Sprintf("%s/%s", path, kk[i+1:])
+		}
+		if d.sids != nil {
+			csid, _ = d.sids.DataSID(cpath)
+		}
+		return kk, cpath, csid, nil
+	case uint64:
+		csid = sid + kk
+	case cborNegInt:
+		csid = sid - uint64(kk) - 1
+	case cborTagged:
+		abs, ok := kk.content.(uint64)
+		if kk.tag != ygot.CBORTagSID || !ok {
+			return "", "", 0, fmt.Errorf("invalid member key %v", k)
+		}
+		csid = abs
+	default:
+		return "", "", 0, fmt.Errorf("invalid member key %v (%T)", k, k)
+	}
+
+	if d.sids == nil {
+		return "", "", 0, fmt.Errorf("member key %v is a SID, but no SIDs were supplied", k)
+	}
+	cpath, ok := d.sids.DataPath(csid)
+	if !ok {
+		return "", "", 0, nil
+	}
+	return cpath[strings.LastIndex(cpath, "/")+1:], cpath, csid, nil
+}
+
+// node returns the RFC7951 JSON value that corresponds to the CBOR value v of
+// the data node with the supplied schema, schema node path and SID.
+func (d *cborDecoder) node(v interface{}, schema *yang.Entry, path string, sid uint64) (interface{}, error) {
+	switch {
+	case util.IsAnydata(schema):
+		return cborAnydataValue(v)
+	case schema.IsContainer():
+		m, ok := v.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid value %v for %s, got: %T, want: map", v, schema.Name, v)
+		}
+		return d.container(m, schema, path, sid)
+	case schema.IsList(), schema.IsLeafList():
+		vs, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid value %v for %s, got: %T, want: array", v, schema.Name, v)
+		}
+		out := []interface{}{}
+		for _, lv := range vs {
+			var jv interface{}
+			var err error
+			if schema.IsList() {
+				m, ok := lv.(map[interface{}]interface{})
+				if !ok {
+					return nil, fmt.Errorf("invalid list entry %v for %s, got: %T, want: map", lv, schema.Name, lv)
+				}
+				jv, err = d.container(m, schema, path, sid)
+			} else {
+				jv, err = d.leaf(lv, schema)
+			}
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, jv)
+		}
+		return out, nil
+	}
+	return d.leaf(v, schema)
+}
+
+// cborAnydataValue returns the RFC7951 JSON value that corresponds to the CBOR
+// value v, which is the contents of an anydata or anyxml node. Since the
+// contents are not described by the schema, maps must be keyed by text
+// strings, which are used as the names of the members of the JSON object.
+func cborAnydataValue(v interface{}) (interface{}, error) {
+	switch vv := v.(type) {
+	case map[interface{}]interface{}:
+		out := map[string]interface{}{}
+		for k, mv := range vv {
+			ks, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid anydata member key %v (%T), want text string", k, k)
+			}
+			jv, err := cborAnydataValue(mv)
+			if err != nil {
+				return nil, err
+			}
+			out[ks] = jv
+		}
+		return out, nil
+	case []interface{}:
+		out := []interface{}{}
+		for _, lv := range vv {
+			jv, err := cborAnydataValue(lv)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, jv)
+		}
+		return out, nil
+	case uint64:
+		return float64(vv), nil
+	case cborNegInt:
+		return -1 - float64(vv), nil
+	case []byte:
+		return base64.StdEncoding.EncodeToString(vv), nil
+	case string, bool, float64, nil:
+		return v, nil
+	}
+	return nil, fmt.Errorf("invalid anydata value %v (%T)", v, v)
+}
+
+// leaf returns the RFC7951 JSON value that corresponds to the CBOR value v of
+// the leaf, or leaf-list entry, with the supplied schema. Where the leaf is a
+// union, the value of the first member type that v is valid for is returned.
+func (d *cborDecoder) leaf(v interface{}, schema *yang.Entry) (interface{}, error) {
+	if schema.Type == nil {
+		return nil, fmt.Errorf("leaf %s has nil type", schema.Name)
+	}
+	ets := d.enumTypes[absoluteSchemaDataPath(schema)]
+	if rs, err := util.ResolveIfLeafRef(schema); err == nil && rs != nil {
+		schema = rs
+		if len(ets) == 0 {
+			ets = d.enumTypes[absoluteSchemaDataPath(schema)]
+		}
+	}
+	enumNames := goEnumNames(ets)
+
+	var errs util.Errors
+	for _, t := range util.FlattenedTypes([]*yang.YangType{schema.Type}) {
+		jv, err := d.scalar(v, t, enumNames)
+		if err == nil {
+			return jv, nil
+		}
+		errs = util.AppendErr(errs, err)
+	}
+	return nil, fmt.Errorf("invalid value %v for leaf %s: %v", v, schema.Name, errs)
+}
+
+// scalar returns the RFC7951 JSON value that corresponds to the CBOR value v
+// of the YANG type t. The names of enumeration values are taken from
+// enumNames, which are those of the generated enumerated types of the leaf,
+// or otherwise from t. Tagged values are only valid for the type that the tag
+// identifies.
+func (d *cborDecoder) scalar(v interface{}, t *yang.YangType, enumNames map[int64]string) (interface{}, error) {
+	if tv, ok := v.(cborTagged); ok {
+		want := map[uint64]yang.TypeKind{
+			ygot.CBORTagDecimalFraction: yang.Ydecimal64,
+			ygot.CBORTagBits:            yang.Ybits,
+			ygot.CBORTagEnumeration:     yang.Yenum,
+			ygot.CBORTagIdentityref:     yang.Yidentityref,
+		}
+		if k, ok := want[tv.tag]; !ok || k != t.Kind {
+			return nil, fmt.Errorf("tag %d is not valid for type %v", tv.tag, t.Kind)
+		}
+		if tv.tag != ygot.CBORTagDecimalFraction {
+			v = tv.content
+		}
+	}
+
+	switch t.Kind {
+	case yang.Yempty:
+		if v != nil {
+			return nil, fmt.Errorf("got %v (%T), want null for empty", v, v)
+		}
+		return []interface{}{nil}, nil
+	case yang.Ybool:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("got %v (%T), want bool", v, v)
+		}
+		return b, nil
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64, yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		return cborIntValue(v, t.Kind)
+	case yang.Ydecimal64:
+		return cborDecimalValue(v)
+	case yang.Ystring, yang.YinstanceIdentifier:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("got %v (%T), want text string", v, v)
+		}
+		return s, nil
+	case yang.Ybinary:
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("got %v (%T), want byte string", v, v)
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	case yang.Yenum:
+		if len(enumNames) == 0 && t.Enum == nil {
+			return nil, errors.New("enumeration has no values")
+		}
+		if s, ok := v.(string); ok {
+			for _, n := range enumNames {
+				if n == s {
+					return s, nil
+				}
+			}
+			if t.Enum != nil && t.Enum.IsDefined(s) {
+				return s, nil
+			}
+		}
+		i, err := cborInt64(v)
+		if err != nil {
+			return nil, err
+		}
+		n, ok := enumNames[i]
+		if !ok && t.Enum != nil {
+			n = t.Enum.Name(i)
+		}
+		if n == "" {
+			return nil, fmt.Errorf("%d is not a valid enumeration value", i)
+		}
+		return n, nil
+	case yang.Ybits:
+		if t.Bit == nil {
+			return nil, errors.New("bits has no positions")
+		}
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("got %v (%T), want byte string", v, v)
+		}
+		var names []string
+		for i, byt := range b {
+			for j := 0; j < 8; j++ {
+				if byt&(1<<uint(j)) == 0 {
+					continue
+				}
+				n := t.Bit.Name(int64(i*8 + j))
+				if n == "" {
+					return nil, fmt.Errorf("%d is not a valid bit position", i*8+j)
+				}
+				names = append(names, n)
+			}
+		}
+		return strings.Join(names, " "), nil
+	case yang.Yidentityref:
+		switch vv := v.(type) {
+		case string:
+			if !strings.Contains(vv, ":") {
+				return nil, fmt.Errorf("identity %s is not qualified with its module", vv)
+			}
+			return vv, nil
+		case uint64:
+			if d.sids == nil {
+				return nil, fmt.Errorf("identity %d is a SID, but no SIDs were supplied", vv)
+			}
+			id, ok := d.sids.Identity(vv)
+			if !ok {
+				return nil, fmt.Errorf("%d is not the SID of an identity", vv)
+			}
+			return id, nil
+		}
+		return nil, fmt.Errorf("got %v (%T), want text string or SID", v, v)
+	}
+	return nil, fmt.Errorf("unsupported type %v", t.Kind)
+}
+
+// goEnumNames returns the names of the enumeration values defined by the
+// generated enumerated types ets, keyed by their YANG value. The identities
+// within ets are not included. Since the generated types reserve zero for the
+// unset value, the YANG value of each enumeration value is one less than its
+// value within the generated code.
+func goEnumNames(ets []reflect.Type) map[int64]string {
+	names := map[int64]string{}
+	for _, et := range ets {
+		ge, ok := reflect.Zero(et).Interface().(ygot.GoEnum)
+		if !ok {
+			continue
+		}
+		for v, d := range ge.ΛMap()[et.Name()] {
+			if d.DefiningModule == "" {
+				names[v-1] = d.Name
+			}
+		}
+	}
+	return names
+}
+
+// cborInt64 returns the CBOR integer v as an int64.
+func cborInt64(v interface{}) (int64, error) {
+	switch vv := v.(type) {
+	case uint64:
+		if vv > math.MaxInt64 {
+			return 0, fmt.Errorf("%d overflows int64", vv)
+		}
+		return int64(vv), nil
+	case cborNegInt:
+		if vv > math.MaxInt64 {
+			return 0, fmt.Errorf("-1-%d overflows int64", uint64(vv))
+		}
+		return -1 - int64(vv), nil
+	}
+	return 0, fmt.Errorf("got %v (%T), want integer", v, v)
+}
+
+// cborIntValue returns the RFC7951 JSON value of the CBOR integer v of the
+// integer type of kind k, which is a number for types of up to 32 bits and a
+// string otherwise.
+func cborIntValue(v interface{}, k yang.TypeKind) (interface{}, error) {
+	bits, err := yangIntTypeBits(k)
+	if err != nil {
+		return nil, err
+	}
+	var s string
+	switch k {
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		u, ok := v.(uint64)
+		if !ok {
+			return nil, fmt.Errorf("got %v (%T), want unsigned integer", v, v)
+		}
+		s = strconv.FormatUint(u, 10)
+		if _, err := strconv.ParseUint(s, 10, bits); err != nil {
+			return nil, err
+		}
+	default:
+		i, err := cborInt64(v)
+		if err != nil {
+			return nil, err
+		}
+		s = strconv.FormatInt(i, 10)
+		if _, err := strconv.ParseInt(s, 10, bits); err != nil {
+			return nil, err
+		}
+	}
+	if bits == 64 {
+		return s, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// cborDecimalValue returns the RFC7951 JSON value of the CBOR decimal
+// fraction v, which is a string.
+func cborDecimalValue(v interface{}) (interface{}, error) {
+	tv, ok := v.(cborTagged)
+	if !ok || tv.tag != ygot.CBORTagDecimalFraction {
+		return nil, fmt.Errorf("got %v (%T), want decimal fraction", v, v)
+	}
+	l, ok := tv.content.([]interface{})
+	if !ok || len(l) != 2 {
+		return nil, fmt.Errorf("invalid decimal fraction %v", tv.content)
+	}
+	exp, err := cborInt64(l[0])
+	if err != nil {
+		return nil, err
+	}
+	mant, err := cborInt64(l[1])
+	if err != nil {
+		return nil, err
+	}
+	if exp > 0 || exp < -18 {
+		return nil, fmt.Errorf("invalid decimal fraction exponent %d", exp)
+	}
+	r := new(big.Rat).SetFrac(big.NewInt(mant), new(big.Int).Exp(big.NewInt(10), big.NewInt(-exp), nil))
+	return r.FloatString(int(-exp)), nil
+}
@@ -0,0 +1,401 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// mustHex returns the bytes encoded by the hexadecimal string s, which may
+// contain whitespace for readability.
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		t.Fatalf("invalid hex string %s, %v", s, err)
+	}
+	return b
+}
+
+// cborTestSIDs returns the SIDs of the data nodes of the schema returned by
+// xmlSchema.
+func cborTestSIDs(t *testing.T) *ygot.SIDs {
+	s := ygot.NewSIDs()
+	for p, sid := range map[string]uint64{
+		"/m1:interfaces":                          1000,
+		"/m1:interfaces/interface":                1001,
+		"/m1:interfaces/interface/name":           1002,
+		"/m1:interfaces/interface/mtu":            1003,
+		"/m1:interfaces/interface/counter":        1004,
+		"/m1:interfaces/interface/type":           1005,
+		"/m1:interfaces/interface/address":        1006,
+		"/m1:interfaces/interface/m2:description": 1007,
+		"/m2:enabled":                             900,
+	} {
+		if err := s.AddDataSID(p, sid); err != nil {
+			t.Fatalf("cannot add SID for %s, %v", p, err)
+		}
+	}
+	if err := s.AddIdentitySID("m3:ETHERNET", 3000); err != nil {
+		t.Fatalf("cannot add SID for identity, %v", err)
+	}
+	return s
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	schema := xmlSchema()
+	sids := cborTestSIDs(t)
+	in := &xmlRoot{
+		Interface: map[string]*xmlInterface{
+			"eth0": {
+				Name:        ygot.String("eth0"),
+				Mtu:         ygot.Uint16(1500),
+				Counter:     ygot.Uint64(math.MaxUint64),
+				Type:        1,
+				Address:     []string{"192.0.2.1", "192.0.2.2"},
+				Description: ygot.String("uplink"),
+			},
+			"eth1": {Name: ygot.String("eth1")},
+		},
+		Enabled: true,
+	}
+
+	for _, sids := range []*ygot.SIDs{nil, sids} {
+		b, err := ygot.MarshalCBOR(in, schema, &ygot.CBORConfig{SIDs: sids})
+		if err != nil {
+			t.Fatalf("MarshalCBOR(SIDs: %v): got unexpected error, %v", sids != nil, err)
+		}
+		got := &xmlRoot{}
+		if err := UnmarshalCBOR(schema, got, b, &CBORSIDs{SIDs: sids}); err != nil {
+			t.Fatalf("UnmarshalCBOR(SIDs: %v, %x): got unexpected error, %v", sids != nil, b, err)
+		}
+		if diff := cmp.Diff(in, got); diff != "" {
+			t.Errorf("UnmarshalCBOR(SIDs: %v, %x): did not get expected GoStruct, diff(-want, +got):\n%s", sids != nil, b, diff)
+		}
+	}
+}
+
+// cborEnumState is an enumerated type, as generated for an enumeration
+// whose values are "up", with value 1, and "down", with value 5. Its values
+// are one greater than those of the enumeration, since zero is reserved for
+// the unset value.
+type cborEnumState int64
+
+func (cborEnumState) IsYANGGoEnum() {}
+
+func (cborEnumState) ΛMap() map[string]map[int64]ygot.EnumDefinition {
+	return map[string]map[int64]ygot.EnumDefinition{
+		"cborEnumState": {
+			2: {Name: "up"},
+			6: {Name: "down"},
+		},
+	}
+}
+
+func (e cborEnumState) String() string {
+	return ygot.EnumLogString(e, int64(e), "cborEnumState")
+}
+
+type cborEnumRoot struct {
+	State  cborEnumState   `path:"state" module:"m1"`
+	States []cborEnumState `path:"states" module:"m1"`
+}
+
+func (*cborEnumRoot) IsYANGGoStruct()                         {}
+func (*cborEnumRoot) Validate(...ygot.ValidationOption) error { return nil }
+func (*cborEnumRoot) ΛEnumTypeMap() map[string][]reflect.Type {
+	return map[string][]reflect.Type{
+		"/state":  {reflect.TypeOf(cborEnumState(0))},
+		"/states": {reflect.TypeOf(cborEnumState(0))},
+	}
+}
+
+func TestCBORRoundTripGoEnum(t *testing.T) {
+	// The schema is serialised to JSON and back as within generated code,
+	// such that the values of its enumerations are not retained, and are
+	// taken from the generated enumerated type.
+	enum := yang.NewEnumType()
+	enum.Set("up", 1)
+	enum.Set("down", 5)
+	js, err := json.Marshal(&yang.Entry{
+		Name:       "device",
+		Kind:       yang.DirectoryEntry,
+		Annotation: map[string]interface{}{"isFakeRoot": true},
+		Dir: map[string]*yang.Entry{
+			"state": {Name: "state", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Yenum, Enum: enum}},
+			"states": {
+				Name:     "states",
+				Kind:     yang.LeafEntry,
+				ListAttr: &yang.ListAttr{},
+				Type:     &yang.YangType{Kind: yang.Yenum, Enum: enum},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("cannot marshal schema, %v", err)
+	}
+	schema := &yang.Entry{}
+	if err := json.Unmarshal(js, schema); err != nil {
+		t.Fatalf("cannot unmarshal schema, %v", err)
+	}
+	for _, e := range schema.Dir {
+		e.Parent = schema
+	}
+
+	in := &cborEnumRoot{State: 6, States: []cborEnumState{2, 6}}
+	b, err := ygot.MarshalCBOR(in, schema, nil)
+	if err != nil {
+		t.Fatalf("MarshalCBOR: got unexpected error, %v", err)
+	}
+	// "m1:state": 5, "m1:states": [1, 5]
+	if want := mustHex(t, `a2 68 6d313a7374617465 05 69 6d313a737461746573 82 01 05`); string(b) != string(want) {
+		t.Errorf("MarshalCBOR: did not get expected CBOR, got: %x, want: %x", b, want)
+	}
+
+	got := &cborEnumRoot{}
+	if err := UnmarshalCBOR(schema, got, b); err != nil {
+		t.Fatalf("UnmarshalCBOR(%x): got unexpected error, %v", b, err)
+	}
+	if diff := cmp.Diff(in, got); diff != "" {
+		t.Errorf("UnmarshalCBOR(%x): did not get expected GoStruct, diff(-want, +got):\n%s", b, diff)
+	}
+
+	if err := UnmarshalCBOR(schema, &cborEnumRoot{}, mustHex(t, `a1 68 6d313a7374617465 02`)); err == nil {
+		t.Errorf("UnmarshalCBOR: did not get expected error for undefined enumeration value")
+	}
+}
+
+func TestUnmarshalCBOR(t *testing.T) {
+	schema := xmlSchema()
+	sids := cborTestSIDs(t)
+	intfSchema := schema.Dir["interfaces"].Dir["interface"]
+
+	tests := []struct {
+		desc             string
+		inSchema         *yang.Entry
+		inParent         ygot.GoStruct
+		inCBOR           string
+		inOpts           []UnmarshalOpt
+		want             ygot.GoStruct
+		wantErrSubstring string
+	}{{
+		desc:     "names within list entry",
+		inSchema: intfSchema,
+		inParent: &xmlInterface{Description: ygot.String("preserved")},
+		// {"m1:name": "eth0", "mtu": 9000}
+		inCBOR: `a2 67 6d313a6e616d65 64 65746830 63 6d7475 19 2328`,
+		want:   &xmlInterface{Name: ygot.String("eth0"), Mtu: ygot.Uint16(9000), Description: ygot.String("preserved")},
+	}, {
+		desc:     "negative SID delta and absolute SID",
+		inSchema: intfSchema,
+		inParent: &xmlInterface{},
+		// {47(1002): "eth0", +4: 3000, -1001: null}, relative to SID 1001
+		// of the list, where the final member has SID 0 which is unknown.
+		inCBOR: `a3 d8 2f 19 03ea 64 65746830 04 19 0bb8 39 03e8 f6`,
+		inOpts: []UnmarshalOpt{&CBORSIDs{SIDs: sids}, &IgnoreExtraFields{}},
+		want:   &xmlInterface{Name: ygot.String("eth0"), Type: 1},
+	}, {
+		desc:     "indefinite length map and string",
+		inSchema: intfSchema,
+		inParent: &xmlInterface{},
+		// {_ "name": (_ "et", "h0")}
+		inCBOR: `bf 64 6e616d65 7f 62 6574 62 6830 ff ff`,
+		want:   &xmlInterface{Name: ygot.String("eth0")},
+	}, {
+		desc:             "SID without SIDs",
+		inSchema:         schema,
+		inParent:         &xmlRoot{},
+		inCBOR:           `a1 19 0384 f6`,
+		wantErrSubstring: "member key 900 is a SID, but no SIDs were supplied",
+	}, {
+		desc:             "unknown SID",
+		inSchema:         schema,
+		inParent:         &xmlRoot{},
+		inCBOR:           `a1 01 f6`,
+		inOpts:           []UnmarshalOpt{&CBORSIDs{SIDs: sids}},
+		wantErrSubstring: "member 1 does not correspond to a data node of device",
+	}, {
+		desc:             "invalid value",
+		inSchema:         schema,
+		inParent:         &xmlRoot{},
+		inCBOR:           `a1 6a 6d323a656e61626c6564 f5`,
+		wantErrSubstring: "invalid value true for leaf enabled",
+	}, {
+		desc:             "not a map",
+		inSchema:         schema,
+		inParent:         &xmlRoot{},
+		inCBOR:           `80`,
+		wantErrSubstring: "want map",
+	}, {
+		desc:             "trailing data",
+		inSchema:         schema,
+		inParent:         &xmlRoot{},
+		inCBOR:           `a0 00`,
+		wantErrSubstring: "1 bytes after data item",
+	}, {
+		desc:             "truncated data",
+		inSchema:         schema,
+		inParent:         &xmlRoot{},
+		inCBOR:           `a1 64 6e61`,
+		wantErrSubstring: "unexpected end of data",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := UnmarshalCBOR(tt.inSchema, tt.inParent, mustHex(t, tt.inCBOR), tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalCBOR: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.inParent); diff != "" {
+				t.Errorf("UnmarshalCBOR: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCBORLeafValue(t *testing.T) {
+	enum := yang.NewEnumType()
+	enum.Set("UP", 1)
+	enum.Set("DOWN", -2)
+	bits := yang.NewEnumType()
+	bits.Set("a", 0)
+	bits.Set("b", 9)
+	leaf := func(t *yang.YangType) *yang.Entry {
+		return &yang.Entry{Name: "leaf", Kind: yang.LeafEntry, Type: t}
+	}
+	union := leaf(&yang.YangType{Kind: yang.Yunion, Type: []*yang.YangType{
+		{Kind: yang.Yint8},
+		{Kind: yang.Yenum, Enum: enum},
+		{Kind: yang.Ybits, Bit: bits},
+		{Kind: yang.Ystring},
+	}})
+
+	tests := []struct {
+		desc             string
+		inSchema         *yang.Entry
+		inCBOR           string
+		want             interface{}
+		wantErrSubstring string
+	}{{
+		desc:     "decimal64",
+		inSchema: leaf(&yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 2}),
+		inCBOR:   `c4 82 21 19 013a`,
+		want:     "3.14",
+	}, {
+		desc:     "negative decimal64",
+		inSchema: leaf(&yang.YangType{Kind: yang.Ydecimal64}),
+		inCBOR:   `c4 82 20 38 18`,
+		want:     "-2.5",
+	}, {
+		desc:     "int64",
+		inSchema: leaf(&yang.YangType{Kind: yang.Yint64}),
+		inCBOR:   `3a ffffffff`,
+		want:     "-4294967296",
+	}, {
+		desc:             "out of range int8",
+		inSchema:         leaf(&yang.YangType{Kind: yang.Yint8}),
+		inCBOR:           `38 80`,
+		wantErrSubstring: "invalid value",
+	}, {
+		desc:     "binary",
+		inSchema: leaf(&yang.YangType{Kind: yang.Ybinary}),
+		inCBOR:   `42 0102`,
+		want:     "AQI=",
+	}, {
+		desc:     "enumeration",
+		inSchema: leaf(&yang.YangType{Kind: yang.Yenum, Enum: enum}),
+		inCBOR:   `21`,
+		want:     "DOWN",
+	}, {
+		desc:     "bits",
+		inSchema: leaf(&yang.YangType{Kind: yang.Ybits, Bit: bits}),
+		inCBOR:   `42 0102`,
+		want:     "a b",
+	}, {
+		desc:     "union integer",
+		inSchema: union,
+		inCBOR:   `20`,
+		want:     float64(-1),
+	}, {
+		desc:     "union tagged enumeration",
+		inSchema: union,
+		inCBOR:   `d8 2c 01`,
+		want:     "UP",
+	}, {
+		desc:     "union tagged bits",
+		inSchema: union,
+		inCBOR:   `d8 2b 42 0002`,
+		want:     "b",
+	}, {
+		desc:     "union string",
+		inSchema: union,
+		inCBOR:   `61 78`,
+		want:     "x",
+	}, {
+		desc:             "identityref SID without SIDs",
+		inSchema:         leaf(&yang.YangType{Kind: yang.Yidentityref}),
+		inCBOR:           `19 0bb8`,
+		wantErrSubstring: "identity 3000 is a SID, but no SIDs were supplied",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			v, rest, err := parseCBOR(mustHex(t, tt.inCBOR))
+			if err != nil || len(rest) != 0 {
+				t.Fatalf("parseCBOR: got unexpected result (rest: %x, err: %v)", rest, err)
+			}
+			got, err := (&cborDecoder{}).leaf(v, tt.inSchema)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("leaf: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("leaf: did not get expected value, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseCBORFloat(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want float64
+	}{
+		{in: "f9 3c00", want: 1},
+		{in: "f9 c400", want: -4},
+		{in: "f9 0001", want: 5.960464477539063e-08},
+		{in: "fa 47c35000", want: 100000},
+		{in: "fb 3ff199999999999a", want: 1.1},
+	} {
+		got, _, err := parseCBOR(mustHex(t, tt.in))
+		if err != nil {
+			t.Fatalf("parseCBOR(%s): got unexpected error, %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("parseCBOR(%s): got %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -17,13 +17,12 @@ package ytypes
 import (
 	"fmt"
 	"reflect"
-	"strings"
 
-	log "github.com/golang/glog"
-	"github.com/kylelemons/godebug/pretty"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 // Refer to: https://tools.ietf.org/html/rfc6020#section-7.5.
@@ -70,7 +69,7 @@ func validateContainer(schema *yang.Entry, value ygot.GoStruct) util.Errors {
 				continue
 			case cschema != nil:
 				// Regular named child.
-				if errs := Validate(cschema, fieldValue); errs != nil {
+				if errs := validate(cschema, fieldValue); errs != nil {
 					errors = util.AppendErrs(errors, util.PrefixErrors(errs, cschema.Path()))
 				}
 			case !util.IsValueNilOrDefault(structElems.Field(i).Interface()):
@@ -148,6 +147,23 @@ func unmarshalContainer(schema *yang.Entry, parent interface{}, jsonTree interfa
 func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string]interface{}, enc Encoding, opts ...UnmarshalOpt) error {
 	destv := reflect.ValueOf(parent).Elem()
 	var allSchemaPaths [][]string
+	// When collecting all errors, errors in the data tree are accumulated in
+	// errs rather than being returned immediately.
+	collect := hasCollectAllErrors(opts)
+	var errs util.Errors
+	// When checking module qualification, the module of the data node that
+	// the struct corresponds to is used to determine which member names must
+	// be qualified.
+	strict := hasStrictModuleQualification(opts)
+	var (
+		parentMod   string
+		parentKnown bool
+		modules     map[string]string
+	)
+	if strict {
+		parentMod, parentKnown = moduleOfParent(schema, opts)
+		modules = util.ModuleNamespaces(schema)
+	}
 
 	// Range over the parent struct fields. For each field, check if the data
 	// is present in the JSON tree and if so unmarshal it into the field.
@@ -155,20 +171,28 @@ func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string
 		f := destv.Field(i)
 		ft := destv.Type().Field(i)
 
-		// Skip annotation fields since they do not have a schema.
-		// TODO(robjs): Implement unmarshalling annotations.
+		// Annotation fields do not have a schema, but may hold the RFC7952
+		// metadata of the struct or of one of its fields.
 		if util.IsYgotAnnotation(ft) {
-			log.Infof("ignoring annotation field %s during unmarshalling, unsupported", ft.Name)
-			// We need to find the paths that we should have unmarshalled here to avoid
-			// throwing errors to users whilst there is a TODO above.
-			paths, err := pathTagFromField(ft)
+			sp, err := annotationDataTreePaths(schema, ft)
 			if err != nil {
-				return fmt.Errorf("cannot find JSON field names for annotation field %s, %v", ft.Name, err)
+				return err
 			}
+			allSchemaPaths = append(allSchemaPaths, sp...)
 
-			for _, s := range strings.Split(paths, "|") {
-				pp := strings.Split(s, "/")
-				allSchemaPaths = append(allSchemaPaths, []string{pp[len(pp)-1]})
+			jsonValue, jsonPath, err := getJSONTreeValForPaths(jsonTree, sp)
+			if err == nil && jsonValue == nil {
+				continue
+			}
+			if err == nil {
+				err = unmarshalMetadata(schema, f, jsonValue, opts...)
+			}
+			if err != nil {
+				if !collect {
+					return err
+				}
+				path := pathElems(jsonPath)
+				errs = util.AppendErrs(errs, prefixUnmarshalErrors(err, path, path, jsonValue))
 			}
 			continue
 		}
@@ -191,9 +215,13 @@ func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string
 		}
 		allSchemaPaths = append(allSchemaPaths, sp...)
 
-		jsonValue, err := getJSONTreeValForField(schema, cschema, ft, jsonTree)
+		jsonValue, jsonPath, err := getJSONTreeValForField(schema, cschema, ft, jsonTree)
 		if err != nil {
-			return err
+			if !collect {
+				return err
+			}
+			errs = util.AppendErrs(errs, prefixUnmarshalErrors(err, pathElems(jsonPath), nil, nil))
+			continue
 		}
 
 		if jsonValue == nil {
@@ -201,11 +229,29 @@ func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string
 			continue
 		}
 
+		copts := opts
+		if strict {
+			fieldMod := ft.Tag.Get("module")
+			err := checkMemberModules(jsonTree, jsonPath, fieldMod, parentMod, parentKnown, modules)
+			if err == nil && (cschema.IsLeaf() || cschema.IsLeafList()) {
+				err = checkIdentityrefModules(cschema, ft.Type, jsonValue, fieldMod, modules)
+			}
+			if err != nil {
+				if !collect {
+					return err
+				}
+				errs = util.AppendErrs(errs, prefixUnmarshalErrors(err, pathElems(jsonPath), nil, jsonValue))
+				continue
+			}
+			copts = withParentModule(opts, fieldMod)
+		}
+
 		util.DbgPrint("populating field %s type %s with paths %v.", ft.Name, ft.Type, sp)
 		// Only create a new field if it is nil, otherwise update just the
 		// fields that are in the data tree being passed to unmarshal, and
 		// preserve all other existing values.
-		if util.IsNilOrInvalidValue(f) {
+		wasNil := util.IsNilOrInvalidValue(f)
+		if wasNil {
 			makeField(destv, ft)
 		}
 
@@ -221,21 +267,70 @@ func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string
 			// current container.
 			p = f.Interface()
 		}
-		if err := unmarshalGeneric(cschema, p, jsonValue, enc, opts...); err != nil {
+		if err := unmarshalGeneric(cschema, p, jsonValue, enc, copts...); err != nil {
+			if !collect {
+				return err
+			}
+			if wasNil && (cschema.IsLeaf() || cschema.IsLeafList() || util.IsAnydata(cschema)) {
+				// Leave the field of an invalid value unset, rather
+				// than set to the zero value created above.
+				f.Set(reflect.Zero(ft.Type))
+			}
+			// The paths of the errors in list entries already include
+			// the list element itself, along with the keys of the entry.
+			path := pathElems(jsonPath)
+			prefix := path
+			if cschema.IsList() {
+				prefix = path[:len(path)-1]
+			}
+			errs = util.AppendErrs(errs, prefixUnmarshalErrors(err, path, prefix, jsonValue))
+		}
+	}
+
+	// Data for schema nodes that were removed since the features that they
+	// depend upon are not enabled is always rejected, since it is not
+	// supported by the target of the schema.
+	disabled := map[string]bool{}
+	for _, df := range disabledFeatureFields(schema, jsonTree, allSchemaPaths) {
+		err := disabledFieldError(schema, parent, df.path, df.exprs)
+		if !collect {
 			return err
 		}
+		disabled[df.path[0]] = true
+		errs = util.AppendErr(errs, &UnmarshalError{
+			Path:  &gpb.Path{Elem: pathElems(df.path)},
+			Value: df.value,
+			Err:   err,
+		})
 	}
 
 	// Only check for missing fields if the IgnoreExtraFields option isn't specified.
 	if !hasIgnoreExtraFields(opts) {
 		// Go over all JSON fields to make sure that each one is covered
 		// by a data path in the struct.
-		if err := checkDataTreeAgainstPaths(jsonTree, allSchemaPaths); err != nil {
-			return fmt.Errorf("parent container %s (type %T): %s", schema.Name, parent, err)
+		if !collect {
+			if err := checkDataTreeAgainstPaths(jsonTree, allSchemaPaths); err != nil {
+				return fmt.Errorf("parent container %s (type %T): %s", schema.Name, parent, err)
+			}
+		} else {
+			for _, jf := range unexpectedDataTreeFields(jsonTree, allSchemaPaths) {
+				if disabled[jf] {
+					continue
+				}
+				errs = util.AppendErr(errs, &UnmarshalError{
+					Path:  &gpb.Path{Elem: pathElems([]string{jf})},
+					Value: jsonTree[jf],
+					Err:   fmt.Errorf("parent container %s (type %T): JSON contains unexpected field %s", schema.Name, parent, jf),
+				})
+			}
 		}
 	}
 
-	util.DbgPrint("container after unmarshal:\n%s\n", pretty.Sprint(destv.Interface()))
+	if len(errs) > 0 {
+		return errs
+	}
+
+	util.DbgPrint("container after unmarshal:\n%s\n", util.PrettyStrDebug(destv.Interface()))
 	return nil
 }
 
@@ -247,7 +342,7 @@ func validateContainerSchema(schema *yang.Entry) error {
 	if schema == nil {
 		return fmt.Errorf("container schema is nil")
 	}
-	if !schema.IsContainer() {
+	if !schema.IsContainer() && !util.IsRPCInputOrOutput(schema) && !util.IsNotification(schema) {
 		return fmt.Errorf("container schema %s is not a container type", schema.Name)
 	}
 
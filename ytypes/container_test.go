@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/ygot"
 )
@@ -79,6 +80,10 @@ func TestValidateContainerSchema(t *testing.T) {
 			desc:   "empty container",
 			schema: &yang.Entry{Kind: yang.DirectoryEntry},
 		},
+		{
+			desc:   "rpc input",
+			schema: &yang.Entry{Name: "input", Kind: yang.InputEntry},
+		},
 		{
 			desc:    "nil schema",
 			schema:  nil,
@@ -417,3 +422,99 @@ func TestUnmarshalContainer(t *testing.T) {
 		t.Errorf("nil schema: got error: nil, want nil schema error")
 	}
 }
+
+type RPCInputStruct struct {
+	Delay  *uint32 `path:"delay"`
+	Method *string `path:"method"`
+}
+
+func (*RPCInputStruct) IsYANGGoStruct() {}
+
+func TestRPCInput(t *testing.T) {
+	rpc := &yang.Entry{
+		Name: "reboot",
+		Kind: yang.DirectoryEntry,
+	}
+	inputSchema := &yang.Entry{
+		Name:   "input",
+		Kind:   yang.InputEntry,
+		Parent: rpc,
+		Dir: map[string]*yang.Entry{
+			"delay": {
+				Kind: yang.LeafEntry,
+				Name: "delay",
+				Type: &yang.YangType{Kind: yang.Yuint32},
+			},
+			"method": {
+				Kind: yang.LeafEntry,
+				Name: "method",
+				Type: &yang.YangType{Kind: yang.Ystring, Length: yang.YangRange{yang.YRange{Min: yang.FromInt(1), Max: yang.FromInt(4)}}},
+			},
+		},
+	}
+	rpc.RPC = &yang.RPCEntry{Input: inputSchema}
+	for _, e := range inputSchema.Dir {
+		e.Parent = inputSchema
+	}
+
+	validateTests := []struct {
+		desc    string
+		val     *RPCInputStruct
+		wantErr string
+	}{{
+		desc: "valid input",
+		val:  &RPCInputStruct{Delay: ygot.Uint32(10), Method: ygot.String("WARM")},
+	}, {
+		desc:    "invalid leaf value",
+		val:     &RPCInputStruct{Method: ygot.String("NOT-WARM")},
+		wantErr: "length",
+	}}
+
+	for _, tt := range validateTests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var err error
+			if errs := Validate(inputSchema, tt.val); errs != nil {
+				err = errs
+			}
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Errorf("Validate: %s", diff)
+			}
+		})
+	}
+
+	unmarshalTests := []struct {
+		desc    string
+		json    string
+		want    *RPCInputStruct
+		wantErr string
+	}{{
+		desc: "valid input",
+		json: `{"delay": 10, "method": "COLD"}`,
+		want: &RPCInputStruct{Delay: ygot.Uint32(10), Method: ygot.String("COLD")},
+	}, {
+		desc:    "unknown field",
+		json:    `{"timeout": 10}`,
+		wantErr: "JSON contains unexpected field timeout",
+	}}
+
+	for _, tt := range unmarshalTests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var jsonTree interface{}
+			if err := json.Unmarshal([]byte(tt.json), &jsonTree); err != nil {
+				t.Fatalf("json.Unmarshal(%s): got unexpected error: %v", tt.json, err)
+			}
+
+			got := &RPCInputStruct{}
+			err := Unmarshal(inputSchema, got, jsonTree)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("Unmarshal: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if !areEqual(got, tt.want) {
+				t.Errorf("Unmarshal: got:\n%v\nwant:\n%v", pretty.Sprint(got), pretty.Sprint(tt.want))
+			}
+		})
+	}
+}
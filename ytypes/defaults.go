@@ -0,0 +1,508 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// Refer to: https://tools.ietf.org/html/rfc7950#section-7.6.1 and
+// https://tools.ietf.org/html/rfc7950#section-7.9.3.
+
+// PopulateDefaults sets each unset leaf of the GoStruct value, which is
+// described by schema, to the default value of the leaf within the schema.
+// A default is only used where it applies in the data tree, i.e., a leaf
+// within a case of a choice is only populated if the case is the active case
+// of the choice, or no case of the choice is active and the case is the
+// default case, and a leaf is only populated if the when statements of the
+// leaf and its ancestors up to value are satisfied. Non-presence containers
+// that are not set are created if a default is populated within them. Since
+// when statements may refer to any node in the data tree, PopulateDefaults
+// should be called on the root of the data tree where they are used.
+func PopulateDefaults(schema *yang.Entry, value ygot.GoStruct) error {
+	if util.IsValueNil(value) {
+		return nil
+	}
+	if schema == nil {
+		return fmt.Errorf("nil schema for type %T, value %v", value, value)
+	}
+
+	d := &defaultsWalker{}
+	var root *xpathNode
+	if hasXPathConstraints(schema) {
+		var err error
+		if root, err = newXPathTree(schema, value); err != nil {
+			return err
+		}
+		d.x = newXPathEvaluator(root)
+		d.nodes = map[interface{}]*xpathNode{}
+		root.walk(func(n *xpathNode) {
+			if n.goStruct != nil {
+				d.nodes[n.goStruct] = n
+			}
+		})
+	}
+
+	if _, errs := d.populateStruct(schema, reflect.ValueOf(value), root); errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// PruneDefaults removes each leaf of the GoStruct value, which is described by
+// schema, whose value is equal to the default value of the leaf within the
+// schema. Leaves within a case of a choice are only removed if the case, and
+// each enclosing case, is the default case of its choice, such that the
+// default continues to apply once the leaf is removed. Non-presence
+// containers that are empty once their leaves are removed are also removed.
+func PruneDefaults(schema *yang.Entry, value ygot.GoStruct) error {
+	if util.IsValueNil(value) {
+		return nil
+	}
+	if schema == nil {
+		return fmt.Errorf("nil schema for type %T, value %v", value, value)
+	}
+	if errs := pruneStruct(schema, reflect.ValueOf(value)); errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// defaultsWalker stores the state used when populating the default values of
+// a data tree.
+type defaultsWalker struct {
+	// x is the evaluator used for when statements. It is nil if the schema
+	// does not contain any must or when statements.
+	x *xpathEvaluator
+	// nodes maps each GoStruct pointer within the data tree to its node
+	// within the XPath data tree used by x.
+	nodes map[interface{}]*xpathNode
+}
+
+// node returns the XPath data tree node of the GoStruct pointer v, or nil if
+// when statements are not being evaluated.
+func (d *defaultsWalker) node(v reflect.Value) *xpathNode {
+	if d.nodes == nil {
+		return nil
+	}
+	return d.nodes[v.Interface()]
+}
+
+// populateStruct populates the defaults of the fields of the GoStruct pointer
+// v, described by schema. The node n is the XPath data tree node of v, which
+// is nil if when statements are not being evaluated. It reports whether any
+// field of v, or of its descendants, was populated.
+func (d *defaultsWalker) populateStruct(schema *yang.Entry, v reflect.Value, n *xpathNode) (bool, util.Errors) {
+	if !util.IsValueStructPtr(v) {
+		return false, util.NewErrs(fmt.Errorf("cannot populate defaults of non-struct ptr type %v", v.Type()))
+	}
+	sv := v.Elem()
+	active, err := activeCases(schema, sv)
+	if err != nil {
+		return false, util.NewErrs(err)
+	}
+	keys := util.ListKeyFieldsMap(schema)
+
+	var (
+		populated bool
+		errs      util.Errors
+	)
+	for i := 0; i < sv.NumField(); i++ {
+		ft, fv := sv.Type().Field(i), sv.Field(i)
+		if util.IsYgotAnnotation(ft) {
+			continue
+		}
+		cs, err := util.ChildSchema(schema, ft)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		if cs == nil || util.IsAnydata(cs) || !caseApplies(schema, cs, active) {
+			continue
+		}
+
+		switch {
+		case cs.IsLeaf():
+			if keys[cs.Name] || !util.IsValueNilOrDefault(fv.Interface()) {
+				continue
+			}
+			dv, ok, err := defaultValue(cs, ft, sv.Type())
+			if err != nil {
+				errs = util.AppendErr(errs, err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			if n != nil {
+				if _, ok, err := d.addNode(schema, cs, n, ft, dv.Interface()); err != nil || !ok {
+					errs = util.AppendErr(errs, err)
+					continue
+				}
+			}
+			fv.Set(dv)
+			populated = true
+		case cs.IsContainer():
+			if !fv.IsNil() {
+				p, cerrs := d.populateStruct(cs, fv, d.node(fv))
+				populated = populated || p
+				errs = util.AppendErrs(errs, cerrs)
+				continue
+			}
+			if util.IsPresenceContainer(cs) {
+				continue
+			}
+			nv := reflect.New(ft.Type.Elem())
+			var cn *xpathNode
+			if n != nil {
+				var ok bool
+				if cn, ok, err = d.addNode(schema, cs, n, ft, nil); err != nil || !ok {
+					errs = util.AppendErr(errs, err)
+					continue
+				}
+				cn.goStruct = nv.Interface()
+			}
+			p, cerrs := d.populateStruct(cs, nv, cn)
+			errs = util.AppendErrs(errs, cerrs)
+			switch {
+			case p:
+				fv.Set(nv)
+				populated = true
+			case cn != nil:
+				removeXPathNode(n, cn)
+			}
+		case cs.IsList():
+			for _, ev := range listEntries(fv) {
+				p, cerrs := d.populateStruct(cs, ev, d.node(ev))
+				populated = populated || p
+				errs = util.AppendErrs(errs, cerrs)
+			}
+		}
+	}
+	return populated, errs
+}
+
+// addNode adds a node for the field ft, described by the schema cs, to the
+// XPath data tree below the node n of the GoStruct described by schema, with
+// the supplied leaf value. It evaluates the when statements of cs, and of its
+// ancestors up to schema, with the node tentatively added to the tree, and
+// reports whether they are satisfied. The node is only retained within the
+// tree if they are.
+func (d *defaultsWalker) addNode(schema, cs *yang.Entry, n *xpathNode, ft reflect.StructField, value interface{}) (*xpathNode, bool, error) {
+	ps, err := util.SchemaPaths(ft)
+	if err != nil {
+		return nil, false, err
+	}
+	var elems []string
+	for _, e := range ps[0] {
+		if e != "" {
+			elems = append(elems, e)
+		}
+	}
+	// As in util.ChildSchema, the path may start with the name of the
+	// container that the field belongs to.
+	if schema.IsContainer() && len(elems) > 1 && elems[0] == schema.Name {
+		elems = elems[1:]
+	}
+	if len(elems) == 0 {
+		return nil, false, nil
+	}
+
+	parent := n
+	for _, e := range elems[:len(elems)-1] {
+		s := util.FirstChild(parent.schema, []string{e})
+		if s == nil {
+			return nil, false, nil
+		}
+		parent = parent.child(e, s)
+		if parent.order == 0 {
+			parent.order = n.order
+		}
+		if parent.module == "" {
+			parent.module = ft.Tag.Get("module")
+		}
+	}
+	c := &xpathNode{name: elems[len(elems)-1], schema: cs, parent: parent, value: value, order: parent.order, module: ft.Tag.Get("module")}
+	parent.children = append(parent.children, c)
+
+	cur := c
+	for e := cs; e != nil && e != schema && e.Parent != nil; e = e.Parent {
+		// The context node for the when statement of a choice or case, and
+		// for the when statements of the augment and uses statements that
+		// added e, is the closest ancestor data node.
+		ancestor := cur
+		if !util.IsChoiceOrCase(e) {
+			ancestor = cur.parent
+		}
+		var whens []string
+		var ctxs []*xpathNode
+		if w, ok := util.WhenStatement(e); ok {
+			whens, ctxs = append(whens, w), append(ctxs, cur)
+		}
+		for _, w := range util.InheritedWhenStatements(e) {
+			whens, ctxs = append(whens, w), append(ctxs, ancestor)
+		}
+		d.x.define(e, cur)
+		for i, w := range whens {
+			r, err := d.x.evalBool(w, ctxs[i])
+			if err != nil || !r {
+				removeXPathNode(n, c)
+				if err != nil {
+					return nil, false, fmt.Errorf("schema path %s: cannot evaluate when statement %q: %v", e.Path(), w, err)
+				}
+				return nil, false, nil
+			}
+		}
+		cur = ancestor
+	}
+	return c, true, nil
+}
+
+// removeXPathNode removes the node c from the XPath data tree, along with any
+// of its ancestors below n that no longer have children.
+func removeXPathNode(n, c *xpathNode) {
+	for c != n && c.parent != nil {
+		p := c.parent
+		p.removeChild(c)
+		if len(p.children) != 0 || p.goStruct != nil {
+			return
+		}
+		c = p
+	}
+}
+
+// pruneStruct removes the fields of the GoStruct pointer v, described by
+// schema, that are equal to their default value.
+func pruneStruct(schema *yang.Entry, v reflect.Value) util.Errors {
+	if !util.IsValueStructPtr(v) {
+		return util.NewErrs(fmt.Errorf("cannot prune defaults of non-struct ptr type %v", v.Type()))
+	}
+	sv := v.Elem()
+	keys := util.ListKeyFieldsMap(schema)
+
+	var errs util.Errors
+	for i := 0; i < sv.NumField(); i++ {
+		ft, fv := sv.Type().Field(i), sv.Field(i)
+		if util.IsYgotAnnotation(ft) || util.IsValueNilOrDefault(fv.Interface()) {
+			continue
+		}
+		cs, err := util.ChildSchema(schema, ft)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		if cs == nil || util.IsAnydata(cs) {
+			continue
+		}
+
+		switch {
+		case cs.IsLeaf():
+			if keys[cs.Name] || !caseApplies(schema, cs, nil) {
+				continue
+			}
+			dv, ok, err := defaultValue(cs, ft, sv.Type())
+			if err != nil {
+				errs = util.AppendErr(errs, err)
+				continue
+			}
+			if ok && reflect.DeepEqual(fv.Interface(), dv.Interface()) {
+				fv.Set(reflect.Zero(ft.Type))
+			}
+		case cs.IsContainer():
+			errs = util.AppendErrs(errs, pruneStruct(cs, fv))
+			if !util.IsPresenceContainer(cs) && isEmptyStruct(fv.Elem()) {
+				fv.Set(reflect.Zero(ft.Type))
+			}
+		case cs.IsList():
+			for _, ev := range listEntries(fv) {
+				errs = util.AppendErrs(errs, pruneStruct(cs, ev))
+			}
+		}
+	}
+	return errs
+}
+
+// isEmptyStruct reports whether none of the data fields of the struct sv are
+// set.
+func isEmptyStruct(sv reflect.Value) bool {
+	for i := 0; i < sv.NumField(); i++ {
+		ft, fv := sv.Type().Field(i), sv.Field(i)
+		if util.IsYgotAnnotation(ft) {
+			continue
+		}
+		switch fv.Kind() {
+		case reflect.Map, reflect.Slice:
+			if fv.Len() != 0 {
+				return false
+			}
+		default:
+			if !util.IsValueNilOrDefault(fv.Interface()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// listEntries returns the entries of the list field fv, which is either a map
+// or a slice of GoStruct pointers.
+func listEntries(fv reflect.Value) []reflect.Value {
+	var entries []reflect.Value
+	switch fv.Kind() {
+	case reflect.Map:
+		for _, k := range fv.MapKeys() {
+			entries = append(entries, fv.MapIndex(k))
+		}
+	case reflect.Slice:
+		for i := 0; i < fv.Len(); i++ {
+			entries = append(entries, fv.Index(i))
+		}
+	}
+	return entries
+}
+
+// activeCases returns the active case of each choice below schema, keyed by
+// the choice, for the fields that are set within the struct sv. A case is
+// active if any data node within it exists.
+func activeCases(schema *yang.Entry, sv reflect.Value) (map[*yang.Entry]*yang.Entry, error) {
+	active := map[*yang.Entry]*yang.Entry{}
+	for i := 0; i < sv.NumField(); i++ {
+		ft, fv := sv.Type().Field(i), sv.Field(i)
+		if util.IsYgotAnnotation(ft) || util.IsValueNilOrDefault(fv.Interface()) {
+			continue
+		}
+		cs, err := util.ChildSchema(schema, ft)
+		if err != nil {
+			return nil, err
+		}
+		for e := cs; e != nil && e != schema && e.Parent != nil; e = e.Parent {
+			if e.Parent.IsChoice() {
+				active[e.Parent] = e
+			}
+		}
+	}
+	return active, nil
+}
+
+// caseApplies reports whether the schema node cs, a descendant of schema, is
+// within the active case of each enclosing choice, or within the default case
+// of each choice that does not have an active case in the supplied map.
+func caseApplies(schema, cs *yang.Entry, active map[*yang.Entry]*yang.Entry) bool {
+	for e := cs; e != nil && e != schema && e.Parent != nil; e = e.Parent {
+		ch := e.Parent
+		if !ch.IsChoice() {
+			continue
+		}
+		if a, ok := active[ch]; ok {
+			if a != e {
+				return false
+			}
+			continue
+		}
+		if ch.Default != e.Name {
+			return false
+		}
+	}
+	return true
+}
+
+// leafDefault returns the default value of the leaf schema, and whether it has
+// one. The default of the leaf's type is used where the leaf does not specify
+// a default itself, unless the leaf is mandatory.
+func leafDefault(schema *yang.Entry) (string, bool) {
+	if schema.Default != "" {
+		return schema.Default, true
+	}
+	if schema.Type != nil && schema.Type.Default != "" && schema.Mandatory != yang.TSTrue {
+		return schema.Type.Default, true
+	}
+	return "", false
+}
+
+// defaultValue returns the value of the field ft, of a struct of type t, that
+// corresponds to the default value of the leaf schema, and whether the leaf
+// has a default value.
+func defaultValue(schema *yang.Entry, ft reflect.StructField, t reflect.Type) (reflect.Value, bool, error) {
+	d, ok := leafDefault(schema)
+	if !ok {
+		return reflect.Value{}, false, nil
+	}
+	rs, err := util.ResolveIfLeafRef(schema)
+	if err != nil {
+		return reflect.Value{}, false, err
+	}
+	if rs.Type == nil {
+		return reflect.Value{}, false, fmt.Errorf("schema path %s: leaf has nil type", schema.Path())
+	}
+
+	// The default is unmarshalled into a new struct, such that the value is
+	// converted to the Go type of the field.
+	parent := reflect.New(t)
+	if rs.Type.Kind == yang.Yunion {
+		// The default of a union is the value of the first member type
+		// that it is valid for.
+		for _, mt := range util.FlattenedTypes(rs.Type.Type) {
+			jv, err := defaultJSONValue(mt, d)
+			if err != nil {
+				continue
+			}
+			if err := unmarshalUnion(rs, parent.Interface(), ft.Name, jv, JSONEncoding); err == nil {
+				return parent.Elem().FieldByName(ft.Name), true, nil
+			}
+		}
+		return reflect.Value{}, false, fmt.Errorf("schema path %s: default value %q is not valid for any union member type", schema.Path(), d)
+	}
+
+	jv, err := defaultJSONValue(rs.Type, d)
+	if err != nil {
+		return reflect.Value{}, false, fmt.Errorf("schema path %s: invalid default value %q: %v", schema.Path(), d, err)
+	}
+	v, err := unmarshalScalar(parent.Interface(), rs, ft.Name, jv, JSONEncoding)
+	if err != nil {
+		return reflect.Value{}, false, fmt.Errorf("schema path %s: invalid default value %q: %v", schema.Path(), d, err)
+	}
+	if err := util.UpdateField(parent.Interface(), ft.Name, v); err != nil {
+		return reflect.Value{}, false, err
+	}
+	return parent.Elem().FieldByName(ft.Name), true, nil
+}
+
+// defaultJSONValue returns the RFC7951 JSON encoding of the default value d of
+// a leaf of type t.
+func defaultJSONValue(t *yang.YangType, d string) (interface{}, error) {
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		// YANG integers may be specified in decimal, hexadecimal or octal.
+		i, err := strconv.ParseInt(d, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid %v", d, t.Kind)
+		}
+		return float64(i), nil
+	case yang.Ybool:
+		if d != "true" && d != "false" {
+			return nil, fmt.Errorf("%q is not a valid boolean", d)
+		}
+		return d == "true", nil
+	case yang.Yempty, yang.Ybits:
+		return nil, fmt.Errorf("default values of type %v are not supported", t.Kind)
+	}
+	return d, nil
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+type defRoot struct {
+	Mtu      *uint16             `path:"mtu"`
+	Name     *string             `path:"name"`
+	Required *string             `path:"required"`
+	Enabled  *bool               `path:"enabled"`
+	Sub      *defSub             `path:"sub"`
+	Pres     *defSub             `path:"pres"`
+	Other    *defOther           `path:"other"`
+	AVal     *uint8              `path:"a-val"`
+	BVal     *uint8              `path:"b-val"`
+	Item     map[uint32]*defItem `path:"item"`
+	Cond     *string             `path:"cond"`
+	Never    *string             `path:"never"`
+}
+
+func (*defRoot) IsYANGGoStruct() {}
+
+type defSub struct {
+	Timer *uint32 `path:"timer"`
+}
+
+func (*defSub) IsYANGGoStruct() {}
+
+type defOther struct {
+	Desc *string `path:"desc"`
+}
+
+func (*defOther) IsYANGGoStruct() {}
+
+type defItem struct {
+	Id     *uint32 `path:"id"`
+	Weight *int32  `path:"weight"`
+}
+
+func (*defItem) IsYANGGoStruct() {}
+
+// defSchema returns the schema of the defRoot struct.
+func defSchema() *yang.Entry {
+	leaf := func(name string, kind yang.TypeKind, dflt string) *yang.Entry {
+		return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: &yang.YangType{Kind: kind}, Default: dflt}
+	}
+	timer := func() map[string]*yang.Entry {
+		return map[string]*yang.Entry{"timer": leaf("timer", yang.Yuint32, "0x10")}
+	}
+
+	name := leaf("name", yang.Ystring, "")
+	name.Type.Default = "typedef-default"
+	required := leaf("required", yang.Ystring, "")
+	required.Type.Default = "typedef-default"
+	required.Mandatory = yang.TSTrue
+	cond := leaf("cond", yang.Ystring, "c")
+	cond.Annotation = map[string]interface{}{util.WhenAnnotation: "../mtu = 1500"}
+	never := leaf("never", yang.Ystring, "n")
+	never.Annotation = map[string]interface{}{util.WhenAnnotation: "../name = 'none'"}
+
+	schema := &yang.Entry{
+		Name: "root",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"mtu":      leaf("mtu", yang.Yuint16, "1500"),
+			"name":     name,
+			"required": required,
+			"enabled":  leaf("enabled", yang.Ybool, "true"),
+			"sub":      {Name: "sub", Kind: yang.DirectoryEntry, Dir: timer()},
+			"pres": {
+				Name:       "pres",
+				Kind:       yang.DirectoryEntry,
+				Annotation: map[string]interface{}{util.PresenceAnnotation: "enables pres"},
+				Dir:        timer(),
+			},
+			"other": {
+				Name: "other",
+				Kind: yang.DirectoryEntry,
+				Dir:  map[string]*yang.Entry{"desc": leaf("desc", yang.Ystring, "")},
+			},
+			"proto": {
+				Name:    "proto",
+				Kind:    yang.ChoiceEntry,
+				Default: "a",
+				Dir: map[string]*yang.Entry{
+					"a": {Name: "a", Kind: yang.CaseEntry, Dir: map[string]*yang.Entry{"a-val": leaf("a-val", yang.Yuint8, "1")}},
+					"b": {Name: "b", Kind: yang.CaseEntry, Dir: map[string]*yang.Entry{"b-val": leaf("b-val", yang.Yuint8, "2")}},
+				},
+			},
+			"item": {
+				Name:     "item",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: &yang.ListAttr{},
+				Key:      "id",
+				Dir: map[string]*yang.Entry{
+					"id":     leaf("id", yang.Yuint32, ""),
+					"weight": leaf("weight", yang.Yint32, "10"),
+				},
+			},
+			"cond":  cond,
+			"never": never,
+		},
+	}
+	addParents(schema)
+	return schema
+}
+
+func TestPopulateDefaults(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inSchema         func() *yang.Entry
+		in               ygot.GoStruct
+		want             ygot.GoStruct
+		wantErrSubstring string
+	}{{
+		desc:     "empty struct",
+		inSchema: defSchema,
+		in:       &defRoot{},
+		want: &defRoot{
+			Mtu:     ygot.Uint16(1500),
+			Name:    ygot.String("typedef-default"),
+			Enabled: ygot.Bool(true),
+			Sub:     &defSub{Timer: ygot.Uint32(16)},
+			AVal:    ygot.Uint8(1),
+			Cond:    ygot.String("c"),
+		},
+	}, {
+		desc:     "set values are retained and active case is respected",
+		inSchema: defSchema,
+		in: &defRoot{
+			Mtu:     ygot.Uint16(9000),
+			Enabled: ygot.Bool(false),
+			BVal:    ygot.Uint8(5),
+			Item:    map[uint32]*defItem{1: {Id: ygot.Uint32(1)}, 2: {Id: ygot.Uint32(2), Weight: ygot.Int32(20)}},
+		},
+		want: &defRoot{
+			Mtu:     ygot.Uint16(9000),
+			Name:    ygot.String("typedef-default"),
+			Enabled: ygot.Bool(false),
+			Sub:     &defSub{Timer: ygot.Uint32(16)},
+			BVal:    ygot.Uint8(5),
+			Item:    map[uint32]*defItem{1: {Id: ygot.Uint32(1), Weight: ygot.Int32(10)}, 2: {Id: ygot.Uint32(2), Weight: ygot.Int32(20)}},
+		},
+	}, {
+		desc:     "existing presence container",
+		inSchema: defSchema,
+		in:       &defRoot{Pres: &defSub{}, Sub: &defSub{Timer: ygot.Uint32(1)}, Name: ygot.String("none")},
+		want: &defRoot{
+			Mtu:     ygot.Uint16(1500),
+			Name:    ygot.String("none"),
+			Enabled: ygot.Bool(true),
+			Sub:     &defSub{Timer: ygot.Uint32(1)},
+			Pres:    &defSub{Timer: ygot.Uint32(16)},
+			AVal:    ygot.Uint8(1),
+			Cond:    ygot.String("c"),
+			Never:   ygot.String("n"),
+		},
+	}, {
+		desc: "invalid default",
+		inSchema: func() *yang.Entry {
+			s := defSchema()
+			s.Dir["mtu"].Default = "jumbo"
+			return s
+		},
+		in:               &defRoot{},
+		wantErrSubstring: `schema path /root/mtu: invalid default value "jumbo"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := PopulateDefaults(tt.inSchema(), tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("PopulateDefaults: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.in); diff != "" {
+				t.Errorf("PopulateDefaults: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPruneDefaults(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   ygot.GoStruct
+		want ygot.GoStruct
+	}{{
+		desc: "values equal to defaults are removed",
+		in: &defRoot{
+			Mtu:     ygot.Uint16(1500),
+			Name:    ygot.String("other"),
+			Enabled: ygot.Bool(true),
+			Sub:     &defSub{Timer: ygot.Uint32(16)},
+			Pres:    &defSub{Timer: ygot.Uint32(16)},
+			Other:   &defOther{},
+			AVal:    ygot.Uint8(1),
+			Item:    map[uint32]*defItem{1: {Id: ygot.Uint32(1), Weight: ygot.Int32(10)}},
+		},
+		want: &defRoot{
+			Name: ygot.String("other"),
+			Pres: &defSub{},
+			Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1)}},
+		},
+	}, {
+		desc: "values within a non-default case are retained",
+		in:   &defRoot{BVal: ygot.Uint8(2), Enabled: ygot.Bool(false), Sub: &defSub{Timer: ygot.Uint32(1)}},
+		want: &defRoot{BVal: ygot.Uint8(2), Enabled: ygot.Bool(false), Sub: &defSub{Timer: ygot.Uint32(1)}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := PruneDefaults(defSchema(), tt.in); err != nil {
+				t.Fatalf("PruneDefaults: got unexpected error, %v", err)
+			}
+			if diff := cmp.Diff(tt.want, tt.in); diff != "" {
+				t.Errorf("PruneDefaults: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
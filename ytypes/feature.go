@@ -0,0 +1,116 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// disabledField is a member of a JSON tree that corresponds to a schema node
+// that was removed from the schema since its if-feature statements evaluated
+// to false.
+type disabledField struct {
+	// path is the path of the member within the JSON tree.
+	path []string
+	// exprs are the if-feature expressions of the schema node.
+	exprs []string
+	// value is the value of the member.
+	value interface{}
+}
+
+// disabledFieldError returns the error for a member of the JSON tree of a
+// container with the supplied schema, found at path relative to the container,
+// that corresponds to a schema node whose if-feature expressions, exprs,
+// evaluated to false. parent is the struct that the container is unmarshalled
+// into.
+func disabledFieldError(schema *yang.Entry, parent interface{}, path, exprs []string) error {
+	return fmt.Errorf("parent container %s (type %T): JSON contains field %s, which is not supported since if-feature %s is false", schema.Name, parent, strings.Join(path, "/"), strings.Join(exprs, ", "))
+}
+
+// disabledFeatureFields returns the members of jsonTree, which is the JSON tree
+// of a data node with the supplied schema, that correspond to schema nodes
+// that were removed since their if-feature statements evaluated to false. The
+// dataPaths are the data tree paths of the fields of the struct that the data
+// node is unmarshalled into. Members that correspond to a container that is
+// not itself the data tree path of a field, such as the config and state
+// containers of a compressed schema, are searched recursively.
+func disabledFeatureFields(schema *yang.Entry, jsonTree map[string]interface{}, dataPaths [][]string) []*disabledField {
+	disabled := disabledChildren(schema)
+
+	var keys []string
+	for k := range jsonTree {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var out []*disabledField
+	for _, k := range keys {
+		name := util.StripModulePrefix(k)
+		if exprs, ok := disabled[name]; ok {
+			out = append(out, &disabledField{path: []string{k}, exprs: exprs, value: jsonTree[k]})
+			continue
+		}
+
+		m, ok := jsonTree[k].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var sub [][]string
+		var exact bool
+		for _, p := range dataPaths {
+			if len(p) == 0 || util.StripModulePrefix(p[0]) != name {
+				continue
+			}
+			if len(p) == 1 {
+				exact = true
+				break
+			}
+			sub = append(sub, p[1:])
+		}
+		ch := schema.Dir[name]
+		if exact || ch == nil || !ch.IsContainer() {
+			continue
+		}
+		for _, d := range disabledFeatureFields(ch, m, sub) {
+			d.path = append([]string{k}, d.path...)
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// disabledChildren returns the children of the supplied schema that were
+// removed since their if-feature statements evaluated to false, including
+// those of any choice or case statements within the schema, since the data
+// nodes within them are children of the schema in the data tree.
+func disabledChildren(schema *yang.Entry) map[string][]string {
+	out := map[string][]string{}
+	for n, exprs := range util.DisabledChildren(schema) {
+		out[n] = exprs
+	}
+	for _, ch := range schema.Dir {
+		if ch.IsChoice() || ch.IsCase() {
+			for n, exprs := range disabledChildren(ch) {
+				out[n] = exprs
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+type featureRoot struct {
+	Name  *string        `path:"config/name|state/name"`
+	Intf  *featureIntf   `path:"intf"`
+	Other *featureChoice `path:"other"`
+}
+
+func (*featureRoot) IsYANGGoStruct() {}
+
+type featureIntf struct {
+	Enabled *bool `path:"enabled"`
+}
+
+func (*featureIntf) IsYANGGoStruct() {}
+
+type featureChoice struct {
+	A *string `path:"a"`
+}
+
+func (*featureChoice) IsYANGGoStruct() {}
+
+// featureSchema returns the schema of the featureRoot struct, from which
+// the nodes that depend upon the disabled features legacy and mtu of the
+// module m have been removed.
+func featureSchema() *yang.Entry {
+	leaf := func(name string, k yang.TypeKind) *yang.Entry {
+		return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: &yang.YangType{Kind: k}}
+	}
+	disabled := func(children map[string][]string) map[string]interface{} {
+		return map[string]interface{}{util.DisabledChildrenAnnotation: children}
+	}
+	schema := &yang.Entry{
+		Name:       "root",
+		Kind:       yang.DirectoryEntry,
+		Annotation: disabled(map[string][]string{"legacy": {"m:legacy"}}),
+		Dir: map[string]*yang.Entry{
+			"config": {
+				Name:       "config",
+				Kind:       yang.DirectoryEntry,
+				Annotation: disabled(map[string][]string{"mtu": {"m:mtu"}}),
+				Dir:        map[string]*yang.Entry{"name": leaf("name", yang.Ystring)},
+			},
+			"state": {
+				Name:       "state",
+				Kind:       yang.DirectoryEntry,
+				Config:     yang.TSFalse,
+				Annotation: disabled(map[string][]string{"mtu": {"m:mtu"}}),
+				Dir:        map[string]*yang.Entry{"name": leaf("name", yang.Ystring)},
+			},
+			"intf": {
+				Name:       "intf",
+				Kind:       yang.DirectoryEntry,
+				Annotation: disabled(map[string][]string{"mtu": {"m:mtu"}}),
+				Dir:        map[string]*yang.Entry{"enabled": leaf("enabled", yang.Ybool)},
+			},
+			"other": {
+				Name: "other",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"choice": {
+						Name: "choice",
+						Kind: yang.ChoiceEntry,
+						Dir: map[string]*yang.Entry{
+							"case": {
+								Name:       "case",
+								Kind:       yang.CaseEntry,
+								Annotation: disabled(map[string][]string{"b": {"m:legacy or m:mtu"}}),
+								Dir:        map[string]*yang.Entry{"a": leaf("a", yang.Ystring)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	populateParentField(nil, schema)
+	return schema
+}
+
+func TestUnmarshalDisabledFeatures(t *testing.T) {
+	tests := []struct {
+		desc    string
+		inJSON  string
+		inOpts  []UnmarshalOpt
+		want    *featureRoot
+		wantErr string
+	}{{
+		desc:   "no data for disabled features",
+		inJSON: `{"config": {"name": "dev"}, "intf": {"enabled": true}, "other": {"a": "x"}}`,
+		want: &featureRoot{
+			Name:  ygot.String("dev"),
+			Intf:  &featureIntf{Enabled: ygot.Bool(true)},
+			Other: &featureChoice{A: ygot.String("x")},
+		},
+	}, {
+		desc:    "disabled container",
+		inJSON:  `{"legacy": {"enabled": true}}`,
+		wantErr: "JSON contains field legacy, which is not supported since if-feature m:legacy is false",
+	}, {
+		desc:    "disabled container with module prefix, extra fields ignored",
+		inJSON:  `{"m:legacy": {"enabled": true}}`,
+		inOpts:  []UnmarshalOpt{&IgnoreExtraFields{}},
+		wantErr: "JSON contains field m:legacy, which is not supported since if-feature m:legacy is false",
+	}, {
+		desc:    "disabled leaf within compressed container",
+		inJSON:  `{"state": {"name": "dev", "mtu": 1500}}`,
+		inOpts:  []UnmarshalOpt{&IgnoreExtraFields{}},
+		wantErr: "JSON contains field state/mtu, which is not supported since if-feature m:mtu is false",
+	}, {
+		desc:    "disabled leaf within child struct",
+		inJSON:  `{"intf": {"mtu": 1500}}`,
+		inOpts:  []UnmarshalOpt{&IgnoreExtraFields{}},
+		wantErr: "parent container intf (type *ytypes.featureIntf): JSON contains field mtu, which is not supported since if-feature m:mtu is false",
+	}, {
+		desc:    "disabled leaf within choice",
+		inJSON:  `{"other": {"b": "y"}}`,
+		wantErr: "JSON contains field b, which is not supported since if-feature m:legacy or m:mtu is false",
+	}, {
+		desc:    "all errors collected",
+		inJSON:  `{"config": {"mtu": 1500}, "legacy": {}}`,
+		inOpts:  []UnmarshalOpt{&CollectAllErrors{}},
+		wantErr: "JSON contains field legacy, which is not supported since if-feature m:legacy is false",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var jsonTree interface{}
+			if err := json.Unmarshal([]byte(tt.inJSON), &jsonTree); err != nil {
+				t.Fatalf("json.Unmarshal(%s): got unexpected error: %v", tt.inJSON, err)
+			}
+
+			got := &featureRoot{}
+			err := Unmarshal(featureSchema(), got, jsonTree, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("Unmarshal: %s", diff)
+			}
+
+			gotStream := &featureRoot{}
+			streamErr := UnmarshalJSONStream(featureSchema(), gotStream, strings.NewReader(tt.inJSON), tt.inOpts...)
+			if diff := errdiff.Substring(streamErr, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalJSONStream: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Unmarshal: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.want, gotStream); diff != "" {
+				t.Errorf("UnmarshalJSONStream: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+
+	// Check that each error is reported when collecting all errors.
+	var jsonTree interface{}
+	if err := json.Unmarshal([]byte(`{"config": {"mtu": 1500}, "legacy": {}}`), &jsonTree); err != nil {
+		t.Fatalf("json.Unmarshal: got unexpected error: %v", err)
+	}
+	errs, ok := Unmarshal(featureSchema(), &featureRoot{}, jsonTree, &CollectAllErrors{}).(util.Errors)
+	if !ok {
+		t.Fatalf("Unmarshal with CollectAllErrors: did not get util.Errors")
+	}
+	var gotPaths []string
+	for _, err := range errs {
+		ue, ok := err.(*UnmarshalError)
+		if !ok {
+			t.Fatalf("Unmarshal with CollectAllErrors: got error of type %T, want *UnmarshalError", err)
+		}
+		p, err := ygot.PathToString(ue.Path)
+		if err != nil {
+			t.Fatalf("cannot convert path %v to string, %v", ue.Path, err)
+		}
+		gotPaths = append(gotPaths, p)
+	}
+	if diff := cmp.Diff([]string{"/config/mtu", "/legacy"}, gotPaths); diff != "" {
+		t.Errorf("Unmarshal with CollectAllErrors: did not get expected error paths, diff(-want, +got):\n%s", diff)
+	}
+}
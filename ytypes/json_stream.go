@@ -0,0 +1,780 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// UnmarshalJSONStream unmarshals the RFC7951 JSON document read from r into
+// the given parent, using the given schema. It has the same semantics as
+// calling Unmarshal with the JSON tree produced by json.Unmarshal of the
+// document, but decodes the document token by token such that the tree of
+// containers and lists is never held in memory: each container and list entry
+// is populated directly in the GoStruct as it is read. Only the values of
+// leaves and leaf-lists, and of nodes whose data tree paths are ambiguous, are
+// decoded in full before being unmarshalled.
+//
+// The errors returned for invalid documents are the same as those returned by
+// Unmarshal. Where a document contains more than one error, the error that is
+// returned may differ, since the document is processed in the order that it is
+// read rather than in the order of the fields of the GoStruct. Syntax errors
+// are those reported by json.Decoder, whose wording may differ from that of
+// the errors reported by json.Unmarshal for the same document.
+//
+// The members of a JSON object are unmarshalled into a copy of parent, such
+// that parent is modified only if the whole document is unmarshalled
+// successfully. Where a JSON object has more than one member with the same
+// name, only the last is unmarshalled, as for the JSON tree produced by
+// json.Unmarshal.
+//
+// If the CollectAllErrors or StrictModuleQualification options are specified,
+// the document is decoded in full and unmarshalled using Unmarshal, such that
+// all errors are returned, and the module names of the document are checked.
+func UnmarshalJSONStream(schema *yang.Entry, parent interface{}, r io.Reader, opts ...UnmarshalOpt) error {
+	if hasCollectAllErrors(opts) || hasStrictModuleQualification(opts) {
+		var jsonTree interface{}
+		if err := json.NewDecoder(r).Decode(&jsonTree); err != nil {
+			return err
+		}
+		return Unmarshal(schema, parent, jsonTree, opts...)
+	}
+
+	d := &jsonStreamDecoder{
+		dec:    json.NewDecoder(r),
+		opts:   opts,
+		tries:  map[jsonStreamKey]*jsonStreamNode{},
+		ignore: hasIgnoreExtraFields(opts),
+	}
+
+	var err error
+	switch {
+	case schema == nil:
+		return fmt.Errorf("nil schema for parent type %T", parent)
+	case schema.IsLeaf() || schema.IsLeafList() || util.IsAnydata(schema):
+		var v interface{}
+		if err := d.dec.Decode(&v); err != nil {
+			return err
+		}
+		err = unmarshalGeneric(schema, parent, v, JSONEncoding, opts...)
+	default:
+		tok, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		pv := reflect.ValueOf(parent)
+		if tok != json.Delim('{') || !util.IsValueStructPtr(pv) || !isStreamedStruct(schema, pv.Type()) {
+			// Any other value is decoded in full, such that parent is
+			// not modified if the document is invalid.
+			v, err := d.rest(tok)
+			if err != nil {
+				return err
+			}
+			if err := d.end(); err != nil {
+				return err
+			}
+			return unmarshalGeneric(schema, parent, v, JSONEncoding, opts...)
+		}
+
+		// The fields of the copy are shared with parent until they are
+		// first modified, see jsonStreamStruct.
+		cp := reflect.New(pv.Elem().Type())
+		cp.Elem().Set(pv.Elem())
+		d.root = cp.Interface()
+		if err := d.node(schema, d.root, pv.Elem(), tok); err != nil {
+			return err
+		}
+		if err := d.end(); err != nil {
+			return err
+		}
+		pv.Elem().Set(cp.Elem())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return d.end()
+}
+
+// end returns an error if the token stream contains any value after the
+// top-level value of the document.
+func (d *jsonStreamDecoder) end() error {
+	if t, err := d.dec.Token(); err != io.EOF {
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("invalid JSON document, unexpected %v after top-level value", t)
+	}
+	return nil
+}
+
+// isStreamedStruct reports whether the members of a JSON object are streamed
+// into a value of type t described by schema, rather than being decoded in
+// full.
+func isStreamedStruct(schema *yang.Entry, t reflect.Type) bool {
+	if !util.IsTypeStructPtr(t) || util.IsTypeOrderedMap(t) {
+		return false
+	}
+	return schema.IsList() || schema.IsContainer() || util.IsRPCInputOrOutput(schema) || util.IsNotification(schema)
+}
+
+// jsonStreamDecoder decodes a JSON document from a token stream into a
+// GoStruct.
+type jsonStreamDecoder struct {
+	dec  *json.Decoder
+	opts []UnmarshalOpt
+	// tries caches the data tree paths of each struct type, keyed by the
+	// schema and type of the struct.
+	tries map[jsonStreamKey]*jsonStreamNode
+	// ignore indicates whether IgnoreExtraFields was supplied.
+	ignore bool
+	// root is the copy of the parent supplied to UnmarshalJSONStream into
+	// which the document is unmarshalled.
+	root interface{}
+}
+
+// jsonStreamKey is the key of the cache of data tree paths of a struct.
+type jsonStreamKey struct {
+	schema *yang.Entry
+	t      reflect.Type
+}
+
+// jsonStreamNode is a node in the trie of data tree paths of the fields of a
+// struct. Each node corresponds to a JSON member name relative to the JSON
+// object of the struct.
+type jsonStreamNode struct {
+	// path is the data tree path of the node relative to the struct.
+	path []string
+	// fields are the fields of the struct which have a data tree path that
+	// terminates at this node.
+	fields []*jsonStreamField
+	// children are the child nodes, keyed by JSON member name.
+	children map[string]*jsonStreamNode
+	// disabled are the children of the schema node at this node that were
+	// removed since their if-feature statements evaluated to false, keyed
+	// by name. It is populated only for nodes at which no field terminates.
+	disabled map[string][]string
+}
+
+// jsonStreamField describes a field of a struct that can be populated from a
+// JSON value.
+type jsonStreamField struct {
+	index  int
+	ft     reflect.StructField
+	schema *yang.Entry
+	// ambiguous indicates that the field has more than one data tree path,
+	// such that its value must be decoded in full so that it can be compared
+	// with the values found at its other paths.
+	ambiguous bool
+	// annotation indicates that the field is an annotation field, which
+	// holds RFC7952 metadata rather than data.
+	annotation bool
+}
+
+// jsonStreamStruct is the state of a struct that is being populated.
+type jsonStreamStruct struct {
+	schema *yang.Entry
+	parent interface{}
+	destv  reflect.Value
+	// orig is the struct as it was before the document was read, to which
+	// fields are restored when a JSON object member is repeated. It is
+	// invalid for structs that are created from the document.
+	orig reflect.Value
+	// shared indicates that the fields of destv are shared with orig, such
+	// that each field must be copied from orig before it is modified.
+	shared bool
+	// copied holds the fields of a shared struct that have been copied.
+	copied map[int]bool
+	// seen holds the values and paths of fields with more than one data
+	// tree path that have already been populated.
+	seen map[int][]jsonStreamSeen
+}
+
+// jsonStreamSeen is a value that was found at path.
+type jsonStreamSeen struct {
+	path  []string
+	value interface{}
+}
+
+// node populates parent, using the given schema, from the JSON value that
+// begins with the token tok. orig is the struct pointed to by parent as it was
+// before the document was read, if any. If the value cannot be streamed into
+// parent, it is decoded in full and unmarshalled using unmarshalGeneric, such
+// that the same error is returned as for Unmarshal.
+func (d *jsonStreamDecoder) node(schema *yang.Entry, parent interface{}, orig reflect.Value, tok json.Token) error {
+	pt := reflect.TypeOf(parent)
+	switch {
+	case tok == nil:
+		return nil
+	case tok == json.Delim('{') && isStreamedStruct(schema, pt):
+		if schema.IsList() {
+			// A single list entry, see unmarshalContainerWithListSchema.
+			newSchema := *schema
+			newSchema.ListAttr = nil
+			schema = &newSchema
+		}
+		return d.object(schema, parent, orig)
+	case schema.IsList() && tok == json.Delim('['):
+		if err := validateListSchema(schema); err != nil {
+			return err
+		}
+		if util.IsTypeMap(pt) || util.IsTypeSlicePtr(pt) || util.IsTypeOrderedMap(pt) {
+			return d.list(schema, parent)
+		}
+	}
+
+	v, err := d.rest(tok)
+	if err != nil {
+		return err
+	}
+	return unmarshalGeneric(schema, parent, v, JSONEncoding, d.opts...)
+}
+
+// list populates parent, which must be a map, a slice ptr or an ordered map,
+// with the entries of the JSON array of the list with the given schema. The
+// opening delimiter of the array must already have been consumed.
+func (d *jsonStreamDecoder) list(schema *yang.Entry, parent interface{}) error {
+	t := reflect.TypeOf(parent)
+	listElementType, err := listElemType(t)
+	if err != nil {
+		return err
+	}
+	if !util.IsTypeStructPtr(listElementType) {
+		return fmt.Errorf("unmarshalList for %s parent type %T, has bad field type %v", listElementType, parent, listElementType)
+	}
+
+	for d.dec.More() {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		newVal := reflect.New(listElementType.Elem())
+		switch tok {
+		case json.Delim('{'):
+			if err := d.object(schema, newVal.Interface(), reflect.Value{}); err != nil {
+				return err
+			}
+		case nil:
+		default:
+			v, err := d.rest(tok)
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("unmarshalList for schema %s: list entry %v: got type %T, expect map[string]interface{}",
+				schema.Name, util.ValueStr(v), v)
+		}
+
+		switch {
+		case util.IsTypeMap(t):
+			var newKey reflect.Value
+			if newKey, err = makeKeyForInsert(schema, parent, newVal); err != nil {
+				return err
+			}
+			err = util.InsertIntoMap(parent, newKey.Interface(), newVal.Interface())
+		case util.IsTypeOrderedMap(t):
+			err = util.AppendIntoOrderedMap(parent, newVal.Interface())
+		default:
+			err = util.InsertIntoSlice(parent, newVal.Interface())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = d.dec.Token()
+	return err
+}
+
+// object populates parent, which must be a struct ptr, with the members of a
+// JSON object using the given schema. orig is the struct pointed to by parent
+// as it was before the document was read, if any. The opening delimiter of the
+// object must already have been consumed.
+func (d *jsonStreamDecoder) object(schema *yang.Entry, parent interface{}, orig reflect.Value) error {
+	root, err := d.trie(schema, parent)
+	if err != nil {
+		return err
+	}
+
+	s := &jsonStreamStruct{
+		schema: schema,
+		parent: parent,
+		destv:  reflect.ValueOf(parent).Elem(),
+		orig:   orig,
+		shared: parent == d.root,
+	}
+	// As with unmarshalStruct, unexpected fields are reported only once all
+	// known fields have been unmarshalled.
+	var extra []string
+	names := map[string]bool{}
+	for d.dec.More() {
+		k, err := d.key()
+		if err != nil {
+			return err
+		}
+		n := root.children[util.StripModulePrefix(k)]
+		if exprs, ok := root.disabled[util.StripModulePrefix(k)]; ok && n == nil {
+			return disabledFieldError(schema, parent, []string{k}, exprs)
+		}
+		if n != nil && names[k] {
+			if err := d.clear(s, n); err != nil {
+				return err
+			}
+		}
+		names[k] = true
+		if n == nil {
+			if !d.ignore {
+				extra = append(extra, k)
+			}
+			if err := d.skip(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := d.member(s, n); err != nil {
+			return err
+		}
+	}
+	if _, err := d.dec.Token(); err != nil {
+		return err
+	}
+	if err := s.check(); err != nil {
+		return err
+	}
+	if len(extra) > 0 {
+		sort.Strings(extra)
+		return fmt.Errorf("parent container %s (type %T): JSON contains unexpected field %s", schema.Name, parent, extra[0])
+	}
+	return nil
+}
+
+// member populates the fields of s that are found at or below the trie node n
+// from the next value in the token stream.
+func (d *jsonStreamDecoder) member(s *jsonStreamStruct, n *jsonStreamNode) error {
+	switch {
+	case len(n.fields) == 1 && len(n.children) == 0:
+		return d.field(s, n.fields[0], n.path)
+	case len(n.fields) == 0:
+		tok, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok != json.Delim('{') {
+			// A value that is not an object cannot contain any of the
+			// descendant paths.
+			_, err := d.rest(tok)
+			return err
+		}
+		names := map[string]bool{}
+		for d.dec.More() {
+			k, err := d.key()
+			if err != nil {
+				return err
+			}
+			c := n.children[util.StripModulePrefix(k)]
+			if exprs, ok := n.disabled[util.StripModulePrefix(k)]; ok && c == nil {
+				return disabledFieldError(s.schema, s.parent, append(append([]string{}, n.path...), k), exprs)
+			}
+			if c != nil && names[k] {
+				if err := d.clear(s, c); err != nil {
+					return err
+				}
+			}
+			names[k] = true
+			if c == nil {
+				if err := d.skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := d.member(s, c); err != nil {
+				return err
+			}
+		}
+		_, err = d.dec.Token()
+		return err
+	}
+
+	// The value at this node is needed by more than one field, hence it must
+	// be decoded in full.
+	var v interface{}
+	if err := d.dec.Decode(&v); err != nil {
+		return err
+	}
+	return d.tree(s, n, v)
+}
+
+// tree populates the fields of s that are found at or below the trie node n
+// from the decoded JSON value v.
+func (d *jsonStreamDecoder) tree(s *jsonStreamStruct, n *jsonStreamNode, v interface{}) error {
+	for _, f := range n.fields {
+		if err := d.set(s, f, n.path, v); err != nil {
+			return err
+		}
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for k, cv := range m {
+		if exprs, ok := n.disabled[util.StripModulePrefix(k)]; ok && n.children[util.StripModulePrefix(k)] == nil {
+			return disabledFieldError(s.schema, s.parent, append(append([]string{}, n.path...), k), exprs)
+		}
+		if c := n.children[util.StripModulePrefix(k)]; c != nil {
+			if err := d.tree(s, c, cv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// field populates the field f of s, found at path, from the next value in the
+// token stream. Containers and lists are streamed into the field, all other
+// values are decoded in full.
+func (d *jsonStreamDecoder) field(s *jsonStreamStruct, f *jsonStreamField, path []string) error {
+	if f.ambiguous || f.annotation || !(f.schema.IsContainer() || f.schema.IsList()) {
+		var v interface{}
+		if err := d.dec.Decode(&v); err != nil {
+			return err
+		}
+		return d.set(s, f, path, v)
+	}
+
+	// Unmarshal treats null as an absent value, hence the field must not be
+	// created for it.
+	tok, err := d.dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+
+	if err := s.copy(f.index); err != nil {
+		return err
+	}
+	fv := s.destv.Field(f.index)
+	if util.IsNilOrInvalidValue(fv) {
+		makeField(s.destv, f.ft)
+	}
+	var orig reflect.Value
+	if s.orig.IsValid() {
+		if ov := s.orig.Field(f.index); util.IsValueStructPtr(ov) {
+			orig = ov.Elem()
+		}
+	}
+	return d.node(f.schema, fieldParent(s.parent, fv, f.schema), orig, tok)
+}
+
+// set populates the field f of s with the decoded JSON value v which was
+// found at path, in the same way as unmarshalStruct.
+func (d *jsonStreamDecoder) set(s *jsonStreamStruct, f *jsonStreamField, path []string, v interface{}) error {
+	if f.ambiguous {
+		// The values are compared once the whole JSON object has been
+		// read, since a value may be replaced by a later member of the
+		// same name.
+		prev := s.seen[f.index]
+		if v != nil || len(prev) > 0 {
+			if s.seen == nil {
+				s.seen = map[int][]jsonStreamSeen{}
+			}
+			s.seen[f.index] = append(prev, jsonStreamSeen{path: path, value: v})
+		}
+		if len(prev) > 0 {
+			return nil
+		}
+	}
+	if v == nil {
+		return nil
+	}
+	return d.apply(s, f, v)
+}
+
+// apply unmarshals the decoded JSON value v into the field f of s.
+func (d *jsonStreamDecoder) apply(s *jsonStreamStruct, f *jsonStreamField, v interface{}) error {
+	if err := s.copy(f.index); err != nil {
+		return err
+	}
+	if f.annotation {
+		return unmarshalMetadata(s.schema, s.destv.Field(f.index), v, d.opts...)
+	}
+
+	fv := s.destv.Field(f.index)
+	if util.IsNilOrInvalidValue(fv) {
+		makeField(s.destv, f.ft)
+	}
+	return unmarshalGeneric(f.schema, fieldParent(s.parent, fv, f.schema), v, JSONEncoding, d.opts...)
+}
+
+// clear restores the fields of s that are found at or below the trie node n
+// to their values before the document was read, such that only the last of
+// the members of a JSON object that have the same name is unmarshalled.
+func (d *jsonStreamDecoder) clear(s *jsonStreamStruct, n *jsonStreamNode) error {
+	for _, f := range n.fields {
+		var keep []jsonStreamSeen
+		if f.ambiguous {
+			for _, p := range s.seen[f.index] {
+				if !pathMatchesPrefix(p.path, n.path) {
+					keep = append(keep, p)
+				}
+			}
+			if len(keep) == len(s.seen[f.index]) {
+				continue
+			}
+			for len(keep) > 0 && keep[0].value == nil {
+				keep = keep[1:]
+			}
+			s.seen[f.index] = keep
+		}
+		if err := s.reset(f.index); err != nil {
+			return err
+		}
+		// The values found at the other paths of the field remain.
+		if len(keep) > 0 {
+			if err := d.apply(s, f, keep[0].value); err != nil {
+				return err
+			}
+		}
+	}
+	for _, c := range n.children {
+		if err := d.clear(s, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check returns an error if different values were found at the data tree
+// paths of a field of s.
+func (s *jsonStreamStruct) check() error {
+	var idx []int
+	for i := range s.seen {
+		idx = append(idx, i)
+	}
+	sort.Ints(idx)
+	for _, i := range idx {
+		seen := s.seen[i]
+		for j := 1; j < len(seen); j++ {
+			c := seen[j]
+			if !cmp.Equal(seen[0].value, c.value) {
+				return fmt.Errorf("values at paths %v and %v are different: %v != %v", seen[0].path, c.path, seen[0].value, c.value)
+			}
+		}
+	}
+	return nil
+}
+
+// copy copies the field with index i of a shared struct from orig before it
+// is first modified.
+func (s *jsonStreamStruct) copy(i int) error {
+	if !s.shared || s.copied[i] {
+		return nil
+	}
+	return s.reset(i)
+}
+
+// reset sets the field with index i of s to a copy of its value in orig, or
+// to its zero value if the struct was created from the document.
+func (s *jsonStreamStruct) reset(i int) error {
+	fv := s.destv.Field(i)
+	fv.Set(reflect.Zero(fv.Type()))
+	if s.shared {
+		if s.copied == nil {
+			s.copied = map[int]bool{}
+		}
+		s.copied[i] = true
+	}
+	if !s.orig.IsValid() || s.orig.Field(i).IsZero() {
+		return nil
+	}
+
+	// The field is copied by copying a struct in which only the field is
+	// populated.
+	p := reflect.New(s.destv.Type())
+	p.Elem().Field(i).Set(s.orig.Field(i))
+	gs, ok := p.Interface().(ygot.GoStruct)
+	if !ok {
+		return fmt.Errorf("cannot copy field %s of %T, which is not a GoStruct", s.destv.Type().Field(i).Name, s.parent)
+	}
+	cp, err := ygot.DeepCopy(gs)
+	if err != nil {
+		return err
+	}
+	fv.Set(reflect.ValueOf(cp).Elem().Field(i))
+	return nil
+}
+
+// fieldParent returns the parent that should be supplied when unmarshalling
+// the field fv of the struct ptr parent with the given schema.
+func fieldParent(parent interface{}, fv reflect.Value, schema *yang.Entry) interface{} {
+	switch {
+	case util.IsUnkeyedList(schema):
+		// For unkeyed list, we must pass in the addr of the slice to be
+		// able to append to it.
+		return fv.Addr().Interface()
+	case schema.IsContainer() || schema.IsList():
+		return fv.Interface()
+	}
+	return parent
+}
+
+// trie returns the trie of the data tree paths of the fields of parent, which
+// must be a struct ptr described by schema.
+func (d *jsonStreamDecoder) trie(schema *yang.Entry, parent interface{}) (*jsonStreamNode, error) {
+	t := reflect.TypeOf(parent).Elem()
+	key := jsonStreamKey{schema: schema, t: t}
+	if n, ok := d.tries[key]; ok {
+		return n, nil
+	}
+
+	root := &jsonStreamNode{}
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+
+		// Annotation fields are described by the schema of the struct, and
+		// are populated from the RFC7952 metadata at their paths.
+		if util.IsYgotAnnotation(ft) {
+			sp, err := annotationDataTreePaths(schema, ft)
+			if err != nil {
+				return nil, err
+			}
+			root.add(&jsonStreamField{index: i, ft: ft, schema: schema, ambiguous: len(sp) > 1, annotation: true}, sp)
+			continue
+		}
+
+		cschema, err := util.ChildSchema(schema, ft)
+		if err != nil {
+			return nil, err
+		}
+		if cschema == nil {
+			return nil, fmt.Errorf("unmarshalContainer could not find schema for type %T, field name %s", parent, ft.Name)
+		}
+		sp, err := dataTreePaths(schema, cschema, ft)
+		if err != nil {
+			return nil, err
+		}
+
+		root.add(&jsonStreamField{index: i, ft: ft, schema: cschema, ambiguous: len(sp) > 1}, sp)
+	}
+
+	root.addDisabled(schema)
+
+	d.tries[key] = root
+	return root, nil
+}
+
+// addDisabled populates the disabled children of n, which corresponds to the
+// given schema, and of each of its descendants that corresponds to a container
+// at which no field terminates, such as the config and state containers of a
+// compressed schema.
+func (n *jsonStreamNode) addDisabled(schema *yang.Entry) {
+	n.disabled = disabledChildren(schema)
+	for name, c := range n.children {
+		if cs := schema.Dir[name]; len(c.fields) == 0 && cs != nil && cs.IsContainer() {
+			c.addDisabled(cs)
+		}
+	}
+}
+
+// add adds the field f to the trie rooted at n at each of the data tree paths.
+func (n *jsonStreamNode) add(f *jsonStreamField, paths [][]string) {
+	for _, p := range paths {
+		if len(p) == 0 {
+			continue
+		}
+		c := n
+		for _, e := range p {
+			c = c.child(e)
+		}
+		c.fields = append(c.fields, f)
+	}
+}
+
+// child returns the child of n with the given name, creating it if it does
+// not exist. The name is stripped of any module prefix.
+func (n *jsonStreamNode) child(name string) *jsonStreamNode {
+	name = util.StripModulePrefix(name)
+	if n.children == nil {
+		n.children = map[string]*jsonStreamNode{}
+	}
+	c, ok := n.children[name]
+	if !ok {
+		c = &jsonStreamNode{path: append(append([]string{}, n.path...), name)}
+		n.children[name] = c
+	}
+	return c
+}
+
+// key returns the next JSON object member name from the token stream.
+func (d *jsonStreamDecoder) key() (string, error) {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return "", err
+	}
+	k, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid JSON document, got %v (%T) for object member name", tok, tok)
+	}
+	return k, nil
+}
+
+// skip discards the next value in the token stream.
+func (d *jsonStreamDecoder) skip() error {
+	var raw json.RawMessage
+	return d.dec.Decode(&raw)
+}
+
+// rest returns the JSON value that begins with the token tok, consuming the
+// remainder of the value from the token stream.
+func (d *jsonStreamDecoder) rest(tok json.Token) (interface{}, error) {
+	switch tok {
+	case json.Delim('{'):
+		m := map[string]interface{}{}
+		for d.dec.More() {
+			k, err := d.key()
+			if err != nil {
+				return nil, err
+			}
+			var v interface{}
+			if err := d.dec.Decode(&v); err != nil {
+				return nil, err
+			}
+			m[k] = v
+		}
+		_, err := d.dec.Token()
+		return m, err
+	case json.Delim('['):
+		l := []interface{}{}
+		for d.dec.More() {
+			var v interface{}
+			if err := d.dec.Decode(&v); err != nil {
+				return nil, err
+			}
+			l = append(l, v)
+		}
+		_, err := d.dec.Token()
+		return l, err
+	}
+	return tok, nil
+}
@@ -0,0 +1,418 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+type streamRoot struct {
+	Name       *string                `path:"config/name|state/name"`
+	Settings   *streamSettings        `path:"settings"`
+	Intf       map[string]*streamIntf `path:"interfaces/intf"`
+	Neighbor   []*streamNeighbor      `path:"neighbor"`
+	Tag        []string               `path:"tag"`
+	Annotation []ygot.Annotation      `path:"@" ygotAnnotation:"true"`
+}
+
+func (*streamRoot) IsYANGGoStruct() {}
+
+type streamSettings struct {
+	Mtu *uint16 `path:"mtu"`
+}
+
+func (*streamSettings) IsYANGGoStruct() {}
+
+type streamIntf struct {
+	Name    *string `path:"config/name|name"`
+	Enabled *bool   `path:"config/enabled"`
+}
+
+func (*streamIntf) IsYANGGoStruct() {}
+
+type streamNeighbor struct {
+	Address *string `path:"address"`
+}
+
+func (*streamNeighbor) IsYANGGoStruct() {}
+
+// streamSchema returns the schema of the streamRoot struct.
+func streamSchema() *yang.Entry {
+	leaf := func(name string, k yang.TypeKind) *yang.Entry {
+		return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: &yang.YangType{Kind: k}}
+	}
+	schema := &yang.Entry{
+		Name: "root",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"config": {
+				Name: "config",
+				Kind: yang.DirectoryEntry,
+				Dir:  map[string]*yang.Entry{"name": leaf("name", yang.Ystring)},
+			},
+			"state": {
+				Name: "state",
+				Kind: yang.DirectoryEntry,
+				Dir:  map[string]*yang.Entry{"name": leaf("name", yang.Ystring)},
+			},
+			"settings": {
+				Name: "settings",
+				Kind: yang.DirectoryEntry,
+				Dir:  map[string]*yang.Entry{"mtu": leaf("mtu", yang.Yuint16)},
+			},
+			"interfaces": {
+				Name: "interfaces",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"intf": {
+						Name:     "intf",
+						Kind:     yang.DirectoryEntry,
+						Key:      "name",
+						ListAttr: &yang.ListAttr{},
+						Dir: map[string]*yang.Entry{
+							"name": leaf("name", yang.Ystring),
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir: map[string]*yang.Entry{
+									"name":    leaf("name", yang.Ystring),
+									"enabled": leaf("enabled", yang.Ybool),
+								},
+							},
+						},
+					},
+				},
+			},
+			"neighbor": {
+				Name:     "neighbor",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: &yang.ListAttr{},
+				Dir:      map[string]*yang.Entry{"address": leaf("address", yang.Ystring)},
+			},
+			"tag": {
+				Name:     "tag",
+				Kind:     yang.LeafEntry,
+				ListAttr: &yang.ListAttr{},
+				Type:     &yang.YangType{Kind: yang.Ystring},
+			},
+		},
+	}
+	populateParentField(nil, schema)
+	return schema
+}
+
+func TestUnmarshalJSONStream(t *testing.T) {
+	schema := streamSchema()
+
+	tests := []struct {
+		desc             string
+		inSchema         *yang.Entry
+		inJSON           string
+		inParent         func() interface{}
+		inOpts           []UnmarshalOpt
+		want             interface{}
+		wantErrSubstring string
+	}{{
+		desc:     "all node types",
+		inSchema: schema,
+		inJSON: `{
+  "config": {"name": "dev"},
+  "state": {"name": "dev"},
+  "m:settings": {"mtu": 1500},
+  "interfaces": {"intf": [
+    {"name": "eth0", "config": {"name": "eth0", "enabled": true}},
+    {"name": "eth1"}
+  ]},
+  "neighbor": [{"address": "192.0.2.1"}, {"address": "192.0.2.2"}],
+  "tag": ["a", "b"],
+  "@": [{"foo": "bar"}]
+}`,
+		inParent: func() interface{} { return &streamRoot{} },
+		want: &streamRoot{
+			Name:     ygot.String("dev"),
+			Settings: &streamSettings{Mtu: ygot.Uint16(1500)},
+			Intf: map[string]*streamIntf{
+				"eth0": {Name: ygot.String("eth0"), Enabled: ygot.Bool(true)},
+				"eth1": {Name: ygot.String("eth1")},
+			},
+			Neighbor: []*streamNeighbor{{Address: ygot.String("192.0.2.1")}, {Address: ygot.String("192.0.2.2")}},
+			Tag:      []string{"a", "b"},
+		},
+	}, {
+		desc:     "existing values preserved",
+		inSchema: schema,
+		inJSON:   `{"interfaces": {"intf": [{"name": "eth1"}]}, "tag": ["b"]}`,
+		inParent: func() interface{} {
+			return &streamRoot{
+				Name: ygot.String("dev"),
+				Intf: map[string]*streamIntf{"eth0": {Name: ygot.String("eth0")}},
+				Tag:  []string{"a"},
+			}
+		},
+		want: &streamRoot{
+			Name: ygot.String("dev"),
+			Intf: map[string]*streamIntf{"eth0": {Name: ygot.String("eth0")}, "eth1": {Name: ygot.String("eth1")}},
+			Tag:  []string{"a", "b"},
+		},
+	}, {
+		desc:     "null values",
+		inSchema: schema,
+		inJSON:   `{"config": null, "settings": null, "interfaces": {"intf": null}, "neighbor": null, "tag": null}`,
+		inParent: func() interface{} { return &streamRoot{} },
+		want:     &streamRoot{},
+	}, {
+		desc:     "empty container",
+		inSchema: schema,
+		inJSON:   `{"settings": {}}`,
+		inParent: func() interface{} { return &streamRoot{} },
+		want:     &streamRoot{Settings: &streamSettings{}},
+	}, {
+		desc:     "unknown member of intermediate container",
+		inSchema: schema,
+		inJSON:   `{"config": {"name": "dev", "bogus": 42}}`,
+		inParent: func() interface{} { return &streamRoot{} },
+		want:     &streamRoot{Name: ygot.String("dev")},
+	}, {
+		desc:             "unexpected field",
+		inSchema:         schema,
+		inJSON:           `{"settings": {"mtu": 1500}, "bogus": 42}`,
+		inParent:         func() interface{} { return &streamRoot{} },
+		wantErrSubstring: "parent container root (type *ytypes.streamRoot): JSON contains unexpected field bogus",
+	}, {
+		desc:             "unexpected field in list entry",
+		inSchema:         schema,
+		inJSON:           `{"interfaces": {"intf": [{"name": "eth0", "bogus": 42}]}}`,
+		inParent:         func() interface{} { return &streamRoot{} },
+		wantErrSubstring: "parent container intf (type *ytypes.streamIntf): JSON contains unexpected field bogus",
+	}, {
+		desc:     "unexpected fields ignored",
+		inSchema: schema,
+		inJSON:   `{"bogus": {"a": [1, 2]}, "interfaces": {"intf": [{"name": "eth0", "bogus": 42}]}}`,
+		inParent: func() interface{} { return &streamRoot{} },
+		inOpts:   []UnmarshalOpt{&IgnoreExtraFields{}},
+		want:     &streamRoot{Intf: map[string]*streamIntf{"eth0": {Name: ygot.String("eth0")}}},
+	}, {
+		desc:             "different values at paths of field",
+		inSchema:         schema,
+		inJSON:           `{"config": {"name": "dev"}, "state": {"name": "other"}}`,
+		inParent:         func() interface{} { return &streamRoot{} },
+		wantErrSubstring: "values at paths [config name] and [state name] are different: dev != other",
+	}, {
+		desc:     "repeated members",
+		inSchema: schema,
+		inJSON:   `{"settings": {"mtu": 1500}, "tag": ["a"], "settings": {}, "tag": ["b"]}`,
+		inParent: func() interface{} { return &streamRoot{} },
+		want:     &streamRoot{Settings: &streamSettings{}, Tag: []string{"b"}},
+	}, {
+		desc:     "repeated members with existing values",
+		inSchema: schema,
+		inJSON:   `{"settings": {"mtu": 1500}, "interfaces": {"intf": [{"name": "eth1"}]}, "settings": null, "interfaces": {"intf": [{"name": "eth2"}]}}`,
+		inParent: func() interface{} {
+			return &streamRoot{
+				Settings: &streamSettings{Mtu: ygot.Uint16(9000)},
+				Intf:     map[string]*streamIntf{"eth0": {Name: ygot.String("eth0")}},
+			}
+		},
+		want: &streamRoot{
+			Settings: &streamSettings{Mtu: ygot.Uint16(9000)},
+			Intf:     map[string]*streamIntf{"eth0": {Name: ygot.String("eth0")}, "eth2": {Name: ygot.String("eth2")}},
+		},
+	}, {
+		desc:     "repeated members within intermediate container",
+		inSchema: schema,
+		inJSON:   `{"state": {"name": "dev"}, "config": {"name": "other", "name": "dev"}}`,
+		inParent: func() interface{} { return &streamRoot{} },
+		want:     &streamRoot{Name: ygot.String("dev")},
+	}, {
+		desc:     "repeated intermediate container",
+		inSchema: schema,
+		inJSON:   `{"config": {"name": "other"}, "state": {"name": "dev"}, "config": {"name": "dev"}}`,
+		inParent: func() interface{} { return &streamRoot{} },
+		want:     &streamRoot{Name: ygot.String("dev")},
+	}, {
+		desc:     "repeated intermediate container without value",
+		inSchema: schema,
+		inJSON:   `{"config": {"name": "other"}, "config": {}}`,
+		inParent: func() interface{} { return &streamRoot{Name: ygot.String("dev")} },
+		want:     &streamRoot{Name: ygot.String("dev")},
+	}, {
+		desc:     "repeated members within list entry",
+		inSchema: schema,
+		inJSON:   `{"interfaces": {"intf": [{"name": "eth0", "config": {"enabled": true}, "config": {"name": "eth0"}}]}}`,
+		inParent: func() interface{} { return &streamRoot{} },
+		want:     &streamRoot{Intf: map[string]*streamIntf{"eth0": {Name: ygot.String("eth0")}}},
+	}, {
+		desc:             "different values at paths of field after repeated member",
+		inSchema:         schema,
+		inJSON:           `{"config": {"name": "dev"}, "config": {"name": "other"}, "state": {"name": "dev"}}`,
+		inParent:         func() interface{} { return &streamRoot{} },
+		wantErrSubstring: "values at paths [config name] and [state name] are different: other != dev",
+	}, {
+		desc:             "invalid leaf value",
+		inSchema:         schema,
+		inJSON:           `{"settings": {"mtu": "1500"}}`,
+		inParent:         func() interface{} { return &streamRoot{} },
+		wantErrSubstring: "got string type for field mtu, expect float64",
+	}, {
+		desc:             "array for container",
+		inSchema:         schema,
+		inJSON:           `{"settings": [1]}`,
+		inParent:         func() interface{} { return &streamRoot{} },
+		wantErrSubstring: "unmarshalContainer for schema settings: jsonTree [ 1 (float64) ]: got type []interface {} inside container",
+	}, {
+		desc:             "object for list",
+		inSchema:         schema,
+		inJSON:           `{"neighbor": {"address": "192.0.2.1"}}`,
+		inParent:         func() interface{} { return &streamRoot{} },
+		wantErrSubstring: "got type map[string]interface {}, expect []interface{}",
+	}, {
+		desc:             "list entry without key",
+		inSchema:         schema,
+		inJSON:           `{"interfaces": {"intf": [{"config": {"enabled": true}}]}}`,
+		inParent:         func() interface{} { return &streamRoot{} },
+		wantErrSubstring: "key field name (*string) has nil value",
+	}, {
+		desc:     "single list entry",
+		inSchema: schema.Dir["interfaces"].Dir["intf"],
+		inJSON:   `{"name": "eth0", "config": {"enabled": false}}`,
+		inParent: func() interface{} { return &streamIntf{} },
+		want:     &streamIntf{Name: ygot.String("eth0"), Enabled: ygot.Bool(false)},
+	}, {
+		desc:     "list",
+		inSchema: schema.Dir["interfaces"].Dir["intf"],
+		inJSON:   `[{"name": "eth0"}]`,
+		inParent: func() interface{} { return map[string]*streamIntf{} },
+		want:     map[string]*streamIntf{"eth0": {Name: ygot.String("eth0")}},
+	}, {
+		desc:     "leaf",
+		inSchema: schema.Dir["settings"].Dir["mtu"],
+		inJSON:   `9000`,
+		inParent: func() interface{} { return &streamSettings{} },
+		want:     &streamSettings{Mtu: ygot.Uint16(9000)},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var jsonTree interface{}
+			if err := json.Unmarshal([]byte(tt.inJSON), &jsonTree); err != nil {
+				t.Fatalf("json.Unmarshal(%s): got unexpected error, %v", tt.inJSON, err)
+			}
+			want := tt.inParent()
+			wantErr := Unmarshal(tt.inSchema, want, jsonTree, tt.inOpts...)
+			if diff := errdiff.Substring(wantErr, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Unmarshal: did not get expected error, %s", diff)
+			}
+
+			got := tt.inParent()
+			err := UnmarshalJSONStream(tt.inSchema, got, strings.NewReader(tt.inJSON), tt.inOpts...)
+			if diff := cmp.Diff(fmt.Sprint(wantErr), fmt.Sprint(err)); diff != "" {
+				t.Fatalf("UnmarshalJSONStream: did not get same error as Unmarshal, diff(-want, +got):\n%s", diff)
+			}
+			if err != nil {
+				if diff := cmp.Diff(tt.inParent(), got); diff != "" {
+					t.Errorf("UnmarshalJSONStream: parent was modified, diff(-want, +got):\n%s", diff)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalJSONStream: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("UnmarshalJSONStream: did not get same GoStruct as Unmarshal, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONStreamInvalidJSON(t *testing.T) {
+	schema := streamSchema()
+	for _, tt := range []struct {
+		in               string
+		wantErrSubstring string
+	}{
+		{in: ``, wantErrSubstring: "EOF"},
+		{in: `{"settings": {"mtu": 1500}`, wantErrSubstring: "unexpected end of JSON input"},
+		{in: `{"settings": {"mtu": }}`, wantErrSubstring: "invalid character"},
+		{in: `{} {}`, wantErrSubstring: "unexpected { after top-level value"},
+		{in: `{"settings": {"mtu": 1500},}`, wantErrSubstring: "invalid character"},
+		{in: `{"config": {"name": "other"}, "tag": ["b",]}`, wantErrSubstring: "invalid character"},
+		{in: `{"interfaces": {"intf": [{"name": "eth1"}]}, "neighbor": [{"address": "192.0.2.1"}} `, wantErrSubstring: "invalid character"},
+	} {
+		// The parent must not be modified by a document that is not
+		// valid JSON, as for json.Unmarshal of the document.
+		parent := func() *streamRoot {
+			return &streamRoot{
+				Name:     ygot.String("dev"),
+				Settings: &streamSettings{Mtu: ygot.Uint16(9000)},
+				Intf:     map[string]*streamIntf{"eth0": {Name: ygot.String("eth0")}},
+				Tag:      []string{"a"},
+			}
+		}
+		got := parent()
+		err := UnmarshalJSONStream(schema, got, strings.NewReader(tt.in))
+		if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+			t.Errorf("UnmarshalJSONStream(%s): did not get expected error, %s", tt.in, diff)
+		}
+		if diff := cmp.Diff(parent(), got); diff != "" {
+			t.Errorf("UnmarshalJSONStream(%s): parent was modified, diff(-want, +got):\n%s", tt.in, diff)
+		}
+	}
+}
+
+// benchmarkStreamJSON returns a JSON document for the streamRoot struct with n
+// list entries.
+func benchmarkStreamJSON(n int) []byte {
+	var b strings.Builder
+	b.WriteString(`{"config": {"name": "dev"}, "interfaces": {"intf": [`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"name": "eth%d", "config": {"name": "eth%d", "enabled": true}}`, i, i)
+	}
+	b.WriteString(`]}}`)
+	return []byte(b.String())
+}
+
+func BenchmarkUnmarshalJSONStream(b *testing.B) {
+	schema := streamSchema()
+	in := benchmarkStreamJSON(1000)
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var jsonTree interface{}
+			if err := json.Unmarshal(in, &jsonTree); err != nil {
+				b.Fatalf("json.Unmarshal: got unexpected error, %v", err)
+			}
+			if err := Unmarshal(schema, &streamRoot{}, jsonTree); err != nil {
+				b.Fatalf("Unmarshal: got unexpected error, %v", err)
+			}
+		}
+	})
+	b.Run("stream", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := UnmarshalJSONStream(schema, &streamRoot{}, strings.NewReader(string(in))); err != nil {
+				b.Fatalf("UnmarshalJSONStream: got unexpected error, %v", err)
+			}
+		}
+	})
+}
@@ -17,11 +17,13 @@ package ytypes
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
-	"github.com/kylelemons/godebug/pretty"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 // Refer to: https://tools.ietf.org/html/rfc6020#section-7.8.
@@ -41,12 +43,23 @@ func validateList(schema *yang.Entry, value interface{}) util.Errors {
 
 	util.DbgPrint("validateList with value %v, type %T, schema name %s", value, value, schema.Name)
 
+	// The order of the entries of an ordered map does not affect its
+	// validity, hence it is validated as a map of its entries.
+	if util.IsValueOrderedMap(reflect.ValueOf(value)) {
+		m, err := util.OrderedMapToMap(reflect.ValueOf(value))
+		if err != nil {
+			return util.NewErrs(err)
+		}
+		value = m.Interface()
+	}
+
 	kind := reflect.TypeOf(value).Kind()
 	if kind == reflect.Slice || kind == reflect.Map {
 		// Check list attributes: size constraints etc.
 		// Skip this check if not a list type - in this case value may be a list
 		// element which shares the list schema (excluding ListAttr).
 		errors = util.AppendErrs(errors, validateListAttr(schema, value))
+		errors = util.AppendErrs(errors, validateListUnique(schema, value))
 	}
 
 	switch kind {
@@ -195,13 +208,117 @@ func validateStructElems(schema *yang.Entry, value interface{}) util.Errors {
 		if cschema == nil {
 			errors = util.AppendErr(errors, fmt.Errorf("child schema not found for struct %s field %s", schema.Name, fieldName))
 		} else {
-			errors = util.AppendErrs(errors, Validate(cschema, fieldValue))
+			errors = util.AppendErrs(errors, validate(cschema, fieldValue))
+		}
+	}
+
+	return errors
+}
+
+// validateListUnique checks that the entries of the list value, which is the
+// map or slice representing the list in the data tree, satisfy each of the
+// unique statements of the list schema. The combined values of the leaves
+// referenced by a unique statement must not be the same in any two entries.
+// Entries in which any of the referenced leaves is not present are not
+// considered. Refer to: https://tools.ietf.org/html/rfc7950#section-7.8.3.
+func validateListUnique(schema *yang.Entry, value interface{}) util.Errors {
+	uniques := util.UniqueStatements(schema)
+	if len(uniques) == 0 {
+		return nil
+	}
+
+	type listEntry struct {
+		desc string
+		node *xpathNode
+	}
+	var errors []error
+	var entries []listEntry
+	addEntry := func(desc string, v reflect.Value) {
+		n := &xpathNode{schema: schema}
+		if err := addXPathStruct(n, v); err != nil {
+			errors = util.AppendErr(errors, err)
+			return
+		}
+		entries = append(entries, listEntry{desc: desc, node: n})
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, k := range keys {
+			addEntry(fmt.Sprintf("key %v", k.Interface()), rv.MapIndex(k))
+		}
+	case reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			addEntry(fmt.Sprintf("index %d", i), rv.Index(i))
+		}
+	}
+
+	for _, u := range uniques {
+		paths := strings.Fields(u)
+		// seen maps the combined values of the referenced leaves to the
+		// entries in which they have been found.
+		seen := map[string][]listEntry{}
+		for _, e := range entries {
+			var vals []string
+			for _, p := range paths {
+				n := uniqueLeaf(e.node, p)
+				if n == nil {
+					break
+				}
+				vals = append(vals, n.stringValue())
+			}
+			if len(vals) != len(paths) {
+				continue
+			}
+			k := fmt.Sprintf("%q", vals)
+			for _, prev := range seen[k] {
+				errors = util.AppendErr(errors, fmt.Errorf("list %s: entries with %s and %s have the same values %v for unique statement %q",
+					schema.Name, prev.desc, e.desc, vals, u))
+			}
+			seen[k] = append(seen[k], e)
 		}
 	}
 
 	return errors
 }
 
+// uniqueLeaf returns the leaf of the list entry n that is referenced by the
+// descendant schema node identifier p of a unique statement, or nil if the
+// leaf is not present. Choice and case nodes within p, which do not appear in
+// the data tree, are skipped.
+func uniqueLeaf(n *xpathNode, p string) *xpathNode {
+	s := n.schema
+	for _, e := range strings.Split(p, "/") {
+		name := xpathLocalName(e)
+		if s = s.Dir[name]; s == nil {
+			return nil
+		}
+		if s.IsChoice() || s.IsCase() {
+			continue
+		}
+		var next *xpathNode
+		for _, c := range n.children {
+			if c.name == name {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		n = next
+	}
+	if !n.isLeaf() {
+		return nil
+	}
+	return n
+}
+
 // validateListSchema validates the given list type schema. This is a sanity
 // check validation rather than a comprehensive validation against the RFC.
 // It is assumed that such a validation is done when the schema is parsed from
@@ -287,10 +404,10 @@ func unmarshalList(schema *yang.Entry, parent interface{}, jsonList interface{},
 
 	util.DbgPrint("unmarshalList jsonList %v, type %T, into parent type %T, schema name %s", util.ValueStrDebug(jsonList), jsonList, parent, schema.Name)
 
-	// Parent must be a map, slice ptr, or struct ptr.
+	// Parent must be a map, slice ptr, ordered map or struct ptr.
 	t := reflect.TypeOf(parent)
 
-	if util.IsTypeStructPtr(t) {
+	if util.IsTypeStructPtr(t) && !util.IsTypeOrderedMap(t) {
 		// May be trying to unmarshal a single list element rather than the
 		// whole list.
 		return unmarshalContainerWithListSchema(schema, parent, jsonList, opts...)
@@ -303,13 +420,13 @@ func unmarshalList(schema *yang.Entry, parent interface{}, jsonList interface{},
 			schema.Name, util.ValueStr(jsonList), jsonList)
 	}
 
-	if !(util.IsTypeMap(t) || util.IsTypeSlicePtr(t)) {
+	if !(util.IsTypeMap(t) || util.IsTypeSlicePtr(t) || util.IsTypeOrderedMap(t)) {
 		return fmt.Errorf("unmarshalList for %s got parent type %s, expect map, slice ptr or struct ptr", schema.Name, t.Kind())
 	}
 
-	listElementType := t.Elem()
-	if util.IsTypeSlicePtr(t) {
-		listElementType = t.Elem().Elem()
+	listElementType, err := listElemType(t)
+	if err != nil {
+		return err
 	}
 	if !util.IsTypeStructPtr(listElementType) {
 		return fmt.Errorf("unmarshalList for %s parent type %T, has bad field type %v", listElementType, parent, listElementType)
@@ -320,40 +437,74 @@ func unmarshalList(schema *yang.Entry, parent interface{}, jsonList interface{},
 	// the new struct list element. When all fields of the new element have been
 	// filled, the constructed object will be added to listFieldName field in
 	// the parent struct, which can be a map or a slice, for keyed/unkeyed list
-	// types respectively.
+	// types respectively. For a keyed list that is ordered-by user, the parent
+	// may be an ordered map, to which the entries are appended in the order of
+	// the JSON list.
 	// For a keyed list, the value(s) of the key are derived from the key fields
 	// in the new list element.
+	// When collecting all errors, the errors of each list entry are
+	// accumulated in errs, with the path of the entry, and the remaining
+	// entries are still unmarshalled.
+	collect := hasCollectAllErrors(opts)
+	var errs util.Errors
 	for _, le := range jl {
 		var err error
 		jt := le.(map[string]interface{})
 		newVal := reflect.New(listElementType.Elem())
 		util.DbgPrint("creating a new list element val of type %v", newVal.Type())
 		if err := unmarshalStruct(schema, newVal.Interface(), jt, enc, opts...); err != nil {
-			return err
+			if !collect {
+				return err
+			}
+			elem := []*gpb.PathElem{listEntryPathElem(schema, jt)}
+			errs = util.AppendErrs(errs, prefixUnmarshalErrors(err, elem, elem, jt))
 		}
 
 		switch {
 		case util.IsTypeMap(t):
 			var newKey reflect.Value
 			newKey, err = makeKeyForInsert(schema, parent, newVal)
-			if err != nil {
-				return err
+			if err == nil {
+				err = util.InsertIntoMap(parent, newKey.Interface(), newVal.Interface())
 			}
-			err = util.InsertIntoMap(parent, newKey.Interface(), newVal.Interface())
 		case util.IsTypeSlicePtr(t):
 			err = util.InsertIntoSlice(parent, newVal.Interface())
+		case util.IsTypeOrderedMap(t):
+			err = util.AppendIntoOrderedMap(parent, newVal.Interface())
 		default:
 			return fmt.Errorf("unexpected type %s inserting in unmarshalList for parent type %T", t, parent)
 		}
 		if err != nil {
-			return err
+			if !collect {
+				return err
+			}
+			errs = util.AppendErr(errs, &UnmarshalError{
+				Path:  &gpb.Path{Elem: []*gpb.PathElem{listEntryPathElem(schema, jt)}},
+				Value: jt,
+				Err:   err,
+			})
 		}
 	}
-	util.DbgPrint("list after unmarshal:\n%s\n", pretty.Sprint(parent))
+	if len(errs) > 0 {
+		return errs
+	}
+	util.DbgPrint("list after unmarshal:\n%s\n", util.PrettyStrDebug(parent))
 
 	return nil
 }
 
+// listElemType returns the type of the entries of a list, where t is the type
+// of the map, slice ptr or ordered map that holds the list.
+func listElemType(t reflect.Type) (reflect.Type, error) {
+	switch {
+	case util.IsTypeSlicePtr(t):
+		return t.Elem().Elem(), nil
+	case util.IsTypeOrderedMap(t):
+		return util.OrderedMapElemType(t)
+	}
+	return t.Elem(), nil
+}
+
 // makeValForInsert is used to create a value with the type extracted from
 // given map. The returned value is populated according to the supplied "keys"
 // map, which is assumed to be the map[string]string keys field from a gNMI
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -389,6 +390,136 @@ func TestValidateListStructKey(t *testing.T) {
 	}
 }
 
+func TestValidateListUnique(t *testing.T) {
+	listSchema := &yang.Entry{
+		Name:     "list-schema",
+		Kind:     yang.DirectoryEntry,
+		ListAttr: yang.NewDefaultListAttr(),
+		Key:      "name",
+		Config:   yang.TSTrue,
+		Annotation: map[string]interface{}{
+			util.UniqueAnnotation: []string{"pfx:config/ip config/port", "choice/case/seq"},
+		},
+		Dir: map[string]*yang.Entry{
+			"name": {
+				Kind: yang.LeafEntry,
+				Name: "name",
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+			"config": {
+				Kind: yang.DirectoryEntry,
+				Name: "config",
+				Dir: map[string]*yang.Entry{
+					"ip": {
+						Kind: yang.LeafEntry,
+						Name: "ip",
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"port": {
+						Kind: yang.LeafEntry,
+						Name: "port",
+						Type: &yang.YangType{Kind: yang.Yuint16},
+					},
+				},
+			},
+			"choice": {
+				Kind: yang.ChoiceEntry,
+				Name: "choice",
+				Dir: map[string]*yang.Entry{
+					"case": {
+						Kind: yang.CaseEntry,
+						Name: "case",
+						Dir: map[string]*yang.Entry{
+							"seq": {
+								Kind: yang.LeafEntry,
+								Name: "seq",
+								Type: &yang.YangType{Kind: yang.Yuint32},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(listSchema)
+
+	type ListElemStruct struct {
+		Name *string `path:"name"`
+		IP   *string `path:"config/ip"`
+		Port *uint16 `path:"config/port"`
+		Seq  *uint32 `path:"seq"`
+	}
+	elem := func(name, ip string, port uint16, seq uint32) *ListElemStruct {
+		e := &ListElemStruct{Name: ygot.String(name), Port: ygot.Uint16(port)}
+		if ip != "" {
+			e.IP = ygot.String(ip)
+		}
+		if seq != 0 {
+			e.Seq = ygot.Uint32(seq)
+		}
+		return e
+	}
+
+	tests := []struct {
+		desc     string
+		val      interface{}
+		wantErrs []string
+	}{{
+		desc: "unique values",
+		val: map[string]*ListElemStruct{
+			"a": elem("a", "10.0.0.1", 80, 1),
+			"b": elem("b", "10.0.0.1", 443, 2),
+			"c": elem("c", "10.0.0.2", 80, 3),
+		},
+	}, {
+		desc: "entries with missing leaves are not considered",
+		val: map[string]*ListElemStruct{
+			"a": elem("a", "", 80, 0),
+			"b": elem("b", "", 80, 0),
+		},
+	}, {
+		desc: "clashing entries",
+		val: map[string]*ListElemStruct{
+			"a": elem("a", "10.0.0.1", 80, 1),
+			"b": elem("b", "10.0.0.1", 80, 2),
+			"c": elem("c", "10.0.0.1", 80, 1),
+		},
+		wantErrs: []string{
+			`list list-schema: entries with key a and key b have the same values [10.0.0.1 80] for unique statement "pfx:config/ip config/port"`,
+			`list list-schema: entries with key a and key c have the same values [10.0.0.1 80] for unique statement "pfx:config/ip config/port"`,
+			`list list-schema: entries with key b and key c have the same values [10.0.0.1 80] for unique statement "pfx:config/ip config/port"`,
+			`list list-schema: entries with key a and key c have the same values [1] for unique statement "choice/case/seq"`,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []string
+			for _, err := range validateListUnique(listSchema, tt.val) {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(tt.wantErrs, got); diff != "" {
+				t.Errorf("validateListUnique(%v): did not get expected errors, (-want, +got):\n%s", tt.val, diff)
+			}
+		})
+	}
+
+	// Keyless lists identify the clashing entries by their index.
+	keyless := []*ListElemStruct{elem("a", "10.0.0.1", 80, 1), elem("b", "10.0.0.2", 80, 1)}
+	want := `list list-schema: entries with index 0 and index 1 have the same values [1] for unique statement "choice/case/seq"`
+	if errs := validateListUnique(listSchema, keyless); len(errs) != 1 || errs[0].Error() != want {
+		t.Errorf("validateListUnique(keyless): got errors %v, want: %s", errs, want)
+	}
+
+	// The unique statements are also checked when validating the list.
+	if errs := Validate(listSchema, map[string]*ListElemStruct{
+		"a": elem("a", "10.0.0.1", 80, 1),
+		"b": elem("b", "10.0.0.1", 80, 2),
+	}); len(errs) != 1 {
+		t.Errorf("Validate: got errors %v, want 1 error", errs)
+	}
+}
+
 func TestUnmarshalList(t *testing.T) {
 	// nil value
 	if got := unmarshalList(nil, nil, nil, JSONEncoding); got != nil {
@@ -784,6 +915,196 @@ func TestUnmarshalSingleListElement(t *testing.T) {
 	}
 }
 
+// orderedListElem is an entry of an ordered map that is generated for a list
+// that is ordered-by user.
+type orderedListElem struct {
+	Key       *string `path:"key"`
+	LeafField *int32  `path:"leaf-field"`
+}
+
+// orderedListMap is an ordered map of orderedListElem structs, which mirrors
+// the ordered maps that are generated by ygen.
+type orderedListMap struct {
+	keys     []string
+	valueMap map[string]*orderedListElem
+}
+
+func (*orderedListMap) IsYANGOrderedList() {}
+
+func (o *orderedListMap) Keys() []string {
+	if o == nil {
+		return nil
+	}
+	return append([]string{}, o.keys...)
+}
+
+func (o *orderedListMap) Values() []*orderedListElem {
+	if o == nil {
+		return nil
+	}
+	var values []*orderedListElem
+	for _, k := range o.keys {
+		values = append(values, o.valueMap[k])
+	}
+	return values
+}
+
+func (o *orderedListMap) Append(v *orderedListElem) error {
+	if v.Key == nil {
+		return fmt.Errorf("invalid nil key received for Key")
+	}
+	if _, ok := o.valueMap[*v.Key]; ok {
+		return fmt.Errorf("duplicate key for list KeyList %v", *v.Key)
+	}
+	if o.valueMap == nil {
+		o.valueMap = map[string]*orderedListElem{}
+	}
+	o.keys = append(o.keys, *v.Key)
+	o.valueMap[*v.Key] = v
+	return nil
+}
+
+type orderedListContainer struct {
+	KeyList *orderedListMap `path:"key-list"`
+}
+
+func orderedListSchema() *yang.Entry {
+	listAttr := yang.NewDefaultListAttr()
+	listAttr.OrderedBy = &yang.Value{Name: "user"}
+	listAttr.MaxElements = 3
+	return &yang.Entry{
+		Name: "container",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"key-list": {
+				Name:     "key-list",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: listAttr,
+				Key:      "key",
+				Config:   yang.TSTrue,
+				Dir: map[string]*yang.Entry{
+					"key": {
+						Kind: yang.LeafEntry,
+						Name: "key",
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"leaf-field": {
+						Kind: yang.LeafEntry,
+						Name: "leaf-field",
+						Type: &yang.YangType{Kind: yang.Yint32},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestUnmarshalOrderedList(t *testing.T) {
+	tests := []struct {
+		desc             string
+		json             string
+		wantKeys         []string
+		wantErrSubstring string
+	}{{
+		desc:     "entries retain order",
+		json:     `{ "key-list" : [ { "key" : "b", "leaf-field" : 1}, { "key" : "c" }, { "key" : "a", "leaf-field" : 3} ] }`,
+		wantKeys: []string{"b", "c", "a"},
+	}, {
+		desc:             "duplicate entries",
+		json:             `{ "key-list" : [ { "key" : "b" }, { "key" : "b" } ] }`,
+		wantErrSubstring: "duplicate key",
+	}, {
+		desc:             "bad field",
+		json:             `{ "key-list" : [ { "key" : "b", "bad-field" : 42} ] }`,
+		wantErrSubstring: "JSON contains unexpected field bad-field",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var jsonTree interface{}
+			if err := json.Unmarshal([]byte(tt.json), &jsonTree); err != nil {
+				t.Fatalf("cannot unmarshal JSON, %v", err)
+			}
+
+			parents := map[string]*orderedListContainer{}
+			parents["Unmarshal"] = &orderedListContainer{}
+			err := Unmarshal(orderedListSchema(), parents["Unmarshal"], jsonTree)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Unmarshal: did not get expected error, %s", diff)
+			}
+
+			parents["UnmarshalJSONStream"] = &orderedListContainer{}
+			err = UnmarshalJSONStream(orderedListSchema(), parents["UnmarshalJSONStream"], strings.NewReader(tt.json))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalJSONStream: did not get expected error, %s", diff)
+			}
+			if tt.wantErrSubstring != "" {
+				return
+			}
+
+			for name, p := range parents {
+				if diff := cmp.Diff(tt.wantKeys, p.KeyList.Keys()); diff != "" {
+					t.Errorf("%s: did not get entries in order, diff(-want, +got):\n%s", name, diff)
+				}
+				for _, v := range p.KeyList.Values() {
+					if v.Key == nil || p.KeyList.valueMap[*v.Key] != v {
+						t.Errorf("%s: entry %v is not stored with its key", name, v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestValidateOrderedList(t *testing.T) {
+	schema := orderedListSchema().Dir["key-list"]
+	entries := func(keys ...string) *orderedListMap {
+		o := &orderedListMap{}
+		for _, k := range keys {
+			if err := o.Append(&orderedListElem{Key: ygot.String(k)}); err != nil {
+				t.Fatalf("cannot append %s, %v", k, err)
+			}
+		}
+		return o
+	}
+
+	tests := []struct {
+		desc             string
+		val              interface{}
+		wantErrSubstring string
+	}{{
+		desc: "valid ordered list",
+		val:  entries("b", "a"),
+	}, {
+		desc:             "too many entries",
+		val:              entries("d", "c", "b", "a"),
+		wantErrSubstring: "list key-list contains more than max allowed elements: 4 > 3",
+	}, {
+		desc: "mismatched key",
+		val: func() *orderedListMap {
+			o := entries("a")
+			o.valueMap["a"].Key = ygot.String("b")
+			return o
+		}(),
+		wantErrSubstring: "element key b != map key a",
+	}, {
+		desc: "nil ordered list",
+		val:  (*orderedListMap)(nil),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var err error
+			if errs := Validate(schema, tt.val); errs != nil {
+				err = errs
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Errorf("Validate: did not get expected error, %s", diff)
+			}
+		})
+	}
+}
+
 type KeyStructMapCreation struct {
 	Key1           string              `path:"key1"`
 	Key2           int32               `path:"key2"`
@@ -0,0 +1,141 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// annotationDataTreePaths returns the data tree paths of the JSON members that
+// hold the RFC7952 metadata stored in the annotation field f of a struct
+// described by schema. Choice and case elements are removed from the paths of
+// the annotation field in the same way as for data fields.
+func annotationDataTreePaths(schema *yang.Entry, f reflect.StructField) ([][]string, error) {
+	paths, err := util.SchemaPaths(f)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find JSON field names for annotation field %s, %v", f.Name, err)
+	}
+
+	var out [][]string
+	for _, p := range paths {
+		if len(p) == 0 {
+			continue
+		}
+		last := p[len(p)-1]
+		if len(p) == 1 || !strings.HasPrefix(last, "@") {
+			out = append(out, p)
+			continue
+		}
+		// The annotated node, named by the last element without its "@"
+		// prefix, is used to determine the non-data elements of the path.
+		dp := append(append([]string{}, p[:len(p)-1]...), strings.TrimPrefix(last, "@"))
+		n, err := removeNonDataPathElements(schema, schema, [][]string{dp})
+		if err != nil {
+			return nil, err
+		}
+		np := n[0]
+		np[len(np)-1] = last
+		out = append(out, np)
+	}
+	return out, nil
+}
+
+// unmarshalMetadata unmarshals the RFC7952 metadata object jsonTree into the
+// annotation field f of a struct described by schema. The metadata is stored
+// as a *ygot.Metadata, replacing any existing Metadata in the field. Values
+// that are not JSON objects, such as the arrays produced by marshalling other
+// Annotation types, and fields that are not of type []ygot.Annotation are
+// ignored.
+//
+// When the schema defines metadata annotations, each annotation name must be
+// defined, unless the IgnoreExtraFields option is supplied in which case
+// undefined annotations are skipped, and each value must be a valid RFC7951
+// encoding of the type of the annotation.
+func unmarshalMetadata(schema *yang.Entry, f reflect.Value, jsonTree interface{}, opts ...UnmarshalOpt) error {
+	jt, ok := jsonTree.(map[string]interface{})
+	if !ok || f.Type() != reflect.TypeOf([]ygot.Annotation{}) {
+		util.DbgPrint("ignoring annotation value %v (%T) for field of type %v", jsonTree, jsonTree, f.Type())
+		return nil
+	}
+
+	known := util.MetadataAnnotations(schema)
+	var names []string
+	for n := range jt {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	md := ygot.NewMetadata()
+	for _, n := range names {
+		if len(known) != 0 {
+			t, ok := known[n]
+			if !ok {
+				if hasIgnoreExtraFields(opts) {
+					continue
+				}
+				return fmt.Errorf("metadata annotation %s is not defined in the schema", n)
+			}
+			if err := validateMetadataValue(t, jt[n]); err != nil {
+				return fmt.Errorf("metadata annotation %s: %v", n, err)
+			}
+		}
+		if err := md.Set(n, jt[n]); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < f.Len(); i++ {
+		if _, ok := f.Index(i).Interface().(*ygot.Metadata); ok {
+			f.Index(i).Set(reflect.ValueOf(md))
+			return nil
+		}
+	}
+	f.Set(reflect.Append(f, reflect.ValueOf(md)))
+	return nil
+}
+
+// validateMetadataValue returns an error if v is not the RFC7951 JSON encoding
+// of a value of the YANG built-in type with the name typeName.
+func validateMetadataValue(typeName string, v interface{}) error {
+	var ok bool
+	switch yang.TypeKindFromName[typeName] {
+	case yang.Ynone:
+		// The type of the annotation is not known, hence any value is
+		// accepted.
+		return nil
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		_, ok = v.(float64)
+	case yang.Ybool:
+		_, ok = v.(bool)
+	case yang.Yempty:
+		l, isList := v.([]interface{})
+		ok = isList && len(l) == 1 && l[0] == nil
+	default:
+		// All other types, including 64-bit integers and decimal64, are
+		// encoded as JSON strings.
+		_, ok = v.(string)
+	}
+	if !ok {
+		return fmt.Errorf("invalid value %v (%T) for type %s", v, v, typeName)
+	}
+	return nil
+}
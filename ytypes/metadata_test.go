@@ -0,0 +1,216 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+type mdRoot struct {
+	Intf *mdIntf `path:"intf"`
+}
+
+func (*mdRoot) IsYANGGoStruct() {}
+
+type mdIntf struct {
+	ΛMetadata    []ygot.Annotation `path:"@" ygotAnnotation:"true"`
+	Name         *string           `path:"config/name"`
+	ΛName        []ygot.Annotation `path:"config/@name" ygotAnnotation:"true"`
+	Description  *string           `path:"description"`
+	ΛDescription []ygot.Annotation `path:"@description" ygotAnnotation:"true"`
+}
+
+func (*mdIntf) IsYANGGoStruct() {}
+
+// mdSchema returns the schema of the mdRoot struct, in which the metadata
+// annotations m:origin, m:count and m:flag are defined.
+func mdSchema() *yang.Entry {
+	leaf := func(name string) *yang.Entry {
+		return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}}
+	}
+	schema := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Annotation: map[string]interface{}{
+			util.MetadataAnnotationsAnnotation: map[string]string{
+				"m:origin": "identityref",
+				"m:count":  "uint8",
+				"m:flag":   "empty",
+			},
+		},
+		Dir: map[string]*yang.Entry{
+			"intf": {
+				Name: "intf",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"config": {
+						Name: "config",
+						Kind: yang.DirectoryEntry,
+						Dir:  map[string]*yang.Entry{"name": leaf("name")},
+					},
+					"description": leaf("description"),
+				},
+			},
+		},
+	}
+	addParents(schema)
+	return schema
+}
+
+func TestUnmarshalMetadata(t *testing.T) {
+	schema := mdSchema()
+
+	md := func(vals map[string]interface{}) []ygot.Annotation {
+		return []ygot.Annotation{&ygot.Metadata{Values: vals}}
+	}
+
+	tests := []struct {
+		desc             string
+		inSchema         *yang.Entry
+		inParent         ygot.GoStruct
+		inJSON           string
+		inOpts           []UnmarshalOpt
+		want             ygot.GoStruct
+		wantErrSubstring string
+	}{{
+		desc:     "metadata of container and leaves",
+		inSchema: schema,
+		inParent: &mdRoot{},
+		inJSON: `{"intf": {
+			"@": {"m:origin": "m:intended", "m:count": 2},
+			"config": {"name": "eth0", "@name": {"m:flag": [null]}},
+			"description": "uplink",
+			"@m:description": {"m:origin": "m:learned"}
+		}}`,
+		want: &mdRoot{Intf: &mdIntf{
+			ΛMetadata:    md(map[string]interface{}{"m:origin": "m:intended", "m:count": float64(2)}),
+			Name:         ygot.String("eth0"),
+			ΛName:        md(map[string]interface{}{"m:flag": []interface{}{nil}}),
+			Description:  ygot.String("uplink"),
+			ΛDescription: md(map[string]interface{}{"m:origin": "m:learned"}),
+		}},
+	}, {
+		desc:     "existing metadata is replaced",
+		inSchema: schema.Dir["intf"],
+		inParent: &mdIntf{ΛMetadata: []ygot.Annotation{&ygot.Metadata{Values: map[string]interface{}{"m:count": float64(1)}}}},
+		inJSON:   `{"@": {"m:count": 3}}`,
+		want:     &mdIntf{ΛMetadata: md(map[string]interface{}{"m:count": float64(3)})},
+	}, {
+		desc:             "undefined annotation",
+		inSchema:         schema.Dir["intf"],
+		inParent:         &mdIntf{},
+		inJSON:           `{"@": {"m:unknown": "x"}}`,
+		wantErrSubstring: "metadata annotation m:unknown is not defined in the schema",
+	}, {
+		desc:     "undefined annotation with ignore",
+		inSchema: schema.Dir["intf"],
+		inParent: &mdIntf{},
+		inJSON:   `{"@": {"m:unknown": "x", "m:count": 1}}`,
+		inOpts:   []UnmarshalOpt{&IgnoreExtraFields{}},
+		want:     &mdIntf{ΛMetadata: md(map[string]interface{}{"m:count": float64(1)})},
+	}, {
+		desc:             "invalid value for type",
+		inSchema:         schema.Dir["intf"],
+		inParent:         &mdIntf{},
+		inJSON:           `{"@": {"m:count": "one"}}`,
+		wantErrSubstring: "metadata annotation m:count: invalid value one (string) for type uint8",
+	}, {
+		desc:             "invalid empty value",
+		inSchema:         schema.Dir["intf"],
+		inParent:         &mdIntf{},
+		inJSON:           `{"@description": {"m:flag": true}}`,
+		wantErrSubstring: "invalid value true (bool) for type empty",
+	}, {
+		desc:     "legacy annotation array is ignored",
+		inSchema: schema.Dir["intf"],
+		inParent: &mdIntf{},
+		inJSON:   `{"@": [{"m:count": 1}]}`,
+		want:     &mdIntf{},
+	}, {
+		desc:             "annotation member for unknown leaf",
+		inSchema:         schema.Dir["intf"],
+		inParent:         &mdIntf{},
+		inJSON:           `{"@mtu": {"m:count": 1}}`,
+		wantErrSubstring: "JSON contains unexpected field @mtu",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var jt interface{}
+			if err := json.Unmarshal([]byte(tt.inJSON), &jt); err != nil {
+				t.Fatalf("json.Unmarshal(%s): got unexpected error, %v", tt.inJSON, err)
+			}
+			err := Unmarshal(tt.inSchema, tt.inParent, jt, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Unmarshal: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.inParent); diff != "" {
+				t.Errorf("Unmarshal: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalMetadataRoundTrip(t *testing.T) {
+	schema := mdSchema()
+	in := &mdRoot{Intf: &mdIntf{Name: ygot.String("eth0"), Description: ygot.String("uplink")}}
+	if err := ygot.SetMetadata(in.Intf, "", "m:origin", "m:intended"); err != nil {
+		t.Fatalf("SetMetadata: got unexpected error, %v", err)
+	}
+	if err := ygot.SetMetadata(in.Intf, "Name", "m:count", float64(7)); err != nil {
+		t.Fatalf("SetMetadata: got unexpected error, %v", err)
+	}
+
+	m, err := ygot.ConstructIETFJSON(in, nil)
+	if err != nil {
+		t.Fatalf("ConstructIETFJSON: got unexpected error, %v", err)
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal: got unexpected error, %v", err)
+	}
+	js := string(b)
+
+	got := &mdRoot{}
+	var jt interface{}
+	if err := json.Unmarshal(b, &jt); err != nil {
+		t.Fatalf("json.Unmarshal(%s): got unexpected error, %v", js, err)
+	}
+	if err := Unmarshal(schema, got, jt); err != nil {
+		t.Fatalf("Unmarshal(%s): got unexpected error, %v", js, err)
+	}
+	if diff := cmp.Diff(in, got); diff != "" {
+		t.Errorf("Unmarshal(%s): did not get expected GoStruct, diff(-want, +got):\n%s", js, diff)
+	}
+
+	streamed := &mdRoot{}
+	if err := UnmarshalJSONStream(schema, streamed, bytes.NewReader(b)); err != nil {
+		t.Fatalf("UnmarshalJSONStream(%s): got unexpected error, %v", js, err)
+	}
+	if diff := cmp.Diff(in, streamed); diff != "" {
+		t.Errorf("UnmarshalJSONStream(%s): did not get expected GoStruct, diff(-want, +got):\n%s", js, diff)
+	}
+}
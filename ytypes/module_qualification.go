@@ -0,0 +1,239 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// Refer to: https://tools.ietf.org/html/rfc7951#section-4 and
+// https://tools.ietf.org/html/rfc7951#section-6.8.
+
+// StrictModuleQualification is an unmarshal option that controls whether the
+// module names of the JSON member names, and of identityref values, are
+// checked when unmarshalling RFC7951 JSON. By default, module names are
+// removed from member names and values without being checked. When
+// StrictModuleQualification is specified:
+//   - a member name must be qualified with the name of the module that defines
+//     the data node where RFC7951 requires it, i.e., for each member of the
+//     top-level object, and where the module of the data node differs from the
+//     module of its parent.
+//   - a qualified member name must be qualified with the module that defines
+//     the data node, such that a node defined by a different module with the
+//     same local name is rejected.
+//   - an identityref value must be qualified with the module that defines the
+//     identity where it is defined in a different module to the leaf, and a
+//     qualified value must specify the defining module.
+//
+// The module of a data node is determined from the module tag of the GoStruct
+// field that it is unmarshalled into, such that nodes whose fields do not have
+// a module tag are not checked. Where Unmarshal is called with a schema that
+// is not the root of the schema tree, and the module of the schema cannot be
+// determined, the members of the top-level object may be unqualified.
+type StrictModuleQualification struct{}
+
+// IsUnmarshalOpt marks StrictModuleQualification as a valid UnmarshalOpt.
+func (*StrictModuleQualification) IsUnmarshalOpt() {}
+
+// hasStrictModuleQualification determines whether the supplied slice of
+// UnmarshalOpts contains the StrictModuleQualification option.
+func hasStrictModuleQualification(opts []UnmarshalOpt) bool {
+	for _, o := range opts {
+		if _, ok := o.(*StrictModuleQualification); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parentModule is an internal unmarshal option that records the module of the
+// data node whose JSON object is being unmarshalled, such that the
+// qualification of the names of its members can be checked.
+type parentModule struct {
+	// name is the name of the module, which is empty if the module is not
+	// known.
+	name string
+}
+
+// IsUnmarshalOpt marks parentModule as a valid UnmarshalOpt.
+func (*parentModule) IsUnmarshalOpt() {}
+
+// withParentModule returns a copy of opts in which the parent module is set to
+// mod.
+func withParentModule(opts []UnmarshalOpt, mod string) []UnmarshalOpt {
+	out := make([]UnmarshalOpt, 0, len(opts)+1)
+	for _, o := range opts {
+		if _, ok := o.(*parentModule); !ok {
+			out = append(out, o)
+		}
+	}
+	return append(out, &parentModule{name: mod})
+}
+
+// moduleOfParent returns the module of the data node with the supplied schema,
+// whose JSON object is being unmarshalled, and whether it is known. The
+// module is empty, and known, for the root of the schema tree, since all of
+// its members must be qualified.
+func moduleOfParent(schema *yang.Entry, opts []UnmarshalOpt) (string, bool) {
+	for _, o := range opts {
+		if pm, ok := o.(*parentModule); ok {
+			return pm.name, pm.name != ""
+		}
+	}
+	switch {
+	case util.IsFakeRoot(schema) || schema.Parent == nil:
+		return "", true
+	case schema.Node != nil:
+		if m, err := schema.InstantiatingModule(); err == nil {
+			return m, true
+		}
+	}
+	return "", false
+}
+
+// checkMemberModules checks the qualification of the member names along the
+// data tree path p within jsonTree, at which the value of a GoStruct field
+// that is defined in module fieldMod was found. The object jsonTree belongs to
+// a data node in module parentMod, which is only used if parentKnown is true.
+// The modules map contains the names of the modules of the schema tree, and
+// is used to determine whether a module name is unknown.
+func checkMemberModules(jsonTree map[string]interface{}, p []string, fieldMod, parentMod string, parentKnown bool, modules map[string]string) error {
+	if fieldMod == "" {
+		return nil
+	}
+	t := jsonTree
+	for i, pe := range p {
+		k, ok := memberName(t, pe)
+		if !ok {
+			return nil
+		}
+		last := i == len(p)-1
+
+		mod := parentMod
+		if m, _ := splitModulePrefix(k); m != "" {
+			switch {
+			case modules != nil && modules[m] == "":
+				return fmt.Errorf("JSON member %s: unknown module %s, expected module %s", k, m, fieldMod)
+			case m != fieldMod && (last || (parentKnown && m != parentMod)):
+				// The module of an element that is compressed out of the
+				// GoStruct is not known, so it may be either the module
+				// of the parent or the module of the field.
+				return fmt.Errorf("JSON member %s: wrong module %s, expected module %s", k, m, fieldMod)
+			}
+			mod, parentKnown = m, true
+		}
+		// All members of the top-level object must be qualified, so the
+		// module of the parent is only empty for the root.
+		if parentKnown && (mod == "" || last && mod != fieldMod) {
+			return fmt.Errorf("JSON member %s: name must be qualified with module %s", k, fieldMod)
+		}
+		parentMod = mod
+
+		if !last {
+			if t, ok = t[k].(map[string]interface{}); !ok {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// memberName returns the name of the member of the JSON object t whose local
+// name is name, and whether one exists.
+func memberName(t map[string]interface{}, name string) (string, bool) {
+	var ks []string
+	for k := range t {
+		if util.StripModulePrefix(k) == name {
+			ks = append(ks, k)
+		}
+	}
+	if len(ks) == 0 {
+		return "", false
+	}
+	sort.Strings(ks)
+	return ks[0], true
+}
+
+// checkIdentityrefModules checks the qualification of the identityref values
+// within the JSON value jsonValue of the leaf or leaf-list with the supplied
+// schema, which is unmarshalled into a field of type ft defined within module
+// leafMod. Only leaves whose type is an identityref are checked.
+func checkIdentityrefModules(schema *yang.Entry, ft reflect.Type, jsonValue interface{}, leafMod string, modules map[string]string) error {
+	rs, err := util.ResolveIfLeafRef(schema)
+	if err != nil || rs.Type == nil || rs.Type.Kind != yang.Yidentityref {
+		return nil
+	}
+	if ft.Kind() == reflect.Slice {
+		ft = ft.Elem()
+	}
+	e, ok := reflect.Zero(ft).Interface().(ygot.GoEnum)
+	if !ok {
+		return nil
+	}
+	defs := e.ΛMap()[ft.Name()]
+
+	var vals []interface{}
+	switch v := jsonValue.(type) {
+	case []interface{}:
+		vals = v
+	default:
+		vals = []interface{}{v}
+	}
+	for _, v := range vals {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		m, name := splitModulePrefix(s)
+		var def *ygot.EnumDefinition
+		for _, d := range defs {
+			if util.StripModulePrefix(d.Name) == name {
+				d := d
+				def = &d
+				break
+			}
+		}
+		if def == nil || def.DefiningModule == "" {
+			// Values that are not valid are reported when they are
+			// unmarshalled.
+			continue
+		}
+		switch {
+		case m == "" && leafMod != "" && def.DefiningModule != leafMod:
+			return fmt.Errorf("identityref value %s of %s: value must be qualified with module %s", s, schema.Name, def.DefiningModule)
+		case m != "" && modules != nil && modules[m] == "":
+			return fmt.Errorf("identityref value %s of %s: unknown module %s, expected module %s", s, schema.Name, m, def.DefiningModule)
+		case m != "" && m != def.DefiningModule:
+			return fmt.Errorf("identityref value %s of %s: wrong module %s, expected module %s", s, schema.Name, m, def.DefiningModule)
+		}
+	}
+	return nil
+}
+
+// splitModulePrefix splits the RFC7951 JSON member name or identity n into its
+// module and local name. The module is empty if n is not qualified.
+func splitModulePrefix(n string) (string, string) {
+	if i := strings.Index(n, ":"); i != -1 {
+		return n[:i], n[i+1:]
+	}
+	return "", n
+}
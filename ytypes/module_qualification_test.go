@@ -0,0 +1,140 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestUnmarshalStrictModuleQualification(t *testing.T) {
+	strict := []UnmarshalOpt{&StrictModuleQualification{}}
+	want := &xmlRoot{
+		Interface: map[string]*xmlInterface{
+			"eth0": {
+				Name:        ygot.String("eth0"),
+				Type:        1,
+				Description: ygot.String("uplink"),
+			},
+		},
+		Enabled: true,
+	}
+
+	tests := []struct {
+		desc             string
+		inJSON           string
+		inOpts           []UnmarshalOpt
+		want             ygot.GoStruct
+		wantErrSubstring string
+	}{{
+		desc: "correctly qualified names",
+		inJSON: `{
+			"m1:interfaces": {"interface": [{"name": "eth0", "type": "m3:ETHERNET", "m2:description": "uplink"}]},
+			"m2:enabled": [null]
+		}`,
+		inOpts: strict,
+		want:   want,
+	}, {
+		desc: "redundant qualification",
+		inJSON: `{
+			"m1:interfaces": {"m1:interface": [{"m1:name": "eth0", "type": "m3:ETHERNET", "m2:description": "uplink"}]},
+			"m2:enabled": [null]
+		}`,
+		inOpts: strict,
+		want:   want,
+	}, {
+		desc:             "unqualified top-level member",
+		inJSON:           `{"interfaces": {"interface": [{"name": "eth0"}]}}`,
+		inOpts:           strict,
+		wantErrSubstring: "JSON member interfaces: name must be qualified with module m1",
+	}, {
+		desc:             "unqualified member at namespace change",
+		inJSON:           `{"m1:interfaces": {"interface": [{"name": "eth0", "description": "uplink"}]}}`,
+		inOpts:           strict,
+		wantErrSubstring: "JSON member description: name must be qualified with module m2",
+	}, {
+		desc:             "member from a different module with the same local name",
+		inJSON:           `{"m1:interfaces": {"interface": [{"name": "eth0", "m3:description": "uplink"}]}}`,
+		inOpts:           strict,
+		wantErrSubstring: "JSON member m3:description: wrong module m3, expected module m2",
+	}, {
+		desc:             "unknown module",
+		inJSON:           `{"vendor:enabled": [null]}`,
+		inOpts:           strict,
+		wantErrSubstring: "JSON member vendor:enabled: unknown module vendor, expected module m2",
+	}, {
+		desc:             "unqualified identityref from another module",
+		inJSON:           `{"m1:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET"}]}}`,
+		inOpts:           strict,
+		wantErrSubstring: "identityref value ETHERNET of type: value must be qualified with module m3",
+	}, {
+		desc:             "identityref with wrong module",
+		inJSON:           `{"m1:interfaces": {"interface": [{"name": "eth0", "type": "m2:ETHERNET"}]}}`,
+		inOpts:           strict,
+		wantErrSubstring: "identityref value m2:ETHERNET of type: wrong module m2, expected module m3",
+	}, {
+		desc: "names are not checked by default",
+		inJSON: `{
+			"interfaces": {"interface": [{"name": "eth0", "type": "m2:ETHERNET", "m1:description": "uplink"}]},
+			"vendor:enabled": [null]
+		}`,
+		want: want,
+	}, {
+		desc:   "errors are collected",
+		inJSON: `{"m1:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET", "description": "uplink"}]}, "vendor:enabled": [null]}`,
+		inOpts: []UnmarshalOpt{&StrictModuleQualification{}, &CollectAllErrors{}},
+		wantErrSubstring: strings.Join([]string{
+			"/interfaces/interface[name=eth0]/type: identityref value ETHERNET of type: value must be qualified with module m3",
+			"/interfaces/interface[name=eth0]/description: JSON member description: name must be qualified with module m2",
+			"/enabled: JSON member vendor:enabled: unknown module vendor, expected module m2",
+		}, ", "),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var jt interface{}
+			if err := json.Unmarshal([]byte(tt.inJSON), &jt); err != nil {
+				t.Fatalf("json.Unmarshal(%s): got unexpected error, %v", tt.inJSON, err)
+			}
+
+			got := &xmlRoot{}
+			err := Unmarshal(xmlSchema(), got, jt, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Unmarshal: did not get expected error, %s", diff)
+			}
+			if err == nil {
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("Unmarshal: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+				}
+			}
+
+			streamed := &xmlRoot{}
+			err = UnmarshalJSONStream(xmlSchema(), streamed, strings.NewReader(tt.inJSON), tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalJSONStream: did not get expected error, %s", diff)
+			}
+			if err == nil {
+				if diff := cmp.Diff(tt.want, streamed); diff != "" {
+					t.Errorf("UnmarshalJSONStream: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
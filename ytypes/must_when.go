@@ -0,0 +1,195 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// Refer to: https://tools.ietf.org/html/rfc7950#section-7.5.3 and
+// https://tools.ietf.org/html/rfc7950#section-7.21.5.
+
+// MustWhenOptions controls the behaviour of the validation of the YANG must
+// and when statements within a data tree, which Validate evaluates for the
+// GoStruct that it is supplied with.
+type MustWhenOptions struct {
+	// IgnoreMust determines whether must statements are evaluated. When
+	// set to true, no error is returned for must statements that are not
+	// satisfied.
+	IgnoreMust bool
+	// IgnoreWhen determines whether when statements are evaluated. When
+	// set to true, no error is returned for data nodes that exist when
+	// their when condition is false.
+	IgnoreWhen bool
+}
+
+// IsValidationOption ensures that MustWhenOptions implements the
+// ValidationOption interface.
+func (*MustWhenOptions) IsValidationOption() {}
+
+// xpathConstraintCache stores whether a schema tree, keyed by its root
+// *yang.Entry, contains any must or when statements.
+var xpathConstraintCache sync.Map
+
+// hasXPathConstraints reports whether the schema tree rooted at schema
+// contains any must or when statements.
+func hasXPathConstraints(schema *yang.Entry) bool {
+	if v, ok := xpathConstraintCache.Load(schema); ok {
+		return v.(bool)
+	}
+	var found bool
+	var walk func(e *yang.Entry)
+	walk = func(e *yang.Entry) {
+		if found {
+			return
+		}
+		if _, ok := util.WhenStatement(e); ok || len(util.MustStatements(e)) != 0 || len(util.InheritedWhenStatements(e)) != 0 {
+			found = true
+			return
+		}
+		for _, ch := range util.Children(e) {
+			walk(ch)
+		}
+	}
+	walk(schema)
+	xpathConstraintCache.Store(schema, found)
+	return found
+}
+
+// ValidateMustWhenData traverses the data tree with root value and the
+// corresponding schema, and evaluates the must and when statements of each
+// data node that exists in the tree. It returns an error for each must
+// statement that evaluates to false, and each existing data node whose when
+// statement evaluates to false, naming the schema path and the expression.
+// The when statements of a data node include those of the augment and uses
+// statements through which it was added to the schema tree. The supplied
+// MustWhenOptions specify whether must or when statements are ignored.
+//
+// The XPath expressions of the statements may refer to any node in the data
+// tree. Where value is not the root of the entire data tree, statements whose
+// evaluation selects nodes outside of the subtree rooted at value are skipped,
+// since their result cannot be determined. The id(), lang() and
+// namespace-uri() XPath functions are not supported, and an error is returned
+// for statements that use them.
+func ValidateMustWhenData(schema *yang.Entry, value interface{}, opt *MustWhenOptions) util.Errors {
+	if opt != nil && opt.IgnoreMust && opt.IgnoreWhen {
+		return nil
+	}
+	if util.IsValueNil(value) || !hasXPathConstraints(schema) {
+		return nil
+	}
+
+	partial := !util.IsFakeRoot(schema) && schema.Parent != nil
+	var root *xpathNode
+	var err error
+	if partial {
+		root, err = newXPathSubtree(schema, value)
+	} else {
+		root, err = newXPathTree(schema, value)
+	}
+	if err != nil {
+		return util.NewErrs(err)
+	}
+	x := newXPathEvaluator(root)
+	x.partial = partial
+
+	var errs util.Errors
+	// checkedChoices tracks the choice and case statements that have already
+	// been evaluated for a particular context node, since the when statement
+	// applies to all of their data node descendants.
+	checkedChoices := map[*xpathNode]map[*yang.Entry]bool{}
+	root.walk(func(n *xpathNode) {
+		if n.parent == nil {
+			return
+		}
+		if opt == nil || !opt.IgnoreMust {
+			errs = util.AppendErrs(errs, x.checkMust(n))
+		}
+		if opt != nil && opt.IgnoreWhen {
+			return
+		}
+		if w, ok := util.WhenStatement(n.schema); ok {
+			errs = util.AppendErr(errs, x.checkWhen(n, n.schema, w, n))
+		}
+		// The context node for the when statements of the augment and uses
+		// statements that added a node, and for the when statement of a
+		// choice or case, is the closest ancestor data node.
+		for _, w := range util.InheritedWhenStatements(n.schema) {
+			errs = util.AppendErr(errs, x.checkWhen(n, n.schema, w, n.parent))
+		}
+		for s := n.schema.Parent; s != nil && util.IsChoiceOrCase(s); s = s.Parent {
+			ws := util.InheritedWhenStatements(s)
+			if w, ok := util.WhenStatement(s); ok {
+				ws = append([]string{w}, ws...)
+			}
+			if len(ws) == 0 || checkedChoices[n.parent][s] {
+				continue
+			}
+			if checkedChoices[n.parent] == nil {
+				checkedChoices[n.parent] = map[*yang.Entry]bool{}
+			}
+			checkedChoices[n.parent][s] = true
+			for _, w := range ws {
+				errs = util.AppendErr(errs, x.checkWhen(n, s, w, n.parent))
+			}
+		}
+	})
+	return errs
+}
+
+// checkMust evaluates the must statements of the schema of node n, with n as
+// the context node, returning an error for each statement that is not
+// satisfied.
+func (x *xpathEvaluator) checkMust(n *xpathNode) util.Errors {
+	var errs util.Errors
+	x.define(n.schema, n)
+	for _, m := range util.MustStatements(n.schema) {
+		x.outside = false
+		ok, err := x.evalBool(m.XPath, n)
+		switch {
+		case err != nil:
+			errs = util.AppendErr(errs, fmt.Errorf("schema path %s: cannot evaluate must statement %q: %v", n.schema.Path(), m.XPath, err))
+		case x.outside:
+			// The result depends on nodes outside of the data tree.
+		case !ok && m.ErrorMessage != "":
+			errs = util.AppendErr(errs, fmt.Errorf("schema path %s, data path %s: must statement %q is not satisfied: %s", n.schema.Path(), n.dataPath(), m.XPath, m.ErrorMessage))
+		case !ok:
+			errs = util.AppendErr(errs, fmt.Errorf("schema path %s, data path %s: must statement %q is not satisfied", n.schema.Path(), n.dataPath(), m.XPath))
+		}
+	}
+	return errs
+}
+
+// checkWhen evaluates the when statement w defined on the schema s, with the
+// context node ctx. It returns an error if the statement evaluates to false,
+// since this means that the data node n is not permitted to exist.
+func (x *xpathEvaluator) checkWhen(n *xpathNode, s *yang.Entry, w string, ctx *xpathNode) error {
+	x.define(s, n)
+	x.outside = false
+	ok, err := x.evalBool(w, ctx)
+	switch {
+	case err != nil:
+		return fmt.Errorf("schema path %s: cannot evaluate when statement %q: %v", s.Path(), w, err)
+	case x.outside:
+		// The result depends on nodes outside of the data tree.
+	case !ok:
+		return fmt.Errorf("schema path %s, data path %s: data node exists but when statement %q is false", s.Path(), n.dataPath(), w)
+	}
+	return nil
+}
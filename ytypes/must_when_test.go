@@ -0,0 +1,424 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestValidateMustWhenData(t *testing.T) {
+	// mustWhenSchema returns the xpathTestSchema with the supplied must and
+	// when annotations added at the specified schema paths, which are
+	// relative to the root.
+	mustWhenSchema := func(must map[string][]*util.MustStatement, when map[string]string) *yang.Entry {
+		s := xpathTestSchema()
+		find := func(p string) *yang.Entry {
+			e := s
+			for _, n := range util.SplitPath(p) {
+				e = e.Dir[n]
+			}
+			if e.Annotation == nil {
+				e.Annotation = map[string]interface{}{}
+			}
+			return e
+		}
+		for p, m := range must {
+			find(p).Annotation[util.MustAnnotation] = m
+		}
+		for p, w := range when {
+			find(p).Annotation[util.WhenAnnotation] = w
+		}
+		return s
+	}
+
+	tests := []struct {
+		desc     string
+		must     map[string][]*util.MustStatement
+		when     map[string]string
+		inData   func(*xpathTestRoot)
+		inOpts   []ygot.ValidationOption
+		wantErrs []string
+	}{{
+		desc: "no constraints",
+	}, {
+		desc: "satisfied must on leaf",
+		must: map[string][]*util.MustStatement{
+			"interfaces/interface/config/mtu": {{XPath: ". >= 64 and . <= 9216"}},
+		},
+	}, {
+		desc: "unsatisfied must on leaf",
+		must: map[string][]*util.MustStatement{
+			"interfaces/interface/config/mtu": {{XPath: ". <= 1500"}},
+		},
+		wantErrs: []string{
+			`schema path /device/interfaces/interface/config/mtu, data path /interfaces/interface[name=eth1]/config/mtu: must statement ". <= 1500" is not satisfied`,
+		},
+	}, {
+		desc: "unsatisfied must on list with error-message",
+		must: map[string][]*util.MustStatement{
+			"interfaces/interface": {{
+				XPath:        "config/mtu or derived-from-or-self(config/type, 'if:loopback')",
+				ErrorMessage: "non-loopback interfaces must have an MTU",
+			}},
+		},
+		inData: func(d *xpathTestRoot) {
+			d.Interface["eth1"].Mtu = nil
+		},
+		wantErrs: []string{
+			`schema path /device/interfaces/interface, data path /interfaces/interface[name=eth1]: must statement "config/mtu or derived-from-or-self(config/type, 'if:loopback')" is not satisfied: non-loopback interfaces must have an MTU`,
+		},
+	}, {
+		desc: "must on leaf-list evaluated per entry",
+		must: map[string][]*util.MustStatement{
+			"system/server": {{XPath: "starts-with(., 'a')"}},
+		},
+		wantErrs: []string{
+			`schema path /device/system/server, data path /system/server: must statement "starts-with(., 'a')" is not satisfied`,
+		},
+	}, {
+		desc: "must referring to another subtree using deref",
+		must: map[string][]*util.MustStatement{
+			"ref": {{XPath: "deref(.)/../enabled = 'true'", ErrorMessage: "referenced interface must be enabled"}},
+		},
+		wantErrs: []string{
+			`schema path /device/ref, data path /ref: must statement "deref(.)/../enabled = 'true'" is not satisfied: referenced interface must be enabled`,
+		},
+	}, {
+		desc: "must on unset leaf is not evaluated",
+		must: map[string][]*util.MustStatement{
+			"interfaces/interface/config/speed": {{XPath: "false()"}},
+		},
+		inData: func(d *xpathTestRoot) {
+			d.Interface["eth0"].Speed = nil
+		},
+	}, {
+		desc: "must that cannot be evaluated",
+		must: map[string][]*util.MustStatement{
+			"system/hostname": {{XPath: "unknown-fn(.)"}},
+		},
+		wantErrs: []string{
+			`schema path /device/system/hostname: cannot evaluate must statement "unknown-fn(.)": unknown function unknown-fn()`,
+		},
+	}, {
+		desc: "satisfied when",
+		when: map[string]string{
+			"interfaces/interface/config/mtu": "../enabled = 'true' or ../enabled = 'false'",
+		},
+	}, {
+		desc: "existing node with false when",
+		when: map[string]string{
+			"interfaces/interface/config/speed": "../type = 'ethernet'",
+		},
+		wantErrs: []string{
+			`schema path /device/interfaces/interface/config/speed, data path /interfaces/interface[name=eth0]/config/speed: data node exists but when statement "../type = 'ethernet'" is false`,
+		},
+	}, {
+		desc: "false when on case",
+		when: map[string]string{
+			"system/choice/case-a": "mode = 'standalone'",
+		},
+		wantErrs: []string{
+			`schema path /device/system/choice/case-a, data path /system/foo: data node exists but when statement "mode = 'standalone'" is false`,
+		},
+	}, {
+		desc: "true when on choice",
+		when: map[string]string{
+			"system/choice": "mode = 'clustered'",
+		},
+	}, {
+		desc: "must and when ignored by option",
+		must: map[string][]*util.MustStatement{
+			"system/hostname": {{XPath: "false()"}},
+		},
+		when: map[string]string{
+			"system/mode": "false()",
+		},
+		inOpts: []ygot.ValidationOption{&MustWhenOptions{IgnoreMust: true, IgnoreWhen: true}},
+	}, {
+		desc: "only when ignored by option",
+		must: map[string][]*util.MustStatement{
+			"system/hostname": {{XPath: "false()"}},
+		},
+		when: map[string]string{
+			"system/mode": "false()",
+		},
+		inOpts: []ygot.ValidationOption{&MustWhenOptions{IgnoreWhen: true}},
+		wantErrs: []string{
+			`schema path /device/system/hostname, data path /system/hostname: must statement "false()" is not satisfied`,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			schema := mustWhenSchema(tt.must, tt.when)
+			data := xpathTestData()
+			if tt.inData != nil {
+				tt.inData(data)
+			}
+
+			var opt *MustWhenOptions
+			for _, o := range tt.inOpts {
+				opt = o.(*MustWhenOptions)
+			}
+			var got []string
+			for _, err := range ValidateMustWhenData(schema, data, opt) {
+				got = append(got, err.Error())
+			}
+			sort.Strings(got)
+			if diff := cmp.Diff(tt.wantErrs, got); diff != "" {
+				t.Errorf("ValidateMustWhenData: did not get expected errors, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateMustWhenJSONAnnotation(t *testing.T) {
+	// Annotations that have been unmarshalled from a JSON schema.
+	schema := xpathTestSchema()
+	schema.Dir["system"].Annotation = map[string]interface{}{
+		util.MustAnnotation: []interface{}{
+			map[string]interface{}{"xpath": "hostname = 'router'", "error-message": "hostname must be router"},
+		},
+	}
+	errs := ValidateMustWhenData(schema, xpathTestData(), nil)
+	want := `schema path /device/system, data path /system: must statement "hostname = 'router'" is not satisfied: hostname must be router`
+	if len(errs) != 1 || errs[0].Error() != want {
+		t.Errorf("ValidateMustWhenData: did not get expected errors, got: %v, want: %s", errs, want)
+	}
+
+	// Validate evaluates the statement alongside the other validation of
+	// the data tree.
+	var found bool
+	for _, err := range Validate(schema, xpathTestData(), &LeafrefOptions{IgnoreMissingData: true}) {
+		found = found || err.Error() == want
+	}
+	if !found {
+		t.Errorf("Validate: did not get expected error %s", want)
+	}
+	if errs := Validate(schema, xpathTestData(), &LeafrefOptions{IgnoreMissingData: true}, &MustWhenOptions{IgnoreMust: true}); errs != nil {
+		for _, err := range errs {
+			if err.Error() == want {
+				t.Errorf("Validate with IgnoreMust: got unexpected error %s", want)
+			}
+		}
+	}
+}
+
+func TestValidateMustWhenNode(t *testing.T) {
+	// Statements that are read from the YANG node of the entry.
+	schema := xpathTestSchema()
+	schema.Dir["system"].Dir["hostname"].Node = &yang.Leaf{
+		Name: "hostname",
+		Must: []*yang.Must{{Name: "string-length(.) < 5"}},
+	}
+	errs := ValidateMustWhenData(schema, xpathTestData(), nil)
+	want := `schema path /device/system/hostname, data path /system/hostname: must statement "string-length(.) < 5" is not satisfied`
+	if len(errs) != 1 || errs[0].Error() != want {
+		t.Errorf("ValidateMustWhenData: did not get expected errors, got: %v, want: %s", errs, want)
+	}
+}
+
+func TestValidateMustWhenAugment(t *testing.T) {
+	// augmentedSchema returns the xpathTestSchema in which the hostname leaf
+	// is added to the system container by an augment with the supplied when
+	// statement.
+	augmentedSchema := func(when string) *yang.Entry {
+		schema := xpathTestSchema()
+		system := schema.Dir["system"]
+		system.Augmented = []*yang.Entry{{
+			Name: "/system",
+			Node: &yang.Augment{Name: "/system", When: &yang.Value{Name: when}},
+			Dir:  map[string]*yang.Entry{"hostname": system.Dir["hostname"]},
+		}}
+		return schema
+	}
+
+	tests := []struct {
+		desc     string
+		inSchema *yang.Entry
+		wantErrs []string
+	}{{
+		desc:     "true when on augment",
+		inSchema: augmentedSchema("mode = 'clustered'"),
+	}, {
+		desc:     "false when on augment",
+		inSchema: augmentedSchema("mode = 'standalone'"),
+		wantErrs: []string{
+			`schema path /device/system/hostname, data path /system/hostname: data node exists but when statement "mode = 'standalone'" is false`,
+		},
+	}, {
+		desc: "false when on uses from annotation",
+		inSchema: func() *yang.Entry {
+			schema := xpathTestSchema()
+			schema.Dir["system"].Dir["choice"].Dir["case-a"].Annotation = map[string]interface{}{
+				util.InheritedWhenAnnotation: []interface{}{"hostname = 'router'"},
+			}
+			return schema
+		}(),
+		wantErrs: []string{
+			`schema path /device/system/choice/case-a, data path /system/foo: data node exists but when statement "hostname = 'router'" is false`,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []string
+			for _, err := range ValidateMustWhenData(tt.inSchema, xpathTestData(), nil) {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(tt.wantErrs, got); diff != "" {
+				t.Errorf("ValidateMustWhenData: did not get expected errors, (-want, +got):\n%s", diff)
+			}
+
+			// The test data is not otherwise valid for Validate, hence
+			// only the when errors are compared.
+			var validateErrs []string
+			for _, err := range Validate(tt.inSchema, xpathTestData()) {
+				if strings.Contains(err.Error(), "when statement") {
+					validateErrs = append(validateErrs, err.Error())
+				}
+			}
+			if diff := cmp.Diff(tt.wantErrs, validateErrs); diff != "" {
+				t.Errorf("Validate: did not get expected errors, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateMustWhenSubtree(t *testing.T) {
+	tests := []struct {
+		desc     string
+		must     map[string][]*util.MustStatement
+		when     map[string]string
+		inPath   string
+		inValue  func(*xpathTestRoot) interface{}
+		wantErrs []string
+	}{{
+		desc:    "unsatisfied must within subtree",
+		must:    map[string][]*util.MustStatement{"system/hostname": {{XPath: "../mode = 'standalone'"}}},
+		inPath:  "system",
+		inValue: func(d *xpathTestRoot) interface{} { return d.System },
+		wantErrs: []string{
+			`schema path /device/system/hostname, data path /system/hostname: must statement "../mode = 'standalone'" is not satisfied`,
+		},
+	}, {
+		desc:    "false when within subtree",
+		when:    map[string]string{"system/choice/case-a/foo": "../mode = 'standalone'"},
+		inPath:  "system",
+		inValue: func(d *xpathTestRoot) interface{} { return d.System },
+		wantErrs: []string{
+			`schema path /device/system/choice/case-a/foo, data path /system/foo: data node exists but when statement "../mode = 'standalone'" is false`,
+		},
+	}, {
+		desc:    "must with absolute path is skipped",
+		must:    map[string][]*util.MustStatement{"system/hostname": {{XPath: "/ref = 'eth0'"}}},
+		inPath:  "system",
+		inValue: func(d *xpathTestRoot) interface{} { return d.System },
+	}, {
+		desc:    "must referring to node outside of subtree is skipped",
+		must:    map[string][]*util.MustStatement{"system/hostname": {{XPath: "../../ref = 'eth0'"}}},
+		inPath:  "system",
+		inValue: func(d *xpathTestRoot) interface{} { return d.System },
+	}, {
+		desc:    "when on case with context outside of subtree is skipped",
+		when:    map[string]string{"system/choice/case-a": "false()"},
+		inPath:  "system/choice/case-a/foo",
+		inValue: func(d *xpathTestRoot) interface{} { return d.System.Foo },
+	}, {
+		desc:    "unsatisfied must on list entry",
+		must:    map[string][]*util.MustStatement{"interfaces/interface": {{XPath: "config/mtu <= 1500"}}},
+		inPath:  "interfaces/interface",
+		inValue: func(d *xpathTestRoot) interface{} { return d.Interface["eth1"] },
+		wantErrs: []string{
+			`schema path /device/interfaces/interface, data path /interface[name=eth1]: must statement "config/mtu <= 1500" is not satisfied`,
+		},
+	}, {
+		desc:    "must referring to siblings of list entry is skipped",
+		must:    map[string][]*util.MustStatement{"interfaces/interface": {{XPath: "count(following-sibling::interface) = 0"}}},
+		inPath:  "interfaces/interface",
+		inValue: func(d *xpathTestRoot) interface{} { return d.Interface["eth1"] },
+	}, {
+		desc:    "unsatisfied must on entries of list",
+		must:    map[string][]*util.MustStatement{"interfaces/interface/config/mtu": {{XPath: ". <= 1500"}}},
+		inPath:  "interfaces/interface",
+		inValue: func(d *xpathTestRoot) interface{} { return d.Interface },
+		wantErrs: []string{
+			`schema path /device/interfaces/interface/config/mtu, data path /interface[name=eth1]/config/mtu: must statement ". <= 1500" is not satisfied`,
+		},
+	}, {
+		desc:    "unsupported function",
+		must:    map[string][]*util.MustStatement{"system/hostname": {{XPath: "lang('en')"}}},
+		inPath:  "system",
+		inValue: func(d *xpathTestRoot) interface{} { return d.System },
+		wantErrs: []string{
+			`schema path /device/system/hostname: cannot evaluate must statement "lang('en')": function lang() is not supported`,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root := xpathTestSchema()
+			find := func(p string) *yang.Entry {
+				e := root
+				for _, n := range util.SplitPath(p) {
+					e = e.Dir[n]
+				}
+				if e.Annotation == nil {
+					e.Annotation = map[string]interface{}{}
+				}
+				return e
+			}
+			for p, m := range tt.must {
+				find(p).Annotation[util.MustAnnotation] = m
+			}
+			for p, w := range tt.when {
+				find(p).Annotation[util.WhenAnnotation] = w
+			}
+			schema := find(tt.inPath)
+			value := tt.inValue(xpathTestData())
+
+			var got []string
+			for _, err := range ValidateMustWhenData(schema, value, nil) {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(tt.wantErrs, got); diff != "" {
+				t.Errorf("ValidateMustWhenData: did not get expected errors, (-want, +got):\n%s", diff)
+			}
+
+			// Validate evaluates the statements of the subtree that it is
+			// supplied with. Only the must and when errors are compared,
+			// since the test data is not otherwise valid.
+			if _, ok := value.(ygot.GoStruct); !ok {
+				return
+			}
+			var validateErrs []string
+			for _, err := range Validate(schema, value) {
+				if strings.Contains(err.Error(), " statement ") {
+					validateErrs = append(validateErrs, err.Error())
+				}
+			}
+			if diff := cmp.Diff(tt.wantErrs, validateErrs); diff != "" {
+				t.Errorf("Validate: did not get expected errors, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -15,7 +15,10 @@
 package ytypes
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
@@ -53,6 +56,63 @@ type retrieveNodeArgs struct {
 	// specifically to deal with uint values being streamed as positive int
 	// values.
 	tolerateJSONInconsistenciesForVal bool
+	// If replace is set to true, the existing contents of a container or list
+	// node are cleared before a JSON val is unmarshalled into it.
+	replace bool
+	// If undo is non-nil, the changes that retrieveNode makes to the
+	// GoStruct are recorded within it, such that they can be reverted if
+	// the retrieval fails.
+	undo *undoLog
+}
+
+// undoLog records the changes made to a GoStruct by retrieveNode, as the
+// functions that revert them.
+type undoLog []func()
+
+// record appends the function f, which reverts a change, to the log. It is
+// a no-op on a nil log.
+func (u *undoLog) record(f func()) {
+	if u != nil {
+		*u = append(*u, f)
+	}
+}
+
+// snapshot records a deep copy of the GoStruct s, such that any changes to
+// it are reverted by restoring the copy. It is a no-op on a nil log.
+func (u *undoLog) snapshot(s interface{}) error {
+	gs, ok := s.(ygot.GoStruct)
+	if u == nil || !ok {
+		return nil
+	}
+	c, err := ygot.DeepCopy(gs)
+	if err != nil {
+		return err
+	}
+	u.record(func() { reflect.ValueOf(s).Elem().Set(reflect.ValueOf(c).Elem()) })
+	return nil
+}
+
+// revert reverts the changes within the log, most recent first.
+func (u *undoLog) revert() {
+	for i := len(*u) - 1; i >= 0; i-- {
+		(*u)[i]()
+	}
+	*u = nil
+}
+
+// initializeStructField initializes the field fieldName of the struct ptr
+// parent as per util.InitializeStructField, recording its initialization
+// within the supplied log where the field was previously nil.
+func initializeStructField(parent interface{}, fieldName string, u *undoLog) error {
+	fv := reflect.ValueOf(parent).Elem().FieldByName(fieldName)
+	wasNil := fv.IsValid() && (util.IsValuePtr(fv) || util.IsValueMap(fv)) && fv.IsNil()
+	if err := util.InitializeStructField(parent, fieldName); err != nil {
+		return err
+	}
+	if wasNil {
+		u.record(func() { fv.Set(reflect.Zero(fv.Type())) })
+	}
+	return nil
 }
 
 // retrieveNode is an internal function that retrieves the node specified by
@@ -65,8 +125,16 @@ func retrieveNode(schema *yang.Entry, root interface{}, path, traversedPath *gpb
 	case path == nil || len(path.Elem) == 0:
 		// When args.val is non-nil and the schema isn't nil, further check whether
 		// the node has a non-leaf schema. Setting a non-leaf schema isn't allowed.
+		// A JSON value may be set at a container or list entry, in which case
+		// it holds the subtree rooted at the node.
 		if !util.IsValueNil(args.val) && schema != nil {
-			if !(schema.IsLeaf() || schema.IsLeafList()) {
+			switch {
+			case schema.IsLeaf() || schema.IsLeafList() || util.IsAnydata(schema):
+			case isJSONTypedValue(args.val) && !util.IsValueNil(root) && util.IsTypeStructPtr(reflect.TypeOf(root)):
+				if err := unmarshalJSONSubtree(schema, root, nil, args); err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "failed to update node at path %v with JSON value: %v", traversedPath, err)
+				}
+			default:
 				return nil, status.Errorf(codes.Unknown, "path %v points to a node with non-leaf schema %v", traversedPath, schema)
 			}
 		}
@@ -85,9 +153,10 @@ func retrieveNode(schema *yang.Entry, root interface{}, path, traversedPath *gpb
 		return nil, status.Errorf(codes.InvalidArgument, "schema is nil for type %T, path %v", root, path)
 	}
 
+	rt := reflect.TypeOf(root)
 	switch {
 	// Check if the schema is a container, or the schema is a list and the parent provided is a member of that list.
-	case schema.IsContainer() || (schema.IsList() && util.IsTypeStructPtr(reflect.TypeOf(root))):
+	case schema.IsContainer() || util.IsRPCInputOrOutput(schema) || util.IsNotification(schema) || (schema.IsList() && util.IsTypeStructPtr(rt) && !util.IsTypeOrderedMap(rt)):
 		return retrieveNodeContainer(schema, root, path, traversedPath, args)
 	case schema.IsList():
 		return retrieveNodeList(schema, root, path, traversedPath, args)
@@ -132,12 +201,12 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 				continue
 			}
 			to := len(p)
-			if util.IsTypeMap(ft.Type) {
+			if util.IsTypeMap(ft.Type) || util.IsTypeOrderedMap(ft.Type) {
 				to--
 			}
 
 			if args.modifyRoot {
-				if err := util.InitializeStructField(root, ft.Name); err != nil {
+				if err := initializeStructField(root, ft.Name, args.undo); err != nil {
 					return nil, status.Errorf(codes.Unknown, "failed to initialize struct field %s in %T, child schema %v, path %v", ft.Name, root, cschema, path)
 				}
 			}
@@ -150,6 +219,23 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 				return nil, nil
 			}
 
+			// If a JSON value is set at a list without specifying the keys of
+			// an entry, then the value holds the entries of the list.
+			if isJSONTypedValue(args.val) && cschema != nil && cschema.IsList() && len(path.Elem) == len(p) && len(path.Elem[len(p)-1].GetKey()) == 0 {
+				if err := unmarshalJSONList(cschema, root, ft.Name, args); err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "failed to update list field %s in %T with JSON value: %v", ft.Name, root, err)
+				}
+				np := traversedPath
+				for _, e := range path.Elem[0:len(p)] {
+					np = appendElem(np, e)
+				}
+				return []*TreeNode{{
+					Path:   np,
+					Schema: cschema,
+					Data:   fv.Interface(),
+				}}, nil
+			}
+
 			// If val in args is set to a non-nil value and the path is exhausted, we
 			// may be dealing with a leaf or leaf list node. We should set the val
 			// to the corresponding field in GoStruct. If the field is an annotation,
@@ -160,9 +246,9 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 					if err := util.UpdateField(root, ft.Name, args.val); err != nil {
 						return nil, status.Errorf(codes.Unknown, "failed to update struct field %s in %T with value %v, because of %v", ft.Name, root, args.val, err)
 					}
-				case cschema.IsLeaf() || cschema.IsLeafList():
-					// With GNMIEncoding, unmarshalGeneric can only unmarshal leaf or leaf list
-					// nodes. Schema provided must be the schema of the leaf or leaf list node.
+				case cschema.IsLeaf() || cschema.IsLeafList() || util.IsAnydata(cschema):
+					// With GNMIEncoding, unmarshalGeneric can only unmarshal leaf, leaf list
+					// or anydata nodes. Schema provided must be the schema of the node.
 					// root must be the reference of container leaf/leaf list belongs to.
 					encoding := GNMIEncoding
 					if args.tolerateJSONInconsistenciesForVal {
@@ -171,6 +257,12 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 					if err := unmarshalGeneric(cschema, root, args.val, encoding); err != nil {
 						return nil, status.Errorf(codes.Unknown, "failed to update struct field %s in %T with value %v; %v", ft.Name, root, args.val, err)
 					}
+				case isJSONTypedValue(args.val) && cschema.IsContainer():
+					// The container is created if it does not exist, such that
+					// the JSON value can be unmarshalled into it.
+					if err := initializeStructField(root, ft.Name, args.undo); err != nil {
+						return nil, status.Errorf(codes.Unknown, "failed to initialize struct field %s in %T, child schema %v, path %v", ft.Name, root, cschema, path)
+					}
 				}
 			}
 
@@ -185,14 +277,95 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 		}
 	}
 
+	// A JSON value may be set at a container that has been compressed out of
+	// the GoStruct, in which case it is unmarshalled into the fields of root
+	// whose schema paths are within the container.
+	if isJSONTypedValue(args.val) {
+		if prefix, cschema := compressedContainer(schema, v.Type(), path); cschema != nil {
+			if err := unmarshalJSONSubtree(schema, root, prefix, args); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "failed to update node at path %v with JSON value: %v", path, err)
+			}
+			np := &gpb.Path{}
+			if traversedPath != nil {
+				np = proto.Clone(traversedPath).(*gpb.Path)
+			}
+			np.Elem = append(np.Elem, path.GetElem()...)
+			return []*TreeNode{{
+				Path:   np,
+				Schema: cschema,
+				Data:   root,
+			}}, nil
+		}
+	}
+
 	return nil, status.Errorf(codes.InvalidArgument, "no match found in %T, for path %v", root, path)
 }
 
-// retrieveNodeList is an internal function and operates on a map. It returns the nodes matching
-// with keys corresponding to the key supplied in path.
+// compressedContainer returns the schema path of the container at the
+// supplied path, relative to the struct type t with the supplied schema,
+// along with the schema of the container, where the container has been
+// compressed out of t. That is, the path is a strict prefix of the schema
+// paths of one or more fields of t. Otherwise, a nil schema is returned.
+func compressedContainer(schema *yang.Entry, t reflect.Type, path *gpb.Path) ([]string, *yang.Entry) {
+	var prefix []string
+	cschema := schema
+	for _, e := range path.GetElem() {
+		if len(e.GetKey()) != 0 || cschema == nil {
+			return nil, nil
+		}
+		prefix = append(prefix, e.GetName())
+		cschema = cschema.Dir[e.GetName()]
+	}
+	if len(prefix) == 0 || cschema == nil || !cschema.IsContainer() {
+		return nil, nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		ps, err := util.SchemaPaths(t.Field(i))
+		if err != nil {
+			continue
+		}
+		for _, p := range ps {
+			if schemaPathWithin(p, prefix) {
+				return prefix, cschema
+			}
+		}
+	}
+	return nil, nil
+}
+
+// schemaPathWithin reports whether the schema path p is below the schema path
+// prefix.
+func schemaPathWithin(p, prefix []string) bool {
+	if len(p) <= len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if p[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// retrieveNodeList is an internal function and operates on a map or an ordered map. It returns
+// the nodes matching with keys corresponding to the key supplied in path.
 // Function returns list of nodes, list of schemas and error.
 func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath *gpb.Path, args retrieveNodeArgs) ([]*TreeNode, error) {
 	rv := reflect.ValueOf(root)
+	var mapKeys []reflect.Value
+	ordered := util.IsValueOrderedMap(rv)
+	if ordered {
+		// The entries of an ordered map are matched in order, using a map
+		// that holds the same entries.
+		var err error
+		if mapKeys, err = util.OrderedMapKeys(rv); err != nil {
+			return nil, status.Errorf(codes.Unknown, "could not get keys of ordered map %T: %v", root, err)
+		}
+		if rv, err = util.OrderedMapToMap(rv); err != nil {
+			return nil, status.Errorf(codes.Unknown, "could not get entries of ordered map %T: %v", root, err)
+		}
+	}
 	switch {
 	case schema.Key == "":
 		return nil, status.Errorf(codes.InvalidArgument, "unkeyed list can't be traversed, type %T, path %v", root, path)
@@ -201,12 +374,27 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 	case !util.IsValueMap(rv):
 		return nil, status.Errorf(codes.InvalidArgument, "root has type %T, expect map", root)
 	}
+	if !ordered {
+		mapKeys = rv.MapKeys()
+	}
+
+	// deleteEntry deletes the entry with key k from the list.
+	deleteEntry := func(k reflect.Value) error {
+		if ordered {
+			if _, err := util.DeleteFromOrderedMap(root, k.Interface()); err != nil {
+				return status.Errorf(codes.Unknown, "could not delete entry %v from %T: %v", k.Interface(), root, err)
+			}
+			return nil
+		}
+		rv.SetMapIndex(k, reflect.Value{})
+		return nil
+	}
 
 	var matches []*TreeNode
 
 	listKeyT := rv.Type().Key()
 	listElemT := rv.Type().Elem()
-	for _, k := range rv.MapKeys() {
+	for _, k := range mapKeys {
 		listElemV := rv.MapIndex(k)
 
 		// Handle lists with a single key.
@@ -246,8 +434,7 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 			if keyAsString == pathKey {
 				remainingPath := util.PopGNMIPath(path)
 				if args.delete && len(remainingPath.GetElem()) == 0 {
-					rv.SetMapIndex(k, reflect.Value{})
-					return nil, nil
+					return nil, deleteEntry(k)
 				}
 				return retrieveNode(schema, listElemV.Interface(), remainingPath, appendElem(traversedPath, path.GetElem()[0]), args)
 			}
@@ -300,8 +487,7 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 			}
 			remainingPath := util.PopGNMIPath(path)
 			if args.delete && len(remainingPath.GetElem()) == 0 {
-				rv.SetMapIndex(k, reflect.Value{})
-				return nil, nil
+				return nil, deleteEntry(k)
 			}
 			nodes, err := retrieveNode(schema, listElemV.Interface(), remainingPath, appendElem(traversedPath, &gpb.PathElem{Name: path.GetElem()[0].Name, Key: keys}), args)
 			if err != nil {
@@ -315,11 +501,20 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 	}
 
 	if len(matches) == 0 && args.modifyRoot {
-		key, err := insertAndGetKey(schema, root, path.GetElem()[0].GetKey())
+		key, err := insertAndGetKey(schema, rv.Interface(), path.GetElem()[0].GetKey())
 		if err != nil {
 			return nil, err
 		}
-		nodes, err := retrieveNode(schema, rv.MapIndex(reflect.ValueOf(key)).Interface(), util.PopGNMIPath(path), appendElem(traversedPath, path.GetElem()[0]), args)
+		entry := rv.MapIndex(reflect.ValueOf(key))
+		if ordered {
+			// The new entry is appended to the ordered map, after it has
+			// been inserted into the map of its entries.
+			if err := util.AppendIntoOrderedMap(root, entry.Interface()); err != nil {
+				return nil, status.Errorf(codes.Unknown, "could not append entry to %T: %v", root, err)
+			}
+		}
+		args.undo.record(func() { deleteEntry(reflect.ValueOf(key)) })
+		nodes, err := retrieveNode(schema, entry.Interface(), util.PopGNMIPath(path), appendElem(traversedPath, path.GetElem()[0]), args)
 		if err != nil {
 			return nil, err
 		}
@@ -329,6 +524,135 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 	return matches, nil
 }
 
+// unmarshalJSONSubtree unmarshals the JSON value args.val into root, which
+// must be a struct ptr corresponding to the container or list entry with the
+// supplied schema. Where prefix is non-empty, the JSON value holds the
+// contents of the container at the schema path prefix relative to root, which
+// has been compressed out of root. If args.replace is set, the existing
+// contents of root, or of the container at prefix, are cleared first, with
+// the exception of the keys of a list entry. The keys of a list entry cannot
+// be changed by the JSON value.
+func unmarshalJSONSubtree(schema *yang.Entry, root interface{}, prefix []string, args retrieveNodeArgs) error {
+	jsonTree, err := decodeJSONTypedValue(args.val)
+	if err != nil {
+		return err
+	}
+	for i := len(prefix) - 1; i >= 0; i-- {
+		jsonTree = map[string]interface{}{prefix[i]: jsonTree}
+	}
+	if err := args.undo.snapshot(root); err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(root).Elem()
+	var keys []string
+	if schema.IsList() {
+		keys = strings.Fields(schema.Key)
+	}
+	keyVals := map[string]interface{}{}
+	for _, k := range keys {
+		kv, err := getKeyValue(v, k)
+		if err != nil {
+			return err
+		}
+		keyVals[k] = kv
+	}
+
+	if args.replace {
+		for i := 0; i < v.NumField(); i++ {
+			ps, err := util.SchemaPaths(v.Type().Field(i))
+			if err != nil {
+				return err
+			}
+			p := ps[len(ps)-1]
+			if _, ok := keyVals[p[len(p)-1]]; ok {
+				continue
+			}
+			for _, p := range ps {
+				if schemaPathWithin(p, prefix) {
+					v.Field(i).Set(reflect.Zero(v.Field(i).Type()))
+					break
+				}
+			}
+		}
+	}
+
+	if err := unmarshalGeneric(schema, root, jsonTree, JSONEncoding); err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		kv, err := getKeyValue(v, k)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(kv, keyVals[k]) {
+			return fmt.Errorf("value %v of key %s does not match the list entry key %v", kv, k, keyVals[k])
+		}
+	}
+	return nil
+}
+
+// unmarshalJSONList unmarshals the JSON value args.val, which holds the
+// entries of the list with the supplied schema, into the map or slice field
+// fieldName of the struct ptr root. If args.replace is set, the existing
+// entries of the list are removed first.
+func unmarshalJSONList(schema *yang.Entry, root interface{}, fieldName string, args retrieveNodeArgs) error {
+	jsonTree, err := decodeJSONTypedValue(args.val)
+	if err != nil {
+		return err
+	}
+
+	if err := args.undo.snapshot(root); err != nil {
+		return err
+	}
+
+	fv := reflect.ValueOf(root).Elem().FieldByName(fieldName)
+	if args.replace {
+		fv.Set(reflect.Zero(fv.Type()))
+	}
+	if err := util.InitializeStructField(root, fieldName); err != nil {
+		return err
+	}
+
+	parent := fv.Interface()
+	if fv.Kind() == reflect.Slice {
+		parent = fv.Addr().Interface()
+	}
+	return unmarshalGeneric(schema, parent, jsonTree, JSONEncoding)
+}
+
+// isJSONTypedValue reports whether val is a TypedValue with JSON or JSON_IETF
+// encoding.
+func isJSONTypedValue(val interface{}) bool {
+	tv, ok := val.(*gpb.TypedValue)
+	if !ok {
+		return false
+	}
+	switch tv.GetValue().(type) {
+	case *gpb.TypedValue_JsonIetfVal, *gpb.TypedValue_JsonVal:
+		return true
+	}
+	return false
+}
+
+// decodeJSONTypedValue returns the decoded value of val, which must be a
+// TypedValue with JSON or JSON_IETF encoding.
+func decodeJSONTypedValue(val interface{}) (interface{}, error) {
+	var b []byte
+	switch v := val.(*gpb.TypedValue).GetValue().(type) {
+	case *gpb.TypedValue_JsonIetfVal:
+		b = v.JsonIetfVal
+	case *gpb.TypedValue_JsonVal:
+		b = v.JsonVal
+	}
+	var jsonTree interface{}
+	if err := json.Unmarshal(b, &jsonTree); err != nil {
+		return nil, fmt.Errorf("invalid JSON value %s: %v", b, err)
+	}
+	return jsonTree, nil
+}
+
 // GetOrCreateNode function retrieves the node specified by the supplied path from the root which must have the
 // schema supplied. It strictly matches keys in the path, in other words doesn't treat partial match as match.
 // However, if there is no match, a new entry in the map is created. GetOrCreateNode also initializes the nodes
@@ -423,20 +747,32 @@ func appendElem(p *gpb.Path, e *gpb.PathElem) *gpb.Path {
 // SetNode sets the value of the node specified by the supplied path from the specified root,
 // whose schema must also be supplied. It takes a set of options which can be used to specify set
 // behaviours, such as whether or not to ensure that the node's ancestors are initialized.
+// If val is a TypedValue with JSON or JSON_IETF encoding, the path may also point to a
+// container, a list, or a list entry, in which case the JSON value is unmarshalled into the
+// subtree rooted at the node, and merged with its existing contents unless ReplaceSubtree is
+// specified. The container may be one that is compressed out of the GoStructs, in which case
+// the JSON value is unmarshalled into the fields of the enclosing GoStruct within it.
+// If SetNode fails, the nodes that it initialized, and any JSON value that it partially
+// unmarshalled, are reverted, such that root is unchanged.
 // Note that SetNode does not do a full validation -- e.g., it does not do the string
 // regex restriction validation done by ytypes.Validate().
 func SetNode(schema *yang.Entry, root interface{}, path *gpb.Path, val interface{}, opts ...SetNodeOpt) error {
+	undo := &undoLog{}
 	nodes, err := retrieveNode(schema, root, path, nil, retrieveNodeArgs{
 		modifyRoot:                        hasInitMissingElements(opts),
 		val:                               val,
 		tolerateJSONInconsistenciesForVal: hasTolerateJSONInconsistencies(opts),
+		replace:                           hasReplaceSubtree(opts),
+		undo:                              undo,
 	})
 
 	if err != nil {
+		undo.revert()
 		return err
 	}
 
 	if len(nodes) == 0 {
+		undo.revert()
 		return status.Errorf(codes.NotFound, "unable to find any nodes for the given path %v", path)
 	}
 
@@ -486,6 +822,25 @@ func hasTolerateJSONInconsistencies(opts []SetNodeOpt) bool {
 	return false
 }
 
+// ReplaceSubtree signals SetNode to clear the existing contents of a container,
+// list, or list entry before a JSON value is unmarshalled into it, such that the
+// subtree is replaced rather than merged. The keys of a list entry are retained.
+type ReplaceSubtree struct{}
+
+// IsSetNodeOpt implements the SetNodeOpt interface.
+func (*ReplaceSubtree) IsSetNodeOpt() {}
+
+// hasReplaceSubtree determines whether there is an instance of ReplaceSubtree
+// within the supplied SetNodeOpt slice.
+func hasReplaceSubtree(opts []SetNodeOpt) bool {
+	for _, o := range opts {
+		if _, ok := o.(*ReplaceSubtree); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // DeleteNode zeroes the value of the node specified by the supplied path from
 // the specified root, whose schema must also be supplied. If the node
 // specified by that path is already its zero value, or an intermediate node
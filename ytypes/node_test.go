@@ -2003,3 +2003,286 @@ func TestRetrieveContainerListError(t *testing.T) {
 		})
 	}
 }
+
+func TestSetNodeJSON(t *testing.T) {
+	jsonVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_JsonVal{JsonVal: []byte(s)}}
+	}
+	jsonIETFVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(s)}}
+	}
+
+	tests := []struct {
+		inDesc           string
+		inParent         *defRoot
+		inPath           *gpb.Path
+		inVal            *gpb.TypedValue
+		inOpts           []SetNodeOpt
+		want             *defRoot
+		wantErrSubstring string
+	}{{
+		inDesc:   "merge at root",
+		inParent: &defRoot{Mtu: ygot.Uint16(9000)},
+		inPath:   mustPath("/"),
+		inVal:    jsonIETFVal(`{"m:name": "eth0", "sub": {"timer": 5}}`),
+		want:     &defRoot{Mtu: ygot.Uint16(9000), Name: ygot.String("eth0"), Sub: &defSub{Timer: ygot.Uint32(5)}},
+	}, {
+		inDesc:   "replace at root",
+		inParent: &defRoot{Mtu: ygot.Uint16(9000)},
+		inPath:   mustPath("/"),
+		inVal:    jsonIETFVal(`{"name": "eth0"}`),
+		inOpts:   []SetNodeOpt{&ReplaceSubtree{}},
+		want:     &defRoot{Name: ygot.String("eth0")},
+	}, {
+		inDesc:   "container that does not exist",
+		inParent: &defRoot{},
+		inPath:   mustPath("/sub"),
+		inVal:    jsonVal(`{"timer": 5}`),
+		want:     &defRoot{Sub: &defSub{Timer: ygot.Uint32(5)}},
+	}, {
+		inDesc:   "replace container",
+		inParent: &defRoot{Sub: &defSub{Timer: ygot.Uint32(5)}},
+		inPath:   mustPath("/sub"),
+		inVal:    jsonVal(`{}`),
+		inOpts:   []SetNodeOpt{&ReplaceSubtree{}},
+		want:     &defRoot{Sub: &defSub{}},
+	}, {
+		inDesc:   "merge list entry",
+		inParent: &defRoot{Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1), Weight: ygot.Int32(5)}}},
+		inPath:   mustPath("/item[id=1]"),
+		inVal:    jsonIETFVal(`{"weight": 20}`),
+		want:     &defRoot{Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1), Weight: ygot.Int32(20)}}},
+	}, {
+		inDesc:   "replace list entry retains keys",
+		inParent: &defRoot{Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1), Weight: ygot.Int32(5)}}},
+		inPath:   mustPath("/item[id=1]"),
+		inVal:    jsonIETFVal(`{}`),
+		inOpts:   []SetNodeOpt{&ReplaceSubtree{}},
+		want:     &defRoot{Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1)}}},
+	}, {
+		inDesc:   "new list entry",
+		inParent: &defRoot{},
+		inPath:   mustPath("/item[id=3]"),
+		inVal:    jsonIETFVal(`{"id": 3, "weight": 1}`),
+		inOpts:   []SetNodeOpt{&InitMissingElements{}},
+		want:     &defRoot{Item: map[uint32]*defItem{3: {Id: ygot.Uint32(3), Weight: ygot.Int32(1)}}},
+	}, {
+		inDesc:           "list entry key changed",
+		inParent:         &defRoot{Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1)}}},
+		inPath:           mustPath("/item[id=1]"),
+		inVal:            jsonIETFVal(`{"id": 2}`),
+		wantErrSubstring: "value 2 of key id does not match the list entry key 1",
+	}, {
+		inDesc:   "merge list",
+		inParent: &defRoot{Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1)}}},
+		inPath:   mustPath("/item"),
+		inVal:    jsonIETFVal(`[{"id": 2, "weight": 2}]`),
+		want:     &defRoot{Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1)}, 2: {Id: ygot.Uint32(2), Weight: ygot.Int32(2)}}},
+	}, {
+		inDesc:   "replace list",
+		inParent: &defRoot{Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1)}}},
+		inPath:   mustPath("/item"),
+		inVal:    jsonIETFVal(`[{"id": 2, "weight": 2}]`),
+		inOpts:   []SetNodeOpt{&ReplaceSubtree{}},
+		want:     &defRoot{Item: map[uint32]*defItem{2: {Id: ygot.Uint32(2), Weight: ygot.Int32(2)}}},
+	}, {
+		inDesc:           "unknown field",
+		inParent:         &defRoot{},
+		inPath:           mustPath("/sub"),
+		inVal:            jsonVal(`{"interval": 5}`),
+		wantErrSubstring: "JSON contains unexpected field interval",
+	}, {
+		inDesc:           "invalid JSON",
+		inParent:         &defRoot{},
+		inPath:           mustPath("/sub"),
+		inVal:            jsonVal(`{"timer": `),
+		wantErrSubstring: "invalid JSON value",
+	}, {
+		inDesc:           "failed update does not create missing list entry",
+		inParent:         &defRoot{},
+		inPath:           mustPath("/item[id=3]"),
+		inVal:            jsonIETFVal(`{"id": 3, "weight": "heavy"}`),
+		inOpts:           []SetNodeOpt{&InitMissingElements{}},
+		want:             &defRoot{},
+		wantErrSubstring: "failed to update node",
+	}, {
+		inDesc:           "failed update does not change existing list entry",
+		inParent:         &defRoot{Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1), Weight: ygot.Int32(5)}}},
+		inPath:           mustPath("/item[id=1]"),
+		inVal:            jsonIETFVal(`{"id": 2, "weight": 20}`),
+		inOpts:           []SetNodeOpt{&ReplaceSubtree{}},
+		want:             &defRoot{Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1), Weight: ygot.Int32(5)}}},
+		wantErrSubstring: "value 2 of key id does not match the list entry key 1",
+	}, {
+		inDesc:           "non-JSON value at container",
+		inParent:         &defRoot{},
+		inPath:           mustPath("/sub"),
+		inVal:            &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "hello"}},
+		wantErrSubstring: "points to a node with non-leaf schema",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.inDesc, func(t *testing.T) {
+			err := SetNode(defSchema(), tt.inParent, tt.inPath, tt.inVal, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("SetNode: did not get expected error, %s", diff)
+			}
+			// A failed update leaves the GoStruct unchanged.
+			if err != nil && tt.want == nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.inParent); diff != "" {
+				t.Errorf("SetNode: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type compressedRoot struct {
+	Interface map[string]*compressedInterface `path:"interfaces/interface"`
+}
+
+func (*compressedRoot) IsYANGGoStruct() {}
+
+type compressedInterface struct {
+	Name        *string `path:"config/name|name"`
+	Mtu         *uint16 `path:"config/mtu"`
+	Description *string `path:"config/description"`
+	Counter     *uint64 `path:"state/counter"`
+}
+
+func (*compressedInterface) IsYANGGoStruct() {}
+
+func (i *compressedInterface) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"name": *i.Name}, nil
+}
+
+// compressedSchema returns the schema of compressedRoot, within which the
+// config and state containers of each interface are compressed out of the
+// compressedInterface struct.
+func compressedSchema() *yang.Entry {
+	leaf := func(name string, kind yang.TypeKind) *yang.Entry {
+		return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: &yang.YangType{Kind: kind}}
+	}
+	schema := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"interfaces": {
+				Name: "interfaces",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"interface": {
+						Name:     "interface",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: &yang.ListAttr{},
+						Key:      "name",
+						Dir: map[string]*yang.Entry{
+							"name": leaf("name", yang.Ystring),
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir: map[string]*yang.Entry{
+									"name":        leaf("name", yang.Ystring),
+									"mtu":         leaf("mtu", yang.Yuint16),
+									"description": leaf("description", yang.Ystring),
+								},
+							},
+							"state": {
+								Name:   "state",
+								Kind:   yang.DirectoryEntry,
+								Config: yang.TSFalse,
+								Dir:    map[string]*yang.Entry{"counter": leaf("counter", yang.Yuint64)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(schema)
+	return schema
+}
+
+func TestSetNodeJSONCompressed(t *testing.T) {
+	jsonIETFVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(s)}}
+	}
+	intf := func(mtu uint16, desc string) *compressedInterface {
+		i := &compressedInterface{Name: ygot.String("eth0"), Counter: ygot.Uint64(42)}
+		if mtu != 0 {
+			i.Mtu = ygot.Uint16(mtu)
+		}
+		if desc != "" {
+			i.Description = ygot.String(desc)
+		}
+		return i
+	}
+
+	tests := []struct {
+		inDesc           string
+		inParent         *compressedRoot
+		inPath           *gpb.Path
+		inVal            *gpb.TypedValue
+		inOpts           []SetNodeOpt
+		want             *compressedRoot
+		wantErrSubstring string
+	}{{
+		inDesc:   "merge compressed container",
+		inParent: &compressedRoot{Interface: map[string]*compressedInterface{"eth0": intf(0, "uplink")}},
+		inPath:   mustPath("/interfaces/interface[name=eth0]/config"),
+		inVal:    jsonIETFVal(`{"mtu": 1500}`),
+		want:     &compressedRoot{Interface: map[string]*compressedInterface{"eth0": intf(1500, "uplink")}},
+	}, {
+		inDesc:   "replace compressed container retains keys and other containers",
+		inParent: &compressedRoot{Interface: map[string]*compressedInterface{"eth0": intf(9000, "uplink")}},
+		inPath:   mustPath("/interfaces/interface[name=eth0]/config"),
+		inVal:    jsonIETFVal(`{"mtu": 1500}`),
+		inOpts:   []SetNodeOpt{&ReplaceSubtree{}},
+		want:     &compressedRoot{Interface: map[string]*compressedInterface{"eth0": intf(1500, "")}},
+	}, {
+		inDesc:   "compressed container of missing list entry",
+		inParent: &compressedRoot{},
+		inPath:   mustPath("/interfaces/interface[name=eth0]/config"),
+		inVal:    jsonIETFVal(`{"name": "eth0", "mtu": 1500}`),
+		inOpts:   []SetNodeOpt{&InitMissingElements{}},
+		want:     &compressedRoot{Interface: map[string]*compressedInterface{"eth0": {Name: ygot.String("eth0"), Mtu: ygot.Uint16(1500)}}},
+	}, {
+		inDesc:           "failed update of compressed container does not create list entry",
+		inParent:         &compressedRoot{},
+		inPath:           mustPath("/interfaces/interface[name=eth0]/config"),
+		inVal:            jsonIETFVal(`{"mtu": "jumbo"}`),
+		inOpts:           []SetNodeOpt{&InitMissingElements{}},
+		want:             &compressedRoot{},
+		wantErrSubstring: "failed to update node",
+	}, {
+		inDesc:           "failed update of compressed container does not change list entry",
+		inParent:         &compressedRoot{Interface: map[string]*compressedInterface{"eth0": intf(9000, "uplink")}},
+		inPath:           mustPath("/interfaces/interface[name=eth0]/config"),
+		inVal:            jsonIETFVal(`{"name": "eth1", "mtu": 1500}`),
+		inOpts:           []SetNodeOpt{&ReplaceSubtree{}},
+		want:             &compressedRoot{Interface: map[string]*compressedInterface{"eth0": intf(9000, "uplink")}},
+		wantErrSubstring: "value eth1 of key name does not match the list entry key eth0",
+	}, {
+		inDesc:           "unknown compressed container",
+		inParent:         &compressedRoot{Interface: map[string]*compressedInterface{"eth0": intf(0, "")}},
+		inPath:           mustPath("/interfaces/interface[name=eth0]/counters"),
+		inVal:            jsonIETFVal(`{}`),
+		wantErrSubstring: "no match found",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.inDesc, func(t *testing.T) {
+			err := SetNode(compressedSchema(), tt.inParent, tt.inPath, tt.inVal, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("SetNode: did not get expected error, %s", diff)
+			}
+			if err != nil && tt.want == nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.inParent); diff != "" {
+				t.Errorf("SetNode: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
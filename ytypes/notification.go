@@ -0,0 +1,225 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// notificationEnvelope is the name of the member of the RFC8040 JSON
+	// notification envelope, within which a YANG notification is encoded.
+	notificationEnvelope = "ietf-restconf:notification"
+	// eventTimeMember is the name of the member of the RFC8040 JSON
+	// notification envelope that stores the time of the event.
+	eventTimeMember = "eventTime"
+)
+
+// Notification stores a YANG notification that has been unmarshalled into the
+// GoStruct that represents it.
+type Notification struct {
+	// Name is the qualified name of the notification, which is the path of
+	// the notification within the data tree, prefixed by the name of the
+	// module of its top-level node, e.g., "module:container/list/event".
+	Name string
+	// Path is the data tree path of the notification, including the keys of
+	// any lists that it is defined within.
+	Path *gpb.Path
+	// EventTime is the time at which the event was generated. It is only
+	// set if the notification was encoded within an RFC8040 notification
+	// envelope.
+	EventTime time.Time
+	// Value is the GoStruct representing the content of the notification.
+	Value ygot.GoStruct
+}
+
+// UnmarshalNotification unmarshals the RFC7951 JSON encoded YANG notification
+// in data into a new instance of the GoStruct that represents it. The
+// notification may be encoded within an RFC8040 notification envelope, as
+// used by RFC8639 subscribed notifications, in which case the time of the
+// event is also returned.
+//
+// The GoStruct is selected from types, which is keyed by the qualified name
+// of each notification, as described for the Name field of Notification.
+// For a notification that is defined within a container or list, the JSON
+// contains the enclosing data nodes, and a single entry, identified by its
+// keys, of each enclosing list, as per RFC7950 Section 7.16.2. The schema of
+// the notification is looked up within schema using the name of its GoStruct.
+//
+// The value of the notification is not validated against the schema, which
+// can be done by calling its Validate method.
+func UnmarshalNotification(schema map[string]*yang.Entry, types map[string]reflect.Type, data []byte, opts ...UnmarshalOpt) (*Notification, error) {
+	var j map[string]interface{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal JSON notification, %v", err)
+	}
+
+	n := &Notification{}
+	if env, ok := j[notificationEnvelope]; ok {
+		if len(j) != 1 {
+			return nil, fmt.Errorf("notification envelope %s has unexpected sibling members", notificationEnvelope)
+		}
+		m, ok := env.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("notification envelope %s is of type %T, expect map[string]interface{}", notificationEnvelope, env)
+		}
+		et, err := eventTime(m[eventTimeMember])
+		if err != nil {
+			return nil, err
+		}
+		n.EventTime = et
+		j = map[string]interface{}{}
+		for k, v := range m {
+			if k != eventTimeMember {
+				j[k] = v
+			}
+		}
+	}
+
+	if len(j) != 1 {
+		return nil, fmt.Errorf("JSON notification must contain a single top-level member, got %d", len(j))
+	}
+	var name, member string
+	var val interface{}
+	for k, v := range j {
+		name, member, val = k, k, v
+	}
+	if !strings.Contains(name, ":") {
+		return nil, fmt.Errorf("top-level member %s of JSON notification is not module-qualified", name)
+	}
+
+	// prefixes stores the qualified names of the data nodes that enclose a
+	// notification.
+	prefixes := map[string]bool{}
+	for k := range types {
+		for i := strings.LastIndex(k, "/"); i != -1; i = strings.LastIndex(k, "/") {
+			k = k[:i]
+			prefixes[k] = true
+		}
+	}
+
+	n.Path = &gpb.Path{}
+	for {
+		elem := &gpb.PathElem{Name: util.StripModulePrefix(member)}
+		n.Path.Elem = append(n.Path.Elem, elem)
+
+		if t, ok := types[name]; ok {
+			if t.Kind() != reflect.Ptr || !t.Implements(reflect.TypeOf((*ygot.GoStruct)(nil)).Elem()) {
+				return nil, fmt.Errorf("type %v of notification %s is not a GoStruct pointer", t, name)
+			}
+			n.Name = name
+			n.Value = reflect.New(t.Elem()).Interface().(ygot.GoStruct)
+			s, ok := schema[t.Elem().Name()]
+			if !ok {
+				return nil, fmt.Errorf("schema for %s, which represents notification %s, not found", t.Elem().Name(), name)
+			}
+			if err := Unmarshal(s, n.Value, val, opts...); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+
+		if !prefixes[name] {
+			return nil, fmt.Errorf("no notification found for %s", name)
+		}
+
+		// A list is encoded as an array, which must contain the single entry
+		// within which the notification is defined.
+		arr, isList := val.([]interface{})
+		if isList {
+			if len(arr) != 1 {
+				return nil, fmt.Errorf("list %s within notification must contain a single entry, got %d", name, len(arr))
+			}
+			val = arr[0]
+		}
+		m, ok := val.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("data node %s within notification is of type %T, expect map[string]interface{}", name, val)
+		}
+
+		var next, nextMember string
+		keys := map[string]string{}
+		for k, v := range m {
+			cn := fmt.Sprintf("%s/%s", name, util.StripModulePrefix(k))
+			if _, ok := types[cn]; ok || prefixes[cn] {
+				if next != "" {
+					return nil, fmt.Errorf("data node %s within notification has multiple children %s and %s", name, next, cn)
+				}
+				next, nextMember, val = cn, k, v
+				continue
+			}
+			// The other members of a list entry are its keys.
+			if !isList {
+				return nil, fmt.Errorf("data node %s within notification has unexpected member %s", name, k)
+			}
+			ks, err := keyValueString(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key %s of list %s within notification, %v", k, name, err)
+			}
+			keys[util.StripModulePrefix(k)] = ks
+		}
+		if next == "" {
+			return nil, fmt.Errorf("data node %s within notification does not contain a notification", name)
+		}
+		if isList {
+			elem.Key = keys
+		}
+		name, member = next, nextMember
+	}
+}
+
+// eventTime parses the eventTime member, v, of an RFC8040 notification
+// envelope.
+func eventTime(v interface{}) (time.Time, error) {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%s of notification is of type %T, expect string", eventTimeMember, v)
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s of notification, %v", eventTimeMember, err)
+	}
+	return t, nil
+}
+
+// keyValueString returns the string representation of the JSON value v of a
+// list key, for use within a gNMI path.
+func keyValueString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []interface{}:
+		// An empty leaf is encoded as [null].
+		if len(v) == 1 && v[0] == nil {
+			return "", nil
+		}
+	}
+	return "", fmt.Errorf("unsupported key value %v of type %T", v, v)
+}
@@ -0,0 +1,238 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+type AlarmNotification struct {
+	Severity *uint8  `path:"severity"`
+	Text     *string `path:"text"`
+}
+
+func (*AlarmNotification) IsYANGGoStruct() {}
+
+type LinkDownNotification struct {
+	Reason *string `path:"reason"`
+}
+
+func (*LinkDownNotification) IsYANGGoStruct() {}
+
+// notificationSchema returns a schema containing a top-level notification,
+// alarm, and a notification, link-down, within the interface list.
+func notificationSchema() map[string]*yang.Entry {
+	module := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+	}
+	alarm := &yang.Entry{
+		Name:   "alarm",
+		Kind:   yang.NotificationEntry,
+		Parent: module,
+		Dir: map[string]*yang.Entry{
+			"severity": {
+				Kind: yang.LeafEntry,
+				Name: "severity",
+				Type: &yang.YangType{Kind: yang.Yuint8},
+			},
+			"text": {
+				Kind: yang.LeafEntry,
+				Name: "text",
+				Type: &yang.YangType{Kind: yang.Ystring, Length: yang.YangRange{yang.YRange{Min: yang.FromInt(1), Max: yang.FromInt(8)}}},
+			},
+		},
+	}
+	linkDown := &yang.Entry{
+		Name: "link-down",
+		Kind: yang.NotificationEntry,
+		Parent: &yang.Entry{
+			Name:     "interface",
+			Kind:     yang.DirectoryEntry,
+			ListAttr: &yang.ListAttr{},
+			Key:      "name",
+			Parent: &yang.Entry{
+				Name:   "interfaces",
+				Kind:   yang.DirectoryEntry,
+				Parent: module,
+			},
+		},
+		Dir: map[string]*yang.Entry{
+			"reason": {
+				Kind: yang.LeafEntry,
+				Name: "reason",
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+		},
+	}
+	for _, n := range []*yang.Entry{alarm, linkDown} {
+		for _, e := range n.Dir {
+			e.Parent = n
+		}
+	}
+
+	return map[string]*yang.Entry{
+		"AlarmNotification":    alarm,
+		"LinkDownNotification": linkDown,
+	}
+}
+
+func TestUnmarshalNotification(t *testing.T) {
+	types := map[string]reflect.Type{
+		"mod:alarm":                          reflect.TypeOf((*AlarmNotification)(nil)),
+		"mod:interfaces/interface/link-down": reflect.TypeOf((*LinkDownNotification)(nil)),
+	}
+
+	tests := []struct {
+		desc    string
+		inJSON  string
+		inTypes map[string]reflect.Type
+		want    *Notification
+		wantErr string
+	}{{
+		desc:   "top-level notification",
+		inJSON: `{"mod:alarm": {"severity": 3, "text": "fan"}}`,
+		want: &Notification{
+			Name:  "mod:alarm",
+			Path:  &gpb.Path{Elem: []*gpb.PathElem{{Name: "alarm"}}},
+			Value: &AlarmNotification{Severity: ygot.Uint8(3), Text: ygot.String("fan")},
+		},
+	}, {
+		desc:   "notification within envelope",
+		inJSON: `{"ietf-restconf:notification": {"eventTime": "2020-06-01T10:20:30.5Z", "mod:alarm": {"severity": 1}}}`,
+		want: &Notification{
+			Name:      "mod:alarm",
+			Path:      &gpb.Path{Elem: []*gpb.PathElem{{Name: "alarm"}}},
+			EventTime: time.Date(2020, 6, 1, 10, 20, 30, 500000000, time.UTC),
+			Value:     &AlarmNotification{Severity: ygot.Uint8(1)},
+		},
+	}, {
+		desc:   "notification within list",
+		inJSON: `{"mod:interfaces": {"interface": [{"name": "eth0", "link-down": {"reason": "LOS"}}]}}`,
+		want: &Notification{
+			Name: "mod:interfaces/interface/link-down",
+			Path: &gpb.Path{Elem: []*gpb.PathElem{
+				{Name: "interfaces"},
+				{Name: "interface", Key: map[string]string{"name": "eth0"}},
+				{Name: "link-down"},
+			}},
+			Value: &LinkDownNotification{Reason: ygot.String("LOS")},
+		},
+	}, {
+		desc:    "invalid JSON",
+		inJSON:  `{`,
+		wantErr: "cannot unmarshal JSON notification",
+	}, {
+		desc:    "invalid eventTime",
+		inJSON:  `{"ietf-restconf:notification": {"eventTime": "yesterday", "mod:alarm": {}}}`,
+		wantErr: "invalid eventTime of notification",
+	}, {
+		desc:    "multiple notifications",
+		inJSON:  `{"mod:alarm": {}, "mod:other": {}}`,
+		wantErr: "must contain a single top-level member, got 2",
+	}, {
+		desc:    "unqualified name",
+		inJSON:  `{"alarm": {}}`,
+		wantErr: "is not module-qualified",
+	}, {
+		desc:    "unknown notification",
+		inJSON:  `{"mod:other": {}}`,
+		wantErr: "no notification found for mod:other",
+	}, {
+		desc:    "multiple list entries",
+		inJSON:  `{"mod:interfaces": {"interface": [{"name": "eth0"}, {"name": "eth1"}]}}`,
+		wantErr: "must contain a single entry, got 2",
+	}, {
+		desc:    "no notification within list entry",
+		inJSON:  `{"mod:interfaces": {"interface": [{"name": "eth0"}]}}`,
+		wantErr: "does not contain a notification",
+	}, {
+		desc:    "unexpected member of container",
+		inJSON:  `{"mod:interfaces": {"name": "eth0", "interface": [{"name": "eth0", "link-down": {}}]}}`,
+		wantErr: "has unexpected member name",
+	}, {
+		desc:    "missing schema",
+		inJSON:  `{"mod:alarm": {}}`,
+		inTypes: map[string]reflect.Type{"mod:alarm": reflect.TypeOf((*RPCInputStruct)(nil))},
+		wantErr: "schema for RPCInputStruct, which represents notification mod:alarm, not found",
+	}, {
+		desc:    "type is not a GoStruct",
+		inJSON:  `{"mod:alarm": {}}`,
+		inTypes: map[string]reflect.Type{"mod:alarm": reflect.TypeOf("")},
+		wantErr: "is not a GoStruct pointer",
+	}, {
+		desc:    "unknown field",
+		inJSON:  `{"mod:alarm": {"priority": 1}}`,
+		wantErr: "JSON contains unexpected field priority",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inTypes := types
+			if tt.inTypes != nil {
+				inTypes = tt.inTypes
+			}
+			got, err := UnmarshalNotification(notificationSchema(), inTypes, []byte(tt.inJSON))
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("UnmarshalNotification: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("UnmarshalNotification: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateNotification(t *testing.T) {
+	schema := notificationSchema()["AlarmNotification"]
+
+	tests := []struct {
+		desc    string
+		val     *AlarmNotification
+		wantErr string
+	}{{
+		desc: "valid notification",
+		val:  &AlarmNotification{Severity: ygot.Uint8(3), Text: ygot.String("fan")},
+	}, {
+		desc:    "invalid leaf value",
+		val:     &AlarmNotification{Text: ygot.String("fan failure")},
+		wantErr: "length",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var err error
+			if errs := Validate(schema, tt.val); errs != nil {
+				err = errs
+			}
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Errorf("Validate: %s", diff)
+			}
+		})
+	}
+}
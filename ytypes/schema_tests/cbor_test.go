@@ -0,0 +1,56 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	uoc "github.com/openconfig/ygot/uexampleoc"
+)
+
+func TestCBORRoundTripGenerated(t *testing.T) {
+	// The schema within the generated code does not retain the values of
+	// enumerations, such that they are taken from the generated enumerated
+	// types.
+	schema := uoc.SchemaTree["Device"]
+
+	in := &uoc.Device{}
+	intf, err := in.GetOrCreateInterfaces().NewInterface("eth0")
+	if err != nil {
+		t.Fatalf("cannot create interface, %v", err)
+	}
+	intf.GetOrCreateConfig().Name = ygot.String("eth0")
+	intf.GetOrCreateConfig().Mtu = ygot.Uint16(1500)
+	st := intf.GetOrCreateState()
+	st.Name = ygot.String("eth0")
+	st.AdminStatus = uoc.OpenconfigInterfaces_Interfaces_Interface_State_AdminStatus_DOWN
+	st.OperStatus = uoc.OpenconfigInterfaces_Interfaces_Interface_State_OperStatus_LOWER_LAYER_DOWN
+
+	b, err := ygot.MarshalCBOR(in, schema, nil)
+	if err != nil {
+		t.Fatalf("MarshalCBOR: got unexpected error, %v", err)
+	}
+	got := &uoc.Device{}
+	if err := ytypes.UnmarshalCBOR(schema, got, b); err != nil {
+		t.Fatalf("UnmarshalCBOR(%x): got unexpected error, %v", b, err)
+	}
+	if diff := cmp.Diff(in, got); diff != "" {
+		t.Errorf("UnmarshalCBOR(%x): did not get expected GoStruct, diff(-want, +got):\n%s", b, diff)
+	}
+}
@@ -21,36 +21,55 @@ import (
 
 	oc "github.com/openconfig/ygot/exampleoc"
 	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
-func BenchmarkDiff(b *testing.B) {
+// benchmarkDevices returns the pair of Devices that are unmarshalled from
+// the interface benchmark JSON files.
+func benchmarkDevices(b *testing.B) (*oc.Device, *oc.Device) {
 	jsonFileA := "interfaceBenchmarkA.json"
 	jsonFileB := "interfaceBenchmarkB.json"
 
 	jsonA, err := ioutil.ReadFile(filepath.Join(testRoot, "testdata", jsonFileA))
 	if err != nil {
-		b.Errorf("ioutil.ReadFile(%s): could not open file: %v", jsonFileA, err)
-		return
+		b.Fatalf("ioutil.ReadFile(%s): could not open file: %v", jsonFileA, err)
 	}
 	deviceA := &oc.Device{}
 	if err := oc.Unmarshal(jsonA, deviceA); err != nil {
-		b.Errorf("ioutil.ReadFile(%s): could unmarschal: %v", jsonFileA, err)
-		return
+		b.Fatalf("ioutil.ReadFile(%s): could unmarschal: %v", jsonFileA, err)
 	}
 
 	jsonB, err := ioutil.ReadFile(filepath.Join(testRoot, "testdata", jsonFileB))
 	if err != nil {
-		b.Errorf("ioutil.ReadFile(%s): could not open file: %v", jsonFileB, err)
-		return
+		b.Fatalf("ioutil.ReadFile(%s): could not open file: %v", jsonFileB, err)
 	}
 	deviceB := &oc.Device{}
 	if err := oc.Unmarshal(jsonB, deviceB); err != nil {
-		b.Errorf("ioutil.ReadFile(%s): could unmarschal: %v", jsonFileB, err)
-		return
+		b.Fatalf("ioutil.ReadFile(%s): could unmarschal: %v", jsonFileB, err)
 	}
-	_, err = ygot.Diff(deviceA, deviceB)
-	if err != nil {
-		b.Errorf("Error in diff %v", err)
-		return
+	return deviceA, deviceB
+}
+
+func BenchmarkDiff(b *testing.B) {
+	deviceA, deviceB := benchmarkDevices(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ygot.Diff(deviceA, deviceB); err != nil {
+			b.Fatalf("Error in diff %v", err)
+		}
+	}
+}
+
+func BenchmarkDiffSubtree(b *testing.B) {
+	deviceA, deviceB := benchmarkDevices(b)
+	opt := &ygot.DiffSubtreeOpt{
+		Path: &gpb.Path{Elem: []*gpb.PathElem{{Name: "interfaces"}, {Name: "interface", Key: map[string]string{"name": "ifp-0/0/1"}}}},
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ygot.Diff(deviceA, deviceB, opt); err != nil {
+			b.Fatalf("Error in diff %v", err)
+		}
 	}
 }
@@ -0,0 +1,136 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	oc "github.com/openconfig/ygot/exampleoc"
+	uoc "github.com/openconfig/ygot/uexampleoc"
+)
+
+// streamTestCase describes a generated package against which the streaming
+// JSON unmarshaller is tested.
+type streamTestCase struct {
+	name        string
+	schema      *yang.Entry
+	newDevice   func() ygot.GoStruct
+	unmarshalFn ytypes.UnmarshalFunc
+	// opts are the options supplied in addition to those of the test.
+	opts []ytypes.UnmarshalOpt
+}
+
+var streamTestCases = []streamTestCase{{
+	name:        "exampleoc",
+	schema:      oc.SchemaTree["Device"],
+	newDevice:   func() ygot.GoStruct { return &oc.Device{} },
+	unmarshalFn: oc.Unmarshal,
+}, {
+	name:        "uexampleoc",
+	schema:      uoc.SchemaTree["Device"],
+	newDevice:   func() ygot.GoStruct { return &uoc.Device{} },
+	unmarshalFn: uoc.Unmarshal,
+	// uexampleoc is generated from a subset of the modules used for the test
+	// data.
+	opts: []ytypes.UnmarshalOpt{&ytypes.IgnoreExtraFields{}},
+}}
+
+func TestUnmarshalJSONStream(t *testing.T) {
+	tests := []struct {
+		jsonFilePath string
+		opts         []ytypes.UnmarshalOpt
+	}{
+		{jsonFilePath: "basic.json"},
+		{jsonFilePath: "basic-extra.json"},
+		{jsonFilePath: "basic-extra.json", opts: []ytypes.UnmarshalOpt{&ytypes.IgnoreExtraFields{}}},
+		{jsonFilePath: "bgp-example.json"},
+		{jsonFilePath: "interfaces-example.json"},
+		{jsonFilePath: "local-routing-example.json"},
+		{jsonFilePath: "policy-example.json"},
+		{jsonFilePath: "relay-agent.json"},
+		{jsonFilePath: "system-cpu.json"},
+		{jsonFilePath: "interfaceBenchmarkA.json"},
+	}
+
+	for _, tc := range streamTestCases {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s %s (opts: %v)", tc.name, tt.jsonFilePath, tt.opts), func(t *testing.T) {
+				j, err := ioutil.ReadFile(filepath.Join(testRoot, "testdata", tt.jsonFilePath))
+				if err != nil {
+					t.Fatalf("ioutil.ReadFile(%s): could not open file: %v", tt.jsonFilePath, err)
+				}
+
+				opts := append(append([]ytypes.UnmarshalOpt{}, tc.opts...), tt.opts...)
+				want := tc.newDevice()
+				wantErr := tc.unmarshalFn(j, want, opts...)
+				got := tc.newDevice()
+				err = ytypes.UnmarshalJSONStream(tc.schema, got, bytes.NewReader(j), opts...)
+				if diff := cmp.Diff(errToString(wantErr), errToString(err)); diff != "" {
+					t.Fatalf("UnmarshalJSONStream: did not get same error as Unmarshal, diff(-want, +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("UnmarshalJSONStream: did not get same GoStruct as Unmarshal, diff(-want, +got):\n%s", diff)
+				}
+			})
+		}
+	}
+}
+
+// benchmarkJSON returns the contents of the interface benchmark JSON file.
+func benchmarkJSON(b *testing.B) []byte {
+	jsonFile := "interfaceBenchmarkA.json"
+	j, err := ioutil.ReadFile(filepath.Join(testRoot, "testdata", jsonFile))
+	if err != nil {
+		b.Fatalf("ioutil.ReadFile(%s): could not open file: %v", jsonFile, err)
+	}
+	return j
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	j := benchmarkJSON(b)
+	for _, tc := range streamTestCases {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := tc.unmarshalFn(j, tc.newDevice(), tc.opts...); err != nil {
+					b.Fatalf("Unmarshal: got unexpected error, %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshalJSONStream(b *testing.B) {
+	j := benchmarkJSON(b)
+	for _, tc := range streamTestCases {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := ytypes.UnmarshalJSONStream(tc.schema, tc.newDevice(), bytes.NewReader(j), tc.opts...); err != nil {
+					b.Fatalf("UnmarshalJSONStream: got unexpected error, %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc/orderedoc"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// orderedDevice returns a device containing entries of the ordered list
+// ordered-lists/ordered-list, in the order of the supplied names. The value
+// of each entry is its index within the list.
+func orderedDevice(t *testing.T, names ...string) *orderedoc.Device {
+	d := &orderedoc.Device{}
+	for i, n := range names {
+		ol, err := d.GetOrCreateModel().NewOrderedList(n)
+		if err != nil {
+			t.Fatalf("cannot add ordered list entry %s, %v", n, err)
+		}
+		ol.Value = ygot.Uint32(uint32(i))
+	}
+	return d
+}
+
+func TestValidateOrderedListMustWhen(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inDevice         *orderedoc.Device
+		wantErrSubstring string
+	}{{
+		desc:             "first entry has zero value",
+		inDevice:         orderedDevice(t, "a", "b"),
+		wantErrSubstring: "first ordered-list entry must not have value 0",
+	}, {
+		desc: "entries evaluated in order",
+		inDevice: func() *orderedoc.Device {
+			d := orderedDevice(t, "b", "a")
+			d.Model.GetOrderedList("b").Value = ygot.Uint32(1)
+			d.Model.GetOrderedList("a").Value = ygot.Uint32(0)
+			return d
+		}(),
+	}, {
+		desc: "when statement false for entry",
+		inDevice: func() *orderedoc.Device {
+			d := orderedDevice(t, "b", "none")
+			d.Model.GetOrderedList("b").Value = ygot.Uint32(1)
+			return d
+		}(),
+		wantErrSubstring: "when statement",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.inDevice.Validate()
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Validate: %s", diff)
+			}
+
+			_, err = ygot.EmitJSON(tt.inDevice, nil)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Errorf("EmitJSON: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOrderedListNode(t *testing.T) {
+	schema := mustSchema(orderedoc.Schema).RootSchema()
+	value := func(v uint32) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: uint64(v)}}
+	}
+
+	t.Run("set existing entry", func(t *testing.T) {
+		d := orderedDevice(t, "b", "a")
+		if err := ytypes.SetNode(schema, d, mustPath("/model/ordered-lists/ordered-list[name=a]/config/value"), value(42)); err != nil {
+			t.Fatalf("SetNode: got unexpected error, %v", err)
+		}
+		if diff := cmp.Diff([]string{"b", "a"}, d.Model.OrderedList.Keys()); diff != "" {
+			t.Errorf("SetNode: did not get expected keys, diff(-want, +got):\n%s", diff)
+		}
+		if got := d.Model.GetOrderedList("a").Value; got == nil || *got != 42 {
+			t.Errorf("SetNode: got value %v, want 42", got)
+		}
+	})
+
+	t.Run("set missing entry", func(t *testing.T) {
+		d := orderedDevice(t, "b", "a")
+		if err := ytypes.SetNode(schema, d, mustPath("/model/ordered-lists/ordered-list[name=c]/config/value"), value(42), &ytypes.InitMissingElements{}); err != nil {
+			t.Fatalf("SetNode: got unexpected error, %v", err)
+		}
+		if diff := cmp.Diff([]string{"b", "a", "c"}, d.Model.OrderedList.Keys()); diff != "" {
+			t.Errorf("SetNode: did not get expected keys, diff(-want, +got):\n%s", diff)
+		}
+		if got := d.Model.GetOrderedList("c").Value; got == nil || *got != 42 {
+			t.Errorf("SetNode: got value %v, want 42", got)
+		}
+	})
+
+	t.Run("set entry of empty list", func(t *testing.T) {
+		d := &orderedoc.Device{}
+		if err := ytypes.SetNode(schema, d, mustPath("/model/ordered-lists/ordered-list[name=a]/config/value"), value(42), &ytypes.InitMissingElements{}); err != nil {
+			t.Fatalf("SetNode: got unexpected error, %v", err)
+		}
+		if diff := cmp.Diff([]string{"a"}, d.Model.OrderedList.Keys()); diff != "" {
+			t.Errorf("SetNode: did not get expected keys, diff(-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("get entries", func(t *testing.T) {
+		d := orderedDevice(t, "c", "a", "b")
+		nodes, err := ytypes.GetNode(schema, d, mustPath("/model/ordered-lists/ordered-list[name=*]"), &ytypes.GetHandleWildcards{})
+		if err != nil {
+			t.Fatalf("GetNode: got unexpected error, %v", err)
+		}
+		var got []string
+		for _, n := range nodes {
+			got = append(got, *n.Data.(*orderedoc.Model_OrderedList).Name)
+		}
+		if diff := cmp.Diff([]string{"c", "a", "b"}, got); diff != "" {
+			t.Errorf("GetNode: did not get entries in order, diff(-want, +got):\n%s", diff)
+		}
+
+		nodes, err = ytypes.GetNode(schema, d, mustPath("/model/ordered-lists/ordered-list[name=a]/config/value"))
+		if err != nil {
+			t.Fatalf("GetNode: got unexpected error, %v", err)
+		}
+		if len(nodes) != 1 || *nodes[0].Data.(*uint32) != 1 {
+			t.Errorf("GetNode: got nodes %v, want value 1", nodes)
+		}
+	})
+
+	t.Run("delete entry", func(t *testing.T) {
+		d := orderedDevice(t, "c", "a", "b")
+		if err := ytypes.DeleteNode(schema, d, mustPath("/model/ordered-lists/ordered-list[name=a]")); err != nil {
+			t.Fatalf("DeleteNode: got unexpected error, %v", err)
+		}
+		if diff := cmp.Diff([]string{"c", "b"}, d.Model.OrderedList.Keys()); diff != "" {
+			t.Errorf("DeleteNode: did not get expected keys, diff(-want, +got):\n%s", diff)
+		}
+		if d.Model.GetOrderedList("a") != nil {
+			t.Errorf("DeleteNode: entry a was not deleted")
+		}
+	})
+
+	t.Run("delete leaf of entry", func(t *testing.T) {
+		d := orderedDevice(t, "c", "a")
+		if err := ytypes.DeleteNode(schema, d, mustPath("/model/ordered-lists/ordered-list[name=a]/config/value")); err != nil {
+			t.Fatalf("DeleteNode: got unexpected error, %v", err)
+		}
+		if d.Model.GetOrderedList("a").Value != nil {
+			t.Errorf("DeleteNode: value of entry a was not deleted")
+		}
+		if diff := cmp.Diff([]string{"c", "a"}, d.Model.OrderedList.Keys()); diff != "" {
+			t.Errorf("DeleteNode: did not get expected keys, diff(-want, +got):\n%s", diff)
+		}
+	})
+}
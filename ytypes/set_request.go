@@ -0,0 +1,151 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Refer to: https://github.com/openconfig/reference/blob/master/rpc/gnmi/gnmi-specification.md#34-modifying-state.
+
+// SetRequestOpt defines an interface that can be used to supply arguments to
+// ApplySetRequest.
+type SetRequestOpt interface {
+	// IsSetRequestOpt is a marker method that is used to identify an instance
+	// of SetRequestOpt.
+	IsSetRequestOpt()
+}
+
+// ValidateSetResult signals ApplySetRequest to validate the data tree that
+// results from applying all operations of the SetRequest, such that the
+// SetRequest is rejected if the data tree is not valid.
+type ValidateSetResult struct {
+	// Opts are the options that are used when validating the data tree.
+	Opts []ygot.ValidationOption
+}
+
+// IsSetRequestOpt implements the SetRequestOpt interface.
+func (*ValidateSetResult) IsSetRequestOpt() {}
+
+// ApplySetRequest applies the operations of the supplied gNMI SetRequest to
+// root, which must be a struct ptr corresponding to the supplied schema. As
+// required by the gNMI specification, the deletes are applied first, followed
+// by the replaces and then the updates, each in the order that they appear in
+// the SetRequest. The paths of all operations are relative to the prefix of
+// the SetRequest. A delete or replace of the root of the data tree resets it
+// to an empty GoStruct of the same type.
+//
+// The SetRequest is applied transactionally: if any operation fails, or if
+// ValidateSetResult is specified and the resulting data tree is not valid,
+// root is left unmodified and an error is returned. Otherwise, a SetResponse
+// that contains an UpdateResult for each operation is returned.
+func ApplySetRequest(schema *yang.Entry, root ygot.GoStruct, req *gpb.SetRequest, opts ...SetRequestOpt) (*gpb.SetResponse, error) {
+	rv := reflect.ValueOf(root)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, status.Errorf(codes.InvalidArgument, "got %T, want non-nil struct ptr root", root)
+	}
+
+	// The operations are applied to a copy of root, which replaces the
+	// contents of root only once all operations have succeeded.
+	cp, err := ygot.DeepCopy(root)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot copy root %T: %v", root, err)
+	}
+
+	var results []*gpb.UpdateResult
+	for _, p := range req.GetDelete() {
+		pp := prefixedPath(req.GetPrefix(), p)
+		// Deleting the root replaces it with an empty GoStruct of the same
+		// type.
+		if len(pp.GetElem()) == 0 {
+			cp = newRootLike(cp)
+		} else if err := DeleteNode(schema, cp, pp); err != nil {
+			return nil, setRequestError(err, "cannot delete path %v", p)
+		}
+		results = append(results, &gpb.UpdateResult{Path: p, Op: gpb.UpdateResult_DELETE})
+	}
+
+	for _, u := range req.GetReplace() {
+		p := prefixedPath(req.GetPrefix(), u.GetPath())
+		// The existing node is deleted first, such that it is replaced by the
+		// value rather than merged with it.
+		if len(p.GetElem()) == 0 {
+			cp = newRootLike(cp)
+		} else if err := DeleteNode(schema, cp, p); err != nil {
+			return nil, setRequestError(err, "cannot replace path %v", u.GetPath())
+		}
+		if err := SetNode(schema, cp, p, u.GetVal(), &InitMissingElements{}, &ReplaceSubtree{}); err != nil {
+			return nil, setRequestError(err, "cannot replace path %v", u.GetPath())
+		}
+		results = append(results, &gpb.UpdateResult{Path: u.GetPath(), Op: gpb.UpdateResult_REPLACE})
+	}
+
+	for _, u := range req.GetUpdate() {
+		if err := SetNode(schema, cp, prefixedPath(req.GetPrefix(), u.GetPath()), u.GetVal(), &InitMissingElements{}); err != nil {
+			return nil, setRequestError(err, "cannot update path %v", u.GetPath())
+		}
+		results = append(results, &gpb.UpdateResult{Path: u.GetPath(), Op: gpb.UpdateResult_UPDATE})
+	}
+
+	for _, o := range opts {
+		if v, ok := o.(*ValidateSetResult); ok {
+			if errs := Validate(schema, cp, v.Opts...); errs != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid data tree after SetRequest: %v", errs)
+			}
+		}
+	}
+
+	rv.Elem().Set(reflect.ValueOf(cp).Elem())
+	return &gpb.SetResponse{
+		Prefix:    req.GetPrefix(),
+		Response:  results,
+		Timestamp: time.Now().UnixNano(),
+	}, nil
+}
+
+// newRootLike returns a new, empty, GoStruct of the same type as root.
+func newRootLike(root ygot.GoStruct) ygot.GoStruct {
+	return reflect.New(reflect.TypeOf(root).Elem()).Interface().(ygot.GoStruct)
+}
+
+// setRequestError returns a status error for the error err, which occurred
+// when applying an operation of a SetRequest, described by format and args.
+// The status code of err is retained where it is NotFound or InvalidArgument,
+// otherwise err is taken to be caused by an invalid operation.
+func setRequestError(err error, format string, args ...interface{}) error {
+	code := status.Code(err)
+	if code != codes.NotFound {
+		code = codes.InvalidArgument
+	}
+	return status.Errorf(code, "%s: %s", fmt.Sprintf(format, args...), status.Convert(err).Message())
+}
+
+// prefixedPath returns the path formed by appending the elements of path to
+// those of prefix.
+func prefixedPath(prefix, path *gpb.Path) *gpb.Path {
+	np := &gpb.Path{}
+	np.Elem = append(np.Elem, prefix.GetElem()...)
+	np.Elem = append(np.Elem, path.GetElem()...)
+	return np
+}
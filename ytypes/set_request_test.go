@@ -0,0 +1,228 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestApplySetRequest(t *testing.T) {
+	uintVal := func(v uint64) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: v}}
+	}
+	stringVal := func(v string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: v}}
+	}
+	jsonIETFVal := func(s string) *gpb.TypedValue {
+		return &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(s)}}
+	}
+	// patternSchema returns the schema of defRoot in which the name leaf
+	// must match a pattern, which is only checked by validation.
+	patternSchema := func() *yang.Entry {
+		s := defSchema()
+		s.Dir["name"].Type.Pattern = []string{"eth[0-9]+"}
+		return s
+	}
+
+	tests := []struct {
+		desc             string
+		inSchema         func() *yang.Entry
+		inRoot           *defRoot
+		inReq            *gpb.SetRequest
+		inOpts           []SetRequestOpt
+		want             *defRoot
+		wantResults      []*gpb.UpdateResult
+		wantErrSubstring string
+		wantErrCode      codes.Code
+	}{{
+		desc:     "delete, replace and update",
+		inSchema: defSchema,
+		inRoot: &defRoot{
+			Mtu:  ygot.Uint16(9000),
+			Sub:  &defSub{Timer: ygot.Uint32(1)},
+			Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1), Weight: ygot.Int32(5)}},
+		},
+		inReq: &gpb.SetRequest{
+			Delete: []*gpb.Path{mustPath("/mtu")},
+			Replace: []*gpb.Update{
+				{Path: mustPath("/item[id=1]"), Val: jsonIETFVal(`{"id": 1}`)},
+				{Path: mustPath("/other"), Val: jsonIETFVal(`{"desc": "uplink"}`)},
+			},
+			Update: []*gpb.Update{{Path: mustPath("/name"), Val: stringVal("eth0")}},
+		},
+		want: &defRoot{
+			Name:  ygot.String("eth0"),
+			Sub:   &defSub{Timer: ygot.Uint32(1)},
+			Other: &defOther{Desc: ygot.String("uplink")},
+			Item:  map[uint32]*defItem{1: {Id: ygot.Uint32(1)}},
+		},
+		wantResults: []*gpb.UpdateResult{
+			{Path: mustPath("/mtu"), Op: gpb.UpdateResult_DELETE},
+			{Path: mustPath("/item[id=1]"), Op: gpb.UpdateResult_REPLACE},
+			{Path: mustPath("/other"), Op: gpb.UpdateResult_REPLACE},
+			{Path: mustPath("/name"), Op: gpb.UpdateResult_UPDATE},
+		},
+	}, {
+		desc:     "deletes are applied before updates",
+		inSchema: defSchema,
+		inRoot:   &defRoot{Mtu: ygot.Uint16(9000)},
+		inReq: &gpb.SetRequest{
+			Update: []*gpb.Update{{Path: mustPath("/mtu"), Val: uintVal(1500)}},
+			Delete: []*gpb.Path{mustPath("/mtu")},
+		},
+		want: &defRoot{Mtu: ygot.Uint16(1500)},
+		wantResults: []*gpb.UpdateResult{
+			{Path: mustPath("/mtu"), Op: gpb.UpdateResult_DELETE},
+			{Path: mustPath("/mtu"), Op: gpb.UpdateResult_UPDATE},
+		},
+	}, {
+		desc:     "paths relative to prefix",
+		inSchema: defSchema,
+		inRoot:   &defRoot{},
+		inReq: &gpb.SetRequest{
+			Prefix:  mustPath("/item[id=2]"),
+			Update:  []*gpb.Update{{Path: mustPath("/weight"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: 20}}}},
+			Replace: []*gpb.Update{{Path: mustPath("/"), Val: jsonIETFVal(`{"weight": 10}`)}},
+		},
+		want: &defRoot{Item: map[uint32]*defItem{2: {Id: ygot.Uint32(2), Weight: ygot.Int32(20)}}},
+		wantResults: []*gpb.UpdateResult{
+			{Path: mustPath("/"), Op: gpb.UpdateResult_REPLACE},
+			{Path: mustPath("/weight"), Op: gpb.UpdateResult_UPDATE},
+		},
+	}, {
+		desc:     "failed operation is rolled back",
+		inSchema: defSchema,
+		inRoot:   &defRoot{Mtu: ygot.Uint16(9000)},
+		inReq: &gpb.SetRequest{
+			Delete: []*gpb.Path{mustPath("/mtu")},
+			Update: []*gpb.Update{
+				{Path: mustPath("/name"), Val: stringVal("eth0")},
+				{Path: mustPath("/unknown"), Val: stringVal("eth0")},
+			},
+		},
+		wantErrSubstring: "cannot update path",
+		wantErrCode:      codes.InvalidArgument,
+	}, {
+		desc:     "delete root",
+		inSchema: defSchema,
+		inRoot: &defRoot{
+			Mtu:  ygot.Uint16(9000),
+			Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1)}},
+		},
+		inReq: &gpb.SetRequest{
+			Delete: []*gpb.Path{mustPath("/")},
+		},
+		want: &defRoot{},
+		wantResults: []*gpb.UpdateResult{
+			{Path: mustPath("/"), Op: gpb.UpdateResult_DELETE},
+		},
+	}, {
+		desc:     "replace root",
+		inSchema: defSchema,
+		inRoot: &defRoot{
+			Mtu:  ygot.Uint16(9000),
+			Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1)}},
+		},
+		inReq: &gpb.SetRequest{
+			Replace: []*gpb.Update{{Path: mustPath("/"), Val: jsonIETFVal(`{"name": "eth0"}`)}},
+		},
+		want: &defRoot{Name: ygot.String("eth0")},
+		wantResults: []*gpb.UpdateResult{
+			{Path: mustPath("/"), Op: gpb.UpdateResult_REPLACE},
+		},
+	}, {
+		desc:     "invalid value",
+		inSchema: defSchema,
+		inRoot:   &defRoot{},
+		inReq: &gpb.SetRequest{
+			Update: []*gpb.Update{{Path: mustPath("/mtu"), Val: stringVal("jumbo")}},
+		},
+		wantErrSubstring: "cannot update path",
+		wantErrCode:      codes.InvalidArgument,
+	}, {
+		desc:     "list entry key not specified",
+		inSchema: defSchema,
+		inRoot:   &defRoot{Item: map[uint32]*defItem{1: {Id: ygot.Uint32(1)}}},
+		inReq: &gpb.SetRequest{
+			Update: []*gpb.Update{{Path: mustPath("/item/weight"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: 20}}}},
+		},
+		wantErrSubstring: "cannot update path",
+		wantErrCode:      codes.NotFound,
+	}, {
+		desc:     "invalid result is rolled back",
+		inSchema: patternSchema,
+		inRoot:   &defRoot{Mtu: ygot.Uint16(9000)},
+		inReq: &gpb.SetRequest{
+			Update: []*gpb.Update{{Path: mustPath("/name"), Val: stringVal("uplink")}},
+		},
+		inOpts:           []SetRequestOpt{&ValidateSetResult{}},
+		wantErrSubstring: "invalid data tree after SetRequest",
+		wantErrCode:      codes.InvalidArgument,
+	}, {
+		desc:     "result is not validated by default",
+		inSchema: patternSchema,
+		inRoot:   &defRoot{Mtu: ygot.Uint16(9000)},
+		inReq: &gpb.SetRequest{
+			Update: []*gpb.Update{{Path: mustPath("/name"), Val: stringVal("uplink")}},
+		},
+		want: &defRoot{Mtu: ygot.Uint16(9000), Name: ygot.String("uplink")},
+		wantResults: []*gpb.UpdateResult{
+			{Path: mustPath("/name"), Op: gpb.UpdateResult_UPDATE},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			orig, err := ygot.DeepCopy(tt.inRoot)
+			if err != nil {
+				t.Fatalf("DeepCopy: got unexpected error, %v", err)
+			}
+
+			got, err := ApplySetRequest(tt.inSchema(), tt.inRoot, tt.inReq, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ApplySetRequest: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				if got := status.Code(err); got != tt.wantErrCode {
+					t.Errorf("ApplySetRequest: got error code %v, want %v", got, tt.wantErrCode)
+				}
+				if diff := cmp.Diff(orig, tt.inRoot); diff != "" {
+					t.Errorf("ApplySetRequest: root was modified after error, diff(-want, +got):\n%s", diff)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, tt.inRoot); diff != "" {
+				t.Errorf("ApplySetRequest: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantResults, got.GetResponse(), protocmp.Transform()); diff != "" {
+				t.Errorf("ApplySetRequest: did not get expected UpdateResults, diff(-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.inReq.GetPrefix(), got.GetPrefix(), protocmp.Transform()); diff != "" {
+				t.Errorf("ApplySetRequest: did not get expected prefix, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// StreamApplier maintains a GoStruct data tree that mirrors the state of a
+// target, by applying the Notifications received within a stream of gNMI
+// SubscribeResponses to it. Alongside the data tree, the timestamp, origin and
+// target of the Notification that last updated each leaf is recorded within a
+// ygot.LeafMetadataStore. Where the root has a struct-level annotation field,
+// the LeafMetadataStore is stored within it, such that the recorded timestamps
+// are used when the root is rendered using ygot.TogNMINotifications.
+//
+// A StreamApplier is not safe for concurrent use, such that the root that it
+// returns must not be accessed whilst a response is being applied.
+type StreamApplier struct {
+	// schema is the schema of the root GoStruct.
+	schema *yang.Entry
+	// root is the GoStruct to which Notifications are applied.
+	root ygot.ValidatedGoStruct
+	// metadata stores the metadata of the last update to each leaf.
+	metadata *ygot.LeafMetadataStore
+	// synced indicates whether a sync_response has been received.
+	synced bool
+}
+
+// NewStreamApplier returns a StreamApplier that applies Notifications to the
+// root of the supplied Schema, which must be the generated fakeroot. Where the
+// root is already populated, its existing contents are retained, along with
+// any LeafMetadataStore within its struct-level annotation field.
+func NewStreamApplier(s *Schema) (*StreamApplier, error) {
+	if s == nil || s.Root == nil || util.IsValueNil(s.Root) || s.SchemaTree == nil {
+		return nil, errors.New("schema must have a non-nil root and schema tree")
+	}
+	rs := s.RootSchema()
+	if rs == nil {
+		return nil, fmt.Errorf("cannot find schema for root type %T", s.Root)
+	}
+	a := &StreamApplier{
+		schema:   rs,
+		root:     s.Root,
+		metadata: ygot.LeafMetadataFromGoStruct(s.Root),
+	}
+	if a.metadata == nil {
+		a.resetMetadata()
+	}
+	return a, nil
+}
+
+// resetMetadata replaces the LeafMetadataStore of the StreamApplier with an
+// empty one, storing it within the root where possible.
+func (a *StreamApplier) resetMetadata() {
+	a.metadata = ygot.NewLeafMetadataStore()
+	// Roots without a struct-level annotation field retain the
+	// LeafMetadataStore only within the StreamApplier.
+	_ = ygot.AttachLeafMetadata(a.root, a.metadata)
+}
+
+// Root returns the root of the data tree to which Notifications are applied.
+// The returned value must be re-read after an atomic Notification for the
+// root, or a deletion of the root, is applied, since these replace the root.
+func (a *StreamApplier) Root() ygot.ValidatedGoStruct {
+	return a.root
+}
+
+// Synced reports whether a sync_response has been received, indicating that
+// the data tree contains the full initial state of the subscription.
+func (a *StreamApplier) Synced() bool {
+	return a.synced
+}
+
+// LeafMetadata returns the LeafMetadataStore that records the metadata of
+// the Notification that last updated each leaf.
+func (a *StreamApplier) LeafMetadata() *ygot.LeafMetadataStore {
+	return a.metadata
+}
+
+// Timestamp returns the timestamp of the Notification that last updated the
+// leaf at the supplied path, and whether there is such a timestamp.
+func (a *StreamApplier) Timestamp(path *gpb.Path) (int64, bool) {
+	md, ok := a.metadata.Get(stripOrigin(path))
+	if !ok {
+		return 0, false
+	}
+	return md.Timestamp, true
+}
+
+// ApplySubscribeResponse applies the supplied SubscribeResponse to the data
+// tree. Updates are applied using ApplyNotification, and sync_responses are
+// recorded such that they are reported by Synced. An error is returned if
+// the response contains an error.
+func (a *StreamApplier) ApplySubscribeResponse(r *gpb.SubscribeResponse) error {
+	switch v := r.GetResponse().(type) {
+	case *gpb.SubscribeResponse_Update:
+		return a.ApplyNotification(v.Update)
+	case *gpb.SubscribeResponse_SyncResponse:
+		a.synced = a.synced || v.SyncResponse
+		return nil
+	//lint:ignore SA1019 Specifically handling deprecated gNMI Error fields.
+	case *gpb.SubscribeResponse_Error:
+		return fmt.Errorf("received error in SubscribeResponse, %v", v.Error)
+	}
+	return fmt.Errorf("unhandled SubscribeResponse type %T", r.GetResponse())
+}
+
+// ApplyNotification applies the supplied Notification to the data tree. The
+// paths of its deletes and updates are interpreted relative to its prefix,
+// and the deletes are applied before the updates. Where the Notification is
+// atomic, its updates replace the entire subtree at its prefix. The
+// timestamp of the Notification is recorded for each leaf that it updates.
+//
+// All updates and deletes are attempted, such that an error for one of them
+// does not prevent the remaining ones from being applied. The errors that
+// occur are returned.
+func (a *StreamApplier) ApplyNotification(n *gpb.Notification) error {
+	if n == nil {
+		return errors.New("nil Notification")
+	}
+
+	var errs util.Errors
+	prefix := stripOrigin(n.GetPrefix())
+	if n.GetAtomic() {
+		if err := a.delete(prefix); err != nil {
+			errs = util.AppendErr(errs, err)
+		}
+	}
+
+	for _, d := range n.GetDelete() {
+		if err := a.delete(joinPaths(prefix, d)); err != nil {
+			errs = util.AppendErr(errs, err)
+		}
+	}
+
+	for _, u := range n.GetUpdate() {
+		path := joinPaths(prefix, u.GetPath())
+		ps, err := ygot.PathToString(path)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("invalid update path %v, %v", u.GetPath(), err))
+			continue
+		}
+		if err := SetNode(a.schema, a.root, path, u.GetVal(), &InitMissingElements{}); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("cannot apply update for %s, %v", ps, err))
+			continue
+		}
+		origin := n.GetPrefix().GetOrigin()
+		if o := u.GetPath().GetOrigin(); o != "" {
+			origin = o
+		}
+		for _, lp := range a.updatedLeaves(path, u.GetVal()) {
+			md := &ygot.LeafMetadata{
+				Timestamp: n.GetTimestamp(),
+				Origin:    origin,
+				Target:    n.GetPrefix().GetTarget(),
+			}
+			if err := a.metadata.Set(lp, md); err != nil {
+				errs = util.AppendErr(errs, err)
+			}
+		}
+	}
+
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// updatedLeaves returns the paths of the leaves that are set by the update
+// with value val at the supplied path. Where val is a JSON value, which may
+// hold a container or list, the leaves are found by applying the update to
+// an empty root, and rendering the leaves within it. The keys of the list
+// entries along path, which are created alongside the entries, are not set by
+// the update, and so are excluded. Otherwise, the path itself is the only leaf
+// that is set.
+func (a *StreamApplier) updatedLeaves(path *gpb.Path, val *gpb.TypedValue) []*gpb.Path {
+	if !isJSONTypedValue(val) {
+		return []*gpb.Path{path}
+	}
+	newRoot := func() ygot.GoStruct {
+		return reflect.New(reflect.TypeOf(a.root).Elem()).Interface().(ygot.GoStruct)
+	}
+
+	root := newRoot()
+	if err := SetNode(a.schema, root, path, val, &InitMissingElements{}); err != nil {
+		return []*gpb.Path{path}
+	}
+	leaves, err := renderedLeaves(root)
+	if err != nil {
+		return []*gpb.Path{path}
+	}
+
+	// Creating the last list entry along path also creates the entries,
+	// and containers, before it.
+	last := -1
+	for i, e := range path.GetElem() {
+		if len(e.GetKey()) != 0 {
+			last = i
+		}
+	}
+	if last == -1 {
+		return leaves
+	}
+	entries := newRoot()
+	if _, _, err := GetOrCreateNode(a.schema, entries, &gpb.Path{Elem: path.GetElem()[:last+1]}); err != nil {
+		return leaves
+	}
+	keyLeaves, err := renderedLeaves(entries)
+	if err != nil {
+		return leaves
+	}
+	keys := map[string]bool{}
+	for _, p := range keyLeaves {
+		if ps, err := ygot.PathToString(p); err == nil {
+			keys[ps] = true
+		}
+	}
+	var set []*gpb.Path
+	for _, p := range leaves {
+		if ps, err := ygot.PathToString(p); err == nil && !keys[ps] {
+			set = append(set, p)
+		}
+	}
+	return set
+}
+
+// renderedLeaves returns the paths of the leaves that are populated within
+// the supplied GoStruct.
+func renderedLeaves(s ygot.GoStruct) ([]*gpb.Path, error) {
+	ns, err := ygot.TogNMINotifications(s, 0, ygot.GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		return nil, err
+	}
+	var paths []*gpb.Path
+	for _, n := range ns {
+		for _, u := range n.GetUpdate() {
+			paths = append(paths, joinPaths(n.GetPrefix(), u.GetPath()))
+		}
+	}
+	return paths, nil
+}
+
+// delete removes the subtree at the supplied path from the data tree, along
+// with the metadata of the leaves within it. Where the path is the root,
+// the root is replaced with an empty GoStruct of the same type.
+func (a *StreamApplier) delete(path *gpb.Path) error {
+	if len(path.GetElem()) == 0 {
+		a.root = reflect.New(reflect.TypeOf(a.root).Elem()).Interface().(ygot.ValidatedGoStruct)
+		a.resetMetadata()
+		return nil
+	}
+
+	if err := DeleteNode(a.schema, a.root, path); err != nil {
+		ps, _ := ygot.PathToString(path)
+		return fmt.Errorf("cannot apply delete for %s, %v", ps, err)
+	}
+	return a.metadata.Delete(path)
+}
+
+// joinPaths returns the path formed by appending the elements of path to
+// those of prefix.
+func joinPaths(prefix, path *gpb.Path) *gpb.Path {
+	p := &gpb.Path{}
+	p.Elem = append(p.Elem, prefix.GetElem()...)
+	p.Elem = append(p.Elem, path.GetElem()...)
+	return p
+}
+
+// stripOrigin returns a copy of the supplied path without its origin and
+// target, such that paths within a single data tree can be compared.
+func stripOrigin(path *gpb.Path) *gpb.Path {
+	return &gpb.Path{Elem: path.GetElem()}
+}
@@ -0,0 +1,392 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+type streamTestRoot struct {
+	ΛMetadata []ygot.Annotation               `path:"@" ygotAnnotation:"true"`
+	Interface map[string]*streamTestInterface `path:"interfaces/interface"`
+	Hostname  *string                         `path:"system/hostname"`
+	Server    []string                        `path:"system/server"`
+}
+
+func (*streamTestRoot) IsYANGGoStruct()                         {}
+func (*streamTestRoot) Validate(...ygot.ValidationOption) error { return nil }
+func (*streamTestRoot) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+
+type streamTestInterface struct {
+	Name *string `path:"config/name|name"`
+	Mtu  *uint16 `path:"config/mtu"`
+}
+
+func (*streamTestInterface) IsYANGGoStruct() {}
+
+func (t *streamTestInterface) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.Name == nil {
+		return nil, fmt.Errorf("nil value for key Name")
+	}
+	return map[string]interface{}{"name": *t.Name}, nil
+}
+
+// streamTestSchema returns a Schema whose root is an empty streamTestRoot.
+func streamTestSchema() *Schema {
+	root := &yang.Entry{
+		Name:       "device",
+		Kind:       yang.DirectoryEntry,
+		Annotation: map[string]interface{}{"isFakeRoot": true},
+		Dir: map[string]*yang.Entry{
+			"interfaces": {
+				Name: "interfaces",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"interface": {
+						Name:     "interface",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Key:      "name",
+						Config:   yang.TSTrue,
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name: "name",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ystring},
+							},
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir: map[string]*yang.Entry{
+									"name": {
+										Name: "name",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ystring},
+									},
+									"mtu": {
+										Name: "mtu",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Yuint16},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"system": {
+				Name: "system",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"hostname": {
+						Name: "hostname",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"server": {
+						Name:     "server",
+						Kind:     yang.LeafEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Type:     &yang.YangType{Kind: yang.Ystring},
+					},
+				},
+			},
+		},
+	}
+	addParents(root)
+	return &Schema{
+		Root:       &streamTestRoot{},
+		SchemaTree: map[string]*yang.Entry{"streamTestRoot": root},
+		Unmarshal:  func([]byte, ygot.GoStruct, ...UnmarshalOpt) error { return nil },
+	}
+}
+
+func stringUpdate(path, val string) *gpb.Update {
+	return &gpb.Update{
+		Path: mustPath(path),
+		Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: val}},
+	}
+}
+
+func uintUpdate(path string, val uint64) *gpb.Update {
+	return &gpb.Update{
+		Path: mustPath(path),
+		Val:  &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: val}},
+	}
+}
+
+func TestStreamApplier(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inResponses      []*gpb.SubscribeResponse
+		want             *streamTestRoot
+		wantSynced       bool
+		wantTimestamps   map[string]int64
+		wantErrSubstring string
+	}{{
+		desc: "updates with prefix",
+		inResponses: []*gpb.SubscribeResponse{{
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 42,
+				Prefix:    mustPath("/interfaces/interface[name=eth0]"),
+				Update: []*gpb.Update{
+					stringUpdate("name", "eth0"),
+					stringUpdate("config/name", "eth0"),
+					uintUpdate("config/mtu", 1500),
+				},
+			}},
+		}, {
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 43,
+				Update: []*gpb.Update{
+					stringUpdate("/system/hostname", "router"),
+					{
+						Path: mustPath("/system/server"),
+						Val: &gpb.TypedValue{Value: &gpb.TypedValue_LeaflistVal{LeaflistVal: &gpb.ScalarArray{
+							Element: []*gpb.TypedValue{
+								{Value: &gpb.TypedValue_StringVal{StringVal: "a"}},
+								{Value: &gpb.TypedValue_StringVal{StringVal: "b"}},
+							},
+						}}},
+					},
+				},
+			}},
+		}, {
+			Response: &gpb.SubscribeResponse_SyncResponse{SyncResponse: true},
+		}},
+		want: &streamTestRoot{
+			Interface: map[string]*streamTestInterface{
+				"eth0": {Name: ygot.String("eth0"), Mtu: ygot.Uint16(1500)},
+			},
+			Hostname: ygot.String("router"),
+			Server:   []string{"a", "b"},
+		},
+		wantSynced: true,
+		wantTimestamps: map[string]int64{
+			"/interfaces/interface[name=eth0]/name":        42,
+			"/interfaces/interface[name=eth0]/config/name": 42,
+			"/interfaces/interface[name=eth0]/config/mtu":  42,
+			"/system/hostname":                             43,
+			"/system/server":                               43,
+		},
+	}, {
+		desc: "JSON updates record each leaf that is set",
+		inResponses: []*gpb.SubscribeResponse{{
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 42,
+				Update: []*gpb.Update{
+					stringUpdate("/interfaces/interface[name=eth0]/config/name", "eth0"),
+					uintUpdate("/interfaces/interface[name=eth0]/config/mtu", 1500),
+				},
+			}},
+		}, {
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 43,
+				Update: []*gpb.Update{{
+					Path: mustPath("/interfaces/interface[name=eth0]"),
+					Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"config": {"mtu": 9000}}`)}},
+				}, {
+					Path: mustPath("/interfaces/interface"),
+					Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonVal{JsonVal: []byte(`[{"name": "eth1", "config": {"name": "eth1"}}]`)}},
+				}},
+			}},
+		}},
+		want: &streamTestRoot{
+			Interface: map[string]*streamTestInterface{
+				"eth0": {Name: ygot.String("eth0"), Mtu: ygot.Uint16(9000)},
+				"eth1": {Name: ygot.String("eth1")},
+			},
+		},
+		wantTimestamps: map[string]int64{
+			"/interfaces/interface[name=eth0]/config/name": 42,
+			"/interfaces/interface[name=eth0]/config/mtu":  43,
+			"/interfaces/interface[name=eth1]/name":        43,
+			"/interfaces/interface[name=eth1]/config/name": 43,
+		},
+	}, {
+		desc: "later updates override earlier values and timestamps",
+		inResponses: []*gpb.SubscribeResponse{{
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 42,
+				Update:    []*gpb.Update{stringUpdate("/system/hostname", "router")},
+			}},
+		}, {
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 84,
+				Update:    []*gpb.Update{stringUpdate("/system/hostname", "switch")},
+			}},
+		}},
+		want:           &streamTestRoot{Hostname: ygot.String("switch")},
+		wantTimestamps: map[string]int64{"/system/hostname": 84},
+	}, {
+		desc: "deletes applied before updates",
+		inResponses: []*gpb.SubscribeResponse{{
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 42,
+				Update: []*gpb.Update{
+					stringUpdate("/interfaces/interface[name=eth0]/config/name", "eth0"),
+					uintUpdate("/interfaces/interface[name=eth0]/config/mtu", 1500),
+					stringUpdate("/interfaces/interface[name=eth1]/config/name", "eth1"),
+					stringUpdate("/system/hostname", "router"),
+				},
+			}},
+		}, {
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 43,
+				Prefix:    mustPath("/interfaces"),
+				Delete:    []*gpb.Path{mustPath("interface[name=eth0]"), mustPath("interface[name=eth1]")},
+				Update:    []*gpb.Update{stringUpdate("interface[name=eth1]/config/name", "eth1")},
+			}},
+		}},
+		want: &streamTestRoot{
+			Interface: map[string]*streamTestInterface{
+				"eth1": {Name: ygot.String("eth1")},
+			},
+			Hostname: ygot.String("router"),
+		},
+		wantTimestamps: map[string]int64{
+			"/interfaces/interface[name=eth1]/config/name": 43,
+			"/system/hostname": 42,
+		},
+	}, {
+		desc: "atomic notification replaces subtree at prefix",
+		inResponses: []*gpb.SubscribeResponse{{
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 42,
+				Update: []*gpb.Update{
+					stringUpdate("/interfaces/interface[name=eth0]/config/name", "eth0"),
+					uintUpdate("/interfaces/interface[name=eth0]/config/mtu", 1500),
+				},
+			}},
+		}, {
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 43,
+				Atomic:    true,
+				Prefix:    mustPath("/interfaces/interface[name=eth0]"),
+				Update:    []*gpb.Update{stringUpdate("config/name", "eth0")},
+			}},
+		}},
+		want: &streamTestRoot{
+			Interface: map[string]*streamTestInterface{
+				"eth0": {Name: ygot.String("eth0")},
+			},
+		},
+		wantTimestamps: map[string]int64{
+			"/interfaces/interface[name=eth0]/config/name": 43,
+		},
+	}, {
+		desc: "delete of root",
+		inResponses: []*gpb.SubscribeResponse{{
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 42,
+				Update:    []*gpb.Update{stringUpdate("/system/hostname", "router")},
+			}},
+		}, {
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 43,
+				Delete:    []*gpb.Path{{}},
+			}},
+		}},
+		want:           &streamTestRoot{},
+		wantTimestamps: map[string]int64{},
+	}, {
+		desc: "invalid update is reported, and others are applied",
+		inResponses: []*gpb.SubscribeResponse{{
+			Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+				Timestamp: 42,
+				Update: []*gpb.Update{
+					stringUpdate("/system/missing", "value"),
+					stringUpdate("/system/hostname", "router"),
+				},
+			}},
+		}},
+		want:             &streamTestRoot{Hostname: ygot.String("router")},
+		wantTimestamps:   map[string]int64{"/system/hostname": 42},
+		wantErrSubstring: "cannot apply update for /system/missing",
+	}, {
+		desc: "error response",
+		inResponses: []*gpb.SubscribeResponse{{
+			//lint:ignore SA1019 Specifically testing deprecated gNMI Error fields.
+			Response: &gpb.SubscribeResponse_Error{Error: &gpb.Error{Message: "oops"}},
+		}},
+		want:             &streamTestRoot{},
+		wantTimestamps:   map[string]int64{},
+		wantErrSubstring: "received error in SubscribeResponse",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			a, err := NewStreamApplier(streamTestSchema())
+			if err != nil {
+				t.Fatalf("NewStreamApplier: got unexpected error, %v", err)
+			}
+
+			var gotErr error
+			for _, r := range tt.inResponses {
+				if err := a.ApplySubscribeResponse(r); err != nil {
+					gotErr = err
+				}
+			}
+			if diff := errdiff.Substring(gotErr, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ApplySubscribeResponse: did not get expected error, %s", diff)
+			}
+
+			if diff := cmp.Diff(tt.want, a.Root(), cmpopts.IgnoreFields(streamTestRoot{}, "ΛMetadata")); diff != "" {
+				t.Errorf("Root: did not get expected data tree, (-want, +got):\n%s", diff)
+			}
+			if got := ygot.LeafMetadataFromGoStruct(a.Root()); got != a.LeafMetadata() {
+				t.Errorf("LeafMetadataFromGoStruct: did not get the StreamApplier's metadata, got: %v, want: %v", got, a.LeafMetadata())
+			}
+			if got := a.Synced(); got != tt.wantSynced {
+				t.Errorf("Synced: got %v, want %v", got, tt.wantSynced)
+			}
+
+			for p, want := range tt.wantTimestamps {
+				if got, ok := a.Timestamp(mustPath(p)); !ok || got != want {
+					t.Errorf("Timestamp(%s): got (%d, %v), want (%d, true)", p, got, ok, want)
+				}
+			}
+			if got := len(a.LeafMetadata().Leaves); got != len(tt.wantTimestamps) {
+				t.Errorf("did not get expected number of timestamps, got: %d, want: %d, leaves: %v", got, len(tt.wantTimestamps), a.LeafMetadata().Leaves)
+			}
+			for p, md := range a.LeafMetadata().Leaves {
+				if md.Notification != nil {
+					t.Errorf("metadata of %s: got Notification %v, want nil", p, md.Notification)
+				}
+			}
+		})
+	}
+}
+
+func TestNewStreamApplierErrors(t *testing.T) {
+	if _, err := NewStreamApplier(nil); err == nil {
+		t.Errorf("NewStreamApplier(nil): did not get expected error")
+	}
+	s := streamTestSchema()
+	s.SchemaTree = map[string]*yang.Entry{}
+	if _, err := NewStreamApplier(s); err == nil {
+		t.Errorf("NewStreamApplier with missing root schema: did not get expected error")
+	}
+}
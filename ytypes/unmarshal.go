@@ -17,9 +17,14 @@ package ytypes
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 // UnmarshalOpt is an interface used for any option to be supplied
@@ -39,6 +44,38 @@ type IgnoreExtraFields struct{}
 // IsUnmarshalOpt marks IgnoreExtraFields as a valid UnmarshalOpt.
 func (*IgnoreExtraFields) IsUnmarshalOpt() {}
 
+// CollectAllErrors is an unmarshal option that controls the behaviour of the
+// Unmarshal function when invalid values are found in the input JSON. By
+// default, Unmarshal returns the first error that it encounters. By specifying
+// the CollectAllErrors option, Unmarshal continues with the remainder of the
+// input JSON, populating the parent with all valid values, and returns a
+// util.Errors containing an *UnmarshalError for each invalid value.
+type CollectAllErrors struct{}
+
+// IsUnmarshalOpt marks CollectAllErrors as a valid UnmarshalOpt.
+func (*CollectAllErrors) IsUnmarshalOpt() {}
+
+// UnmarshalError is an error returned by Unmarshal for an invalid value in the
+// input JSON when the CollectAllErrors option is specified.
+type UnmarshalError struct {
+	// Path is the data tree path of the invalid value, including the keys
+	// of any list entries, relative to the schema supplied to Unmarshal.
+	Path *gpb.Path
+	// Value is the invalid JSON value.
+	Value interface{}
+	// Err is the error encountered unmarshalling Value.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *UnmarshalError) Error() string {
+	p, err := ygot.PathToString(e.Path)
+	if err != nil {
+		p = e.Path.String()
+	}
+	return fmt.Sprintf("%s: %v", p, e.Err)
+}
+
 // Unmarshal recursively unmarshals JSON data tree in value into the given
 // parent, using the given schema. Any values already in the parent that are
 // not present in value are preserved. If provided schema is a leaf or leaf
@@ -79,11 +116,13 @@ func unmarshalGeneric(schema *yang.Entry, parent interface{}, value interface{},
 	}
 	util.DbgPrint("Unmarshal value %v, type %T, into parent type %T, schema name %s", util.ValueStrDebug(value), value, parent, schema.Name)
 
-	if enc == GNMIEncoding && !(schema.IsLeaf() || schema.IsLeafList()) {
+	if enc == GNMIEncoding && !(schema.IsLeaf() || schema.IsLeafList() || util.IsAnydata(schema)) {
 		return errors.New("unmarshalling a non leaf node isn't supported in GNMIEncoding mode")
 	}
 
 	switch {
+	case util.IsAnydata(schema):
+		return unmarshalAnydata(schema, parent, value, enc)
 	case schema.IsLeaf():
 		return unmarshalLeaf(schema, parent, value, enc)
 	case schema.IsLeafList():
@@ -92,12 +131,82 @@ func unmarshalGeneric(schema *yang.Entry, parent interface{}, value interface{},
 		return unmarshalList(schema, parent, value, enc, opts...)
 	case schema.IsChoice():
 		return fmt.Errorf("cannot pass choice schema %s to Unmarshal", schema.Name)
-	case schema.IsContainer():
+	case schema.IsContainer(), util.IsRPCInputOrOutput(schema), util.IsNotification(schema):
 		return unmarshalContainer(schema, parent, value, enc, opts...)
 	}
 	return fmt.Errorf("unknown schema type for type %T, value %v", value, value)
 }
 
+// hasCollectAllErrors determines whether the supplied slice of UnmarshalOpts
+// contains the CollectAllErrors option.
+func hasCollectAllErrors(opts []UnmarshalOpt) bool {
+	for _, o := range opts {
+		if _, ok := o.(*CollectAllErrors); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixUnmarshalErrors returns the errors in err as UnmarshalErrors. The path
+// of any UnmarshalError in err is prefixed with the elements of prefix. Any
+// other error is assumed to have been returned when unmarshalling value, which
+// is found at path.
+func prefixUnmarshalErrors(err error, path, prefix []*gpb.PathElem, value interface{}) util.Errors {
+	errs, ok := err.(util.Errors)
+	if !ok {
+		errs = util.NewErrs(err)
+	}
+	var out util.Errors
+	for _, e := range errs {
+		ue, ok := e.(*UnmarshalError)
+		if !ok {
+			out = util.AppendErr(out, &UnmarshalError{Path: &gpb.Path{Elem: path}, Value: value, Err: e})
+			continue
+		}
+		elems := append(append([]*gpb.PathElem{}, prefix...), ue.Path.GetElem()...)
+		out = util.AppendErr(out, &UnmarshalError{Path: &gpb.Path{Elem: elems}, Value: ue.Value, Err: ue.Err})
+	}
+	return out
+}
+
+// pathElems returns the gNMI path elements corresponding to the data tree path
+// p, which may contain module prefixes.
+func pathElems(p []string) []*gpb.PathElem {
+	var elems []*gpb.PathElem
+	for _, e := range p {
+		elems = append(elems, &gpb.PathElem{Name: util.StripModulePrefix(e)})
+	}
+	return elems
+}
+
+// listEntryPathElem returns the gNMI path element of the entry of the list
+// with the given schema that is described by the JSON tree jt. The keys of the
+// path element are taken from the key leaves found in jt.
+func listEntryPathElem(schema *yang.Entry, jt map[string]interface{}) *gpb.PathElem {
+	e := &gpb.PathElem{Name: schema.Name}
+	if !util.IsKeyedList(schema) {
+		return e
+	}
+	for _, k := range strings.Fields(schema.Key) {
+		for jk, jv := range jt {
+			if util.StripModulePrefix(jk) != k {
+				continue
+			}
+			if e.Key == nil {
+				e.Key = map[string]string{}
+			}
+			switch v := jv.(type) {
+			case float64:
+				e.Key[k] = strconv.FormatFloat(v, 'f', -1, 64)
+			default:
+				e.Key[k] = fmt.Sprint(v)
+			}
+		}
+	}
+	return e
+}
+
 // hasIgnoreExtraFields determines whether the supplied slice of UnmarshalOpts contains
 // the IgnoreExtraFields option.
 func hasIgnoreExtraFields(opts []UnmarshalOpt) bool {
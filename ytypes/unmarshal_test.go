@@ -15,9 +15,16 @@
 package ytypes
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 func TestUnmarshal(t *testing.T) {
@@ -79,3 +86,86 @@ func TestUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectAllErrors(t *testing.T) {
+	in := `{
+  "config": {"name": "dev"},
+  "state": {"name": "other"},
+  "settings": {"mtu": 70000},
+  "interfaces": {"intf": [
+    {"name": "eth0", "config": {"enabled": "yes"}, "bogus": 1},
+    {"name": "eth1", "config": {"enabled": true}},
+    {"config": {"enabled": true}}
+  ]},
+  "neighbor": [{"address": 42}, {"address": "192.0.2.1"}],
+  "zzz": true
+}`
+	var jsonTree interface{}
+	if err := json.Unmarshal([]byte(in), &jsonTree); err != nil {
+		t.Fatalf("json.Unmarshal: got unexpected error, %v", err)
+	}
+
+	type pathValue struct {
+		Path  string
+		Value interface{}
+	}
+	wantErrs := []pathValue{
+		{Path: "/config/name"},
+		{Path: "/settings/mtu", Value: float64(70000)},
+		{Path: "/interfaces/intf[name=eth0]/config/enabled", Value: "yes"},
+		{Path: "/interfaces/intf[name=eth0]/bogus", Value: float64(1)},
+		{Path: "/interfaces/intf", Value: map[string]interface{}{"config": map[string]interface{}{"enabled": true}}},
+		{Path: "/neighbor/address", Value: float64(42)},
+		{Path: "/zzz", Value: true},
+	}
+	want := &streamRoot{
+		Settings: &streamSettings{},
+		Intf: map[string]*streamIntf{
+			"eth0": {Name: ygot.String("eth0")},
+			"eth1": {Name: ygot.String("eth1"), Enabled: ygot.Bool(true)},
+		},
+		Neighbor: []*streamNeighbor{{}, {Address: ygot.String("192.0.2.1")}},
+	}
+
+	got := &streamRoot{}
+	err := Unmarshal(streamSchema(), got, jsonTree, &CollectAllErrors{})
+	errs, ok := err.(util.Errors)
+	if !ok {
+		t.Fatalf("Unmarshal: got error %v (%T), want util.Errors", err, err)
+	}
+	var gotErrs []pathValue
+	for _, e := range errs {
+		ue, ok := e.(*UnmarshalError)
+		if !ok {
+			t.Fatalf("Unmarshal: got error %v (%T), want *UnmarshalError", e, e)
+		}
+		p, err := ygot.PathToString(ue.Path)
+		if err != nil {
+			t.Fatalf("cannot convert path %v to string, %v", ue.Path, err)
+		}
+		gotErrs = append(gotErrs, pathValue{Path: p, Value: ue.Value})
+	}
+	if diff := cmp.Diff(wantErrs, gotErrs); diff != "" {
+		t.Errorf("Unmarshal: did not get expected errors, diff(-want, +got):\n%s\nerrors: %v", diff, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unmarshal: did not get expected partial GoStruct, diff(-want, +got):\n%s", diff)
+	}
+
+	// Without the option, only the first error is returned.
+	if err := Unmarshal(streamSchema(), &streamRoot{}, jsonTree); err == nil {
+		t.Errorf("Unmarshal without CollectAllErrors: got nil error, want error")
+	} else if _, ok := err.(util.Errors); ok {
+		t.Errorf("Unmarshal without CollectAllErrors: got util.Errors %v, want single error", err)
+	}
+}
+
+func TestUnmarshalErrorString(t *testing.T) {
+	e := &UnmarshalError{
+		Path: &gpb.Path{Elem: []*gpb.PathElem{{Name: "interfaces"}, {Name: "interface", Key: map[string]string{"name": "eth0"}}, {Name: "mtu"}}},
+		Err:  fmt.Errorf("invalid value"),
+	}
+	if got, want := e.Error(), "/interfaces/interface[name=eth0]/mtu: invalid value"; got != want {
+		t.Errorf("Error(): got %s, want %s", got, want)
+	}
+}
@@ -24,28 +24,36 @@ import (
 )
 
 // getJSONTreeValForField returns the JSON subtree of the provided tree that
-// corresponds to any of the paths in struct field f.
-// If no such JSON subtree exists, it returns nil, nil.
+// corresponds to any of the paths in struct field f, along with the path at
+// which it was found.
+// If no such JSON subtree exists, it returns nil, nil, nil.
 // If more than one path has a JSON subtree, the function returns an error if
 // the two subtrees are unequal.
-func getJSONTreeValForField(parentSchema, schema *yang.Entry, f reflect.StructField, tree interface{}) (interface{}, error) {
+func getJSONTreeValForField(parentSchema, schema *yang.Entry, f reflect.StructField, tree interface{}) (interface{}, []string, error) {
 	ps, err := dataTreePaths(parentSchema, schema, f)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	return getJSONTreeValForPaths(tree, ps)
+}
+
+// getJSONTreeValForPaths returns the JSON subtree from tree that is found at
+// any of the given data tree paths, along with the path it was found at. An
+// error is returned if different values are found at more than one path.
+func getJSONTreeValForPaths(tree interface{}, ps [][]string) (interface{}, []string, error) {
 	var out interface{}
 	var outPath []string
 	for _, p := range ps {
 		if jr, ok := getJSONTreeValForPath(tree, p); ok {
 			if out != nil && !cmp.Equal(out, jr) {
-				return nil, fmt.Errorf("values at paths %v and %v are different: %v != %v", outPath, p, out, jr)
+				return nil, outPath, fmt.Errorf("values at paths %v and %v are different: %v != %v", outPath, p, out, jr)
 			}
 			out = jr
 			outPath = p
 		}
 	}
 
-	return out, nil
+	return out, outPath, nil
 }
 
 // getJSONTreeValForPath returns a JSON subtree from tree at the given path from
@@ -17,6 +17,7 @@ package ytypes
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/openconfig/goyang/pkg/yang"
@@ -212,6 +213,15 @@ func removeNonDataPathElements(parentSchema, schema *yang.Entry, paths [][]strin
 // This function is used to verify that the jsonTree does not contain any elements
 // in the first level that do not have data paths found in the schema.
 func checkDataTreeAgainstPaths(jsonTree map[string]interface{}, dataPaths [][]string) error {
+	if fs := unexpectedDataTreeFields(jsonTree, dataPaths); len(fs) > 0 {
+		return fmt.Errorf("JSON contains unexpected field %s", fs[0])
+	}
+	return nil
+}
+
+// unexpectedDataTreeFields returns the sorted first level keys of jsonTree that
+// do not point to any of the given schema paths.
+func unexpectedDataTreeFields(jsonTree map[string]interface{}, dataPaths [][]string) []string {
 	// Go over all first level JSON tree map keys to make sure they all point
 	// to valid schema paths.
 	pm := map[string]bool{}
@@ -219,13 +229,14 @@ func checkDataTreeAgainstPaths(jsonTree map[string]interface{}, dataPaths [][]st
 		pm[util.StripModulePrefix(sp[0])] = true
 	}
 	util.DbgSchema("check dataPaths %v against dataTree %v\n", pm, jsonTree)
+	var fs []string
 	for jf := range jsonTree {
 		if !pm[util.StripModulePrefix(jf)] {
-			return fmt.Errorf("JSON contains unexpected field %s", jf)
+			fs = append(fs, jf)
 		}
 	}
-
-	return nil
+	sort.Strings(fs)
+	return fs
 }
 
 // removeRootPrefix removes the root prefix from root schema entities e.g.
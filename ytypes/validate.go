@@ -72,10 +72,13 @@ func Validate(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOpti
 	// explicitly returning an error.
 	var leafrefOpt *LeafrefOptions
 	var customValidOpt *CustomValidationOptions
+	var mustWhenOpt *MustWhenOptions
 	for _, o := range opts {
 		switch v := o.(type) {
 		case *LeafrefOptions:
 			leafrefOpt = v
+		case *MustWhenOptions:
+			mustWhenOpt = v
 		case *CustomValidationOptions:
 			customValidOpt = v
 		}
@@ -95,24 +98,48 @@ func Validate(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOpti
 			}
 		}
 	}
+	// The XPath expressions of must and when statements can refer to any
+	// node in the tree, so are evaluated once for the subtree rooted at
+	// the struct that validation starts from, rather than for each
+	// descendant struct.
+	if _, ok := value.(ygot.GoStruct); ok {
+		errs = util.AppendErrs(errs, ValidateMustWhenData(schema, value, mustWhenOpt))
+	}
+
+	return util.AppendErrs(errs, validate(schema, value))
+}
+
+// validate recursively validates the value of the given data tree struct
+// against the given schema, without the validation of leafrefs, and must and
+// when statements, that Validate carries out for the entire data tree.
+func validate(schema *yang.Entry, value interface{}) util.Errors {
+	// Nil value means the field is unset.
+	if util.IsValueNil(value) {
+		return nil
+	}
+	if schema == nil {
+		return util.NewErrs(fmt.Errorf("nil schema for type %T, value %v", value, value))
+	}
 
 	util.DbgPrint("Validate with value %v, type %T, schema name %s", util.ValueStr(value), value, schema.Name)
 
 	switch {
+	case util.IsAnydata(schema):
+		return validateAny(schema, value)
 	case schema.IsLeaf():
-		return util.AppendErrs(errs, validateLeaf(schema, value))
-	case schema.IsContainer():
+		return validateLeaf(schema, value)
+	case schema.IsContainer(), util.IsRPCInputOrOutput(schema), util.IsNotification(schema):
 		gsv, ok := value.(ygot.GoStruct)
 		if !ok {
-			return util.AppendErr(errs, fmt.Errorf("type %T is not a GoStruct for schema %s", value, schema.Name))
+			return util.NewErrs(fmt.Errorf("type %T is not a GoStruct for schema %s", value, schema.Name))
 		}
-		return util.AppendErrs(errs, validateContainer(schema, gsv))
+		return validateContainer(schema, gsv)
 	case schema.IsLeafList():
-		return util.AppendErrs(errs, validateLeafList(schema, value))
+		return validateLeafList(schema, value)
 	case schema.IsList():
-		return util.AppendErrs(errs, validateList(schema, value))
+		return validateList(schema, value)
 	case schema.IsChoice():
-		return util.AppendErrs(errs, util.NewErrs(fmt.Errorf("cannot pass choice schema %s to Validate", schema.Name)))
+		return util.NewErrs(fmt.Errorf("cannot pass choice schema %s to Validate", schema.Name))
 	}
-	return util.AppendErrs(errs, util.NewErrs(fmt.Errorf("unknown schema type for type %T, value %v", value, value)))
+	return util.NewErrs(fmt.Errorf("unknown schema type for type %T, value %v", value, value))
 }
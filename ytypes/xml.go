@@ -0,0 +1,312 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// xmlElement is an element of an XML document.
+type xmlElement struct {
+	// name is the namespace-qualified name of the element.
+	name xml.Name
+	// prefixes maps the namespace prefixes that are in scope at the element
+	// to their namespaces.
+	prefixes map[string]string
+	// children is the set of child elements of the element, in document
+	// order.
+	children []*xmlElement
+	// text is the character data that the element contains.
+	text string
+}
+
+// UnmarshalXML unmarshals the XML document data, encoded following the rules
+// of RFC7950 Section 7, into the parent GoStruct using the supplied schema,
+// which must be the schema of parent. Where schema is the root of the schema
+// tree, the document consists of an element for each top-level data node,
+// which may be wrapped in a NETCONF <config> or <data> element. Otherwise,
+// the document consists of a single element corresponding to parent. Any
+// values already in the parent that are not present in data are preserved.
+//
+// The modules that elements belong to are determined from their namespaces,
+// which are resolved using util.ModuleNamespaces. The XML document is
+// converted to an RFC7951 JSON tree, which is unmarshalled using Unmarshal
+// with the supplied options. Where IgnoreExtraFields is specified, elements
+// that do not correspond to a data node of the schema are discarded.
+func UnmarshalXML(schema *yang.Entry, parent interface{}, data []byte, opts ...UnmarshalOpt) error {
+	if schema == nil {
+		return fmt.Errorf("nil schema for parent type %T", parent)
+	}
+	nss := util.ModuleNamespaces(schema)
+	if len(nss) == 0 {
+		return fmt.Errorf("schema %s does not specify the namespaces of its modules", schema.Name)
+	}
+	mods := map[string]string{}
+	for m, ns := range nss {
+		mods[ns] = m
+	}
+
+	elems, err := parseXML(data)
+	if err != nil {
+		return err
+	}
+
+	d := &xmlDecoder{modules: mods, ignoreExtra: hasIgnoreExtraFields(opts)}
+	var jsonTree map[string]interface{}
+	if schema.Parent == nil || util.IsFakeRoot(schema) {
+		// NETCONF wraps the contents of a datastore in a <config> or <data>
+		// element, which does not correspond to a data node.
+		if len(elems) == 1 && elems[0].name.Space == ygot.NETCONFNamespace && (elems[0].name.Local == "config" || elems[0].name.Local == "data") {
+			elems = elems[0].children
+		}
+		if jsonTree, err = d.children(elems, schema, ""); err != nil {
+			return err
+		}
+	} else {
+		if len(elems) != 1 || elems[0].name.Local != schema.Name {
+			return fmt.Errorf("XML document must contain a single %s element", schema.Name)
+		}
+		if _, ok := mods[elems[0].name.Space]; !ok {
+			return fmt.Errorf("element %s has unknown namespace %q", schema.Name, elems[0].name.Space)
+		}
+		if jsonTree, err = d.children(elems[0].children, schema, elems[0].name.Space); err != nil {
+			return err
+		}
+	}
+
+	return Unmarshal(schema, parent, jsonTree, opts...)
+}
+
+// parseXML parses the XML document data, returning its top-level elements.
+func parseXML(data []byte) ([]*xmlElement, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	root := &xmlElement{prefixes: map[string]string{}}
+	stack := []*xmlElement{root}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse XML, %v", err)
+		}
+		cur := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			e := &xmlElement{name: t.Name, prefixes: cur.prefixes}
+			for _, a := range t.Attr {
+				if a.Name.Space != "xmlns" {
+					continue
+				}
+				// Namespace prefixes are copied on write, such that each
+				// element stores those in scope.
+				if len(e.prefixes) == len(cur.prefixes) {
+					e.prefixes = map[string]string{}
+					for p, ns := range cur.prefixes {
+						e.prefixes[p] = ns
+					}
+				}
+				e.prefixes[a.Name.Local] = a.Value
+			}
+			cur.children = append(cur.children, e)
+			stack = append(stack, e)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			cur.text += string(t)
+		}
+	}
+	return root.children, nil
+}
+
+// xmlDecoder stores the state used when converting an XML document to an
+// RFC7951 JSON tree.
+type xmlDecoder struct {
+	// modules maps XML namespaces to the name of the module that defines
+	// them.
+	modules map[string]string
+	// ignoreExtra specifies whether elements that do not correspond to a
+	// data node are discarded rather than causing an error.
+	ignoreExtra bool
+}
+
+// children returns the RFC7951 JSON object that corresponds to the supplied
+// elements, which are children of the data node with the supplied schema,
+// whose element has the namespace parentNS.
+func (d *xmlDecoder) children(elems []*xmlElement, schema *yang.Entry, parentNS string) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	for _, e := range elems {
+		cs := util.FirstChild(schema, []string{e.name.Local})
+		mod, ok := d.modules[e.name.Space]
+		if cs == nil || !ok {
+			if d.ignoreExtra {
+				continue
+			}
+			return nil, fmt.Errorf("element %s with namespace %q does not correspond to a data node of %s", e.name.Local, e.name.Space, schema.Name)
+		}
+
+		name := e.name.Local
+		if e.name.Space != parentNS {
+			name = fmt.Sprintf("%s:%s", mod, name)
+		}
+
+		switch {
+		case util.IsAnydata(cs):
+			v := anydataValue(e)
+			if _, ok := v.(string); ok && cs.Kind == yang.AnyDataEntry {
+				v = map[string]interface{}{}
+			}
+			out[name] = v
+		case cs.IsContainer() || cs.IsList():
+			v, err := d.children(e.children, cs, e.name.Space)
+			if err != nil {
+				return nil, err
+			}
+			if !cs.IsList() {
+				out[name] = v
+				continue
+			}
+			l, _ := out[name].([]interface{})
+			out[name] = append(l, v)
+		case cs.IsLeafList():
+			v, err := d.leafValue(e, cs)
+			if err != nil {
+				return nil, err
+			}
+			l, _ := out[name].([]interface{})
+			out[name] = append(l, v)
+		default:
+			v, err := d.leafValue(e, cs)
+			if err != nil {
+				return nil, err
+			}
+			out[name] = v
+		}
+	}
+	return out, nil
+}
+
+// anydataValue returns the RFC7951 JSON value of the contents of the element
+// e of an anydata or anyxml node, which are not described by the schema. An
+// element with child elements is converted to a JSON object whose members are
+// named by the child elements, and where a name is repeated, its member is an
+// array. An element without child elements is converted to its text.
+func anydataValue(e *xmlElement) interface{} {
+	if len(e.children) == 0 {
+		return strings.TrimSpace(e.text)
+	}
+	out := map[string]interface{}{}
+	for _, c := range e.children {
+		v := anydataValue(c)
+		switch ev := out[c.name.Local].(type) {
+		case nil:
+			out[c.name.Local] = v
+		case []interface{}:
+			out[c.name.Local] = append(ev, v)
+		default:
+			out[c.name.Local] = []interface{}{ev, v}
+		}
+	}
+	return out
+}
+
+// leafValue returns the RFC7951 JSON value of the leaf, or leaf-list entry,
+// with the supplied schema that is encoded by the element e. Where the leaf
+// is a union, the value of the first member type that the element's content
+// is valid for is returned.
+func (d *xmlDecoder) leafValue(e *xmlElement, schema *yang.Entry) (interface{}, error) {
+	if schema.Type == nil {
+		return nil, fmt.Errorf("leaf %s has nil type", schema.Name)
+	}
+	if rs, err := util.ResolveIfLeafRef(schema); err == nil && rs != nil {
+		schema = rs
+	}
+
+	var errs util.Errors
+	for _, t := range util.FlattenedTypes([]*yang.YangType{schema.Type}) {
+		v, err := d.scalarValue(e, t.Kind)
+		if err == nil {
+			return v, nil
+		}
+		errs = util.AppendErr(errs, err)
+	}
+	return nil, fmt.Errorf("invalid value %q for leaf %s: %v", e.text, schema.Name, errs)
+}
+
+// scalarValue returns the RFC7951 JSON value of the element e, whose content
+// is a value of the YANG type kind k.
+func (d *xmlDecoder) scalarValue(e *xmlElement, k yang.TypeKind) (interface{}, error) {
+	s := strings.TrimSpace(e.text)
+	switch k {
+	case yang.Yempty:
+		if s != "" {
+			return nil, fmt.Errorf("empty leaf has content %q", s)
+		}
+		return []interface{}{nil}, nil
+	case yang.Ybool:
+		return strconv.ParseBool(s)
+	case yang.Yint8, yang.Yint16, yang.Yint32:
+		bits, err := yangIntTypeBits(k)
+		if err != nil {
+			return nil, err
+		}
+		v, err := strconv.ParseInt(s, 10, bits)
+		return float64(v), err
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		bits, err := yangIntTypeBits(k)
+		if err != nil {
+			return nil, err
+		}
+		v, err := strconv.ParseUint(s, 10, bits)
+		return float64(v), err
+	case yang.Yint64:
+		_, err := strconv.ParseInt(s, 10, 64)
+		return s, err
+	case yang.Yuint64:
+		_, err := strconv.ParseUint(s, 10, 64)
+		return s, err
+	case yang.Ydecimal64:
+		_, err := strconv.ParseFloat(s, 64)
+		return s, err
+	case yang.Yidentityref:
+		// Identities are qualified with a namespace prefix in XML, and
+		// with the name of their module in RFC7951 JSON.
+		i := strings.Index(s, ":")
+		if i == -1 {
+			return s, nil
+		}
+		ns, ok := e.prefixes[s[:i]]
+		if !ok {
+			return nil, fmt.Errorf("identity %s has undeclared prefix %s", s, s[:i])
+		}
+		mod, ok := d.modules[ns]
+		if !ok {
+			return nil, fmt.Errorf("identity %s has unknown namespace %q", s, ns)
+		}
+		return fmt.Sprintf("%s:%s", mod, s[i+1:]), nil
+	case yang.Yenum, yang.Ybits, yang.Ybinary:
+		return s, nil
+	}
+	return e.text, nil
+}
@@ -0,0 +1,335 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// xmlIdentity is an identityref type used in the XML tests.
+type xmlIdentity int64
+
+func (xmlIdentity) ΛMap() map[string]map[int64]ygot.EnumDefinition {
+	return map[string]map[int64]ygot.EnumDefinition{
+		"xmlIdentity": {
+			1: {Name: "ETHERNET", DefiningModule: "m3"},
+		},
+	}
+}
+
+func (e xmlIdentity) String() string {
+	return ygot.EnumLogString(e, int64(e), "xmlIdentity")
+}
+
+func (xmlIdentity) IsYANGGoEnum() {}
+
+type xmlRoot struct {
+	Interface map[string]*xmlInterface `path:"interfaces/interface" module:"m1"`
+	Enabled   YANGEmpty                `path:"enabled" module:"m2"`
+}
+
+func (*xmlRoot) IsYANGGoStruct() {}
+
+type xmlInterface struct {
+	Name        *string     `path:"name" module:"m1"`
+	Mtu         *uint16     `path:"mtu" module:"m1"`
+	Counter     *uint64     `path:"counter" module:"m1"`
+	Type        xmlIdentity `path:"type" module:"m1"`
+	Address     []string    `path:"address" module:"m1"`
+	Description *string     `path:"description" module:"m2"`
+}
+
+func (*xmlInterface) IsYANGGoStruct() {}
+
+func (i *xmlInterface) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"name": *i.Name}, nil
+}
+
+// xmlSchema returns the schema of xmlRoot, which is a fakeroot whose
+// children are defined in the modules m1 and m2.
+func xmlSchema() *yang.Entry {
+	leaf := func(name string, k yang.TypeKind) *yang.Entry {
+		return &yang.Entry{Name: name, Kind: yang.LeafEntry, Type: &yang.YangType{Kind: k}}
+	}
+	address := leaf("address", yang.Ystring)
+	address.ListAttr = &yang.ListAttr{}
+
+	root := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Annotation: map[string]interface{}{
+			"isFakeRoot":        true,
+			"module-namespaces": map[string]string{"m1": "urn:m1", "m2": "urn:m2", "m3": "urn:m3"},
+		},
+		Dir: map[string]*yang.Entry{
+			"interfaces": {
+				Name: "interfaces",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"interface": {
+						Name:     "interface",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: &yang.ListAttr{},
+						Key:      "name",
+						Dir: map[string]*yang.Entry{
+							"name":        leaf("name", yang.Ystring),
+							"mtu":         leaf("mtu", yang.Yuint16),
+							"counter":     leaf("counter", yang.Yuint64),
+							"type":        leaf("type", yang.Yidentityref),
+							"address":     address,
+							"description": leaf("description", yang.Ystring),
+						},
+					},
+				},
+			},
+			"enabled": leaf("enabled", yang.Yempty),
+		},
+	}
+	populateParentField(nil, root)
+	return root
+}
+
+func TestUnmarshalXML(t *testing.T) {
+	schema := xmlSchema()
+	want := &xmlRoot{
+		Interface: map[string]*xmlInterface{
+			"eth0": {
+				Name:        ygot.String("eth0"),
+				Mtu:         ygot.Uint16(1500),
+				Counter:     ygot.Uint64(18446744073709551615),
+				Type:        1,
+				Address:     []string{"192.0.2.1", "192.0.2.2"},
+				Description: ygot.String("<uplink>"),
+			},
+			"eth1": {Name: ygot.String("eth1")},
+		},
+		Enabled: true,
+	}
+
+	tests := []struct {
+		desc             string
+		inSchema         *yang.Entry
+		inParent         ygot.GoStruct
+		inXML            string
+		inOpts           []UnmarshalOpt
+		want             ygot.GoStruct
+		wantErrSubstring string
+	}{{
+		desc:     "fakeroot",
+		inSchema: schema,
+		inParent: &xmlRoot{},
+		inXML: `<enabled xmlns="urn:m2"/>
+<interfaces xmlns="urn:m1">
+  <interface>
+    <name>eth0</name>
+    <mtu>1500</mtu>
+    <counter>18446744073709551615</counter>
+    <type xmlns:eth="urn:m3">eth:ETHERNET</type>
+    <address>192.0.2.1</address>
+    <address>192.0.2.2</address>
+    <description xmlns="urn:m2">&lt;uplink&gt;</description>
+  </interface>
+  <interface><name>eth1</name></interface>
+</interfaces>`,
+		want: want,
+	}, {
+		desc:     "fakeroot within NETCONF data element with prefixed names",
+		inSchema: schema,
+		inParent: &xmlRoot{},
+		inXML: `<data xmlns="urn:ietf:params:xml:ns:netconf:base:1.0" xmlns:a="urn:m1" xmlns:b="urn:m2" xmlns:c="urn:m3">
+  <b:enabled/>
+  <a:interfaces>
+    <a:interface>
+      <a:name>eth0</a:name>
+      <a:mtu>1500</a:mtu>
+      <a:counter>18446744073709551615</a:counter>
+      <a:type>c:ETHERNET</a:type>
+      <a:address>192.0.2.1</a:address>
+      <a:address>192.0.2.2</a:address>
+      <b:description>&lt;uplink&gt;</b:description>
+    </a:interface>
+    <a:interface><a:name>eth1</a:name></a:interface>
+  </a:interfaces>
+</data>`,
+		want: want,
+	}, {
+		desc:     "list entry",
+		inSchema: schema.Dir["interfaces"].Dir["interface"],
+		inParent: &xmlInterface{Description: ygot.String("preserved")},
+		inXML:    `<interface xmlns="urn:m1"><name>eth0</name><mtu>9000</mtu></interface>`,
+		want:     &xmlInterface{Name: ygot.String("eth0"), Mtu: ygot.Uint16(9000), Description: ygot.String("preserved")},
+	}, {
+		desc:     "unknown element ignored",
+		inSchema: schema,
+		inParent: &xmlRoot{},
+		inXML:    `<enabled xmlns="urn:m2"/><unknown xmlns="urn:m1"/><enabled xmlns="urn:m4"/>`,
+		inOpts:   []UnmarshalOpt{&IgnoreExtraFields{}},
+		want:     &xmlRoot{Enabled: true},
+	}, {
+		desc:             "unknown element",
+		inSchema:         schema,
+		inParent:         &xmlRoot{},
+		inXML:            `<unknown xmlns="urn:m1"/>`,
+		wantErrSubstring: "element unknown with namespace \"urn:m1\" does not correspond to a data node of device",
+	}, {
+		desc:             "unknown namespace",
+		inSchema:         schema,
+		inParent:         &xmlRoot{},
+		inXML:            `<enabled xmlns="urn:m4"/>`,
+		wantErrSubstring: "does not correspond to a data node",
+	}, {
+		desc:             "undeclared identity prefix",
+		inSchema:         schema,
+		inParent:         &xmlRoot{},
+		inXML:            `<interfaces xmlns="urn:m1"><interface><name>eth0</name><type>eth:ETHERNET</type></interface></interfaces>`,
+		wantErrSubstring: "identity eth:ETHERNET has undeclared prefix eth",
+	}, {
+		desc:             "out of range value",
+		inSchema:         schema,
+		inParent:         &xmlRoot{},
+		inXML:            `<interfaces xmlns="urn:m1"><interface><name>eth0</name><mtu>65536</mtu></interface></interfaces>`,
+		wantErrSubstring: "invalid value \"65536\" for leaf mtu",
+	}, {
+		desc:             "wrong element for list entry",
+		inSchema:         schema.Dir["interfaces"].Dir["interface"],
+		inParent:         &xmlInterface{},
+		inXML:            `<interfaces xmlns="urn:m1"/>`,
+		wantErrSubstring: "XML document must contain a single interface element",
+	}, {
+		desc:             "invalid XML",
+		inSchema:         schema,
+		inParent:         &xmlRoot{},
+		inXML:            `<enabled xmlns="urn:m2">`,
+		wantErrSubstring: "cannot parse XML",
+	}, {
+		desc:             "schema without namespaces",
+		inSchema:         &yang.Entry{Name: "device", Kind: yang.DirectoryEntry},
+		inParent:         &xmlRoot{},
+		inXML:            `<enabled xmlns="urn:m2"/>`,
+		wantErrSubstring: "does not specify the namespaces of its modules",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := UnmarshalXML(tt.inSchema, tt.inParent, []byte(tt.inXML), tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalXML: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.inParent); diff != "" {
+				t.Errorf("UnmarshalXML: did not get expected GoStruct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	schema := xmlSchema()
+	in := &xmlRoot{
+		Interface: map[string]*xmlInterface{
+			"eth0": {
+				Name:        ygot.String("eth0"),
+				Counter:     ygot.Uint64(42),
+				Type:        1,
+				Address:     []string{"192.0.2.1"},
+				Description: ygot.String("a & b"),
+			},
+		},
+		Enabled: true,
+	}
+
+	x, err := ygot.MarshalXML(in, schema, &ygot.XMLConfig{Indent: "  ", RootElement: "config", RootNamespace: ygot.NETCONFNamespace})
+	if err != nil {
+		t.Fatalf("MarshalXML: got unexpected error, %v", err)
+	}
+	got := &xmlRoot{}
+	if err := UnmarshalXML(schema, got, x); err != nil {
+		t.Fatalf("UnmarshalXML(%s): got unexpected error, %v", x, err)
+	}
+	if diff := cmp.Diff(in, got); diff != "" {
+		t.Errorf("UnmarshalXML(%s): did not get expected GoStruct, diff(-want, +got):\n%s", x, diff)
+	}
+}
+
+func TestXMLLeafValue(t *testing.T) {
+	union := &yang.Entry{
+		Name: "union",
+		Kind: yang.LeafEntry,
+		Type: &yang.YangType{Kind: yang.Yunion, Type: []*yang.YangType{{Kind: yang.Yint8}, {Kind: yang.Ybool}, {Kind: yang.Ystring}}},
+	}
+	d := &xmlDecoder{}
+
+	tests := []struct {
+		desc             string
+		inSchema         *yang.Entry
+		inText           string
+		want             interface{}
+		wantErrSubstring string
+	}{{
+		desc:     "union matching first member",
+		inSchema: union,
+		inText:   " -42 ",
+		want:     float64(-42),
+	}, {
+		desc:     "union matching second member",
+		inSchema: union,
+		inText:   "true",
+		want:     true,
+	}, {
+		desc:     "union matching last member",
+		inSchema: union,
+		inText:   "128",
+		want:     "128",
+	}, {
+		desc:     "decimal64",
+		inSchema: &yang.Entry{Name: "d", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ydecimal64}},
+		inText:   "4.2",
+		want:     "4.2",
+	}, {
+		desc:     "string with whitespace",
+		inSchema: &yang.Entry{Name: "s", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+		inText:   " a b ",
+		want:     " a b ",
+	}, {
+		desc:             "empty with content",
+		inSchema:         &yang.Entry{Name: "e", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Yempty}},
+		inText:           "x",
+		wantErrSubstring: "empty leaf has content",
+	}, {
+		desc:             "invalid int64",
+		inSchema:         &yang.Entry{Name: "i", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Yint64}},
+		inText:           "1.5",
+		wantErrSubstring: "invalid value \"1.5\" for leaf i",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := d.leafValue(&xmlElement{text: tt.inText}, tt.inSchema)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("leafValue: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("leafValue: did not get expected value, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
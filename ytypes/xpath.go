@@ -0,0 +1,812 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// This file contains a lexer and parser for XPath 1.0 expressions, as used
+// in YANG must and when statements and leafref paths. The grammar is defined
+// in https://www.w3.org/TR/1999/REC-xpath-19991116/ and the YANG-specific
+// use of XPath is described in https://tools.ietf.org/html/rfc7950#section-6.4.
+
+// xpathTokenKind is the type of a token within an XPath expression.
+type xpathTokenKind int
+
+const (
+	// xtEOF indicates the end of the expression.
+	xtEOF xpathTokenKind = iota
+	// xtNumber is a numeric literal, e.g., 42 or 4.2.
+	xtNumber
+	// xtLiteral is a string literal enclosed in single or double quotes.
+	xtLiteral
+	// xtNameTest is a name test within a step, e.g., foo, pfx:foo, pfx:* or *.
+	xtNameTest
+	// xtNodeType is a node type test, e.g., node or text.
+	xtNodeType
+	// xtFunctionName is the name of a function that is being called.
+	xtFunctionName
+	// xtAxisName is the name of an axis, e.g., child or ancestor.
+	xtAxisName
+	// xtOperator is an operator, e.g., and, =, / or |.
+	xtOperator
+	// xtVariable is a variable reference of the form $name.
+	xtVariable
+	// xtLParen is an opening parenthesis.
+	xtLParen
+	// xtRParen is a closing parenthesis.
+	xtRParen
+	// xtLBracket is an opening square bracket.
+	xtLBracket
+	// xtRBracket is a closing square bracket.
+	xtRBracket
+	// xtDot is the abbreviated self::node() step.
+	xtDot
+	// xtDotDot is the abbreviated parent::node() step.
+	xtDotDot
+	// xtAt is the abbreviated attribute axis specifier.
+	xtAt
+	// xtComma separates function arguments.
+	xtComma
+	// xtColonColon separates an axis name from a node test.
+	xtColonColon
+)
+
+// xpathToken is a single lexical token of an XPath expression.
+type xpathToken struct {
+	kind xpathTokenKind
+	// val is the string value of the token.
+	val string
+	// pos is the byte offset of the token within the expression.
+	pos int
+}
+
+// xpathNodeTypes is the set of node type names that can be used as a node test.
+var xpathNodeTypes = map[string]bool{
+	"node":                   true,
+	"text":                   true,
+	"comment":                true,
+	"processing-instruction": true,
+}
+
+// xpathAxes is the set of valid XPath axis names.
+var xpathAxes = map[string]bool{
+	"ancestor":           true,
+	"ancestor-or-self":   true,
+	"attribute":          true,
+	"child":              true,
+	"descendant":         true,
+	"descendant-or-self": true,
+	"following":          true,
+	"following-sibling":  true,
+	"namespace":          true,
+	"parent":             true,
+	"preceding":          true,
+	"preceding-sibling":  true,
+	"self":               true,
+}
+
+// lexXPath splits the expression expr into a slice of tokens, applying the
+// disambiguation rules of section 3.7 of the XPath 1.0 specification. It
+// returns an error if the expression contains invalid tokens.
+func lexXPath(expr string) ([]xpathToken, error) {
+	var toks []xpathToken
+
+	// precedingAllowsOperator reports whether the token preceding the
+	// current position means that * and NCNames must be treated as
+	// operators.
+	precedingAllowsOperator := func() bool {
+		if len(toks) == 0 {
+			return false
+		}
+		switch toks[len(toks)-1].kind {
+		case xtAt, xtColonColon, xtLParen, xtLBracket, xtComma, xtOperator:
+			return false
+		}
+		return true
+	}
+
+	// nextNonSpace returns the index of the next non-whitespace character
+	// at, or after, i.
+	nextNonSpace := func(i int) int {
+		for i < len(expr) && isXPathSpace(expr[i]) {
+			i++
+		}
+		return i
+	}
+
+	i := 0
+	for {
+		i = nextNonSpace(i)
+		if i >= len(expr) {
+			break
+		}
+		start := i
+		c := expr[i]
+		switch {
+		case c == '(':
+			toks = append(toks, xpathToken{kind: xtLParen, val: "(", pos: start})
+			i++
+		case c == ')':
+			toks = append(toks, xpathToken{kind: xtRParen, val: ")", pos: start})
+			i++
+		case c == '[':
+			toks = append(toks, xpathToken{kind: xtLBracket, val: "[", pos: start})
+			i++
+		case c == ']':
+			toks = append(toks, xpathToken{kind: xtRBracket, val: "]", pos: start})
+			i++
+		case c == '@':
+			toks = append(toks, xpathToken{kind: xtAt, val: "@", pos: start})
+			i++
+		case c == ',':
+			toks = append(toks, xpathToken{kind: xtComma, val: ",", pos: start})
+			i++
+		case c == ':' && i+1 < len(expr) && expr[i+1] == ':':
+			toks = append(toks, xpathToken{kind: xtColonColon, val: "::", pos: start})
+			i += 2
+		case c == '.' && i+1 < len(expr) && expr[i+1] == '.':
+			toks = append(toks, xpathToken{kind: xtDotDot, val: "..", pos: start})
+			i += 2
+		case c == '.' && (i+1 >= len(expr) || !isXPathDigit(expr[i+1])):
+			toks = append(toks, xpathToken{kind: xtDot, val: ".", pos: start})
+			i++
+		case isXPathDigit(c) || c == '.':
+			for i < len(expr) && isXPathDigit(expr[i]) {
+				i++
+			}
+			if i < len(expr) && expr[i] == '.' {
+				i++
+				for i < len(expr) && isXPathDigit(expr[i]) {
+					i++
+				}
+			}
+			toks = append(toks, xpathToken{kind: xtNumber, val: expr[start:i], pos: start})
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated string literal at position %d", start)
+			}
+			toks = append(toks, xpathToken{kind: xtLiteral, val: expr[i+1 : i+1+end], pos: start})
+			i += end + 2
+		case c == '/':
+			if i+1 < len(expr) && expr[i+1] == '/' {
+				toks = append(toks, xpathToken{kind: xtOperator, val: "//", pos: start})
+				i += 2
+				continue
+			}
+			toks = append(toks, xpathToken{kind: xtOperator, val: "/", pos: start})
+			i++
+		case c == '|' || c == '+' || c == '-' || c == '=':
+			toks = append(toks, xpathToken{kind: xtOperator, val: string(c), pos: start})
+			i++
+		case c == '!':
+			if i+1 >= len(expr) || expr[i+1] != '=' {
+				return nil, fmt.Errorf("invalid character '!' at position %d", start)
+			}
+			toks = append(toks, xpathToken{kind: xtOperator, val: "!=", pos: start})
+			i += 2
+		case c == '<' || c == '>':
+			if i+1 < len(expr) && expr[i+1] == '=' {
+				toks = append(toks, xpathToken{kind: xtOperator, val: expr[i : i+2], pos: start})
+				i += 2
+				continue
+			}
+			toks = append(toks, xpathToken{kind: xtOperator, val: string(c), pos: start})
+			i++
+		case c == '*':
+			if precedingAllowsOperator() {
+				toks = append(toks, xpathToken{kind: xtOperator, val: "*", pos: start})
+			} else {
+				toks = append(toks, xpathToken{kind: xtNameTest, val: "*", pos: start})
+			}
+			i++
+		case c == '$':
+			i++
+			n := scanXPathNCName(expr[i:])
+			if n == 0 {
+				return nil, fmt.Errorf("invalid variable reference at position %d", start)
+			}
+			i += n
+			if i+1 < len(expr) && expr[i] == ':' && expr[i+1] != ':' {
+				if m := scanXPathNCName(expr[i+1:]); m != 0 {
+					i += m + 1
+				}
+			}
+			toks = append(toks, xpathToken{kind: xtVariable, val: expr[start+1 : i], pos: start})
+		default:
+			n := scanXPathNCName(expr[i:])
+			if n == 0 {
+				r, _ := utf8.DecodeRuneInString(expr[i:])
+				return nil, fmt.Errorf("invalid character %q at position %d", r, start)
+			}
+			i += n
+			name := expr[start:i]
+
+			if precedingAllowsOperator() {
+				switch name {
+				case "and", "or", "mod", "div":
+					toks = append(toks, xpathToken{kind: xtOperator, val: name, pos: start})
+					continue
+				}
+				return nil, fmt.Errorf("unexpected name %q at position %d, expected an operator", name, start)
+			}
+
+			// Determine whether this is a QName, or a prefixed wildcard
+			// name test of the form pfx:*.
+			if i+1 < len(expr) && expr[i] == ':' && expr[i+1] != ':' {
+				switch m := scanXPathNCName(expr[i+1:]); {
+				case expr[i+1] == '*':
+					i += 2
+					toks = append(toks, xpathToken{kind: xtNameTest, val: expr[start:i], pos: start})
+					continue
+				case m != 0:
+					i += m + 1
+					name = expr[start:i]
+				default:
+					return nil, fmt.Errorf("invalid qualified name at position %d", start)
+				}
+			}
+
+			next := nextNonSpace(i)
+			switch {
+			case next < len(expr) && expr[next] == '(' && xpathNodeTypes[name]:
+				toks = append(toks, xpathToken{kind: xtNodeType, val: name, pos: start})
+			case next < len(expr) && expr[next] == '(':
+				toks = append(toks, xpathToken{kind: xtFunctionName, val: name, pos: start})
+			case next+1 < len(expr) && expr[next] == ':' && expr[next+1] == ':':
+				if !xpathAxes[name] {
+					return nil, fmt.Errorf("invalid axis name %q at position %d", name, start)
+				}
+				toks = append(toks, xpathToken{kind: xtAxisName, val: name, pos: start})
+			default:
+				toks = append(toks, xpathToken{kind: xtNameTest, val: name, pos: start})
+			}
+		}
+	}
+	return append(toks, xpathToken{kind: xtEOF, pos: len(expr)}), nil
+}
+
+// isXPathSpace reports whether c is XPath whitespace.
+func isXPathSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// isXPathDigit reports whether c is a decimal digit.
+func isXPathDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// scanXPathNCName returns the length in bytes of the NCName at the start of s,
+// or 0 if s does not start with an NCName.
+func scanXPathNCName(s string) int {
+	n := 0
+	for n < len(s) {
+		r, sz := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case r == '_' || unicode.IsLetter(r):
+		case n != 0 && (r == '-' || r == '.' || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)):
+		default:
+			return n
+		}
+		n += sz
+	}
+	return n
+}
+
+// xpathExpr is a node of the abstract syntax tree of a parsed XPath expression.
+type xpathExpr interface {
+	// String returns a string representation of the expression.
+	String() string
+}
+
+// xpathBinaryExpr is an expression consisting of an operator applied to two
+// operands, e.g., a = b or a | b.
+type xpathBinaryExpr struct {
+	op       string
+	lhs, rhs xpathExpr
+}
+
+// String returns a string representation of the expression.
+func (e *xpathBinaryExpr) String() string {
+	return fmt.Sprintf("(%s %s %s)", e.lhs, e.op, e.rhs)
+}
+
+// xpathNegateExpr is the unary minus applied to an expression.
+type xpathNegateExpr struct {
+	expr xpathExpr
+}
+
+// String returns a string representation of the expression.
+func (e *xpathNegateExpr) String() string {
+	return fmt.Sprintf("-%s", e.expr)
+}
+
+// xpathLiteralExpr is a string literal.
+type xpathLiteralExpr struct {
+	val string
+}
+
+// String returns a string representation of the expression.
+func (e *xpathLiteralExpr) String() string {
+	return strconv.Quote(e.val)
+}
+
+// xpathNumberExpr is a numeric literal.
+type xpathNumberExpr struct {
+	val float64
+}
+
+// String returns a string representation of the expression.
+func (e *xpathNumberExpr) String() string {
+	return xpathNumberToString(e.val)
+}
+
+// xpathVariableExpr is a variable reference. YANG does not define any
+// variables, so evaluating a reference always results in an error.
+type xpathVariableExpr struct {
+	name string
+}
+
+// String returns a string representation of the expression.
+func (e *xpathVariableExpr) String() string {
+	return "$" + e.name
+}
+
+// xpathFunctionCall is a call to a named function with zero or more arguments.
+type xpathFunctionCall struct {
+	// name is the function name, with any module prefix removed.
+	name string
+	args []xpathExpr
+}
+
+// String returns a string representation of the expression.
+func (e *xpathFunctionCall) String() string {
+	var args []string
+	for _, a := range e.args {
+		args = append(args, a.String())
+	}
+	return fmt.Sprintf("%s(%s)", e.name, strings.Join(args, ", "))
+}
+
+// xpathFilterExpr is a primary expression that is filtered by a set of
+// predicates, e.g., current()[1].
+type xpathFilterExpr struct {
+	primary    xpathExpr
+	predicates []xpathExpr
+}
+
+// String returns a string representation of the expression.
+func (e *xpathFilterExpr) String() string {
+	s := e.primary.String()
+	for _, p := range e.predicates {
+		s += fmt.Sprintf("[%s]", p)
+	}
+	return s
+}
+
+// xpathPathExpr is a location path, optionally relative to the result of a
+// filter expression, e.g., /a/b, ../c or current()/../d.
+type xpathPathExpr struct {
+	// filter is the expression that the steps are evaluated relative to, it
+	// is nil if the path is a plain location path.
+	filter xpathExpr
+	// absolute indicates that the path is evaluated from the root node.
+	absolute bool
+	steps    []*xpathStep
+}
+
+// String returns a string representation of the expression.
+func (e *xpathPathExpr) String() string {
+	var s []string
+	for _, st := range e.steps {
+		s = append(s, st.String())
+	}
+	p := strings.Join(s, "/")
+	switch {
+	case e.filter != nil:
+		return fmt.Sprintf("%s/%s", e.filter, p)
+	case e.absolute:
+		return "/" + p
+	}
+	return p
+}
+
+// xpathStep is a single step of a location path.
+type xpathStep struct {
+	axis string
+	// nodeType is set when the node test is a node type test, e.g., node().
+	nodeType string
+	// prefix and name store the name test of the step; name is "*" for a
+	// wildcard test.
+	prefix, name string
+	predicates   []xpathExpr
+}
+
+// String returns a string representation of the step.
+func (s *xpathStep) String() string {
+	test := s.name
+	switch {
+	case s.nodeType != "":
+		test = s.nodeType + "()"
+	case s.prefix != "":
+		test = s.prefix + ":" + s.name
+	}
+	out := fmt.Sprintf("%s::%s", s.axis, test)
+	for _, p := range s.predicates {
+		out += fmt.Sprintf("[%s]", p)
+	}
+	return out
+}
+
+// xpathParser is a recursive-descent parser for XPath 1.0 expressions.
+type xpathParser struct {
+	toks []xpathToken
+	pos  int
+}
+
+// parseXPath parses the XPath expression expr, returning its abstract syntax
+// tree, or an error if the expression is not valid.
+func parseXPath(expr string) (xpathExpr, error) {
+	toks, err := lexXPath(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XPath expression %q: %v", expr, err)
+	}
+	p := &xpathParser{toks: toks}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid XPath expression %q: %v", expr, err)
+	}
+	if t := p.peek(); t.kind != xtEOF {
+		return nil, fmt.Errorf("invalid XPath expression %q: unexpected token %q at position %d", expr, t.val, t.pos)
+	}
+	return e, nil
+}
+
+// peek returns the current token without consuming it.
+func (p *xpathParser) peek() xpathToken {
+	return p.toks[p.pos]
+}
+
+// next consumes and returns the current token.
+func (p *xpathParser) next() xpathToken {
+	t := p.toks[p.pos]
+	if t.kind != xtEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isOperator reports whether the current token is one of the operators ops.
+func (p *xpathParser) isOperator(ops ...string) bool {
+	t := p.peek()
+	if t.kind != xtOperator {
+		return false
+	}
+	for _, o := range ops {
+		if t.val == o {
+			return true
+		}
+	}
+	return false
+}
+
+// expect consumes the current token, returning an error if it is not of
+// kind k.
+func (p *xpathParser) expect(k xpathTokenKind, want string) error {
+	if t := p.next(); t.kind != k {
+		if t.kind == xtEOF {
+			return fmt.Errorf("unexpected end of expression, expected %s", want)
+		}
+		return fmt.Errorf("unexpected token %q at position %d, expected %s", t.val, t.pos, want)
+	}
+	return nil
+}
+
+// parseBinary parses a left-associative sequence of operands produced by
+// operand, separated by the operators ops.
+func (p *xpathParser) parseBinary(operand func() (xpathExpr, error), ops ...string) (xpathExpr, error) {
+	lhs, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(ops...) {
+		op := p.next().val
+		rhs, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &xpathBinaryExpr{op: op, lhs: lhs, rhs: rhs}
+	}
+	return lhs, nil
+}
+
+// parseOr parses an OrExpr, which is the top-level production of an XPath
+// expression.
+func (p *xpathParser) parseOr() (xpathExpr, error) {
+	return p.parseBinary(p.parseAnd, "or")
+}
+
+// parseAnd parses an AndExpr.
+func (p *xpathParser) parseAnd() (xpathExpr, error) {
+	return p.parseBinary(p.parseEquality, "and")
+}
+
+// parseEquality parses an EqualityExpr.
+func (p *xpathParser) parseEquality() (xpathExpr, error) {
+	return p.parseBinary(p.parseRelational, "=", "!=")
+}
+
+// parseRelational parses a RelationalExpr.
+func (p *xpathParser) parseRelational() (xpathExpr, error) {
+	return p.parseBinary(p.parseAdditive, "<", ">", "<=", ">=")
+}
+
+// parseAdditive parses an AdditiveExpr.
+func (p *xpathParser) parseAdditive() (xpathExpr, error) {
+	return p.parseBinary(p.parseMultiplicative, "+", "-")
+}
+
+// parseMultiplicative parses a MultiplicativeExpr.
+func (p *xpathParser) parseMultiplicative() (xpathExpr, error) {
+	return p.parseBinary(p.parseUnary, "*", "div", "mod")
+}
+
+// parseUnary parses a UnaryExpr.
+func (p *xpathParser) parseUnary() (xpathExpr, error) {
+	if p.isOperator("-") {
+		p.next()
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &xpathNegateExpr{expr: e}, nil
+	}
+	return p.parseBinary(p.parsePath, "|")
+}
+
+// parsePath parses a PathExpr, which is either a location path, or a filter
+// expression optionally followed by a relative location path.
+func (p *xpathParser) parsePath() (xpathExpr, error) {
+	switch p.peek().kind {
+	case xtNumber, xtLiteral, xtFunctionName, xtLParen, xtVariable:
+	default:
+		return p.parseLocationPath()
+	}
+
+	filter, err := p.parseFilter()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOperator("/", "//") {
+		return filter, nil
+	}
+	path := &xpathPathExpr{filter: filter}
+	if err := p.parseRelativeLocationPath(path); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+// parseFilter parses a FilterExpr.
+func (p *xpathParser) parseFilter() (xpathExpr, error) {
+	primary, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	preds, err := p.parsePredicates()
+	if err != nil {
+		return nil, err
+	}
+	if len(preds) == 0 {
+		return primary, nil
+	}
+	return &xpathFilterExpr{primary: primary, predicates: preds}, nil
+}
+
+// parsePrimary parses a PrimaryExpr.
+func (p *xpathParser) parsePrimary() (xpathExpr, error) {
+	t := p.next()
+	switch t.kind {
+	case xtNumber:
+		f, err := strconv.ParseFloat(t.val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.val, t.pos)
+		}
+		return &xpathNumberExpr{val: f}, nil
+	case xtLiteral:
+		return &xpathLiteralExpr{val: t.val}, nil
+	case xtVariable:
+		return &xpathVariableExpr{name: t.val}, nil
+	case xtLParen:
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(xtRParen, ")"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case xtFunctionName:
+		fn := &xpathFunctionCall{name: xpathLocalName(t.val)}
+		if err := p.expect(xtLParen, "("); err != nil {
+			return nil, err
+		}
+		if p.peek().kind == xtRParen {
+			p.next()
+			return fn, nil
+		}
+		for {
+			a, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			fn.args = append(fn.args, a)
+			if p.peek().kind != xtComma {
+				break
+			}
+			p.next()
+		}
+		if err := p.expect(xtRParen, ")"); err != nil {
+			return nil, err
+		}
+		return fn, nil
+	}
+	return nil, fmt.Errorf("unexpected token %q at position %d", t.val, t.pos)
+}
+
+// parsePredicates parses zero or more predicates of the form [expr].
+func (p *xpathParser) parsePredicates() ([]xpathExpr, error) {
+	var preds []xpathExpr
+	for p.peek().kind == xtLBracket {
+		p.next()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(xtRBracket, "]"); err != nil {
+			return nil, err
+		}
+		preds = append(preds, e)
+	}
+	return preds, nil
+}
+
+// startsStep reports whether the current token can begin a location step.
+func (p *xpathParser) startsStep() bool {
+	switch p.peek().kind {
+	case xtNameTest, xtNodeType, xtAxisName, xtAt, xtDot, xtDotDot:
+		return true
+	}
+	return false
+}
+
+// parseLocationPath parses an absolute or relative LocationPath.
+func (p *xpathParser) parseLocationPath() (xpathExpr, error) {
+	path := &xpathPathExpr{}
+	switch {
+	case p.isOperator("/"):
+		path.absolute = true
+		p.next()
+		// A lone "/" selects the root node.
+		if !p.startsStep() {
+			return path, nil
+		}
+	case p.isOperator("//"):
+		path.absolute = true
+		p.next()
+		path.steps = append(path.steps, descendantOrSelfStep())
+	}
+
+	step, err := p.parseStep()
+	if err != nil {
+		return nil, err
+	}
+	path.steps = append(path.steps, step)
+	if err := p.parseRelativeLocationPath(path); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+// parseRelativeLocationPath parses zero or more steps that are each preceded
+// by a / or // separator, appending the steps to path.
+func (p *xpathParser) parseRelativeLocationPath(path *xpathPathExpr) error {
+	for p.isOperator("/", "//") {
+		if p.next().val == "//" {
+			path.steps = append(path.steps, descendantOrSelfStep())
+		}
+		step, err := p.parseStep()
+		if err != nil {
+			return err
+		}
+		path.steps = append(path.steps, step)
+	}
+	return nil
+}
+
+// descendantOrSelfStep returns the step corresponding to the abbreviated
+// syntax //.
+func descendantOrSelfStep() *xpathStep {
+	return &xpathStep{axis: "descendant-or-self", nodeType: "node"}
+}
+
+// parseStep parses a single location Step.
+func (p *xpathParser) parseStep() (*xpathStep, error) {
+	switch p.peek().kind {
+	case xtDot:
+		p.next()
+		return &xpathStep{axis: "self", nodeType: "node"}, nil
+	case xtDotDot:
+		p.next()
+		return &xpathStep{axis: "parent", nodeType: "node"}, nil
+	}
+
+	step := &xpathStep{axis: "child"}
+	switch t := p.peek(); t.kind {
+	case xtAt:
+		p.next()
+		step.axis = "attribute"
+	case xtAxisName:
+		p.next()
+		step.axis = t.val
+		if err := p.expect(xtColonColon, "::"); err != nil {
+			return nil, err
+		}
+	}
+
+	switch t := p.next(); t.kind {
+	case xtNameTest:
+		step.name = t.val
+		if i := strings.IndexByte(t.val, ':'); i != -1 {
+			step.prefix, step.name = t.val[:i], t.val[i+1:]
+		}
+	case xtNodeType:
+		step.nodeType = t.val
+		if err := p.expect(xtLParen, "("); err != nil {
+			return nil, err
+		}
+		if t.val == "processing-instruction" && p.peek().kind == xtLiteral {
+			p.next()
+		}
+		if err := p.expect(xtRParen, ")"); err != nil {
+			return nil, err
+		}
+	case xtEOF:
+		return nil, fmt.Errorf("unexpected end of expression, expected a location step")
+	default:
+		return nil, fmt.Errorf("unexpected token %q at position %d, expected a location step", t.val, t.pos)
+	}
+
+	preds, err := p.parsePredicates()
+	if err != nil {
+		return nil, err
+	}
+	step.predicates = preds
+	return step, nil
+}
+
+// xpathLocalName returns the local part of the potentially prefixed name n.
+func xpathLocalName(n string) string {
+	if i := strings.IndexByte(n, ':'); i != -1 {
+		return n[i+1:]
+	}
+	return n
+}
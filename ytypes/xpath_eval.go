@@ -0,0 +1,1377 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// xpathNode is a node of the data tree that XPath expressions are evaluated
+// against. The tree is built from a GoStruct and its schema such that it
+// mirrors the YANG data tree, i.e., nodes that are compressed out of the
+// generated GoStructs are restored, and each list entry and leaf-list value
+// is a separate node.
+type xpathNode struct {
+	// name is the name of the data node, it is empty for the root node.
+	name string
+	// schema is the schema of the data node.
+	schema *yang.Entry
+	parent *xpathNode
+	// children are the child data nodes, in document order.
+	children []*xpathNode
+	// value is the Go value of a leaf, or leaf-list entry. It is nil
+	// for interior nodes.
+	value interface{}
+	// order is the position of the node in document order.
+	order int
+	// goStruct is the GoStruct pointer that the node was created from. It
+	// is nil for leaves, and for nodes that are compressed out of the
+	// generated GoStructs.
+	goStruct interface{}
+	// module is the name of the module that instantiates the data node,
+	// as specified by the module tag of the GoStruct field that the node
+	// was created from. It is empty if the field has no module tag.
+	module string
+}
+
+// isLeaf reports whether n is a leaf or leaf-list entry.
+func (n *xpathNode) isLeaf() bool {
+	return n.value != nil
+}
+
+// stringValue returns the XPath string-value of the node. For leaves, this
+// is the canonical string representation of the leaf's value, and for other
+// nodes it is the concatenation of the values of the descendant leaves in
+// document order.
+func (n *xpathNode) stringValue() string {
+	if n.isLeaf() {
+		return xpathLeafString(n.value)
+	}
+	var b strings.Builder
+	for _, c := range n.children {
+		b.WriteString(c.stringValue())
+	}
+	return b.String()
+}
+
+// child returns the first interior child of n with the specified name,
+// creating it with the supplied schema if it does not exist.
+func (n *xpathNode) child(name string, schema *yang.Entry) *xpathNode {
+	for _, c := range n.children {
+		if c.name == name && !c.isLeaf() && !c.schema.IsList() {
+			return c
+		}
+	}
+	c := &xpathNode{name: name, schema: schema, parent: n}
+	n.children = append(n.children, c)
+	return c
+}
+
+// removeChild removes the child c from the children of n.
+func (n *xpathNode) removeChild(c *xpathNode) {
+	for i, nc := range n.children {
+		if nc == c {
+			n.children = append(n.children[:i], n.children[i+1:]...)
+			return
+		}
+	}
+}
+
+// dataPath returns the path of n within the data tree, including the values
+// of the keys of any list entries along the path.
+func (n *xpathNode) dataPath() string {
+	if n.parent == nil {
+		return "/"
+	}
+	var elems []string
+	for c := n; c.parent != nil; c = c.parent {
+		e := c.name
+		if c.schema != nil && c.schema.IsList() && c.schema.Key != "" {
+			for _, k := range strings.Fields(c.schema.Key) {
+				for _, kc := range c.children {
+					if kc.name == k && kc.isLeaf() {
+						e += fmt.Sprintf("[%s=%s]", k, kc.stringValue())
+						break
+					}
+				}
+			}
+		}
+		elems = append([]string{e}, elems...)
+	}
+	return "/" + strings.Join(elems, "/")
+}
+
+// walk calls fn for n and each of its descendants in document order.
+func (n *xpathNode) walk(fn func(*xpathNode)) {
+	fn(n)
+	for _, c := range n.children {
+		c.walk(fn)
+	}
+}
+
+// newXPathTree builds the XPath data tree corresponding to the GoStruct value
+// described by the supplied schema, and returns its root.
+func newXPathTree(schema *yang.Entry, value interface{}) (*xpathNode, error) {
+	root := &xpathNode{schema: schema}
+	if err := addXPathStruct(root, reflect.ValueOf(value)); err != nil {
+		return nil, err
+	}
+	root.number()
+	return root, nil
+}
+
+// newXPathSubtree builds the XPath data tree for the value of a container or
+// list, described by the supplied schema, that is not the root of the data
+// tree. The returned root is a placeholder for the data tree that the value
+// is part of, and has a child node for the container, or for each entry of
+// the list. The value of a list may be a single entry.
+func newXPathSubtree(schema *yang.Entry, value interface{}) (*xpathNode, error) {
+	root := &xpathNode{}
+	rv := reflect.ValueOf(value)
+	entries := []reflect.Value{rv}
+	if schema.IsList() && rv.Kind() != reflect.Ptr {
+		var err error
+		if entries, err = xpathListEntries(schema, rv); err != nil {
+			return nil, err
+		}
+	}
+	for _, ev := range entries {
+		c := &xpathNode{name: schema.Name, schema: schema, parent: root}
+		root.children = append(root.children, c)
+		if err := addXPathStruct(c, ev); err != nil {
+			return nil, err
+		}
+		// There is no field that the value was created from, so the
+		// node is considered to be instantiated by the same module as
+		// its children.
+		for _, cc := range c.children {
+			if cc.module != "" {
+				c.module = cc.module
+				break
+			}
+		}
+	}
+	root.number()
+	return root, nil
+}
+
+// number sets the document order of n and each of its descendants.
+func (n *xpathNode) number() {
+	order := 0
+	n.walk(func(d *xpathNode) {
+		d.order = order
+		order++
+	})
+}
+
+// addXPathStruct adds the fields of the GoStruct v as children of the data
+// tree node n, using the path tags of the fields to determine their location.
+func addXPathStruct(n *xpathNode, v reflect.Value) error {
+	if util.IsNilOrInvalidValue(v) {
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		n.goStruct = v.Interface()
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot build data tree for non-struct type %v at %s", v.Type(), n.dataPath())
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		fv := v.Field(i)
+		if util.IsYgotAnnotation(sf) || util.IsNilOrInvalidValue(fv) || util.IsValueNil(fv.Interface()) {
+			continue
+		}
+		ps, err := util.SchemaPaths(sf)
+		if err != nil {
+			return err
+		}
+		for _, p := range ps {
+			if err := addXPathField(n, p, fv, sf.Tag.Get("module")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addXPathField adds the value fv of a GoStruct field that has the relative
+// schema path p, and is instantiated by the module mod, to the data tree below
+// node n.
+func addXPathField(n *xpathNode, p []string, fv reflect.Value, mod string) error {
+	var elems []string
+	for _, e := range p {
+		if e != "" {
+			elems = append(elems, e)
+		}
+	}
+	if len(elems) == 0 {
+		return nil
+	}
+
+	parent := n
+	for _, e := range elems[:len(elems)-1] {
+		s := util.FirstChild(parent.schema, []string{e})
+		if s == nil {
+			return nil
+		}
+		parent = parent.child(e, s)
+		if parent.module == "" {
+			parent.module = mod
+		}
+	}
+
+	name := elems[len(elems)-1]
+	schema := util.FirstChild(parent.schema, []string{name})
+	if schema == nil {
+		// Fields that do not have a schema are reported by the type
+		// validation, so are skipped here.
+		return nil
+	}
+
+	switch {
+	case util.IsAnydata(schema):
+		// The contents of anydata and anyxml nodes are not described by
+		// the schema, so only the node itself is added.
+		parent.child(name, schema).module = mod
+	case schema.IsLeaf():
+		if fv.Type().Name() == ygot.EmptyTypeName && !fv.Bool() {
+			// An empty leaf that is false is not present in the data tree.
+			return nil
+		}
+		parent.children = append(parent.children, &xpathNode{name: name, schema: schema, parent: parent, value: fv.Interface(), module: mod})
+	case schema.IsLeafList():
+		if fv.Kind() != reflect.Slice {
+			return fmt.Errorf("leaf-list %s has non-slice type %v", schema.Path(), fv.Type())
+		}
+		for i := 0; i < fv.Len(); i++ {
+			parent.children = append(parent.children, &xpathNode{name: name, schema: schema, parent: parent, value: fv.Index(i).Interface(), module: mod})
+		}
+	case schema.IsList():
+		entries, err := xpathListEntries(schema, fv)
+		if err != nil {
+			return err
+		}
+		for _, ev := range entries {
+			c := &xpathNode{name: name, schema: schema, parent: parent, module: mod}
+			parent.children = append(parent.children, c)
+			if err := addXPathStruct(c, ev); err != nil {
+				return err
+			}
+		}
+	default:
+		c := parent.child(name, schema)
+		c.module = mod
+		if err := addXPathStruct(c, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// xpathListEntries returns the entries of the list with the supplied schema,
+// whose value is fv, in document order.
+func xpathListEntries(schema *yang.Entry, fv reflect.Value) ([]reflect.Value, error) {
+	var entries []reflect.Value
+	switch {
+	case util.IsValueOrderedMap(fv):
+		// The entries of an ordered map are in the order specified by
+		// the user, which is the document order of the list.
+		return util.OrderedMapValues(fv)
+	case fv.Kind() == reflect.Map:
+		keys := fv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, k := range keys {
+			entries = append(entries, fv.MapIndex(k))
+		}
+	case fv.Kind() == reflect.Slice:
+		for i := 0; i < fv.Len(); i++ {
+			entries = append(entries, fv.Index(i))
+		}
+	default:
+		return nil, fmt.Errorf("list %s has non-map, non-slice type %v", schema.Path(), fv.Type())
+	}
+	return entries, nil
+}
+
+// xpathLeafString returns the canonical string representation of the value of
+// a leaf, as used as the string-value of the leaf's node.
+func xpathLeafString(v interface{}) string {
+	// decimal64 values are stored as float64, which would lose precision
+	// if encoded as a TypedValue.
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Elem().Kind() == reflect.Float64 {
+		return strconv.FormatFloat(rv.Elem().Float(), 'f', -1, 64)
+	}
+	tv, err := ygot.EncodeTypedValue(v, gpb.Encoding_JSON)
+	if err != nil || tv == nil {
+		return fmt.Sprint(v)
+	}
+	switch t := tv.Value.(type) {
+	case *gpb.TypedValue_StringVal:
+		return t.StringVal
+	case *gpb.TypedValue_IntVal:
+		return strconv.FormatInt(t.IntVal, 10)
+	case *gpb.TypedValue_UintVal:
+		return strconv.FormatUint(t.UintVal, 10)
+	case *gpb.TypedValue_BoolVal:
+		if reflect.TypeOf(v).Name() == ygot.EmptyTypeName {
+			return ""
+		}
+		return strconv.FormatBool(t.BoolVal)
+	case *gpb.TypedValue_FloatVal:
+		return strconv.FormatFloat(float64(t.FloatVal), 'f', -1, 32)
+	case *gpb.TypedValue_BytesVal:
+		return base64.StdEncoding.EncodeToString(t.BytesVal)
+	case *gpb.TypedValue_DecimalVal:
+		return strconv.FormatFloat(float64(t.DecimalVal.Digits)/math.Pow10(int(t.DecimalVal.Precision)), 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// xpathNodeSet is a set of data tree nodes, as produced by evaluating an
+// XPath location path.
+type xpathNodeSet []*xpathNode
+
+// sortedUnique returns the nodes of s in document order, with duplicate nodes
+// removed.
+func (s xpathNodeSet) sortedUnique() xpathNodeSet {
+	seen := map[*xpathNode]bool{}
+	var out xpathNodeSet
+	for _, n := range s {
+		if !seen[n] {
+			seen[n] = true
+			out = append(out, n)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].order < out[j].order })
+	return out
+}
+
+// xpathEvaluator evaluates XPath expressions against a data tree. An
+// evaluator caches the parsed form of the expressions that it evaluates.
+type xpathEvaluator struct {
+	root   *xpathNode
+	parsed map[string]xpathExpr
+	// partial specifies that the data tree is a subtree of the complete
+	// data tree, as built by newXPathSubtree, such that root is a
+	// placeholder for the nodes that are outside of the subtree.
+	partial bool
+	// outside is set when evaluating an expression against a partial data
+	// tree selects, or may select, nodes outside of the subtree. The
+	// result of such an expression cannot be determined.
+	outside bool
+	// schema and module are the schema entry, and the name of the module,
+	// that define the expression being evaluated. The prefixes within the
+	// expression are resolved relative to the module.
+	schema *yang.Entry
+	module string
+}
+
+// newXPathEvaluator returns an evaluator for the data tree with the specified
+// root.
+func newXPathEvaluator(root *xpathNode) *xpathEvaluator {
+	return &xpathEvaluator{root: root, parsed: map[string]xpathExpr{}}
+}
+
+// xpathContext is the context within which an expression is evaluated.
+type xpathContext struct {
+	// node is the context node.
+	node *xpathNode
+	// position and size are the context position and size.
+	position, size int
+	// current is the node returned by the YANG current() function.
+	current *xpathNode
+}
+
+// define sets the schema entry s, which describes the data node n, as the
+// definition of the expressions that are subsequently evaluated. The module
+// that defines them is that of the YANG node of s, or the module that
+// instantiates n where the schema does not retain its YANG nodes, as is
+// the case for schemas serialised by ygen.
+func (x *xpathEvaluator) define(s *yang.Entry, n *xpathNode) {
+	x.schema, x.module = s, n.module
+	if s == nil || s.Node == nil {
+		return
+	}
+	if m := yang.RootNode(s.Node); m != nil {
+		x.module = m.Name
+		if m.BelongsTo != nil {
+			x.module = m.BelongsTo.Name
+		}
+	}
+}
+
+// resolvePrefix returns the name of the module that prefix refers to within
+// the module that defines the expression being evaluated. The empty prefix
+// refers to the defining module itself.
+func (x *xpathEvaluator) resolvePrefix(prefix string) (string, error) {
+	if prefix == "" {
+		return x.module, nil
+	}
+	if x.schema != nil && x.schema.Node != nil {
+		if m := yang.FindModuleByPrefix(x.schema.Node, prefix); m != nil {
+			if m.BelongsTo != nil {
+				return m.BelongsTo.Name, nil
+			}
+			return m.Name, nil
+		}
+	}
+	if m, ok := util.ModulePrefixes(x.schema)[x.module][prefix]; ok {
+		return m, nil
+	}
+	return "", fmt.Errorf("cannot resolve prefix %s within module %q", prefix, x.module)
+}
+
+// parse returns the parsed form of expr, using the evaluator's cache.
+func (x *xpathEvaluator) parse(expr string) (xpathExpr, error) {
+	if e, ok := x.parsed[expr]; ok {
+		return e, nil
+	}
+	e, err := parseXPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	x.parsed[expr] = e
+	return e, nil
+}
+
+// evalBool evaluates expr with the context node n, and returns the result
+// converted to a boolean.
+func (x *xpathEvaluator) evalBool(expr string, n *xpathNode) (bool, error) {
+	v, err := x.evalString(expr, n)
+	if err != nil {
+		return false, err
+	}
+	return xpathToBool(v), nil
+}
+
+// evalString parses and evaluates expr with the context node n, which is also
+// used as the current node.
+func (x *xpathEvaluator) evalString(expr string, n *xpathNode) (interface{}, error) {
+	e, err := x.parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	return x.eval(e, &xpathContext{node: n, position: 1, size: 1, current: n})
+}
+
+// eval evaluates the expression e in the context ctx. The returned value is
+// one of xpathNodeSet, string, float64 or bool.
+func (x *xpathEvaluator) eval(e xpathExpr, ctx *xpathContext) (interface{}, error) {
+	switch e := e.(type) {
+	case *xpathLiteralExpr:
+		return e.val, nil
+	case *xpathNumberExpr:
+		return e.val, nil
+	case *xpathVariableExpr:
+		return nil, fmt.Errorf("variable $%s is not defined", e.name)
+	case *xpathNegateExpr:
+		v, err := x.eval(e.expr, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return -xpathToNumber(v), nil
+	case *xpathBinaryExpr:
+		return x.evalBinary(e, ctx)
+	case *xpathFunctionCall:
+		return x.evalFunction(e, ctx)
+	case *xpathFilterExpr:
+		v, err := x.eval(e.primary, ctx)
+		if err != nil {
+			return nil, err
+		}
+		ns, ok := v.(xpathNodeSet)
+		if !ok {
+			return nil, fmt.Errorf("predicate applied to non node-set expression %s", e.primary)
+		}
+		return x.filter(ns.sortedUnique(), e.predicates, ctx)
+	case *xpathPathExpr:
+		return x.evalPath(e, ctx)
+	}
+	return nil, fmt.Errorf("unknown expression type %T", e)
+}
+
+// evalBinary evaluates the binary expression e in the context ctx.
+func (x *xpathEvaluator) evalBinary(e *xpathBinaryExpr, ctx *xpathContext) (interface{}, error) {
+	lhs, err := x.eval(e.lhs, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The boolean operators short-circuit their evaluation.
+	switch e.op {
+	case "or":
+		if xpathToBool(lhs) {
+			return true, nil
+		}
+	case "and":
+		if !xpathToBool(lhs) {
+			return false, nil
+		}
+	}
+
+	rhs, err := x.eval(e.rhs, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "or", "and":
+		return xpathToBool(rhs), nil
+	case "|":
+		l, lok := lhs.(xpathNodeSet)
+		r, rok := rhs.(xpathNodeSet)
+		if !lok || !rok {
+			return nil, fmt.Errorf("union operator applied to non node-set in %s", e)
+		}
+		return append(append(xpathNodeSet{}, l...), r...).sortedUnique(), nil
+	case "=", "!=", "<", "<=", ">", ">=":
+		return xpathCompare(e.op, lhs, rhs), nil
+	}
+
+	l, r := xpathToNumber(lhs), xpathToNumber(rhs)
+	switch e.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "div":
+		return l / r, nil
+	case "mod":
+		return math.Mod(l, r), nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", e.op)
+}
+
+// evalPath evaluates the location path e in the context ctx.
+func (x *xpathEvaluator) evalPath(e *xpathPathExpr, ctx *xpathContext) (interface{}, error) {
+	var ns xpathNodeSet
+	switch {
+	case e.filter != nil:
+		v, err := x.eval(e.filter, ctx)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if ns, ok = v.(xpathNodeSet); !ok {
+			return nil, fmt.Errorf("location path applied to non node-set expression %s", e.filter)
+		}
+	case e.absolute:
+		ns = xpathNodeSet{x.root}
+	default:
+		ns = xpathNodeSet{ctx.node}
+	}
+	x.checkOutside("self", nil, ns)
+
+	for _, step := range e.steps {
+		var out xpathNodeSet
+		for _, n := range ns {
+			var matched xpathNodeSet
+			axis := xpathAxis(step.axis, n)
+			x.checkOutside(step.axis, n, axis)
+			for _, c := range axis {
+				if xpathNodeTest(step, c) {
+					matched = append(matched, c)
+				}
+			}
+			filtered, err := x.filter(matched, step.predicates, ctx)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, filtered...)
+		}
+		ns = out.sortedUnique()
+	}
+	return ns, nil
+}
+
+// checkOutside records whether the nodes ns, which are selected by the
+// specified axis from node n, include nodes outside of a partial data tree.
+// Since the placeholder root of the tree is the only node outside of the
+// subtree, the nodes preceding and following the subtree, including the
+// siblings of its top-level nodes, are missing from the tree and are
+// considered to be outside of it.
+func (x *xpathEvaluator) checkOutside(axis string, n *xpathNode, ns xpathNodeSet) {
+	if !x.partial {
+		return
+	}
+	switch {
+	case axis == "following" || axis == "preceding":
+		x.outside = true
+	case (axis == "following-sibling" || axis == "preceding-sibling") && n.parent == x.root:
+		x.outside = true
+	}
+	for _, c := range ns {
+		if c == x.root {
+			x.outside = true
+		}
+	}
+}
+
+// filter returns the nodes of ns for which all the predicates preds are true,
+// where ns is ordered according to the axis that selected them.
+func (x *xpathEvaluator) filter(ns xpathNodeSet, preds []xpathExpr, ctx *xpathContext) (xpathNodeSet, error) {
+	for _, p := range preds {
+		var out xpathNodeSet
+		for i, n := range ns {
+			v, err := x.eval(p, &xpathContext{node: n, position: i + 1, size: len(ns), current: ctx.current})
+			if err != nil {
+				return nil, err
+			}
+			// A numeric predicate is true if it is equal to the context
+			// position.
+			if f, ok := v.(float64); ok {
+				if f == float64(i+1) {
+					out = append(out, n)
+				}
+				continue
+			}
+			if xpathToBool(v) {
+				out = append(out, n)
+			}
+		}
+		ns = out
+	}
+	return ns, nil
+}
+
+// xpathAxis returns the nodes on the specified axis from node n, in the order
+// of the axis, i.e., reverse axes return nodes in reverse document order.
+func xpathAxis(axis string, n *xpathNode) xpathNodeSet {
+	var out xpathNodeSet
+	switch axis {
+	case "self":
+		out = xpathNodeSet{n}
+	case "child":
+		out = append(out, n.children...)
+	case "descendant", "descendant-or-self":
+		n.walk(func(d *xpathNode) {
+			if d != n || axis == "descendant-or-self" {
+				out = append(out, d)
+			}
+		})
+	case "parent":
+		if n.parent != nil {
+			out = xpathNodeSet{n.parent}
+		}
+	case "ancestor", "ancestor-or-self":
+		if axis == "ancestor-or-self" {
+			out = append(out, n)
+		}
+		for p := n.parent; p != nil; p = p.parent {
+			out = append(out, p)
+		}
+	case "following-sibling", "preceding-sibling":
+		if n.parent == nil {
+			return nil
+		}
+		sibs := n.parent.children
+		for i, s := range sibs {
+			if s != n {
+				continue
+			}
+			if axis == "following-sibling" {
+				out = append(out, sibs[i+1:]...)
+				break
+			}
+			for j := i - 1; j >= 0; j-- {
+				out = append(out, sibs[j])
+			}
+			break
+		}
+	case "following", "preceding":
+		ancestors := map[*xpathNode]bool{}
+		for p := n.parent; p != nil; p = p.parent {
+			ancestors[p] = true
+		}
+		var root *xpathNode
+		for root = n; root.parent != nil; root = root.parent {
+		}
+		end := n.order
+		// The following axis excludes the descendants of n, which are
+		// the nodes immediately following n in document order.
+		n.walk(func(d *xpathNode) {
+			if d.order > end {
+				end = d.order
+			}
+		})
+		root.walk(func(d *xpathNode) {
+			switch {
+			case axis == "following" && d.order > end:
+				out = append(out, d)
+			case axis == "preceding" && d.order < n.order && !ancestors[d]:
+				out = append(xpathNodeSet{d}, out...)
+			}
+		})
+	}
+	// The attribute and namespace axes are always empty, since there are
+	// no such nodes in the YANG data tree.
+	return out
+}
+
+// xpathNodeTest reports whether node n matches the node test of step s.
+func xpathNodeTest(s *xpathStep, n *xpathNode) bool {
+	switch {
+	case s.nodeType == "node":
+		return true
+	case s.nodeType != "":
+		// Text, comment and processing instruction nodes are not
+		// represented in the data tree.
+		return false
+	case n.parent == nil:
+		// The root node is not an element, and hence never matches
+		// a name test.
+		return false
+	case s.name == "*":
+		return true
+	}
+	return s.name == n.name
+}
+
+// xpathToBool converts an XPath value to a boolean per the boolean() function.
+func xpathToBool(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case string:
+		return v != ""
+	case xpathNodeSet:
+		return len(v) != 0
+	}
+	return false
+}
+
+// xpathToNumber converts an XPath value to a number per the number() function.
+func xpathToNumber(v interface{}) float64 {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case float64:
+		return v
+	case string:
+		return xpathStringToNumber(v)
+	case xpathNodeSet:
+		return xpathStringToNumber(xpathToString(v))
+	}
+	return math.NaN()
+}
+
+// xpathNumberPattern matches the strings that can be converted to an XPath
+// number.
+var xpathNumberPattern = regexp.MustCompile(`^-?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+
+// xpathStringToNumber converts the string s to an XPath number, returning NaN
+// if s does not represent a number.
+func xpathStringToNumber(s string) float64 {
+	s = strings.Trim(s, " \t\r\n")
+	if !xpathNumberPattern.MatchString(s) {
+		return math.NaN()
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return f
+}
+
+// xpathToString converts an XPath value to a string per the string() function.
+func xpathToString(v interface{}) string {
+	switch v := v.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return xpathNumberToString(v)
+	case string:
+		return v
+	case xpathNodeSet:
+		if len(v) == 0 {
+			return ""
+		}
+		return v.sortedUnique()[0].stringValue()
+	}
+	return ""
+}
+
+// xpathNumberToString returns the XPath string representation of the number f.
+func xpathNumberToString(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	case f == 0:
+		return "0"
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// xpathCompare compares the values lhs and rhs using the comparison operator
+// op, following the rules of section 3.4 of the XPath 1.0 specification.
+func xpathCompare(op string, lhs, rhs interface{}) bool {
+	lns, lok := lhs.(xpathNodeSet)
+	rns, rok := rhs.(xpathNodeSet)
+	switch {
+	case lok && rok:
+		for _, l := range lns {
+			for _, r := range rns {
+				if xpathCompareAtomic(op, l.stringValue(), r.stringValue()) {
+					return true
+				}
+			}
+		}
+		return false
+	case lok:
+		if b, ok := rhs.(bool); ok {
+			return xpathCompareAtomic(op, len(lns) != 0, b)
+		}
+		for _, l := range lns {
+			if xpathCompareAtomic(op, xpathNodeAtomic(l, rhs), rhs) {
+				return true
+			}
+		}
+		return false
+	case rok:
+		if b, ok := lhs.(bool); ok {
+			return xpathCompareAtomic(op, b, len(rns) != 0)
+		}
+		for _, r := range rns {
+			if xpathCompareAtomic(op, lhs, xpathNodeAtomic(r, lhs)) {
+				return true
+			}
+		}
+		return false
+	}
+	return xpathCompareAtomic(op, lhs, rhs)
+}
+
+// xpathNodeAtomic converts the node n to the type of the value other that it
+// is being compared with.
+func xpathNodeAtomic(n *xpathNode, other interface{}) interface{} {
+	if _, ok := other.(float64); ok {
+		return xpathStringToNumber(n.stringValue())
+	}
+	return n.stringValue()
+}
+
+// xpathCompareAtomic compares two values that are not node-sets.
+func xpathCompareAtomic(op string, lhs, rhs interface{}) bool {
+	if op == "=" || op == "!=" {
+		var eq bool
+		_, lb := lhs.(bool)
+		_, rb := rhs.(bool)
+		_, lf := lhs.(float64)
+		_, rf := rhs.(float64)
+		switch {
+		case lb || rb:
+			eq = xpathToBool(lhs) == xpathToBool(rhs)
+		case lf || rf:
+			eq = xpathToNumber(lhs) == xpathToNumber(rhs)
+		default:
+			eq = xpathToString(lhs) == xpathToString(rhs)
+		}
+		return eq == (op == "=")
+	}
+
+	l, r := xpathToNumber(lhs), xpathToNumber(rhs)
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	}
+	return false
+}
+
+// xpathFunction is the implementation of an XPath function. It is supplied
+// with the evaluated arguments to the function.
+type xpathFunction struct {
+	// minArgs and maxArgs are the minimum and maximum number of arguments
+	// to the function, maxArgs is -1 if the function is variadic.
+	minArgs, maxArgs int
+	fn               func(x *xpathEvaluator, ctx *xpathContext, args []interface{}) (interface{}, error)
+}
+
+// xpathFunctions is the core XPath 1.0 function library, plus the functions
+// defined by YANG in RFC7950 Section 10.
+var xpathFunctions map[string]*xpathFunction
+
+func init() {
+	xpathFunctions = map[string]*xpathFunction{
+		// Node set functions.
+		"last": {0, 0, func(_ *xpathEvaluator, ctx *xpathContext, _ []interface{}) (interface{}, error) {
+			return float64(ctx.size), nil
+		}},
+		"position": {0, 0, func(_ *xpathEvaluator, ctx *xpathContext, _ []interface{}) (interface{}, error) {
+			return float64(ctx.position), nil
+		}},
+		"count": {1, 1, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			ns, err := xpathNodeSetArg("count", args[0])
+			if err != nil {
+				return nil, err
+			}
+			return float64(len(ns)), nil
+		}},
+		"id":            {1, 1, xpathUnsupportedFunc("id")},
+		"local-name":    {0, 1, xpathNameFunc("local-name")},
+		"name":          {0, 1, xpathNameFunc("name")},
+		"namespace-uri": {0, 1, xpathUnsupportedFunc("namespace-uri")},
+		// String functions.
+		"string": {0, 1, func(_ *xpathEvaluator, ctx *xpathContext, args []interface{}) (interface{}, error) {
+			return xpathToString(xpathArgOrContext(ctx, args)), nil
+		}},
+		"concat": {2, -1, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			var b strings.Builder
+			for _, a := range args {
+				b.WriteString(xpathToString(a))
+			}
+			return b.String(), nil
+		}},
+		"starts-with": {2, 2, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			return strings.HasPrefix(xpathToString(args[0]), xpathToString(args[1])), nil
+		}},
+		"contains": {2, 2, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			return strings.Contains(xpathToString(args[0]), xpathToString(args[1])), nil
+		}},
+		"substring-before": {2, 2, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			s, sep := xpathToString(args[0]), xpathToString(args[1])
+			if i := strings.Index(s, sep); i != -1 {
+				return s[:i], nil
+			}
+			return "", nil
+		}},
+		"substring-after": {2, 2, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			s, sep := xpathToString(args[0]), xpathToString(args[1])
+			if i := strings.Index(s, sep); i != -1 {
+				return s[i+len(sep):], nil
+			}
+			return "", nil
+		}},
+		"substring": {2, 3, xpathSubstring},
+		"string-length": {0, 1, func(_ *xpathEvaluator, ctx *xpathContext, args []interface{}) (interface{}, error) {
+			return float64(utf8.RuneCountInString(xpathToString(xpathArgOrContext(ctx, args)))), nil
+		}},
+		"normalize-space": {0, 1, func(_ *xpathEvaluator, ctx *xpathContext, args []interface{}) (interface{}, error) {
+			return strings.Join(strings.Fields(xpathToString(xpathArgOrContext(ctx, args))), " "), nil
+		}},
+		"translate": {3, 3, xpathTranslate},
+		// Boolean functions.
+		"boolean": {1, 1, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			return xpathToBool(args[0]), nil
+		}},
+		"not": {1, 1, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			return !xpathToBool(args[0]), nil
+		}},
+		"true": {0, 0, func(_ *xpathEvaluator, _ *xpathContext, _ []interface{}) (interface{}, error) {
+			return true, nil
+		}},
+		"false": {0, 0, func(_ *xpathEvaluator, _ *xpathContext, _ []interface{}) (interface{}, error) {
+			return false, nil
+		}},
+		"lang": {1, 1, xpathUnsupportedFunc("lang")},
+		// Number functions.
+		"number": {0, 1, func(_ *xpathEvaluator, ctx *xpathContext, args []interface{}) (interface{}, error) {
+			return xpathToNumber(xpathArgOrContext(ctx, args)), nil
+		}},
+		"sum": {1, 1, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			ns, err := xpathNodeSetArg("sum", args[0])
+			if err != nil {
+				return nil, err
+			}
+			var s float64
+			for _, n := range ns {
+				s += xpathStringToNumber(n.stringValue())
+			}
+			return s, nil
+		}},
+		"floor": {1, 1, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			return math.Floor(xpathToNumber(args[0])), nil
+		}},
+		"ceiling": {1, 1, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			return math.Ceil(xpathToNumber(args[0])), nil
+		}},
+		"round": {1, 1, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			return xpathRound(xpathToNumber(args[0])), nil
+		}},
+		// YANG functions, defined in RFC7950 Section 10.
+		"current": {0, 0, func(x *xpathEvaluator, ctx *xpathContext, _ []interface{}) (interface{}, error) {
+			ns := xpathNodeSet{ctx.current}
+			x.checkOutside("self", nil, ns)
+			return ns, nil
+		}},
+		"re-match": {2, 2, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			pats, _ := util.SanitizedPattern(&yang.YangType{Pattern: []string{xpathToString(args[1])}})
+			r, err := regexp.Compile(pats[0])
+			if err != nil {
+				return nil, fmt.Errorf("re-match: invalid pattern %q: %v", xpathToString(args[1]), err)
+			}
+			return r.MatchString(xpathToString(args[0])), nil
+		}},
+		"deref":                {1, 1, xpathDeref},
+		"derived-from":         {2, 2, xpathDerivedFrom(false)},
+		"derived-from-or-self": {2, 2, xpathDerivedFrom(true)},
+		"enum-value": {1, 1, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			ns, err := xpathNodeSetArg("enum-value", args[0])
+			if err != nil {
+				return nil, err
+			}
+			if len(ns) == 0 {
+				return math.NaN(), nil
+			}
+			n := ns.sortedUnique()[0]
+			name := n.stringValue()
+			for _, t := range xpathLeafTypes(n.schema) {
+				if t.Kind == yang.Yenum && t.Enum != nil && t.Enum.IsDefined(name) {
+					return float64(t.Enum.Value(name)), nil
+				}
+			}
+			return math.NaN(), nil
+		}},
+		"bit-is-set": {2, 2, func(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+			ns, err := xpathNodeSetArg("bit-is-set", args[0])
+			if err != nil {
+				return nil, err
+			}
+			if len(ns) == 0 {
+				return false, nil
+			}
+			bit := xpathToString(args[1])
+			for _, b := range strings.Fields(ns.sortedUnique()[0].stringValue()) {
+				if b == bit {
+					return true, nil
+				}
+			}
+			return false, nil
+		}},
+	}
+}
+
+// evalFunction evaluates the function call e in the context ctx.
+func (x *xpathEvaluator) evalFunction(e *xpathFunctionCall, ctx *xpathContext) (interface{}, error) {
+	f, ok := xpathFunctions[e.name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s()", e.name)
+	}
+	if len(e.args) < f.minArgs || (f.maxArgs != -1 && len(e.args) > f.maxArgs) {
+		return nil, fmt.Errorf("invalid number of arguments to %s(), got: %d", e.name, len(e.args))
+	}
+	var args []interface{}
+	for _, a := range e.args {
+		v, err := x.eval(a, ctx)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+	return f.fn(x, ctx, args)
+}
+
+// xpathArgOrContext returns the first argument in args, or a node-set
+// containing the context node if there are no arguments.
+func xpathArgOrContext(ctx *xpathContext, args []interface{}) interface{} {
+	if len(args) == 0 {
+		return xpathNodeSet{ctx.node}
+	}
+	return args[0]
+}
+
+// xpathNodeSetArg returns v as a node-set, or an error if it is not a node-set.
+func xpathNodeSetArg(fn string, v interface{}) (xpathNodeSet, error) {
+	ns, ok := v.(xpathNodeSet)
+	if !ok {
+		return nil, fmt.Errorf("%s() requires a node-set argument, got: %v", fn, xpathToString(v))
+	}
+	return ns, nil
+}
+
+// xpathNameFunc returns the implementation of the local-name() and name()
+// functions. Since module prefixes are not retained in the data tree, both
+// return the local name of the node.
+func xpathNameFunc(fn string) func(*xpathEvaluator, *xpathContext, []interface{}) (interface{}, error) {
+	return func(_ *xpathEvaluator, ctx *xpathContext, args []interface{}) (interface{}, error) {
+		ns, err := xpathNodeSetArg(fn, xpathArgOrContext(ctx, args))
+		if err != nil {
+			return nil, err
+		}
+		if len(ns) == 0 {
+			return "", nil
+		}
+		return ns.sortedUnique()[0].name, nil
+	}
+}
+
+// xpathUnsupportedFunc returns the implementation of an XPath function that
+// is not supported by the evaluator, which returns an error. The id() and
+// lang() functions depend on the ID and xml:lang attributes of XML documents,
+// and namespace-uri() on the namespaces of the nodes, none of which are
+// retained in the data tree.
+func xpathUnsupportedFunc(fn string) func(*xpathEvaluator, *xpathContext, []interface{}) (interface{}, error) {
+	return func(*xpathEvaluator, *xpathContext, []interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("function %s() is not supported", fn)
+	}
+}
+
+// xpathRound rounds f to the closest integer, rounding halves towards
+// positive infinity, per the XPath round() function.
+func xpathRound(f float64) float64 {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return f
+	}
+	return math.Floor(f + 0.5)
+}
+
+// xpathSubstring implements the XPath substring() function.
+func xpathSubstring(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+	s := []rune(xpathToString(args[0]))
+	start := xpathRound(xpathToNumber(args[1]))
+	end := math.Inf(1)
+	if len(args) == 3 {
+		end = start + xpathRound(xpathToNumber(args[2]))
+	}
+	var b strings.Builder
+	for i, r := range s {
+		if p := float64(i + 1); p >= start && p < end {
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), nil
+}
+
+// xpathTranslate implements the XPath translate() function.
+func xpathTranslate(_ *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+	from, to := []rune(xpathToString(args[1])), []rune(xpathToString(args[2]))
+	m := map[rune]int{}
+	for i, r := range from {
+		if _, ok := m[r]; !ok {
+			m[r] = i
+		}
+	}
+	var b strings.Builder
+	for _, r := range xpathToString(args[0]) {
+		i, ok := m[r]
+		switch {
+		case !ok:
+			b.WriteRune(r)
+		case i < len(to):
+			b.WriteRune(to[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// xpathLeafTypes returns the types of the leaf with the supplied schema,
+// expanding any union types into their member types.
+func xpathLeafTypes(schema *yang.Entry) []*yang.YangType {
+	if schema == nil || schema.Type == nil {
+		return nil
+	}
+	return util.FlattenedTypes([]*yang.YangType{schema.Type})
+}
+
+// xpathDeref implements the YANG deref() function, which returns the nodes
+// referred to by the first node of its argument if the node is a leafref or
+// an instance-identifier.
+func xpathDeref(x *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+	ns, err := xpathNodeSetArg("deref", args[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(ns) == 0 {
+		return xpathNodeSet{}, nil
+	}
+	n := ns.sortedUnique()[0]
+	if !n.isLeaf() || n.schema.Type == nil {
+		return xpathNodeSet{}, nil
+	}
+
+	switch n.schema.Type.Kind {
+	case yang.Yleafref:
+		v, err := x.evalString(n.schema.Type.Path, n)
+		if err != nil {
+			return nil, fmt.Errorf("deref: cannot resolve leafref path %s: %v", n.schema.Type.Path, err)
+		}
+		targets, ok := v.(xpathNodeSet)
+		if !ok {
+			return nil, fmt.Errorf("deref: leafref path %s did not evaluate to a node-set", n.schema.Type.Path)
+		}
+		// The leafref path may select multiple nodes, only those that
+		// have the same value as the referring node are referenced.
+		var out xpathNodeSet
+		val := n.stringValue()
+		for _, t := range targets {
+			if t.stringValue() == val {
+				out = append(out, t)
+			}
+		}
+		return out, nil
+	case yang.YinstanceIdentifier:
+		v, err := x.evalString(n.stringValue(), x.root)
+		if err != nil {
+			return nil, fmt.Errorf("deref: cannot resolve instance-identifier %s: %v", n.stringValue(), err)
+		}
+		if targets, ok := v.(xpathNodeSet); ok {
+			return targets, nil
+		}
+	}
+	return xpathNodeSet{}, nil
+}
+
+// xpathDerivedFrom returns the implementation of the YANG derived-from() and
+// derived-from-or-self() functions. The functions return true if any node
+// in the first argument is an identityref whose value is derived from (or
+// equal to, if orSelf is set) the identity named by the second argument.
+// Identities are compared by module and name, where the prefix of the second
+// argument is resolved within the module that defines the expression, and
+// the module of the value is that of its GoEnum definition, or its module
+// qualifier.
+func xpathDerivedFrom(orSelf bool) func(*xpathEvaluator, *xpathContext, []interface{}) (interface{}, error) {
+	fn := "derived-from"
+	if orSelf {
+		fn = "derived-from-or-self"
+	}
+	return func(x *xpathEvaluator, _ *xpathContext, args []interface{}) (interface{}, error) {
+		ns, err := xpathNodeSetArg(fn, args[0])
+		if err != nil {
+			return nil, err
+		}
+		prefix, name := xpathSplitPrefix(xpathToString(args[1]))
+		mod, err := x.resolvePrefix(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", fn, err)
+		}
+		for _, n := range ns {
+			vmod, vname := xpathSplitPrefix(n.stringValue())
+			if m := xpathIdentityModule(n.value); m != "" {
+				vmod = m
+			}
+			if orSelf && xpathModuleNameEqual(vmod, vname, mod, name) {
+				return true, nil
+			}
+			for _, t := range xpathLeafTypes(n.schema) {
+				if t.Kind != yang.Yidentityref || t.IdentityBase == nil {
+					continue
+				}
+				base, err := findIdentity(append([]*yang.Identity{t.IdentityBase}, t.IdentityBase.Values...), mod, name)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", fn, err)
+				}
+				if base == nil {
+					continue
+				}
+				derived, err := findIdentity(base.Values, vmod, vname)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", fn, err)
+				}
+				if derived != nil {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+}
+
+// xpathSplitPrefix splits the qualified name s into its prefix, which is
+// empty if s is not qualified, and its local name.
+func xpathSplitPrefix(s string) (string, string) {
+	if i := strings.Index(s, ":"); i != -1 {
+		return s[:i], s[i+1:]
+	}
+	return "", s
+}
+
+// xpathModuleNameEqual reports whether the identity with module m1 and name
+// n1 is equal to that with module m2 and name n2. An empty module is unknown,
+// and hence is equal to any module.
+func xpathModuleNameEqual(m1, n1, m2, n2 string) bool {
+	return n1 == n2 && (m1 == "" || m2 == "" || m1 == m2)
+}
+
+// xpathIdentityModule returns the name of the module that defines the value
+// v of an identityref leaf, if v is a GoEnum whose definition specifies its
+// module. Otherwise, the empty string is returned.
+func xpathIdentityModule(v interface{}) string {
+	e, ok := v.(ygot.GoEnum)
+	if !ok {
+		return ""
+	}
+	rv := reflect.ValueOf(e)
+	return e.ΛMap()[rv.Type().Name()][rv.Int()].DefiningModule
+}
+
+// identityModule returns the name of the module that defines the identity id,
+// or the empty string if it is not known, as is the case for the identities
+// of schemas serialised by ygen.
+func identityModule(id *yang.Identity) string {
+	if id.Parent == nil {
+		return ""
+	}
+	m := yang.RootNode(id)
+	switch {
+	case m == nil:
+		return ""
+	case m.BelongsTo != nil:
+		return m.BelongsTo.Name
+	}
+	return m.Name
+}
+
+// findIdentity returns the identity within ids that is defined in the module
+// mod with the supplied name, or nil if there is no such identity. Where the
+// module of an identity is unknown, only its name is compared, and an error
+// is returned if more than one identity matches.
+func findIdentity(ids []*yang.Identity, mod, name string) (*yang.Identity, error) {
+	var found *yang.Identity
+	for _, id := range ids {
+		if !xpathModuleNameEqual(identityModule(id), id.Name, mod, name) || id == found {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("identity %s:%s is ambiguous within the schema", mod, name)
+		}
+		found = id
+	}
+	return found, nil
+}
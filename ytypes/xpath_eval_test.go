@@ -0,0 +1,690 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+type xpathTestRoot struct {
+	Interface map[string]*xpathTestInterface `path:"interfaces/interface" module:"xpath-test"`
+	System    *xpathTestSystem               `path:"system" module:"xpath-test"`
+	Ref       *string                        `path:"ref" module:"xpath-test"`
+}
+
+func (*xpathTestRoot) IsYANGGoStruct() {}
+
+type xpathTestInterface struct {
+	Name    *string  `path:"config/name|name" module:"xpath-test"`
+	Mtu     *uint16  `path:"config/mtu" module:"xpath-test"`
+	Type    *string  `path:"config/type" module:"xpath-test"`
+	Enabled *bool    `path:"config/enabled" module:"xpath-test"`
+	Speed   *float64 `path:"config/speed" module:"xpath-test"`
+}
+
+func (*xpathTestInterface) IsYANGGoStruct() {}
+
+type xpathTestSystem struct {
+	Hostname *string           `path:"hostname" module:"xpath-test"`
+	Server   []string          `path:"server" module:"xpath-test"`
+	Mode     *string           `path:"mode" module:"xpath-test"`
+	Loopback YANGEmpty         `path:"loopback" module:"xpath-test"`
+	Inst     *string           `path:"inst" module:"xpath-test"`
+	Flags    *string           `path:"flags" module:"xpath-test"`
+	Foo      *xpathTestChoiceA `path:"foo" module:"xpath-test"`
+}
+
+func (*xpathTestSystem) IsYANGGoStruct() {}
+
+type xpathTestChoiceA struct {
+	Bar *string `path:"bar" module:"xpath-test"`
+}
+
+func (*xpathTestChoiceA) IsYANGGoStruct() {}
+
+// xpathTestSchema returns the schema corresponding to the xpathTestRoot
+// GoStruct.
+func xpathTestSchema() *yang.Entry {
+	ifMod := &yang.Module{Name: "xpath-test-if"}
+	ethernet := &yang.Identity{Name: "ethernet", Parent: ifMod}
+	fastEthernet := &yang.Identity{Name: "fast-ethernet", Parent: ifMod}
+	ethernet.Values = []*yang.Identity{fastEthernet}
+	ifBase := &yang.Identity{
+		Name:   "interface-type",
+		Parent: ifMod,
+		Values: []*yang.Identity{ethernet, fastEthernet, {Name: "loopback", Parent: ifMod}},
+	}
+
+	modeEnum := yang.NewEnumType()
+	modeEnum.Set("standalone", 10)
+	modeEnum.Set("clustered", 20)
+
+	flagsBits := yang.NewBitfield()
+	flagsBits.Set("up", 0)
+	flagsBits.Set("running", 1)
+
+	configDir := func() map[string]*yang.Entry {
+		return map[string]*yang.Entry{
+			"name": {
+				Name: "name",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+			"mtu": {
+				Name: "mtu",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Yuint16},
+			},
+			"type": {
+				Name: "type",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Yidentityref, IdentityBase: ifBase},
+			},
+			"enabled": {
+				Name: "enabled",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Ybool},
+			},
+			"speed": {
+				Name: "speed",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 2},
+			},
+		}
+	}
+
+	root := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Annotation: map[string]interface{}{
+			"isFakeRoot": true,
+			util.ModulePrefixesAnnotation: map[string]map[string]string{
+				"xpath-test": {"xt": "xpath-test", "if": "xpath-test-if", "other": "xpath-test-other"},
+			},
+		},
+		Dir: map[string]*yang.Entry{
+			"interfaces": {
+				Name: "interfaces",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"interface": {
+						Name:     "interface",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Key:      "name",
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name: "name",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yleafref, Path: "../config/name"},
+							},
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir:  configDir(),
+							},
+						},
+					},
+				},
+			},
+			"system": {
+				Name: "system",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"hostname": {
+						Name: "hostname",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"server": {
+						Name:     "server",
+						Kind:     yang.LeafEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Type:     &yang.YangType{Kind: yang.Ystring},
+					},
+					"mode": {
+						Name: "mode",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Yenum, Enum: modeEnum},
+					},
+					"loopback": {
+						Name: "loopback",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Yempty},
+					},
+					"inst": {
+						Name: "inst",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.YinstanceIdentifier},
+					},
+					"flags": {
+						Name: "flags",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ybits, Bit: flagsBits},
+					},
+					"choice": {
+						Name: "choice",
+						Kind: yang.ChoiceEntry,
+						Dir: map[string]*yang.Entry{
+							"case-a": {
+								Name: "case-a",
+								Kind: yang.CaseEntry,
+								Dir: map[string]*yang.Entry{
+									"foo": {
+										Name: "foo",
+										Kind: yang.DirectoryEntry,
+										Dir: map[string]*yang.Entry{
+											"bar": {
+												Name: "bar",
+												Kind: yang.LeafEntry,
+												Type: &yang.YangType{Kind: yang.Ystring},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"ref": {
+				Name: "ref",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Yleafref, Path: "/interfaces/interface/config/name"},
+			},
+		},
+	}
+	addParents(root)
+	return root
+}
+
+// xpathTestData returns a populated xpathTestRoot.
+func xpathTestData() *xpathTestRoot {
+	return &xpathTestRoot{
+		Interface: map[string]*xpathTestInterface{
+			"eth0": {
+				Name:    ygot.String("eth0"),
+				Mtu:     ygot.Uint16(1500),
+				Type:    ygot.String("fast-ethernet"),
+				Enabled: ygot.Bool(true),
+				Speed:   ygot.Float64(10.25),
+			},
+			"eth1": {
+				Name:    ygot.String("eth1"),
+				Mtu:     ygot.Uint16(9000),
+				Type:    ygot.String("ethernet"),
+				Enabled: ygot.Bool(false),
+			},
+			"lo0": {
+				Name: ygot.String("lo0"),
+				Type: ygot.String("loopback"),
+			},
+		},
+		System: &xpathTestSystem{
+			Hostname: ygot.String("  router   one "),
+			Server:   []string{"a.example.com", "b.example.com"},
+			Mode:     ygot.String("clustered"),
+			Loopback: true,
+			Inst:     ygot.String("/interfaces/interface[name='eth1']/config/mtu"),
+			Flags:    ygot.String("up running"),
+			Foo:      &xpathTestChoiceA{Bar: ygot.String("baz")},
+		},
+		Ref: ygot.String("eth1"),
+	}
+}
+
+// xpathTestNode returns the node at the absolute location path p in the data
+// tree rooted at root.
+func xpathTestNode(t *testing.T, root *xpathNode, p string) *xpathNode {
+	t.Helper()
+	v, err := newXPathEvaluator(root).evalString(p, root)
+	if err != nil {
+		t.Fatalf("cannot evaluate context path %s: %v", p, err)
+	}
+	ns, ok := v.(xpathNodeSet)
+	if !ok || len(ns) != 1 {
+		t.Fatalf("context path %s did not select a single node, got: %v", p, v)
+	}
+	return ns[0]
+}
+
+func TestXPathTree(t *testing.T) {
+	root, err := newXPathTree(xpathTestSchema(), xpathTestData())
+	if err != nil {
+		t.Fatalf("newXPathTree: got unexpected error: %v", err)
+	}
+
+	var got []string
+	root.walk(func(n *xpathNode) {
+		got = append(got, n.dataPath())
+	})
+	want := []string{
+		"/",
+		"/interfaces",
+		"/interfaces/interface[name=eth0]",
+		"/interfaces/interface[name=eth0]/config",
+		"/interfaces/interface[name=eth0]/config/name",
+		"/interfaces/interface[name=eth0]/config/mtu",
+		"/interfaces/interface[name=eth0]/config/type",
+		"/interfaces/interface[name=eth0]/config/enabled",
+		"/interfaces/interface[name=eth0]/config/speed",
+		"/interfaces/interface[name=eth0]/name",
+		"/interfaces/interface[name=eth1]",
+		"/interfaces/interface[name=eth1]/config",
+		"/interfaces/interface[name=eth1]/config/name",
+		"/interfaces/interface[name=eth1]/config/mtu",
+		"/interfaces/interface[name=eth1]/config/type",
+		"/interfaces/interface[name=eth1]/config/enabled",
+		"/interfaces/interface[name=eth1]/name",
+		"/interfaces/interface[name=lo0]",
+		"/interfaces/interface[name=lo0]/config",
+		"/interfaces/interface[name=lo0]/config/name",
+		"/interfaces/interface[name=lo0]/config/type",
+		"/interfaces/interface[name=lo0]/name",
+		"/system",
+		"/system/hostname",
+		"/system/server",
+		"/system/server",
+		"/system/mode",
+		"/system/loopback",
+		"/system/inst",
+		"/system/flags",
+		"/system/foo",
+		"/system/foo/bar",
+		"/ref",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("newXPathTree: did not get expected tree, (-want, +got):\n%s", diff)
+	}
+}
+
+func TestXPathEval(t *testing.T) {
+	tests := []struct {
+		desc string
+		// context is the absolute path of the context node, the root is
+		// used if it is not set.
+		context          string
+		expr             string
+		want             interface{}
+		wantErrSubstring string
+	}{{
+		desc: "count of list entries",
+		expr: "count(/interfaces/interface)",
+		want: float64(3),
+	}, {
+		desc: "count of leaf-list entries",
+		expr: "count(system/server)",
+		want: float64(2),
+	}, {
+		desc:    "relative path from leaf",
+		context: "/interfaces/interface[name='eth0']/config/mtu",
+		expr:    "../name",
+		want:    "eth0",
+	}, {
+		desc:    "current",
+		context: "/interfaces/interface[name='eth0']/config/mtu",
+		expr:    "current() = 1500",
+		want:    true,
+	}, {
+		desc:    "current within predicate",
+		context: "/interfaces/interface[name='eth1']/config/mtu",
+		expr:    "count(/interfaces/interface[config/mtu < current()])",
+		want:    float64(1),
+	}, {
+		desc: "numeric comparison of node-set",
+		expr: "/interfaces/interface/config/mtu > 8000",
+		want: true,
+	}, {
+		desc: "node-set comparison with no match",
+		expr: "/interfaces/interface/config/mtu > 9000",
+		want: false,
+	}, {
+		desc: "node-set to node-set comparison",
+		expr: "/ref = /interfaces/interface/name",
+		want: true,
+	}, {
+		desc: "boolean comparison with empty node-set",
+		expr: "/doesnotexist = false()",
+		want: true,
+	}, {
+		desc: "not equal with node-set",
+		expr: "/system/server != 'a.example.com'",
+		want: true,
+	}, {
+		desc: "boolean leaf",
+		expr: "/interfaces/interface[name='eth1']/config/enabled = 'false'",
+		want: true,
+	}, {
+		desc: "decimal64 leaf",
+		expr: "/interfaces/interface[name='eth0']/config/speed * 4",
+		want: float64(41),
+	}, {
+		desc: "empty leaf exists",
+		expr: "boolean(/system/loopback) and /system/loopback = ''",
+		want: true,
+	}, {
+		desc: "arithmetic",
+		expr: "(7 mod 4) + 10 div 4 - -1",
+		want: float64(6.5),
+	}, {
+		desc: "division by zero",
+		expr: "1 div 0",
+		want: math.Inf(1),
+	}, {
+		desc: "position and last",
+		expr: "/interfaces/interface[position() = last()]/name",
+		want: "lo0",
+	}, {
+		desc: "numeric predicate",
+		expr: "string(/interfaces/interface[2]/name)",
+		want: "eth1",
+	}, {
+		desc: "union in document order",
+		expr: "string((/ref | /interfaces/interface/name)[1])",
+		want: "eth0",
+	}, {
+		desc: "descendant abbreviation",
+		expr: "count(//mtu)",
+		want: float64(2),
+	}, {
+		desc:    "ancestor axis",
+		context: "/system/foo/bar",
+		expr:    "name(ancestor::*[last()])",
+		want:    "system",
+	}, {
+		desc:    "preceding-sibling axis",
+		context: "/interfaces/interface[name='lo0']",
+		expr:    "preceding-sibling::interface[1]/name",
+		want:    "eth1",
+	}, {
+		desc:    "following-sibling axis",
+		context: "/interfaces/interface[name='eth0']",
+		expr:    "count(following-sibling::interface)",
+		want:    float64(2),
+	}, {
+		desc:    "following axis",
+		context: "/system/foo",
+		expr:    "name(following::*)",
+		want:    "ref",
+	}, {
+		desc:    "preceding axis",
+		context: "/system",
+		expr:    "count(preceding::mtu)",
+		want:    float64(2),
+	}, {
+		desc: "wildcard",
+		expr: "count(/system/*)",
+		want: float64(8),
+	}, {
+		desc: "string functions",
+		expr: "concat(substring-before('a-b', '-'), substring-after('a-b', '-'), substring('12345', 1.5, 2.6), translate('bar', 'abc', 'AB'))",
+		want: "ab234BAr",
+	}, {
+		desc: "normalize-space and string-length",
+		expr: "string-length(normalize-space(/system/hostname))",
+		want: float64(10),
+	}, {
+		desc: "starts-with and contains",
+		expr: "starts-with(/system/server[2], 'b.') and contains(/system/server[1], 'example')",
+		want: true,
+	}, {
+		desc: "number functions",
+		expr: "floor(2.5) + ceiling(2.5) + round(2.5) + round(-2.5) + sum(/interfaces/interface/config/mtu)",
+		want: float64(10506),
+	}, {
+		desc: "number of non-numeric string",
+		expr: "string(number('abc'))",
+		want: "NaN",
+	}, {
+		desc: "re-match",
+		expr: "re-match(/system/server[1], '[a-z]\\.example\\.com') and not(re-match('xa.example.com', '[a-z]\\.example\\.com'))",
+		want: true,
+	}, {
+		desc: "deref of leafref",
+		expr: "deref(/ref)/../mtu",
+		want: "9000",
+	}, {
+		desc:    "deref of relative leafref",
+		context: "/interfaces/interface[name='eth0']",
+		expr:    "count(deref(name))",
+		want:    float64(1),
+	}, {
+		desc: "deref of instance-identifier",
+		expr: "deref(/system/inst) = 9000",
+		want: true,
+	}, {
+		desc: "derived-from",
+		expr: "count(/interfaces/interface[derived-from(config/type, 'if:ethernet')])",
+		want: float64(1),
+	}, {
+		desc: "derived-from-or-self",
+		expr: "count(/interfaces/interface[derived-from-or-self(config/type, 'if:ethernet')])",
+		want: float64(2),
+	}, {
+		desc: "derived-from base",
+		expr: "count(/interfaces/interface[derived-from(config/type, 'if:interface-type')])",
+		want: float64(3),
+	}, {
+		desc:             "derived-from with unknown prefix",
+		expr:             "derived-from(/interfaces/interface/config/type, 'unknown:ethernet')",
+		wantErrSubstring: `derived-from: cannot resolve prefix unknown within module "xpath-test"`,
+	}, {
+		desc: "enum-value",
+		expr: "enum-value(/system/mode)",
+		want: float64(20),
+	}, {
+		desc: "bit-is-set",
+		expr: "bit-is-set(/system/flags, 'running') and not(bit-is-set(/system/flags, 'down'))",
+		want: true,
+	}, {
+		desc:             "unknown function",
+		expr:             "frobnicate()",
+		wantErrSubstring: "unknown function frobnicate()",
+	}, {
+		desc:             "unsupported function",
+		expr:             "lang('en')",
+		wantErrSubstring: "function lang() is not supported",
+	}, {
+		desc:             "wrong number of arguments",
+		expr:             "count()",
+		wantErrSubstring: "invalid number of arguments to count()",
+	}, {
+		desc:             "non node-set argument",
+		expr:             "count('a')",
+		wantErrSubstring: "count() requires a node-set argument",
+	}, {
+		desc:             "undefined variable",
+		expr:             "$foo",
+		wantErrSubstring: "variable $foo is not defined",
+	}, {
+		desc:             "invalid expression",
+		expr:             "a[",
+		wantErrSubstring: "invalid XPath expression",
+	}}
+
+	root, err := newXPathTree(xpathTestSchema(), xpathTestData())
+	if err != nil {
+		t.Fatalf("newXPathTree: got unexpected error: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx := root
+			if tt.context != "" {
+				ctx = xpathTestNode(t, root, tt.context)
+			}
+			// The expressions are evaluated as though they are defined
+			// within the xpath-test module.
+			x := newXPathEvaluator(root)
+			x.schema, x.module = root.schema, "xpath-test"
+			got, err := x.evalString(tt.expr, ctx)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("evalString(%q): did not get expected error, %s", tt.expr, diff)
+			}
+			if err != nil {
+				return
+			}
+			if ns, ok := got.(xpathNodeSet); ok {
+				got = xpathToString(ns)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("evalString(%q): did not get expected result, (-want, +got):\n%s", tt.expr, diff)
+			}
+		})
+	}
+}
+
+// xpathTestIdentity is an identityref type used in the XPath tests.
+type xpathTestIdentity int64
+
+func (xpathTestIdentity) ΛMap() map[string]map[int64]ygot.EnumDefinition {
+	return map[string]map[int64]ygot.EnumDefinition{
+		"xpathTestIdentity": {
+			1: {Name: "ethernet", DefiningModule: "xpath-test-if"},
+			2: {Name: "ethernet", DefiningModule: "xpath-test-other"},
+		},
+	}
+}
+
+func (e xpathTestIdentity) String() string {
+	return ygot.EnumLogString(e, int64(e), "xpathTestIdentity")
+}
+
+func (xpathTestIdentity) IsYANGGoEnum() {}
+
+func TestXPathDerivedFrom(t *testing.T) {
+	// otherSchema returns the xpathTestSchema with an identity named
+	// ethernet, derived from interface-type, added in the module
+	// xpath-test-other. The module of the identities is not retained
+	// where serialised is set.
+	otherSchema := func(serialised bool) *yang.Entry {
+		s := xpathTestSchema()
+		base := s.Dir["interfaces"].Dir["interface"].Dir["config"].Dir["type"].Type.IdentityBase
+		base.Values = append(base.Values, &yang.Identity{Name: "ethernet", Parent: &yang.Module{Name: "xpath-test-other"}})
+		if serialised {
+			base.Parent = nil
+			for _, id := range base.Values {
+				id.Parent = nil
+			}
+		}
+		return s
+	}
+
+	tests := []struct {
+		desc             string
+		inSchema         *yang.Entry
+		inType           interface{}
+		expr             string
+		want             bool
+		wantErrSubstring string
+	}{{
+		desc:     "same name in different module is not self",
+		inSchema: otherSchema(false),
+		inType:   ygot.String("xpath-test-other:ethernet"),
+		expr:     "derived-from-or-self(., 'if:ethernet')",
+		want:     false,
+	}, {
+		desc:     "self in module resolved from prefix",
+		inSchema: otherSchema(false),
+		inType:   ygot.String("xpath-test-other:ethernet"),
+		expr:     "derived-from-or-self(., 'other:ethernet')",
+		want:     true,
+	}, {
+		desc:     "same name in different module is not derived",
+		inSchema: otherSchema(false),
+		inType:   ygot.String("xpath-test-other:ethernet"),
+		expr:     "derived-from(., 'if:ethernet')",
+		want:     false,
+	}, {
+		desc:     "derived from base in other module",
+		inSchema: otherSchema(false),
+		inType:   ygot.String("xpath-test-other:ethernet"),
+		expr:     "derived-from(., 'if:interface-type')",
+		want:     true,
+	}, {
+		desc:     "module of GoEnum value",
+		inSchema: otherSchema(false),
+		inType:   xpathTestIdentity(2),
+		expr:     "derived-from-or-self(., 'if:ethernet')",
+		want:     false,
+	}, {
+		desc:     "module of GoEnum value is self",
+		inSchema: otherSchema(false),
+		inType:   xpathTestIdentity(1),
+		expr:     "derived-from-or-self(., 'if:ethernet')",
+		want:     true,
+	}, {
+		desc:             "ambiguous identity without modules",
+		inSchema:         otherSchema(true),
+		inType:           xpathTestIdentity(1),
+		expr:             "derived-from(., 'if:ethernet')",
+		wantErrSubstring: "identity xpath-test-if:ethernet is ambiguous",
+	}, {
+		desc:     "identity without modules",
+		inSchema: otherSchema(true),
+		inType:   ygot.String("fast-ethernet"),
+		expr:     "derived-from(., 'if:interface-type')",
+		want:     true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root, err := newXPathTree(tt.inSchema, xpathTestData())
+			if err != nil {
+				t.Fatalf("newXPathTree: got unexpected error: %v", err)
+			}
+			n := xpathTestNode(t, root, "/interfaces/interface[name='eth0']/config/type")
+			n.value = tt.inType
+
+			x := newXPathEvaluator(root)
+			x.define(n.schema, n)
+			got, err := x.evalBool(tt.expr, n)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("evalBool(%q): did not get expected error, %s", tt.expr, diff)
+			}
+			if got != tt.want {
+				t.Errorf("evalBool(%q): got %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXPathNumberToString(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{in: 0, want: "0"},
+		{in: math.Copysign(0, -1), want: "0"},
+		{in: 42, want: "42"},
+		{in: -1.5, want: "-1.5"},
+		{in: 1e21, want: "1000000000000000000000"},
+		{in: math.NaN(), want: "NaN"},
+		{in: math.Inf(1), want: "Infinity"},
+		{in: math.Inf(-1), want: "-Infinity"},
+	}
+	for _, tt := range tests {
+		if got := xpathNumberToString(tt.in); got != tt.want {
+			t.Errorf("xpathNumberToString(%v): got %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
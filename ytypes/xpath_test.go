@@ -0,0 +1,181 @@
+// Copyright 2026 The ygot Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestLexXPath(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               string
+		wantKinds        []xpathTokenKind
+		wantVals         []string
+		wantErrSubstring string
+	}{{
+		desc:      "simple path",
+		in:        "../config/name",
+		wantKinds: []xpathTokenKind{xtDotDot, xtOperator, xtNameTest, xtOperator, xtNameTest, xtEOF},
+		wantVals:  []string{"..", "/", "config", "/", "name", ""},
+	}, {
+		desc:      "multiply disambiguation",
+		in:        "* * 2",
+		wantKinds: []xpathTokenKind{xtNameTest, xtOperator, xtNumber, xtEOF},
+		wantVals:  []string{"*", "*", "2", ""},
+	}, {
+		desc:      "operator names",
+		in:        "a and b or c div 2 mod 3",
+		wantKinds: []xpathTokenKind{xtNameTest, xtOperator, xtNameTest, xtOperator, xtNameTest, xtOperator, xtNumber, xtOperator, xtNumber, xtEOF},
+		wantVals:  []string{"a", "and", "b", "or", "c", "div", "2", "mod", "3", ""},
+	}, {
+		desc:      "name that is an operator name in a path",
+		in:        "/and/or",
+		wantKinds: []xpathTokenKind{xtOperator, xtNameTest, xtOperator, xtNameTest, xtEOF},
+		wantVals:  []string{"/", "and", "/", "or", ""},
+	}, {
+		desc:      "prefixed names, functions and axes",
+		in:        "oc-if:interfaces/ancestor::node()[current()/pfx:* != 'a b']",
+		wantKinds: []xpathTokenKind{xtNameTest, xtOperator, xtAxisName, xtColonColon, xtNodeType, xtLParen, xtRParen, xtLBracket, xtFunctionName, xtLParen, xtRParen, xtOperator, xtNameTest, xtOperator, xtLiteral, xtRBracket, xtEOF},
+		wantVals:  []string{"oc-if:interfaces", "/", "ancestor", "::", "node", "(", ")", "[", "current", "(", ")", "/", "pfx:*", "!=", "a b", "]", ""},
+	}, {
+		desc:      "numbers and comparisons",
+		in:        "1.5<=.5 and -2>=3.",
+		wantKinds: []xpathTokenKind{xtNumber, xtOperator, xtNumber, xtOperator, xtOperator, xtNumber, xtOperator, xtNumber, xtEOF},
+		wantVals:  []string{"1.5", "<=", ".5", "and", "-", "2", ">=", "3.", ""},
+	}, {
+		desc:             "unterminated literal",
+		in:               `name = "foo`,
+		wantErrSubstring: "unterminated string literal",
+	}, {
+		desc:             "invalid character",
+		in:               "a # b",
+		wantErrSubstring: "invalid character",
+	}, {
+		desc:             "invalid axis",
+		in:               "sideways::a",
+		wantErrSubstring: "invalid axis name",
+	}, {
+		desc:             "name where operator expected",
+		in:               "a b",
+		wantErrSubstring: "expected an operator",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := lexXPath(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("lexXPath(%q): did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if len(got) != len(tt.wantKinds) {
+				t.Fatalf("lexXPath(%q): did not get expected number of tokens, got: %v, want kinds: %v", tt.in, got, tt.wantKinds)
+			}
+			for i, tok := range got {
+				if tok.kind != tt.wantKinds[i] || tok.val != tt.wantVals[i] {
+					t.Errorf("lexXPath(%q): token %d, got: {%v, %q}, want: {%v, %q}", tt.in, i, tok.kind, tok.val, tt.wantKinds[i], tt.wantVals[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseXPath(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               string
+		want             string
+		wantErrSubstring string
+	}{{
+		desc: "relative path",
+		in:   "../config/name",
+		want: "parent::node()/child::config/child::name",
+	}, {
+		desc: "absolute path with prefixes",
+		in:   "/oc-if:interfaces/oc-if:interface",
+		want: "/child::oc-if:interfaces/child::oc-if:interface",
+	}, {
+		desc: "root",
+		in:   "/",
+		want: "/",
+	}, {
+		desc: "abbreviated descendant",
+		in:   "//name",
+		want: "/descendant-or-self::node()/child::name",
+	}, {
+		desc: "filter expression with path",
+		in:   "current()/../name",
+		want: "current()/parent::node()/child::name",
+	}, {
+		desc: "leafref path with predicate",
+		in:   "../../list[key = current()/../../key]/int32",
+		want: "parent::node()/parent::node()/child::list[(child::key = current()/parent::node()/parent::node()/child::key)]/child::int32",
+	}, {
+		desc: "operator precedence",
+		in:   "1 + 2 * 3 = 7 and not(false()) or a | b",
+		want: "((((1 + (2 * 3)) = 7) and not(false())) or (child::a | child::b))",
+	}, {
+		desc: "unary minus",
+		in:   "--1 - -2",
+		want: "(--1 - -2)",
+	}, {
+		desc: "attribute and self steps",
+		in:   "./@foo",
+		want: "self::node()/attribute::foo",
+	}, {
+		desc: "filter predicate",
+		in:   "(a | b)[1]",
+		want: "(child::a | child::b)[1]",
+	}, {
+		desc: "function with multiple arguments",
+		in:   "concat('a', \"b\", 3)",
+		want: `concat("a", "b", 3)`,
+	}, {
+		desc:             "unbalanced parenthesis",
+		in:               "count(a",
+		wantErrSubstring: "unexpected end of expression, expected )",
+	}, {
+		desc:             "trailing tokens",
+		in:               "a)",
+		wantErrSubstring: "unexpected token",
+	}, {
+		desc:             "missing step",
+		in:               "a/",
+		wantErrSubstring: "expected a location step",
+	}, {
+		desc:             "unclosed predicate",
+		in:               "a[1",
+		wantErrSubstring: "expected ]",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := parseXPath(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("parseXPath(%q): did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("parseXPath(%q): did not get expected expression, got: %s, want: %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}